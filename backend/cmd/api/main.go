@@ -1,15 +1,20 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	_ "github.com/TobiasRV/challenge-fs-senior/docs"
 	"github.com/TobiasRV/challenge-fs-senior/internals/db"
 	"github.com/TobiasRV/challenge-fs-senior/internals/handlers"
+	"github.com/TobiasRV/challenge-fs-senior/internals/mailer"
+	"github.com/TobiasRV/challenge-fs-senior/internals/oidc"
 	"github.com/TobiasRV/challenge-fs-senior/internals/repository"
 	"github.com/TobiasRV/challenge-fs-senior/internals/router"
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
 	"github.com/joho/godotenv"
 )
 
@@ -35,6 +40,21 @@ func main() {
 		log.Fatal("missing SERVER_PORT env")
 	}
 
+	keySet, err := utils.LoadJWTKeySet(os.Getenv("JWT_KEYS_DIR"), os.Getenv("JWT_SIGNING_KEY_ID"))
+
+	if err != nil {
+		log.Fatal("Can´t load jwt keys: ", err)
+	}
+
+	utils.SetJWTKeySet(keySet)
+	utils.ConfigureJWTClaims(os.Getenv("JWT_ISSUER"), os.Getenv("JWT_AUDIENCE"))
+
+	err = utils.ConfigureCookies(os.Getenv("COOKIE_DOMAIN"), os.Getenv("COOKIE_PATH"), os.Getenv("COOKIE_SAMESITE"))
+
+	if err != nil {
+		log.Fatal("Can´t configure cookies: ", err)
+	}
+
 	r := router.New()
 
 	queries, dbConn := db.New()
@@ -44,12 +64,108 @@ func main() {
 	tr := repository.NewTeamsRepository(queries, dbConn)
 	pr := repository.NewProjectRepository(queries, dbConn)
 	tsr := repository.NewTaskRepository(queries, dbConn)
+	sr := repository.NewSessionRepository(queries, dbConn)
+	prtr := repository.NewPasswordResetTokenRepository(queries, dbConn)
+	ir := repository.NewInvitationRepository(queries, dbConn)
+	mr := repository.NewMfaRepository(queries, dbConn)
+	lar := repository.NewLoginAttemptRepository(queries, dbConn)
+	patr := repository.NewPersonalAccessTokenRepository(queries, dbConn)
+	uir := repository.NewUserIdentityRepository(queries, dbConn)
+	rsr := repository.NewRevokedSessionRepository(queries, dbConn)
+	rr := repository.NewRoleRepository(queries, dbConn)
+	or := repository.NewOrganizationRepository(queries, dbConn)
+
+	m, err := mailer.New()
+
+	if err != nil {
+		log.Fatal("Can´t create mailer: ", err)
+	}
+
+	// Emails are sent in the background so the responses don't depend on the mail server
+	mailQueue := mailer.NewQueueMailer(m, 100)
+
+	go mailQueue.Run(context.Background())
+
+	op, err := oidc.New()
+
+	if err != nil {
+		log.Fatal("Can´t configure single sign-on: ", err)
+	}
+
+	h := handlers.NewHandler(ur, rtr, tr, pr, tsr, sr, prtr, ir, mr, lar, patr, uir, rsr, rr, or, op, mailQueue)
+
+	revokedSessionsSyncInterval := 5 * time.Second
+
+	if interval := os.Getenv("REVOKED_SESSIONS_SYNC_INTERVAL"); interval != "" {
+		revokedSessionsSyncInterval, err = time.ParseDuration(interval)
+
+		if err != nil || revokedSessionsSyncInterval <= 0 {
+			log.Fatal("invalid REVOKED_SESSIONS_SYNC_INTERVAL env: ", interval)
+		}
+	}
+
+	err = h.SyncRevokedSessions(context.Background())
 
-	h := handlers.NewHandler(ur, rtr, tr, pr, tsr)
+	if err != nil {
+		log.Fatal("Can´t load revoked sessions: ", err)
+	}
+
+	go h.WatchRevokedSessions(context.Background(), revokedSessionsSyncInterval)
+
+	rolesSyncInterval := 5 * time.Second
+
+	if interval := os.Getenv("ROLES_SYNC_INTERVAL"); interval != "" {
+		rolesSyncInterval, err = time.ParseDuration(interval)
+
+		if err != nil || rolesSyncInterval <= 0 {
+			log.Fatal("invalid ROLES_SYNC_INTERVAL env: ", interval)
+		}
+	}
+
+	err = h.SyncRolePermissions(context.Background())
+
+	if err != nil {
+		log.Fatal("Can´t load role permissions: ", err)
+	}
+
+	go h.WatchRolePermissions(context.Background(), rolesSyncInterval)
+
+	adminExists, err := ur.AdminExists(context.Background())
+
+	if err != nil {
+		log.Fatal("Can´t check for an admin user: ", err)
+	}
+
+	// Until the first admin registers, POST /auth/register-admin needs this one-time setup token
+	if !adminExists {
+		setupToken := os.Getenv("SETUP_TOKEN")
+
+		if setupToken == "" {
+			setupToken, _, err = utils.GenerateOpaqueToken()
+
+			if err != nil {
+				log.Fatal("Can´t generate setup token: ", err)
+			}
+		}
+
+		if setupTokenFile := os.Getenv("SETUP_TOKEN_FILE"); setupTokenFile != "" {
+			err = os.WriteFile(setupTokenFile, []byte(setupToken+"\n"), 0600)
+
+			if err != nil {
+				log.Fatal("Can´t write setup token file: ", err)
+			}
+
+			log.Printf("No admin user found, the setup token to register one was written to %s", setupTokenFile)
+		} else {
+			log.Printf("No admin user found, register one with the setup token: %s", setupToken)
+		}
+
+		h.EnableAdminBootstrap(setupToken)
+	}
 
 	h.Register(r)
 
-	err := r.Listen(fmt.Sprintf(":%v", portString))
+	err = r.Listen(fmt.Sprintf(":%v", portString))
 
 	if err != nil {
 		log.Fatal(err)
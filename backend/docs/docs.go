@@ -15,9 +15,61 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/auth/forgot-password": {
+            "post": {
+                "description": "Email a single use password reset link to the user. The response is the same whether the email belongs to an account or not. Requests are throttled per email and per ip address.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Request a password reset",
+                "parameters": [
+                    {
+                        "description": "Account email",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many password reset requests",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/auth/login": {
             "post": {
-                "description": "Authenticate user and return access and refresh tokens",
+                "description": "Authenticate user, open a new session for the device and return access and refresh tokens. Failed attempts are counted per account and per ip address, after a few of them the caller has to wait longer between attempts and the account is locked for 15 minutes after 10. When the user has two-factor authentication enabled, or their team requires it, an mfa token is returned instead to complete the login in /auth/mfa/verify or /auth/mfa/enroll",
                 "consumes": [
                     "application/json"
                 ],
@@ -46,14 +98,32 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.LoginResponse"
                         }
                     },
+                    "202": {
+                        "description": "Two-factor authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.MfaLoginResponse"
+                        }
+                    },
                     "400": {
                         "description": "Validation error",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "The account has been deactivated",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
                     "409": {
-                        "description": "Invalid credentials",
+                        "description": "Invalid email or password",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed attempts for the account or the ip address, see the Retry-After header",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
@@ -74,7 +144,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Logout user and revoke all refresh tokens",
+                "description": "Logout user and revoke the current session",
                 "consumes": [
                     "application/json"
                 ],
@@ -107,9 +177,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/refresh-token": {
+        "/auth/mfa/enroll": {
             "post": {
-                "description": "Get a new access token using a refresh token",
+                "description": "Generate a TOTP secret for a user whose team requires two-factor authentication, using the mfa token returned by the login",
                 "consumes": [
                     "application/json"
                 ],
@@ -119,15 +189,15 @@ const docTemplate = `{
                 "tags": [
                     "Auth"
                 ],
-                "summary": "Refresh access token",
+                "summary": "Start a required two-factor enrollment",
                 "parameters": [
                     {
-                        "description": "Refresh token",
+                        "description": "Mfa token",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.RefreshTokenRequest"
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.MfaTokenRequest"
                         }
                     }
                 ],
@@ -135,7 +205,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.RefreshTokenResponse"
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TotpEnrollmentResponse"
                         }
                     },
                     "400": {
@@ -145,7 +215,13 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "Invalid or expired refresh token",
+                        "description": "Invalid or expired mfa token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Two-factor authentication already enabled",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
@@ -159,9 +235,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/register-admin": {
+        "/auth/mfa/enroll/confirm": {
             "post": {
-                "description": "Create a new admin user account",
+                "description": "Enable two-factor authentication with a code from the authenticator app and log in. The recovery codes are only returned once",
                 "consumes": [
                     "application/json"
                 ],
@@ -171,33 +247,39 @@ const docTemplate = `{
                 "tags": [
                     "Auth"
                 ],
-                "summary": "Register admin user",
+                "summary": "Confirm a required two-factor enrollment",
                 "parameters": [
                     {
-                        "description": "Admin registration data",
+                        "description": "Mfa token and code",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateAdminRequest"
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.VerifyMfaRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateAdminResponse"
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.ConfirmMfaEnrollmentResponse"
                         }
                     },
                     "400": {
-                        "description": "Validation error",
+                        "description": "Validation error or enrollment not started",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or expired mfa token or invalid code",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
                     },
                     "409": {
-                        "description": "User already exists",
+                        "description": "Two-factor authentication already enabled",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
@@ -211,14 +293,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects": {
-            "get": {
+        "/auth/mfa/recovery-codes": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get paginated list of projects (Admin or Manager only)",
+                "description": "Replace every recovery code of the logged user, a current code or an unused recovery code is required",
                 "consumes": [
                     "application/json"
                 ],
@@ -226,63 +308,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Projects"
+                    "Auth"
                 ],
-                "summary": "Get all projects",
+                "summary": "Regenerate recovery codes",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Filter by name",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by team ID",
-                        "name": "teamId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by manager ID",
-                        "name": "managerId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Include task statistics",
-                        "name": "withStats",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Pagination cursor",
-                        "name": "cursor",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of items per page",
-                        "name": "limit",
-                        "in": "query",
-                        "required": true
+                        "description": "Code from the authenticator app or recovery code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.MfaCodeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.ProjectsListResponse"
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.RecoveryCodesResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad request - teamId or managerId required",
+                        "description": "Validation error or two-factor authentication not enabled",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden - Admin or Manager only",
+                    "401": {
+                        "description": "Invalid code",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
@@ -294,14 +348,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/mfa/totp": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new project (Manager only)",
+                "description": "Generate a new TOTP secret for the logged user. It has to be confirmed with a code before it is enabled",
                 "consumes": [
                     "application/json"
                 ],
@@ -309,35 +365,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Projects"
+                    "Auth"
                 ],
-                "summary": "Create a new project",
-                "parameters": [
-                    {
-                        "description": "Project creation data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                "summary": "Start two-factor enrollment",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateProjectPayload"
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TotpEnrollmentResponse"
                         }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.GetProjectsResponse"
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Validation error or manager not found",
+                    "404": {
+                        "description": "User not found",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden - Manager only",
+                    "409": {
+                        "description": "Two-factor authentication already enabled",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
@@ -349,16 +400,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/projects/{id}": {
-            "put": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update project information (Manager only, must be the project manager)",
+                "description": "Disable two-factor authentication of the logged user with a current code or a recovery code. Not allowed when the team of the session or the default team of the user requires it",
                 "consumes": [
                     "application/json"
                 ],
@@ -366,24 +415,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Projects"
+                    "Auth"
                 ],
-                "summary": "Update a project",
+                "summary": "Disable two-factor authentication",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Project ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Project update data",
+                        "description": "Code from the authenticator app or recovery code",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UpdateProjectPayload"
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.MfaCodeRequest"
                         }
                     }
                 ],
@@ -391,23 +433,29 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.GetProjectsResponse"
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.DisableMfaResponse"
                         }
                     },
                     "400": {
-                        "description": "Validation error",
+                        "description": "Validation error or two-factor authentication not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid code",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Forbidden - Must be the project manager",
+                        "description": "Required by the team",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Project not found",
+                        "description": "User not found",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
@@ -419,14 +467,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/auth/mfa/totp/confirm": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a project by ID (Manager only)",
+                "description": "Enable two-factor authentication with a code from the authenticator app. The recovery codes are only returned once",
                 "consumes": [
                     "application/json"
                 ],
@@ -434,39 +484,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Projects"
+                    "Auth"
                 ],
-                "summary": "Delete a project",
+                "summary": "Confirm two-factor enrollment",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Project ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Code from the authenticator app",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.MfaCodeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.MessageResponse"
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.RecoveryCodesResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid ID",
+                        "description": "Validation error or enrollment not started",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden - Manager only",
+                    "401": {
+                        "description": "Invalid code",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Project not found",
+                    "409": {
+                        "description": "Two-factor authentication already enabled",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
@@ -480,14 +532,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get paginated list of tasks. Members can only see their own tasks.",
+        "/auth/mfa/verify": {
+            "post": {
+                "description": "Exchange the mfa token returned by the login and a code from the authenticator app or a recovery code for the access and refresh tokens. The mfa token can only be exchanged once and invalid codes are throttled per user",
                 "consumes": [
                     "application/json"
                 ],
@@ -495,45 +542,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tasks"
+                    "Auth"
                 ],
-                "summary": "Get all tasks",
+                "summary": "Complete a two-factor login",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Filter by project ID (required for Admin/Manager)",
-                        "name": "projectId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by title",
-                        "name": "title",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Pagination cursor",
-                        "name": "cursor",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of items per page",
-                        "name": "limit",
-                        "in": "query",
-                        "required": true
+                        "description": "Mfa token and code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.VerifyMfaRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TasksListResponse"
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.LoginResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad request - projectId required for Admin/Manager",
+                        "description": "Validation error or two-factor authentication not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or expired mfa token or invalid code",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
@@ -545,14 +582,52 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+            }
+        },
+        "/auth/oidc/authorize": {
+            "get": {
+                "description": "Get the identity provider url to send the user to, with PKCE. The state token has to be kept by the client and sent back to /auth/oidc/callback together with the code and state the identity provider redirects with, it expires in 10 minutes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
                 ],
-                "description": "Create a new task (Manager only)",
+                "summary": "Start single sign-on",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.OIDCAuthorizeResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Single sign-on is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Identity provider unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/callback": {
+            "post": {
+                "description": "Exchange the code the identity provider redirected with and sign the user in. The identity is matched to the user who signed in with it before, then to the user with the same verified email, and otherwise a user is created in the configured team when there is one. The response is the same as /auth/login",
                 "consumes": [
                     "application/json"
                 ],
@@ -560,41 +635,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tasks"
+                    "Auth"
                 ],
-                "summary": "Create a new task",
+                "summary": "Finish single sign-on",
                 "parameters": [
                     {
-                        "description": "Task creation data",
+                        "description": "Code and state from the identity provider and the state token from /auth/oidc/authorize",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateTaksPayload"
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.OIDCCallbackRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.GetTasksResponse"
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.LoginResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Two-factor authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.MfaLoginResponse"
                         }
                     },
                     "400": {
-                        "description": "Validation error",
+                        "description": "Validation error or invalid state",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "The identity provider didn't accept the code or returned an invalid id token",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Forbidden - Manager only",
+                        "description": "No verified email or no account for the identity",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Project not found",
+                        "description": "Single sign-on is not configured",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
@@ -608,14 +695,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/{id}": {
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Update task information (Manager only)",
+        "/auth/refresh-token": {
+            "post": {
+                "description": "Get a new access token and rotate the refresh token. Reusing an already rotated refresh token revokes every token issued from the same login.",
                 "consumes": [
                     "application/json"
                 ],
@@ -623,24 +705,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tasks"
+                    "Auth"
                 ],
-                "summary": "Update a task",
+                "summary": "Refresh access token",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Task ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Task update data",
+                        "description": "Refresh token",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UpdateTaskPayload"
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.RefreshTokenRequest"
                         }
                     }
                 ],
@@ -648,7 +723,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.GetTasksResponse"
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.RefreshTokenResponse"
                         }
                     },
                     "400": {
@@ -657,14 +732,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden - Manager only",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Task not found",
+                    "401": {
+                        "description": "Invalid, expired or reused refresh token",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
@@ -676,14 +745,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Delete a task by ID (Manager only)",
+            }
+        },
+        "/auth/register-admin": {
+            "post": {
+                "description": "Create the first admin account with the one-time setup token printed or written to a file at startup. Once an admin exists the endpoint is closed and new admins are created by existing admins through POST /users.",
                 "consumes": [
                     "application/json"
                 ],
@@ -691,39 +757,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tasks"
+                    "Auth"
                 ],
-                "summary": "Delete a task",
+                "summary": "Register the first admin user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Task ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Admin registration data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateAdminRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.DeleteTaskResponse"
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateAdminResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid ID",
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid setup token",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Forbidden - Manager only",
+                        "description": "Admin registration is closed",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Task not found",
+                    "409": {
+                        "description": "User already exists",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
@@ -737,14 +811,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/teams": {
+        "/auth/reset-password": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Create a new team (Admin only)",
+                "description": "Set a new password with the token received by email. The token can only be used once and every session of the user is revoked.",
                 "consumes": [
                     "application/json"
                 ],
@@ -752,47 +821,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Teams"
+                    "Auth"
                 ],
-                "summary": "Create a new team",
+                "summary": "Reset password",
                 "parameters": [
                     {
-                        "description": "Team creation data",
+                        "description": "Reset token and new password",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateTeamRequest"
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.ResetPasswordRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Team"
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.MessageResponse"
                         }
                     },
                     "400": {
-                        "description": "Validation error",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden - Admin only",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "User not found",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
-                        }
-                    },
-                    "409": {
-                        "description": "User already has a team",
+                        "description": "Validation error or invalid token",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
@@ -806,14 +857,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/teams/by-owner": {
+        "/auth/sessions": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get the team owned by the current user (Admin only)",
+                "description": "List the active sessions of the current user, one per logged in device",
                 "consumes": [
                     "application/json"
                 ],
@@ -821,18 +872,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Teams"
+                    "Sessions"
                 ],
-                "summary": "Get team by owner",
+                "summary": "List sessions",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TeamExistsResponse"
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.SessionsListResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden - Admin only",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
@@ -846,14 +897,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
-            "get": {
+        "/auth/sessions/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get paginated list of users (Admin or Manager only)",
+                "description": "Revoke one of the current user's sessions, the device has to log in again",
                 "consumes": [
                     "application/json"
                 ],
@@ -861,40 +912,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Sessions"
                 ],
-                "summary": "Get all users",
+                "summary": "Revoke a session",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter by email",
-                        "name": "email",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Team ID (required)",
-                        "name": "teamId",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by role (Admin, Manager, Member)",
-                        "name": "role",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Pagination cursor",
-                        "name": "cursor",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of items per page",
-                        "name": "limit",
-                        "in": "query",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -902,17 +928,17 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UsersListResponse"
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.DeleteSessionResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Invalid ID",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden - Admin or Manager only",
+                    "404": {
+                        "description": "Session not found",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
@@ -924,14 +950,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/switch-team": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new user (Admin only)",
+                "description": "Work in another team the current user is a member of. The session keeps the team, its access tokens carry it with the role the user has in it",
                 "consumes": [
                     "application/json"
                 ],
@@ -939,25 +967,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Sessions"
                 ],
-                "summary": "Create a new user",
+                "summary": "Switch the team of the session",
                 "parameters": [
                     {
-                        "description": "User creation data",
+                        "description": "Team to work in",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateUserPayload"
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.SwitchTeamRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateUserResponse"
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.SwitchTeamResponse"
                         }
                     },
                     "400": {
@@ -967,13 +995,13 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden - Admin only",
+                        "description": "The team requires two-factor authentication",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "User already exists",
+                    "404": {
+                        "description": "Team not found",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
@@ -987,9 +1015,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/exists-by-email": {
+        "/auth/tokens": {
             "get": {
-                "description": "Check if a user with the given email exists",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the personal access tokens of the current user, expired ones included",
                 "consumes": [
                     "application/json"
                 ],
@@ -997,27 +1030,73 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
-                ],
-                "summary": "Check if user exists by email",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "User email",
-                        "name": "email",
-                        "in": "query",
-                        "required": true
-                    }
+                    "Personal Access Tokens"
                 ],
+                "summary": "List personal access tokens",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UserExistsResponse"
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.PersonalAccessTokensListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a named token for scripts and CI. It can only be used on the routes its scopes allow and is returned only once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Personal Access Tokens"
+                ],
+                "summary": "Create a personal access token",
+                "parameters": [
+                    {
+                        "description": "Token name, scopes and expiry",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreatePersonalAccessTokenPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreatePersonalAccessTokenResponse"
                         }
                     },
                     "400": {
-                        "description": "Email is required",
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
@@ -1031,14 +1110,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}": {
-            "put": {
+        "/auth/tokens/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update user information (Admin only)",
+                "description": "Revoke one of the current user's personal access tokens, it stops working right away",
                 "consumes": [
                     "application/json"
                 ],
@@ -1046,32 +1125,124 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Personal Access Tokens"
                 ],
-                "summary": "Update a user",
+                "summary": "Revoke a personal access token",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Personal access token ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.DeletePersonalAccessTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Personal access token not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
                     },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations": {
+            "get": {
+                "security": [
                     {
-                        "description": "User update data",
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the invitations of the admin team that have not been accepted yet (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invitations"
+                ],
+                "summary": "List pending invitations",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.InvitationsListResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Email an invitation to join the admin team with the given role. The invitee sets their own password from the link (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invitations"
+                ],
+                "summary": "Invite a user",
+                "parameters": [
+                    {
+                        "description": "Invitation data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UpdateUserPayload"
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateInvitationPayload"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.User"
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.InvitationResponse"
                         }
                     },
                     "400": {
@@ -1087,7 +1258,13 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Team not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User already exists or already invited",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
@@ -1099,14 +1276,74 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/invitations/accept": {
+            "post": {
+                "description": "Create the invited user with the username and password of their choice and log them in. Each link can only be used once. When the team requires two-factor authentication the user gets an mfa token to set it up instead, like in the login",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invitations"
+                ],
+                "summary": "Accept an invitation",
+                "parameters": [
+                    {
+                        "description": "Invitation token and user data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.AcceptInvitationPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.AcceptInvitationResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Two-factor authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.MfaLoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or invalid invitation",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User already exists",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations/{id}": {
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a user by ID (Admin only)",
+                "description": "Delete a pending invitation, its link stops working (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1114,13 +1351,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Invitations"
                 ],
-                "summary": "Delete a user",
+                "summary": "Revoke an invitation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Invitation ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1130,7 +1367,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.MessageResponse"
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.DeleteInvitationResponse"
                         }
                     },
                     "400": {
@@ -1146,7 +1383,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Invitation not found",
                         "schema": {
                             "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
                         }
@@ -1159,291 +1396,4543 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateAdminRequest": {
-            "type": "object",
-            "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "admin@example.com"
-                },
-                "password": {
-                    "type": "string",
-                    "example": "password123"
-                },
-                "username": {
-                    "type": "string",
-                    "example": "admin"
-                }
-            }
-        },
-        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateAdminResponse": {
-            "type": "object",
-            "properties": {
-                "accessToken": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
-                },
-                "refreshToken": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
-                },
-                "user": {
-                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.User"
-                }
-            }
         },
-        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateProjectPayload": {
-            "type": "object",
-            "required": [
-                "name"
-            ],
-            "properties": {
-                "name": {
-                    "type": "string"
+        "/invitations/{id}/resend": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Email a new invitation link with a new expiration. Links sent before stop working (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invitations"
+                ],
+                "summary": "Resend an invitation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invitation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.InvitationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Invitation not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the organizations the current user administers (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Get the organizations of the user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.OrganizationsListResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create an organization administered by the current user, its teams are created with organizationId (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Create an organization",
+                "parameters": [
+                    {
+                        "description": "Organization data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateOrganizationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.OrganizationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get an organization the current user administers by ID (Admin only, must administer the organization)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Get an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.OrganizationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rename an organization or require two-factor authentication in all its teams. Requiring it turns it on in every team of the organization and logs out their sessions without it, the teams can't turn it off while the organization requires it (Admin only, must administer the organization)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Update the settings of an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Organization settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UpdateOrganizationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.OrganizationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}/admins": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the admins of an organization (Admin only, must administer the organization)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "List the admins of an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.OrganizationAdminsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Let an admin of one of the teams of an organization manage all of them (Admin only, must administer the organization)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Add an admin to an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New admin",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.AddOrganizationAdminRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.OrganizationAdminResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or the user isn't an admin",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization or user not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User already administers the organization",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}/admins/{userId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop an admin from managing the teams of an organization, the teams they own stay theirs. The last admin can't be removed (Admin only, must administer the organization)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Remove an admin from an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or the user is the last admin",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization or admin not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}/report": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Count the members, projects, tasks and done tasks of each team of an organization, and of the whole organization (Admin only, must administer the organization)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Get the report of an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.OrganizationReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}/teams": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List every team of an organization, its admins manage all of them (Admin only, must administer the organization)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "List the teams of an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.OrganizationTeamsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the users of every team of an organization, each one with the teams they are in and their role in each (Admin only, must administer the organization)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Get the user directory of an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.OrganizationUsersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get paginated list of projects, only the team of the user and its managers can be listed. Roles that can't list every project only list the ones they are members of",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Get all projects",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by team ID",
+                        "name": "teamId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by manager ID",
+                        "name": "managerId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include task statistics",
+                        "name": "withStats",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.ProjectsListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - teamId or managerId required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Team or manager not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new project, its manager is its first owner. Managers manage the projects they create unless they pick another manager of the team, admins have to pick one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Create a new project",
+                "parameters": [
+                    {
+                        "description": "Project creation data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateProjectPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.GetProjectsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error, managerId required or the user isn't a manager",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Manager not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update project information (must be an owner of the project or an admin of the team)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Update a project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Project update data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UpdateProjectPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.GetProjectsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Must be an owner of the project",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a project by ID (must be an owner of the project or an admin of the team)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Delete a project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Must be an owner of the project",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/members": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the owners, contributors and viewers of a project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "List the members of a project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.ProjectMembersListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a user of the team to a project as an owner, a contributor or a viewer (must be an owner of the project). Only managers can own a project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Add a member to a project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Member data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.AddProjectMemberPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.ProjectMemberResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or the user can't own a project",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Must be an owner of the project",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Project or user not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User is already a member of the project",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/members/{userId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a member from a project (must be an owner of the project), its tasks in the project are left unassigned. The last owner of a project can't be removed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Remove a member from a project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or last owner of the project",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Must be an owner of the project",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Project or member not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the built-in roles and the custom roles of the team of the user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "List roles",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.RolesListResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a custom role for the admin team with a set of permissions over its users, projects and tasks (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Create a role",
+                "parameters": [
+                    {
+                        "description": "Role data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.RolePayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.RoleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Role already exists",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a built-in role or a custom role of the team of the user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Get a role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.RoleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Role not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rename a custom role of the admin team or change its permissions, its users get them on their next request (within ROLES_SYNC_INTERVAL on the other instances of the api). Built-in roles can't be changed (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Update a role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.RolePayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.RoleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or built-in role",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Role not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Role already exists",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a custom role of the admin team, its users go back to the built-in role of their base role. Built-in roles can't be deleted (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Delete a role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.DeleteRoleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or built-in role",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Role not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get paginated list of tasks. Members see the tasks of the projects they are members of and the ones assigned to them, only projects of the team of the user can be listed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Get all tasks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by project ID (required for Admin/Manager)",
+                        "name": "projectId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by title",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TasksListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - projectId required for Admin/Manager",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new task (must be an owner of the project or an admin of the team). Tasks can only be assigned to owners and contributors of the project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Create a new task",
+                "parameters": [
+                    {
+                        "description": "Task creation data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateTaksPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.GetTasksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or the user is not a contributor of the project",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Must be an owner of the project",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Project or user not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update task information (must be an owner of the project or an admin of the team). Tasks can only be assigned to owners and contributors of the project. Members can change the status of the tasks assigned to them and add to their description, sending the rest of the task as it is",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Update a task",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Task update data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UpdateTaskPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.GetTasksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or the user is not a contributor of the project",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Must be an owner of the project, or the assignee changing only the status and the description",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Task or user not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a task by ID (must be an owner of the project or an admin of the team)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Delete a task",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.DeleteTaskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Must be an owner of the project",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Task not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get paginated list of the teams owned by the current user (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Get the teams of the user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TeamsListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new team owned by the current user, admins can own several. The team goes in organizationId, which the user has to administer, and teams created without one get an organization of their own (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Create a new team",
+                "parameters": [
+                    {
+                        "description": "Team creation data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateTeamRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Team"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User or organization not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/by-owner": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the team owned by the current user that the session works in (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Get team by owner",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TeamExistsResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/mfa": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Require two-factor authentication for everyone in the team the admin works in, including the admin. Turning it on logs out the sessions of the team without it, members enroll on their next login. It can't be turned off while the organization of the team requires it (Admin only, must own the team)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Require two-factor authentication",
+                "parameters": [
+                    {
+                        "description": "Two-factor requirement",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UpdateTeamMfaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TeamResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or the organization requires two-factor authentication",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a team owned by the current user, or of an organization the user administers, by ID (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Get a team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TeamResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Must own the team or administer its organization",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a team with its projects, tasks, invitations and custom roles. The sessions working in it are logged out and the users it was the default team of move to their next team. With dryRun it only reports what would be removed, otherwise confirm has to be the name of the team (Admin only, must own the team or administer its organization)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Delete a team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only report what would be removed",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of the team, required to delete it",
+                        "name": "confirm",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TeamDeletionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or the confirmation doesn't match the name of the team",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Must own the team or administer its organization",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rename a team owned by the current user, or of an organization the user administers (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Rename a team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Team data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UpdateTeamRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TeamResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Must own the team or administer its organization",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{id}/members": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the users of a team with the role each one has in it, wherever their default team is (Admin only, must own the team or administer its organization)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "List the members of a team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TeamMembersListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Must own the team or administer its organization",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add an existing user to a team as a manager or a member, optionally with a custom role of the team. The team of the user doesn't change, they switch to this one from their session (Admin only, must own the team or administer its organization)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Add a member to a team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Member data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.AddTeamMemberPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TeamMemberResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or the admin role was given",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Must own the team or administer its organization",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Team, user or role not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User is already a member of the team",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{id}/members/{userId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a user from a team. Their tasks in it are unassigned, they leave its projects and their sessions in it are logged out. Users whose default team it was move to their next team (Admin only, must own the team or administer its organization)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Remove a member from a team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID, the user owns the team or is the only owner of projects of the team",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Must own the team or administer its organization",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Team or member not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{id}/transfer-ownership": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Make another admin of the team its owner, the current owner stays in the team as one of its admins (Admin only, must own the team or administer its organization)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Transfer the ownership of a team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New owner",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TransferTeamOwnershipRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TeamResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error, the user isn't an admin or already owns the team",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Must own the team or administer its organization",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Team or user not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get paginated list of the users of a team (Admin or Manager only), only the team of the user can be listed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get all users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by email",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Team ID, defaults to the team of the user",
+                        "name": "teamId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by role (Admin, Manager, Member)",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UsersListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin or Manager only",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new user in the team of the admin (Admin only), teamId defaults to it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Create a new user",
+                "parameters": [
+                    {
+                        "description": "User creation data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateUserPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User already exists",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/exists-by-email": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Check if a user with the given email exists (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Check if user exists by email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User email",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UserExistsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Email is required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the profile of the logged in user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get my profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.User"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the username or email of the logged in user, fields left empty keep their value. Changing the email requires the current password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update my profile",
+                "parameters": [
+                    {
+                        "description": "Profile changes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UpdateCurrentUserPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or missing or wrong current password",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Email already in use",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/password": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the password of the logged in user. The current password is required and every other session of the user is revoked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Change my password",
+                "parameters": [
+                    {
+                        "description": "Current and new password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.ChangePasswordPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or wrong current password",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/teams": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the teams the logged in user is a member of with the role it has in each one, the session can switch to any of them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "List my teams",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TeamMembersListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update user information (Admin only). Changing the role of a Manager or Member, or assigning them a role with roleId, revokes every session of the user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User update data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UpdateUserPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deactivate a user of the team by ID, handing their teams, projects and tasks that aren't done over to other users first: teamOwnerId takes over the teams they own and has to be an admin of them, projectManagerId the projects they manage and has to be a manager of their teams, and taskAssigneeId the tasks assigned to them and has to be a member of their teams. Each one is required when the user has any. The user is logged out everywhere and can be reactivated. With dryRun it only reports what would be handed over, with permanent it deletes a user already deactivated for good. All their work has to be in the team of the caller or in teams the caller owns or administers the organization of. Users whose default team is another one are removed from the team instead (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Deactivate a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only report what would be handed over",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete a deactivated user for good",
+                        "name": "permanent",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Users that take over the work of the user",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.DeactivateUserPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UserOffboardingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID, the user belongs to another team, or their work isn't handed over to valid users",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only, or the user has work in a team the caller doesn't manage",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The user is already deactivated",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/reactivate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reactivate a deactivated user of the team so they can log in again. The work handed over when they were deactivated stays with the users that took it over (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Reactivate a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or the user belongs to another team",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The user isn't deactivated",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/sessions": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Log a user of the admin's team out of every device (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Revoke all sessions of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.RevokeSessionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/unlock": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Clear the failed login attempts of a user of the admin's team so they can log in again right away (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Unlock a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UnlockUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.AcceptInvitationPayload": {
+            "type": "object",
+            "required": [
+                "password",
+                "token",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "password123"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJFZERTQSIsImtpZCI6ImRlZmF1bHQiLCJ0eXAiOiJKV1QifQ..."
+                },
+                "username": {
+                    "type": "string",
+                    "example": "member"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.AcceptInvitationResponse": {
+            "type": "object",
+            "properties": {
+                "accessToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "refreshToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "user": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.User"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.AddOrganizationAdminRequest": {
+            "type": "object",
+            "required": [
+                "userId"
+            ],
+            "properties": {
+                "userId": {
+                    "type": "string",
+                    "example": "7f3c2a1e-8b4d-4c6f-9a2e-1d5b7c9e0f21"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.AddProjectMemberPayload": {
+            "type": "object",
+            "required": [
+                "role",
+                "userId"
+            ],
+            "properties": {
+                "role": {
+                    "enum": [
+                        "Owner",
+                        "Contributor",
+                        "Viewer"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Projectmemberroles"
+                        }
+                    ],
+                    "example": "Contributor"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.AddTeamMemberPayload": {
+            "type": "object",
+            "required": [
+                "email",
+                "role"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "contractor@example.com"
+                },
+                "role": {
+                    "enum": [
+                        "Manager",
+                        "Member"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Userroles"
+                        }
+                    ],
+                    "example": "Member"
+                },
+                "roleId": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.ChangePasswordPayload": {
+            "type": "object",
+            "required": [
+                "currentPassword",
+                "newPassword"
+            ],
+            "properties": {
+                "currentPassword": {
+                    "type": "string",
+                    "example": "password123"
+                },
+                "newPassword": {
+                    "type": "string",
+                    "example": "newpassword123"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.ConfirmMfaEnrollmentResponse": {
+            "type": "object",
+            "properties": {
+                "accessToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "recoveryCodes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "k7m2p-x9q4r",
+                        "a3b5c-d7e8f"
+                    ]
+                },
+                "refreshToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "user": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.User"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateAdminRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "admin@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "password123"
+                },
+                "setupToken": {
+                    "type": "string",
+                    "example": "x4CZ0n6sQm9aL2pE7hV1bR8tW3yK5uJ0dF6gH2jN9cM"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateAdminResponse": {
+            "type": "object",
+            "properties": {
+                "accessToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "refreshToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "user": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.User"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateInvitationPayload": {
+            "type": "object",
+            "required": [
+                "email",
+                "role"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "member@example.com"
+                },
+                "role": {
+                    "enum": [
+                        "Manager",
+                        "Member"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Userroles"
+                        }
+                    ],
+                    "example": "Member"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateOrganizationRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Acme"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreatePersonalAccessTokenPayload": {
+            "type": "object",
+            "required": [
+                "expiresInDays",
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expiresInDays": {
+                    "type": "integer",
+                    "maximum": 365,
+                    "minimum": 1,
+                    "example": 90
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "CI pipeline"
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "tasks:read",
+                        "tasks:write"
+                    ]
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreatePersonalAccessTokenResponse": {
+            "type": "object",
+            "properties": {
+                "personalAccessToken": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.PersonalAccessToken"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "pat_3q2-7wXb0kz5Jm1hY8fQ4uVtR6nE9cLpA0sDgHjKlZx"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateProjectPayload": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "managerId": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateTaksPayload": {
+            "type": "object",
+            "required": [
+                "projectId",
+                "title"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "projectId": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateTeamRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Development Team"
+                },
+                "organizationId": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateUserPayload": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "role",
+                "username"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "role": {
+                    "enum": [
+                        "Admin",
+                        "Manager",
+                        "Member"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Userroles"
+                        }
+                    ]
+                },
+                "teamId": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateUserResponse": {
+            "type": "object",
+            "properties": {
+                "user": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.User"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.DeactivateUserPayload": {
+            "type": "object",
+            "properties": {
+                "projectManagerId": {
+                    "type": "string"
+                },
+                "taskAssigneeId": {
+                    "type": "string"
+                },
+                "teamOwnerId": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.DeleteInvitationResponse": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.DeletePersonalAccessTokenResponse": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.DeleteRoleResponse": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.DeleteSessionResponse": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.DeleteTaskResponse": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.DisableMfaResponse": {
+            "type": "object",
+            "properties": {
+                "disabled": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.ForgotPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.GetProjectsResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "doneTasks": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "inProgressTasks": {
+                    "type": "integer"
+                },
+                "managerId": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Projectstatus"
+                },
+                "teamId": {
+                    "type": "string"
+                },
+                "toDoTasks": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.GetTasksResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "projectId": {
+                    "type": "string"
+                },
+                "projectName": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Taskstatus"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "userName": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.InvitationResponse": {
+            "type": "object",
+            "properties": {
+                "invitation": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Invitation"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.InvitationsListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Invitation"
+                    }
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "label": {
+                    "type": "string",
+                    "example": "Work laptop"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "password123"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "accessToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "refreshToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "user": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.User"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.LogoutResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Successfully logged out"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.MessageResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "operation completed successfully"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.MfaCodeRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.MfaLoginResponse": {
+            "type": "object",
+            "properties": {
+                "mfaEnrolled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "mfaRequired": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "mfaToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJFZERTQSIsImtpZCI6ImRlZmF1bHQiLCJ0eXAiOiJKV1QifQ..."
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.MfaTokenRequest": {
+            "type": "object",
+            "properties": {
+                "mfaToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJFZERTQSIsImtpZCI6ImRlZmF1bHQiLCJ0eXAiOiJKV1QifQ..."
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.OIDCAuthorizeResponse": {
+            "type": "object",
+            "properties": {
+                "authorizationUrl": {
+                    "type": "string",
+                    "example": "https://idp.example.com/authorize?client_id=challenge\u0026code_challenge=...\u0026response_type=code\u0026state=..."
+                },
+                "stateToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJFZERTQSIsImtpZCI6IjIwMjYtMDEiLCJ0eXAiOiJKV1QifQ..."
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.OIDCCallbackRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "state",
+                "stateToken"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "SplxlOBeZQQYbYS6WxSbIA"
+                },
+                "label": {
+                    "type": "string",
+                    "example": "Work laptop"
+                },
+                "state": {
+                    "type": "string",
+                    "example": "af0ifjsldkj"
+                },
+                "stateToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJFZERTQSIsImtpZCI6IjIwMjYtMDEiLCJ0eXAiOiJKV1QifQ..."
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.OffboardingTask": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "$ref": "#/definitions/sql.NullString"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "projectId": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Taskstatus"
+                },
+                "teamId": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.OrganizationAdminResponse": {
+            "type": "object",
+            "properties": {
+                "admin": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.OrganizationAdmin"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.OrganizationAdminsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.OrganizationAdmin"
+                    }
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.OrganizationReport": {
+            "type": "object",
+            "properties": {
+                "doneTasks": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "projects": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "tasks": {
+                    "type": "integer",
+                    "example": 64
+                },
+                "teams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TeamReport"
+                    }
+                },
+                "users": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.OrganizationReportResponse": {
+            "type": "object",
+            "properties": {
+                "report": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.OrganizationReport"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.OrganizationResponse": {
+            "type": "object",
+            "properties": {
+                "organization": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Organization"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.OrganizationTeamsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Team"
+                    }
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.OrganizationUsersResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.OrganizationUser"
+                    }
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.OrganizationsListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Organization"
+                    }
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.PersonalAccessTokensListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.PersonalAccessToken"
+                    }
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.ProjectMemberResponse": {
+            "type": "object",
+            "properties": {
+                "member": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.ProjectMember"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.ProjectMembersListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.ProjectMember"
+                    }
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.ProjectsListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.GetProjectsResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.Pagination"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.RecoveryCodesResponse": {
+            "type": "object",
+            "properties": {
+                "recoveryCodes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "k7m2p-x9q4r",
+                        "a3b5c-d7e8f"
+                    ]
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.RefreshTokenRequest": {
+            "type": "object",
+            "properties": {
+                "refreshToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.RefreshTokenResponse": {
+            "type": "object",
+            "properties": {
+                "accessToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "refreshToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.ResetPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "newpassword123"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "x4CZ0n6sQm9aL2pE7hV1bR8tW3yK5uJ0dF6gH2jN9cM"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.RevokeSessionsResponse": {
+            "type": "object",
+            "properties": {
+                "revoked": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.RolePayload": {
+            "type": "object",
+            "required": [
+                "name",
+                "permissions"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "QA"
+                },
+                "permissions": {
+                    "type": "array",
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "tasks:view_any",
+                        "tasks:edit_any"
+                    ]
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.RoleResponse": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Role"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.RolesListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Role"
+                    }
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.SessionResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "current": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ipAddress": {
+                    "type": "string",
+                    "example": "127.0.0.1"
+                },
+                "label": {
+                    "type": "string",
+                    "example": "Work laptop"
+                },
+                "lastUsedAt": {
+                    "type": "string"
+                },
+                "userAgent": {
+                    "type": "string",
+                    "example": "Mozilla/5.0"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.SessionsListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.SessionResponse"
+                    }
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.SwitchTeamRequest": {
+            "type": "object",
+            "required": [
+                "teamId"
+            ],
+            "properties": {
+                "teamId": {
+                    "type": "string",
+                    "example": "7f3c2a1e-8b4d-4c6f-9a2e-1d5b7c9e0f21"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.SwitchTeamResponse": {
+            "type": "object",
+            "properties": {
+                "accessToken": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.User"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TasksListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.GetTasksResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.Pagination"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TeamDeletionReport": {
+            "type": "object",
+            "properties": {
+                "invitations": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "projects": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "roles": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "tasks": {
+                    "type": "integer",
+                    "example": 24
+                },
+                "users": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TeamDeletionResponse": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "report": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TeamDeletionReport"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TeamExistsResponse": {
+            "type": "object",
+            "properties": {
+                "exists": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "team": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Team"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TeamMemberResponse": {
+            "type": "object",
+            "properties": {
+                "member": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.TeamMember"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TeamMembersListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.TeamMember"
+                    }
                 }
             }
         },
-        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateTaksPayload": {
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TeamReport": {
             "type": "object",
-            "required": [
-                "projectId",
-                "title"
-            ],
             "properties": {
-                "description": {
-                    "type": "string"
+                "doneTasks": {
+                    "type": "integer",
+                    "example": 10
                 },
-                "projectId": {
-                    "type": "string"
+                "members": {
+                    "type": "integer",
+                    "example": 5
                 },
-                "title": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "example": "Development Team"
                 },
-                "userId": {
+                "projects": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "tasks": {
+                    "type": "integer",
+                    "example": 24
+                },
+                "teamId": {
                     "type": "string"
                 }
             }
         },
-        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateTeamRequest": {
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TeamResponse": {
             "type": "object",
             "properties": {
-                "name": {
-                    "type": "string",
-                    "example": "Development Team"
+                "team": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Team"
                 }
             }
         },
-        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateUserPayload": {
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TeamsListResponse": {
             "type": "object",
-            "required": [
-                "email",
-                "password",
-                "role",
-                "username"
-            ],
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "password": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Team"
+                    }
                 },
-                "role": {
-                    "enum": [
-                        "Admin",
-                        "Manager",
-                        "Member"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Userroles"
-                        }
-                    ]
+                "pagination": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.Pagination"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TotpEnrollmentResponse": {
+            "type": "object",
+            "properties": {
+                "provisioningUri": {
+                    "type": "string",
+                    "example": "otpauth://totp/challenge-fs-senior:user@example.com?algorithm=SHA1\u0026digits=6\u0026issuer=challenge-fs-senior\u0026period=30\u0026secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
                 },
-                "teamId": {
-                    "type": "string"
+                "qrPayload": {
+                    "type": "string",
+                    "example": "otpauth://totp/challenge-fs-senior:user@example.com?algorithm=SHA1\u0026digits=6\u0026issuer=challenge-fs-senior\u0026period=30\u0026secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
                 },
-                "username": {
-                    "type": "string"
+                "secret": {
+                    "type": "string",
+                    "example": "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
                 }
             }
         },
-        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.CreateUserResponse": {
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TransferTeamOwnershipRequest": {
             "type": "object",
+            "required": [
+                "userId"
+            ],
             "properties": {
-                "user": {
-                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.User"
+                "userId": {
+                    "type": "string",
+                    "example": "7f3c2a1e-8b4d-4c6f-9a2e-1d5b7c9e0f21"
                 }
             }
         },
-        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.DeleteTaskResponse": {
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UnlockUserResponse": {
             "type": "object",
             "properties": {
-                "deleted": {
+                "unlocked": {
                     "type": "boolean",
                     "example": true
                 }
             }
         },
-        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.GetProjectsResponse": {
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UpdateCurrentUserPayload": {
             "type": "object",
             "properties": {
-                "createdAt": {
-                    "type": "string"
-                },
-                "doneTasks": {
-                    "type": "integer"
-                },
-                "id": {
-                    "type": "string"
+                "currentPassword": {
+                    "type": "string",
+                    "example": "password123"
                 },
-                "inProgressTasks": {
-                    "type": "integer"
+                "email": {
+                    "type": "string",
+                    "example": "jdoe@example.com"
                 },
-                "managerId": {
-                    "type": "string"
+                "username": {
+                    "type": "string",
+                    "example": "jdoe"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UpdateOrganizationRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "Acme Inc"
                 },
+                "requireMfa": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UpdateProjectPayload": {
+            "type": "object",
+            "required": [
+                "name",
+                "status"
+            ],
+            "properties": {
                 "name": {
                     "type": "string"
                 },
                 "status": {
-                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Projectstatus"
-                },
-                "teamId": {
-                    "type": "string"
-                },
-                "toDoTasks": {
-                    "type": "integer"
-                },
-                "updatedAt": {
-                    "type": "string"
+                    "enum": [
+                        "OnHold",
+                        "InProgress",
+                        "Completed"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Projectstatus"
+                        }
+                    ]
                 }
             }
         },
-        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.GetTasksResponse": {
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UpdateTaskPayload": {
             "type": "object",
+            "required": [
+                "status",
+                "title"
+            ],
             "properties": {
-                "createdAt": {
-                    "type": "string"
-                },
                 "description": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
-                },
-                "projectId": {
-                    "type": "string"
-                },
-                "projectName": {
-                    "type": "string"
-                },
                 "status": {
-                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Taskstatus"
+                    "enum": [
+                        "ToDo",
+                        "InProgress",
+                        "Done"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Taskstatus"
+                        }
+                    ]
                 },
                 "title": {
                     "type": "string"
                 },
-                "updatedAt": {
-                    "type": "string"
-                },
                 "userId": {
-                    "$ref": "#/definitions/uuid.NullUUID"
-                },
-                "userName": {
                     "type": "string"
                 }
             }
         },
-        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.LoginRequest": {
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UpdateTeamMfaRequest": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "user@example.com"
-                },
-                "password": {
-                    "type": "string",
-                    "example": "password123"
+                "requireMfa": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.LoginResponse": {
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UpdateTeamRequest": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
-                "accessToken": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
-                },
-                "refreshToken": {
+                "name": {
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
-                },
-                "user": {
-                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.User"
+                    "example": "Platform Team"
                 }
             }
         },
-        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.LogoutResponse": {
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UpdateUserPayload": {
             "type": "object",
+            "required": [
+                "email",
+                "username"
+            ],
             "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "Successfully logged out"
+                "email": {
+                    "type": "string"
+                },
+                "role": {
+                    "enum": [
+                        "Manager",
+                        "Member"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Userroles"
+                        }
+                    ],
+                    "example": "Member"
+                },
+                "roleId": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.MessageResponse": {
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UserExistsResponse": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "operation completed successfully"
+                "exists": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.ProjectsListResponse": {
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UserOffboardingReport": {
             "type": "object",
             "properties": {
-                "data": {
+                "projects": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.GetProjectsResponse"
+                        "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Project"
                     }
                 },
-                "pagination": {
-                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.Pagination"
-                }
-            }
-        },
-        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.RefreshTokenRequest": {
-            "type": "object",
-            "properties": {
-                "refreshToken": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.OffboardingTask"
+                    }
+                },
+                "teams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Team"
+                    }
                 }
             }
         },
-        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.RefreshTokenResponse": {
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UserOffboardingResponse": {
             "type": "object",
             "properties": {
-                "accessToken": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                "deactivated": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "report": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UserOffboardingReport"
                 }
             }
         },
-        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TasksListResponse": {
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UsersListResponse": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_interfaces.GetTasksResponse"
+                        "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.User"
                     }
                 },
                 "pagination": {
@@ -1451,110 +5940,209 @@ const docTemplate = `{
                 }
             }
         },
-        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.TeamExistsResponse": {
+        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.VerifyMfaRequest": {
             "type": "object",
             "properties": {
-                "exists": {
-                    "type": "boolean",
-                    "example": true
+                "code": {
+                    "type": "string",
+                    "example": "123456"
                 },
-                "team": {
-                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Team"
+                "label": {
+                    "type": "string",
+                    "example": "Work laptop"
+                },
+                "mfaToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJFZERTQSIsImtpZCI6ImRlZmF1bHQiLCJ0eXAiOiJKV1QifQ..."
                 }
             }
         },
-        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UpdateProjectPayload": {
+        "github_com_TobiasRV_challenge-fs-senior_internals_models.Invitation": {
             "type": "object",
-            "required": [
-                "name",
-                "status"
-            ],
             "properties": {
-                "name": {
+                "createdAt": {
                     "type": "string"
                 },
-                "status": {
-                    "enum": [
-                        "OnHold",
-                        "InProgress",
-                        "Completed"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Projectstatus"
-                        }
-                    ]
+                "email": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "invitedBy": {
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Userroles"
+                },
+                "teamId": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UpdateTaskPayload": {
+        "github_com_TobiasRV_challenge-fs-senior_internals_models.Organization": {
             "type": "object",
-            "required": [
-                "status",
-                "title"
-            ],
             "properties": {
-                "description": {
+                "createdAt": {
                     "type": "string"
                 },
-                "status": {
-                    "enum": [
-                        "ToDo",
-                        "InProgress",
-                        "Done"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Taskstatus"
-                        }
-                    ]
+                "id": {
+                    "type": "string"
                 },
-                "title": {
+                "name": {
                     "type": "string"
                 },
-                "userId": {
+                "requireMfa": {
+                    "type": "boolean"
+                },
+                "updatedAt": {
                     "type": "string"
                 }
             }
         },
-        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UpdateUserPayload": {
+        "github_com_TobiasRV_challenge-fs-senior_internals_models.OrganizationAdmin": {
             "type": "object",
-            "required": [
-                "email",
-                "username"
-            ],
             "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
                 "email": {
                     "type": "string"
                 },
+                "organizationId": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                },
                 "username": {
                     "type": "string"
                 }
             }
         },
-        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UserExistsResponse": {
+        "github_com_TobiasRV_challenge-fs-senior_internals_models.OrganizationUser": {
             "type": "object",
             "properties": {
-                "exists": {
-                    "type": "boolean",
-                    "example": true
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "teams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.TeamMember"
+                    }
+                },
+                "username": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_TobiasRV_challenge-fs-senior_internals_interfaces.UsersListResponse": {
+        "github_com_TobiasRV_challenge-fs-senior_internals_models.PersonalAccessToken": {
             "type": "object",
             "properties": {
-                "data": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastUsedAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.User"
+                        "type": "string"
                     }
                 },
-                "pagination": {
-                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_utils.Pagination"
+                "tokenPrefix": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
                 }
             }
         },
+        "github_com_TobiasRV_challenge-fs-senior_internals_models.Project": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "managerId": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Projectstatus"
+                },
+                "teamId": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_models.ProjectMember": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "projectId": {
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Projectmemberroles"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_models.Projectmemberroles": {
+            "type": "string",
+            "enum": [
+                "Owner",
+                "Contributor",
+                "Viewer"
+            ],
+            "x-enum-varnames": [
+                "ProjectmemberrolesOwner",
+                "ProjectmemberrolesContributor",
+                "ProjectmemberrolesViewer"
+            ]
+        },
         "github_com_TobiasRV_challenge-fs-senior_internals_models.Projectstatus": {
             "type": "string",
             "enum": [
@@ -1568,6 +6156,38 @@ const docTemplate = `{
                 "ProjectstatusCompleted"
             ]
         },
+        "github_com_TobiasRV_challenge-fs-senior_internals_models.Role": {
+            "type": "object",
+            "properties": {
+                "baseRole": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Userroles"
+                },
+                "builtIn": {
+                    "type": "boolean"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "teamId": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
         "github_com_TobiasRV_challenge-fs-senior_internals_models.Taskstatus": {
             "type": "string",
             "enum": [
@@ -1593,11 +6213,49 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "organizationId": {
+                    "type": "string"
+                },
                 "ownerId": {
                     "type": "string"
                 },
+                "requireMfa": {
+                    "type": "boolean"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_TobiasRV_challenge-fs-senior_internals_models.TeamMember": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Userroles"
+                },
+                "roleId": {
+                    "type": "string"
+                },
+                "teamId": {
+                    "type": "string"
+                },
+                "teamName": {
+                    "type": "string"
+                },
                 "updatedAt": {
                     "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
                 }
             }
         },
@@ -1607,6 +6265,10 @@ const docTemplate = `{
                 "createdAt": {
                     "type": "string"
                 },
+                "deactivatedAt": {
+                    "description": "DeactivatedAt is set while the user is deactivated, they can't log in until they are reactivated",
+                    "type": "string"
+                },
                 "email": {
                     "type": "string"
                 },
@@ -1616,6 +6278,9 @@ const docTemplate = `{
                 "role": {
                     "$ref": "#/definitions/github_com_TobiasRV_challenge-fs-senior_internals_models.Userroles"
                 },
+                "roleId": {
+                    "type": "string"
+                },
                 "teamId": {
                     "type": "string"
                 },
@@ -1670,6 +6335,18 @@ const docTemplate = `{
                 }
             }
         },
+        "sql.NullString": {
+            "type": "object",
+            "properties": {
+                "string": {
+                    "type": "string"
+                },
+                "valid": {
+                    "description": "Valid is true if String is not NULL",
+                    "type": "boolean"
+                }
+            }
+        },
         "uuid.NullUUID": {
             "type": "object",
             "properties": {
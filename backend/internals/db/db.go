@@ -23,7 +23,7 @@ func New() (queries *database.Queries, dbConn *sql.DB) {
 	}
 
 	// Run migrations
-	if err := runMigrations(conn); err != nil {
+	if err := Migrate(conn); err != nil {
 		log.Fatal("Failed to run migrations: ", err)
 	}
 
@@ -39,7 +39,8 @@ func New() (queries *database.Queries, dbConn *sql.DB) {
 	return queries, conn
 }
 
-func runMigrations(db *sql.DB) error {
+// Migrate runs the embedded migrations up to the latest one
+func Migrate(db *sql.DB) error {
 	goose.SetBaseFS(internalsql.EmbedMigrations)
 
 	if err := goose.SetDialect("postgres"); err != nil {
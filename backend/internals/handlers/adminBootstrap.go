@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"sync"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
+)
+
+// adminBootstrap holds the hash of the one-time setup token that allows registering the first admin.
+// It's empty when an admin already existed at startup or once the token has been used
+type adminBootstrap struct {
+	mu        sync.Mutex
+	tokenHash string
+}
+
+// EnableAdminBootstrap opens POST /auth/register-admin to whoever has the setup token, it's called at
+// startup when there is no admin yet
+func (h *Handler) EnableAdminBootstrap(setupToken string) {
+	h.adminBootstrap.mu.Lock()
+	defer h.adminBootstrap.mu.Unlock()
+
+	h.adminBootstrap.tokenHash = utils.HashOpaqueToken(setupToken)
+}
+
+func (ab *adminBootstrap) enabled() bool {
+	ab.mu.Lock()
+	defer ab.mu.Unlock()
+
+	return ab.tokenHash != ""
+}
+
+func (ab *adminBootstrap) verify(setupToken string) bool {
+	ab.mu.Lock()
+	defer ab.mu.Unlock()
+
+	if ab.tokenHash == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(ab.tokenHash), []byte(utils.HashOpaqueToken(setupToken))) == 1
+}
+
+func (ab *adminBootstrap) close() {
+	ab.mu.Lock()
+	defer ab.mu.Unlock()
+
+	ab.tokenHash = ""
+}
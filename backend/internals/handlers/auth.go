@@ -5,7 +5,7 @@ import (
 	"errors"
 	"time"
 
-	_ "github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
 	"github.com/TobiasRV/challenge-fs-senior/internals/models"
 	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
 	"github.com/gofiber/fiber/v2"
@@ -15,20 +15,24 @@ import (
 
 // LogIn godoc
 // @Summary User login
-// @Description Authenticate user and return access and refresh tokens
+// @Description Authenticate user, open a new session for the device and return access and refresh tokens. Failed attempts are counted per account and per ip address, after a few of them the caller has to wait longer between attempts and the account is locked for 15 minutes after 10. When the user has two-factor authentication enabled, or their team requires it, an mfa token is returned instead to complete the login in /auth/mfa/verify or /auth/mfa/enroll
 // @Tags Auth
 // @Accept json
 // @Produce json
 // @Param request body interfaces.LoginRequest true "Login credentials"
 // @Success 200 {object} interfaces.LoginResponse
+// @Success 202 {object} interfaces.MfaLoginResponse "Two-factor authentication required"
 // @Failure 400 {object} utils.ErrorResponse "Validation error"
-// @Failure 409 {object} utils.ErrorResponse "Invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "The account has been deactivated"
+// @Failure 409 {object} utils.ErrorResponse "Invalid email or password"
+// @Failure 429 {object} utils.ErrorResponse "Too many failed attempts for the account or the ip address, see the Retry-After header"
 // @Failure 500 {object} utils.ErrorResponse "Internal server error"
 // @Router /auth/login [post]
 func (h *Handler) LogIn(c *fiber.Ctx) error {
 	payload := struct {
 		Email    string `json:"email" validate:"required"`
 		Password string `json:"password" validate:"required"`
+		Label    string `json:"label"`
 	}{}
 
 	if err := c.BodyParser(&payload); err != nil {
@@ -41,10 +45,29 @@ func (h *Handler) LogIn(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
 	}
 
+	now := time.Now().UTC()
+	accountKey := loginAccountKey(payload.Email)
+
+	attempts, err := h.loginAttemptRepository.GetLoginAttempts(c.Context(), interfaces.GetLoginAttemptsData{
+		AccountKey: accountKey,
+		IpAddress:  c.IP(),
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error getting data from database"))
+	}
+
+	if retryAfter := loginRetryAfter(attempts, now); retryAfter > 0 {
+		return tooManyLoginAttempts(c, retryAfter)
+	}
+
 	existingUser, err := h.userRepository.GetUserByEmail(c.Context(), payload.Email)
 
 	if errors.Is(err, sql.ErrNoRows) {
-		return c.Status(fiber.StatusConflict).JSON(utils.ErrorString("Invalid username or password"))
+		// Hash anyway so unknown emails answer as slow as wrong passwords
+		bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(payload.Password))
+
+		return h.failedLogin(c, accountKey, now)
 	}
 
 	if err != nil {
@@ -52,56 +75,68 @@ func (h *Handler) LogIn(c *fiber.Ctx) error {
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(existingUser.Password), []byte(payload.Password)); err != nil {
-		return c.Status(fiber.StatusConflict).JSON(utils.ErrorString("Invalid credentials"))
+		return h.failedLogin(c, accountKey, now)
 	}
 
-	token, err := utils.GenerateJWTToken(existingUser)
+	err = h.loginAttemptRepository.ClearLoginAttempts(c.Context(), interfaces.LoginAttemptKey{
+		Scope:      models.LoginAttemptScopeAccount,
+		Identifier: accountKey,
+	})
+
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error saving login attempt"))
 	}
 
-	refreshToken, expiresAt, err := utils.GenerateJWTRefreshToken(existingUser)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	return h.completeLogin(c, existingUser, payload.Label)
+}
+
+// completeLogin answers a login where the user proved who they are, with an mfa token when a second
+// factor is still needed or with the tokens of a new session otherwise
+func (h *Handler) completeLogin(c *fiber.Ctx, user models.User, label string) error {
+	if user.DeactivatedAt != nil {
+		return c.Status(fiber.StatusForbidden).JSON(utils.ErrorString(errUserDeactivated.Error()))
 	}
 
-	err = h.refreshTokenRepository.DeleteRefreshTokensByUserId(c.Context(), existingUser.ID.String())
+	mfaEnabled, mfaRequired, err := h.mfaStatus(c, user)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error deleting old refresh tokens"))
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error getting data from database"))
 	}
 
-	err = h.refreshTokenRepository.CreateRefreshToken(c.Context(), models.RefreshToken{
-		ID:        uuid.New(),
-		CreatedAt: time.Now().UTC(),
-		ExpiresAt: expiresAt,
-		Userid:    existingUser.ID,
-		Token:     refreshToken,
-		Revoked:   false,
-	})
+	if mfaEnabled || mfaRequired {
+		mfaToken, err := h.newMfaToken(c, user)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+		}
 
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error saving refresh token"))
+		return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+			"mfaRequired": true,
+			"mfaEnrolled": mfaEnabled,
+			"mfaToken":    mfaToken,
+		})
 	}
 
-	utils.GenerateCookie(c, token)
+	token, refreshToken, err := h.startSession(c, user, label)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error creating session"))
+	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"accessToken":  token,
 		"refreshToken": refreshToken,
-		"user":         existingUser,
+		"user":         user,
 	})
 }
 
 // RefreshToken godoc
 // @Summary Refresh access token
-// @Description Get a new access token using a refresh token
+// @Description Get a new access token and rotate the refresh token. Reusing an already rotated refresh token revokes every token issued from the same login.
 // @Tags Auth
 // @Accept json
 // @Produce json
 // @Param request body interfaces.RefreshTokenRequest true "Refresh token"
 // @Success 200 {object} interfaces.RefreshTokenResponse
 // @Failure 400 {object} utils.ErrorResponse "Validation error"
-// @Failure 401 {object} utils.ErrorResponse "Invalid or expired refresh token"
+// @Failure 401 {object} utils.ErrorResponse "Invalid, expired or reused refresh token"
 // @Failure 500 {object} utils.ErrorResponse "Internal server error"
 // @Router /auth/refresh-token [post]
 func (h *Handler) RefreshToken(c *fiber.Ctx) error {
@@ -119,6 +154,13 @@ func (h *Handler) RefreshToken(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
 	}
 
+	// Access tokens and tokens from other issuers are rejected before hitting the database
+	_, err = utils.ParseJWTToken(payload.RefreshToken, utils.RefreshTokenType)
+
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorString("invalid refresh token"))
+	}
+
 	existingToken, err := h.refreshTokenRepository.GetRefreshTokenByToken(c.Context(), payload.RefreshToken)
 
 	if errors.Is(err, sql.ErrNoRows) {
@@ -133,23 +175,90 @@ func (h *Handler) RefreshToken(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorString("refresh token has been revoked"))
 	}
 
+	if existingToken.UsedAt.Valid {
+		return h.revokeReusedRefreshToken(c, existingToken.FamilyID)
+	}
+
 	if time.Now().UTC().After(existingToken.ExpiresAt) {
 		return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorString("refresh token has expired"))
 	}
 
-	newAccessToken, err := utils.GenerateJWTToken(existingToken.UserData)
+	newRefreshToken, expiresAt, err := utils.GenerateJWTRefreshToken(existingToken.UserData, existingToken.FamilyID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	rotated, err := h.refreshTokenRepository.RotateRefreshToken(c.Context(), existingToken.ID, models.RefreshToken{
+		ID:        uuid.New(),
+		CreatedAt: time.Now().UTC(),
+		ExpiresAt: expiresAt,
+		Userid:    existingToken.Userid,
+		Token:     newRefreshToken,
+		Revoked:   false,
+		FamilyID:  existingToken.FamilyID,
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error saving refresh token"))
+	}
+
+	// Another request rotated the same token first
+	if !rotated {
+		return h.revokeReusedRefreshToken(c, existingToken.FamilyID)
+	}
+
+	err = h.sessionRepository.UpdateSessionLastUsed(c.Context(), interfaces.UpdateSessionLastUsedData{
+		LastUsedAt: time.Now().UTC(),
+		IpAddress:  c.IP(),
+		ID:         existingToken.FamilyID,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error updating session"))
+	}
+
+	session, err := h.sessionRepository.GetSessionById(c.Context(), existingToken.FamilyID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	// The access token carries the team the session works in, with the role the user has in it now
+	user, err := h.userInTeam(c, existingToken.UserData, session.TeamID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	newAccessToken, err := utils.GenerateJWTToken(user, existingToken.FamilyID)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"accessToken": newAccessToken,
+		"accessToken":  newAccessToken,
+		"refreshToken": newRefreshToken,
 	})
 }
 
+// revokeReusedRefreshToken ends the whole session when one of its refresh tokens is used twice, so
+// the access tokens issued to whoever stole it stop working too
+func (h *Handler) revokeReusedRefreshToken(c *fiber.Ctx, familyId uuid.UUID) error {
+	err := h.refreshTokenRepository.RevokeRefreshTokenFamily(c.Context(), familyId)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error revoking refresh tokens"))
+	}
+
+	err = h.sessionRepository.DeleteSession(c.Context(), familyId)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error deleting session"))
+	}
+
+	h.revokeSession(familyId)
+
+	return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorString("refresh token has already been used"))
+}
+
 // LogOut godoc
 // @Summary User logout
-// @Description Logout user and revoke all refresh tokens
+// @Description Logout user and revoke the current session
 // @Tags Auth
 // @Accept json
 // @Produce json
@@ -159,15 +268,38 @@ func (h *Handler) RefreshToken(c *fiber.Ctx) error {
 // @Security BearerAuth
 // @Router /auth/logout [delete]
 func (h *Handler) LogOut(c *fiber.Ctx) error {
-	userId := c.Locals("userId").(string)
-	err := h.refreshTokenRepository.DeleteRefreshTokensByUserId(c.Context(), userId)
+	sessionId := c.Locals("sessionId").(string)
+
+	sessionUUID, err := uuid.Parse(sessionId)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error deleting refresh tokens"))
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
 	}
 
-	c.ClearCookie("jwt")
+	err = h.sessionRepository.DeleteSession(c.Context(), sessionUUID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error deleting session"))
+	}
+
+	h.revokeSession(sessionUUID)
+
+	utils.ClearCookies(c)
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Successfully logged out",
 	})
 }
+
+// GetJWKS serves the public keys of every active verification key so other services can
+// verify our tokens without holding the private keys. It's mounted outside /api/v1 at
+// /.well-known/jwks.json
+func (h *Handler) GetJWKS(c *fiber.Ctx) error {
+	keySet, err := utils.GetJWTKeySet()
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+
+	return c.Status(fiber.StatusOK).JSON(keySet.JWKS())
+}
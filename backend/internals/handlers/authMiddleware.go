@@ -1,16 +1,123 @@
 package handlers
 
 import (
-	"fmt"
-	"os"
+	"database/sql"
+	"errors"
 	"strings"
+	"time"
 
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
 	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
 	jwtware "github.com/gofiber/contrib/jwt"
 	"github.com/gofiber/fiber/v2"
-	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
+// personalAccessTokenLastUsedInterval avoids writing the last use of a token on every request
+const personalAccessTokenLastUsedInterval = time.Minute
+
+// AuthMiddleware accepts the access tokens checked by jwtMiddleware and, on routes that list scopes,
+// personal access tokens granted all of them. Routes without scopes only accept access tokens
+func (h *Handler) AuthMiddleware(jwtMiddleware fiber.Handler, scopes ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		token, found := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+
+		if !found || !utils.IsPersonalAccessToken(token) {
+			return jwtMiddleware(c)
+		}
+
+		if len(scopes) == 0 {
+			return c.Status(fiber.StatusForbidden).JSON(utils.ErrorString("personal access tokens can't be used on this route"))
+		}
+
+		return h.personalAccessTokenHandler(c, token, scopes)
+	}
+}
+
+func (h *Handler) personalAccessTokenHandler(c *fiber.Ctx, token string, scopes []string) error {
+	personalAccessToken, err := h.personalAccessTokenRepository.GetPersonalAccessTokenByHash(c.Context(), utils.HashOpaqueToken(token))
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorString("invalid personal access token"))
+	}
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	now := time.Now().UTC()
+
+	if now.After(personalAccessToken.ExpiresAt) {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorString("personal access token has expired"))
+	}
+
+	if !personalAccessToken.HasScopes(scopes...) {
+		return c.Status(fiber.StatusForbidden).JSON(utils.ErrorString("personal access token is missing the scope " + strings.Join(scopes, ", ")))
+	}
+
+	if personalAccessToken.LastUsedAt == nil || now.Sub(*personalAccessToken.LastUsedAt) > personalAccessTokenLastUsedInterval {
+		err = h.personalAccessTokenRepository.UpdatePersonalAccessTokenLastUsed(c.Context(), interfaces.UpdatePersonalAccessTokenLastUsedData{
+			LastUsedAt: now,
+			ID:         personalAccessToken.ID,
+		})
+
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+		}
+	}
+
+	c.Locals("userId", personalAccessToken.UserID.String())
+	c.Locals("userRole", string(personalAccessToken.UserRole))
+
+	if err := h.setRolePermissions(c, personalAccessToken.UserRoleId); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	// Personal access tokens work in the default team of the user
+	if _, err := h.callerTeamId(c); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	return c.Next()
+}
+
+// setRolePermissions stores the permissions of a custom role for policy.SubjectFromContext. They come from
+// h.rolePermissions, which changes to the role update right away on this instance and with the next sync
+// on the others. Built-in roles and roles deleted since go by the permissions of the base role
+func (h *Handler) setRolePermissions(c *fiber.Ctx, roleId uuid.UUID) error {
+	if roleId == uuid.Nil || models.IsBuiltInRole(roleId) {
+		return nil
+	}
+
+	permissions, found := h.rolePermissions.get(roleId)
+
+	// Roles created through another instance since the last sync
+	if !found {
+		role, err := h.roleRepository.GetRoleById(c.Context(), roleId)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			h.rolePermissions.remove(roleId)
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		h.rolePermissions.set(role)
+		permissions = grantedPermissions(role)
+	}
+
+	if permissions == nil {
+		return nil
+	}
+
+	c.Locals("permissions", permissions)
+
+	return nil
+}
+
 func (h *Handler) JWTErrorHandler(c *fiber.Ctx, err error) error {
 	if err == jwtware.ErrJWTMissingOrMalformed {
 		return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorString("missing or malformed JWT"))
@@ -18,44 +125,70 @@ func (h *Handler) JWTErrorHandler(c *fiber.Ctx, err error) error {
 	return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorString("Invalid or expired API Key"))
 }
 
+// JWTSuccessHandler takes the access token from the Authorization header or, when there is none, from the
+// jwt cookie. Browsers send the cookie on their own, so cookie requests that change something also need
+// the csrf token of the session in the X-CSRF-Token header
 func (h *Handler) JWTSuccessHandler(c *fiber.Ctx) error {
-
-	cookieToken := c.Cookies("jwt")
 	var tokenString string
-	if cookieToken != "" {
-		tokenString = cookieToken
-	} else {
-		authHeader := c.Get("Authorization")
-		if authHeader == "" {
-			return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorString("missing or malformed JWT"))
-		}
+	fromCookie := false
 
+	authHeader := c.Get("Authorization")
+	if authHeader != "" {
 		tokenParts := strings.Split(authHeader, " ")
 		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
 			return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorString("missing or malformed JWT"))
 		}
 
 		tokenString = tokenParts[1]
+	} else {
+		tokenString = c.Cookies(utils.AccessTokenCookie)
+		fromCookie = true
+
+		if tokenString == "" {
+			return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorString("missing or malformed JWT"))
+		}
 	}
 
-	secret := []byte(os.Getenv("JWT_SECRET"))
+	claims, err := utils.ParseJWTToken(tokenString, utils.AccessTokenType)
 
-	token, err := jwt.Parse(tokenString, func(t *jwt.Token) (any, error) {
-		if t.Method.Alg() != jwt.GetSigningMethod("HS256").Alg() {
-			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+	if err != nil {
+		if fromCookie {
+			utils.ClearCookies(c)
 		}
-		return secret, nil
-	})
-
-	if err != nil || !token.Valid {
-		c.ClearCookie()
 		return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorString("missing or malformed JWT"))
 	}
 
-	userId := token.Claims.(jwt.MapClaims)["userId"].(string)
-	userRole := token.Claims.(jwt.MapClaims)["userRole"].(string)
-	c.Locals("userId", userId)
-	c.Locals("userRole", userRole)
+	// ParseJWTToken already checked the session id is a uuid
+	if h.revokedSessions.contains(uuid.MustParse(claims.SessionId)) {
+		if fromCookie {
+			utils.ClearCookies(c)
+		}
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorString("session has been revoked"))
+	}
+
+	if fromCookie && !utils.IsSafeMethod(c.Method()) {
+		if err := utils.VerifyCSRFToken(c.Get(utils.CSRFTokenHeader), claims); err != nil {
+			return c.Status(fiber.StatusForbidden).JSON(utils.ErrorString("missing or invalid csrf token"))
+		}
+	}
+
+	c.Locals("userId", claims.UserId)
+	c.Locals("userRole", claims.Role)
+	c.Locals("sessionId", claims.SessionId)
+	c.Locals("teamId", claims.TeamId)
+
+	// Tokens issued before roles existed don't carry one, they go by the built-in role
+	roleId, _ := uuid.Parse(claims.RoleId)
+
+	if err := h.setRolePermissions(c, roleId); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	// Every request is scoped to the team of its session before the handlers run, the repositories
+	// refuse the requests that aren't
+	if _, err := h.callerTeamId(c); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
 
 	return c.Next()
 }
@@ -3,12 +3,27 @@ package handlers
 import "github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
 
 type Handler struct {
-	validator              *Validator
-	userRepository         interfaces.IUserRepository
-	refreshTokenRepository interfaces.IRefreshTokenRepository
-	teamRepository         interfaces.ITeamRepository
-	projectRepository      interfaces.IProjectRepository
-	taskRepository         interfaces.ITaskRepository
+	validator                     *Validator
+	userRepository                interfaces.IUserRepository
+	refreshTokenRepository        interfaces.IRefreshTokenRepository
+	teamRepository                interfaces.ITeamRepository
+	projectRepository             interfaces.IProjectRepository
+	taskRepository                interfaces.ITaskRepository
+	sessionRepository             interfaces.ISessionRepository
+	passwordResetTokenRepository  interfaces.IPasswordResetTokenRepository
+	invitationRepository          interfaces.IInvitationRepository
+	mfaRepository                 interfaces.IMfaRepository
+	loginAttemptRepository        interfaces.ILoginAttemptRepository
+	personalAccessTokenRepository interfaces.IPersonalAccessTokenRepository
+	userIdentityRepository        interfaces.IUserIdentityRepository
+	revokedSessionRepository      interfaces.IRevokedSessionRepository
+	roleRepository                interfaces.IRoleRepository
+	organizationRepository        interfaces.IOrganizationRepository
+	oidcProvider                  interfaces.OIDCProvider
+	mailer                        interfaces.Mailer
+	revokedSessions               *revokedSessions
+	rolePermissions               *rolePermissions
+	adminBootstrap                *adminBootstrap
 }
 
 func NewHandler(
@@ -17,14 +32,41 @@ func NewHandler(
 	tr interfaces.ITeamRepository,
 	pr interfaces.IProjectRepository,
 	tsr interfaces.ITaskRepository,
+	sr interfaces.ISessionRepository,
+	prtr interfaces.IPasswordResetTokenRepository,
+	ir interfaces.IInvitationRepository,
+	mr interfaces.IMfaRepository,
+	lar interfaces.ILoginAttemptRepository,
+	patr interfaces.IPersonalAccessTokenRepository,
+	uir interfaces.IUserIdentityRepository,
+	rsr interfaces.IRevokedSessionRepository,
+	rr interfaces.IRoleRepository,
+	or interfaces.IOrganizationRepository,
+	op interfaces.OIDCProvider,
+	m interfaces.Mailer,
 ) *Handler {
 	v := NewValidator()
 	return &Handler{
-		validator:              v,
-		userRepository:         ur,
-		refreshTokenRepository: rtr,
-		teamRepository:         tr,
-		projectRepository:      pr,
-		taskRepository:         tsr,
+		validator:                     v,
+		userRepository:                ur,
+		refreshTokenRepository:        rtr,
+		teamRepository:                tr,
+		projectRepository:             pr,
+		taskRepository:                tsr,
+		sessionRepository:             sr,
+		passwordResetTokenRepository:  prtr,
+		invitationRepository:          ir,
+		mfaRepository:                 mr,
+		loginAttemptRepository:        lar,
+		personalAccessTokenRepository: patr,
+		userIdentityRepository:        uir,
+		revokedSessionRepository:      rsr,
+		roleRepository:                rr,
+		organizationRepository:        or,
+		oidcProvider:                  op,
+		mailer:                        m,
+		revokedSessions:               newRevokedSessions(),
+		rolePermissions:               newRolePermissions(),
+		adminBootstrap:                &adminBootstrap{},
 	}
 }
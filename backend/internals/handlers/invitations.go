@@ -0,0 +1,423 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/policy"
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const invitationTTL = time.Hour * 168 // 7 days
+
+// invitationLink points to the frontend page that reads the token and calls /invitations/accept
+func invitationLink(token string) string {
+	return frontendLink("INVITATION_URL", "http://localhost:3000/accept-invitation", token)
+}
+
+// adminTeam returns the team owned by the admin making the request that its session works in, invitations
+// are always scoped to it
+func (h *Handler) adminTeam(c *fiber.Ctx) (adminUUID uuid.UUID, exists bool, team models.Team, err error) {
+	userId := c.Locals("userId")
+
+	adminUUID, err = uuid.Parse(userId.(string))
+
+	if err != nil {
+		return uuid.Nil, false, models.Team{}, err
+	}
+
+	exists, team, err = h.ownedTeam(c, adminUUID)
+
+	return adminUUID, exists, team, err
+}
+
+func (h *Handler) sendInvitation(c *fiber.Ctx, invitation models.Invitation, team models.Team) error {
+	token, err := utils.GenerateInvitationToken(invitation)
+	if err != nil {
+		return err
+	}
+
+	return h.mailer.Send(c.Context(), interfaces.Email{
+		To:      invitation.Email,
+		Subject: fmt.Sprintf("You have been invited to %s", team.Name),
+		Body: fmt.Sprintf(
+			"Hi,\n\nYou have been invited to join the team %s as %s. Open the link below to choose your username and password:\n\n%s\n\nThe link expires on %s.\n",
+			team.Name,
+			invitation.Role,
+			invitationLink(token),
+			invitation.ExpiresAt.Format(time.RFC1123),
+		),
+	})
+}
+
+// CreateInvitation godoc
+// @Summary Invite a user
+// @Description Email an invitation to join the admin team with the given role. The invitee sets their own password from the link (Admin only)
+// @Tags Invitations
+// @Accept json
+// @Produce json
+// @Param request body interfaces.CreateInvitationPayload true "Invitation data"
+// @Success 201 {object} interfaces.InvitationResponse
+// @Failure 400 {object} utils.ErrorResponse "Validation error"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} utils.ErrorResponse "Team not found"
+// @Failure 409 {object} utils.ErrorResponse "User already exists or already invited"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /invitations [post]
+func (h *Handler) CreateInvitation(c *fiber.Ctx) error {
+
+	if err := policy.Authorize(c.Context(), policy.ActionCreate, policy.Collection(policy.ResourceInvitations)); err != nil {
+		return policy.Forbidden(c)
+	}
+
+	payload := interfaces.CreateInvitationPayload{}
+
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	err := h.validator.Validate(payload)
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
+	}
+
+	adminUUID, exists, team, err := h.adminTeam(c)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if !exists {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("team not found"))
+	}
+
+	_, err = h.userRepository.GetUserByEmail(c.Context(), payload.Email)
+
+	if err == nil {
+		return c.Status(fiber.StatusConflict).JSON(utils.ErrorString("user already exists"))
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error getting data from database"))
+	}
+
+	_, err = h.invitationRepository.GetPendingInvitationByEmail(c.Context(), team.ID, payload.Email)
+
+	if err == nil {
+		return c.Status(fiber.StatusConflict).JSON(utils.ErrorString("user already invited"))
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error getting data from database"))
+	}
+
+	now := time.Now().UTC()
+
+	invitation, err := h.invitationRepository.CreateInvitation(c.Context(), models.Invitation{
+		ID:        uuid.New(),
+		TeamID:    team.ID,
+		Email:     payload.Email,
+		Role:      payload.Role,
+		TokenID:   uuid.New(),
+		InvitedBy: adminUUID,
+		ExpiresAt: now.Add(invitationTTL),
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error creating invitation"))
+	}
+
+	// The invitation is kept, the admin can resend it
+	if err := h.sendInvitation(c, invitation, team); err != nil {
+		log.Printf("error sending invitation %s: %v", invitation.ID, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"invitation": invitation,
+	})
+}
+
+// GetInvitations godoc
+// @Summary List pending invitations
+// @Description List the invitations of the admin team that have not been accepted yet (Admin only)
+// @Tags Invitations
+// @Accept json
+// @Produce json
+// @Success 200 {object} interfaces.InvitationsListResponse
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} utils.ErrorResponse "Team not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /invitations [get]
+func (h *Handler) GetInvitations(c *fiber.Ctx) error {
+
+	if err := policy.Authorize(c.Context(), policy.ActionRead, policy.Collection(policy.ResourceInvitations)); err != nil {
+		return policy.Forbidden(c)
+	}
+
+	_, exists, team, err := h.adminTeam(c)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if !exists {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("team not found"))
+	}
+
+	invitations, err := h.invitationRepository.GetPendingInvitationsByTeam(c.Context(), team.ID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": invitations,
+	})
+}
+
+// ResendInvitation godoc
+// @Summary Resend an invitation
+// @Description Email a new invitation link with a new expiration. Links sent before stop working (Admin only)
+// @Tags Invitations
+// @Accept json
+// @Produce json
+// @Param id path string true "Invitation ID"
+// @Success 200 {object} interfaces.InvitationResponse
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} utils.ErrorResponse "Invitation not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /invitations/{id}/resend [post]
+func (h *Handler) ResendInvitation(c *fiber.Ctx) error {
+
+	if err := policy.Authorize(c.Context(), policy.ActionUpdate, policy.Collection(policy.ResourceInvitations)); err != nil {
+		return policy.Forbidden(c)
+	}
+
+	invitationId := c.Params("id")
+
+	if invitationId == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid id"))
+	}
+
+	invitationUUID, err := uuid.Parse(invitationId)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	found, invitation, team, err := h.getTeamInvitation(c, invitationUUID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if !found {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("invitation not found"))
+	}
+
+	now := time.Now().UTC()
+
+	renewed, err := h.invitationRepository.RenewInvitation(c.Context(), interfaces.RenewInvitationData{
+		ID:        invitation.ID,
+		TokenID:   uuid.New(),
+		ExpiresAt: now.Add(invitationTTL),
+		UpdatedAt: now,
+	})
+
+	// Accepted while we were renewing it
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("invitation not found"))
+	}
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if err := h.sendInvitation(c, renewed, team); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error sending invitation"))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"invitation": renewed,
+	})
+}
+
+// RevokeInvitation godoc
+// @Summary Revoke an invitation
+// @Description Delete a pending invitation, its link stops working (Admin only)
+// @Tags Invitations
+// @Accept json
+// @Produce json
+// @Param id path string true "Invitation ID"
+// @Success 200 {object} interfaces.DeleteInvitationResponse
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} utils.ErrorResponse "Invitation not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /invitations/{id} [delete]
+func (h *Handler) RevokeInvitation(c *fiber.Ctx) error {
+
+	if err := policy.Authorize(c.Context(), policy.ActionDelete, policy.Collection(policy.ResourceInvitations)); err != nil {
+		return policy.Forbidden(c)
+	}
+
+	invitationId := c.Params("id")
+
+	if invitationId == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid id"))
+	}
+
+	invitationUUID, err := uuid.Parse(invitationId)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	found, invitation, _, err := h.getTeamInvitation(c, invitationUUID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if !found {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("invitation not found"))
+	}
+
+	err = h.invitationRepository.DeleteInvitation(c.Context(), invitation.ID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"deleted": true,
+	})
+}
+
+// getTeamInvitation loads a pending invitation of the admin team, invitations of other teams are reported as not found
+func (h *Handler) getTeamInvitation(c *fiber.Ctx, invitationId uuid.UUID) (found bool, invitation models.Invitation, team models.Team, err error) {
+	_, exists, team, err := h.adminTeam(c)
+
+	if err != nil || !exists {
+		return false, models.Invitation{}, models.Team{}, err
+	}
+
+	invitation, err = h.invitationRepository.GetInvitationById(c.Context(), invitationId)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, models.Invitation{}, models.Team{}, nil
+	}
+
+	if err != nil {
+		return false, models.Invitation{}, models.Team{}, err
+	}
+
+	if invitation.TeamID != team.ID || invitation.AcceptedAt.Valid {
+		return false, models.Invitation{}, models.Team{}, nil
+	}
+
+	return true, invitation, team, nil
+}
+
+// AcceptInvitation godoc
+// @Summary Accept an invitation
+// @Description Create the invited user with the username and password of their choice and log them in. Each link can only be used once. When the team requires two-factor authentication the user gets an mfa token to set it up instead, like in the login
+// @Tags Invitations
+// @Accept json
+// @Produce json
+// @Param request body interfaces.AcceptInvitationPayload true "Invitation token and user data"
+// @Success 200 {object} interfaces.AcceptInvitationResponse
+// @Success 202 {object} interfaces.MfaLoginResponse "Two-factor authentication required"
+// @Failure 400 {object} utils.ErrorResponse "Validation error or invalid invitation"
+// @Failure 409 {object} utils.ErrorResponse "User already exists"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /invitations/accept [post]
+func (h *Handler) AcceptInvitation(c *fiber.Ctx) error {
+	payload := interfaces.AcceptInvitationPayload{}
+
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	err := h.validator.Validate(payload)
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
+	}
+
+	claims, err := utils.ParseJWTToken(payload.Token, utils.InvitationTokenType)
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid or expired invitation"))
+	}
+
+	invitationUUID := uuid.MustParse(claims.Subject)
+	tokenUUID := uuid.MustParse(claims.ID)
+
+	invitation, err := h.invitationRepository.GetInvitationById(c.Context(), invitationUUID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid or expired invitation"))
+	}
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	now := time.Now().UTC()
+
+	if invitation.AcceptedAt.Valid || invitation.TokenID != tokenUUID || now.After(invitation.ExpiresAt) {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid or expired invitation"))
+	}
+
+	_, err = h.userRepository.GetUserByEmail(c.Context(), invitation.Email)
+
+	if err == nil {
+		return c.Status(fiber.StatusConflict).JSON(utils.ErrorString("user already exists"))
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error getting data from database"))
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(payload.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	newUser, accepted, err := h.invitationRepository.AcceptInvitation(c.Context(), interfaces.AcceptInvitationData{
+		InvitationID: invitation.ID,
+		TokenID:      tokenUUID,
+		Username:     payload.Username,
+		Password:     string(hashedPassword),
+		AcceptedAt:   now,
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error accepting invitation"))
+	}
+
+	// Another request used the link first or the invitation was resent
+	if !accepted {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid or expired invitation"))
+	}
+
+	// Logged in like any other login, so a team that requires 2FA asks the new user to set it up first
+	return h.completeLogin(c, newUser, "")
+}
@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/policy"
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// loginAttemptsWindow is how long failures are remembered, it's also how long a lockout lasts
+const loginAttemptsWindow = 15 * time.Minute
+
+const invalidCredentialsMessage = "Invalid email or password"
+
+// loginThrottle lets freeAttempts failures through, then makes the caller wait twice as long after
+// every failure (up to maxDelay) and locks the key for the whole window once it reaches lockAfter
+type loginThrottle struct {
+	freeAttempts int32
+	maxDelay     time.Duration
+	lockAfter    int32
+}
+
+var (
+	accountLoginThrottle = loginThrottle{
+		freeAttempts: 3,
+		maxDelay:     30 * time.Second,
+		lockAfter:    10,
+	}
+	// Higher limits so users behind the same NAT don't lock each other out
+	ipLoginThrottle = loginThrottle{
+		freeAttempts: 10,
+		maxDelay:     30 * time.Second,
+		lockAfter:    50,
+	}
+	// Invalid two-factor codes are counted per user instead of per email
+	accountMfaThrottle = loginThrottle{
+		freeAttempts: 3,
+		maxDelay:     30 * time.Second,
+		lockAfter:    10,
+	}
+	ipMfaThrottle = ipLoginThrottle
+	// Password reset requests are throttled with the same counters, every request counts
+	accountPasswordResetThrottle = loginThrottle{
+		freeAttempts: 3,
+		maxDelay:     time.Minute,
+		lockAfter:    5,
+	}
+	ipPasswordResetThrottle = loginThrottle{
+		freeAttempts: 10,
+		maxDelay:     time.Minute,
+		lockAfter:    30,
+	}
+)
+
+// dummyPasswordHash is compared against when the email doesn't exist, so both cases take as long
+var dummyPasswordHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("not-a-real-password"), bcrypt.DefaultCost)
+	return hash
+})
+
+func (t loginThrottle) retryAfter(attempt models.LoginAttempt, now time.Time) time.Duration {
+	var wait time.Duration
+
+	switch {
+	case attempt.FailedCount >= t.lockAfter:
+		wait = loginAttemptsWindow
+	case attempt.FailedCount > t.freeAttempts:
+		exponent := float64(attempt.FailedCount - t.freeAttempts - 1)
+		wait = time.Duration(math.Min(float64(time.Second)*math.Pow(2, exponent), float64(t.maxDelay)))
+	default:
+		return 0
+	}
+
+	return max(attempt.LastFailedAt.Add(wait).Sub(now), 0)
+}
+
+// loginRetryAfter is how long the caller has to wait before trying again, 0 when it can try now
+func loginRetryAfter(attempts []models.LoginAttempt, now time.Time) time.Duration {
+	return throttleRetryAfter(attempts, accountLoginThrottle, ipLoginThrottle, now)
+}
+
+// throttleRetryAfter applies accountThrottle to the account counter and ipThrottle to the ip one
+func throttleRetryAfter(attempts []models.LoginAttempt, accountThrottle loginThrottle, ipThrottle loginThrottle, now time.Time) time.Duration {
+	var retryAfter time.Duration
+
+	for _, attempt := range attempts {
+		throttle := accountThrottle
+		if attempt.Scope == models.LoginAttemptScopeIp {
+			throttle = ipThrottle
+		}
+
+		retryAfter = max(retryAfter, throttle.retryAfter(attempt, now))
+	}
+
+	return retryAfter
+}
+
+// loginAccountKey is the identifier of the account counter. It's the email and not the user id
+// so unknown emails are throttled exactly like existing ones
+func loginAccountKey(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// attemptsKey keeps the counters of key for purpose (password resets, two-factor codes) apart from its
+// login counters
+func attemptsKey(purpose string, key string) string {
+	return purpose + ":" + key
+}
+
+func tooManyLoginAttempts(c *fiber.Ctx, retryAfter time.Duration) error {
+	return tooManyRequests(c, retryAfter, "Too many failed login attempts, try again later")
+}
+
+func tooManyRequests(c *fiber.Ctx, retryAfter time.Duration, message string) error {
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+
+	return c.Status(fiber.StatusTooManyRequests).JSON(utils.ErrorString(message))
+}
+
+func (h *Handler) failedLogin(c *fiber.Ctx, accountKey string, now time.Time) error {
+	_, err := h.loginAttemptRepository.RecordFailedLogin(c.Context(), interfaces.RecordFailedLoginData{
+		AccountKey:  accountKey,
+		IpAddress:   c.IP(),
+		FailedAt:    now,
+		WindowStart: now.Add(-loginAttemptsWindow),
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error saving login attempt"))
+	}
+
+	return c.Status(fiber.StatusConflict).JSON(utils.ErrorString(invalidCredentialsMessage))
+}
+
+// UnlockUser godoc
+// @Summary Unlock a user
+// @Description Clear the failed login attempts of a user of the admin's team so they can log in again right away (Admin only)
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} interfaces.UnlockUserResponse
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} utils.ErrorResponse "User not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /users/{id}/unlock [post]
+func (h *Handler) UnlockUser(c *fiber.Ctx) error {
+	if err := policy.Authorize(c.Context(), policy.ActionUnlock, policy.Collection(policy.ResourceUsers)); err != nil {
+		return policy.Forbidden(c)
+	}
+
+	targetId := c.Params("id")
+
+	if targetId == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid id"))
+	}
+
+	targetUUID, err := uuid.Parse(targetId)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	targetUser, err := h.authorizeUser(c, policy.ActionUnlock, targetUUID)
+
+	if err != nil {
+		return policy.Deny(c, err, "user not found")
+	}
+
+	err = h.loginAttemptRepository.ClearLoginAttempts(c.Context(), interfaces.LoginAttemptKey{
+		Scope:      models.LoginAttemptScopeAccount,
+		Identifier: loginAccountKey(targetUser.Email),
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"unlocked": true,
+	})
+}
@@ -0,0 +1,734 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const recoveryCodesCount = 10
+
+// mfaPendingTokenTTL is how long the user has to send the code after the login
+const mfaPendingTokenTTL = 5 * time.Minute
+
+var (
+	errMfaAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	errMfaNotEnabled     = errors.New("two-factor authentication is not enabled")
+	errMfaNotEnrolling   = errors.New("two-factor authentication enrollment not started")
+	errInvalidMfaCode    = errors.New("invalid two-factor authentication code")
+	errInvalidMfaToken   = errors.New("invalid or expired mfa token")
+)
+
+// mfaThrottledError is returned while the invalid codes of the user are throttled
+type mfaThrottledError struct {
+	retryAfter time.Duration
+}
+
+func (e *mfaThrottledError) Error() string {
+	return "Too many invalid two-factor authentication codes, try again later"
+}
+
+func mfaErrorResponse(c *fiber.Ctx, err error) error {
+	var throttled *mfaThrottledError
+
+	switch {
+	case errors.As(err, &throttled):
+		return tooManyRequests(c, throttled.retryAfter, throttled.Error())
+	case errors.Is(err, errMfaAlreadyEnabled):
+		return c.Status(fiber.StatusConflict).JSON(utils.ErrorString(err.Error()))
+	case errors.Is(err, errMfaNotEnabled), errors.Is(err, errMfaNotEnrolling):
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString(err.Error()))
+	case errors.Is(err, errInvalidMfaCode), errors.Is(err, errInvalidMfaToken):
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorString(err.Error()))
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+}
+
+// totpIssuer is the name authenticator apps show next to the code
+func totpIssuer() string {
+	issuer := os.Getenv("TOTP_ISSUER")
+	if issuer == "" {
+		issuer = "challenge-fs-senior"
+	}
+
+	return issuer
+}
+
+// mfaStatus tells if the user has two-factor authentication enabled and if their team requires it
+func (h *Handler) mfaStatus(c *fiber.Ctx, user models.User) (enabled bool, required bool, err error) {
+	totp, err := h.mfaRepository.GetUserTotp(c.Context(), user.ID)
+
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return false, false, err
+	}
+
+	if err == nil && totp.EnabledAt.Valid {
+		return true, true, nil
+	}
+
+	// Users log in to their default team
+	teamId, err := h.userTeamId(c, user)
+
+	if err != nil {
+		return false, false, err
+	}
+
+	required, err = h.teamRequiresMfa(c, teamId)
+
+	return false, required, err
+}
+
+// teamRequiresMfa tells if a team requires two-factor authentication from its members
+func (h *Handler) teamRequiresMfa(c *fiber.Ctx, teamId uuid.UUID) (bool, error) {
+	if teamId == uuid.Nil {
+		return false, nil
+	}
+
+	team, err := h.teamRepository.GetTeamById(c.Context(), teamId)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return team.RequireMfa, nil
+}
+
+// newMfaToken stores and signs the mfa token of a login waiting for the second factor
+func (h *Handler) newMfaToken(c *fiber.Ctx, user models.User) (string, error) {
+	now := time.Now().UTC()
+
+	pending := models.MfaPendingToken{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		ExpiresAt: now.Add(mfaPendingTokenTTL),
+		CreatedAt: now,
+	}
+
+	if err := h.mfaRepository.CreateMfaPendingToken(c.Context(), pending); err != nil {
+		return "", err
+	}
+
+	return utils.GenerateMfaPendingToken(pending)
+}
+
+// parseMfaToken returns the user the mfa pending token was issued to, unless they were deactivated since,
+// and the id of the token
+func (h *Handler) parseMfaToken(c *fiber.Ctx, mfaToken string) (models.User, uuid.UUID, error) {
+	claims, err := utils.ParseJWTToken(mfaToken, utils.MfaPendingTokenType)
+
+	if err != nil {
+		return models.User{}, uuid.Nil, err
+	}
+
+	tokenId, err := uuid.Parse(claims.ID)
+
+	if err != nil {
+		return models.User{}, uuid.Nil, utils.ErrInvalidTokenClaims
+	}
+
+	user, err := h.userRepository.GetUserById(c.Context(), uuid.MustParse(claims.UserId))
+
+	if err != nil {
+		return models.User{}, uuid.Nil, err
+	}
+
+	if user.DeactivatedAt != nil {
+		return models.User{}, uuid.Nil, errUserDeactivated
+	}
+
+	return user, tokenId, nil
+}
+
+// useMfaToken exchanges the mfa token for a session, which only works once
+func (h *Handler) useMfaToken(c *fiber.Ctx, tokenId uuid.UUID, userId uuid.UUID) error {
+	used, err := h.mfaRepository.UseMfaPendingToken(c.Context(), interfaces.UseMfaPendingTokenData{
+		ID:     tokenId,
+		UserID: userId,
+		UsedAt: time.Now().UTC(),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if !used {
+		return errInvalidMfaToken
+	}
+
+	return nil
+}
+
+func (h *Handler) startTotpEnrollment(c *fiber.Ctx, user models.User) (interfaces.TotpEnrollmentResponse, error) {
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return interfaces.TotpEnrollmentResponse{}, err
+	}
+
+	_, started, err := h.mfaRepository.StartTotpEnrollment(c.Context(), models.UserTotp{
+		UserID:    user.ID,
+		Secret:    secret,
+		CreatedAt: time.Now().UTC(),
+	})
+
+	if err != nil {
+		return interfaces.TotpEnrollmentResponse{}, err
+	}
+
+	if !started {
+		return interfaces.TotpEnrollmentResponse{}, errMfaAlreadyEnabled
+	}
+
+	uri := utils.TOTPProvisioningURI(totpIssuer(), user.Email, secret)
+
+	return interfaces.TotpEnrollmentResponse{
+		Secret:          secret,
+		ProvisioningURI: uri,
+		QRPayload:       uri,
+	}, nil
+}
+
+// confirmTotpEnrollment enables two-factor authentication when the code matches the pending secret
+// and returns the recovery codes, which are only shown this time
+func (h *Handler) confirmTotpEnrollment(c *fiber.Ctx, userId uuid.UUID, code string) ([]string, error) {
+	totp, err := h.mfaRepository.GetUserTotp(c.Context(), userId)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errMfaNotEnrolling
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if totp.EnabledAt.Valid {
+		return nil, errMfaAlreadyEnabled
+	}
+
+	now := time.Now().UTC()
+
+	step, ok := utils.ValidateTOTP(totp.Secret, code, now)
+	if !ok {
+		return nil, errInvalidMfaCode
+	}
+
+	codes, recoveryCodes, err := newRecoveryCodes(userId, now)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled, err := h.mfaRepository.EnableTotp(c.Context(), interfaces.EnableTotpData{
+		UserID:        userId,
+		Step:          step,
+		EnabledAt:     now,
+		RecoveryCodes: recoveryCodes,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Another request confirmed it first
+	if !enabled {
+		return nil, errMfaAlreadyEnabled
+	}
+
+	return codes, nil
+}
+
+// verifyMfaCode accepts a code from the authenticator app or an unused recovery code, each of them works once.
+// Invalid codes are counted per user and ip address and throttled like failed logins
+func (h *Handler) verifyMfaCode(c *fiber.Ctx, userId uuid.UUID, code string) error {
+	now := time.Now().UTC()
+	accountKey := attemptsKey("mfa", userId.String())
+	ipKey := attemptsKey("mfa", c.IP())
+
+	attempts, err := h.loginAttemptRepository.GetLoginAttempts(c.Context(), interfaces.GetLoginAttemptsData{
+		AccountKey: accountKey,
+		IpAddress:  ipKey,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if retryAfter := throttleRetryAfter(attempts, accountMfaThrottle, ipMfaThrottle, now); retryAfter > 0 {
+		return &mfaThrottledError{retryAfter: retryAfter}
+	}
+
+	totp, err := h.mfaRepository.GetUserTotp(c.Context(), userId)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return errMfaNotEnabled
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !totp.EnabledAt.Valid {
+		return errMfaNotEnabled
+	}
+
+	var used bool
+
+	if step, ok := utils.ValidateTOTP(totp.Secret, code, now); ok {
+		used, err = h.mfaRepository.UseTotpStep(c.Context(), interfaces.UseTotpStepData{
+			UserID: userId,
+			Step:   step,
+			UsedAt: now,
+		})
+	} else {
+		used, err = h.mfaRepository.UseRecoveryCode(c.Context(), interfaces.UseRecoveryCodeData{
+			UserID:   userId,
+			CodeHash: utils.HashRecoveryCode(code),
+			UsedAt:   now,
+		})
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !used {
+		_, err = h.loginAttemptRepository.RecordFailedLogin(c.Context(), interfaces.RecordFailedLoginData{
+			AccountKey:  accountKey,
+			IpAddress:   ipKey,
+			FailedAt:    now,
+			WindowStart: now.Add(-loginAttemptsWindow),
+		})
+
+		if err != nil {
+			return err
+		}
+
+		return errInvalidMfaCode
+	}
+
+	return h.loginAttemptRepository.ClearLoginAttempts(c.Context(), interfaces.LoginAttemptKey{
+		Scope:      models.LoginAttemptScopeAccount,
+		Identifier: accountKey,
+	})
+}
+
+func newRecoveryCodes(userId uuid.UUID, createdAt time.Time) ([]string, []models.MfaRecoveryCode, error) {
+	codes, hashes, err := utils.GenerateRecoveryCodes(recoveryCodesCount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	recoveryCodes := make([]models.MfaRecoveryCode, 0, len(hashes))
+	for _, hash := range hashes {
+		recoveryCodes = append(recoveryCodes, models.MfaRecoveryCode{
+			ID:        uuid.New(),
+			UserID:    userId,
+			CodeHash:  hash,
+			CreatedAt: createdAt,
+		})
+	}
+
+	return codes, recoveryCodes, nil
+}
+
+// VerifyMfa godoc
+// @Summary Complete a two-factor login
+// @Description Exchange the mfa token returned by the login and a code from the authenticator app or a recovery code for the access and refresh tokens. The mfa token can only be exchanged once and invalid codes are throttled per user
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body interfaces.VerifyMfaRequest true "Mfa token and code"
+// @Success 200 {object} interfaces.LoginResponse
+// @Failure 400 {object} utils.ErrorResponse "Validation error or two-factor authentication not enabled"
+// @Failure 401 {object} utils.ErrorResponse "Invalid or expired mfa token or invalid code"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /auth/mfa/verify [post]
+func (h *Handler) VerifyMfa(c *fiber.Ctx) error {
+	payload := struct {
+		MfaToken string `json:"mfaToken" validate:"required"`
+		Code     string `json:"code" validate:"required"`
+		Label    string `json:"label"`
+	}{}
+
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	err := h.validator.Validate(payload)
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
+	}
+
+	user, tokenId, err := h.parseMfaToken(c, payload.MfaToken)
+
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorString(errInvalidMfaToken.Error()))
+	}
+
+	if err := h.verifyMfaCode(c, user.ID, payload.Code); err != nil {
+		return mfaErrorResponse(c, err)
+	}
+
+	if err := h.useMfaToken(c, tokenId, user.ID); err != nil {
+		return mfaErrorResponse(c, err)
+	}
+
+	token, refreshToken, err := h.startSession(c, user, payload.Label)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error creating session"))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"accessToken":  token,
+		"refreshToken": refreshToken,
+		"user":         user,
+	})
+}
+
+// EnrollMfa godoc
+// @Summary Start a required two-factor enrollment
+// @Description Generate a TOTP secret for a user whose team requires two-factor authentication, using the mfa token returned by the login
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body interfaces.MfaTokenRequest true "Mfa token"
+// @Success 200 {object} interfaces.TotpEnrollmentResponse
+// @Failure 400 {object} utils.ErrorResponse "Validation error"
+// @Failure 401 {object} utils.ErrorResponse "Invalid or expired mfa token"
+// @Failure 409 {object} utils.ErrorResponse "Two-factor authentication already enabled"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /auth/mfa/enroll [post]
+func (h *Handler) EnrollMfa(c *fiber.Ctx) error {
+	payload := struct {
+		MfaToken string `json:"mfaToken" validate:"required"`
+	}{}
+
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	err := h.validator.Validate(payload)
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
+	}
+
+	user, _, err := h.parseMfaToken(c, payload.MfaToken)
+
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorString(errInvalidMfaToken.Error()))
+	}
+
+	enrollment, err := h.startTotpEnrollment(c, user)
+
+	if err != nil {
+		return mfaErrorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(enrollment)
+}
+
+// ConfirmMfaEnrollment godoc
+// @Summary Confirm a required two-factor enrollment
+// @Description Enable two-factor authentication with a code from the authenticator app and log in. The recovery codes are only returned once
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body interfaces.VerifyMfaRequest true "Mfa token and code"
+// @Success 200 {object} interfaces.ConfirmMfaEnrollmentResponse
+// @Failure 400 {object} utils.ErrorResponse "Validation error or enrollment not started"
+// @Failure 401 {object} utils.ErrorResponse "Invalid or expired mfa token or invalid code"
+// @Failure 409 {object} utils.ErrorResponse "Two-factor authentication already enabled"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /auth/mfa/enroll/confirm [post]
+func (h *Handler) ConfirmMfaEnrollment(c *fiber.Ctx) error {
+	payload := struct {
+		MfaToken string `json:"mfaToken" validate:"required"`
+		Code     string `json:"code" validate:"required"`
+		Label    string `json:"label"`
+	}{}
+
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	err := h.validator.Validate(payload)
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
+	}
+
+	user, tokenId, err := h.parseMfaToken(c, payload.MfaToken)
+
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorString(errInvalidMfaToken.Error()))
+	}
+
+	recoveryCodes, err := h.confirmTotpEnrollment(c, user.ID, payload.Code)
+
+	if err != nil {
+		return mfaErrorResponse(c, err)
+	}
+
+	if err := h.useMfaToken(c, tokenId, user.ID); err != nil {
+		return mfaErrorResponse(c, err)
+	}
+
+	token, refreshToken, err := h.startSession(c, user, payload.Label)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error creating session"))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"accessToken":   token,
+		"refreshToken":  refreshToken,
+		"user":          user,
+		"recoveryCodes": recoveryCodes,
+	})
+}
+
+// StartTotp godoc
+// @Summary Start two-factor enrollment
+// @Description Generate a new TOTP secret for the logged user. It has to be confirmed with a code before it is enabled
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Success 200 {object} interfaces.TotpEnrollmentResponse
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized"
+// @Failure 404 {object} utils.ErrorResponse "User not found"
+// @Failure 409 {object} utils.ErrorResponse "Two-factor authentication already enabled"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /auth/mfa/totp [post]
+func (h *Handler) StartTotp(c *fiber.Ctx) error {
+	userId := c.Locals("userId")
+
+	userUUID, err := uuid.Parse(userId.(string))
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	user, err := h.userRepository.GetUserById(c.Context(), userUUID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("user not found"))
+	}
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	enrollment, err := h.startTotpEnrollment(c, user)
+
+	if err != nil {
+		return mfaErrorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(enrollment)
+}
+
+// ConfirmTotp godoc
+// @Summary Confirm two-factor enrollment
+// @Description Enable two-factor authentication with a code from the authenticator app. The recovery codes are only returned once
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body interfaces.MfaCodeRequest true "Code from the authenticator app"
+// @Success 200 {object} interfaces.RecoveryCodesResponse
+// @Failure 400 {object} utils.ErrorResponse "Validation error or enrollment not started"
+// @Failure 401 {object} utils.ErrorResponse "Invalid code"
+// @Failure 409 {object} utils.ErrorResponse "Two-factor authentication already enabled"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /auth/mfa/totp/confirm [post]
+func (h *Handler) ConfirmTotp(c *fiber.Ctx) error {
+	userId := c.Locals("userId")
+
+	payload := struct {
+		Code string `json:"code" validate:"required"`
+	}{}
+
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	err := h.validator.Validate(payload)
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
+	}
+
+	userUUID, err := uuid.Parse(userId.(string))
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	recoveryCodes, err := h.confirmTotpEnrollment(c, userUUID, payload.Code)
+
+	if err != nil {
+		return mfaErrorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"recoveryCodes": recoveryCodes,
+	})
+}
+
+// DisableTotp godoc
+// @Summary Disable two-factor authentication
+// @Description Disable two-factor authentication of the logged user with a current code or a recovery code. Not allowed when the team of the session or the default team of the user requires it
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body interfaces.MfaCodeRequest true "Code from the authenticator app or recovery code"
+// @Success 200 {object} interfaces.DisableMfaResponse
+// @Failure 400 {object} utils.ErrorResponse "Validation error or two-factor authentication not enabled"
+// @Failure 401 {object} utils.ErrorResponse "Invalid code"
+// @Failure 403 {object} utils.ErrorResponse "Required by the team"
+// @Failure 404 {object} utils.ErrorResponse "User not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /auth/mfa/totp [delete]
+func (h *Handler) DisableTotp(c *fiber.Ctx) error {
+	userId := c.Locals("userId")
+
+	payload := struct {
+		Code string `json:"code" validate:"required"`
+	}{}
+
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	err := h.validator.Validate(payload)
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
+	}
+
+	userUUID, err := uuid.Parse(userId.(string))
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	user, err := h.userRepository.GetUserById(c.Context(), userUUID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("user not found"))
+	}
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	// The session can work in another team than the default one, both have to let the user go without it
+	sessionTeamId, err := h.callerTeamId(c)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	defaultTeamId, err := h.userTeamId(c, user)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	required, err := h.teamRequiresMfa(c, sessionTeamId)
+
+	if err == nil && !required && defaultTeamId != sessionTeamId {
+		required, err = h.teamRequiresMfa(c, defaultTeamId)
+	}
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if required {
+		return c.Status(fiber.StatusForbidden).JSON(utils.ErrorString("two-factor authentication is required by your team"))
+	}
+
+	if err := h.verifyMfaCode(c, userUUID, payload.Code); err != nil {
+		return mfaErrorResponse(c, err)
+	}
+
+	err = h.mfaRepository.DisableTotp(c.Context(), userUUID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"disabled": true,
+	})
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary Regenerate recovery codes
+// @Description Replace every recovery code of the logged user, a current code or an unused recovery code is required
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body interfaces.MfaCodeRequest true "Code from the authenticator app or recovery code"
+// @Success 200 {object} interfaces.RecoveryCodesResponse
+// @Failure 400 {object} utils.ErrorResponse "Validation error or two-factor authentication not enabled"
+// @Failure 401 {object} utils.ErrorResponse "Invalid code"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /auth/mfa/recovery-codes [post]
+func (h *Handler) RegenerateRecoveryCodes(c *fiber.Ctx) error {
+	userId := c.Locals("userId")
+
+	payload := struct {
+		Code string `json:"code" validate:"required"`
+	}{}
+
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	err := h.validator.Validate(payload)
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
+	}
+
+	userUUID, err := uuid.Parse(userId.(string))
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if err := h.verifyMfaCode(c, userUUID, payload.Code); err != nil {
+		return mfaErrorResponse(c, err)
+	}
+
+	codes, recoveryCodes, err := newRecoveryCodes(userUUID, time.Now().UTC())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	err = h.mfaRepository.ReplaceRecoveryCodes(c.Context(), userUUID, recoveryCodes)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"recoveryCodes": codes,
+	})
+}
@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// oidcProvisioning is the team and role given to users created on their first single sign-on,
+// OIDC_TEAM_ID empty means only existing users can sign in
+func oidcProvisioning() (teamId uuid.UUID, role models.Userroles, err error) {
+	team := os.Getenv("OIDC_TEAM_ID")
+	if team == "" {
+		return uuid.Nil, "", nil
+	}
+
+	teamId, err = uuid.Parse(team)
+	if err != nil {
+		return uuid.Nil, "", fmt.Errorf("invalid OIDC_TEAM_ID env: %w", err)
+	}
+
+	role = models.Userroles(os.Getenv("OIDC_DEFAULT_ROLE"))
+
+	switch role {
+	case "":
+		role = models.UserrolesMember
+	case models.UserrolesManager, models.UserrolesMember:
+	default:
+		return uuid.Nil, "", fmt.Errorf("invalid OIDC_DEFAULT_ROLE env %q", role)
+	}
+
+	return teamId, role, nil
+}
+
+// OIDCAuthorize godoc
+// @Summary Start single sign-on
+// @Description Get the identity provider url to send the user to, with PKCE. The state token has to be kept by the client and sent back to /auth/oidc/callback together with the code and state the identity provider redirects with, it expires in 10 minutes
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Success 200 {object} interfaces.OIDCAuthorizeResponse
+// @Failure 404 {object} utils.ErrorResponse "Single sign-on is not configured"
+// @Failure 502 {object} utils.ErrorResponse "Identity provider unavailable"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /auth/oidc/authorize [get]
+func (h *Handler) OIDCAuthorize(c *fiber.Ctx) error {
+	if h.oidcProvider == nil {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("single sign-on is not configured"))
+	}
+
+	state, _, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	nonce, _, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	codeVerifier, _, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	stateToken, err := utils.GenerateOIDCStateToken(state, nonce, codeVerifier)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	authorizationURL, err := h.oidcProvider.AuthorizationURL(c.Context(), interfaces.OIDCAuthorizationData{
+		State:         state,
+		Nonce:         nonce,
+		CodeChallenge: utils.PKCECodeChallenge(codeVerifier),
+	})
+
+	if err != nil {
+		log.Printf("error contacting the identity provider: %v", err)
+		return c.Status(fiber.StatusBadGateway).JSON(utils.ErrorString("Error contacting the identity provider"))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"authorizationUrl": authorizationURL,
+		"stateToken":       stateToken,
+	})
+}
+
+// OIDCCallback godoc
+// @Summary Finish single sign-on
+// @Description Exchange the code the identity provider redirected with and sign the user in. The identity is matched to the user who signed in with it before, then to the user with the same verified email, and otherwise a user is created in the configured team when there is one. The response is the same as /auth/login
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body interfaces.OIDCCallbackRequest true "Code and state from the identity provider and the state token from /auth/oidc/authorize"
+// @Success 200 {object} interfaces.LoginResponse
+// @Success 202 {object} interfaces.MfaLoginResponse "Two-factor authentication required"
+// @Failure 400 {object} utils.ErrorResponse "Validation error or invalid state"
+// @Failure 401 {object} utils.ErrorResponse "The identity provider didn't accept the code or returned an invalid id token"
+// @Failure 403 {object} utils.ErrorResponse "No verified email or no account for the identity"
+// @Failure 404 {object} utils.ErrorResponse "Single sign-on is not configured"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /auth/oidc/callback [post]
+func (h *Handler) OIDCCallback(c *fiber.Ctx) error {
+	if h.oidcProvider == nil {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("single sign-on is not configured"))
+	}
+
+	payload := interfaces.OIDCCallbackRequest{}
+
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	err := h.validator.Validate(payload)
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
+	}
+
+	// The state has to come back to the client that started the sign-on, otherwise someone could
+	// get a user signed in to the attacker's account
+	stateClaims, err := utils.ParseOIDCStateToken(payload.StateToken)
+
+	if err != nil || subtle.ConstantTimeCompare([]byte(stateClaims.State), []byte(payload.State)) != 1 {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid or expired single sign-on state"))
+	}
+
+	identity, err := h.oidcProvider.Exchange(c.Context(), interfaces.OIDCExchangeData{
+		Code:         payload.Code,
+		CodeVerifier: stateClaims.CodeVerifier,
+		Nonce:        stateClaims.Nonce,
+	})
+
+	if err != nil {
+		log.Printf("error finishing single sign-on: %v", err)
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorString("single sign-on failed"))
+	}
+
+	return h.signInWithIdentity(c, identity, payload.Label)
+}
+
+// signInWithIdentity finds or creates the user of a verified identity and logs them in
+func (h *Handler) signInWithIdentity(c *fiber.Ctx, identity interfaces.OIDCIdentity, label string) error {
+	linkedIdentity, err := h.userIdentityRepository.GetUserIdentity(c.Context(), interfaces.GetUserIdentityData{
+		Issuer:  identity.Issuer,
+		Subject: identity.Subject,
+	})
+
+	if err == nil {
+		user, err := h.userRepository.GetUserById(c.Context(), linkedIdentity.UserID)
+
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error getting data from database"))
+		}
+
+		return h.completeLogin(c, user, label)
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error getting data from database"))
+	}
+
+	// Accounts are only matched or created from emails the identity provider vouches for
+	if identity.Email == "" || !identity.EmailVerified {
+		return c.Status(fiber.StatusForbidden).JSON(utils.ErrorString("the identity provider didn't share a verified email"))
+	}
+
+	now := time.Now().UTC()
+
+	newIdentity := models.UserIdentity{
+		ID:        uuid.New(),
+		Issuer:    identity.Issuer,
+		Subject:   identity.Subject,
+		Email:     identity.Email,
+		CreatedAt: now,
+	}
+
+	existingUser, err := h.userRepository.GetUserByEmail(c.Context(), identity.Email)
+
+	if err == nil {
+		newIdentity.UserID = existingUser.ID
+
+		err = h.userIdentityRepository.CreateUserIdentity(c.Context(), newIdentity)
+
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error linking identity"))
+		}
+
+		return h.completeLogin(c, existingUser, label)
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error getting data from database"))
+	}
+
+	teamId, role, err := oidcProvisioning()
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if teamId == uuid.Nil {
+		return c.Status(fiber.StatusForbidden).JSON(utils.ErrorString("there is no account for this identity"))
+	}
+
+	// Users created here sign in through the identity provider, nobody knows this password
+	// until they set one with /auth/forgot-password
+	randomPassword, _, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(randomPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	username := identity.Name
+	if username == "" {
+		username, _, _ = strings.Cut(identity.Email, "@")
+	}
+
+	newUser, err := h.userIdentityRepository.ProvisionUser(c.Context(), interfaces.ProvisionUserData{
+		User: models.User{
+			CreatedAt: now,
+			UpdatedAt: now,
+			Username:  username,
+			Password:  string(hashedPassword),
+			Email:     identity.Email,
+			Role:      role,
+			TeamId:    teamId,
+		},
+		Identity: newIdentity,
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error creating user"))
+	}
+
+	return h.completeLogin(c, newUser, label)
+}
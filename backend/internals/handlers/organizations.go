@@ -0,0 +1,509 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/policy"
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// authorizeOrganization loads an organization and checks the action on it, organizations that don't exist
+// or the user doesn't administer are policy.ErrNotFound
+func (h *Handler) authorizeOrganization(c *fiber.Ctx, action policy.Action, organizationUUID uuid.UUID) (models.Organization, error) {
+	organization, err := h.organizationRepository.GetOrganizationById(c.Context(), organizationUUID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Organization{}, policy.ErrNotFound
+	}
+
+	if err != nil {
+		return models.Organization{}, err
+	}
+
+	if err := h.authorize(c, action, policy.Organization(organization)); err != nil {
+		return models.Organization{}, err
+	}
+
+	return organization, nil
+}
+
+// CreateOrganization godoc
+// @Summary Create an organization
+// @Description Create an organization administered by the current user, its teams are created with organizationId (Admin only)
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Param request body interfaces.CreateOrganizationRequest true "Organization data"
+// @Success 201 {object} interfaces.OrganizationResponse
+// @Failure 400 {object} utils.ErrorResponse "Validation error"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /organizations [post]
+func (h *Handler) CreateOrganization(c *fiber.Ctx) error {
+	userUUID, err := uuid.Parse(c.Locals("userId").(string))
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if err := policy.Authorize(c.Context(), policy.ActionCreate, policy.Collection(policy.ResourceOrganizations)); err != nil {
+		return policy.Forbidden(c)
+	}
+
+	payload := interfaces.CreateOrganizationRequest{}
+
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	err = h.validator.Validate(payload)
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
+	}
+
+	organization, err := h.organizationRepository.CreateOrganization(c.Context(), interfaces.CreateOrganizationData{
+		Name:      payload.Name,
+		AdminID:   userUUID,
+		CreatedAt: time.Now().UTC(),
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"organization": organization,
+	})
+}
+
+// GetOrganizations godoc
+// @Summary Get the organizations of the user
+// @Description List the organizations the current user administers (Admin only)
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Success 200 {object} interfaces.OrganizationsListResponse
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /organizations [get]
+func (h *Handler) GetOrganizations(c *fiber.Ctx) error {
+	userUUID, err := uuid.Parse(c.Locals("userId").(string))
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	organizations, err := h.organizationRepository.GetUserOrganizations(c.Context(), userUUID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": organizations,
+	})
+}
+
+// organizationParam is the organization of the id of the path, checking the action on it. It writes the
+// response when it fails
+func (h *Handler) organizationParam(c *fiber.Ctx, action policy.Action) (models.Organization, bool, error) {
+	organizationId := c.Params("id")
+
+	if organizationId == "" {
+		return models.Organization{}, false, c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid id"))
+	}
+
+	organizationUUID, err := uuid.Parse(organizationId)
+
+	if err != nil {
+		return models.Organization{}, false, c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	organization, err := h.authorizeOrganization(c, action, organizationUUID)
+
+	if err != nil {
+		return models.Organization{}, false, policy.Deny(c, err, "organization not found")
+	}
+
+	return organization, true, nil
+}
+
+// GetOrganization godoc
+// @Summary Get an organization
+// @Description Get an organization the current user administers by ID (Admin only, must administer the organization)
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Success 200 {object} interfaces.OrganizationResponse
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} utils.ErrorResponse "Organization not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /organizations/{id} [get]
+func (h *Handler) GetOrganization(c *fiber.Ctx) error {
+	organization, ok, err := h.organizationParam(c, policy.ActionRead)
+
+	if !ok {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"organization": organization,
+	})
+}
+
+// UpdateOrganization godoc
+// @Summary Update the settings of an organization
+// @Description Rename an organization or require two-factor authentication in all its teams. Requiring it turns it on in every team of the organization and logs out their sessions without it, the teams can't turn it off while the organization requires it (Admin only, must administer the organization)
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Param request body interfaces.UpdateOrganizationRequest true "Organization settings"
+// @Success 200 {object} interfaces.OrganizationResponse
+// @Failure 400 {object} utils.ErrorResponse "Validation error"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} utils.ErrorResponse "Organization not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /organizations/{id} [patch]
+func (h *Handler) UpdateOrganization(c *fiber.Ctx) error {
+	organization, ok, err := h.organizationParam(c, policy.ActionUpdate)
+
+	if !ok {
+		return err
+	}
+
+	payload := interfaces.UpdateOrganizationRequest{}
+
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	err = h.validator.Validate(payload)
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
+	}
+
+	data := interfaces.UpdateOrganizationData{
+		ID:         organization.ID,
+		Name:       organization.Name,
+		RequireMfa: organization.RequireMfa,
+		UpdatedAt:  time.Now().UTC(),
+	}
+
+	if payload.Name != nil {
+		data.Name = *payload.Name
+	}
+
+	if payload.RequireMfa != nil {
+		data.RequireMfa = *payload.RequireMfa
+	}
+
+	organization, err = h.organizationRepository.UpdateOrganization(c.Context(), data)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if organization.RequireMfa {
+		h.syncRevokedSessionsAfter(c)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"organization": organization,
+	})
+}
+
+// GetOrganizationTeams godoc
+// @Summary List the teams of an organization
+// @Description List every team of an organization, its admins manage all of them (Admin only, must administer the organization)
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Success 200 {object} interfaces.OrganizationTeamsResponse
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} utils.ErrorResponse "Organization not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /organizations/{id}/teams [get]
+func (h *Handler) GetOrganizationTeams(c *fiber.Ctx) error {
+	organization, ok, err := h.organizationParam(c, policy.ActionRead)
+
+	if !ok {
+		return err
+	}
+
+	teams, err := h.organizationRepository.GetOrganizationTeams(c.Context(), organization.ID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": teams,
+	})
+}
+
+// GetOrganizationUsers godoc
+// @Summary Get the user directory of an organization
+// @Description List the users of every team of an organization, each one with the teams they are in and their role in each (Admin only, must administer the organization)
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Success 200 {object} interfaces.OrganizationUsersResponse
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} utils.ErrorResponse "Organization not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /organizations/{id}/users [get]
+func (h *Handler) GetOrganizationUsers(c *fiber.Ctx) error {
+	organization, ok, err := h.organizationParam(c, policy.ActionRead)
+
+	if !ok {
+		return err
+	}
+
+	users, err := h.organizationRepository.GetOrganizationUsers(c.Context(), organization.ID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": users,
+	})
+}
+
+// GetOrganizationReport godoc
+// @Summary Get the report of an organization
+// @Description Count the members, projects, tasks and done tasks of each team of an organization, and of the whole organization (Admin only, must administer the organization)
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Success 200 {object} interfaces.OrganizationReportResponse
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} utils.ErrorResponse "Organization not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /organizations/{id}/report [get]
+func (h *Handler) GetOrganizationReport(c *fiber.Ctx) error {
+	organization, ok, err := h.organizationParam(c, policy.ActionRead)
+
+	if !ok {
+		return err
+	}
+
+	report, err := h.organizationRepository.GetOrganizationReport(c.Context(), organization.ID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"report": report,
+	})
+}
+
+// GetOrganizationAdmins godoc
+// @Summary List the admins of an organization
+// @Description List the admins of an organization (Admin only, must administer the organization)
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Success 200 {object} interfaces.OrganizationAdminsResponse
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} utils.ErrorResponse "Organization not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /organizations/{id}/admins [get]
+func (h *Handler) GetOrganizationAdmins(c *fiber.Ctx) error {
+	organization, ok, err := h.organizationParam(c, policy.ActionRead)
+
+	if !ok {
+		return err
+	}
+
+	admins, err := h.organizationRepository.GetOrganizationAdmins(c.Context(), organization.ID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": admins,
+	})
+}
+
+// AddOrganizationAdmin godoc
+// @Summary Add an admin to an organization
+// @Description Let an admin of one of the teams of an organization manage all of them (Admin only, must administer the organization)
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Param request body interfaces.AddOrganizationAdminRequest true "New admin"
+// @Success 201 {object} interfaces.OrganizationAdminResponse
+// @Failure 400 {object} utils.ErrorResponse "Validation error or the user isn't an admin"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} utils.ErrorResponse "Organization or user not found"
+// @Failure 409 {object} utils.ErrorResponse "User already administers the organization"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /organizations/{id}/admins [post]
+func (h *Handler) AddOrganizationAdmin(c *fiber.Ctx) error {
+	organization, ok, err := h.organizationParam(c, policy.ActionUpdate)
+
+	if !ok {
+		return err
+	}
+
+	payload := interfaces.AddOrganizationAdminRequest{}
+
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	err = h.validator.Validate(payload)
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
+	}
+
+	// Users of other organizations are not found
+	member, err := h.organizationRepository.IsOrganizationMember(c.Context(), organization.ID, payload.UserId)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if !member {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("user not found"))
+	}
+
+	user, err := h.userRepository.GetUserById(c.Context(), payload.UserId)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("user not found"))
+	}
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if user.Role != models.UserrolesAdmin {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("only admins can administer an organization"))
+	}
+
+	exists, _, err := h.organizationRepository.GetOrganizationAdmin(c.Context(), organization.ID, user.ID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if exists {
+		return c.Status(fiber.StatusConflict).JSON(utils.ErrorString("the user already administers the organization"))
+	}
+
+	admin, err := h.organizationRepository.AddOrganizationAdmin(c.Context(), models.OrganizationAdmin{
+		OrganizationID: organization.ID,
+		UserID:         user.ID,
+		CreatedAt:      time.Now().UTC(),
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	admin.Username = user.Username
+	admin.Email = user.Email
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"admin": admin,
+	})
+}
+
+// RemoveOrganizationAdmin godoc
+// @Summary Remove an admin from an organization
+// @Description Stop an admin from managing the teams of an organization, the teams they own stay theirs. The last admin can't be removed (Admin only, must administer the organization)
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Param userId path string true "User ID"
+// @Success 200 {object} interfaces.MessageResponse
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID or the user is the last admin"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} utils.ErrorResponse "Organization or admin not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /organizations/{id}/admins/{userId} [delete]
+func (h *Handler) RemoveOrganizationAdmin(c *fiber.Ctx) error {
+	organization, ok, err := h.organizationParam(c, policy.ActionUpdate)
+
+	if !ok {
+		return err
+	}
+
+	userId := c.Params("userId")
+
+	if userId == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid id"))
+	}
+
+	userUUID, err := uuid.Parse(userId)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	admins, err := h.organizationRepository.GetOrganizationAdmins(c.Context(), organization.ID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	isAdmin := false
+	for _, a := range admins {
+		if a.UserID == userUUID {
+			isAdmin = true
+		}
+	}
+
+	if !isAdmin {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("admin not found"))
+	}
+
+	// Someone has to be left to manage the teams
+	if len(admins) == 1 {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("the organization needs at least one admin"))
+	}
+
+	if err := h.organizationRepository.RemoveOrganizationAdmin(c.Context(), organization.ID, userUUID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"deleted": true,
+	})
+}
@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const passwordResetTokenTTL = time.Hour
+
+const forgotPasswordMessage = "If the email belongs to an account, a password reset link has been sent"
+
+// frontendLink builds a link to the frontend page set in envKey (or fallback) carrying the token in the query
+func frontendLink(envKey string, fallback string, token string) string {
+	base := os.Getenv(envKey)
+	if base == "" {
+		base = fallback
+	}
+
+	return base + "?token=" + url.QueryEscape(token)
+}
+
+// passwordResetLink points to the frontend page that reads the token and calls /auth/reset-password
+func passwordResetLink(token string) string {
+	return frontendLink("PASSWORD_RESET_URL", "http://localhost:3000/reset-password", token)
+}
+
+// ForgotPassword godoc
+// @Summary Request a password reset
+// @Description Email a single use password reset link to the user. The response is the same whether the email belongs to an account or not. Requests are throttled per email and per ip address.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body interfaces.ForgotPasswordRequest true "Account email"
+// @Success 200 {object} interfaces.MessageResponse
+// @Failure 400 {object} utils.ErrorResponse "Validation error"
+// @Failure 429 {object} utils.ErrorResponse "Too many password reset requests"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /auth/forgot-password [post]
+func (h *Handler) ForgotPassword(c *fiber.Ctx) error {
+	payload := struct {
+		Email string `json:"email" validate:"required,email"`
+	}{}
+
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	err := h.validator.Validate(payload)
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
+	}
+
+	now := time.Now().UTC()
+	accountKey := attemptsKey("password-reset", loginAccountKey(payload.Email))
+	ipKey := attemptsKey("password-reset", c.IP())
+
+	attempts, err := h.loginAttemptRepository.GetLoginAttempts(c.Context(), interfaces.GetLoginAttemptsData{
+		AccountKey: accountKey,
+		IpAddress:  ipKey,
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error getting data from database"))
+	}
+
+	if retryAfter := throttleRetryAfter(attempts, accountPasswordResetThrottle, ipPasswordResetThrottle, now); retryAfter > 0 {
+		return tooManyRequests(c, retryAfter, "Too many password reset requests, try again later")
+	}
+
+	// Every request counts, for unknown emails too, so the throttle doesn't tell which accounts exist
+	_, err = h.loginAttemptRepository.RecordFailedLogin(c.Context(), interfaces.RecordFailedLoginData{
+		AccountKey:  accountKey,
+		IpAddress:   ipKey,
+		FailedAt:    now,
+		WindowStart: now.Add(-loginAttemptsWindow),
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error saving password reset request"))
+	}
+
+	existingUser, err := h.userRepository.GetUserByEmail(c.Context(), payload.Email)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"message": forgotPasswordMessage,
+		})
+	}
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error getting data from database"))
+	}
+
+	token, tokenHash, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	err = h.passwordResetTokenRepository.CreatePasswordResetToken(c.Context(), models.PasswordResetToken{
+		ID:        uuid.New(),
+		UserID:    existingUser.ID,
+		TokenHash: tokenHash,
+		ExpiresAt: now.Add(passwordResetTokenTTL),
+		CreatedAt: now,
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error creating reset token"))
+	}
+
+	err = h.mailer.Send(c.Context(), interfaces.Email{
+		To:      existingUser.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nWe received a request to reset your password. Open the link below to choose a new one:\n\n%s\n\nThe link expires in 1 hour and can only be used once. If you didn't ask for it you can ignore this email.\n",
+			existingUser.Username,
+			passwordResetLink(token),
+		),
+	})
+
+	// The mailer only queues the email, so the response doesn't wait for the mail server. Failing
+	// here would tell the caller the account exists
+	if err != nil {
+		log.Printf("error queueing password reset email to user %s: %v", existingUser.ID, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": forgotPasswordMessage,
+	})
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description Set a new password with the token received by email. The token can only be used once and every session of the user is revoked.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body interfaces.ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} interfaces.MessageResponse
+// @Failure 400 {object} utils.ErrorResponse "Validation error or invalid token"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /auth/reset-password [post]
+func (h *Handler) ResetPassword(c *fiber.Ctx) error {
+	payload := struct {
+		Token    string `json:"token" validate:"required"`
+		Password string `json:"password" validate:"required"`
+	}{}
+
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	err := h.validator.Validate(payload)
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
+	}
+
+	resetToken, err := h.passwordResetTokenRepository.GetPasswordResetTokenByHash(c.Context(), utils.HashOpaqueToken(payload.Token))
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid or expired reset token"))
+	}
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	now := time.Now().UTC()
+
+	if resetToken.UsedAt.Valid || now.After(resetToken.ExpiresAt) {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid or expired reset token"))
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(payload.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	reset, err := h.passwordResetTokenRepository.ResetPassword(c.Context(), interfaces.ResetPasswordData{
+		TokenID:  resetToken.ID,
+		UserID:   resetToken.UserID,
+		Password: string(hashedPassword),
+		UsedAt:   now,
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error resetting password"))
+	}
+
+	// Another request used the token first
+	if !reset {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid or expired reset token"))
+	}
+
+	h.syncRevokedSessionsAfter(c)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Password has been reset",
+	})
+}
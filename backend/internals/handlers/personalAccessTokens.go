@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// personalAccessTokenPrefixLength is how much of the token is kept in clear to identify it
+const personalAccessTokenPrefixLength = len(utils.PersonalAccessTokenPrefix) + 6
+
+// CreatePersonalAccessToken godoc
+// @Summary Create a personal access token
+// @Description Create a named token for scripts and CI. It can only be used on the routes its scopes allow and is returned only once.
+// @Tags Personal Access Tokens
+// @Accept json
+// @Produce json
+// @Param request body interfaces.CreatePersonalAccessTokenPayload true "Token name, scopes and expiry"
+// @Success 201 {object} interfaces.CreatePersonalAccessTokenResponse
+// @Failure 400 {object} utils.ErrorResponse "Validation error"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /auth/tokens [post]
+func (h *Handler) CreatePersonalAccessToken(c *fiber.Ctx) error {
+	userId := c.Locals("userId")
+
+	payload := interfaces.CreatePersonalAccessTokenPayload{}
+
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	err := h.validator.Validate(payload)
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
+	}
+
+	userUUID, err := uuid.Parse(userId.(string))
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	token, tokenHash, err := utils.GeneratePersonalAccessToken()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	now := time.Now().UTC()
+
+	personalAccessToken, err := h.personalAccessTokenRepository.CreatePersonalAccessToken(c.Context(), models.PersonalAccessToken{
+		ID:          uuid.New(),
+		UserID:      userUUID,
+		Name:        payload.Name,
+		TokenHash:   tokenHash,
+		TokenPrefix: token[:personalAccessTokenPrefixLength],
+		Scopes:      payload.Scopes,
+		ExpiresAt:   now.AddDate(0, 0, payload.ExpiresInDays),
+		CreatedAt:   now,
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error creating personal access token"))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"token":               token,
+		"personalAccessToken": personalAccessToken,
+	})
+}
+
+// GetPersonalAccessTokens godoc
+// @Summary List personal access tokens
+// @Description List the personal access tokens of the current user, expired ones included
+// @Tags Personal Access Tokens
+// @Accept json
+// @Produce json
+// @Success 200 {object} interfaces.PersonalAccessTokensListResponse
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /auth/tokens [get]
+func (h *Handler) GetPersonalAccessTokens(c *fiber.Ctx) error {
+	userId := c.Locals("userId")
+
+	userUUID, err := uuid.Parse(userId.(string))
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	tokens, err := h.personalAccessTokenRepository.GetPersonalAccessTokensByUserId(c.Context(), userUUID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": tokens,
+	})
+}
+
+// DeletePersonalAccessToken godoc
+// @Summary Revoke a personal access token
+// @Description Revoke one of the current user's personal access tokens, it stops working right away
+// @Tags Personal Access Tokens
+// @Accept json
+// @Produce json
+// @Param id path string true "Personal access token ID"
+// @Success 200 {object} interfaces.DeletePersonalAccessTokenResponse
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID"
+// @Failure 404 {object} utils.ErrorResponse "Personal access token not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /auth/tokens/{id} [delete]
+func (h *Handler) DeletePersonalAccessToken(c *fiber.Ctx) error {
+	userId := c.Locals("userId")
+
+	userUUID, err := uuid.Parse(userId.(string))
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	tokenId := c.Params("id")
+
+	if tokenId == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid id"))
+	}
+
+	tokenUUID, err := uuid.Parse(tokenId)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	deleted, err := h.personalAccessTokenRepository.DeletePersonalAccessToken(c.Context(), interfaces.DeletePersonalAccessTokenData{
+		ID:     tokenUUID,
+		UserID: userUUID,
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if !deleted {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("personal access token not found"))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"deleted": true,
+	})
+}
@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/policy"
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// authorizeProject loads a project with its members and checks the action on it, projects that don't
+// exist or belong to another team are policy.ErrNotFound
+func (h *Handler) authorizeProject(c *fiber.Ctx, action policy.Action, projectUUID uuid.UUID) (models.Project, []models.ProjectMember, error) {
+	project, err := h.projectRepository.GetProjectById(c.Context(), projectUUID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Project{}, nil, policy.ErrNotFound
+	}
+
+	if err != nil {
+		return models.Project{}, nil, err
+	}
+
+	members, err := h.projectRepository.GetProjectMembers(c.Context(), project.ID)
+
+	if err != nil {
+		return models.Project{}, nil, err
+	}
+
+	if err := h.authorize(c, action, policy.Project(project, members)); err != nil {
+		return models.Project{}, nil, err
+	}
+
+	return project, members, nil
+}
+
+// GetProjectMembers godoc
+// @Summary List the members of a project
+// @Description List the owners, contributors and viewers of a project
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Param id path string true "Project ID"
+// @Success 200 {object} interfaces.ProjectMembersListResponse
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden"
+// @Failure 404 {object} utils.ErrorResponse "Project not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /projects/{id}/members [get]
+func (h *Handler) GetProjectMembers(c *fiber.Ctx) error {
+	projectId := c.Params("id")
+
+	if projectId == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid id"))
+	}
+
+	projectUUID, err := uuid.Parse(projectId)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	_, members, err := h.authorizeProject(c, policy.ActionRead, projectUUID)
+
+	if err != nil {
+		return policy.Deny(c, err, "project not found")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": members,
+	})
+}
+
+// AddProjectMember godoc
+// @Summary Add a member to a project
+// @Description Add a user of the team to a project as an owner, a contributor or a viewer (must be an owner of the project). Only managers can own a project
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Param id path string true "Project ID"
+// @Param request body interfaces.AddProjectMemberPayload true "Member data"
+// @Success 201 {object} interfaces.ProjectMemberResponse
+// @Failure 400 {object} utils.ErrorResponse "Validation error or the user can't own a project"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Must be an owner of the project"
+// @Failure 404 {object} utils.ErrorResponse "Project or user not found"
+// @Failure 409 {object} utils.ErrorResponse "User is already a member of the project"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /projects/{id}/members [post]
+func (h *Handler) AddProjectMember(c *fiber.Ctx) error {
+	projectId := c.Params("id")
+
+	if projectId == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid id"))
+	}
+
+	projectUUID, err := uuid.Parse(projectId)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if _, _, err := h.authorizeProject(c, policy.ActionUpdate, projectUUID); err != nil {
+		return policy.Deny(c, err, "project not found")
+	}
+
+	payload := interfaces.AddProjectMemberPayload{}
+
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	err = h.validator.Validate(payload)
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
+	}
+
+	// Members are users of the team of the project
+	user, err := h.authorizeUser(c, policy.ActionRead, payload.UserId)
+
+	if err != nil {
+		return policy.Deny(c, err, "user not found")
+	}
+
+	if payload.Role == models.ProjectmemberrolesOwner && user.Role != models.UserrolesManager {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("only managers can own a project"))
+	}
+
+	exists, _, err := h.projectRepository.GetProjectMember(c.Context(), projectUUID, user.ID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if exists {
+		return c.Status(fiber.StatusConflict).JSON(utils.ErrorString("user is already a member of the project"))
+	}
+
+	member, err := h.projectRepository.AddProjectMember(c.Context(), models.ProjectMember{
+		ProjectID: projectUUID,
+		UserID:    user.ID,
+		Role:      payload.Role,
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	member.Username = user.Username
+	member.Email = user.Email
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"member": member,
+	})
+}
+
+// RemoveProjectMember godoc
+// @Summary Remove a member from a project
+// @Description Remove a member from a project (must be an owner of the project), its tasks in the project are left unassigned. The last owner of a project can't be removed
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Param id path string true "Project ID"
+// @Param userId path string true "User ID"
+// @Success 200 {object} interfaces.MessageResponse
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID or last owner of the project"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Must be an owner of the project"
+// @Failure 404 {object} utils.ErrorResponse "Project or member not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /projects/{id}/members/{userId} [delete]
+func (h *Handler) RemoveProjectMember(c *fiber.Ctx) error {
+	projectId := c.Params("id")
+	userId := c.Params("userId")
+
+	if projectId == "" || userId == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid id"))
+	}
+
+	projectUUID, err := uuid.Parse(projectId)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	userUUID, err := uuid.Parse(userId)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	_, members, err := h.authorizeProject(c, policy.ActionUpdate, projectUUID)
+
+	if err != nil {
+		return policy.Deny(c, err, "project not found")
+	}
+
+	var member *models.ProjectMember
+	owners := 0
+
+	for i, m := range members {
+		if m.UserID == userUUID {
+			member = &members[i]
+		}
+
+		if m.Role == models.ProjectmemberrolesOwner {
+			owners++
+		}
+	}
+
+	if member == nil {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("member not found"))
+	}
+
+	if member.Role == models.ProjectmemberrolesOwner && owners == 1 {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("a project needs an owner"))
+	}
+
+	err = h.projectRepository.RemoveProjectMember(c.Context(), interfaces.RemoveProjectMemberData{
+		ProjectID: projectUUID,
+		UserID:    userUUID,
+		UpdatedAt: time.Now().UTC(),
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"deleted": true,
+	})
+}
@@ -6,31 +6,64 @@ import (
 	"time"
 
 	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/policy"
 	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/TobiasRV/challenge-fs-senior/internals/tenant"
 	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
 
+// recordAdminChanges has the database write the changes the request makes to projects and tasks to the
+// admin audit log, in the same transaction, when an admin makes it. The other roles aren't recorded
+func (h *Handler) recordAdminChanges(c *fiber.Ctx) error {
+	subject := policy.SubjectFromContext(c.Context())
+
+	if subject.Role != models.UserrolesAdmin {
+		return nil
+	}
+
+	// Only the queries scoped to a team carry the admin
+	if _, err := h.callerTeamId(c); err != nil {
+		return err
+	}
+
+	tenant.SetActingAdmin(c, subject.ID)
+
+	return nil
+}
+
+// projectResource loads the members of the project, its owners own it with its manager
+func (h *Handler) projectResource(c *fiber.Ctx, project models.Project) (policy.Resource, error) {
+	members, err := h.projectRepository.GetProjectMembers(c.Context(), project.ID)
+
+	if err != nil {
+		return policy.Resource{}, err
+	}
+
+	return policy.Project(project, members), nil
+}
+
 // CreateProject godoc
 // @Summary Create a new project
-// @Description Create a new project (Manager only)
+// @Description Create a new project, its manager is its first owner. Managers manage the projects they create unless they pick another manager of the team, admins have to pick one
 // @Tags Projects
 // @Accept json
 // @Produce json
 // @Param request body interfaces.CreateProjectPayload true "Project creation data"
 // @Success 201 {object} interfaces.GetProjectsResponse
-// @Failure 400 {object} utils.ErrorResponse "Validation error or manager not found"
-// @Failure 403 {object} utils.ErrorResponse "Forbidden - Manager only"
+// @Failure 400 {object} utils.ErrorResponse "Validation error, managerId required or the user isn't a manager"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden"
+// @Failure 404 {object} utils.ErrorResponse "Manager not found"
 // @Failure 500 {object} utils.ErrorResponse "Internal server error"
 // @Security BearerAuth
 // @Router /projects [post]
 func (h *Handler) CreateProject(c *fiber.Ctx) error {
-	userRole := c.Locals("userRole")
 	userId := c.Locals("userId")
 
-	if userRole != "Manager" {
-		return c.Status(fiber.StatusForbidden).JSON(utils.ErrorString("unauthorized"))
+	if err := policy.Authorize(c.Context(), policy.ActionCreate, policy.Collection(policy.ResourceProjects)); err != nil {
+		return policy.Forbidden(c)
 	}
 
 	userUUID, err := uuid.Parse(userId.(string))
@@ -51,22 +84,51 @@ func (h *Handler) CreateProject(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
 	}
 
-	user, err := h.userRepository.GetUserById(c.Context(), userUUID)
+	// Projects are created in the team the session works in, not in the default team of the manager
+	teamId, err := h.callerTeamId(c)
 
-	if errors.Is(err, sql.ErrNoRows) {
-		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("manager doesn't exists"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
 	}
 
+	if teamId == uuid.Nil {
+		return policy.Forbidden(c)
+	}
+
+	managerId := payload.ManagerId
+
+	if managerId == uuid.Nil {
+		// Admins don't manage projects, they pick who does
+		if models.Userroles(c.Locals("userRole").(string)) == models.UserrolesAdmin {
+			return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("managerId required"))
+		}
+
+		managerId = userUUID
+	} else if _, err := h.authorizeUser(c, policy.ActionRead, managerId); err != nil {
+		return policy.Deny(c, err, "manager not found")
+	}
+
+	// The role that counts is the one the manager has in the team, a manager of another team can be a member of this one
+	exists, member, err := h.teamRepository.GetTeamMember(c.Context(), teamId, managerId)
+
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
 	}
 
+	if !exists || member.Role != models.UserrolesManager {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("the manager of a project must be a manager of its team"))
+	}
+
+	if err := h.recordAdminChanges(c); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
 	project, err := h.projectRepository.CreateProject(c.Context(), database.CreateProjectParams{
 		CreatedAt: time.Now().UTC(),
 		UpdatedAt: time.Now().UTC(),
 		Name:      payload.Name,
-		TeamID:    user.TeamId,
-		ManagerID: user.ID,
+		TeamID:    teamId,
+		ManagerID: managerId,
 	})
 
 	if err != nil {
@@ -79,7 +141,7 @@ func (h *Handler) CreateProject(c *fiber.Ctx) error {
 
 // GetProjects godoc
 // @Summary Get all projects
-// @Description Get paginated list of projects (Admin or Manager only)
+// @Description Get paginated list of projects, only the team of the user and its managers can be listed. Roles that can't list every project only list the ones they are members of
 // @Tags Projects
 // @Accept json
 // @Produce json
@@ -91,16 +153,20 @@ func (h *Handler) CreateProject(c *fiber.Ctx) error {
 // @Param limit query int true "Number of items per page"
 // @Success 200 {object} interfaces.ProjectsListResponse
 // @Failure 400 {object} utils.ErrorResponse "Bad request - teamId or managerId required"
-// @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin or Manager only"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden"
+// @Failure 404 {object} utils.ErrorResponse "Team or manager not found"
 // @Failure 500 {object} utils.ErrorResponse "Internal server error"
 // @Security BearerAuth
 // @Router /projects [get]
 func (h *Handler) GetProjects(c *fiber.Ctx) error {
 
-	userRole := c.Locals("userRole")
+	subject := policy.SubjectFromContext(c.Context())
+
+	// Roles that can't read every project only list the ones they are members of
+	listAll := policy.Can(subject, policy.ActionRead, policy.Collection(policy.ResourceProjects))
 
-	if userRole != "Admin" && userRole != "Manager" {
-		return c.Status(fiber.StatusForbidden).JSON(utils.ErrorString("unauthorized"))
+	if !listAll && !policy.Can(subject, policy.ActionRead, policy.MemberResources(policy.ResourceProjects, subject.ID)) {
+		return policy.Forbidden(c)
 	}
 
 	queryParams := interfaces.GetProjectsParams{}
@@ -109,10 +175,37 @@ func (h *Handler) GetProjects(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.NewError(err))
 	}
 
-	if queryParams.ManagerId == uuid.Nil && queryParams.TeamId == uuid.Nil {
+	if listAll && queryParams.ManagerId == uuid.Nil && queryParams.TeamId == uuid.Nil {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("teamId or managerId required"))
 	}
 
+	// The projects of a member are already in its team, the filters only narrow them down
+	if listAll && queryParams.TeamId != uuid.Nil {
+		if err := h.authorize(c, policy.ActionRead, policy.TeamResources(policy.ResourceProjects, queryParams.TeamId)); err != nil {
+			return policy.Deny(c, err, "team not found")
+		}
+	}
+
+	if listAll && queryParams.ManagerId != uuid.Nil {
+		if _, err := h.authorizeUser(c, policy.ActionRead, queryParams.ManagerId); err != nil {
+			return policy.Deny(c, err, "manager not found")
+		}
+	}
+
+	// Both filters are in the team of the user now, listing by it too keeps the projects a manager
+	// left behind in another team out
+	teamId, err := h.callerTeamId(c)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	var memberUUID uuid.UUID
+
+	if !listAll {
+		memberUUID = subject.ID
+	}
+
 	cursor := queryParams.Cursor
 	isFirstPage := true
 	var cursorCreatedAt time.Time
@@ -139,8 +232,9 @@ func (h *Handler) GetProjects(c *fiber.Ctx) error {
 
 	projects, err := h.projectRepository.GetProjects(c.Context(), interfaces.GetProjectsFilters{
 		Name:            queryParams.Name,
-		TeamId:          queryParams.TeamId,
+		TeamId:          teamId,
 		ManagerId:       queryParams.ManagerId,
+		MemberId:        memberUUID,
 		Limit:           queryParams.Limit,
 		IsFirstPage:     isFirstPage,
 		PointsNext:      pointsNext,
@@ -205,7 +299,7 @@ func (h *Handler) GetProjects(c *fiber.Ctx) error {
 
 // UpdateProject godoc
 // @Summary Update a project
-// @Description Update project information (Manager only, must be the project manager)
+// @Description Update project information (must be an owner of the project or an admin of the team)
 // @Tags Projects
 // @Accept json
 // @Produce json
@@ -213,26 +307,13 @@ func (h *Handler) GetProjects(c *fiber.Ctx) error {
 // @Param request body interfaces.UpdateProjectPayload true "Project update data"
 // @Success 200 {object} interfaces.GetProjectsResponse
 // @Failure 400 {object} utils.ErrorResponse "Validation error"
-// @Failure 403 {object} utils.ErrorResponse "Forbidden - Must be the project manager"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Must be an owner of the project"
 // @Failure 404 {object} utils.ErrorResponse "Project not found"
 // @Failure 500 {object} utils.ErrorResponse "Internal server error"
 // @Security BearerAuth
 // @Router /projects/{id} [put]
 func (h *Handler) UpdateProject(c *fiber.Ctx) error {
 
-	userRole := c.Locals("userRole")
-	userId := c.Locals("userId")
-
-	userUUID, err := uuid.Parse(userId.(string))
-
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
-	}
-
-	if userRole != "Manager" {
-		return c.Status(fiber.StatusForbidden).JSON(utils.ErrorString("unauthorized"))
-	}
-
 	projectId := c.Params("id")
 
 	if projectId == "" {
@@ -255,8 +336,14 @@ func (h *Handler) UpdateProject(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
 	}
 
-	if existingProject.ManagerID != userUUID {
-		return c.Status(fiber.StatusForbidden).JSON(utils.ErrorString("unauthorized"))
+	resource, err := h.projectResource(c, existingProject)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if err := h.authorize(c, policy.ActionUpdate, resource); err != nil {
+		return policy.Deny(c, err, "project not found")
 	}
 
 	payload := interfaces.UpdateProjectPayload{}
@@ -271,6 +358,10 @@ func (h *Handler) UpdateProject(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
 	}
 
+	if err := h.recordAdminChanges(c); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
 	updatedProject, err := h.projectRepository.UpdateProject(c.Context(), interfaces.UpdateProjectData{
 		Name:      payload.Name,
 		Status:    payload.Status,
@@ -286,25 +377,19 @@ func (h *Handler) UpdateProject(c *fiber.Ctx) error {
 
 // DeleteProject godoc
 // @Summary Delete a project
-// @Description Delete a project by ID (Manager only)
+// @Description Delete a project by ID (must be an owner of the project or an admin of the team)
 // @Tags Projects
 // @Accept json
 // @Produce json
 // @Param id path string true "Project ID"
 // @Success 200 {object} interfaces.MessageResponse
 // @Failure 400 {object} utils.ErrorResponse "Invalid ID"
-// @Failure 403 {object} utils.ErrorResponse "Forbidden - Manager only"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Must be an owner of the project"
 // @Failure 404 {object} utils.ErrorResponse "Project not found"
 // @Failure 500 {object} utils.ErrorResponse "Internal server error"
 // @Security BearerAuth
 // @Router /projects/{id} [delete]
 func (h *Handler) DeleteProject(c *fiber.Ctx) error {
-	userRole := c.Locals("userRole")
-
-	if userRole != "Manager" {
-		return c.Status(fiber.StatusForbidden).JSON(utils.ErrorString("unauthorized"))
-	}
-
 	projectId := c.Params("id")
 
 	if projectId == "" {
@@ -317,6 +402,30 @@ func (h *Handler) DeleteProject(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
 	}
 
+	existingProject, err := h.projectRepository.GetProjectById(c.Context(), projectUUID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("project not found"))
+	}
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	resource, err := h.projectResource(c, existingProject)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if err := h.authorize(c, policy.ActionDelete, resource); err != nil {
+		return policy.Deny(c, err, "project not found")
+	}
+
+	if err := h.recordAdminChanges(c); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
 	err = h.projectRepository.DeleteProject(c.Context(), projectUUID)
 
 	if err != nil {
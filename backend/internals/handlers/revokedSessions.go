@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// revokedSessions keeps in memory the sessions revoked while their access tokens may still be valid,
+// so checking a token doesn't need the database. Each id is forgotten once those tokens have expired
+type revokedSessions struct {
+	mu  sync.RWMutex
+	ids map[uuid.UUID]time.Time
+}
+
+func newRevokedSessions() *revokedSessions {
+	return &revokedSessions{
+		ids: make(map[uuid.UUID]time.Time),
+	}
+}
+
+func (rs *revokedSessions) add(forgetAt time.Time, ids ...uuid.UUID) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	for _, id := range ids {
+		if _, exists := rs.ids[id]; !exists {
+			rs.ids[id] = forgetAt
+		}
+	}
+}
+
+func (rs *revokedSessions) contains(id uuid.UUID) bool {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+
+	_, exists := rs.ids[id]
+
+	return exists
+}
+
+func (rs *revokedSessions) prune(now time.Time) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	for id, forgetAt := range rs.ids {
+		if now.After(forgetAt) {
+			delete(rs.ids, id)
+		}
+	}
+}
+
+// SyncRevokedSessions loads the sessions revoked by any instance of the api in the last AccessTokenTTL
+func (h *Handler) SyncRevokedSessions(c context.Context) error {
+	now := time.Now().UTC()
+
+	ids, err := h.revokedSessionRepository.GetRevokedSessionIds(c, now.Add(-utils.AccessTokenTTL))
+
+	if err != nil {
+		return err
+	}
+
+	h.revokedSessions.add(now.Add(utils.AccessTokenTTL), ids...)
+	h.revokedSessions.prune(now)
+
+	return nil
+}
+
+// WatchRevokedSessions syncs the revoked sessions every interval until c is done, so sessions revoked
+// through another instance are denied here too. It also deletes the rows nobody needs anymore
+func (h *Handler) WatchRevokedSessions(c context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := h.revokedSessionRepository.DeleteRevokedSessionsBefore(c, time.Now().UTC().Add(-utils.AccessTokenTTL))
+
+		if err != nil {
+			log.Printf("error deleting expired revoked sessions: %v", err)
+		}
+
+		if err := h.SyncRevokedSessions(c); err != nil {
+			log.Printf("error syncing revoked sessions: %v", err)
+		}
+	}
+}
+
+// revokeSession denies the access tokens of a session this instance just deleted
+func (h *Handler) revokeSession(id uuid.UUID) {
+	h.revokedSessions.add(time.Now().UTC().Add(utils.AccessTokenTTL), id)
+}
+
+// syncRevokedSessionsAfter is called after deleting several sessions at once. The request already
+// succeeded, if the sync fails the next tick of WatchRevokedSessions picks them up
+func (h *Handler) syncRevokedSessionsAfter(c *fiber.Ctx) {
+	if err := h.SyncRevokedSessions(c.Context()); err != nil {
+		log.Printf("error syncing revoked sessions: %v", err)
+	}
+}
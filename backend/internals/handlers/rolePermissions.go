@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/google/uuid"
+)
+
+// rolePermissions keeps in memory the permissions of the custom roles so checking an access token doesn't
+// need the database. A role stored with nil permissions was deleted, its users go by the base role
+type rolePermissions struct {
+	mu    sync.RWMutex
+	roles map[uuid.UUID][]string
+}
+
+func newRolePermissions() *rolePermissions {
+	return &rolePermissions{
+		roles: make(map[uuid.UUID][]string),
+	}
+}
+
+func (rp *rolePermissions) get(id uuid.UUID) (permissions []string, found bool) {
+	rp.mu.RLock()
+	defer rp.mu.RUnlock()
+
+	permissions, found = rp.roles[id]
+
+	return permissions, found
+}
+
+func (rp *rolePermissions) set(role models.Role) {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	rp.roles[role.ID] = grantedPermissions(role)
+}
+
+func (rp *rolePermissions) remove(id uuid.UUID) {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	rp.roles[id] = nil
+}
+
+func (rp *rolePermissions) replace(roles []models.Role) {
+	loaded := make(map[uuid.UUID][]string, len(roles))
+
+	for _, role := range roles {
+		loaded[role.ID] = grantedPermissions(role)
+	}
+
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	rp.roles = loaded
+}
+
+// grantedPermissions never returns nil, a role left without permissions grants none, not the ones of the
+// base role
+func grantedPermissions(role models.Role) []string {
+	if role.Permissions == nil {
+		return []string{}
+	}
+
+	return role.Permissions
+}
+
+// SyncRolePermissions loads the permissions of every custom role, dropping the roles deleted since
+func (h *Handler) SyncRolePermissions(c context.Context) error {
+	roles, err := h.roleRepository.GetCustomRoles(c)
+
+	if err != nil {
+		return err
+	}
+
+	h.rolePermissions.replace(roles)
+
+	return nil
+}
+
+// WatchRolePermissions syncs the role permissions every interval until c is done, so changes made to a role
+// through another instance apply here too
+func (h *Handler) WatchRolePermissions(c context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := h.SyncRolePermissions(c); err != nil {
+			log.Printf("error syncing role permissions: %v", err)
+		}
+	}
+}
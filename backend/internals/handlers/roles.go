@@ -0,0 +1,335 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/policy"
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// authorizeRole loads a role and checks the action on it, roles that don't exist or belong to another
+// team are policy.ErrNotFound
+func (h *Handler) authorizeRole(c *fiber.Ctx, action policy.Action, roleUUID uuid.UUID) (models.Role, error) {
+	role, err := h.roleRepository.GetRoleById(c.Context(), roleUUID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Role{}, policy.ErrNotFound
+	}
+
+	if err != nil {
+		return models.Role{}, err
+	}
+
+	if err := h.authorize(c, action, policy.Role(role)); err != nil {
+		return models.Role{}, err
+	}
+
+	return role, nil
+}
+
+// roleNameTaken tells if another built-in role or custom role of the team already has the name
+func (h *Handler) roleNameTaken(c *fiber.Ctx, teamId uuid.UUID, name string, roleId uuid.UUID) (bool, error) {
+	exists, role, err := h.roleRepository.GetRoleByName(c.Context(), interfaces.GetRoleByNameData{
+		TeamID: teamId,
+		Name:   name,
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return exists && role.ID != roleId, nil
+}
+
+// GetRoles godoc
+// @Summary List roles
+// @Description List the built-in roles and the custom roles of the team of the user
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Success 200 {object} interfaces.RolesListResponse
+// @Failure 403 {object} utils.ErrorResponse "Forbidden"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /roles [get]
+func (h *Handler) GetRoles(c *fiber.Ctx) error {
+
+	if err := policy.Authorize(c.Context(), policy.ActionRead, policy.Collection(policy.ResourceRoles)); err != nil {
+		return policy.Forbidden(c)
+	}
+
+	teamId, err := h.callerTeamId(c)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	roles, err := h.roleRepository.GetRolesByTeam(c.Context(), teamId)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": roles,
+	})
+}
+
+// GetRole godoc
+// @Summary Get a role
+// @Description Get a built-in role or a custom role of the team of the user
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Param id path string true "Role ID"
+// @Success 200 {object} interfaces.RoleResponse
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden"
+// @Failure 404 {object} utils.ErrorResponse "Role not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /roles/{id} [get]
+func (h *Handler) GetRole(c *fiber.Ctx) error {
+	roleId := c.Params("id")
+
+	if roleId == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid id"))
+	}
+
+	roleUUID, err := uuid.Parse(roleId)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	role, err := h.authorizeRole(c, policy.ActionRead, roleUUID)
+
+	if err != nil {
+		return policy.Deny(c, err, "role not found")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"role": role,
+	})
+}
+
+// CreateRole godoc
+// @Summary Create a role
+// @Description Create a custom role for the admin team with a set of permissions over its users, projects and tasks (Admin only)
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Param request body interfaces.RolePayload true "Role data"
+// @Success 201 {object} interfaces.RoleResponse
+// @Failure 400 {object} utils.ErrorResponse "Validation error"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} utils.ErrorResponse "Team not found"
+// @Failure 409 {object} utils.ErrorResponse "Role already exists"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /roles [post]
+func (h *Handler) CreateRole(c *fiber.Ctx) error {
+
+	if err := policy.Authorize(c.Context(), policy.ActionCreate, policy.Collection(policy.ResourceRoles)); err != nil {
+		return policy.Forbidden(c)
+	}
+
+	payload := interfaces.RolePayload{}
+
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	err := h.validator.Validate(payload)
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
+	}
+
+	teamId, err := h.callerTeamId(c)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if teamId == uuid.Nil {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("team not found"))
+	}
+
+	taken, err := h.roleNameTaken(c, teamId, payload.Name, uuid.Nil)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if taken {
+		return c.Status(fiber.StatusConflict).JSON(utils.ErrorString("role already exists"))
+	}
+
+	now := time.Now().UTC()
+
+	role, err := h.roleRepository.CreateRole(c.Context(), models.Role{
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		TeamID:      uuid.NullUUID{UUID: teamId, Valid: true},
+		Name:        payload.Name,
+		Permissions: payload.Permissions,
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	h.rolePermissions.set(role)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"role": role,
+	})
+}
+
+// UpdateRole godoc
+// @Summary Update a role
+// @Description Rename a custom role of the admin team or change its permissions, its users get them on their next request (within ROLES_SYNC_INTERVAL on the other instances of the api). Built-in roles can't be changed (Admin only)
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Param id path string true "Role ID"
+// @Param request body interfaces.RolePayload true "Role data"
+// @Success 200 {object} interfaces.RoleResponse
+// @Failure 400 {object} utils.ErrorResponse "Validation error or built-in role"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} utils.ErrorResponse "Role not found"
+// @Failure 409 {object} utils.ErrorResponse "Role already exists"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /roles/{id} [put]
+func (h *Handler) UpdateRole(c *fiber.Ctx) error {
+
+	if err := policy.Authorize(c.Context(), policy.ActionUpdate, policy.Collection(policy.ResourceRoles)); err != nil {
+		return policy.Forbidden(c)
+	}
+
+	roleId := c.Params("id")
+
+	if roleId == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid id"))
+	}
+
+	roleUUID, err := uuid.Parse(roleId)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	role, err := h.authorizeRole(c, policy.ActionUpdate, roleUUID)
+
+	if err != nil {
+		return policy.Deny(c, err, "role not found")
+	}
+
+	if role.BuiltIn {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("built-in roles can't be changed"))
+	}
+
+	payload := interfaces.RolePayload{}
+
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	err = h.validator.Validate(payload)
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
+	}
+
+	taken, err := h.roleNameTaken(c, role.TeamID.UUID, payload.Name, role.ID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if taken {
+		return c.Status(fiber.StatusConflict).JSON(utils.ErrorString("role already exists"))
+	}
+
+	updatedRole, err := h.roleRepository.UpdateRole(c.Context(), interfaces.UpdateRoleData{
+		Name:        payload.Name,
+		Permissions: payload.Permissions,
+		UpdatedAt:   time.Now().UTC(),
+		ID:          role.ID,
+	})
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("role not found"))
+	}
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	h.rolePermissions.set(updatedRole)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"role": updatedRole,
+	})
+}
+
+// DeleteRole godoc
+// @Summary Delete a role
+// @Description Delete a custom role of the admin team, its users go back to the built-in role of their base role. Built-in roles can't be deleted (Admin only)
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Param id path string true "Role ID"
+// @Success 200 {object} interfaces.DeleteRoleResponse
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID or built-in role"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} utils.ErrorResponse "Role not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /roles/{id} [delete]
+func (h *Handler) DeleteRole(c *fiber.Ctx) error {
+
+	if err := policy.Authorize(c.Context(), policy.ActionDelete, policy.Collection(policy.ResourceRoles)); err != nil {
+		return policy.Forbidden(c)
+	}
+
+	roleId := c.Params("id")
+
+	if roleId == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid id"))
+	}
+
+	roleUUID, err := uuid.Parse(roleId)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	role, err := h.authorizeRole(c, policy.ActionDelete, roleUUID)
+
+	if err != nil {
+		return policy.Deny(c, err, "role not found")
+	}
+
+	if role.BuiltIn {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("built-in roles can't be deleted"))
+	}
+
+	if err := h.roleRepository.DeleteRole(c.Context(), role.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	h.rolePermissions.remove(role.ID)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"deleted": true,
+	})
+}
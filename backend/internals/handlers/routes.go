@@ -1,8 +1,9 @@
 package handlers
 
 import (
-	"os"
-
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/policy"
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
 	jwtware "github.com/gofiber/contrib/jwt"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/swagger"
@@ -12,6 +13,9 @@ func (h *Handler) Register(r *fiber.App) {
 	// Swagger documentation endpoint
 	r.Get("/swagger/*", swagger.HandlerDefault)
 
+	// Public keys for services that verify our tokens
+	r.Get("/.well-known/jwks.json", h.GetJWKS)
+
 	api := r.Group("/api")
 	v1 := api.Group("/v1")
 
@@ -19,37 +23,107 @@ func (h *Handler) Register(r *fiber.App) {
 	authRoutes.Post("/register-admin", h.CreateUserAdmin)
 	authRoutes.Post("/login", h.LogIn)
 	authRoutes.Post("/refresh-token", h.RefreshToken)
-
-	usersRoutes := v1.Group("/users")
-	usersRoutes.Get("/exists-by-email", h.UserExistsByEmail)
+	authRoutes.Post("/forgot-password", h.ForgotPassword)
+	authRoutes.Post("/reset-password", h.ResetPassword)
+	authRoutes.Post("/mfa/verify", h.VerifyMfa)
+	authRoutes.Post("/mfa/enroll", h.EnrollMfa)
+	authRoutes.Post("/mfa/enroll/confirm", h.ConfirmMfaEnrollment)
+	authRoutes.Get("/oidc/authorize", h.OIDCAuthorize)
+	authRoutes.Post("/oidc/callback", h.OIDCCallback)
 
 	jwtMiddleware := jwtware.New(jwtware.Config{
-		SigningKey:     jwtware.SigningKey{Key: []byte(os.Getenv("JWT_SECRET"))},
+		KeyFunc:        utils.JWTKeyFunc,
+		TokenLookup:    "header:Authorization,cookie:" + utils.AccessTokenCookie,
 		ErrorHandler:   h.JWTErrorHandler,
 		SuccessHandler: h.JWTSuccessHandler,
 	})
-	usersRoutes.Use(jwtMiddleware)
-	usersRoutes.Get("/", h.GetUsers)
-	usersRoutes.Post("/", h.CreateUser)
-	usersRoutes.Put("/:id", h.UpdateUser)
-	usersRoutes.Delete("/:id", h.DeleteUser)
-
-	teamsRoutes := v1.Group("/teams", jwtMiddleware)
-	teamsRoutes.Post("/", h.CreateTeam)
-	teamsRoutes.Get("/by-owner", h.GetTeamByOwner)
-
-	projectRoutes := v1.Group("/projects", jwtMiddleware)
-	projectRoutes.Get("/", h.GetProjects)
-	projectRoutes.Post("/", h.CreateProject)
-	projectRoutes.Put("/:id", h.UpdateProject)
-	projectRoutes.Delete("/:id", h.DeleteProject)
-
-	taskRoutes := v1.Group("/tasks", jwtMiddleware)
-	taskRoutes.Post("/", h.CreateTask)
-	taskRoutes.Get("/", h.GetTasks)
-	taskRoutes.Put("/:id", h.UpdateTask)
-	taskRoutes.Delete("/:id", h.DeleteTask)
-
-	authRoutes.Use(jwtMiddleware)
-	authRoutes.Delete("/logout", h.LogOut)
+
+	// Every protected route takes an access token, personal access tokens are only
+	// accepted where the route lists the scopes they need
+	auth := func(scopes ...string) fiber.Handler {
+		return h.AuthMiddleware(jwtMiddleware, scopes...)
+	}
+
+	// policy.Require rejects the roles that can't perform the action at all, the handlers check
+	// the ownership of the resource with policy.Authorize once they load it
+
+	usersRoutes := v1.Group("/users")
+	usersRoutes.Get("/exists-by-email", auth(), policy.Require(policy.ActionCreate, policy.ResourceUsers), h.UserExistsByEmail)
+	usersRoutes.Get("/", auth(models.ScopeUsersRead), policy.Require(policy.ActionRead, policy.ResourceUsers), h.GetUsers)
+	usersRoutes.Post("/", auth(), policy.Require(policy.ActionCreate, policy.ResourceUsers), h.CreateUser)
+	usersRoutes.Get("/me", auth(models.ScopeUsersRead), policy.Require(policy.ActionRead, policy.ResourceProfile), h.GetCurrentUser)
+	usersRoutes.Patch("/me", auth(), policy.Require(policy.ActionUpdate, policy.ResourceProfile), h.UpdateCurrentUser)
+	usersRoutes.Get("/me/teams", auth(), policy.Require(policy.ActionRead, policy.ResourceProfile), h.GetCurrentUserTeams)
+	usersRoutes.Post("/me/password", auth(), policy.Require(policy.ActionUpdate, policy.ResourceProfile), h.ChangePassword)
+	usersRoutes.Put("/:id", auth(), policy.Require(policy.ActionUpdate, policy.ResourceUsers), h.UpdateUser)
+	usersRoutes.Delete("/:id", auth(), policy.Require(policy.ActionDelete, policy.ResourceUsers), h.DeleteUser)
+	usersRoutes.Post("/:id/reactivate", auth(), policy.Require(policy.ActionDelete, policy.ResourceUsers), h.ReactivateUser)
+	usersRoutes.Delete("/:id/sessions", auth(), policy.Require(policy.ActionRevokeSessions, policy.ResourceUsers), h.RevokeUserSessions)
+	usersRoutes.Post("/:id/unlock", auth(), policy.Require(policy.ActionUnlock, policy.ResourceUsers), h.UnlockUser)
+
+	invitationsRoutes := v1.Group("/invitations")
+	invitationsRoutes.Post("/accept", h.AcceptInvitation)
+	invitationsRoutes.Get("/", auth(), policy.Require(policy.ActionRead, policy.ResourceInvitations), h.GetInvitations)
+	invitationsRoutes.Post("/", auth(), policy.Require(policy.ActionCreate, policy.ResourceInvitations), h.CreateInvitation)
+	invitationsRoutes.Post("/:id/resend", auth(), policy.Require(policy.ActionUpdate, policy.ResourceInvitations), h.ResendInvitation)
+	invitationsRoutes.Delete("/:id", auth(), policy.Require(policy.ActionDelete, policy.ResourceInvitations), h.RevokeInvitation)
+
+	teamsRoutes := v1.Group("/teams")
+	teamsRoutes.Post("/", auth(), policy.Require(policy.ActionCreate, policy.ResourceTeams), h.CreateTeam)
+	teamsRoutes.Get("/by-owner", auth(), policy.Require(policy.ActionRead, policy.ResourceTeams), h.GetTeamByOwner)
+	teamsRoutes.Put("/mfa", auth(), policy.Require(policy.ActionUpdate, policy.ResourceTeams), h.UpdateTeamMfa)
+	teamsRoutes.Get("/", auth(), policy.Require(policy.ActionRead, policy.ResourceTeams), h.GetTeams)
+	teamsRoutes.Get("/:id", auth(), policy.Require(policy.ActionRead, policy.ResourceTeams), h.GetTeam)
+	teamsRoutes.Patch("/:id", auth(), policy.Require(policy.ActionUpdate, policy.ResourceTeams), h.UpdateTeam)
+	teamsRoutes.Post("/:id/transfer-ownership", auth(), policy.Require(policy.ActionUpdate, policy.ResourceTeams), h.TransferTeamOwnership)
+	teamsRoutes.Delete("/:id", auth(), policy.Require(policy.ActionDelete, policy.ResourceTeams), h.DeleteTeam)
+	teamsRoutes.Get("/:id/members", auth(), policy.Require(policy.ActionRead, policy.ResourceTeams), h.GetTeamMembers)
+	teamsRoutes.Post("/:id/members", auth(), policy.Require(policy.ActionUpdate, policy.ResourceTeams), h.AddTeamMember)
+	teamsRoutes.Delete("/:id/members/:userId", auth(), policy.Require(policy.ActionUpdate, policy.ResourceTeams), h.RemoveTeamMember)
+
+	organizationsRoutes := v1.Group("/organizations")
+	organizationsRoutes.Get("/", auth(), policy.Require(policy.ActionRead, policy.ResourceOrganizations), h.GetOrganizations)
+	organizationsRoutes.Post("/", auth(), policy.Require(policy.ActionCreate, policy.ResourceOrganizations), h.CreateOrganization)
+	organizationsRoutes.Get("/:id", auth(), policy.Require(policy.ActionRead, policy.ResourceOrganizations), h.GetOrganization)
+	organizationsRoutes.Patch("/:id", auth(), policy.Require(policy.ActionUpdate, policy.ResourceOrganizations), h.UpdateOrganization)
+	organizationsRoutes.Get("/:id/teams", auth(), policy.Require(policy.ActionRead, policy.ResourceOrganizations), h.GetOrganizationTeams)
+	organizationsRoutes.Get("/:id/users", auth(), policy.Require(policy.ActionRead, policy.ResourceOrganizations), h.GetOrganizationUsers)
+	organizationsRoutes.Get("/:id/report", auth(), policy.Require(policy.ActionRead, policy.ResourceOrganizations), h.GetOrganizationReport)
+	organizationsRoutes.Get("/:id/admins", auth(), policy.Require(policy.ActionRead, policy.ResourceOrganizations), h.GetOrganizationAdmins)
+	organizationsRoutes.Post("/:id/admins", auth(), policy.Require(policy.ActionUpdate, policy.ResourceOrganizations), h.AddOrganizationAdmin)
+	organizationsRoutes.Delete("/:id/admins/:userId", auth(), policy.Require(policy.ActionUpdate, policy.ResourceOrganizations), h.RemoveOrganizationAdmin)
+
+	rolesRoutes := v1.Group("/roles")
+	rolesRoutes.Get("/", auth(), policy.Require(policy.ActionRead, policy.ResourceRoles), h.GetRoles)
+	rolesRoutes.Post("/", auth(), policy.Require(policy.ActionCreate, policy.ResourceRoles), h.CreateRole)
+	rolesRoutes.Get("/:id", auth(), policy.Require(policy.ActionRead, policy.ResourceRoles), h.GetRole)
+	rolesRoutes.Put("/:id", auth(), policy.Require(policy.ActionUpdate, policy.ResourceRoles), h.UpdateRole)
+	rolesRoutes.Delete("/:id", auth(), policy.Require(policy.ActionDelete, policy.ResourceRoles), h.DeleteRole)
+
+	projectRoutes := v1.Group("/projects")
+	projectRoutes.Get("/", auth(models.ScopeProjectsRead), policy.Require(policy.ActionRead, policy.ResourceProjects), h.GetProjects)
+	projectRoutes.Post("/", auth(models.ScopeProjectsWrite), policy.Require(policy.ActionCreate, policy.ResourceProjects), h.CreateProject)
+	projectRoutes.Put("/:id", auth(models.ScopeProjectsWrite), policy.Require(policy.ActionUpdate, policy.ResourceProjects), h.UpdateProject)
+	projectRoutes.Delete("/:id", auth(models.ScopeProjectsWrite), policy.Require(policy.ActionDelete, policy.ResourceProjects), h.DeleteProject)
+	projectRoutes.Get("/:id/members", auth(models.ScopeProjectsRead), policy.Require(policy.ActionRead, policy.ResourceProjects), h.GetProjectMembers)
+	projectRoutes.Post("/:id/members", auth(models.ScopeProjectsWrite), policy.Require(policy.ActionUpdate, policy.ResourceProjects), h.AddProjectMember)
+	projectRoutes.Delete("/:id/members/:userId", auth(models.ScopeProjectsWrite), policy.Require(policy.ActionUpdate, policy.ResourceProjects), h.RemoveProjectMember)
+
+	taskRoutes := v1.Group("/tasks")
+	taskRoutes.Post("/", auth(models.ScopeTasksWrite), policy.Require(policy.ActionCreate, policy.ResourceTasks), h.CreateTask)
+	taskRoutes.Get("/", auth(models.ScopeTasksRead), policy.Require(policy.ActionRead, policy.ResourceTasks), h.GetTasks)
+	taskRoutes.Put("/:id", auth(models.ScopeTasksWrite), policy.Require(policy.ActionUpdate, policy.ResourceTasks), h.UpdateTask)
+	taskRoutes.Delete("/:id", auth(models.ScopeTasksWrite), policy.Require(policy.ActionDelete, policy.ResourceTasks), h.DeleteTask)
+
+	authRoutes.Delete("/logout", auth(), h.LogOut)
+	authRoutes.Post("/switch-team", auth(), h.SwitchTeam)
+	authRoutes.Get("/sessions", auth(), policy.Require(policy.ActionRead, policy.ResourceSessions), h.GetSessions)
+	authRoutes.Delete("/sessions/:id", auth(), policy.Require(policy.ActionDelete, policy.ResourceSessions), h.DeleteSession)
+	authRoutes.Post("/mfa/totp", auth(), policy.Require(policy.ActionUpdate, policy.ResourceProfile), h.StartTotp)
+	authRoutes.Post("/mfa/totp/confirm", auth(), policy.Require(policy.ActionUpdate, policy.ResourceProfile), h.ConfirmTotp)
+	authRoutes.Delete("/mfa/totp", auth(), policy.Require(policy.ActionUpdate, policy.ResourceProfile), h.DisableTotp)
+	authRoutes.Post("/mfa/recovery-codes", auth(), policy.Require(policy.ActionUpdate, policy.ResourceProfile), h.RegenerateRecoveryCodes)
+	authRoutes.Post("/tokens", auth(), policy.Require(policy.ActionCreate, policy.ResourcePersonalAccessTokens), h.CreatePersonalAccessToken)
+	authRoutes.Get("/tokens", auth(), policy.Require(policy.ActionRead, policy.ResourcePersonalAccessTokens), h.GetPersonalAccessTokens)
+	authRoutes.Delete("/tokens/:id", auth(), policy.Require(policy.ActionDelete, policy.ResourcePersonalAccessTokens), h.DeletePersonalAccessToken)
 }
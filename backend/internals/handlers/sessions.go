@@ -0,0 +1,330 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/policy"
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// startSession registers a new device session for the user and issues its first access and refresh tokens,
+// the access token is also set as a cookie together with its csrf token
+func (h *Handler) startSession(c *fiber.Ctx, user models.User, label string) (accessToken string, refreshToken string, err error) {
+	userAgent := c.Get(fiber.HeaderUserAgent)
+
+	if label == "" {
+		label = userAgent
+	}
+
+	if label == "" {
+		label = "Unknown device"
+	}
+
+	now := time.Now().UTC()
+
+	session, err := h.sessionRepository.CreateSession(c.Context(), models.Session{
+		ID:         uuid.New(),
+		UserID:     user.ID,
+		Label:      label,
+		UserAgent:  userAgent,
+		IpAddress:  c.IP(),
+		TeamID:     user.TeamId,
+		CreatedAt:  now,
+		LastUsedAt: now,
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	accessToken, err = utils.GenerateJWTToken(user, session.ID)
+	if err != nil {
+		return "", "", err
+	}
+
+	refreshToken, expiresAt, err := utils.GenerateJWTRefreshToken(user, session.ID)
+	if err != nil {
+		return "", "", err
+	}
+
+	err = h.refreshTokenRepository.CreateRefreshToken(c.Context(), models.RefreshToken{
+		ID:        uuid.New(),
+		CreatedAt: now,
+		ExpiresAt: expiresAt,
+		Userid:    user.ID,
+		Token:     refreshToken,
+		Revoked:   false,
+		FamilyID:  session.ID,
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	csrfToken, err := utils.GenerateCSRFToken(user, session.ID)
+	if err != nil {
+		return "", "", err
+	}
+
+	utils.GenerateCookie(c, accessToken, csrfToken)
+
+	return accessToken, refreshToken, nil
+}
+
+// GetSessions godoc
+// @Summary List sessions
+// @Description List the active sessions of the current user, one per logged in device
+// @Tags Sessions
+// @Accept json
+// @Produce json
+// @Success 200 {object} interfaces.SessionsListResponse
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /auth/sessions [get]
+func (h *Handler) GetSessions(c *fiber.Ctx) error {
+	userId := c.Locals("userId")
+	currentSessionId, _ := c.Locals("sessionId").(string)
+
+	userUUID, err := uuid.Parse(userId.(string))
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	sessions, err := h.sessionRepository.GetSessionsByUserId(c.Context(), userUUID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	data := []interfaces.SessionResponse{}
+
+	for _, s := range sessions {
+		data = append(data, interfaces.SessionResponse{
+			ID:         s.ID,
+			Label:      s.Label,
+			UserAgent:  s.UserAgent,
+			IpAddress:  s.IpAddress,
+			CreatedAt:  s.CreatedAt,
+			LastUsedAt: s.LastUsedAt,
+			Current:    s.ID.String() == currentSessionId,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": data,
+	})
+}
+
+// DeleteSession godoc
+// @Summary Revoke a session
+// @Description Revoke one of the current user's sessions, the device has to log in again
+// @Tags Sessions
+// @Accept json
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} interfaces.DeleteSessionResponse
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID"
+// @Failure 404 {object} utils.ErrorResponse "Session not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /auth/sessions/{id} [delete]
+func (h *Handler) DeleteSession(c *fiber.Ctx) error {
+	sessionId := c.Params("id")
+
+	if sessionId == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid id"))
+	}
+
+	sessionUUID, err := uuid.Parse(sessionId)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	session, err := h.sessionRepository.GetSessionById(c.Context(), sessionUUID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("session not found"))
+	}
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	// The sessions of other users are reported as missing
+	if err := policy.Authorize(c.Context(), policy.ActionDelete, policy.Session(session)); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("session not found"))
+	}
+
+	err = h.sessionRepository.DeleteSession(c.Context(), sessionUUID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	h.revokeSession(sessionUUID)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"deleted": true,
+	})
+}
+
+// RevokeUserSessions godoc
+// @Summary Revoke all sessions of a user
+// @Description Log a user of the admin's team out of every device (Admin only)
+// @Tags Sessions
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} interfaces.RevokeSessionsResponse
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} utils.ErrorResponse "User not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /users/{id}/sessions [delete]
+func (h *Handler) RevokeUserSessions(c *fiber.Ctx) error {
+	if err := policy.Authorize(c.Context(), policy.ActionRevokeSessions, policy.Collection(policy.ResourceUsers)); err != nil {
+		return policy.Forbidden(c)
+	}
+
+	targetId := c.Params("id")
+
+	if targetId == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid id"))
+	}
+
+	targetUUID, err := uuid.Parse(targetId)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if _, err := h.authorizeUser(c, policy.ActionRevokeSessions, targetUUID); err != nil {
+		return policy.Deny(c, err, "user not found")
+	}
+
+	err = h.sessionRepository.DeleteSessionsByUserId(c.Context(), targetUUID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	h.syncRevokedSessionsAfter(c)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"revoked": true,
+	})
+}
+
+// SwitchTeam godoc
+// @Summary Switch the team of the session
+// @Description Work in another team the current user is a member of. The session keeps the team, its access tokens carry it with the role the user has in it
+// @Tags Sessions
+// @Accept json
+// @Produce json
+// @Param request body interfaces.SwitchTeamRequest true "Team to work in"
+// @Success 200 {object} interfaces.SwitchTeamResponse
+// @Failure 400 {object} utils.ErrorResponse "Validation error"
+// @Failure 403 {object} utils.ErrorResponse "The team requires two-factor authentication"
+// @Failure 404 {object} utils.ErrorResponse "Team not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /auth/switch-team [post]
+func (h *Handler) SwitchTeam(c *fiber.Ctx) error {
+	userUUID, err := uuid.Parse(c.Locals("userId").(string))
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	sessionUUID, err := uuid.Parse(c.Locals("sessionId").(string))
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	payload := interfaces.SwitchTeamRequest{}
+
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if err := h.validator.Validate(payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
+	}
+
+	user, err := h.userRepository.GetUserById(c.Context(), userUUID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("user not found"))
+	}
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	// Teams the user isn't a member of are reported as missing
+	exists, member, err := h.teamRepository.GetTeamMember(c.Context(), payload.TeamId, userUUID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if !exists {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("team not found"))
+	}
+
+	team, err := h.teamRepository.GetTeamById(c.Context(), payload.TeamId)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	// Users log in to their default team, teams that require 2FA are only reachable with it enabled
+	if team.RequireMfa {
+		totp, err := h.mfaRepository.GetUserTotp(c.Context(), userUUID)
+
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+		}
+
+		if err != nil || !totp.EnabledAt.Valid {
+			return c.Status(fiber.StatusForbidden).JSON(utils.ErrorString("the team requires two-factor authentication, enable it first"))
+		}
+	}
+
+	err = h.sessionRepository.UpdateSessionTeam(c.Context(), interfaces.UpdateSessionTeamData{
+		TeamID: payload.TeamId,
+		ID:     sessionUUID,
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	user = user.InTeam(member)
+
+	accessToken, err := utils.GenerateJWTToken(user, sessionUUID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	csrfToken, err := utils.GenerateCSRFToken(user, sessionUUID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	utils.GenerateCookie(c, accessToken, csrfToken)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"accessToken": accessToken,
+		"user":        user,
+	})
+}
@@ -3,35 +3,62 @@ package handlers
 import (
 	"database/sql"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/policy"
 	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
 	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
 
+// taskResource loads the project of the task and its members, the owners of the project own the task
+func (h *Handler) taskResource(c *fiber.Ctx, task models.Task) (policy.Resource, error) {
+	project, err := h.projectRepository.GetProjectById(c.Context(), task.ProjectID)
+
+	if err != nil {
+		return policy.Resource{}, err
+	}
+
+	members, err := h.projectRepository.GetProjectMembers(c.Context(), project.ID)
+
+	if err != nil {
+		return policy.Resource{}, err
+	}
+
+	return policy.Task(task, project, members), nil
+}
+
+// canBeAssigned tells if tasks of the project can be assigned to the user, only its owners and
+// contributors can
+func (h *Handler) canBeAssigned(c *fiber.Ctx, projectId uuid.UUID, userId uuid.UUID) (bool, error) {
+	exists, member, err := h.projectRepository.GetProjectMember(c.Context(), projectId, userId)
+
+	if err != nil {
+		return false, err
+	}
+
+	return exists && member.CanBeAssigned(), nil
+}
+
 // CreateTask godoc
 // @Summary Create a new task
-// @Description Create a new task (Manager only)
+// @Description Create a new task (must be an owner of the project or an admin of the team). Tasks can only be assigned to owners and contributors of the project
 // @Tags Tasks
 // @Accept json
 // @Produce json
 // @Param request body interfaces.CreateTaksPayload true "Task creation data"
 // @Success 201 {object} interfaces.GetTasksResponse
-// @Failure 400 {object} utils.ErrorResponse "Validation error"
-// @Failure 403 {object} utils.ErrorResponse "Forbidden - Manager only"
-// @Failure 404 {object} utils.ErrorResponse "Project not found"
+// @Failure 400 {object} utils.ErrorResponse "Validation error or the user is not a contributor of the project"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Must be an owner of the project"
+// @Failure 404 {object} utils.ErrorResponse "Project or user not found"
 // @Failure 500 {object} utils.ErrorResponse "Internal server error"
 // @Security BearerAuth
 // @Router /tasks [post]
 func (h *Handler) CreateTask(c *fiber.Ctx) error {
-	userRole := c.Locals("userRole")
-	if userRole != "Manager" {
-		return c.Status(fiber.StatusForbidden).JSON(utils.ErrorString("unauthorized"))
-	}
-
 	payload := interfaces.CreateTaksPayload{}
 
 	if err := c.BodyParser(&payload); err != nil {
@@ -60,6 +87,8 @@ func (h *Handler) CreateTask(c *fiber.Ctx) error {
 		}
 	}
 
+	project, err := h.projectRepository.GetProjectById(c.Context(), projectUUID)
+
 	if errors.Is(err, sql.ErrNoRows) {
 		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("project not found"))
 	}
@@ -68,6 +97,37 @@ func (h *Handler) CreateTask(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
 	}
 
+	members, err := h.projectRepository.GetProjectMembers(c.Context(), project.ID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if err := h.authorize(c, policy.ActionCreate, policy.ProjectTasks(project, members)); err != nil {
+		return policy.Deny(c, err, "project not found")
+	}
+
+	// Tasks can only be assigned to users of the team contributing to the project
+	if userUUID != uuid.Nil {
+		if _, err := h.authorizeUser(c, policy.ActionRead, userUUID); err != nil {
+			return policy.Deny(c, err, "user not found")
+		}
+
+		assignable, err := h.canBeAssigned(c, projectUUID, userUUID)
+
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+		}
+
+		if !assignable {
+			return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("the user is not a contributor of the project"))
+		}
+	}
+
+	if err := h.recordAdminChanges(c); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
 	task, err := h.taskRepository.CreateTask(c.Context(), database.CreateTasksParams{
 		CreatedAt: time.Now().UTC(),
 		UpdatedAt: time.Now().UTC(),
@@ -91,7 +151,7 @@ func (h *Handler) CreateTask(c *fiber.Ctx) error {
 
 // GetTasks godoc
 // @Summary Get all tasks
-// @Description Get paginated list of tasks. Members can only see their own tasks.
+// @Description Get paginated list of tasks. Members see the tasks of the projects they are members of and the ones assigned to them, only projects of the team of the user can be listed.
 // @Tags Tasks
 // @Accept json
 // @Produce json
@@ -101,6 +161,7 @@ func (h *Handler) CreateTask(c *fiber.Ctx) error {
 // @Param limit query int true "Number of items per page"
 // @Success 200 {object} interfaces.TasksListResponse
 // @Failure 400 {object} utils.ErrorResponse "Bad request - projectId required for Admin/Manager"
+// @Failure 404 {object} utils.ErrorResponse "Project not found"
 // @Failure 500 {object} utils.ErrorResponse "Internal server error"
 // @Security BearerAuth
 // @Router /tasks [get]
@@ -112,14 +173,31 @@ func (h *Handler) GetTasks(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.NewError(err))
 	}
 
-	userRole := c.Locals("userRole")
+	if _, err := h.callerTeamId(c); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	subject := policy.SubjectFromContext(c.Context())
+
+	if !policy.Allows(subject, policy.ActionRead, policy.ResourceTasks) {
+		return policy.Forbidden(c)
+	}
+
+	// Roles that can't read every task only list the ones assigned to them
+	listAll := policy.Can(subject, policy.ActionRead, policy.Collection(policy.ResourceTasks))
 
-	if userRole != "Member" && queryParams.ProjectId == "" {
+	if listAll && queryParams.ProjectId == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("projectId required"))
 	}
 
 	var err error
 	var projectUUID uuid.UUID
+	var userUUID uuid.UUID
+	var memberUUID uuid.UUID
+
+	if !listAll {
+		userUUID = subject.ID
+	}
 
 	if queryParams.ProjectId != "" {
 		projectUUID, err = uuid.Parse(queryParams.ProjectId)
@@ -127,17 +205,36 @@ func (h *Handler) GetTasks(c *fiber.Ctx) error {
 		if err != nil {
 			return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
 		}
-	}
 
-	var userUUID uuid.UUID
+		project, err := h.projectRepository.GetProjectById(c.Context(), projectUUID)
 
-	if userRole == "Member" {
-		userId := c.Locals("userId")
-		userUUID, err = uuid.Parse(userId.(string))
+		if errors.Is(err, sql.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("project not found"))
+		}
 
 		if err != nil {
 			return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
 		}
+
+		members, err := h.projectRepository.GetProjectMembers(c.Context(), project.ID)
+
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+		}
+
+		resource := policy.ProjectTasks(project, members)
+
+		if !policy.InTeam(subject, resource) {
+			return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("project not found"))
+		}
+
+		// Members of the project see all of its tasks
+		if policy.Can(subject, policy.ActionRead, resource) {
+			userUUID = uuid.Nil
+		}
+	} else if !listAll && policy.Can(subject, policy.ActionRead, policy.MemberResources(policy.ResourceTasks, subject.ID)) {
+		userUUID = uuid.Nil
+		memberUUID = subject.ID
 	}
 
 	cursor := queryParams.Cursor
@@ -173,6 +270,7 @@ func (h *Handler) GetTasks(c *fiber.Ctx) error {
 		Title:           queryParams.Title,
 		ProjectId:       projectUUID,
 		UserId:          userUUID,
+		MemberId:        memberUUID,
 	})
 
 	if err != nil {
@@ -231,27 +329,21 @@ func (h *Handler) GetTasks(c *fiber.Ctx) error {
 
 // UpdateTask godoc
 // @Summary Update a task
-// @Description Update task information (Manager only)
+// @Description Update task information (must be an owner of the project or an admin of the team). Tasks can only be assigned to owners and contributors of the project. Members can change the status of the tasks assigned to them and add to their description, sending the rest of the task as it is
 // @Tags Tasks
 // @Accept json
 // @Produce json
 // @Param id path string true "Task ID"
 // @Param request body interfaces.UpdateTaskPayload true "Task update data"
 // @Success 200 {object} interfaces.GetTasksResponse
-// @Failure 400 {object} utils.ErrorResponse "Validation error"
-// @Failure 403 {object} utils.ErrorResponse "Forbidden - Manager only"
-// @Failure 404 {object} utils.ErrorResponse "Task not found"
+// @Failure 400 {object} utils.ErrorResponse "Validation error or the user is not a contributor of the project"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Must be an owner of the project, or the assignee changing only the status and the description"
+// @Failure 404 {object} utils.ErrorResponse "Task or user not found"
 // @Failure 500 {object} utils.ErrorResponse "Internal server error"
 // @Security BearerAuth
 // @Router /tasks/{id} [put]
 func (h *Handler) UpdateTask(c *fiber.Ctx) error {
 
-	userRole := c.Locals("userRole")
-
-	if userRole != "Manager" {
-		return c.Status(fiber.StatusForbidden).JSON(utils.ErrorString("unauthorized"))
-	}
-
 	taskId := c.Params("id")
 
 	if taskId == "" {
@@ -264,16 +356,26 @@ func (h *Handler) UpdateTask(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
 	}
 
-	_, err = h.taskRepository.GetTaskById(c.Context(), taskUUID)
+	existingTask, err := h.taskRepository.GetTaskById(c.Context(), taskUUID)
 
 	if errors.Is(err, sql.ErrNoRows) {
-		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("project not found"))
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("task not found"))
+	}
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
 	}
 
+	resource, err := h.taskResource(c, existingTask)
+
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
 	}
 
+	if err := h.authorize(c, policy.ActionUpdate, resource); err != nil {
+		return policy.Deny(c, err, "task not found")
+	}
+
 	payload := interfaces.UpdateTaskPayload{}
 
 	if err := c.BodyParser(&payload); err != nil {
@@ -286,6 +388,32 @@ func (h *Handler) UpdateTask(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
 	}
 
+	// Assignees move their tasks along, the rest of the task stays as its owners left it
+	if policy.OnlyAsAssignee(policy.SubjectFromContext(c.Context()), policy.ActionUpdate, resource) && !onlyStatusOrDescriptionChange(existingTask, payload) {
+		return c.Status(fiber.StatusForbidden).JSON(utils.ErrorString("assignees can only change the status and add to the description"))
+	}
+
+	// Only a new assignee is checked, the one the task has stays assigned
+	if payload.UserId != uuid.Nil && payload.UserId != existingTask.UserID.UUID {
+		if _, err := h.authorizeUser(c, policy.ActionRead, payload.UserId); err != nil {
+			return policy.Deny(c, err, "user not found")
+		}
+
+		assignable, err := h.canBeAssigned(c, existingTask.ProjectID, payload.UserId)
+
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+		}
+
+		if !assignable {
+			return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("the user is not a contributor of the project"))
+		}
+	}
+
+	if err := h.recordAdminChanges(c); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
 	updatedTask, err := h.taskRepository.UpdateTask(c.Context(), interfaces.UpdateTaskData{
 		Title: payload.Title,
 		Description: sql.NullString{
@@ -307,27 +435,29 @@ func (h *Handler) UpdateTask(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(updatedTask)
 }
 
+// onlyStatusOrDescriptionChange tells if the payload keeps the title and the assignee of the task, and
+// its description, which can only be added to
+func onlyStatusOrDescriptionChange(task models.Task, payload interfaces.UpdateTaskPayload) bool {
+	return payload.Title == task.Title &&
+		payload.UserId == task.UserID.UUID &&
+		strings.HasPrefix(payload.Description, task.Description.String)
+}
+
 // DeleteTask godoc
 // @Summary Delete a task
-// @Description Delete a task by ID (Manager only)
+// @Description Delete a task by ID (must be an owner of the project or an admin of the team)
 // @Tags Tasks
 // @Accept json
 // @Produce json
 // @Param id path string true "Task ID"
 // @Success 200 {object} interfaces.DeleteTaskResponse
 // @Failure 400 {object} utils.ErrorResponse "Invalid ID"
-// @Failure 403 {object} utils.ErrorResponse "Forbidden - Manager only"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Must be an owner of the project"
 // @Failure 404 {object} utils.ErrorResponse "Task not found"
 // @Failure 500 {object} utils.ErrorResponse "Internal server error"
 // @Security BearerAuth
 // @Router /tasks/{id} [delete]
 func (h *Handler) DeleteTask(c *fiber.Ctx) error {
-	userRole := c.Locals("userRole")
-
-	if userRole != "Manager" {
-		return c.Status(fiber.StatusForbidden).JSON(utils.ErrorString("unauthorized"))
-	}
-
 	taskId := c.Params("id")
 
 	if taskId == "" {
@@ -340,6 +470,30 @@ func (h *Handler) DeleteTask(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
 	}
 
+	existingTask, err := h.taskRepository.GetTaskById(c.Context(), taskUUID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("task not found"))
+	}
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	resource, err := h.taskResource(c, existingTask)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if err := h.authorize(c, policy.ActionDelete, resource); err != nil {
+		return policy.Deny(c, err, "task not found")
+	}
+
+	if err := h.recordAdminChanges(c); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
 	err = h.taskRepository.DeleteTask(c.Context(), taskUUID)
 
 	if err != nil {
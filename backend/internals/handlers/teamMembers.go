@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/policy"
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// GetTeamMembers godoc
+// @Summary List the members of a team
+// @Description List the users of a team with the role each one has in it, wherever their default team is (Admin only, must own the team or administer its organization)
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Param id path string true "Team ID"
+// @Success 200 {object} interfaces.TeamMembersListResponse
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Must own the team or administer its organization"
+// @Failure 404 {object} utils.ErrorResponse "Team not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /teams/{id}/members [get]
+func (h *Handler) GetTeamMembers(c *fiber.Ctx) error {
+	teamId := c.Params("id")
+
+	if teamId == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid id"))
+	}
+
+	teamUUID, err := uuid.Parse(teamId)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if _, err := h.authorizeTeam(c, policy.ActionRead, teamUUID); err != nil {
+		return policy.Deny(c, err, "team not found")
+	}
+
+	members, err := h.teamRepository.GetTeamMembers(c.Context(), teamUUID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": members,
+	})
+}
+
+// AddTeamMember godoc
+// @Summary Add a member to a team
+// @Description Add an existing user to a team as a manager or a member, optionally with a custom role of the team. The team of the user doesn't change, they switch to this one from their session (Admin only, must own the team or administer its organization)
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Param id path string true "Team ID"
+// @Param request body interfaces.AddTeamMemberPayload true "Member data"
+// @Success 201 {object} interfaces.TeamMemberResponse
+// @Failure 400 {object} utils.ErrorResponse "Validation error or the admin role was given"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Must own the team or administer its organization"
+// @Failure 404 {object} utils.ErrorResponse "Team, user or role not found"
+// @Failure 409 {object} utils.ErrorResponse "User is already a member of the team"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /teams/{id}/members [post]
+func (h *Handler) AddTeamMember(c *fiber.Ctx) error {
+	teamId := c.Params("id")
+
+	if teamId == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid id"))
+	}
+
+	teamUUID, err := uuid.Parse(teamId)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if _, err := h.authorizeTeam(c, policy.ActionUpdate, teamUUID); err != nil {
+		return policy.Deny(c, err, "team not found")
+	}
+
+	payload := interfaces.AddTeamMemberPayload{}
+
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	err = h.validator.Validate(payload)
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
+	}
+
+	// Members can come from any team, they are looked up by their email
+	user, err := h.userRepository.GetUserByEmail(c.Context(), payload.Email)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("user not found"))
+	}
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	exists, _, err := h.teamRepository.GetTeamMember(c.Context(), teamUUID, user.ID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if exists {
+		return c.Status(fiber.StatusConflict).JSON(utils.ErrorString("the user is already a member of the team"))
+	}
+
+	roleId := models.BuiltInRoleIds[payload.Role]
+
+	if payload.RoleId != uuid.Nil {
+		role, err := h.roleRepository.GetRoleById(c.Context(), payload.RoleId)
+
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+		}
+
+		// Custom roles of other teams are reported as missing
+		if err != nil || (!role.BuiltIn && role.TeamID.UUID != teamUUID) {
+			return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("role not found"))
+		}
+
+		if role.BaseRole == models.UserrolesAdmin {
+			return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("the admin role can't be assigned"))
+		}
+
+		// A built-in role also sets the base role, custom roles keep the one given
+		if role.BuiltIn {
+			payload.Role = role.BaseRole
+		}
+
+		roleId = role.ID
+	}
+
+	member, err := h.teamRepository.AddTeamMember(c.Context(), models.TeamMember{
+		TeamID:    teamUUID,
+		UserID:    user.ID,
+		Role:      payload.Role,
+		RoleId:    roleId,
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	member.Username = user.Username
+	member.Email = user.Email
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"member": member,
+	})
+}
+
+// RemoveTeamMember godoc
+// @Summary Remove a member from a team
+// @Description Remove a user from a team. Their tasks in it are unassigned, they leave its projects and their sessions in it are logged out. Users whose default team it was move to their next team (Admin only, must own the team or administer its organization)
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Param id path string true "Team ID"
+// @Param userId path string true "User ID"
+// @Success 200 {object} interfaces.MessageResponse
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID, the user owns the team or is the only owner of projects of the team"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Must own the team or administer its organization"
+// @Failure 404 {object} utils.ErrorResponse "Team or member not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /teams/{id}/members/{userId} [delete]
+func (h *Handler) RemoveTeamMember(c *fiber.Ctx) error {
+	teamId := c.Params("id")
+	userId := c.Params("userId")
+
+	if teamId == "" || userId == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid id"))
+	}
+
+	teamUUID, err := uuid.Parse(teamId)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	userUUID, err := uuid.Parse(userId)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	team, err := h.authorizeTeam(c, policy.ActionUpdate, teamUUID)
+
+	if err != nil {
+		return policy.Deny(c, err, "team not found")
+	}
+
+	if userUUID == team.OwnerID {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("the owner can't leave the team, transfer its ownership first"))
+	}
+
+	exists, _, err := h.teamRepository.GetTeamMember(c.Context(), teamUUID, userUUID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if !exists {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("member not found"))
+	}
+
+	// Projects of the team can't be left without an owner
+	ownedProjects, err := h.teamRepository.CountProjectsOnlyOwnedBy(c.Context(), teamUUID, userUUID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if ownedProjects > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("the user is the only owner of projects of the team, add another owner first"))
+	}
+
+	err = h.teamRepository.RemoveTeamMember(c.Context(), interfaces.RemoveTeamMemberData{
+		TeamID:    teamUUID,
+		UserID:    userUUID,
+		UpdatedAt: time.Now().UTC(),
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	h.syncRevokedSessionsAfter(c)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"deleted": true,
+	})
+}
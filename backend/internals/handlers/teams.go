@@ -5,8 +5,9 @@ import (
 	"errors"
 	"time"
 
-	_ "github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
 	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/policy"
 	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -14,7 +15,7 @@ import (
 
 // CreateTeam godoc
 // @Summary Create a new team
-// @Description Create a new team (Admin only)
+// @Description Create a new team owned by the current user, admins can own several. The team goes in organizationId, which the user has to administer, and teams created without one get an organization of their own (Admin only)
 // @Tags Teams
 // @Accept json
 // @Produce json
@@ -22,21 +23,18 @@ import (
 // @Success 201 {object} models.Team
 // @Failure 400 {object} utils.ErrorResponse "Validation error"
 // @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin only"
-// @Failure 404 {object} utils.ErrorResponse "User not found"
-// @Failure 409 {object} utils.ErrorResponse "User already has a team"
+// @Failure 404 {object} utils.ErrorResponse "User or organization not found"
 // @Failure 500 {object} utils.ErrorResponse "Internal server error"
 // @Security BearerAuth
 // @Router /teams [post]
 func (h *Handler) CreateTeam(c *fiber.Ctx) error {
 	userId := c.Locals("userId")
-	userRole := c.Locals("userRole")
-	if userRole != "Admin" {
-		return c.Status(fiber.StatusForbidden).JSON(utils.ErrorString("unauthorized"))
+
+	if err := policy.Authorize(c.Context(), policy.ActionCreate, policy.Collection(policy.ResourceTeams)); err != nil {
+		return policy.Forbidden(c)
 	}
 
-	payload := struct {
-		Name string `json:"name" validate:"required"`
-	}{}
+	payload := interfaces.CreateTeamRequest{}
 
 	if err := c.BodyParser(&payload); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
@@ -64,23 +62,27 @@ func (h *Handler) CreateTeam(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
 	}
 
-	alreadyExists, _, err := h.teamRepository.GetTeamByOwner(c.Context(), userUUID)
-
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
-	}
-
-	if alreadyExists {
-		return c.Status(fiber.StatusConflict).JSON(utils.ErrorString("user already has a team with that name"))
-	}
-
-	team, err := h.teamRepository.CreateTeam(c.Context(), models.Team{
+	newTeam := models.Team{
 		ID:        uuid.New(),
 		CreatedAt: time.Now().UTC(),
 		UpdatedAt: time.Now().UTC(),
 		Name:      payload.Name,
 		OwnerID:   userUUID,
-	})
+	}
+
+	// Teams of an organization require two-factor authentication when it does
+	if payload.OrganizationId != uuid.Nil {
+		organization, err := h.authorizeOrganization(c, policy.ActionUpdate, payload.OrganizationId)
+
+		if err != nil {
+			return policy.Deny(c, err, "organization not found")
+		}
+
+		newTeam.OrganizationID = organization.ID
+		newTeam.RequireMfa = organization.RequireMfa
+	}
+
+	team, err := h.teamRepository.CreateTeam(c.Context(), newTeam)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
 	}
@@ -88,9 +90,35 @@ func (h *Handler) CreateTeam(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusCreated).JSON(team)
 }
 
+// ownedTeam returns the team the admin of the request owns: the team the session works in, or the oldest
+// one it owns for tokens that don't carry one. It doesn't exist when the admin doesn't own the team it works in
+func (h *Handler) ownedTeam(c *fiber.Ctx, userUUID uuid.UUID) (exists bool, team models.Team, err error) {
+	teamId, ok := tokenTeamId(c)
+
+	if !ok {
+		return h.teamRepository.GetTeamByOwner(c.Context(), userUUID)
+	}
+
+	team, err = h.teamRepository.GetTeamById(c.Context(), teamId)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, models.Team{}, nil
+	}
+
+	if err != nil {
+		return false, models.Team{}, err
+	}
+
+	if team.OwnerID != userUUID {
+		return false, models.Team{}, nil
+	}
+
+	return true, team, nil
+}
+
 // GetTeamByOwner godoc
 // @Summary Get team by owner
-// @Description Get the team owned by the current user (Admin only)
+// @Description Get the team owned by the current user that the session works in (Admin only)
 // @Tags Teams
 // @Accept json
 // @Produce json
@@ -102,11 +130,6 @@ func (h *Handler) CreateTeam(c *fiber.Ctx) error {
 func (h *Handler) GetTeamByOwner(c *fiber.Ctx) error {
 
 	userId := c.Locals("userId")
-	userRole := c.Locals("userRole")
-
-	if userRole != "Admin" {
-		return c.Status(fiber.StatusForbidden).JSON(utils.ErrorString("unauthorized"))
-	}
 
 	userUUID, err := uuid.Parse(userId.(string))
 
@@ -114,7 +137,11 @@ func (h *Handler) GetTeamByOwner(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
 	}
 
-	exists, team, err := h.teamRepository.GetTeamByOwner(c.Context(), userUUID)
+	if err := policy.Authorize(c.Context(), policy.ActionRead, policy.TeamsOwnedBy(userUUID)); err != nil {
+		return policy.Forbidden(c)
+	}
+
+	exists, team, err := h.ownedTeam(c, userUUID)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
 	}
@@ -131,3 +158,477 @@ func (h *Handler) GetTeamByOwner(c *fiber.Ctx) error {
 		"team":   team,
 	})
 }
+
+// UpdateTeamMfa godoc
+// @Summary Require two-factor authentication
+// @Description Require two-factor authentication for everyone in the team the admin works in, including the admin. Turning it on logs out the sessions of the team without it, members enroll on their next login. It can't be turned off while the organization of the team requires it (Admin only, must own the team)
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Param request body interfaces.UpdateTeamMfaRequest true "Two-factor requirement"
+// @Success 200 {object} interfaces.TeamResponse
+// @Failure 400 {object} utils.ErrorResponse "Validation error or the organization requires two-factor authentication"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} utils.ErrorResponse "Team not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /teams/mfa [put]
+func (h *Handler) UpdateTeamMfa(c *fiber.Ctx) error {
+	userId := c.Locals("userId")
+
+	userUUID, err := uuid.Parse(userId.(string))
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if err := policy.Authorize(c.Context(), policy.ActionUpdate, policy.TeamsOwnedBy(userUUID)); err != nil {
+		return policy.Forbidden(c)
+	}
+
+	payload := struct {
+		RequireMfa *bool `json:"requireMfa" validate:"required"`
+	}{}
+
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	err = h.validator.Validate(payload)
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
+	}
+
+	exists, team, err := h.ownedTeam(c, userUUID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if !exists {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("team not found"))
+	}
+
+	if !*payload.RequireMfa {
+		organization, err := h.organizationRepository.GetOrganizationById(c.Context(), team.OrganizationID)
+
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+		}
+
+		if organization.RequireMfa {
+			return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("the organization of the team requires two-factor authentication"))
+		}
+	}
+
+	team, err = h.teamRepository.SetTeamRequireMfa(c.Context(), interfaces.SetTeamRequireMfaData{
+		TeamID:     team.ID,
+		RequireMfa: *payload.RequireMfa,
+		UpdatedAt:  time.Now().UTC(),
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if team.RequireMfa {
+		h.syncRevokedSessionsAfter(c)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"team": team,
+	})
+}
+
+// authorizeTeam loads a team and checks the action on it, teams that don't exist or are owned by
+// another admin outside the organizations the user administers are policy.ErrNotFound
+func (h *Handler) authorizeTeam(c *fiber.Ctx, action policy.Action, teamUUID uuid.UUID) (models.Team, error) {
+	team, err := h.teamRepository.GetTeamById(c.Context(), teamUUID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Team{}, policy.ErrNotFound
+	}
+
+	if err != nil {
+		return models.Team{}, err
+	}
+
+	if err := h.authorize(c, action, policy.Team(team)); err != nil {
+		return models.Team{}, err
+	}
+
+	return team, nil
+}
+
+// GetTeams godoc
+// @Summary Get the teams of the user
+// @Description Get paginated list of the teams owned by the current user (Admin only)
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Param name query string false "Filter by name"
+// @Param cursor query string false "Pagination cursor"
+// @Param limit query int true "Number of items per page"
+// @Success 200 {object} interfaces.TeamsListResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad request"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /teams [get]
+func (h *Handler) GetTeams(c *fiber.Ctx) error {
+	userUUID, err := uuid.Parse(c.Locals("userId").(string))
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if err := policy.Authorize(c.Context(), policy.ActionRead, policy.TeamsOwnedBy(userUUID)); err != nil {
+		return policy.Forbidden(c)
+	}
+
+	queryParams := interfaces.GetTeamsParams{}
+
+	if err := c.QueryParser(&queryParams); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.NewError(err))
+	}
+
+	cursor := queryParams.Cursor
+	isFirstPage := true
+	var cursorCreatedAt time.Time
+	var cursorId uuid.UUID
+	pointsNext := false
+	if cursor != "" {
+		decodedCursor, err := utils.DecodeCursor(cursor)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+		}
+		cursorCreatedAt, err = time.Parse(time.RFC3339Nano, decodedCursor["created_at"].(string))
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+		}
+		cursorId, err = uuid.Parse(decodedCursor["id"].(string))
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+		}
+
+		pointsNext = decodedCursor["points_next"] == true
+
+		isFirstPage = false
+	}
+
+	teams, err := h.teamRepository.GetTeams(c.Context(), models.TeamFilter{
+		Limit:           queryParams.Limit,
+		IsFirstPage:     isFirstPage,
+		PointsNext:      pointsNext,
+		CursorCreatedAt: cursorCreatedAt,
+		CursorId:        cursorId,
+		Name:            queryParams.Name,
+		UserId:          userUUID,
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	hasPagination := len(teams) > int(queryParams.Limit)
+
+	if hasPagination {
+		if cursor == "" || pointsNext {
+			teams = teams[:int(queryParams.Limit)]
+		} else {
+			teams = teams[len(teams)-int(queryParams.Limit):]
+		}
+	}
+
+	if len(teams) == 0 {
+		pager := utils.GeneratePager(utils.Cursor{}, utils.Cursor{})
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"data":       teams,
+			"pagination": pager,
+		})
+	}
+
+	var nextCursor utils.Cursor
+	var prevCursor utils.Cursor
+
+	if cursor == "" {
+		if hasPagination {
+			nextCursor = utils.CreateCursor(teams[len(teams)-1].ID, teams[len(teams)-1].CreatedAt, true)
+		}
+	} else {
+		if pointsNext {
+			if hasPagination {
+				nextCursor = utils.CreateCursor(teams[len(teams)-1].ID, teams[len(teams)-1].CreatedAt, true)
+			}
+
+			prevCursor = utils.CreateCursor(teams[0].ID, teams[0].CreatedAt, false)
+		} else {
+			nextCursor = utils.CreateCursor(teams[len(teams)-1].ID, teams[len(teams)-1].CreatedAt, true)
+
+			if hasPagination {
+				prevCursor = utils.CreateCursor(teams[0].ID, teams[0].CreatedAt, false)
+			}
+		}
+	}
+
+	pager := utils.GeneratePager(nextCursor, prevCursor)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":       teams,
+		"pagination": pager,
+	})
+}
+
+// GetTeam godoc
+// @Summary Get a team
+// @Description Get a team owned by the current user, or of an organization the user administers, by ID (Admin only)
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Param id path string true "Team ID"
+// @Success 200 {object} interfaces.TeamResponse
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Must own the team or administer its organization"
+// @Failure 404 {object} utils.ErrorResponse "Team not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /teams/{id} [get]
+func (h *Handler) GetTeam(c *fiber.Ctx) error {
+	teamId := c.Params("id")
+
+	if teamId == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid id"))
+	}
+
+	teamUUID, err := uuid.Parse(teamId)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	team, err := h.authorizeTeam(c, policy.ActionRead, teamUUID)
+
+	if err != nil {
+		return policy.Deny(c, err, "team not found")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"team": team,
+	})
+}
+
+// UpdateTeam godoc
+// @Summary Rename a team
+// @Description Rename a team owned by the current user, or of an organization the user administers (Admin only)
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Param id path string true "Team ID"
+// @Param request body interfaces.UpdateTeamRequest true "Team data"
+// @Success 200 {object} interfaces.TeamResponse
+// @Failure 400 {object} utils.ErrorResponse "Validation error"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Must own the team or administer its organization"
+// @Failure 404 {object} utils.ErrorResponse "Team not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /teams/{id} [patch]
+func (h *Handler) UpdateTeam(c *fiber.Ctx) error {
+	teamId := c.Params("id")
+
+	if teamId == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid id"))
+	}
+
+	teamUUID, err := uuid.Parse(teamId)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if _, err := h.authorizeTeam(c, policy.ActionUpdate, teamUUID); err != nil {
+		return policy.Deny(c, err, "team not found")
+	}
+
+	payload := interfaces.UpdateTeamRequest{}
+
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	err = h.validator.Validate(payload)
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
+	}
+
+	team, err := h.teamRepository.UpdateTeamName(c.Context(), interfaces.UpdateTeamNameData{
+		TeamID:    teamUUID,
+		Name:      payload.Name,
+		UpdatedAt: time.Now().UTC(),
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"team": team,
+	})
+}
+
+// TransferTeamOwnership godoc
+// @Summary Transfer the ownership of a team
+// @Description Make another admin of the team its owner, the current owner stays in the team as one of its admins (Admin only, must own the team or administer its organization)
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Param id path string true "Team ID"
+// @Param request body interfaces.TransferTeamOwnershipRequest true "New owner"
+// @Success 200 {object} interfaces.TeamResponse
+// @Failure 400 {object} utils.ErrorResponse "Validation error, the user isn't an admin or already owns the team"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Must own the team or administer its organization"
+// @Failure 404 {object} utils.ErrorResponse "Team or user not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /teams/{id}/transfer-ownership [post]
+func (h *Handler) TransferTeamOwnership(c *fiber.Ctx) error {
+	teamId := c.Params("id")
+
+	if teamId == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid id"))
+	}
+
+	teamUUID, err := uuid.Parse(teamId)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	team, err := h.authorizeTeam(c, policy.ActionUpdate, teamUUID)
+
+	if err != nil {
+		return policy.Deny(c, err, "team not found")
+	}
+
+	payload := interfaces.TransferTeamOwnershipRequest{}
+
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	err = h.validator.Validate(payload)
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
+	}
+
+	if payload.UserId == team.OwnerID {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("the user already owns the team"))
+	}
+
+	// The new owner is one of the admins of the team
+	exists, member, err := h.teamRepository.GetTeamMember(c.Context(), teamUUID, payload.UserId)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if !exists {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("user not found"))
+	}
+
+	if member.Role != models.UserrolesAdmin {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("only admins can own a team"))
+	}
+
+	team, err = h.teamRepository.TransferTeamOwnership(c.Context(), interfaces.TransferTeamOwnershipData{
+		TeamID:    teamUUID,
+		OwnerID:   member.UserID,
+		UpdatedAt: time.Now().UTC(),
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"team": team,
+	})
+}
+
+// DeleteTeam godoc
+// @Summary Delete a team
+// @Description Delete a team with its projects, tasks, invitations and custom roles. The sessions working in it are logged out and the users it was the default team of move to their next team. With dryRun it only reports what would be removed, otherwise confirm has to be the name of the team (Admin only, must own the team or administer its organization)
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Param id path string true "Team ID"
+// @Param dryRun query bool false "Only report what would be removed"
+// @Param confirm query string false "Name of the team, required to delete it"
+// @Success 200 {object} interfaces.TeamDeletionResponse
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID or the confirmation doesn't match the name of the team"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Must own the team or administer its organization"
+// @Failure 404 {object} utils.ErrorResponse "Team not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /teams/{id} [delete]
+func (h *Handler) DeleteTeam(c *fiber.Ctx) error {
+	teamId := c.Params("id")
+
+	if teamId == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid id"))
+	}
+
+	teamUUID, err := uuid.Parse(teamId)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	team, err := h.authorizeTeam(c, policy.ActionDelete, teamUUID)
+
+	if err != nil {
+		return policy.Deny(c, err, "team not found")
+	}
+
+	queryParams := interfaces.DeleteTeamParams{}
+
+	if err := c.QueryParser(&queryParams); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.NewError(err))
+	}
+
+	report, err := h.teamRepository.GetTeamDeletionReport(c.Context(), teamUUID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if queryParams.DryRun {
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"deleted": false,
+			"report":  report,
+		})
+	}
+
+	// The cascade can't be undone, the admin has to type the name of the team it is deleting
+	if queryParams.Confirm != team.Name {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("confirm with the name of the team to delete it"))
+	}
+
+	err = h.teamRepository.DeleteTeam(c.Context(), interfaces.DeleteTeamData{
+		TeamID:    teamUUID,
+		UpdatedAt: time.Now().UTC(),
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	h.syncRevokedSessionsAfter(c)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"deleted": true,
+		"report":  report,
+	})
+}
@@ -0,0 +1,245 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/policy"
+	"github.com/TobiasRV/challenge-fs-senior/internals/tenant"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// userTeamId returns the default team of a user: the one an admin owns, the one a manager, member or
+// an admin that doesn't own one belongs to. It's uuid.Nil when the user has none
+func (h *Handler) userTeamId(c *fiber.Ctx, user models.User) (uuid.UUID, error) {
+	if user.Role != models.UserrolesAdmin {
+		return user.TeamId, nil
+	}
+
+	exists, team, err := h.teamRepository.GetTeamByOwner(c.Context(), user.ID)
+
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if !exists {
+		return user.TeamId, nil
+	}
+
+	return team.ID, nil
+}
+
+// callerTeamId returns the team of the user of the request. It's looked up once per request and the
+// request is scoped to it, for policy.SubjectFromContext and the repositories
+func (h *Handler) callerTeamId(c *fiber.Ctx) (uuid.UUID, error) {
+	if teamId, ok := tenant.TeamID(c.Context()); ok {
+		return teamId, nil
+	}
+
+	userUUID, err := uuid.Parse(c.Locals("userId").(string))
+
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	// Access tokens carry the team their session works in, the user has to still be one of its members
+	if teamId, ok := tokenTeamId(c); ok {
+		exists, _, err := h.teamRepository.GetTeamMember(c.Context(), teamId, userUUID)
+
+		if err != nil {
+			return uuid.Nil, err
+		}
+
+		if !exists {
+			teamId = uuid.Nil
+		}
+
+		tenant.SetTeam(c, teamId)
+
+		return teamId, nil
+	}
+
+	// Personal access tokens go by the default team of the user
+	userRole, _ := c.Locals("userRole").(string)
+
+	// Admins are the only role that doesn't need its row, the team is the one it owns
+	user := models.User{ID: userUUID, Role: models.UserrolesAdmin}
+
+	if models.Userroles(userRole) != models.UserrolesAdmin {
+		user, err = h.userRepository.GetUserById(c.Context(), userUUID)
+
+		// A user deleted after its token was issued has no team anymore
+		if errors.Is(err, sql.ErrNoRows) {
+			user, err = models.User{}, nil
+		}
+
+		if err != nil {
+			return uuid.Nil, err
+		}
+	}
+
+	teamId, err := h.userTeamId(c, user)
+
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	// Admins that don't own a team are in the one they belong to, like the owner that handed it over
+	if teamId == uuid.Nil && user.Role == models.UserrolesAdmin {
+		user, err = h.userRepository.GetUserById(c.Context(), userUUID)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			user, err = models.User{}, nil
+		}
+
+		if err != nil {
+			return uuid.Nil, err
+		}
+
+		teamId = user.TeamId
+	}
+
+	tenant.SetTeam(c, teamId)
+
+	return teamId, nil
+}
+
+// tokenTeamId is the team the access token of the request was issued for
+func tokenTeamId(c *fiber.Ctx) (uuid.UUID, bool) {
+	teamId, err := uuid.Parse(fmt.Sprint(c.Locals("teamId")))
+
+	return teamId, err == nil
+}
+
+// userInTeam is the user working in a team, with the role it has in it. Users that aren't members of the
+// team work in their default team
+func (h *Handler) userInTeam(c *fiber.Ctx, user models.User, teamId uuid.UUID) (models.User, error) {
+	if teamId == uuid.Nil || teamId == user.TeamId {
+		return user, nil
+	}
+
+	exists, member, err := h.teamRepository.GetTeamMember(c.Context(), teamId, user.ID)
+
+	if err != nil {
+		return models.User{}, err
+	}
+
+	if !exists {
+		return user, nil
+	}
+
+	return user.InTeam(member), nil
+}
+
+// callerOrganizationIds returns the organizations the user of the request administers. They are looked up
+// once per request and kept for policy.SubjectFromContext
+func (h *Handler) callerOrganizationIds(c *fiber.Ctx) ([]uuid.UUID, error) {
+	if organizationIds, ok := c.Locals("organizations").([]uuid.UUID); ok {
+		return organizationIds, nil
+	}
+
+	userUUID, err := uuid.Parse(c.Locals("userId").(string))
+
+	if err != nil {
+		return nil, err
+	}
+
+	organizations, err := h.organizationRepository.GetUserOrganizations(c.Context(), userUUID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	organizationIds := []uuid.UUID{}
+	for _, o := range organizations {
+		organizationIds = append(organizationIds, o.ID)
+	}
+
+	c.Locals("organizations", organizationIds)
+
+	return organizationIds, nil
+}
+
+// authorize is policy.Authorize for resources that may belong to a team, the team of the user of the
+// request is looked up first so resources of other teams come back as policy.ErrNotFound
+func (h *Handler) authorize(c *fiber.Ctx, action policy.Action, resource policy.Resource) error {
+	if resource.TeamID.Valid {
+		if _, err := h.callerTeamId(c); err != nil {
+			return err
+		}
+	}
+
+	err := policy.Authorize(c.Context(), action, resource)
+
+	// Admins of an organization manage it and its teams from whatever team they work in, the organizations
+	// they administer are only looked up when the team doesn't let them
+	if err != nil && resource.OrganizationID.Valid {
+		if _, lookupErr := h.callerOrganizationIds(c); lookupErr != nil {
+			return lookupErr
+		}
+
+		err = policy.Authorize(c.Context(), action, resource)
+	}
+
+	return err
+}
+
+// authorizeUser loads a user and checks the action on it, users that don't exist or belong to another
+// team are policy.ErrNotFound
+func (h *Handler) authorizeUser(c *fiber.Ctx, action policy.Action, userUUID uuid.UUID) (models.User, error) {
+	user, err := h.userRepository.GetUserById(c.Context(), userUUID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.User{}, policy.ErrNotFound
+	}
+
+	if err != nil {
+		return models.User{}, err
+	}
+
+	// Users always see themselves, the team is only needed for the others
+	var teamId uuid.UUID
+
+	if user.ID != policy.SubjectFromContext(c.Context()).ID {
+		teamId, err = h.userTeamId(c, user)
+
+		if err != nil {
+			return models.User{}, err
+		}
+
+		callerTeamId, err := h.callerTeamId(c)
+
+		if err != nil {
+			return models.User{}, err
+		}
+
+		// Users of other teams that are members of this one are in it with the role they have in it,
+		// their TeamId is still their default team
+		if teamId != callerTeamId && callerTeamId != uuid.Nil {
+			exists, member, err := h.teamRepository.GetTeamMember(c.Context(), callerTeamId, user.ID)
+
+			if err != nil {
+				return models.User{}, err
+			}
+
+			if exists {
+				teamId = callerTeamId
+				user.Role, user.RoleId = member.Role, member.RoleId
+			}
+		}
+	}
+
+	if err := h.authorize(c, action, policy.User(user, teamId)); err != nil {
+		return models.User{}, err
+	}
+
+	// Custom roles can be granted users:manage, admins are still only changed by admins
+	if action != policy.ActionRead && user.Role == models.UserrolesAdmin && policy.SubjectFromContext(c.Context()).Role != models.UserrolesAdmin {
+		return models.User{}, policy.ErrForbidden
+	}
+
+	return user, nil
+}
@@ -3,10 +3,12 @@ package handlers
 import (
 	"database/sql"
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
 	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/policy"
 	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -14,23 +16,30 @@ import (
 )
 
 // CreateUserAdmin godoc
-// @Summary Register admin user
-// @Description Create a new admin user account
+// @Summary Register the first admin user
+// @Description Create the first admin account with the one-time setup token printed or written to a file at startup. Once an admin exists the endpoint is closed and new admins are created by existing admins through POST /users.
 // @Tags Auth
 // @Accept json
 // @Produce json
 // @Param request body interfaces.CreateAdminRequest true "Admin registration data"
 // @Success 201 {object} interfaces.CreateAdminResponse
 // @Failure 400 {object} utils.ErrorResponse "Validation error"
+// @Failure 401 {object} utils.ErrorResponse "Invalid setup token"
+// @Failure 403 {object} utils.ErrorResponse "Admin registration is closed"
 // @Failure 409 {object} utils.ErrorResponse "User already exists"
 // @Failure 500 {object} utils.ErrorResponse "Internal server error"
 // @Router /auth/register-admin [post]
 func (h *Handler) CreateUserAdmin(c *fiber.Ctx) error {
 
+	if !h.adminBootstrap.enabled() {
+		return c.Status(fiber.StatusForbidden).JSON(utils.ErrorString("admin registration is closed"))
+	}
+
 	payload := struct {
-		Username string `json:"username" validate:"required"`
-		Password string `json:"password" validate:"required"`
-		Email    string `json:"email" validate:"required,email"`
+		Username   string `json:"username" validate:"required"`
+		Password   string `json:"password" validate:"required"`
+		Email      string `json:"email" validate:"required,email"`
+		SetupToken string `json:"setupToken" validate:"required"`
 	}{}
 
 	if err := c.BodyParser(&payload); err != nil {
@@ -43,6 +52,10 @@ func (h *Handler) CreateUserAdmin(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
 	}
 
+	if !h.adminBootstrap.verify(payload.SetupToken) {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.ErrorString("invalid setup token"))
+	}
+
 	_, err = h.userRepository.GetUserByEmail(c.Context(), payload.Email)
 
 	if err == nil {
@@ -54,7 +67,7 @@ func (h *Handler) CreateUserAdmin(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
 	}
 
-	newUser, err := h.userRepository.CreateUser(c.Context(), models.User{
+	created, newUser, err := h.userRepository.CreateFirstAdmin(c.Context(), models.User{
 		Username:  payload.Username,
 		Password:  string(hashedPassword),
 		CreatedAt: time.Now().UTC(),
@@ -66,31 +79,18 @@ func (h *Handler) CreateUserAdmin(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
 	}
 
-	token, err := utils.GenerateJWTToken(newUser)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
-	}
+	// The setup token is single use, and another instance may have created the admin first
+	h.adminBootstrap.close()
 
-	refreshToken, expiresAt, err := utils.GenerateJWTRefreshToken(newUser)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	if !created {
+		return c.Status(fiber.StatusForbidden).JSON(utils.ErrorString("admin registration is closed"))
 	}
 
-	err = h.refreshTokenRepository.CreateRefreshToken(c.Context(), models.RefreshToken{
-		ID:        uuid.New(),
-		CreatedAt: time.Now().UTC(),
-		ExpiresAt: expiresAt,
-		Userid:    newUser.ID,
-		Token:     refreshToken,
-		Revoked:   false,
-	})
-
+	token, refreshToken, err := h.startSession(c, newUser, "")
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
 	}
 
-	utils.GenerateCookie(c, token)
-
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"accessToken":  token,
 		"refreshToken": refreshToken,
@@ -100,7 +100,7 @@ func (h *Handler) CreateUserAdmin(c *fiber.Ctx) error {
 
 // CreateUser godoc
 // @Summary Create a new user
-// @Description Create a new user (Admin only)
+// @Description Create a new user in the team of the admin (Admin only), teamId defaults to it
 // @Tags Users
 // @Accept json
 // @Produce json
@@ -108,16 +108,15 @@ func (h *Handler) CreateUserAdmin(c *fiber.Ctx) error {
 // @Success 201 {object} interfaces.CreateUserResponse
 // @Failure 400 {object} utils.ErrorResponse "Validation error"
 // @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} utils.ErrorResponse "Team not found"
 // @Failure 409 {object} utils.ErrorResponse "User already exists"
 // @Failure 500 {object} utils.ErrorResponse "Internal server error"
 // @Security BearerAuth
 // @Router /users [post]
 func (h *Handler) CreateUser(c *fiber.Ctx) error {
 
-	userRole := c.Locals("userRole")
-
-	if userRole != "Admin" {
-		return c.Status(fiber.StatusForbidden).JSON(utils.ErrorString("unauthorized"))
+	if err := policy.Authorize(c.Context(), policy.ActionCreate, policy.Collection(policy.ResourceUsers)); err != nil {
+		return policy.Forbidden(c)
 	}
 
 	payload := interfaces.CreateUserPayload{}
@@ -132,6 +131,21 @@ func (h *Handler) CreateUser(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
 	}
 
+	if payload.Role == models.UserrolesAdmin && policy.SubjectFromContext(c.Context()).Role != models.UserrolesAdmin {
+		return policy.Forbidden(c)
+	}
+
+	// Users are created in the team of the admin, another team can't be picked
+	if payload.TeamId == uuid.Nil {
+		payload.TeamId, err = h.callerTeamId(c)
+
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+		}
+	} else if err := h.authorize(c, policy.ActionCreate, policy.TeamResources(policy.ResourceUsers, payload.TeamId)); err != nil {
+		return policy.Deny(c, err, "team not found")
+	}
+
 	_, err = h.userRepository.GetUserByEmail(c.Context(), payload.Email)
 
 	if err == nil {
@@ -163,16 +177,23 @@ func (h *Handler) CreateUser(c *fiber.Ctx) error {
 
 // UserExistsByEmail godoc
 // @Summary Check if user exists by email
-// @Description Check if a user with the given email exists
+// @Description Check if a user with the given email exists (Admin only)
 // @Tags Users
 // @Accept json
 // @Produce json
 // @Param email query string true "User email"
 // @Success 200 {object} interfaces.UserExistsResponse
 // @Failure 400 {object} utils.ErrorResponse "Email is required"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin only"
 // @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
 // @Router /users/exists-by-email [get]
 func (h *Handler) UserExistsByEmail(c *fiber.Ctx) error {
+	// Only admins need it to create users, anyone else could use it to find accounts
+	if err := policy.Authorize(c.Context(), policy.ActionCreate, policy.Collection(policy.ResourceUsers)); err != nil {
+		return policy.Forbidden(c)
+	}
+
 	email := c.Query("email")
 	if email == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("email is required"))
@@ -197,27 +218,26 @@ func (h *Handler) UserExistsByEmail(c *fiber.Ctx) error {
 
 // GetUsers godoc
 // @Summary Get all users
-// @Description Get paginated list of users (Admin or Manager only)
+// @Description Get paginated list of the users of a team (Admin or Manager only), only the team of the user can be listed
 // @Tags Users
 // @Accept json
 // @Produce json
 // @Param email query string false "Filter by email"
-// @Param teamId query string true "Team ID (required)"
+// @Param teamId query string false "Team ID, defaults to the team of the user"
 // @Param role query string false "Filter by role (Admin, Manager, Member)"
 // @Param cursor query string false "Pagination cursor"
 // @Param limit query int true "Number of items per page"
 // @Success 200 {object} interfaces.UsersListResponse
 // @Failure 400 {object} utils.ErrorResponse "Bad request"
 // @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin or Manager only"
+// @Failure 404 {object} utils.ErrorResponse "Team not found"
 // @Failure 500 {object} utils.ErrorResponse "Internal server error"
 // @Security BearerAuth
 // @Router /users [get]
 func (h *Handler) GetUsers(c *fiber.Ctx) error {
 
-	userRole := c.Locals("userRole")
-
-	if userRole != "Admin" && userRole != "Manager" {
-		return c.Status(fiber.StatusForbidden).JSON(utils.ErrorString("unauthorized"))
+	if err := policy.Authorize(c.Context(), policy.ActionRead, policy.Collection(policy.ResourceUsers)); err != nil {
+		return policy.Forbidden(c)
 	}
 
 	queryParams := interfaces.GetUserParams{}
@@ -226,6 +246,21 @@ func (h *Handler) GetUsers(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.NewError(err))
 	}
 
+	// Without a team every user would be listed, it defaults to the one of the user
+	if queryParams.TeamId == uuid.Nil {
+		teamId, err := h.callerTeamId(c)
+
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+		}
+
+		queryParams.TeamId = teamId
+	}
+
+	if err := h.authorize(c, policy.ActionRead, policy.TeamResources(policy.ResourceUsers, queryParams.TeamId)); err != nil {
+		return policy.Deny(c, err, "team not found")
+	}
+
 	cursor := queryParams.Cursor
 	isFirstPage := true
 	var cursorCreatedAt time.Time
@@ -317,7 +352,7 @@ func (h *Handler) GetUsers(c *fiber.Ctx) error {
 
 // UpdateUser godoc
 // @Summary Update a user
-// @Description Update user information (Admin only)
+// @Description Update user information (Admin only). Changing the role of a Manager or Member, or assigning them a role with roleId, revokes every session of the user
 // @Tags Users
 // @Accept json
 // @Produce json
@@ -332,10 +367,8 @@ func (h *Handler) GetUsers(c *fiber.Ctx) error {
 // @Router /users/{id} [put]
 func (h *Handler) UpdateUser(c *fiber.Ctx) error {
 
-	userRole := c.Locals("userRole")
-
-	if userRole != "Admin" {
-		return c.Status(fiber.StatusForbidden).JSON(utils.ErrorString("unauthorized"))
+	if err := policy.Authorize(c.Context(), policy.ActionUpdate, policy.Collection(policy.ResourceUsers)); err != nil {
+		return policy.Forbidden(c)
 	}
 
 	userId := c.Params("id")
@@ -350,14 +383,10 @@ func (h *Handler) UpdateUser(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
 	}
 
-	currentUser, err := h.userRepository.GetUserById(c.Context(), userUUID)
-
-	if errors.Is(err, sql.ErrNoRows) {
-		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("user not found"))
-	}
+	currentUser, err := h.authorizeUser(c, policy.ActionUpdate, userUUID)
 
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+		return policy.Deny(c, err, "user not found")
 	}
 
 	payload := interfaces.UpdateUserPayload{}
@@ -380,6 +409,37 @@ func (h *Handler) UpdateUser(c *fiber.Ctx) error {
 		}
 	}
 
+	var assignedRole *models.Role
+
+	if payload.RoleId != uuid.Nil && payload.RoleId != currentUser.RoleId {
+		if currentUser.Role == models.UserrolesAdmin {
+			return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("the role of an admin can't be changed"))
+		}
+
+		role, err := h.authorizeRole(c, policy.ActionRead, payload.RoleId)
+
+		if err != nil {
+			return policy.Deny(c, err, "role not found")
+		}
+
+		if role.BaseRole == models.UserrolesAdmin {
+			return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("the admin role can't be assigned"))
+		}
+
+		// A built-in role also sets the base role, custom roles keep the one the user has
+		if role.BuiltIn {
+			payload.Role = role.BaseRole
+		}
+
+		assignedRole = &role
+	}
+
+	roleChanged := payload.Role != "" && payload.Role != currentUser.Role
+
+	if roleChanged && currentUser.Role == models.UserrolesAdmin {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("the role of an admin can't be changed"))
+	}
+
 	newUser, err := h.userRepository.UpdateUser(c.Context(), interfaces.UpdateUserData{
 		Username:  payload.Username,
 		Email:     payload.Email,
@@ -390,29 +450,105 @@ func (h *Handler) UpdateUser(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
 	}
 
+	teamId := currentUser.TeamId
+
+	if roleChanged || assignedRole != nil {
+		teamId, err = h.callerTeamId(c)
+
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+		}
+	}
+
+	// Members whose default team is another one have their role in this team in their membership
+	if teamId != currentUser.TeamId {
+		role, roleId := currentUser.Role, currentUser.RoleId
+
+		if roleChanged {
+			role = payload.Role
+
+			if models.IsBuiltInRole(roleId) {
+				roleId = models.BuiltInRoleIds[role]
+			}
+		}
+
+		if assignedRole != nil {
+			roleId = assignedRole.ID
+		}
+
+		member, err := h.teamRepository.UpdateTeamMemberRole(c.Context(), interfaces.UpdateTeamMemberRoleData{
+			TeamID:    teamId,
+			UserID:    userUUID,
+			Role:      role,
+			RoleId:    roleId,
+			UpdatedAt: time.Now().UTC(),
+		})
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+		}
+
+		h.syncRevokedSessionsAfter(c)
+
+		newUser.Role, newUser.RoleId = member.Role, member.RoleId
+
+		return c.Status(fiber.StatusCreated).JSON(newUser)
+	}
+
+	// Changing the role logs the user out everywhere, their tokens carry the old one
+	if roleChanged {
+		newUser, err = h.userRepository.UpdateUserRole(c.Context(), interfaces.UpdateUserRoleData{
+			Role:      payload.Role,
+			UpdatedAt: time.Now().UTC(),
+			ID:        userUUID,
+		})
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+		}
+	}
+
+	if assignedRole != nil {
+		newUser, err = h.userRepository.AssignUserRole(c.Context(), interfaces.AssignUserRoleData{
+			RoleId:    assignedRole.ID,
+			UpdatedAt: time.Now().UTC(),
+			ID:        userUUID,
+		})
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+		}
+	}
+
+	if roleChanged || assignedRole != nil {
+		h.syncRevokedSessionsAfter(c)
+	}
+
 	return c.Status(fiber.StatusCreated).JSON(newUser)
 }
 
+// errUserDeactivated is returned for deactivated users, they can't log in until they are reactivated
+var errUserDeactivated = errors.New("the account has been deactivated")
+
 // DeleteUser godoc
-// @Summary Delete a user
-// @Description Delete a user by ID (Admin only)
+// @Summary Deactivate a user
+// @Description Deactivate a user of the team by ID, handing their teams, projects and tasks that aren't done over to other users first: teamOwnerId takes over the teams they own and has to be an admin of them, projectManagerId the projects they manage and has to be a manager of their teams, and taskAssigneeId the tasks assigned to them and has to be a member of their teams. Each one is required when the user has any. The user is logged out everywhere and can be reactivated. With dryRun it only reports what would be handed over, with permanent it deletes a user already deactivated for good. All their work has to be in the team of the caller or in teams the caller owns or administers the organization of. Users whose default team is another one are removed from the team instead (Admin only)
 // @Tags Users
 // @Accept json
 // @Produce json
 // @Param id path string true "User ID"
-// @Success 200 {object} interfaces.MessageResponse
-// @Failure 400 {object} utils.ErrorResponse "Invalid ID"
-// @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin only"
+// @Param dryRun query bool false "Only report what would be handed over"
+// @Param permanent query bool false "Delete a deactivated user for good"
+// @Param request body interfaces.DeactivateUserPayload false "Users that take over the work of the user"
+// @Success 200 {object} interfaces.UserOffboardingResponse
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID, the user belongs to another team, or their work isn't handed over to valid users"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin only, or the user has work in a team the caller doesn't manage"
 // @Failure 404 {object} utils.ErrorResponse "User not found"
+// @Failure 409 {object} utils.ErrorResponse "The user is already deactivated"
 // @Failure 500 {object} utils.ErrorResponse "Internal server error"
 // @Security BearerAuth
 // @Router /users/{id} [delete]
 func (h *Handler) DeleteUser(c *fiber.Ctx) error {
 
-	userRole := c.Locals("userRole")
-
-	if userRole != "Admin" {
-		return c.Status(fiber.StatusForbidden).JSON(utils.ErrorString("unauthorized"))
+	if err := policy.Authorize(c.Context(), policy.ActionDelete, policy.Collection(policy.ResourceUsers)); err != nil {
+		return policy.Forbidden(c)
 	}
 
 	userId := c.Params("id")
@@ -427,13 +563,519 @@ func (h *Handler) DeleteUser(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
 	}
 
-	err = h.userRepository.DeleteUser(c.Context(), userUUID)
+	user, err := h.authorizeUser(c, policy.ActionDelete, userUUID)
+
+	if err != nil {
+		return policy.Deny(c, err, "user not found")
+	}
+
+	teamId, err := h.callerTeamId(c)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	// Members that belong to other teams too are removed from the team instead
+	if user.TeamId != teamId {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("the user belongs to another team, remove them from this team instead"))
+	}
+
+	queryParams := interfaces.DeleteUserParams{}
+
+	if err := c.QueryParser(&queryParams); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.NewError(err))
+	}
+
+	workTeamIds, err := h.offboardingTeamIds(c, userUUID)
+
+	if err != nil {
+		return policy.Deny(c, err, "user not found")
+	}
+
+	report, err := h.userRepository.GetUserOffboarding(c.Context(), userUUID, workTeamIds)
 
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
 	}
 
+	if queryParams.DryRun {
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"deactivated": false,
+			"report":      report,
+		})
+	}
+
+	if queryParams.Permanent {
+		return h.deleteDeactivatedUser(c, user, report)
+	}
+
+	if user.DeactivatedAt != nil {
+		return c.Status(fiber.StatusConflict).JSON(utils.ErrorString("the user is already deactivated"))
+	}
+
+	if user.ID == policy.SubjectFromContext(c.Context()).ID {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("you can't deactivate yourself"))
+	}
+
+	payload := interfaces.DeactivateUserPayload{}
+
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&payload); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(utils.NewError(err))
+		}
+	}
+
+	ownedTeamIds, projectTeamIds, taskTeamIds := []uuid.UUID{}, []uuid.UUID{}, []uuid.UUID{}
+
+	for _, team := range report.Teams {
+		ownedTeamIds = appendTeamId(ownedTeamIds, team.ID)
+	}
+
+	for _, project := range report.Projects {
+		projectTeamIds = appendTeamId(projectTeamIds, project.TeamID)
+	}
+
+	for _, task := range report.Tasks {
+		taskTeamIds = appendTeamId(taskTeamIds, task.TeamID)
+	}
+
+	handovers := []struct {
+		field    string
+		targetId uuid.UUID
+		teamIds  []uuid.UUID
+		role     models.Userroles
+		invalid  string
+	}{
+		{field: "teamOwnerId", targetId: payload.TeamOwnerId, teamIds: ownedTeamIds, role: models.UserrolesAdmin, invalid: "must be an admin of every team the user owns"},
+		{field: "projectManagerId", targetId: payload.ProjectManagerId, teamIds: projectTeamIds, role: models.UserrolesManager, invalid: "must be a manager of the team of every project the user manages"},
+		{field: "taskAssigneeId", targetId: payload.TaskAssigneeId, teamIds: taskTeamIds, invalid: "must be a member of the team of every task assigned to the user"},
+	}
+
+	data := interfaces.DeactivateUserData{
+		ID:            userUUID,
+		TeamIDs:       workTeamIds,
+		DeactivatedAt: time.Now().UTC(),
+	}
+
+	for _, handover := range handovers {
+		// Nothing to hand over, the target is ignored
+		if len(handover.teamIds) == 0 {
+			continue
+		}
+
+		if handover.targetId == uuid.Nil {
+			return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString(handover.field + " is required to hand over the work of the user"))
+		}
+
+		ok, err := h.canTakeOver(c, userUUID, handover.targetId, handover.teamIds, handover.role)
+
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+		}
+
+		if !ok {
+			return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString(handover.field + " " + handover.invalid))
+		}
+	}
+
+	if len(report.Teams) > 0 {
+		data.TeamOwnerID = payload.TeamOwnerId
+	}
+
+	if len(report.Projects) > 0 {
+		data.ProjectManagerID = payload.ProjectManagerId
+	}
+
+	if len(report.Tasks) > 0 {
+		data.TaskAssigneeID = payload.TaskAssigneeId
+	}
+
+	if err := h.userRepository.DeactivateUser(c.Context(), data); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	h.syncRevokedSessionsAfter(c)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"deactivated": true,
+		"report":      report,
+	})
+}
+
+// deleteDeactivatedUser deletes a deactivated user for good, once nothing they owned would go with them
+func (h *Handler) deleteDeactivatedUser(c *fiber.Ctx, user models.User, report interfaces.UserOffboardingReport) error {
+	if user.DeactivatedAt == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("deactivate the user before deleting them"))
+	}
+
+	if len(report.Teams) > 0 || len(report.Projects) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("the user still owns teams or manages projects, reactivate and deactivate them again to hand them over"))
+	}
+
+	if err := h.userRepository.DeleteUser(c.Context(), user.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"deleted": true,
 	})
 }
+
+// offboardingTeamIds returns the teams the user owns, manages projects of or has open tasks in, once the
+// caller is checked to manage every one of them: its own team, or the teams it can update (the ones it
+// owns and the ones of the organizations it administers). Work in any other team is policy.ErrForbidden
+func (h *Handler) offboardingTeamIds(c *fiber.Ctx, userId uuid.UUID) ([]uuid.UUID, error) {
+	teamIds, err := h.userRepository.GetUserWorkTeamIds(c.Context(), userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	callerTeamId, err := h.callerTeamId(c)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, teamId := range teamIds {
+		if teamId == callerTeamId {
+			continue
+		}
+
+		_, err := h.authorizeTeam(c, policy.ActionUpdate, teamId)
+
+		if errors.Is(err, policy.ErrNotFound) || errors.Is(err, policy.ErrForbidden) {
+			return nil, policy.ErrForbidden
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return teamIds, nil
+}
+
+// canTakeOver checks the user taking over the work of the user being deactivated: another user, active,
+// and a member of every team of the work with role, or any role when it's empty
+func (h *Handler) canTakeOver(c *fiber.Ctx, userId uuid.UUID, targetId uuid.UUID, teamIds []uuid.UUID, role models.Userroles) (bool, error) {
+	if targetId == userId {
+		return false, nil
+	}
+
+	target, err := h.userRepository.GetUserById(c.Context(), targetId)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	if target.DeactivatedAt != nil {
+		return false, nil
+	}
+
+	for _, teamId := range teamIds {
+		exists, member, err := h.teamRepository.GetTeamMember(c.Context(), teamId, targetId)
+
+		if err != nil {
+			return false, err
+		}
+
+		if !exists || (role != "" && member.Role != role) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// appendTeamId adds the team to teamIds unless it's already there
+func appendTeamId(teamIds []uuid.UUID, teamId uuid.UUID) []uuid.UUID {
+	if slices.Contains(teamIds, teamId) {
+		return teamIds
+	}
+
+	return append(teamIds, teamId)
+}
+
+// ReactivateUser godoc
+// @Summary Reactivate a user
+// @Description Reactivate a deactivated user of the team so they can log in again. The work handed over when they were deactivated stays with the users that took it over (Admin only)
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} models.User
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID or the user belongs to another team"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} utils.ErrorResponse "User not found"
+// @Failure 409 {object} utils.ErrorResponse "The user isn't deactivated"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /users/{id}/reactivate [post]
+func (h *Handler) ReactivateUser(c *fiber.Ctx) error {
+	userId := c.Params("id")
+
+	if userId == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("invalid id"))
+	}
+
+	userUUID, err := uuid.Parse(userId)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	user, err := h.authorizeUser(c, policy.ActionDelete, userUUID)
+
+	if err != nil {
+		return policy.Deny(c, err, "user not found")
+	}
+
+	teamId, err := h.callerTeamId(c)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if user.TeamId != teamId {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("the user belongs to another team"))
+	}
+
+	if user.DeactivatedAt == nil {
+		return c.Status(fiber.StatusConflict).JSON(utils.ErrorString("the user isn't deactivated"))
+	}
+
+	user, err = h.userRepository.ReactivateUser(c.Context(), interfaces.ReactivateUserData{
+		ID:        userUUID,
+		UpdatedAt: time.Now().UTC(),
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(user)
+}
+
+// currentUser loads the user of the token, sql.ErrNoRows means it was deleted after the token was issued
+func (h *Handler) currentUser(c *fiber.Ctx) (models.User, error) {
+	userUUID, err := uuid.Parse(c.Locals("userId").(string))
+
+	if err != nil {
+		return models.User{}, err
+	}
+
+	return h.userRepository.GetUserById(c.Context(), userUUID)
+}
+
+// GetCurrentUser godoc
+// @Summary Get my profile
+// @Description Get the profile of the logged in user
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.User
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized"
+// @Failure 404 {object} utils.ErrorResponse "User not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /users/me [get]
+func (h *Handler) GetCurrentUser(c *fiber.Ctx) error {
+	user, err := h.currentUser(c)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("user not found"))
+	}
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(user)
+}
+
+// GetCurrentUserTeams godoc
+// @Summary List my teams
+// @Description List the teams the logged in user is a member of with the role it has in each one, the session can switch to any of them
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Success 200 {object} interfaces.TeamMembersListResponse
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /users/me/teams [get]
+func (h *Handler) GetCurrentUserTeams(c *fiber.Ctx) error {
+	userUUID, err := uuid.Parse(c.Locals("userId").(string))
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	teams, err := h.teamRepository.GetUserTeams(c.Context(), userUUID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": teams,
+	})
+}
+
+// UpdateCurrentUser godoc
+// @Summary Update my profile
+// @Description Change the username or email of the logged in user, fields left empty keep their value. Changing the email requires the current password
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param request body interfaces.UpdateCurrentUserPayload true "Profile changes"
+// @Success 200 {object} models.User
+// @Failure 400 {object} utils.ErrorResponse "Validation error or missing or wrong current password"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized"
+// @Failure 404 {object} utils.ErrorResponse "User not found"
+// @Failure 409 {object} utils.ErrorResponse "Email already in use"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /users/me [patch]
+func (h *Handler) UpdateCurrentUser(c *fiber.Ctx) error {
+	payload := interfaces.UpdateCurrentUserPayload{}
+
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	err := h.validator.Validate(payload)
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
+	}
+
+	user, err := h.currentUser(c)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("user not found"))
+	}
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if payload.Username == "" {
+		payload.Username = user.Username
+	}
+
+	if payload.Email == "" {
+		payload.Email = user.Email
+	}
+
+	if user.Email != payload.Email {
+		// The email is what a password reset is sent to, so changing it needs the password like
+		// changing the password does
+		if payload.CurrentPassword == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("current password is required to change the email"))
+		}
+
+		if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(payload.CurrentPassword)); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("current password is incorrect"))
+		}
+
+		_, err = h.userRepository.GetUserByEmail(c.Context(), payload.Email)
+
+		if err == nil {
+			return c.Status(fiber.StatusConflict).JSON(utils.ErrorString("user already exists"))
+		}
+
+		if !errors.Is(err, sql.ErrNoRows) {
+			return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+		}
+	}
+
+	updatedUser, err := h.userRepository.UpdateUser(c.Context(), interfaces.UpdateUserData{
+		Username:  payload.Username,
+		Email:     payload.Email,
+		UpdatedAt: time.Now().UTC(),
+		ID:        user.ID,
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(updatedUser)
+}
+
+// ChangePassword godoc
+// @Summary Change my password
+// @Description Change the password of the logged in user. The current password is required and every other session of the user is revoked.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param request body interfaces.ChangePasswordPayload true "Current and new password"
+// @Success 200 {object} interfaces.MessageResponse
+// @Failure 400 {object} utils.ErrorResponse "Validation error or wrong current password"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized"
+// @Failure 404 {object} utils.ErrorResponse "User not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /users/me/password [post]
+func (h *Handler) ChangePassword(c *fiber.Ctx) error {
+	sessionId := c.Locals("sessionId").(string)
+
+	sessionUUID, err := uuid.Parse(sessionId)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	payload := interfaces.ChangePasswordPayload{}
+
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	err = h.validator.Validate(payload)
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.NewValidatorError(err))
+	}
+
+	user, err := h.currentUser(c)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString("user not found"))
+	}
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(payload.CurrentPassword)); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.ErrorString("current password is incorrect"))
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(payload.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+	}
+
+	err = h.userRepository.ChangeUserPassword(c.Context(), interfaces.ChangeUserPasswordData{
+		Password:      string(hashedPassword),
+		UpdatedAt:     time.Now().UTC(),
+		ID:            user.ID,
+		KeepSessionID: sessionUUID,
+	})
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.ErrorString("Error changing password"))
+	}
+
+	h.syncRevokedSessionsAfter(c)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Password has been changed",
+	})
+}
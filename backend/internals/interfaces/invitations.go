@@ -0,0 +1,63 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/google/uuid"
+)
+
+type IInvitationRepository interface {
+	CreateInvitation(context.Context, models.Invitation) (models.Invitation, error)
+	GetInvitationById(context.Context, uuid.UUID) (models.Invitation, error)
+	GetPendingInvitationByEmail(ctx context.Context, teamId uuid.UUID, email string) (models.Invitation, error)
+	GetPendingInvitationsByTeam(context.Context, uuid.UUID) ([]models.Invitation, error)
+	RenewInvitation(context.Context, RenewInvitationData) (models.Invitation, error)
+	DeleteInvitation(context.Context, uuid.UUID) error
+	AcceptInvitation(context.Context, AcceptInvitationData) (user models.User, accepted bool, err error)
+}
+
+type RenewInvitationData struct {
+	ID        uuid.UUID
+	TokenID   uuid.UUID
+	ExpiresAt time.Time
+	UpdatedAt time.Time
+}
+
+type AcceptInvitationData struct {
+	InvitationID uuid.UUID
+	TokenID      uuid.UUID
+	Username     string
+	Password     string
+	AcceptedAt   time.Time
+}
+
+type CreateInvitationPayload struct {
+	Email string           `json:"email" validate:"required,email" example:"member@example.com"`
+	Role  models.Userroles `json:"role" validate:"required,oneof=Manager Member" example:"Member"`
+}
+
+type AcceptInvitationPayload struct {
+	Token    string `json:"token" validate:"required" example:"eyJhbGciOiJFZERTQSIsImtpZCI6ImRlZmF1bHQiLCJ0eXAiOiJKV1QifQ..."`
+	Username string `json:"username" validate:"required" example:"member"`
+	Password string `json:"password" validate:"required" example:"password123"`
+}
+
+type InvitationResponse struct {
+	Invitation models.Invitation `json:"invitation"`
+}
+
+type InvitationsListResponse struct {
+	Data []models.Invitation `json:"data"`
+}
+
+type AcceptInvitationResponse struct {
+	AccessToken  string      `json:"accessToken" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	RefreshToken string      `json:"refreshToken" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	User         models.User `json:"user"`
+}
+
+type DeleteInvitationResponse struct {
+	Deleted bool `json:"deleted" example:"true"`
+}
@@ -0,0 +1,38 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+)
+
+type ILoginAttemptRepository interface {
+	GetLoginAttempts(context.Context, GetLoginAttemptsData) ([]models.LoginAttempt, error)
+	RecordFailedLogin(context.Context, RecordFailedLoginData) ([]models.LoginAttempt, error)
+	ClearLoginAttempts(context.Context, LoginAttemptKey) error
+}
+
+type GetLoginAttemptsData struct {
+	AccountKey string
+	IpAddress  string
+}
+
+// RecordFailedLoginData counts a failure for the account and the ip address, failures before
+// WindowStart are forgotten and the count starts again
+type RecordFailedLoginData struct {
+	AccountKey  string
+	IpAddress   string
+	FailedAt    time.Time
+	WindowStart time.Time
+}
+
+// LoginAttemptKey identifies the counter of an account or an ip address
+type LoginAttemptKey struct {
+	Scope      string
+	Identifier string
+}
+
+type UnlockUserResponse struct {
+	Unlocked bool `json:"unlocked" example:"true"`
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import "context"
+
+type Email struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(context.Context, Email) error
+}
@@ -0,0 +1,87 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/google/uuid"
+)
+
+type IMfaRepository interface {
+	StartTotpEnrollment(context.Context, models.UserTotp) (totp models.UserTotp, started bool, err error)
+	GetUserTotp(context.Context, uuid.UUID) (models.UserTotp, error)
+	EnableTotp(context.Context, EnableTotpData) (enabled bool, err error)
+	UseTotpStep(context.Context, UseTotpStepData) (used bool, err error)
+	UseRecoveryCode(context.Context, UseRecoveryCodeData) (used bool, err error)
+	ReplaceRecoveryCodes(ctx context.Context, userId uuid.UUID, codes []models.MfaRecoveryCode) error
+	DisableTotp(context.Context, uuid.UUID) error
+	CreateMfaPendingToken(context.Context, models.MfaPendingToken) error
+	UseMfaPendingToken(context.Context, UseMfaPendingTokenData) (used bool, err error)
+}
+
+type EnableTotpData struct {
+	UserID        uuid.UUID
+	Step          int64
+	EnabledAt     time.Time
+	RecoveryCodes []models.MfaRecoveryCode
+}
+
+type UseTotpStepData struct {
+	UserID uuid.UUID
+	Step   int64
+	UsedAt time.Time
+}
+
+type UseRecoveryCodeData struct {
+	UserID   uuid.UUID
+	CodeHash string
+	UsedAt   time.Time
+}
+
+type UseMfaPendingTokenData struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+	UsedAt time.Time
+}
+
+type MfaCodeRequest struct {
+	Code string `json:"code" example:"123456"`
+}
+
+type MfaTokenRequest struct {
+	MfaToken string `json:"mfaToken" example:"eyJhbGciOiJFZERTQSIsImtpZCI6ImRlZmF1bHQiLCJ0eXAiOiJKV1QifQ..."`
+}
+
+type VerifyMfaRequest struct {
+	MfaToken string `json:"mfaToken" example:"eyJhbGciOiJFZERTQSIsImtpZCI6ImRlZmF1bHQiLCJ0eXAiOiJKV1QifQ..."`
+	Code     string `json:"code" example:"123456"`
+	Label    string `json:"label" example:"Work laptop"`
+}
+
+type MfaLoginResponse struct {
+	MfaRequired bool   `json:"mfaRequired" example:"true"`
+	MfaEnrolled bool   `json:"mfaEnrolled" example:"true"`
+	MfaToken    string `json:"mfaToken" example:"eyJhbGciOiJFZERTQSIsImtpZCI6ImRlZmF1bHQiLCJ0eXAiOiJKV1QifQ..."`
+}
+
+type TotpEnrollmentResponse struct {
+	Secret          string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	ProvisioningURI string `json:"provisioningUri" example:"otpauth://totp/challenge-fs-senior:user@example.com?algorithm=SHA1&digits=6&issuer=challenge-fs-senior&period=30&secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	QRPayload       string `json:"qrPayload" example:"otpauth://totp/challenge-fs-senior:user@example.com?algorithm=SHA1&digits=6&issuer=challenge-fs-senior&period=30&secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes" example:"k7m2p-x9q4r,a3b5c-d7e8f"`
+}
+
+type ConfirmMfaEnrollmentResponse struct {
+	AccessToken   string      `json:"accessToken" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	RefreshToken  string      `json:"refreshToken" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	User          models.User `json:"user"`
+	RecoveryCodes []string    `json:"recoveryCodes" example:"k7m2p-x9q4r,a3b5c-d7e8f"`
+}
+
+type DisableMfaResponse struct {
+	Disabled bool `json:"disabled" example:"true"`
+}
@@ -0,0 +1,43 @@
+package interfaces
+
+import "context"
+
+// OIDCProvider is an OpenID Connect identity provider used for single sign-on with the
+// authorization code flow and PKCE
+type OIDCProvider interface {
+	AuthorizationURL(context.Context, OIDCAuthorizationData) (string, error)
+	Exchange(context.Context, OIDCExchangeData) (OIDCIdentity, error)
+}
+
+type OIDCAuthorizationData struct {
+	State         string
+	Nonce         string
+	CodeChallenge string
+}
+
+type OIDCExchangeData struct {
+	Code         string
+	CodeVerifier string
+	Nonce        string
+}
+
+// OIDCIdentity holds the claims of a verified id token
+type OIDCIdentity struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+type OIDCAuthorizeResponse struct {
+	AuthorizationURL string `json:"authorizationUrl" example:"https://idp.example.com/authorize?client_id=challenge&code_challenge=...&response_type=code&state=..."`
+	StateToken       string `json:"stateToken" example:"eyJhbGciOiJFZERTQSIsImtpZCI6IjIwMjYtMDEiLCJ0eXAiOiJKV1QifQ..."`
+}
+
+type OIDCCallbackRequest struct {
+	Code       string `json:"code" validate:"required" example:"SplxlOBeZQQYbYS6WxSbIA"`
+	State      string `json:"state" validate:"required" example:"af0ifjsldkj"`
+	StateToken string `json:"stateToken" validate:"required" example:"eyJhbGciOiJFZERTQSIsImtpZCI6IjIwMjYtMDEiLCJ0eXAiOiJKV1QifQ..."`
+	Label      string `json:"label" example:"Work laptop"`
+}
@@ -0,0 +1,101 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/google/uuid"
+)
+
+type IOrganizationRepository interface {
+	CreateOrganization(context.Context, CreateOrganizationData) (models.Organization, error)
+	GetOrganizationById(context.Context, uuid.UUID) (models.Organization, error)
+	GetUserOrganizations(context.Context, uuid.UUID) ([]models.Organization, error)
+	UpdateOrganization(context.Context, UpdateOrganizationData) (models.Organization, error)
+	GetOrganizationTeams(context.Context, uuid.UUID) ([]models.Team, error)
+	GetOrganizationUsers(context.Context, uuid.UUID) ([]models.OrganizationUser, error)
+	IsOrganizationMember(ctx context.Context, organizationId uuid.UUID, userId uuid.UUID) (bool, error)
+	GetOrganizationReport(context.Context, uuid.UUID) (OrganizationReport, error)
+	GetOrganizationAdmin(ctx context.Context, organizationId uuid.UUID, userId uuid.UUID) (exists bool, admin models.OrganizationAdmin, err error)
+	GetOrganizationAdmins(context.Context, uuid.UUID) ([]models.OrganizationAdmin, error)
+	AddOrganizationAdmin(context.Context, models.OrganizationAdmin) (models.OrganizationAdmin, error)
+	RemoveOrganizationAdmin(ctx context.Context, organizationId uuid.UUID, userId uuid.UUID) error
+}
+
+// CreateOrganizationData creates an organization administered by AdminID
+type CreateOrganizationData struct {
+	Name      string
+	AdminID   uuid.UUID
+	CreatedAt time.Time
+}
+
+// UpdateOrganizationData saves the settings of an organization. Requiring two-factor authentication
+// requires it in every team of the organization and logs out their sessions without it
+type UpdateOrganizationData struct {
+	ID         uuid.UUID
+	Name       string
+	RequireMfa bool
+	UpdatedAt  time.Time
+}
+
+// OrganizationReport counts the activity of the teams of an organization, Users are the distinct members
+// of its teams
+type OrganizationReport struct {
+	Users     int64        `json:"users" example:"12"`
+	Projects  int64        `json:"projects" example:"7"`
+	Tasks     int64        `json:"tasks" example:"64"`
+	DoneTasks int64        `json:"doneTasks" example:"40"`
+	Teams     []TeamReport `json:"teams"`
+}
+
+type TeamReport struct {
+	TeamID    uuid.UUID `json:"teamId"`
+	Name      string    `json:"name" example:"Development Team"`
+	Members   int64     `json:"members" example:"5"`
+	Projects  int64     `json:"projects" example:"3"`
+	Tasks     int64     `json:"tasks" example:"24"`
+	DoneTasks int64     `json:"doneTasks" example:"10"`
+}
+
+type CreateOrganizationRequest struct {
+	Name string `json:"name" validate:"required" example:"Acme"`
+}
+
+// UpdateOrganizationRequest changes the fields that are set
+type UpdateOrganizationRequest struct {
+	Name       *string `json:"name" validate:"omitempty,min=1" example:"Acme Inc"`
+	RequireMfa *bool   `json:"requireMfa" example:"true"`
+}
+
+type OrganizationResponse struct {
+	Organization models.Organization `json:"organization"`
+}
+
+type OrganizationsListResponse struct {
+	Data []models.Organization `json:"data"`
+}
+
+type OrganizationTeamsResponse struct {
+	Data []models.Team `json:"data"`
+}
+
+type OrganizationUsersResponse struct {
+	Data []models.OrganizationUser `json:"data"`
+}
+
+type OrganizationReportResponse struct {
+	Report OrganizationReport `json:"report"`
+}
+
+type AddOrganizationAdminRequest struct {
+	UserId uuid.UUID `json:"userId" validate:"required" example:"7f3c2a1e-8b4d-4c6f-9a2e-1d5b7c9e0f21"`
+}
+
+type OrganizationAdminsResponse struct {
+	Data []models.OrganizationAdmin `json:"data"`
+}
+
+type OrganizationAdminResponse struct {
+	Admin models.OrganizationAdmin `json:"admin"`
+}
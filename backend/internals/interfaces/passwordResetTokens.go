@@ -0,0 +1,31 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/google/uuid"
+)
+
+type IPasswordResetTokenRepository interface {
+	CreatePasswordResetToken(context.Context, models.PasswordResetToken) error
+	GetPasswordResetTokenByHash(context.Context, string) (models.PasswordResetToken, error)
+	ResetPassword(context.Context, ResetPasswordData) (reset bool, err error)
+}
+
+type ResetPasswordData struct {
+	TokenID  uuid.UUID
+	UserID   uuid.UUID
+	Password string
+	UsedAt   time.Time
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" example:"user@example.com"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" example:"x4CZ0n6sQm9aL2pE7hV1bR8tW3yK5uJ0dF6gH2jN9cM"`
+	Password string `json:"password" example:"newpassword123"`
+}
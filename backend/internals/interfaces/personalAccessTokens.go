@@ -0,0 +1,46 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/google/uuid"
+)
+
+type IPersonalAccessTokenRepository interface {
+	CreatePersonalAccessToken(context.Context, models.PersonalAccessToken) (models.PersonalAccessToken, error)
+	GetPersonalAccessTokenByHash(context.Context, string) (models.PersonalAccessTokenWithUser, error)
+	GetPersonalAccessTokensByUserId(context.Context, uuid.UUID) ([]models.PersonalAccessToken, error)
+	UpdatePersonalAccessTokenLastUsed(context.Context, UpdatePersonalAccessTokenLastUsedData) error
+	DeletePersonalAccessToken(context.Context, DeletePersonalAccessTokenData) (deleted bool, err error)
+}
+
+type UpdatePersonalAccessTokenLastUsedData struct {
+	LastUsedAt time.Time
+	ID         uuid.UUID
+}
+
+type DeletePersonalAccessTokenData struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+}
+
+type CreatePersonalAccessTokenPayload struct {
+	Name          string   `json:"name" validate:"required,max=100" example:"CI pipeline"`
+	Scopes        []string `json:"scopes" validate:"required,min=1,dive,oneof=tasks:read tasks:write projects:read projects:write users:read" example:"tasks:read,tasks:write"`
+	ExpiresInDays int      `json:"expiresInDays" validate:"required,min=1,max=365" example:"90"`
+}
+
+type CreatePersonalAccessTokenResponse struct {
+	Token               string                     `json:"token" example:"pat_3q2-7wXb0kz5Jm1hY8fQ4uVtR6nE9cLpA0sDgHjKlZx"`
+	PersonalAccessToken models.PersonalAccessToken `json:"personalAccessToken"`
+}
+
+type PersonalAccessTokensListResponse struct {
+	Data []models.PersonalAccessToken `json:"data"`
+}
+
+type DeletePersonalAccessTokenResponse struct {
+	Deleted bool `json:"deleted" example:"true"`
+}
@@ -17,6 +17,10 @@ type IProjectRepository interface {
 	GetProjectById(context.Context, uuid.UUID) (models.Project, error)
 	GetProjectByManager(context.Context, uuid.UUID) (models.Project, error)
 	DeleteProject(context.Context, uuid.UUID) error
+	GetProjectMembers(context.Context, uuid.UUID) ([]models.ProjectMember, error)
+	GetProjectMember(ctx context.Context, projectId uuid.UUID, userId uuid.UUID) (exists bool, member models.ProjectMember, err error)
+	AddProjectMember(context.Context, models.ProjectMember) (models.ProjectMember, error)
+	RemoveProjectMember(context.Context, RemoveProjectMemberData) error
 }
 
 type ProjectsListResponse struct {
@@ -33,10 +37,13 @@ type GetProjectsParams struct {
 	ManagerId uuid.UUID `query:"managerId,uuid"`
 }
 
+// GetProjectsFilters lists the projects of TeamId, of ManagerId or the ones MemberId is a member of,
+// the filters that are set
 type GetProjectsFilters struct {
 	Name            string
 	TeamId          uuid.UUID
 	ManagerId       uuid.UUID
+	MemberId        uuid.UUID
 	Limit           uint64
 	IsFirstPage     bool
 	PointsNext      bool
@@ -45,8 +52,11 @@ type GetProjectsFilters struct {
 	WithStats       bool
 }
 
+// CreateProjectPayload creates a project managed by ManagerId, admins have to pick it and managers
+// leave it empty to manage it themselves
 type CreateProjectPayload struct {
-	Name string `json:"name" validate:"required"`
+	Name      string    `json:"name" validate:"required"`
+	ManagerId uuid.UUID `json:"managerId"`
 }
 
 type GetProjectsResponse struct {
@@ -73,3 +83,24 @@ type UpdateProjectPayload struct {
 	Name   string               `json:"name" validate:"required"`
 	Status models.Projectstatus `json:"status" validate:"required,oneof=OnHold InProgress Completed"`
 }
+
+type ProjectMembersListResponse struct {
+	Data []models.ProjectMember `json:"data"`
+}
+
+type ProjectMemberResponse struct {
+	Member models.ProjectMember `json:"member"`
+}
+
+type AddProjectMemberPayload struct {
+	UserId uuid.UUID                 `json:"userId" validate:"required"`
+	Role   models.Projectmemberroles `json:"role" validate:"required,oneof=Owner Contributor Viewer" example:"Contributor"`
+}
+
+// RemoveProjectMemberData removes UserID from the project, the tasks of the project assigned to them are
+// left unassigned
+type RemoveProjectMemberData struct {
+	ProjectID uuid.UUID
+	UserID    uuid.UUID
+	UpdatedAt time.Time
+}
@@ -4,17 +4,21 @@ import (
 	"context"
 
 	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/google/uuid"
 )
 
 type IRefreshTokenRepository interface {
 	CreateRefreshToken(context.Context, models.RefreshToken) error
 	GetRefreshTokenByToken(context.Context, string) (models.RefreshTokenWithUser, error)
 	DeleteRefreshTokensByUserId(context.Context, string) error
+	RotateRefreshToken(context.Context, uuid.UUID, models.RefreshToken) (rotated bool, err error)
+	RevokeRefreshTokenFamily(context.Context, uuid.UUID) error
 }
 
 type LoginRequest struct {
 	Email    string `json:"email" example:"user@example.com"`
 	Password string `json:"password" example:"password123"`
+	Label    string `json:"label" example:"Work laptop"`
 }
 
 type LoginResponse struct {
@@ -28,7 +32,8 @@ type RefreshTokenRequest struct {
 }
 
 type RefreshTokenResponse struct {
-	AccessToken string `json:"accessToken" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	AccessToken  string `json:"accessToken" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	RefreshToken string `json:"refreshToken" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
 }
 
 type LogoutResponse struct {
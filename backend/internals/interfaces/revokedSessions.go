@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IRevokedSessionRepository reads the sessions deleted while their access tokens may still be valid,
+// the rows are written by the database when a session is deleted
+type IRevokedSessionRepository interface {
+	GetRevokedSessionIds(context.Context, time.Time) ([]uuid.UUID, error)
+	DeleteRevokedSessionsBefore(context.Context, time.Time) error
+}
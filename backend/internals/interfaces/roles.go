@@ -0,0 +1,49 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/google/uuid"
+)
+
+type IRoleRepository interface {
+	CreateRole(context.Context, models.Role) (models.Role, error)
+	GetRoleById(context.Context, uuid.UUID) (models.Role, error)
+	GetCustomRoles(context.Context) ([]models.Role, error)
+	GetRoleByName(context.Context, GetRoleByNameData) (exists bool, role models.Role, err error)
+	GetRolesByTeam(context.Context, uuid.UUID) ([]models.Role, error)
+	UpdateRole(context.Context, UpdateRoleData) (models.Role, error)
+	DeleteRole(context.Context, uuid.UUID) error
+}
+
+// GetRoleByNameData looks the name up among the built-in roles and the custom roles of the team
+type GetRoleByNameData struct {
+	TeamID uuid.UUID
+	Name   string
+}
+
+type UpdateRoleData struct {
+	Name        string
+	Permissions []string
+	UpdatedAt   time.Time
+	ID          uuid.UUID
+}
+
+type RolePayload struct {
+	Name        string   `json:"name" validate:"required,max=50" example:"QA"`
+	Permissions []string `json:"permissions" validate:"required,min=1,unique,dive,oneof=users:view users:manage projects:view projects:view_member projects:create projects:edit_own projects:edit_any projects:delete_own projects:delete_any tasks:view_assigned tasks:view_member tasks:view_any tasks:create_own tasks:create_any tasks:edit_assigned tasks:edit_own tasks:edit_any tasks:delete_own tasks:delete_any" example:"tasks:view_any,tasks:edit_any"`
+}
+
+type RolesListResponse struct {
+	Data []models.Role `json:"data"`
+}
+
+type RoleResponse struct {
+	Role models.Role `json:"role"`
+}
+
+type DeleteRoleResponse struct {
+	Deleted bool `json:"deleted" example:"true"`
+}
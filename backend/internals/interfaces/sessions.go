@@ -0,0 +1,62 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/google/uuid"
+)
+
+type ISessionRepository interface {
+	CreateSession(context.Context, models.Session) (models.Session, error)
+	GetSessionById(context.Context, uuid.UUID) (models.Session, error)
+	GetSessionsByUserId(context.Context, uuid.UUID) ([]models.Session, error)
+	UpdateSessionLastUsed(context.Context, UpdateSessionLastUsedData) error
+	UpdateSessionTeam(context.Context, UpdateSessionTeamData) error
+	DeleteSession(context.Context, uuid.UUID) error
+	DeleteSessionsByUserId(context.Context, uuid.UUID) error
+}
+
+type UpdateSessionLastUsedData struct {
+	LastUsedAt time.Time
+	IpAddress  string
+	ID         uuid.UUID
+}
+
+// UpdateSessionTeamData moves the session to another team of the user
+type UpdateSessionTeamData struct {
+	TeamID uuid.UUID
+	ID     uuid.UUID
+}
+
+type SessionResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Label      string    `json:"label" example:"Work laptop"`
+	UserAgent  string    `json:"userAgent" example:"Mozilla/5.0"`
+	IpAddress  string    `json:"ipAddress" example:"127.0.0.1"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+	Current    bool      `json:"current" example:"true"`
+}
+
+type SessionsListResponse struct {
+	Data []SessionResponse `json:"data"`
+}
+
+type DeleteSessionResponse struct {
+	Deleted bool `json:"deleted" example:"true"`
+}
+
+type RevokeSessionsResponse struct {
+	Revoked bool `json:"revoked" example:"true"`
+}
+
+type SwitchTeamRequest struct {
+	TeamId uuid.UUID `json:"teamId" validate:"required" example:"7f3c2a1e-8b4d-4c6f-9a2e-1d5b7c9e0f21"`
+}
+
+type SwitchTeamResponse struct {
+	AccessToken string      `json:"accessToken"`
+	User        models.User `json:"user"`
+}
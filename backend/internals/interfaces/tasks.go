@@ -42,6 +42,8 @@ type GetTasksParams struct {
 	ProjectId string `query:"projectId"`
 }
 
+// GetTasksFilters lists the tasks of ProjectId, assigned to UserId or of the projects MemberId is a
+// member of, the filters that are set
 type GetTasksFilters struct {
 	Limit           uint64
 	IsFirstPage     bool
@@ -51,6 +53,7 @@ type GetTasksFilters struct {
 	Title           string
 	ProjectId       uuid.UUID
 	UserId          uuid.UUID
+	MemberId        uuid.UUID
 }
 
 type GetTasksResponse struct {
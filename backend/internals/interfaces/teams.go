@@ -2,21 +2,146 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
 	"github.com/google/uuid"
 )
 
 type ITeamRepository interface {
 	CreateTeam(context.Context, models.Team) (models.Team, error)
 	GetTeamByOwner(context.Context, uuid.UUID) (exists bool, team models.Team, err error)
+	GetTeamById(context.Context, uuid.UUID) (models.Team, error)
+	SetTeamRequireMfa(context.Context, SetTeamRequireMfaData) (models.Team, error)
+	GetTeams(context.Context, models.TeamFilter) ([]models.Team, error)
+	UpdateTeamName(context.Context, UpdateTeamNameData) (models.Team, error)
+	TransferTeamOwnership(context.Context, TransferTeamOwnershipData) (models.Team, error)
+	GetTeamDeletionReport(context.Context, uuid.UUID) (TeamDeletionReport, error)
+	DeleteTeam(context.Context, DeleteTeamData) error
+	GetTeamMember(ctx context.Context, teamId uuid.UUID, userId uuid.UUID) (exists bool, member models.TeamMember, err error)
+	GetTeamMembers(context.Context, uuid.UUID) ([]models.TeamMember, error)
+	GetUserTeams(context.Context, uuid.UUID) ([]models.TeamMember, error)
+	AddTeamMember(context.Context, models.TeamMember) (models.TeamMember, error)
+	UpdateTeamMemberRole(context.Context, UpdateTeamMemberRoleData) (models.TeamMember, error)
+	CountProjectsOnlyOwnedBy(ctx context.Context, teamId uuid.UUID, userId uuid.UUID) (int64, error)
+	RemoveTeamMember(context.Context, RemoveTeamMemberData) error
 }
 
+type UpdateTeamNameData struct {
+	TeamID    uuid.UUID
+	Name      string
+	UpdatedAt time.Time
+}
+
+// TransferTeamOwnershipData hands the team over to OwnerID, an admin of the team. The previous owner
+// stays in the team as one of its admins
+type TransferTeamOwnershipData struct {
+	TeamID    uuid.UUID
+	OwnerID   uuid.UUID
+	UpdatedAt time.Time
+}
+
+// DeleteTeamData deletes a team with its projects, tasks, invitations and roles. The sessions working in
+// it are logged out, except the ones of the owner, and the users it was the default team of move to
+// another of their teams
+type DeleteTeamData struct {
+	TeamID    uuid.UUID
+	UpdatedAt time.Time
+}
+
+// TeamDeletionReport counts what deleting a team removes, Users are its members
+type TeamDeletionReport struct {
+	Projects    int64 `json:"projects" example:"3"`
+	Tasks       int64 `json:"tasks" example:"24"`
+	Users       int64 `json:"users" example:"5"`
+	Invitations int64 `json:"invitations" example:"1"`
+	Roles       int64 `json:"roles" example:"2"`
+}
+
+type SetTeamRequireMfaData struct {
+	TeamID     uuid.UUID
+	RequireMfa bool
+	UpdatedAt  time.Time
+}
+
+// CreateTeamRequest creates the team in OrganizationId, or in an organization of its own when it's empty
 type CreateTeamRequest struct {
-	Name string `json:"name" example:"Development Team"`
+	Name           string    `json:"name" validate:"required" example:"Development Team"`
+	OrganizationId uuid.UUID `json:"organizationId,omitempty"`
 }
 
 type TeamExistsResponse struct {
 	Exists bool         `json:"exists" example:"true"`
 	Team   *models.Team `json:"team"`
 }
+
+type UpdateTeamMfaRequest struct {
+	RequireMfa bool `json:"requireMfa" example:"true"`
+}
+
+type TeamResponse struct {
+	Team models.Team `json:"team"`
+}
+
+type GetTeamsParams struct {
+	Limit  uint64 `query:"limit,required"`
+	Cursor string `query:"cursor"`
+	Name   string `query:"name"`
+}
+
+type TeamsListResponse struct {
+	Data       []models.Team    `json:"data"`
+	Pagination utils.Pagination `json:"pagination"`
+}
+
+type UpdateTeamRequest struct {
+	Name string `json:"name" validate:"required" example:"Platform Team"`
+}
+
+type TransferTeamOwnershipRequest struct {
+	UserId uuid.UUID `json:"userId" validate:"required" example:"7f3c2a1e-8b4d-4c6f-9a2e-1d5b7c9e0f21"`
+}
+
+// DeleteTeamParams either previews the deletion with DryRun or confirms it with the name of the team
+type DeleteTeamParams struct {
+	DryRun  bool   `query:"dryRun"`
+	Confirm string `query:"confirm"`
+}
+
+type TeamDeletionResponse struct {
+	Deleted bool               `json:"deleted" example:"false"`
+	Report  TeamDeletionReport `json:"report"`
+}
+
+// UpdateTeamMemberRoleData changes the role of a user in a team that isn't their default one, which
+// logs them out everywhere
+type UpdateTeamMemberRoleData struct {
+	TeamID    uuid.UUID
+	UserID    uuid.UUID
+	Role      models.Userroles
+	RoleId    uuid.UUID
+	UpdatedAt time.Time
+}
+
+// RemoveTeamMemberData removes UserID from the team: the tasks of the team assigned to them are left
+// unassigned, they leave its projects and their sessions working in it are logged out
+type RemoveTeamMemberData struct {
+	TeamID    uuid.UUID
+	UserID    uuid.UUID
+	UpdatedAt time.Time
+}
+
+type AddTeamMemberPayload struct {
+	Email  string           `json:"email" validate:"required,email" example:"contractor@example.com"`
+	Role   models.Userroles `json:"role" validate:"required,oneof=Manager Member" example:"Member"`
+	RoleId uuid.UUID        `json:"roleId,omitempty"`
+}
+
+type TeamMembersListResponse struct {
+	Data []models.TeamMember `json:"data"`
+}
+
+type TeamMemberResponse struct {
+	Member models.TeamMember `json:"member"`
+}
@@ -0,0 +1,24 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+)
+
+type IUserIdentityRepository interface {
+	GetUserIdentity(context.Context, GetUserIdentityData) (models.UserIdentity, error)
+	CreateUserIdentity(context.Context, models.UserIdentity) error
+	ProvisionUser(context.Context, ProvisionUserData) (models.User, error)
+}
+
+type GetUserIdentityData struct {
+	Issuer  string
+	Subject string
+}
+
+// ProvisionUserData creates User and links Identity to it, the ids of Identity are set by the repository
+type ProvisionUserData struct {
+	User     models.User
+	Identity models.UserIdentity
+}
@@ -11,17 +11,27 @@ import (
 
 type IUserRepository interface {
 	CreateUser(context.Context, models.User) (models.User, error)
+	AdminExists(context.Context) (bool, error)
+	CreateFirstAdmin(context.Context, models.User) (created bool, user models.User, err error)
 	GetUserByEmail(context.Context, string) (models.User, error)
 	GetUserById(context.Context, uuid.UUID) (models.User, error)
 	GetUsers(context.Context, GetUserFilters) ([]models.User, error)
 	UpdateUser(context.Context, UpdateUserData) (models.User, error)
+	UpdateUserRole(context.Context, UpdateUserRoleData) (models.User, error)
+	AssignUserRole(context.Context, AssignUserRoleData) (models.User, error)
+	ChangeUserPassword(context.Context, ChangeUserPasswordData) error
+	GetUserWorkTeamIds(context.Context, uuid.UUID) ([]uuid.UUID, error)
+	GetUserOffboarding(context.Context, uuid.UUID, []uuid.UUID) (UserOffboardingReport, error)
+	DeactivateUser(context.Context, DeactivateUserData) error
+	ReactivateUser(context.Context, ReactivateUserData) (models.User, error)
 	DeleteUser(context.Context, uuid.UUID) error
 }
 
 type CreateAdminRequest struct {
-	Username string `json:"username" example:"admin"`
-	Password string `json:"password" example:"password123"`
-	Email    string `json:"email" example:"admin@example.com"`
+	Username   string `json:"username" example:"admin"`
+	Password   string `json:"password" example:"password123"`
+	Email      string `json:"email" example:"admin@example.com"`
+	SetupToken string `json:"setupToken" example:"x4CZ0n6sQm9aL2pE7hV1bR8tW3yK5uJ0dF6gH2jN9cM"`
 }
 
 type CreateAdminResponse struct {
@@ -49,7 +59,7 @@ type MessageResponse struct {
 
 type GetUserParams struct {
 	Email  string           `query:"email"`
-	TeamId uuid.UUID        `query:"teamId,required, uuid"`
+	TeamId uuid.UUID        `query:"teamId"`
 	Role   models.Userroles `query:"role,oneof=Admin Manager Member"`
 	Cursor string           `query:"cursor"`
 	Limit  uint64           `query:"limit,required"`
@@ -74,9 +84,13 @@ type CreateUserPayload struct {
 	TeamId   uuid.UUID        `json:"teamId" validate:"uuid"`
 }
 
+// UpdateUserPayload changes a user, RoleId assigns a role of the team or a built-in one, which also sets
+// the base role
 type UpdateUserPayload struct {
-	Username string `json:"username" validate:"required"`
-	Email    string `json:"email" validate:"required,email"`
+	Username string           `json:"username" validate:"required"`
+	Email    string           `json:"email" validate:"required,email"`
+	Role     models.Userroles `json:"role,omitempty" validate:"omitempty,oneof=Manager Member" example:"Member"`
+	RoleId   uuid.UUID        `json:"roleId,omitempty"`
 }
 
 type UpdateUserData struct {
@@ -85,3 +99,83 @@ type UpdateUserData struct {
 	UpdatedAt time.Time
 	ID        uuid.UUID
 }
+
+type UpdateUserRoleData struct {
+	Role      models.Userroles
+	UpdatedAt time.Time
+	ID        uuid.UUID
+}
+
+type AssignUserRoleData struct {
+	RoleId    uuid.UUID
+	UpdatedAt time.Time
+	ID        uuid.UUID
+}
+
+// UpdateCurrentUserPayload changes the profile of the logged in user, empty fields are left as they are.
+// CurrentPassword is only needed to change the email
+type UpdateCurrentUserPayload struct {
+	Username        string `json:"username" example:"jdoe"`
+	Email           string `json:"email" validate:"omitempty,email" example:"jdoe@example.com"`
+	CurrentPassword string `json:"currentPassword" example:"password123"`
+}
+
+type ChangePasswordPayload struct {
+	CurrentPassword string `json:"currentPassword" validate:"required" example:"password123"`
+	NewPassword     string `json:"newPassword" validate:"required,nefield=CurrentPassword" example:"newpassword123"`
+}
+
+type ChangeUserPasswordData struct {
+	Password      string
+	UpdatedAt     time.Time
+	ID            uuid.UUID
+	KeepSessionID uuid.UUID
+}
+
+// UserOffboardingReport is what a user leaves behind, it has to be handed over to other users before
+// deactivating them
+type UserOffboardingReport struct {
+	Teams    []models.Team     `json:"teams"`
+	Projects []models.Project  `json:"projects"`
+	Tasks    []OffboardingTask `json:"tasks"`
+}
+
+// OffboardingTask is a task assigned to the user that isn't done, with the team of its project
+type OffboardingTask struct {
+	models.Task
+	TeamID uuid.UUID `json:"teamId"`
+}
+
+// DeleteUserParams previews the offboarding with DryRun, Permanent deletes a deactivated user for good
+type DeleteUserParams struct {
+	DryRun    bool `query:"dryRun"`
+	Permanent bool `query:"permanent"`
+}
+
+// DeactivateUserPayload names the users that take over the teams, projects and open tasks of the user
+// being deactivated, each one is required when the user has any
+type DeactivateUserPayload struct {
+	TeamOwnerId      uuid.UUID `json:"teamOwnerId,omitempty"`
+	ProjectManagerId uuid.UUID `json:"projectManagerId,omitempty"`
+	TaskAssigneeId   uuid.UUID `json:"taskAssigneeId,omitempty"`
+}
+
+// DeactivateUserData hands over the work of the user in TeamIDs only
+type DeactivateUserData struct {
+	ID               uuid.UUID
+	TeamIDs          []uuid.UUID
+	TeamOwnerID      uuid.UUID
+	ProjectManagerID uuid.UUID
+	TaskAssigneeID   uuid.UUID
+	DeactivatedAt    time.Time
+}
+
+type ReactivateUserData struct {
+	ID        uuid.UUID
+	UpdatedAt time.Time
+}
+
+type UserOffboardingResponse struct {
+	Deactivated bool                  `json:"deactivated" example:"false"`
+	Report      UserOffboardingReport `json:"report"`
+}
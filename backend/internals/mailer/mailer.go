@@ -0,0 +1,59 @@
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"os"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+)
+
+// New builds the mailer selected by MAILER_DRIVER, "smtp" or "outbox" (default)
+func New() (interfaces.Mailer, error) {
+	from := os.Getenv("MAIL_FROM")
+	if from == "" {
+		from = "no-reply@localhost"
+	}
+
+	switch os.Getenv("MAILER_DRIVER") {
+	case "smtp":
+		host := os.Getenv("SMTP_HOST")
+		if host == "" {
+			return nil, fmt.Errorf("missing SMTP_HOST env")
+		}
+
+		port := os.Getenv("SMTP_PORT")
+		if port == "" {
+			port = "587"
+		}
+
+		return NewSMTPMailer(host, port, os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"), from), nil
+	case "", "outbox":
+		dir := os.Getenv("MAIL_OUTBOX_DIR")
+		if dir == "" {
+			dir = "outbox"
+		}
+
+		return NewOutboxMailer(dir, from), nil
+	default:
+		return nil, fmt.Errorf("unknown MAILER_DRIVER %q", os.Getenv("MAILER_DRIVER"))
+	}
+}
+
+// buildMessage renders the email as a plain text RFC 5322 message
+func buildMessage(from string, email interfaces.Email, date time.Time) []byte {
+	var msg bytes.Buffer
+
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", email.To)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", email.Subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(email.Body)
+
+	return msg.Bytes()
+}
@@ -0,0 +1,49 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+)
+
+var ErrQueueFull = errors.New("the mail queue is full")
+
+// QueueMailer hands the emails to a background worker that sends them with the wrapped mailer, so
+// requests don't wait for (or take longer because of) the mail server
+type QueueMailer struct {
+	next  interfaces.Mailer
+	queue chan interfaces.Email
+}
+
+func NewQueueMailer(next interfaces.Mailer, size int) *QueueMailer {
+	return &QueueMailer{
+		next:  next,
+		queue: make(chan interfaces.Email, size),
+	}
+}
+
+// Send enqueues the email and returns right away, errors sending it are only logged
+func (m *QueueMailer) Send(c context.Context, email interfaces.Email) error {
+	select {
+	case m.queue <- email:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Run sends the queued emails until ctx is done
+func (m *QueueMailer) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case email := <-m.queue:
+			if err := m.next.Send(ctx, email); err != nil {
+				log.Printf("error sending email %q: %v", email.Subject, err)
+			}
+		}
+	}
+}
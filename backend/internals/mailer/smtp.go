@@ -0,0 +1,35 @@
+package mailer
+
+import (
+	"context"
+	"net"
+	"net/smtp"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+)
+
+type SMTPMailer struct {
+	addr string
+	host string
+	auth smtp.Auth
+	from string
+}
+
+func NewSMTPMailer(host string, port string, username string, password string, from string) *SMTPMailer {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SMTPMailer{
+		addr: net.JoinHostPort(host, port),
+		host: host,
+		auth: auth,
+		from: from,
+	}
+}
+
+func (m *SMTPMailer) Send(c context.Context, email interfaces.Email) error {
+	return smtp.SendMail(m.addr, m.auth, m.from, []string{email.To}, buildMessage(m.from, email, time.Now()))
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/google/uuid"
+)
+
+// Invitation is pending until accepted, TokenID is the jti of the only link that can accept it
+type Invitation struct {
+	ID         uuid.UUID    `json:"id"`
+	TeamID     uuid.UUID    `json:"teamId"`
+	Email      string       `json:"email"`
+	Role       Userroles    `json:"role"`
+	TokenID    uuid.UUID    `json:"-"`
+	InvitedBy  uuid.UUID    `json:"invitedBy"`
+	ExpiresAt  time.Time    `json:"expiresAt"`
+	CreatedAt  time.Time    `json:"createdAt"`
+	UpdatedAt  time.Time    `json:"updatedAt"`
+	AcceptedAt sql.NullTime `json:"-"`
+}
+
+func DatabaseInvitationToInvitation(dbInvitation database.Invitation) Invitation {
+	return Invitation{
+		ID:         dbInvitation.ID,
+		TeamID:     dbInvitation.TeamID,
+		Email:      dbInvitation.Email,
+		Role:       Userroles(dbInvitation.Role),
+		TokenID:    dbInvitation.TokenID,
+		InvitedBy:  dbInvitation.InvitedBy,
+		ExpiresAt:  dbInvitation.ExpiresAt,
+		CreatedAt:  dbInvitation.CreatedAt,
+		UpdatedAt:  dbInvitation.UpdatedAt,
+		AcceptedAt: dbInvitation.AcceptedAt,
+	}
+}
+
+func DatabaseInvitationsToInvitations(dbInvitations []database.Invitation) []Invitation {
+	res := []Invitation{}
+	for _, i := range dbInvitations {
+		res = append(res, DatabaseInvitationToInvitation(i))
+	}
+
+	return res
+}
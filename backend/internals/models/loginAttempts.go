@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+)
+
+const (
+	LoginAttemptScopeAccount = "account"
+	LoginAttemptScopeIp      = "ip"
+)
+
+// LoginAttempt counts the failed logins of an email (account scope) or an ip address (ip scope)
+// since the last success or since the tracking window expired
+type LoginAttempt struct {
+	Scope        string    `json:"scope"`
+	Identifier   string    `json:"identifier"`
+	FailedCount  int32     `json:"failedCount"`
+	LastFailedAt time.Time `json:"lastFailedAt"`
+}
+
+func DatabaseLoginAttemptToLoginAttempt(dbLoginAttempt database.LoginAttempt) LoginAttempt {
+	return LoginAttempt{
+		Scope:        dbLoginAttempt.Scope,
+		Identifier:   dbLoginAttempt.Identifier,
+		FailedCount:  dbLoginAttempt.FailedCount,
+		LastFailedAt: dbLoginAttempt.LastFailedAt,
+	}
+}
+
+func DatabaseLoginAttemptsToLoginAttempts(dbLoginAttempts []database.LoginAttempt) []LoginAttempt {
+	res := []LoginAttempt{}
+	for _, a := range dbLoginAttempts {
+		res = append(res, DatabaseLoginAttemptToLoginAttempt(a))
+	}
+
+	return res
+}
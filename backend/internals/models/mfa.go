@@ -0,0 +1,47 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/google/uuid"
+)
+
+// UserTotp is enabled once EnabledAt is set, LastUsedStep keeps a code from being used twice
+type UserTotp struct {
+	UserID       uuid.UUID    `json:"userId"`
+	Secret       string       `json:"-"`
+	EnabledAt    sql.NullTime `json:"-"`
+	LastUsedStep int64        `json:"-"`
+	CreatedAt    time.Time    `json:"createdAt"`
+	UpdatedAt    time.Time    `json:"updatedAt"`
+}
+
+// MfaPendingToken is the mfa token of a login waiting for the second factor, it can be used once
+type MfaPendingToken struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UsedAt    sql.NullTime
+}
+
+type MfaRecoveryCode struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	CodeHash  string
+	CreatedAt time.Time
+	UsedAt    sql.NullTime
+}
+
+func DatabaseUserTotpToUserTotp(dbUserTotp database.UserTotp) UserTotp {
+	return UserTotp{
+		UserID:       dbUserTotp.UserID,
+		Secret:       dbUserTotp.Secret,
+		EnabledAt:    dbUserTotp.EnabledAt,
+		LastUsedStep: dbUserTotp.LastUsedStep,
+		CreatedAt:    dbUserTotp.CreatedAt,
+		UpdatedAt:    dbUserTotp.UpdatedAt,
+	}
+}
@@ -0,0 +1,106 @@
+package models
+
+import (
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/google/uuid"
+)
+
+// Organization owns teams, its admins manage all of them. RequireMfa is required by every team of the
+// organization
+type Organization struct {
+	ID         uuid.UUID `json:"id"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+	Name       string    `json:"name"`
+	RequireMfa bool      `json:"requireMfa"`
+}
+
+type OrganizationAdmin struct {
+	OrganizationID uuid.UUID `json:"organizationId"`
+	UserID         uuid.UUID `json:"userId"`
+	Username       string    `json:"username,omitempty"`
+	Email          string    `json:"email,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// OrganizationUser is a user of the directory of an organization, with the teams of the organization they
+// are a member of
+type OrganizationUser struct {
+	ID       uuid.UUID    `json:"id"`
+	Username string       `json:"username"`
+	Email    string       `json:"email"`
+	Teams    []TeamMember `json:"teams"`
+}
+
+func DatabaseOrganizationToOrganization(dbOrganization database.Organization) Organization {
+	return Organization{
+		ID:         dbOrganization.ID,
+		CreatedAt:  dbOrganization.CreatedAt,
+		UpdatedAt:  dbOrganization.UpdatedAt,
+		Name:       dbOrganization.Name,
+		RequireMfa: dbOrganization.RequireMfa,
+	}
+}
+
+func DatabaseOrganizationsToOrganizations(dbOrganizations []database.Organization) []Organization {
+	res := []Organization{}
+	for _, o := range dbOrganizations {
+		res = append(res, DatabaseOrganizationToOrganization(o))
+	}
+
+	return res
+}
+
+func DatabaseOrganizationAdminToOrganizationAdmin(dbAdmin database.OrganizationAdmin) OrganizationAdmin {
+	return OrganizationAdmin{
+		OrganizationID: dbAdmin.OrganizationID,
+		UserID:         dbAdmin.UserID,
+		CreatedAt:      dbAdmin.CreatedAt,
+	}
+}
+
+func DatabaseOrganizationAdminsToOrganizationAdmins(dbAdmins []database.GetOrganizationAdminsRow) []OrganizationAdmin {
+	res := []OrganizationAdmin{}
+	for _, a := range dbAdmins {
+		res = append(res, OrganizationAdmin{
+			OrganizationID: a.OrganizationID,
+			UserID:         a.UserID,
+			Username:       a.Username,
+			Email:          a.Email,
+			CreatedAt:      a.CreatedAt,
+		})
+	}
+
+	return res
+}
+
+// DatabaseOrganizationUsersToOrganizationUsers groups the memberships of the organization by user, they
+// come ordered by user
+func DatabaseOrganizationUsersToOrganizationUsers(dbMemberships []database.GetOrganizationUsersRow) []OrganizationUser {
+	res := []OrganizationUser{}
+	for _, m := range dbMemberships {
+		if len(res) == 0 || res[len(res)-1].ID != m.UserID {
+			res = append(res, OrganizationUser{
+				ID:       m.UserID,
+				Username: m.Username,
+				Email:    m.Email,
+				Teams:    []TeamMember{},
+			})
+		}
+
+		user := &res[len(res)-1]
+		user.Teams = append(user.Teams, TeamMember{
+			TeamID:    m.TeamID,
+			UserID:    m.UserID,
+			Role:      Userroles(m.Role),
+			RoleId:    memberRoleId(m.Role, m.RoleID),
+			TeamName:  m.Name,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/google/uuid"
+)
+
+// PasswordResetToken only keeps the hash of the token sent by email
+type PasswordResetToken struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	TokenHash string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UsedAt    sql.NullTime
+}
+
+func DatabasePasswordResetTokenToPasswordResetToken(dbToken database.PasswordResetToken) PasswordResetToken {
+	return PasswordResetToken{
+		ID:        dbToken.ID,
+		UserID:    dbToken.UserID,
+		TokenHash: dbToken.TokenHash,
+		ExpiresAt: dbToken.ExpiresAt,
+		CreatedAt: dbToken.CreatedAt,
+		UsedAt:    dbToken.UsedAt,
+	}
+}
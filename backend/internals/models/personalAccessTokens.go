@@ -0,0 +1,105 @@
+package models
+
+import (
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/google/uuid"
+)
+
+const (
+	ScopeTasksRead     = "tasks:read"
+	ScopeTasksWrite    = "tasks:write"
+	ScopeProjectsRead  = "projects:read"
+	ScopeProjectsWrite = "projects:write"
+	ScopeUsersRead     = "users:read"
+)
+
+// PersonalAccessToken only keeps the hash of the token, TokenPrefix is its first characters so the
+// user can tell their tokens apart
+type PersonalAccessToken struct {
+	ID          uuid.UUID  `json:"id"`
+	UserID      uuid.UUID  `json:"userId"`
+	Name        string     `json:"name"`
+	TokenHash   string     `json:"-"`
+	TokenPrefix string     `json:"tokenPrefix"`
+	Scopes      []string   `json:"scopes"`
+	ExpiresAt   time.Time  `json:"expiresAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	LastUsedAt  *time.Time `json:"lastUsedAt"`
+}
+
+// PersonalAccessTokenWithUser carries the current role and team of the owner, so role changes
+// apply to their tokens right away
+type PersonalAccessTokenWithUser struct {
+	PersonalAccessToken
+	UserRole   Userroles
+	UserTeamId uuid.UUID
+	UserRoleId uuid.UUID
+}
+
+// HasScopes tells if the token was granted every one of the scopes
+func (t PersonalAccessToken) HasScopes(scopes ...string) bool {
+	for _, scope := range scopes {
+		granted := false
+		for _, s := range t.Scopes {
+			if s == scope {
+				granted = true
+				break
+			}
+		}
+
+		if !granted {
+			return false
+		}
+	}
+
+	return true
+}
+
+func DatabasePersonalAccessTokenToPersonalAccessToken(dbToken database.PersonalAccessToken) PersonalAccessToken {
+	token := PersonalAccessToken{
+		ID:          dbToken.ID,
+		UserID:      dbToken.UserID,
+		Name:        dbToken.Name,
+		TokenHash:   dbToken.TokenHash,
+		TokenPrefix: dbToken.TokenPrefix,
+		Scopes:      dbToken.Scopes,
+		ExpiresAt:   dbToken.ExpiresAt,
+		CreatedAt:   dbToken.CreatedAt,
+	}
+
+	if dbToken.LastUsedAt.Valid {
+		token.LastUsedAt = &dbToken.LastUsedAt.Time
+	}
+
+	return token
+}
+
+func DatabasePersonalAccessTokensToPersonalAccessTokens(dbTokens []database.PersonalAccessToken) []PersonalAccessToken {
+	res := []PersonalAccessToken{}
+	for _, t := range dbTokens {
+		res = append(res, DatabasePersonalAccessTokenToPersonalAccessToken(t))
+	}
+
+	return res
+}
+
+func DatabasePersonalAccessTokenWithUserToPersonalAccessTokenWithUser(dbToken database.GetPersonalAccessTokenByHashRow) PersonalAccessTokenWithUser {
+	return PersonalAccessTokenWithUser{
+		PersonalAccessToken: DatabasePersonalAccessTokenToPersonalAccessToken(database.PersonalAccessToken{
+			ID:          dbToken.ID,
+			UserID:      dbToken.UserID,
+			Name:        dbToken.Name,
+			TokenHash:   dbToken.TokenHash,
+			TokenPrefix: dbToken.TokenPrefix,
+			Scopes:      dbToken.Scopes,
+			ExpiresAt:   dbToken.ExpiresAt,
+			CreatedAt:   dbToken.CreatedAt,
+			LastUsedAt:  dbToken.LastUsedAt,
+		}),
+		UserRole:   Userroles(dbToken.UserRole),
+		UserTeamId: dbToken.UserTeamID.UUID,
+		UserRoleId: dbToken.UserRoleID,
+	}
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/google/uuid"
+)
+
+type Projectmemberroles string
+
+const (
+	ProjectmemberrolesOwner       Projectmemberroles = "Owner"
+	ProjectmemberrolesContributor Projectmemberroles = "Contributor"
+	ProjectmemberrolesViewer      Projectmemberroles = "Viewer"
+)
+
+// ProjectMember is a user with a role in a project: owners manage the project with its other owners,
+// contributors can be assigned its tasks and viewers only see them
+type ProjectMember struct {
+	ProjectID uuid.UUID          `json:"projectId"`
+	UserID    uuid.UUID          `json:"userId"`
+	Role      Projectmemberroles `json:"role"`
+	Username  string             `json:"username,omitempty"`
+	Email     string             `json:"email,omitempty"`
+	CreatedAt time.Time          `json:"createdAt"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+}
+
+// CanBeAssigned tells if tasks of the project can be assigned to the member
+func (pm ProjectMember) CanBeAssigned() bool {
+	return pm.Role == ProjectmemberrolesOwner || pm.Role == ProjectmemberrolesContributor
+}
+
+func DatabaseProjectMemberToProjectMember(dbMember database.ProjectMember) ProjectMember {
+	return ProjectMember{
+		ProjectID: dbMember.ProjectID,
+		UserID:    dbMember.UserID,
+		Role:      Projectmemberroles(dbMember.Role),
+		CreatedAt: dbMember.CreatedAt,
+		UpdatedAt: dbMember.UpdatedAt,
+	}
+}
+
+func DatabaseProjectMembersToProjectMembers(dbMembers []database.GetProjectMembersRow) []ProjectMember {
+	res := []ProjectMember{}
+	for _, m := range dbMembers {
+		res = append(res, ProjectMember{
+			ProjectID: m.ProjectID,
+			UserID:    m.UserID,
+			Role:      Projectmemberroles(m.Role),
+			Username:  m.Username,
+			Email:     m.Email,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		})
+	}
+
+	return res
+}
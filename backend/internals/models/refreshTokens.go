@@ -1,6 +1,7 @@
 package models
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
@@ -14,6 +15,8 @@ type RefreshToken struct {
 	ExpiresAt time.Time
 	CreatedAt time.Time
 	Revoked   bool
+	FamilyID  uuid.UUID
+	UsedAt    sql.NullTime
 }
 
 type RefreshTokenWithUser struct {
@@ -23,6 +26,8 @@ type RefreshTokenWithUser struct {
 	ExpiresAt time.Time
 	CreatedAt time.Time
 	Revoked   bool
+	FamilyID  uuid.UUID
+	UsedAt    sql.NullTime
 	UserData  User
 }
 
@@ -34,6 +39,8 @@ func DatabaseRefreshTokenToRefreshToken(dbRefreshToken database.RefreshToken) Re
 		ExpiresAt: dbRefreshToken.ExpiresAt,
 		CreatedAt: dbRefreshToken.CreatedAt,
 		Revoked:   dbRefreshToken.Revoked,
+		FamilyID:  dbRefreshToken.FamilyID,
+		UsedAt:    dbRefreshToken.UsedAt,
 	}
 }
 
@@ -45,6 +52,8 @@ func DatabaseRefreshTokenWithUserToRefreshTokenWithUser(dbRefreshToken database.
 		ExpiresAt: dbRefreshToken.ExpiresAt,
 		CreatedAt: dbRefreshToken.CreatedAt,
 		Revoked:   dbRefreshToken.Revoked,
+		FamilyID:  dbRefreshToken.FamilyID,
+		UsedAt:    dbRefreshToken.UsedAt,
 		UserData: User{
 			ID:        dbRefreshToken.Userdataid,
 			CreatedAt: dbRefreshToken.Userdatacreatedat,
@@ -53,6 +62,7 @@ func DatabaseRefreshTokenWithUserToRefreshTokenWithUser(dbRefreshToken database.
 			Password:  dbRefreshToken.Userdatapassword,
 			Email:     dbRefreshToken.Userdataemail,
 			Role:      Userroles(dbRefreshToken.Userdatarole),
+			RoleId:    dbRefreshToken.Userdataroleid,
 		},
 	}
 }
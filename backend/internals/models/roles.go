@@ -0,0 +1,120 @@
+package models
+
+import (
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/google/uuid"
+)
+
+// Permissions a role can be granted over the users, projects and tasks of its team. Own projects are
+// the ones the user owns, own tasks the ones of those projects, and member projects the ones the user
+// is a member of. Assigned tasks can only have their status changed and their description added to
+const (
+	PermissionUsersView          = "users:view"
+	PermissionUsersManage        = "users:manage"
+	PermissionProjectsView       = "projects:view"
+	PermissionProjectsViewMember = "projects:view_member"
+	PermissionProjectsCreate     = "projects:create"
+	PermissionProjectsEditOwn    = "projects:edit_own"
+	PermissionProjectsEditAny    = "projects:edit_any"
+	PermissionProjectsDeleteOwn  = "projects:delete_own"
+	PermissionProjectsDeleteAny  = "projects:delete_any"
+	PermissionTasksViewAssigned  = "tasks:view_assigned"
+	PermissionTasksViewMember    = "tasks:view_member"
+	PermissionTasksViewAny       = "tasks:view_any"
+	PermissionTasksCreateOwn     = "tasks:create_own"
+	PermissionTasksCreateAny     = "tasks:create_any"
+	PermissionTasksEditAssigned  = "tasks:edit_assigned"
+	PermissionTasksEditOwn       = "tasks:edit_own"
+	PermissionTasksEditAny       = "tasks:edit_any"
+	PermissionTasksDeleteOwn     = "tasks:delete_own"
+	PermissionTasksDeleteAny     = "tasks:delete_any"
+)
+
+// BuiltInRoleIds are the ids the roles migration gives the built-in roles, every team can assign them
+var BuiltInRoleIds = map[Userroles]uuid.UUID{
+	UserrolesAdmin:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+	UserrolesManager: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+	UserrolesMember:  uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+}
+
+// BuiltInRolePermissions are the permissions of the built-in roles, the roles migrations store the same
+// ones so they are listed with the custom roles
+var BuiltInRolePermissions = map[Userroles][]string{
+	UserrolesAdmin: {
+		PermissionUsersView,
+		PermissionUsersManage,
+		PermissionProjectsView,
+		PermissionProjectsCreate,
+		PermissionProjectsEditAny,
+		PermissionProjectsDeleteAny,
+		PermissionTasksViewAny,
+		PermissionTasksCreateAny,
+		PermissionTasksEditAny,
+		PermissionTasksDeleteAny,
+	},
+	UserrolesManager: {
+		PermissionUsersView,
+		PermissionProjectsView,
+		PermissionProjectsCreate,
+		PermissionProjectsEditOwn,
+		PermissionProjectsDeleteOwn,
+		PermissionTasksViewAny,
+		PermissionTasksCreateOwn,
+		PermissionTasksEditOwn,
+		PermissionTasksDeleteOwn,
+	},
+	UserrolesMember: {
+		PermissionTasksViewAssigned,
+		PermissionProjectsViewMember,
+		PermissionTasksViewMember,
+		PermissionTasksEditAssigned,
+	},
+}
+
+// IsBuiltInRole tells if the id is the one of a built-in role
+func IsBuiltInRole(id uuid.UUID) bool {
+	for _, builtInId := range BuiltInRoleIds {
+		if id == builtInId {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Role is a set of permissions. Built-in roles have the base role they stand for and no team, custom
+// roles belong to the team that created them
+type Role struct {
+	ID          uuid.UUID     `json:"id"`
+	CreatedAt   time.Time     `json:"createdAt"`
+	UpdatedAt   time.Time     `json:"updatedAt"`
+	TeamID      uuid.NullUUID `json:"teamId"`
+	Name        string        `json:"name"`
+	Permissions []string      `json:"permissions"`
+	BuiltIn     bool          `json:"builtIn"`
+	BaseRole    Userroles     `json:"baseRole,omitempty"`
+}
+
+func DatabaseRoleToRole(dbRole database.Role) Role {
+	return Role{
+		ID:          dbRole.ID,
+		CreatedAt:   dbRole.CreatedAt,
+		UpdatedAt:   dbRole.UpdatedAt,
+		TeamID:      dbRole.TeamID,
+		Name:        dbRole.Name,
+		Permissions: dbRole.Permissions,
+		BuiltIn:     dbRole.BaseRole.Valid,
+		BaseRole:    Userroles(dbRole.BaseRole.Userroles),
+	}
+}
+
+func DatabaseRolesToRoles(dbRoles []database.Role) []Role {
+	res := []Role{}
+	for _, r := range dbRoles {
+		res = append(res, DatabaseRoleToRole(r))
+	}
+
+	return res
+}
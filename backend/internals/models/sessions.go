@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/google/uuid"
+)
+
+// Session is a logged in device, TeamID is the team it works in
+type Session struct {
+	ID         uuid.UUID `json:"id"`
+	UserID     uuid.UUID `json:"userId"`
+	Label      string    `json:"label"`
+	UserAgent  string    `json:"userAgent"`
+	IpAddress  string    `json:"ipAddress"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+	TeamID     uuid.UUID `json:"teamId"`
+}
+
+func DatabaseSessionToSession(dbSession database.Session) Session {
+	return Session{
+		ID:         dbSession.ID,
+		UserID:     dbSession.UserID,
+		Label:      dbSession.Label,
+		UserAgent:  dbSession.UserAgent,
+		IpAddress:  dbSession.IpAddress,
+		CreatedAt:  dbSession.CreatedAt,
+		LastUsedAt: dbSession.LastUsedAt,
+		TeamID:     dbSession.TeamID.UUID,
+	}
+}
+
+func DatabaseSessionsToSessions(dbSessions []database.Session) []Session {
+	res := []Session{}
+	for _, s := range dbSessions {
+		res = append(res, DatabaseSessionToSession(s))
+	}
+
+	return res
+}
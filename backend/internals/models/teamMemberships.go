@@ -0,0 +1,86 @@
+package models
+
+import (
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/google/uuid"
+)
+
+// TeamMember is a user with a role in a team. Users can be members of several teams with a different
+// role in each one, RoleId is the built-in or custom role that sets their permissions in the team
+type TeamMember struct {
+	TeamID    uuid.UUID `json:"teamId"`
+	UserID    uuid.UUID `json:"userId"`
+	Role      Userroles `json:"role"`
+	RoleId    uuid.UUID `json:"roleId"`
+	Username  string    `json:"username,omitempty"`
+	Email     string    `json:"email,omitempty"`
+	TeamName  string    `json:"teamName,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// InTeam is the user working in the team of the membership, with the role it has in it
+func (u User) InTeam(member TeamMember) User {
+	u.TeamId = member.TeamID
+	u.Role = member.Role
+	u.RoleId = member.RoleId
+
+	return u
+}
+
+// memberRoleId is the role of a membership, the built-in role of its base role when it has none
+func memberRoleId(role database.Userroles, roleId uuid.NullUUID) uuid.UUID {
+	if roleId.Valid {
+		return roleId.UUID
+	}
+
+	return BuiltInRoleIds[Userroles(role)]
+}
+
+func DatabaseTeamMembershipToTeamMember(dbMembership database.TeamMembership) TeamMember {
+	return TeamMember{
+		TeamID:    dbMembership.TeamID,
+		UserID:    dbMembership.UserID,
+		Role:      Userroles(dbMembership.Role),
+		RoleId:    memberRoleId(dbMembership.Role, dbMembership.RoleID),
+		CreatedAt: dbMembership.CreatedAt,
+		UpdatedAt: dbMembership.UpdatedAt,
+	}
+}
+
+func DatabaseTeamMembershipsToTeamMembers(dbMemberships []database.GetTeamMembershipsRow) []TeamMember {
+	res := []TeamMember{}
+	for _, m := range dbMemberships {
+		res = append(res, TeamMember{
+			TeamID:    m.TeamID,
+			UserID:    m.UserID,
+			Role:      Userroles(m.Role),
+			RoleId:    memberRoleId(m.Role, m.RoleID),
+			Username:  m.Username,
+			Email:     m.Email,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		})
+	}
+
+	return res
+}
+
+func DatabaseUserTeamMembershipsToTeamMembers(dbMemberships []database.GetUserTeamMembershipsRow) []TeamMember {
+	res := []TeamMember{}
+	for _, m := range dbMemberships {
+		res = append(res, TeamMember{
+			TeamID:    m.TeamID,
+			UserID:    m.UserID,
+			Role:      Userroles(m.Role),
+			RoleId:    memberRoleId(m.Role, m.RoleID),
+			TeamName:  m.Name,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		})
+	}
+
+	return res
+}
@@ -8,26 +8,43 @@ import (
 )
 
 type Team struct {
-	ID        uuid.UUID `json:"id"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
-	Name      string    `json:"name"`
-	OwnerID   uuid.UUID `json:"ownerId"`
+	ID             uuid.UUID `json:"id"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+	Name           string    `json:"name"`
+	OwnerID        uuid.UUID `json:"ownerId"`
+	RequireMfa     bool      `json:"requireMfa"`
+	OrganizationID uuid.UUID `json:"organizationId"`
 }
 
+// TeamFilter lists the teams owned by UserId, Name narrows them down
 type TeamFilter struct {
-	Cursor string
-	Limit  uint64
-	Name   string
-	UserId uuid.UUID
+	Limit           uint64
+	IsFirstPage     bool
+	PointsNext      bool
+	CursorCreatedAt time.Time
+	CursorId        uuid.UUID
+	Name            string
+	UserId          uuid.UUID
 }
 
 func DatabaseTeamToTeam(dbTeam database.Team) Team {
 	return Team{
-		ID:        dbTeam.ID,
-		CreatedAt: dbTeam.CreatedAt,
-		UpdatedAt: dbTeam.UpdatedAt,
-		Name:      dbTeam.Name,
-		OwnerID:   dbTeam.OwnerID,
+		ID:             dbTeam.ID,
+		CreatedAt:      dbTeam.CreatedAt,
+		UpdatedAt:      dbTeam.UpdatedAt,
+		Name:           dbTeam.Name,
+		OwnerID:        dbTeam.OwnerID,
+		RequireMfa:     dbTeam.RequireMfa,
+		OrganizationID: dbTeam.OrganizationID,
 	}
 }
+
+func DatabaseTeamsToTeams(dbTeams []database.Team) []Team {
+	res := []Team{}
+	for _, t := range dbTeams {
+		res = append(res, DatabaseTeamToTeam(t))
+	}
+
+	return res
+}
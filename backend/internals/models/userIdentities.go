@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/google/uuid"
+)
+
+// UserIdentity links a user to the subject of an external identity provider, Issuer and Subject identify
+// the account at the provider and Email is the one it had when the link was created
+type UserIdentity struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"userId"`
+	Issuer    string    `json:"issuer"`
+	Subject   string    `json:"subject"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func DatabaseUserIdentityToUserIdentity(dbIdentity database.UserIdentity) UserIdentity {
+	return UserIdentity{
+		ID:        dbIdentity.ID,
+		UserID:    dbIdentity.UserID,
+		Issuer:    dbIdentity.Issuer,
+		Subject:   dbIdentity.Subject,
+		Email:     dbIdentity.Email,
+		CreatedAt: dbIdentity.CreatedAt,
+	}
+}
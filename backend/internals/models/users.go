@@ -24,6 +24,9 @@ type User struct {
 	Email     string    `json:"email"`
 	Role      Userroles `json:"role"`
 	TeamId    uuid.UUID `json:"teamId"`
+	RoleId    uuid.UUID `json:"roleId"`
+	// DeactivatedAt is set while the user is deactivated, they can't log in until they are reactivated
+	DeactivatedAt *time.Time `json:"deactivatedAt"`
 }
 
 func DatabaseUserToUser(dbUser database.User) User {
@@ -32,7 +35,7 @@ func DatabaseUserToUser(dbUser database.User) User {
 		uuid = dbUser.TeamID.UUID
 	}
 
-	return User{
+	user := User{
 		ID:        dbUser.ID,
 		CreatedAt: dbUser.CreatedAt,
 		UpdatedAt: dbUser.UpdatedAt,
@@ -41,7 +44,14 @@ func DatabaseUserToUser(dbUser database.User) User {
 		Email:     dbUser.Email,
 		Role:      Userroles(dbUser.Role),
 		TeamId:    uuid,
+		RoleId:    dbUser.RoleID,
 	}
+
+	if dbUser.DeactivatedAt.Valid {
+		user.DeactivatedAt = &dbUser.DeactivatedAt.Time
+	}
+
+	return user
 }
 
 func DatabaseUsersToUsers(dbUsers []database.User) []User {
@@ -0,0 +1,40 @@
+package oidc
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+)
+
+// New builds the provider set in OIDC_ISSUER_URL, single sign-on is disabled when it's empty
+func New() (interfaces.OIDCProvider, error) {
+	issuerURL := os.Getenv("OIDC_ISSUER_URL")
+	if issuerURL == "" {
+		return nil, nil
+	}
+
+	clientId := os.Getenv("OIDC_CLIENT_ID")
+	if clientId == "" {
+		return nil, fmt.Errorf("missing OIDC_CLIENT_ID env")
+	}
+
+	redirectURL := os.Getenv("OIDC_REDIRECT_URL")
+	if redirectURL == "" {
+		return nil, fmt.Errorf("missing OIDC_REDIRECT_URL env")
+	}
+
+	scopes := strings.Fields(os.Getenv("OIDC_SCOPES"))
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "email", "profile"}
+	}
+
+	return NewProvider(Config{
+		IssuerURL:    issuerURL,
+		ClientID:     clientId,
+		ClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+		RedirectURL:  redirectURL,
+		Scopes:       scopes,
+	}), nil
+}
@@ -0,0 +1,250 @@
+package oidc
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MicahParks/keyfunc/v2"
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrIssuerMismatch = errors.New("oidc: issuer of the discovery document doesn't match the configured issuer")
+	ErrMissingIdToken = errors.New("oidc: token response has no id_token")
+	ErrInvalidIdToken = errors.New("oidc: invalid id token")
+)
+
+// idTokenSigningMethods are the algorithms accepted for id tokens, symmetric ones are left out
+// since the client secret isn't meant to verify signatures
+var idTokenSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+type Config struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	HTTPClient   *http.Client
+}
+
+// Provider talks to the identity provider found at Config.IssuerURL. The discovery document and the
+// signing keys are loaded on first use, so the api can start before the identity provider
+type Provider struct {
+	config Config
+	client *http.Client
+
+	mu       sync.Mutex
+	metadata *providerMetadata
+	jwks     *keyfunc.JWKS
+}
+
+type providerMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type tokenResponse struct {
+	IdToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+type idTokenClaims struct {
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	Name              string `json:"name"`
+	PreferredUsername string `json:"preferred_username"`
+	Nonce             string `json:"nonce"`
+	AuthorizedParty   string `json:"azp"`
+	jwt.RegisteredClaims
+}
+
+func NewProvider(config Config) *Provider {
+	client := config.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &Provider{
+		config: config,
+		client: client,
+	}
+}
+
+// discover fetches the discovery document and the signing keys, a failure is retried on the next call
+func (p *Provider) discover(c context.Context) (*providerMetadata, *keyfunc.JWKS, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.metadata != nil {
+		return p.metadata, p.jwks, nil
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, strings.TrimSuffix(p.config.IssuerURL, "/")+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("oidc: discovery returned status %d", resp.StatusCode)
+	}
+
+	metadata := &providerMetadata{}
+	if err := json.NewDecoder(resp.Body).Decode(metadata); err != nil {
+		return nil, nil, err
+	}
+
+	if metadata.Issuer != p.config.IssuerURL {
+		return nil, nil, ErrIssuerMismatch
+	}
+
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		return nil, nil, errors.New("oidc: incomplete discovery document")
+	}
+
+	// Keys rotated by the provider are fetched when a token signed with an unknown kid shows up
+	jwks, err := keyfunc.Get(metadata.JWKSURI, keyfunc.Options{
+		Client:            p.client,
+		RefreshUnknownKID: true,
+		RefreshRateLimit:  time.Minute,
+		RefreshTimeout:    10 * time.Second,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p.metadata = metadata
+	p.jwks = jwks
+
+	return metadata, jwks, nil
+}
+
+// AuthorizationURL is where the user is sent to sign in, the code challenge has to be the S256 one
+func (p *Provider) AuthorizationURL(c context.Context, data interfaces.OIDCAuthorizationData) (string, error) {
+	metadata, _, err := p.discover(c)
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := url.Parse(metadata.AuthorizationEndpoint)
+	if err != nil {
+		return "", err
+	}
+
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientID)
+	query.Set("redirect_uri", p.config.RedirectURL)
+	query.Set("scope", strings.Join(p.config.Scopes, " "))
+	query.Set("state", data.State)
+	query.Set("nonce", data.Nonce)
+	query.Set("code_challenge", data.CodeChallenge)
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	return authURL.String(), nil
+}
+
+// Exchange redeems the authorization code and verifies the id token it returns: signature, issuer,
+// audience, expiration and the nonce sent in the authorization request
+func (p *Provider) Exchange(c context.Context, data interfaces.OIDCExchangeData) (interfaces.OIDCIdentity, error) {
+	metadata, jwks, err := p.discover(c)
+	if err != nil {
+		return interfaces.OIDCIdentity{}, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", data.Code)
+	form.Set("redirect_uri", p.config.RedirectURL)
+	form.Set("code_verifier", data.CodeVerifier)
+	form.Set("client_id", p.config.ClientID)
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return interfaces.OIDCIdentity{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	if p.config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return interfaces.OIDCIdentity{}, err
+	}
+	defer resp.Body.Close()
+
+	tokens := tokenResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
+		return interfaces.OIDCIdentity{}, fmt.Errorf("oidc: token endpoint returned status %d", resp.StatusCode)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return interfaces.OIDCIdentity{}, fmt.Errorf("oidc: token endpoint returned %q: %s", tokens.Error, tokens.ErrorDescription)
+	}
+
+	if tokens.IdToken == "" {
+		return interfaces.OIDCIdentity{}, ErrMissingIdToken
+	}
+
+	claims := &idTokenClaims{}
+
+	_, err = jwt.ParseWithClaims(tokens.IdToken, claims, jwks.Keyfunc,
+		jwt.WithValidMethods(idTokenSigningMethods),
+		jwt.WithIssuer(metadata.Issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithIssuedAt(),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return interfaces.OIDCIdentity{}, fmt.Errorf("%w: %w", ErrInvalidIdToken, err)
+	}
+
+	if claims.Subject == "" {
+		return interfaces.OIDCIdentity{}, fmt.Errorf("%w: missing subject", ErrInvalidIdToken)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(data.Nonce)) != 1 {
+		return interfaces.OIDCIdentity{}, fmt.Errorf("%w: nonce mismatch", ErrInvalidIdToken)
+	}
+
+	// A token issued to several clients has to name us as the party it was issued for
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != p.config.ClientID {
+		return interfaces.OIDCIdentity{}, fmt.Errorf("%w: unexpected authorized party", ErrInvalidIdToken)
+	}
+
+	name := claims.Name
+	if name == "" {
+		name = claims.PreferredUsername
+	}
+
+	return interfaces.OIDCIdentity{
+		Issuer:        claims.Issuer,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          name,
+	}, nil
+}
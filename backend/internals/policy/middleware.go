@@ -0,0 +1,40 @@
+package policy
+
+import (
+	"errors"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Require rejects the request unless the role of the user has been granted the action on the
+// resource type. It goes after the auth middleware, handlers still call Authorize with the
+// resource once they load it so the ownership conditions are checked
+func Require(action Action, resourceType ResourceType) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !Allows(SubjectFromContext(c.Context()), action, resourceType) {
+			return Forbidden(c)
+		}
+
+		return c.Next()
+	}
+}
+
+// Forbidden is the response for a request Authorize denied
+func Forbidden(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusForbidden).JSON(utils.ErrorString("unauthorized"))
+}
+
+// Deny is the response for an error of Authorize: resources of another team are reported as missing
+// with the notFound message, other errors are internal
+func Deny(c *fiber.Ctx, err error, notFound string) error {
+	if errors.Is(err, ErrNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(utils.ErrorString(notFound))
+	}
+
+	if errors.Is(err, ErrForbidden) {
+		return Forbidden(c)
+	}
+
+	return c.Status(fiber.StatusInternalServerError).JSON(utils.NewError(err))
+}
@@ -0,0 +1,340 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/tenant"
+	"github.com/google/uuid"
+)
+
+type Action string
+
+const (
+	ActionCreate         Action = "create"
+	ActionRead           Action = "read"
+	ActionUpdate         Action = "update"
+	ActionDelete         Action = "delete"
+	ActionRevokeSessions Action = "revoke_sessions"
+	ActionUnlock         Action = "unlock"
+)
+
+type ResourceType string
+
+const (
+	ResourceUsers                ResourceType = "users"
+	ResourceProfile              ResourceType = "profile"
+	ResourceSessions             ResourceType = "sessions"
+	ResourcePersonalAccessTokens ResourceType = "personal_access_tokens"
+	ResourceTeams                ResourceType = "teams"
+	ResourceInvitations          ResourceType = "invitations"
+	ResourceProjects             ResourceType = "projects"
+	ResourceTasks                ResourceType = "tasks"
+	ResourceRoles                ResourceType = "roles"
+	ResourceOrganizations        ResourceType = "organizations"
+)
+
+var (
+	ErrForbidden = errors.New("unauthorized")
+	// ErrNotFound is returned for resources of another team, they are reported as missing so their
+	// existence isn't leaked
+	ErrNotFound = errors.New("not found")
+)
+
+// Subject is the user making the request. TeamID is the team it belongs to, the one it owns for an
+// admin, and uuid.Nil without one. Permissions are the ones of its custom role, nil for the built-in
+// role of Role. Organizations are the organizations it administers, once they have been looked up
+type Subject struct {
+	ID            uuid.UUID
+	Role          models.Userroles
+	TeamID        uuid.UUID
+	Permissions   []string
+	Organizations []uuid.UUID
+}
+
+func (s Subject) permissions() []string {
+	if s.Permissions == nil {
+		return models.BuiltInRolePermissions[s.Role]
+	}
+
+	return s.Permissions
+}
+
+// Resource carries the ownership data the conditions look at. OwnerID is the user the resource belongs
+// to (the user itself, the owner of a team, the manager of a project or of the project of a task) and
+// AssigneeID the user a task is assigned to. Both are empty when checking a whole collection. Members
+// are the members of the project of a project or a task with their role in it. TeamID is set on the
+// resources that belong to a team, its UUID is uuid.Nil for a user without one. OrganizationID is set on
+// organizations and their teams
+type Resource struct {
+	Type           ResourceType
+	OwnerID        uuid.UUID
+	AssigneeID     uuid.UUID
+	Members        map[uuid.UUID]models.Projectmemberroles
+	TeamID         uuid.NullUUID
+	OrganizationID uuid.NullUUID
+}
+
+// Condition decides whether a role that has been granted an action can perform it on a resource
+type Condition func(Subject, Resource) bool
+
+// Any allows the action on every resource of the type
+func Any(Subject, Resource) bool {
+	return true
+}
+
+// Owner allows the action on the resources the subject owns, projects are owned by all their owners
+func Owner(s Subject, r Resource) bool {
+	if r.OwnerID != uuid.Nil && r.OwnerID == s.ID {
+		return true
+	}
+
+	return r.Members[s.ID] == models.ProjectmemberrolesOwner
+}
+
+// Member allows the action on the projects the subject is a member of, whatever its role in them, and
+// on their tasks
+func Member(s Subject, r Resource) bool {
+	_, member := r.Members[s.ID]
+	return member
+}
+
+// Assignee allows the action on the resources assigned to the subject
+func Assignee(s Subject, r Resource) bool {
+	return r.AssigneeID != uuid.Nil && r.AssigneeID == s.ID
+}
+
+// OrganizationAdmin allows the action on the organizations the subject administers and on their teams
+func OrganizationAdmin(s Subject, r Resource) bool {
+	return r.OrganizationID.Valid && slices.Contains(s.Organizations, r.OrganizationID.UUID)
+}
+
+// either allows the action when any of the conditions does
+func either(conditions ...Condition) Condition {
+	return func(s Subject, r Resource) bool {
+		for _, condition := range conditions {
+			if condition(s, r) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+type grants map[models.Userroles]Condition
+
+func everyone(condition Condition) grants {
+	return grants{
+		models.UserrolesAdmin:   condition,
+		models.UserrolesManager: condition,
+		models.UserrolesMember:  condition,
+	}
+}
+
+// matrix lists what each role can do on the resources that aren't granted by permissions, anything not
+// listed is denied
+var matrix = map[ResourceType]map[Action]grants{
+	ResourceProfile: {
+		ActionRead:   everyone(Owner),
+		ActionUpdate: everyone(Owner),
+	},
+	ResourceSessions: {
+		ActionRead:   everyone(Owner),
+		ActionDelete: everyone(Owner),
+	},
+	ResourcePersonalAccessTokens: {
+		ActionCreate: everyone(Owner),
+		ActionRead:   everyone(Owner),
+		ActionDelete: everyone(Owner),
+	},
+	ResourceTeams: {
+		ActionCreate: {models.UserrolesAdmin: Any},
+		ActionRead:   {models.UserrolesAdmin: either(Owner, OrganizationAdmin)},
+		ActionUpdate: {models.UserrolesAdmin: either(Owner, OrganizationAdmin)},
+		ActionDelete: {models.UserrolesAdmin: either(Owner, OrganizationAdmin)},
+	},
+	ResourceOrganizations: {
+		ActionCreate: {models.UserrolesAdmin: Any},
+		ActionRead:   {models.UserrolesAdmin: OrganizationAdmin},
+		ActionUpdate: {models.UserrolesAdmin: OrganizationAdmin},
+	},
+	ResourceInvitations: {
+		ActionCreate: {models.UserrolesAdmin: Any},
+		ActionRead:   {models.UserrolesAdmin: Any},
+		ActionUpdate: {models.UserrolesAdmin: Any},
+		ActionDelete: {models.UserrolesAdmin: Any},
+	},
+	ResourceRoles: {
+		ActionCreate: {models.UserrolesAdmin: Any},
+		ActionRead:   everyone(Any),
+		ActionUpdate: {models.UserrolesAdmin: Any},
+		ActionDelete: {models.UserrolesAdmin: Any},
+	},
+}
+
+type grant struct {
+	resourceType ResourceType
+	action       Action
+	condition    Condition
+}
+
+// permissions lists what each permission of a role grants on the users, projects and tasks of the team
+var permissions = map[string][]grant{
+	models.PermissionUsersView: {{ResourceUsers, ActionRead, Any}},
+	models.PermissionUsersManage: {
+		{ResourceUsers, ActionCreate, Any},
+		{ResourceUsers, ActionUpdate, Any},
+		{ResourceUsers, ActionDelete, Any},
+		{ResourceUsers, ActionRevokeSessions, Any},
+		{ResourceUsers, ActionUnlock, Any},
+	},
+	models.PermissionProjectsView:       {{ResourceProjects, ActionRead, Any}},
+	models.PermissionProjectsViewMember: {{ResourceProjects, ActionRead, Member}},
+	models.PermissionProjectsCreate:     {{ResourceProjects, ActionCreate, Any}},
+	models.PermissionProjectsEditOwn:    {{ResourceProjects, ActionUpdate, Owner}},
+	models.PermissionProjectsEditAny:    {{ResourceProjects, ActionUpdate, Any}},
+	models.PermissionProjectsDeleteOwn:  {{ResourceProjects, ActionDelete, Owner}},
+	models.PermissionProjectsDeleteAny:  {{ResourceProjects, ActionDelete, Any}},
+	models.PermissionTasksViewAssigned:  {{ResourceTasks, ActionRead, Assignee}},
+	models.PermissionTasksViewMember:    {{ResourceTasks, ActionRead, Member}},
+	models.PermissionTasksViewAny:       {{ResourceTasks, ActionRead, Any}},
+	models.PermissionTasksCreateOwn:     {{ResourceTasks, ActionCreate, Owner}},
+	models.PermissionTasksCreateAny:     {{ResourceTasks, ActionCreate, Any}},
+	models.PermissionTasksEditAssigned:  {{ResourceTasks, ActionUpdate, Assignee}},
+	models.PermissionTasksEditOwn:       {{ResourceTasks, ActionUpdate, Owner}},
+	models.PermissionTasksEditAny:       {{ResourceTasks, ActionUpdate, Any}},
+	models.PermissionTasksDeleteOwn:     {{ResourceTasks, ActionDelete, Owner}},
+	models.PermissionTasksDeleteAny:     {{ResourceTasks, ActionDelete, Any}},
+}
+
+// grantedByPermissions are the resource types the permissions of the role decide on, the others go
+// by the matrix
+var grantedByPermissions = map[ResourceType]bool{
+	ResourceUsers:    true,
+	ResourceProjects: true,
+	ResourceTasks:    true,
+}
+
+// conditions returns the conditions under which the subject can perform the action on the resource type,
+// none when it can't at all
+func conditions(subject Subject, action Action, resourceType ResourceType) []Condition {
+	if !grantedByPermissions[resourceType] {
+		if condition, granted := matrix[resourceType][action][subject.Role]; granted {
+			return []Condition{condition}
+		}
+
+		return nil
+	}
+
+	var granted []Condition
+
+	for _, permission := range subject.permissions() {
+		for _, g := range permissions[permission] {
+			if g.resourceType == resourceType && g.action == action {
+				granted = append(granted, g.condition)
+			}
+		}
+	}
+
+	return granted
+}
+
+// Allows reports whether the subject has been granted the action on the resource type at all,
+// the conditions on each resource are checked by Can
+func Allows(subject Subject, action Action, resourceType ResourceType) bool {
+	return len(conditions(subject, action, resourceType)) > 0
+}
+
+// InTeam reports whether the resource is visible to the subject: every resource that doesn't belong to
+// a team is, the ones that do only to the users of that team. Users without a team only see themselves.
+// Organizations and their teams are visible to the admins of the organization too
+func InTeam(subject Subject, resource Resource) bool {
+	if resource.OrganizationID.Valid {
+		if OrganizationAdmin(subject, resource) {
+			return true
+		}
+
+		if resource.Type == ResourceOrganizations {
+			return false
+		}
+	}
+
+	if !resource.TeamID.Valid {
+		return true
+	}
+
+	if resource.TeamID.UUID != uuid.Nil && resource.TeamID.UUID == subject.TeamID {
+		return true
+	}
+
+	return resource.Type == ResourceUsers && resource.OwnerID != uuid.Nil && resource.OwnerID == subject.ID
+}
+
+// Can reports whether the subject can perform the action on the resource
+func Can(subject Subject, action Action, resource Resource) bool {
+	if !InTeam(subject, resource) {
+		return false
+	}
+
+	for _, condition := range conditions(subject, action, resource.Type) {
+		if condition(subject, resource) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// OnlyAsAssignee reports whether the subject can perform the action on the resource only because it's
+// assigned to it, the handlers limit what it can change then
+func OnlyAsAssignee(subject Subject, action Action, resource Resource) bool {
+	unassigned := resource
+	unassigned.AssigneeID = uuid.Nil
+
+	return Can(subject, action, resource) && !Can(subject, action, unassigned)
+}
+
+// The auth middleware stores the user of the request in these locals, and the permissions of its role
+// when it's a custom one. The handlers scope the request to its team with tenant.SetTeam once they
+// look it up, and store the organizations it administers when they need them
+const (
+	userIdLocal        = "userId"
+	userRoleLocal      = "userRole"
+	permissionsLocal   = "permissions"
+	organizationsLocal = "organizations"
+)
+
+// SubjectFromContext returns the user of the request, ctx is the fiber request context (c.Context())
+// where the auth middleware stored it
+func SubjectFromContext(ctx context.Context) Subject {
+	userId, _ := ctx.Value(userIdLocal).(string)
+	userRole, _ := ctx.Value(userRoleLocal).(string)
+	rolePermissions, _ := ctx.Value(permissionsLocal).([]string)
+	organizations, _ := ctx.Value(organizationsLocal).([]uuid.UUID)
+	teamId, _ := tenant.TeamID(ctx)
+
+	// Without an id the subject still has the grants of its role but owns nothing, and without a
+	// team it sees no resource of one
+	id, _ := uuid.Parse(userId)
+
+	return Subject{ID: id, Role: models.Userroles(userRole), TeamID: teamId, Permissions: rolePermissions, Organizations: organizations}
+}
+
+// Authorize returns ErrNotFound when the resource belongs to another team than the user of the request
+// and ErrForbidden when the user can't perform the action on it
+func Authorize(ctx context.Context, action Action, resource Resource) error {
+	subject := SubjectFromContext(ctx)
+
+	if !InTeam(subject, resource) {
+		return ErrNotFound
+	}
+
+	if !Can(subject, action, resource) {
+		return ErrForbidden
+	}
+
+	return nil
+}
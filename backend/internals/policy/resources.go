@@ -0,0 +1,102 @@
+package policy
+
+import (
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/google/uuid"
+)
+
+// Collection is a resource type as a whole, for listing or creating. Only roles granted Any pass
+func Collection(resourceType ResourceType) Resource {
+	return Resource{Type: resourceType}
+}
+
+// TeamResources is a resource type within a team, for listing or creating in it
+func TeamResources(resourceType ResourceType, teamId uuid.UUID) Resource {
+	return Resource{Type: resourceType, TeamID: inTeam(teamId)}
+}
+
+// User is a user as seen by the others, teamId is the team it belongs to or owns
+func User(user models.User, teamId uuid.UUID) Resource {
+	return Resource{Type: ResourceUsers, OwnerID: user.ID, TeamID: inTeam(teamId)}
+}
+
+// Profile is the account of the user
+func Profile(userId uuid.UUID) Resource {
+	return Resource{Type: ResourceProfile, OwnerID: userId}
+}
+
+func Session(session models.Session) Resource {
+	return Resource{Type: ResourceSessions, OwnerID: session.UserID}
+}
+
+// PersonalAccessTokens are the tokens of the user
+func PersonalAccessTokens(userId uuid.UUID) Resource {
+	return Resource{Type: ResourcePersonalAccessTokens, OwnerID: userId}
+}
+
+// TeamsOwnedBy are the teams of an admin, for looking them up before loading one
+func TeamsOwnedBy(userId uuid.UUID) Resource {
+	return Resource{Type: ResourceTeams, OwnerID: userId}
+}
+
+// Team is owned by its admin and managed by the admins of its organization, the teams of other admins are
+// not found
+func Team(team models.Team) Resource {
+	return Resource{Type: ResourceTeams, OwnerID: team.OwnerID, TeamID: inTeam(team.ID), OrganizationID: inOrganization(team.OrganizationID)}
+}
+
+// Organization is seen by its admins only
+func Organization(organization models.Organization) Resource {
+	return Resource{Type: ResourceOrganizations, OrganizationID: inOrganization(organization.ID)}
+}
+
+// Project is owned by its manager and the other owners in members
+func Project(project models.Project, members []models.ProjectMember) Resource {
+	return Resource{Type: ResourceProjects, OwnerID: project.ManagerID, Members: projectMembers(members), TeamID: inTeam(project.TeamID)}
+}
+
+// Task belongs to the owners of its project, members are the ones of the project
+func Task(task models.Task, project models.Project, members []models.ProjectMember) Resource {
+	resource := ProjectTasks(project, members)
+
+	if task.UserID.Valid {
+		resource.AssigneeID = task.UserID.UUID
+	}
+
+	return resource
+}
+
+// ProjectTasks are the tasks of a project, for creating or listing them
+func ProjectTasks(project models.Project, members []models.ProjectMember) Resource {
+	return Resource{Type: ResourceTasks, OwnerID: project.ManagerID, Members: projectMembers(members), TeamID: inTeam(project.TeamID)}
+}
+
+// MemberResources are the projects the user is a member of or their tasks, for listing them without
+// picking a project
+func MemberResources(resourceType ResourceType, userId uuid.UUID) Resource {
+	return Resource{Type: resourceType, Members: map[uuid.UUID]models.Projectmemberroles{userId: models.ProjectmemberrolesViewer}}
+}
+
+func projectMembers(members []models.ProjectMember) map[uuid.UUID]models.Projectmemberroles {
+	res := map[uuid.UUID]models.Projectmemberroles{}
+	for _, m := range members {
+		res[m.UserID] = m.Role
+	}
+
+	return res
+}
+
+// inTeam marks a resource as belonging to the team, uuid.Nil being no team at all
+func inTeam(teamId uuid.UUID) uuid.NullUUID {
+	return uuid.NullUUID{UUID: teamId, Valid: true}
+}
+
+// inOrganization marks a resource as belonging to the organization
+func inOrganization(organizationId uuid.UUID) uuid.NullUUID {
+	return uuid.NullUUID{UUID: organizationId, Valid: true}
+}
+
+// Role is seen by every team when it's built-in, custom roles only by their team
+func Role(role models.Role) Resource {
+	return Resource{Type: ResourceRoles, TeamID: role.TeamID}
+}
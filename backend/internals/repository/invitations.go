@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/google/uuid"
+)
+
+type InvitationRepository struct {
+	queries *database.Queries
+	db      *sql.DB
+}
+
+func NewInvitationRepository(queries *database.Queries, db *sql.DB) *InvitationRepository {
+	return &InvitationRepository{
+		queries: queries,
+		db:      db,
+	}
+}
+
+func (ir *InvitationRepository) CreateInvitation(c context.Context, invitationData models.Invitation) (models.Invitation, error) {
+	newInvitation, err := ir.queries.CreateInvitation(c, database.CreateInvitationParams{
+		ID:        invitationData.ID,
+		TeamID:    invitationData.TeamID,
+		Email:     invitationData.Email,
+		Role:      database.Userroles(invitationData.Role),
+		TokenID:   invitationData.TokenID,
+		InvitedBy: invitationData.InvitedBy,
+		ExpiresAt: invitationData.ExpiresAt,
+		CreatedAt: invitationData.CreatedAt,
+		UpdatedAt: invitationData.UpdatedAt,
+	})
+
+	if err != nil {
+		return models.Invitation{}, err
+	}
+
+	return models.DatabaseInvitationToInvitation(newInvitation), nil
+}
+
+func (ir *InvitationRepository) GetInvitationById(c context.Context, id uuid.UUID) (models.Invitation, error) {
+	invitation, err := ir.queries.GetInvitationById(c, id)
+
+	if err != nil {
+		return models.Invitation{}, err
+	}
+
+	return models.DatabaseInvitationToInvitation(invitation), nil
+}
+
+func (ir *InvitationRepository) GetPendingInvitationByEmail(c context.Context, teamId uuid.UUID, email string) (models.Invitation, error) {
+	invitation, err := ir.queries.GetPendingInvitationByEmail(c, database.GetPendingInvitationByEmailParams{
+		TeamID: teamId,
+		Email:  email,
+	})
+
+	if err != nil {
+		return models.Invitation{}, err
+	}
+
+	return models.DatabaseInvitationToInvitation(invitation), nil
+}
+
+func (ir *InvitationRepository) GetPendingInvitationsByTeam(c context.Context, teamId uuid.UUID) ([]models.Invitation, error) {
+	invitations, err := ir.queries.GetPendingInvitationsByTeam(c, teamId)
+
+	if err != nil {
+		return []models.Invitation{}, err
+	}
+
+	return models.DatabaseInvitationsToInvitations(invitations), nil
+}
+
+// RenewInvitation gives the invitation a new token id and expiration, links sent before stop working
+func (ir *InvitationRepository) RenewInvitation(c context.Context, data interfaces.RenewInvitationData) (models.Invitation, error) {
+	invitation, err := ir.queries.RenewInvitation(c, database.RenewInvitationParams{
+		TokenID:   data.TokenID,
+		ExpiresAt: data.ExpiresAt,
+		UpdatedAt: data.UpdatedAt,
+		ID:        data.ID,
+	})
+
+	if err != nil {
+		return models.Invitation{}, err
+	}
+
+	return models.DatabaseInvitationToInvitation(invitation), nil
+}
+
+func (ir *InvitationRepository) DeleteInvitation(c context.Context, id uuid.UUID) error {
+	err := ir.queries.DeleteInvitation(c, id)
+
+	return err
+}
+
+// AcceptInvitation creates the invited user in the invitation team with the invitation role.
+// accepted is false when the invitation was accepted, renewed or expired in the meantime
+func (ir *InvitationRepository) AcceptInvitation(c context.Context, data interfaces.AcceptInvitationData) (user models.User, accepted bool, err error) {
+	tx, err := ir.db.BeginTx(c, nil)
+	if err != nil {
+		return models.User{}, false, err
+	}
+	defer tx.Rollback()
+
+	qtx := ir.queries.WithTx(tx)
+
+	invitation, err := qtx.GetInvitationById(c, data.InvitationID)
+
+	if err != nil {
+		return models.User{}, false, err
+	}
+
+	affected, err := qtx.MarkInvitationAccepted(c, database.MarkInvitationAcceptedParams{
+		AcceptedAt: sql.NullTime{
+			Time:  data.AcceptedAt,
+			Valid: true,
+		},
+		UpdatedAt: data.AcceptedAt,
+		ID:        data.InvitationID,
+		TokenID:   data.TokenID,
+	})
+
+	if err != nil {
+		return models.User{}, false, err
+	}
+
+	if affected == 0 {
+		return models.User{}, false, nil
+	}
+
+	newUser, err := qtx.CreateUser(c, database.CreateUserParams{
+		CreatedAt: data.AcceptedAt,
+		UpdatedAt: data.AcceptedAt,
+		Username:  data.Username,
+		Password:  data.Password,
+		Email:     invitation.Email,
+		Role:      invitation.Role,
+		TeamID: uuid.NullUUID{
+			UUID:  invitation.TeamID,
+			Valid: true,
+		},
+	})
+
+	if err != nil {
+		return models.User{}, false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.User{}, false, err
+	}
+
+	return models.DatabaseUserToUser(newUser), true, nil
+}
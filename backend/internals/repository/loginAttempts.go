@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+)
+
+type LoginAttemptRepository struct {
+	queries *database.Queries
+	db      *sql.DB
+}
+
+func NewLoginAttemptRepository(queries *database.Queries, db *sql.DB) *LoginAttemptRepository {
+	return &LoginAttemptRepository{
+		queries: queries,
+		db:      db,
+	}
+}
+
+func (lr *LoginAttemptRepository) GetLoginAttempts(c context.Context, data interfaces.GetLoginAttemptsData) ([]models.LoginAttempt, error) {
+	attempts, err := lr.queries.GetLoginAttempts(c, database.GetLoginAttemptsParams{
+		AccountKey: data.AccountKey,
+		IpAddress:  data.IpAddress,
+	})
+
+	if err != nil {
+		return []models.LoginAttempt{}, err
+	}
+
+	return models.DatabaseLoginAttemptsToLoginAttempts(attempts), nil
+}
+
+// RecordFailedLogin increments the account and ip counters together and returns both
+func (lr *LoginAttemptRepository) RecordFailedLogin(c context.Context, data interfaces.RecordFailedLoginData) ([]models.LoginAttempt, error) {
+	tx, err := lr.db.BeginTx(c, nil)
+	if err != nil {
+		return []models.LoginAttempt{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := lr.queries.WithTx(tx)
+
+	attempts := []models.LoginAttempt{}
+
+	// Always in the same order so concurrent failures lock the rows in the same order
+	for _, key := range []interfaces.LoginAttemptKey{
+		{Scope: models.LoginAttemptScopeAccount, Identifier: data.AccountKey},
+		{Scope: models.LoginAttemptScopeIp, Identifier: data.IpAddress},
+	} {
+		attempt, err := qtx.RecordFailedLoginAttempt(c, database.RecordFailedLoginAttemptParams{
+			Scope:       key.Scope,
+			Identifier:  key.Identifier,
+			FailedAt:    data.FailedAt,
+			WindowStart: data.WindowStart,
+		})
+
+		if err != nil {
+			return []models.LoginAttempt{}, err
+		}
+
+		attempts = append(attempts, models.DatabaseLoginAttemptToLoginAttempt(attempt))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return []models.LoginAttempt{}, err
+	}
+
+	return attempts, nil
+}
+
+func (lr *LoginAttemptRepository) ClearLoginAttempts(c context.Context, data interfaces.LoginAttemptKey) error {
+	return lr.queries.DeleteLoginAttempt(c, database.DeleteLoginAttemptParams{
+		Scope:      data.Scope,
+		Identifier: data.Identifier,
+	})
+}
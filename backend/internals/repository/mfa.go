@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/google/uuid"
+)
+
+type MfaRepository struct {
+	queries *database.Queries
+	db      *sql.DB
+}
+
+func NewMfaRepository(queries *database.Queries, db *sql.DB) *MfaRepository {
+	return &MfaRepository{
+		queries: queries,
+		db:      db,
+	}
+}
+
+// StartTotpEnrollment stores a new secret waiting to be confirmed, replacing any unconfirmed one.
+// started is false when the user already has two-factor authentication enabled
+func (mr *MfaRepository) StartTotpEnrollment(c context.Context, totpData models.UserTotp) (totp models.UserTotp, started bool, err error) {
+	t, err := mr.queries.StartUserTotpEnrollment(c, database.StartUserTotpEnrollmentParams{
+		UserID:    totpData.UserID,
+		Secret:    totpData.Secret,
+		CreatedAt: totpData.CreatedAt,
+	})
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.UserTotp{}, false, nil
+	}
+
+	if err != nil {
+		return models.UserTotp{}, false, err
+	}
+
+	return models.DatabaseUserTotpToUserTotp(t), true, nil
+}
+
+func (mr *MfaRepository) GetUserTotp(c context.Context, userId uuid.UUID) (models.UserTotp, error) {
+	totp, err := mr.queries.GetUserTotp(c, userId)
+
+	if err != nil {
+		return models.UserTotp{}, err
+	}
+
+	return models.DatabaseUserTotpToUserTotp(totp), nil
+}
+
+// EnableTotp confirms the pending enrollment and stores the first recovery codes of the user
+func (mr *MfaRepository) EnableTotp(c context.Context, data interfaces.EnableTotpData) (enabled bool, err error) {
+	tx, err := mr.db.BeginTx(c, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	qtx := mr.queries.WithTx(tx)
+
+	affected, err := qtx.EnableUserTotp(c, database.EnableUserTotpParams{
+		EnabledAt: sql.NullTime{
+			Time:  data.EnabledAt,
+			Valid: true,
+		},
+		LastUsedStep: data.Step,
+		UpdatedAt:    data.EnabledAt,
+		UserID:       data.UserID,
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	if affected == 0 {
+		return false, nil
+	}
+
+	if err := replaceRecoveryCodes(c, qtx, data.UserID, data.RecoveryCodes); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// UseTotpStep records the step of a valid code, used is false when that step or a later one was already used
+func (mr *MfaRepository) UseTotpStep(c context.Context, data interfaces.UseTotpStepData) (used bool, err error) {
+	affected, err := mr.queries.UseUserTotpStep(c, database.UseUserTotpStepParams{
+		LastUsedStep: data.Step,
+		UpdatedAt:    data.UsedAt,
+		UserID:       data.UserID,
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// UseRecoveryCode marks the code as used, used is false when it doesn't exist or was already used
+func (mr *MfaRepository) UseRecoveryCode(c context.Context, data interfaces.UseRecoveryCodeData) (used bool, err error) {
+	affected, err := mr.queries.UseRecoveryCode(c, database.UseRecoveryCodeParams{
+		UsedAt: sql.NullTime{
+			Time:  data.UsedAt,
+			Valid: true,
+		},
+		UserID:   data.UserID,
+		CodeHash: data.CodeHash,
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (mr *MfaRepository) ReplaceRecoveryCodes(c context.Context, userId uuid.UUID, codes []models.MfaRecoveryCode) error {
+	tx, err := mr.db.BeginTx(c, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := replaceRecoveryCodes(c, mr.queries.WithTx(tx), userId, codes); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (mr *MfaRepository) DisableTotp(c context.Context, userId uuid.UUID) error {
+	tx, err := mr.db.BeginTx(c, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qtx := mr.queries.WithTx(tx)
+
+	if err := qtx.DeleteRecoveryCodesByUserId(c, userId); err != nil {
+		return err
+	}
+
+	if err := qtx.DeleteUserTotp(c, userId); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (mr *MfaRepository) CreateMfaPendingToken(c context.Context, token models.MfaPendingToken) error {
+	return mr.queries.CreateMfaPendingToken(c, database.CreateMfaPendingTokenParams{
+		ID:        token.ID,
+		UserID:    token.UserID,
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: token.CreatedAt,
+	})
+}
+
+// UseMfaPendingToken marks the mfa token as used, used is false when it doesn't exist, expired or was already used
+func (mr *MfaRepository) UseMfaPendingToken(c context.Context, data interfaces.UseMfaPendingTokenData) (used bool, err error) {
+	affected, err := mr.queries.UseMfaPendingToken(c, database.UseMfaPendingTokenParams{
+		UsedAt: sql.NullTime{
+			Time:  data.UsedAt,
+			Valid: true,
+		},
+		ID:     data.ID,
+		UserID: data.UserID,
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func replaceRecoveryCodes(c context.Context, qtx *database.Queries, userId uuid.UUID, codes []models.MfaRecoveryCode) error {
+	if err := qtx.DeleteRecoveryCodesByUserId(c, userId); err != nil {
+		return err
+	}
+
+	for _, code := range codes {
+		err := qtx.CreateRecoveryCode(c, database.CreateRecoveryCodeParams{
+			ID:        code.ID,
+			UserID:    userId,
+			CodeHash:  code.CodeHash,
+			CreatedAt: code.CreatedAt,
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
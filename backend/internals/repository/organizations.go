@@ -0,0 +1,252 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/google/uuid"
+)
+
+type OrganizationRepository struct {
+	queries *database.Queries
+	db      *sql.DB
+}
+
+func NewOrganizationRepository(queries *database.Queries, db *sql.DB) *OrganizationRepository {
+	return &OrganizationRepository{
+		queries: queries,
+		db:      db,
+	}
+}
+
+// CreateOrganization creates the organization with its admin
+func (or *OrganizationRepository) CreateOrganization(c context.Context, data interfaces.CreateOrganizationData) (models.Organization, error) {
+	tx, err := or.db.BeginTx(c, nil)
+	if err != nil {
+		return models.Organization{}, err
+	}
+	defer tx.Rollback()
+
+	organization, err := createOrganization(c, or.queries.WithTx(tx), data)
+
+	if err != nil {
+		return models.Organization{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Organization{}, err
+	}
+
+	return organization, nil
+}
+
+// createOrganization creates the organization and its admin with qtx, in the transaction of the caller
+func createOrganization(c context.Context, qtx *database.Queries, data interfaces.CreateOrganizationData) (models.Organization, error) {
+	organization, err := qtx.CreateOrganization(c, database.CreateOrganizationParams{
+		CreatedAt: data.CreatedAt,
+		UpdatedAt: data.CreatedAt,
+		Name:      data.Name,
+	})
+
+	if err != nil {
+		return models.Organization{}, err
+	}
+
+	_, err = qtx.AddOrganizationAdmin(c, database.AddOrganizationAdminParams{
+		OrganizationID: organization.ID,
+		UserID:         data.AdminID,
+		CreatedAt:      data.CreatedAt,
+	})
+
+	if err != nil {
+		return models.Organization{}, err
+	}
+
+	return models.DatabaseOrganizationToOrganization(organization), nil
+}
+
+func (or *OrganizationRepository) GetOrganizationById(c context.Context, id uuid.UUID) (models.Organization, error) {
+	organization, err := or.queries.GetOrganizationById(c, id)
+
+	if err != nil {
+		return models.Organization{}, err
+	}
+
+	return models.DatabaseOrganizationToOrganization(organization), nil
+}
+
+// GetUserOrganizations lists the organizations the user administers
+func (or *OrganizationRepository) GetUserOrganizations(c context.Context, userId uuid.UUID) ([]models.Organization, error) {
+	organizations, err := or.queries.GetUserOrganizations(c, userId)
+
+	if err != nil {
+		return []models.Organization{}, err
+	}
+
+	return models.DatabaseOrganizationsToOrganizations(organizations), nil
+}
+
+// UpdateOrganization saves the settings of the organization. Requiring two-factor authentication requires
+// it in all its teams and revokes the sessions working in them without it, so they have to log in again
+// and enroll
+func (or *OrganizationRepository) UpdateOrganization(c context.Context, data interfaces.UpdateOrganizationData) (models.Organization, error) {
+	tx, err := or.db.BeginTx(c, nil)
+	if err != nil {
+		return models.Organization{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := or.queries.WithTx(tx)
+
+	organization, err := qtx.UpdateOrganization(c, database.UpdateOrganizationParams{
+		Name:       data.Name,
+		RequireMfa: data.RequireMfa,
+		UpdatedAt:  data.UpdatedAt,
+		ID:         data.ID,
+	})
+
+	if err != nil {
+		return models.Organization{}, err
+	}
+
+	if data.RequireMfa {
+		err = qtx.RequireOrganizationTeamsMfa(c, database.RequireOrganizationTeamsMfaParams{
+			UpdatedAt:      data.UpdatedAt,
+			OrganizationID: data.ID,
+		})
+
+		if err != nil {
+			return models.Organization{}, err
+		}
+
+		if err := qtx.DeleteOrganizationSessionsWithoutMfa(c, data.ID); err != nil {
+			return models.Organization{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Organization{}, err
+	}
+
+	return models.DatabaseOrganizationToOrganization(organization), nil
+}
+
+func (or *OrganizationRepository) GetOrganizationTeams(c context.Context, organizationId uuid.UUID) ([]models.Team, error) {
+	teams, err := or.queries.GetOrganizationTeams(c, organizationId)
+
+	if err != nil {
+		return []models.Team{}, err
+	}
+
+	return models.DatabaseTeamsToTeams(teams), nil
+}
+
+// GetOrganizationUsers lists the members of the teams of the organization, each one with the teams they
+// are in and their role in each
+func (or *OrganizationRepository) GetOrganizationUsers(c context.Context, organizationId uuid.UUID) ([]models.OrganizationUser, error) {
+	users, err := or.queries.GetOrganizationUsers(c, organizationId)
+
+	if err != nil {
+		return []models.OrganizationUser{}, err
+	}
+
+	return models.DatabaseOrganizationUsersToOrganizationUsers(users), nil
+}
+
+// IsOrganizationMember reports whether the user is a member of any team of the organization
+func (or *OrganizationRepository) IsOrganizationMember(c context.Context, organizationId uuid.UUID, userId uuid.UUID) (bool, error) {
+	return or.queries.IsOrganizationMember(c, database.IsOrganizationMemberParams{
+		OrganizationID: organizationId,
+		UserID:         userId,
+	})
+}
+
+// GetOrganizationReport counts the members, projects and tasks of each team of the organization, and
+// adds them up
+func (or *OrganizationRepository) GetOrganizationReport(c context.Context, organizationId uuid.UUID) (interfaces.OrganizationReport, error) {
+	teams, err := or.queries.GetOrganizationTeamReports(c, organizationId)
+
+	if err != nil {
+		return interfaces.OrganizationReport{}, err
+	}
+
+	users, err := or.queries.CountOrganizationUsers(c, organizationId)
+
+	if err != nil {
+		return interfaces.OrganizationReport{}, err
+	}
+
+	report := interfaces.OrganizationReport{
+		Users: users,
+		Teams: []interfaces.TeamReport{},
+	}
+
+	for _, t := range teams {
+		report.Projects += t.Projects
+		report.Tasks += t.Tasks
+		report.DoneTasks += t.DoneTasks
+		report.Teams = append(report.Teams, interfaces.TeamReport{
+			TeamID:    t.ID,
+			Name:      t.Name,
+			Members:   t.Members,
+			Projects:  t.Projects,
+			Tasks:     t.Tasks,
+			DoneTasks: t.DoneTasks,
+		})
+	}
+
+	return report, nil
+}
+
+func (or *OrganizationRepository) GetOrganizationAdmin(c context.Context, organizationId uuid.UUID, userId uuid.UUID) (exists bool, admin models.OrganizationAdmin, err error) {
+	a, err := or.queries.GetOrganizationAdmin(c, database.GetOrganizationAdminParams{
+		OrganizationID: organizationId,
+		UserID:         userId,
+	})
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, models.OrganizationAdmin{}, nil
+	}
+
+	if err != nil {
+		return false, models.OrganizationAdmin{}, err
+	}
+
+	return true, models.DatabaseOrganizationAdminToOrganizationAdmin(a), nil
+}
+
+// GetOrganizationAdmins lists the admins of the organization with their username and email
+func (or *OrganizationRepository) GetOrganizationAdmins(c context.Context, organizationId uuid.UUID) ([]models.OrganizationAdmin, error) {
+	admins, err := or.queries.GetOrganizationAdmins(c, organizationId)
+
+	if err != nil {
+		return []models.OrganizationAdmin{}, err
+	}
+
+	return models.DatabaseOrganizationAdminsToOrganizationAdmins(admins), nil
+}
+
+func (or *OrganizationRepository) AddOrganizationAdmin(c context.Context, data models.OrganizationAdmin) (models.OrganizationAdmin, error) {
+	admin, err := or.queries.AddOrganizationAdmin(c, database.AddOrganizationAdminParams{
+		OrganizationID: data.OrganizationID,
+		UserID:         data.UserID,
+		CreatedAt:      data.CreatedAt,
+	})
+
+	if err != nil {
+		return models.OrganizationAdmin{}, err
+	}
+
+	return models.DatabaseOrganizationAdminToOrganizationAdmin(admin), nil
+}
+
+func (or *OrganizationRepository) RemoveOrganizationAdmin(c context.Context, organizationId uuid.UUID, userId uuid.UUID) error {
+	return or.queries.DeleteOrganizationAdmin(c, database.DeleteOrganizationAdminParams{
+		OrganizationID: organizationId,
+		UserID:         userId,
+	})
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+)
+
+type PasswordResetTokenRepository struct {
+	queries *database.Queries
+	db      *sql.DB
+}
+
+func NewPasswordResetTokenRepository(queries *database.Queries, db *sql.DB) *PasswordResetTokenRepository {
+	return &PasswordResetTokenRepository{
+		queries: queries,
+		db:      db,
+	}
+}
+
+// CreatePasswordResetToken replaces any pending token of the user, only the last email sent works
+func (prtr *PasswordResetTokenRepository) CreatePasswordResetToken(c context.Context, tokenData models.PasswordResetToken) error {
+	tx, err := prtr.db.BeginTx(c, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qtx := prtr.queries.WithTx(tx)
+
+	err = qtx.DeletePasswordResetTokensByUserId(c, tokenData.UserID)
+
+	if err != nil {
+		return err
+	}
+
+	err = qtx.CreatePasswordResetToken(c, database.CreatePasswordResetTokenParams{
+		ID:        tokenData.ID,
+		UserID:    tokenData.UserID,
+		TokenHash: tokenData.TokenHash,
+		ExpiresAt: tokenData.ExpiresAt,
+		CreatedAt: tokenData.CreatedAt,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (prtr *PasswordResetTokenRepository) GetPasswordResetTokenByHash(c context.Context, tokenHash string) (models.PasswordResetToken, error) {
+	token, err := prtr.queries.GetPasswordResetTokenByHash(c, tokenHash)
+
+	if err != nil {
+		return models.PasswordResetToken{}, err
+	}
+
+	return models.DatabasePasswordResetTokenToPasswordResetToken(token), nil
+}
+
+// ResetPassword consumes the token, sets the new password and logs the user out of every session.
+// reset is false when the token was already used or expired in the meantime
+func (prtr *PasswordResetTokenRepository) ResetPassword(c context.Context, data interfaces.ResetPasswordData) (reset bool, err error) {
+	tx, err := prtr.db.BeginTx(c, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	qtx := prtr.queries.WithTx(tx)
+
+	affected, err := qtx.MarkPasswordResetTokenUsed(c, database.MarkPasswordResetTokenUsedParams{
+		UsedAt: sql.NullTime{
+			Time:  data.UsedAt,
+			Valid: true,
+		},
+		ID: data.TokenID,
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	if affected == 0 {
+		return false, nil
+	}
+
+	err = qtx.UpdateUserPassword(c, database.UpdateUserPasswordParams{
+		Password:  data.Password,
+		UpdatedAt: data.UsedAt,
+		ID:        data.UserID,
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	err = qtx.DeleteSessionsByUserId(c, data.UserID)
+
+	if err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
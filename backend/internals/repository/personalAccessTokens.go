@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/google/uuid"
+)
+
+type PersonalAccessTokenRepository struct {
+	queries *database.Queries
+	db      *sql.DB
+}
+
+func NewPersonalAccessTokenRepository(queries *database.Queries, db *sql.DB) *PersonalAccessTokenRepository {
+	return &PersonalAccessTokenRepository{
+		queries: queries,
+		db:      db,
+	}
+}
+
+func (pr *PersonalAccessTokenRepository) CreatePersonalAccessToken(c context.Context, tokenData models.PersonalAccessToken) (models.PersonalAccessToken, error) {
+	token, err := pr.queries.CreatePersonalAccessToken(c, database.CreatePersonalAccessTokenParams{
+		ID:          tokenData.ID,
+		UserID:      tokenData.UserID,
+		Name:        tokenData.Name,
+		TokenHash:   tokenData.TokenHash,
+		TokenPrefix: tokenData.TokenPrefix,
+		Scopes:      tokenData.Scopes,
+		ExpiresAt:   tokenData.ExpiresAt,
+		CreatedAt:   tokenData.CreatedAt,
+	})
+
+	if err != nil {
+		return models.PersonalAccessToken{}, err
+	}
+
+	return models.DatabasePersonalAccessTokenToPersonalAccessToken(token), nil
+}
+
+func (pr *PersonalAccessTokenRepository) GetPersonalAccessTokenByHash(c context.Context, tokenHash string) (models.PersonalAccessTokenWithUser, error) {
+	token, err := pr.queries.GetPersonalAccessTokenByHash(c, tokenHash)
+
+	if err != nil {
+		return models.PersonalAccessTokenWithUser{}, err
+	}
+
+	return models.DatabasePersonalAccessTokenWithUserToPersonalAccessTokenWithUser(token), nil
+}
+
+func (pr *PersonalAccessTokenRepository) GetPersonalAccessTokensByUserId(c context.Context, userId uuid.UUID) ([]models.PersonalAccessToken, error) {
+	tokens, err := pr.queries.GetPersonalAccessTokensByUserId(c, userId)
+
+	if err != nil {
+		return []models.PersonalAccessToken{}, err
+	}
+
+	return models.DatabasePersonalAccessTokensToPersonalAccessTokens(tokens), nil
+}
+
+func (pr *PersonalAccessTokenRepository) UpdatePersonalAccessTokenLastUsed(c context.Context, data interfaces.UpdatePersonalAccessTokenLastUsedData) error {
+	return pr.queries.UpdatePersonalAccessTokenLastUsed(c, database.UpdatePersonalAccessTokenLastUsedParams{
+		LastUsedAt: sql.NullTime{
+			Time:  data.LastUsedAt,
+			Valid: true,
+		},
+		ID: data.ID,
+	})
+}
+
+// DeletePersonalAccessToken only deletes the token if it belongs to the user, deleted is false otherwise
+func (pr *PersonalAccessTokenRepository) DeletePersonalAccessToken(c context.Context, data interfaces.DeletePersonalAccessTokenData) (deleted bool, err error) {
+	affected, err := pr.queries.DeletePersonalAccessToken(c, database.DeletePersonalAccessTokenParams{
+		ID:     data.ID,
+		UserID: data.UserID,
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -25,8 +26,26 @@ func NewProjectRepository(queries *database.Queries, db *sql.DB) *ProjectReposit
 	}
 }
 
+// CreateProject creates the project with its manager as its owner
 func (pr *ProjectRepository) CreateProject(c context.Context, data database.CreateProjectParams) (models.Project, error) {
-	newProject, err := pr.queries.CreateProject(c, data)
+	var newProject database.Project
+
+	err := inTeamTx(c, pr.db, pr.queries, func(q *database.Queries, _ database.DBTX) (err error) {
+		newProject, err = q.CreateProject(c, data)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = q.AddProjectMember(c, database.AddProjectMemberParams{
+			ProjectID: newProject.ID,
+			UserID:    newProject.ManagerID,
+			Role:      database.ProjectmemberrolesOwner,
+			CreatedAt: newProject.CreatedAt,
+			UpdatedAt: newProject.UpdatedAt,
+		})
+		return err
+	})
 
 	if err != nil {
 		return models.Project{}, err
@@ -53,7 +72,7 @@ func (pr *ProjectRepository) GetProjects(c context.Context, filters interfaces.G
 			"COUNT(t.id) FILTER (WHERE t.status = 'Done') AS \"DoneTasks\"",
 		).From("projects p").LeftJoin("tasks t ON t.project_id = p.id").GroupBy("p.id", "p.created_at", "p.updated_at", "p.name", "p.team_id", "p.manager_id", "p.status")
 	} else {
-		sql = sq.StatementBuilder.PlaceholderFormat(sq.Dollar).Select("*").From("projects p")
+		sql = sq.StatementBuilder.PlaceholderFormat(sq.Dollar).Select("p.id", "p.created_at", "p.updated_at", "p.name", "p.team_id", "p.manager_id", "p.status").From("projects p")
 	}
 
 	if filters.TeamId != uuid.Nil {
@@ -64,6 +83,10 @@ func (pr *ProjectRepository) GetProjects(c context.Context, filters interfaces.G
 		sql = sql.Where(sq.Eq{"p.manager_id": filters.ManagerId})
 	}
 
+	if filters.MemberId != uuid.Nil {
+		sql = sql.Where(sq.Expr("p.id IN (SELECT project_id FROM project_members WHERE user_id = ?)", filters.MemberId))
+	}
+
 	orderAsc := true
 
 	// Handle cursor pagination
@@ -106,39 +129,45 @@ func (pr *ProjectRepository) GetProjects(c context.Context, filters interfaces.G
 		return []interfaces.GetProjectsResponse{}, err
 	}
 
-	rows, err := pr.db.Query(queryString, arg...)
+	var projects []interfaces.GetProjectsResponse
 
-	if err != nil {
-		return []interfaces.GetProjectsResponse{}, err
-	}
+	// Scoped to the team of the request, the database drops the rows of other teams even if the
+	// filters above miss them
+	err = inTeam(c, pr.db, pr.queries, func(_ *database.Queries, db database.DBTX) error {
+		rows, err := db.QueryContext(c, queryString, arg...)
 
-	defer rows.Close()
+		if err != nil {
+			return err
+		}
 
-	var projects []interfaces.GetProjectsResponse
+		defer rows.Close()
 
-	if filters.WithStats {
-		for rows.Next() {
-			var project interfaces.GetProjectsResponse
+		if filters.WithStats {
+			for rows.Next() {
+				var project interfaces.GetProjectsResponse
+
+				if err := rows.Scan(&project.ID, &project.CreatedAt, &project.UpdatedAt, &project.Name, &project.TeamID, &project.ManagerID, &project.Status, &project.ToDoTasks, &project.InProgressTasks, &project.DoneTasks); err != nil {
+					return err
+				}
 
-			if err := rows.Scan(&project.ID, &project.CreatedAt, &project.UpdatedAt, &project.Name, &project.TeamID, &project.ManagerID, &project.Status, &project.ToDoTasks, &project.InProgressTasks, &project.DoneTasks); err != nil {
-				return projects, err
+				projects = append(projects, project)
 			}
+		} else {
+			for rows.Next() {
+				var project interfaces.GetProjectsResponse
 
-			projects = append(projects, project)
-		}
-	} else {
-		for rows.Next() {
-			var project interfaces.GetProjectsResponse
+				if err := rows.Scan(&project.ID, &project.CreatedAt, &project.UpdatedAt, &project.Name, &project.TeamID, &project.ManagerID, &project.Status); err != nil {
+					return err
+				}
 
-			if err := rows.Scan(&project.ID, &project.CreatedAt, &project.UpdatedAt, &project.Name, &project.TeamID, &project.ManagerID, &project.Status); err != nil {
-				return projects, err
+				projects = append(projects, project)
 			}
-
-			projects = append(projects, project)
 		}
-	}
 
-	if err = rows.Err(); err != nil {
+		return rows.Err()
+	})
+
+	if err != nil {
 		return []interfaces.GetProjectsResponse{}, err
 	}
 
@@ -154,7 +183,12 @@ func (pr *ProjectRepository) GetProjects(c context.Context, filters interfaces.G
 
 func (pr *ProjectRepository) GetProjectById(c context.Context, id uuid.UUID) (models.Project, error) {
 
-	project, err := pr.queries.GetProjectById(c, id)
+	var project database.Project
+
+	err := inTeam(c, pr.db, pr.queries, func(q *database.Queries, _ database.DBTX) (err error) {
+		project, err = q.GetProjectById(c, id)
+		return err
+	})
 
 	if err != nil {
 		return models.Project{}, err
@@ -166,7 +200,12 @@ func (pr *ProjectRepository) GetProjectById(c context.Context, id uuid.UUID) (mo
 
 func (pr *ProjectRepository) GetProjectByManager(c context.Context, id uuid.UUID) (models.Project, error) {
 
-	project, err := pr.queries.GetProjectByManager(c, id)
+	var project database.Project
+
+	err := inTeam(c, pr.db, pr.queries, func(q *database.Queries, _ database.DBTX) (err error) {
+		project, err = q.GetProjectByManager(c, id)
+		return err
+	})
 
 	if err != nil {
 		return models.Project{}, err
@@ -177,11 +216,16 @@ func (pr *ProjectRepository) GetProjectByManager(c context.Context, id uuid.UUID
 }
 
 func (pr *ProjectRepository) UpdateProject(c context.Context, data interfaces.UpdateProjectData) (models.Project, error) {
-	project, err := pr.queries.UpdateProject(c, database.UpdateProjectParams{
-		Name:      data.Name,
-		Status:    database.Projectstatus(data.Status),
-		UpdatedAt: data.UpdatedAt,
-		ID:        data.ID,
+	var project database.Project
+
+	err := inTeam(c, pr.db, pr.queries, func(q *database.Queries, _ database.DBTX) (err error) {
+		project, err = q.UpdateProject(c, database.UpdateProjectParams{
+			Name:      data.Name,
+			Status:    database.Projectstatus(data.Status),
+			UpdatedAt: data.UpdatedAt,
+			ID:        data.ID,
+		})
+		return err
 	})
 
 	if err != nil {
@@ -192,7 +236,97 @@ func (pr *ProjectRepository) UpdateProject(c context.Context, data interfaces.Up
 }
 
 func (pr *ProjectRepository) DeleteProject(c context.Context, id uuid.UUID) error {
-	err := pr.queries.DeleteProject(c, id)
+	return inTeam(c, pr.db, pr.queries, func(q *database.Queries, _ database.DBTX) error {
+		return q.DeleteProject(c, id)
+	})
+}
+
+// GetProjectMembers lists the members of the project with their username and email
+func (pr *ProjectRepository) GetProjectMembers(c context.Context, projectId uuid.UUID) ([]models.ProjectMember, error) {
+	var members []database.GetProjectMembersRow
 
-	return err
+	err := inTeam(c, pr.db, pr.queries, func(q *database.Queries, _ database.DBTX) (err error) {
+		members, err = q.GetProjectMembers(c, projectId)
+		return err
+	})
+
+	if err != nil {
+		return []models.ProjectMember{}, err
+	}
+
+	return models.DatabaseProjectMembersToProjectMembers(members), nil
+}
+
+func (pr *ProjectRepository) GetProjectMember(c context.Context, projectId uuid.UUID, userId uuid.UUID) (exists bool, member models.ProjectMember, err error) {
+	var m database.ProjectMember
+
+	err = inTeam(c, pr.db, pr.queries, func(q *database.Queries, _ database.DBTX) (err error) {
+		m, err = q.GetProjectMember(c, database.GetProjectMemberParams{
+			ProjectID: projectId,
+			UserID:    userId,
+		})
+		return err
+	})
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, models.ProjectMember{}, nil
+	}
+
+	if err != nil {
+		return false, models.ProjectMember{}, err
+	}
+
+	return true, models.DatabaseProjectMemberToProjectMember(m), nil
+}
+
+func (pr *ProjectRepository) AddProjectMember(c context.Context, data models.ProjectMember) (models.ProjectMember, error) {
+	var member database.ProjectMember
+
+	err := inTeam(c, pr.db, pr.queries, func(q *database.Queries, _ database.DBTX) (err error) {
+		member, err = q.AddProjectMember(c, database.AddProjectMemberParams{
+			ProjectID: data.ProjectID,
+			UserID:    data.UserID,
+			Role:      database.Projectmemberroles(data.Role),
+			CreatedAt: data.CreatedAt,
+			UpdatedAt: data.UpdatedAt,
+		})
+		return err
+	})
+
+	if err != nil {
+		return models.ProjectMember{}, err
+	}
+
+	return models.DatabaseProjectMemberToProjectMember(member), nil
+}
+
+// RemoveProjectMember unassigns the tasks of the project from the user before removing them, when the
+// user was the manager of the project the owner that has been one the longest takes over
+func (pr *ProjectRepository) RemoveProjectMember(c context.Context, data interfaces.RemoveProjectMemberData) error {
+	return inTeamTx(c, pr.db, pr.queries, func(q *database.Queries, _ database.DBTX) error {
+		err := q.UnassignProjectMemberTasks(c, database.UnassignProjectMemberTasksParams{
+			ProjectID: data.ProjectID,
+			UserID:    data.UserID,
+			UpdatedAt: data.UpdatedAt,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteProjectMember(c, database.DeleteProjectMemberParams{
+			ProjectID: data.ProjectID,
+			UserID:    data.UserID,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		return q.ReassignProjectManager(c, database.ReassignProjectManagerParams{
+			ID:        data.ProjectID,
+			UpdatedAt: data.UpdatedAt,
+			ManagerID: data.UserID,
+		})
+	})
 }
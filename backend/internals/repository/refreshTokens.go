@@ -22,7 +22,15 @@ func NewRefreshTokenRepository(queries *database.Queries, db *sql.DB) *RefreshTo
 }
 
 func (rtr *RefreshTokenRepository) CreateRefreshToken(c context.Context, refreshTokenData models.RefreshToken) error {
-	err := rtr.queries.CreateRefreshToken(c, database.CreateRefreshTokenParams(refreshTokenData))
+	err := rtr.queries.CreateRefreshToken(c, database.CreateRefreshTokenParams{
+		ID:        refreshTokenData.ID,
+		Userid:    refreshTokenData.Userid,
+		Token:     refreshTokenData.Token,
+		ExpiresAt: refreshTokenData.ExpiresAt,
+		CreatedAt: refreshTokenData.CreatedAt,
+		Revoked:   refreshTokenData.Revoked,
+		FamilyID:  refreshTokenData.FamilyID,
+	})
 
 	if err != nil {
 		return err
@@ -55,3 +63,60 @@ func (rtr *RefreshTokenRepository) DeleteRefreshTokensByUserId(c context.Context
 
 	return nil
 }
+
+// rotated is false when the used token was already rotated or revoked by another request
+func (rtr *RefreshTokenRepository) RotateRefreshToken(c context.Context, usedTokenId uuid.UUID, newToken models.RefreshToken) (rotated bool, err error) {
+	tx, err := rtr.db.BeginTx(c, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	qtx := rtr.queries.WithTx(tx)
+
+	affected, err := qtx.MarkRefreshTokenUsed(c, database.MarkRefreshTokenUsedParams{
+		UsedAt: sql.NullTime{
+			Time:  newToken.CreatedAt,
+			Valid: true,
+		},
+		ID: usedTokenId,
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	if affected == 0 {
+		return false, nil
+	}
+
+	err = qtx.CreateRefreshToken(c, database.CreateRefreshTokenParams{
+		ID:        newToken.ID,
+		Userid:    newToken.Userid,
+		Token:     newToken.Token,
+		ExpiresAt: newToken.ExpiresAt,
+		CreatedAt: newToken.CreatedAt,
+		Revoked:   newToken.Revoked,
+		FamilyID:  newToken.FamilyID,
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (rtr *RefreshTokenRepository) RevokeRefreshTokenFamily(c context.Context, familyId uuid.UUID) error {
+	err := rtr.queries.RevokeRefreshTokenFamily(c, familyId)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/google/uuid"
+)
+
+type RevokedSessionRepository struct {
+	queries *database.Queries
+	db      *sql.DB
+}
+
+func NewRevokedSessionRepository(queries *database.Queries, db *sql.DB) *RevokedSessionRepository {
+	return &RevokedSessionRepository{
+		queries: queries,
+		db:      db,
+	}
+}
+
+// GetRevokedSessionIds returns the sessions revoked after since
+func (rr *RevokedSessionRepository) GetRevokedSessionIds(c context.Context, since time.Time) ([]uuid.UUID, error) {
+	ids, err := rr.queries.GetRevokedSessionIds(c, since)
+
+	if err != nil {
+		return []uuid.UUID{}, err
+	}
+
+	return ids, nil
+}
+
+func (rr *RevokedSessionRepository) DeleteRevokedSessionsBefore(c context.Context, before time.Time) error {
+	err := rr.queries.DeleteRevokedSessionsBefore(c, before)
+
+	return err
+}
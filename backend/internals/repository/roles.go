@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/google/uuid"
+)
+
+type RoleRepository struct {
+	queries *database.Queries
+	db      *sql.DB
+}
+
+func NewRoleRepository(queries *database.Queries, db *sql.DB) *RoleRepository {
+	return &RoleRepository{
+		queries: queries,
+		db:      db,
+	}
+}
+
+func (rr *RoleRepository) CreateRole(c context.Context, roleData models.Role) (models.Role, error) {
+	role, err := rr.queries.CreateRole(c, database.CreateRoleParams{
+		CreatedAt:   roleData.CreatedAt,
+		UpdatedAt:   roleData.UpdatedAt,
+		TeamID:      roleData.TeamID,
+		Name:        roleData.Name,
+		Permissions: roleData.Permissions,
+	})
+
+	if err != nil {
+		return models.Role{}, err
+	}
+
+	return models.DatabaseRoleToRole(role), nil
+}
+
+func (rr *RoleRepository) GetRoleById(c context.Context, id uuid.UUID) (models.Role, error) {
+	role, err := rr.queries.GetRoleById(c, id)
+
+	if err != nil {
+		return models.Role{}, err
+	}
+
+	return models.DatabaseRoleToRole(role), nil
+}
+
+func (rr *RoleRepository) GetRoleByName(c context.Context, data interfaces.GetRoleByNameData) (exists bool, role models.Role, err error) {
+	r, err := rr.queries.GetRoleByName(c, database.GetRoleByNameParams{
+		TeamID: uuid.NullUUID{UUID: data.TeamID, Valid: true},
+		Name:   data.Name,
+	})
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, models.Role{}, nil
+	}
+
+	if err != nil {
+		return false, models.Role{}, err
+	}
+
+	return true, models.DatabaseRoleToRole(r), nil
+}
+
+// GetCustomRoles lists the custom roles of every team
+func (rr *RoleRepository) GetCustomRoles(c context.Context) ([]models.Role, error) {
+	roles, err := rr.queries.GetCustomRoles(c)
+
+	if err != nil {
+		return []models.Role{}, err
+	}
+
+	return models.DatabaseRolesToRoles(roles), nil
+}
+
+// GetRolesByTeam lists the built-in roles first, then the custom roles of the team
+func (rr *RoleRepository) GetRolesByTeam(c context.Context, teamId uuid.UUID) ([]models.Role, error) {
+	roles, err := rr.queries.GetRolesByTeam(c, uuid.NullUUID{UUID: teamId, Valid: true})
+
+	if err != nil {
+		return []models.Role{}, err
+	}
+
+	return models.DatabaseRolesToRoles(roles), nil
+}
+
+// UpdateRole only changes custom roles, built-in ones are sql.ErrNoRows
+func (rr *RoleRepository) UpdateRole(c context.Context, data interfaces.UpdateRoleData) (models.Role, error) {
+	role, err := rr.queries.UpdateRole(c, database.UpdateRoleParams{
+		Name:        data.Name,
+		Permissions: data.Permissions,
+		UpdatedAt:   data.UpdatedAt,
+		ID:          data.ID,
+	})
+
+	if err != nil {
+		return models.Role{}, err
+	}
+
+	return models.DatabaseRoleToRole(role), nil
+}
+
+// DeleteRole removes a custom role, its users go back to the built-in role of their base role
+func (rr *RoleRepository) DeleteRole(c context.Context, id uuid.UUID) error {
+	return rr.queries.DeleteRole(c, id)
+}
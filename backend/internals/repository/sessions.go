@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/google/uuid"
+)
+
+type SessionRepository struct {
+	queries *database.Queries
+	db      *sql.DB
+}
+
+func NewSessionRepository(queries *database.Queries, db *sql.DB) *SessionRepository {
+	return &SessionRepository{
+		queries: queries,
+		db:      db,
+	}
+}
+
+func (sr *SessionRepository) CreateSession(c context.Context, sessionData models.Session) (models.Session, error) {
+	newSession, err := sr.queries.CreateSession(c, database.CreateSessionParams{
+		ID:         sessionData.ID,
+		UserID:     sessionData.UserID,
+		Label:      sessionData.Label,
+		UserAgent:  sessionData.UserAgent,
+		IpAddress:  sessionData.IpAddress,
+		CreatedAt:  sessionData.CreatedAt,
+		LastUsedAt: sessionData.LastUsedAt,
+		TeamID: uuid.NullUUID{
+			UUID:  sessionData.TeamID,
+			Valid: sessionData.TeamID != uuid.Nil,
+		},
+	})
+
+	if err != nil {
+		return models.Session{}, err
+	}
+
+	return models.DatabaseSessionToSession(newSession), nil
+}
+
+func (sr *SessionRepository) GetSessionById(c context.Context, id uuid.UUID) (models.Session, error) {
+	session, err := sr.queries.GetSessionById(c, id)
+
+	if err != nil {
+		return models.Session{}, err
+	}
+
+	return models.DatabaseSessionToSession(session), nil
+}
+
+func (sr *SessionRepository) GetSessionsByUserId(c context.Context, userId uuid.UUID) ([]models.Session, error) {
+	sessions, err := sr.queries.GetSessionsByUserId(c, userId)
+
+	if err != nil {
+		return []models.Session{}, err
+	}
+
+	return models.DatabaseSessionsToSessions(sessions), nil
+}
+
+func (sr *SessionRepository) UpdateSessionLastUsed(c context.Context, data interfaces.UpdateSessionLastUsedData) error {
+	err := sr.queries.UpdateSessionLastUsed(c, database.UpdateSessionLastUsedParams{
+		LastUsedAt: data.LastUsedAt,
+		IpAddress:  data.IpAddress,
+		ID:         data.ID,
+	})
+
+	return err
+}
+
+func (sr *SessionRepository) UpdateSessionTeam(c context.Context, data interfaces.UpdateSessionTeamData) error {
+	err := sr.queries.UpdateSessionTeam(c, database.UpdateSessionTeamParams{
+		TeamID: uuid.NullUUID{
+			UUID:  data.TeamID,
+			Valid: data.TeamID != uuid.Nil,
+		},
+		ID: data.ID,
+	})
+
+	return err
+}
+
+func (sr *SessionRepository) DeleteSession(c context.Context, id uuid.UUID) error {
+	err := sr.queries.DeleteSession(c, id)
+
+	return err
+}
+
+func (sr *SessionRepository) DeleteSessionsByUserId(c context.Context, userId uuid.UUID) error {
+	err := sr.queries.DeleteSessionsByUserId(c, userId)
+
+	return err
+}
@@ -26,7 +26,12 @@ func NewTaskRepository(queries *database.Queries, db *stdsql.DB) *TaskRepository
 }
 
 func (tsr *TaskRepository) CreateTask(c context.Context, data database.CreateTasksParams) (models.Task, error) {
-	newTask, err := tsr.queries.CreateTasks(c, data)
+	var newTask database.Task
+
+	err := inTeam(c, tsr.db, tsr.queries, func(q *database.Queries, _ database.DBTX) (err error) {
+		newTask, err = q.CreateTasks(c, data)
+		return err
+	})
 
 	if err != nil {
 		return models.Task{}, err
@@ -47,6 +52,10 @@ func (tsr *TaskRepository) GetTasks(c context.Context, filters interfaces.GetTas
 		sql = sql.Where(sq.Eq{"t.user_id": filters.UserId})
 	}
 
+	if filters.MemberId != uuid.Nil {
+		sql = sql.Where(sq.Expr("t.project_id IN (SELECT project_id FROM project_members WHERE user_id = ?)", filters.MemberId))
+	}
+
 	orderAsc := true
 
 	// Handle cursor pagination
@@ -89,45 +98,51 @@ func (tsr *TaskRepository) GetTasks(c context.Context, filters interfaces.GetTas
 		return []interfaces.GetTasksResponse{}, err
 	}
 
-	rows, err := tsr.db.Query(queryString, arg...)
-
-	if err != nil {
-		return []interfaces.GetTasksResponse{}, err
-	}
-
-	defer rows.Close()
-
 	var tasks []interfaces.GetTasksResponse
 
-	for rows.Next() {
-		var task interfaces.GetTasksResponse
-		var description, projectName, userName stdsql.NullString
-		if err := rows.Scan(&task.ID, &task.CreatedAt, &task.UpdatedAt, &task.ProjectID, &task.UserID, &task.Status, &task.Title, &description, &projectName, &userName); err != nil {
-			return tasks, err
-		}
-
-		if description.Valid {
-			task.Description = description.String
-		} else {
-			task.Description = ""
-		}
+	// Scoped to the team of the request, the database drops the rows of other teams even if the
+	// filters above miss them
+	err = inTeam(c, tsr.db, tsr.queries, func(_ *database.Queries, db database.DBTX) error {
+		rows, err := db.QueryContext(c, queryString, arg...)
 
-		if projectName.Valid {
-			task.ProjectName = projectName.String
-		} else {
-			task.ProjectName = ""
+		if err != nil {
+			return err
 		}
 
-		if userName.Valid {
-			task.UserName = userName.String
-		} else {
-			task.UserName = ""
+		defer rows.Close()
+
+		for rows.Next() {
+			var task interfaces.GetTasksResponse
+			var description, projectName, userName stdsql.NullString
+			if err := rows.Scan(&task.ID, &task.CreatedAt, &task.UpdatedAt, &task.ProjectID, &task.UserID, &task.Status, &task.Title, &description, &projectName, &userName); err != nil {
+				return err
+			}
+
+			if description.Valid {
+				task.Description = description.String
+			} else {
+				task.Description = ""
+			}
+
+			if projectName.Valid {
+				task.ProjectName = projectName.String
+			} else {
+				task.ProjectName = ""
+			}
+
+			if userName.Valid {
+				task.UserName = userName.String
+			} else {
+				task.UserName = ""
+			}
+
+			tasks = append(tasks, task)
 		}
 
-		tasks = append(tasks, task)
-	}
+		return rows.Err()
+	})
 
-	if err = rows.Err(); err != nil {
+	if err != nil {
 		return []interfaces.GetTasksResponse{}, err
 	}
 
@@ -143,7 +158,12 @@ func (tsr *TaskRepository) GetTasks(c context.Context, filters interfaces.GetTas
 
 func (tsr *TaskRepository) GetTaskById(c context.Context, id uuid.UUID) (models.Task, error) {
 
-	task, err := tsr.queries.GetTaskById(c, id)
+	var task database.Task
+
+	err := inTeam(c, tsr.db, tsr.queries, func(q *database.Queries, _ database.DBTX) (err error) {
+		task, err = q.GetTaskById(c, id)
+		return err
+	})
 
 	if err != nil {
 		return models.Task{}, err
@@ -154,24 +174,29 @@ func (tsr *TaskRepository) GetTaskById(c context.Context, id uuid.UUID) (models.
 }
 
 func (tsr *TaskRepository) UpdateTask(c context.Context, data interfaces.UpdateTaskData) (models.Task, error) {
-	user, err := tsr.queries.UpdateTask(c, database.UpdateTaskParams{
-		Title:       data.Title,
-		UserID:      data.UserId,
-		Status:      database.Taskstatus(data.Status),
-		Description: data.Description,
-		UpdatedAt:   data.UpdatedAt,
-		ID:          data.ID,
+	var task database.Task
+
+	err := inTeam(c, tsr.db, tsr.queries, func(q *database.Queries, _ database.DBTX) (err error) {
+		task, err = q.UpdateTask(c, database.UpdateTaskParams{
+			Title:       data.Title,
+			UserID:      data.UserId,
+			Status:      database.Taskstatus(data.Status),
+			Description: data.Description,
+			UpdatedAt:   data.UpdatedAt,
+			ID:          data.ID,
+		})
+		return err
 	})
 
 	if err != nil {
 		return models.Task{}, err
 	}
 
-	return models.DatabaseTaskToTask(user), nil
+	return models.DatabaseTaskToTask(task), nil
 }
 
 func (tsr *TaskRepository) DeleteTask(c context.Context, id uuid.UUID) error {
-	err := tsr.queries.DeleteTask(c, id)
-
-	return err
+	return inTeam(c, tsr.db, tsr.queries, func(q *database.Queries, _ database.DBTX) error {
+		return q.DeleteTask(c, id)
+	})
 }
@@ -4,7 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
 
+	sq "github.com/Masterminds/squirrel"
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
 	"github.com/TobiasRV/challenge-fs-senior/internals/models"
 	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
 	"github.com/google/uuid"
@@ -22,19 +26,48 @@ func NewTeamsRepository(queries *database.Queries, db *sql.DB) *TeamsRepository
 	}
 }
 
+// CreateTeam creates the team in its organization. Teams without one are wrapped in an organization of
+// their own, named after the team and administered by its owner
 func (tr *TeamsRepository) CreateTeam(c context.Context, teamData models.Team) (models.Team, error) {
+	tx, err := tr.db.BeginTx(c, nil)
+	if err != nil {
+		return models.Team{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := tr.queries.WithTx(tx)
+
+	if teamData.OrganizationID == uuid.Nil {
+		organization, err := createOrganization(c, qtx, interfaces.CreateOrganizationData{
+			Name:      teamData.Name,
+			AdminID:   teamData.OwnerID,
+			CreatedAt: teamData.CreatedAt,
+		})
+
+		if err != nil {
+			return models.Team{}, err
+		}
 
-	newTeam, err := tr.queries.CreateTeam(c, database.CreateTeamParams{
-		CreatedAt: teamData.CreatedAt,
-		UpdatedAt: teamData.UpdatedAt,
-		Name:      teamData.Name,
-		OwnerID:   teamData.OwnerID,
+		teamData.OrganizationID = organization.ID
+	}
+
+	newTeam, err := qtx.CreateTeam(c, database.CreateTeamParams{
+		CreatedAt:      teamData.CreatedAt,
+		UpdatedAt:      teamData.UpdatedAt,
+		Name:           teamData.Name,
+		OwnerID:        teamData.OwnerID,
+		OrganizationID: teamData.OrganizationID,
+		RequireMfa:     teamData.RequireMfa,
 	})
 
 	if err != nil {
 		return models.Team{}, err
 	}
 
+	if err := tx.Commit(); err != nil {
+		return models.Team{}, err
+	}
+
 	return models.DatabaseTeamToTeam(newTeam), nil
 }
 func (tr *TeamsRepository) GetTeamByOwner(c context.Context, ownerId uuid.UUID) (exists bool, team models.Team, err error) {
@@ -52,3 +85,393 @@ func (tr *TeamsRepository) GetTeamByOwner(c context.Context, ownerId uuid.UUID)
 	return true, models.DatabaseTeamToTeam(t), nil
 
 }
+
+func (tr *TeamsRepository) GetTeamById(c context.Context, id uuid.UUID) (models.Team, error) {
+	team, err := tr.queries.GetTeamById(c, id)
+
+	if err != nil {
+		return models.Team{}, err
+	}
+
+	return models.DatabaseTeamToTeam(team), nil
+}
+
+// SetTeamRequireMfa turns the two-factor authentication requirement of the team on or off. Turning it on
+// revokes the sessions of the members without it, so they have to log in again and enroll
+func (tr *TeamsRepository) SetTeamRequireMfa(c context.Context, data interfaces.SetTeamRequireMfaData) (models.Team, error) {
+	tx, err := tr.db.BeginTx(c, nil)
+	if err != nil {
+		return models.Team{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := tr.queries.WithTx(tx)
+
+	team, err := qtx.UpdateTeamRequireMfa(c, database.UpdateTeamRequireMfaParams{
+		RequireMfa: data.RequireMfa,
+		UpdatedAt:  data.UpdatedAt,
+		ID:         data.TeamID,
+	})
+
+	if err != nil {
+		return models.Team{}, err
+	}
+
+	if data.RequireMfa {
+		err = qtx.DeleteSessionsWithoutMfaByTeam(c, uuid.NullUUID{
+			UUID:  data.TeamID,
+			Valid: true,
+		})
+
+		if err != nil {
+			return models.Team{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Team{}, err
+	}
+
+	return models.DatabaseTeamToTeam(team), nil
+}
+
+// GetTeams lists the teams owned by the user of the filter, a page at a time
+func (tr *TeamsRepository) GetTeams(c context.Context, filters models.TeamFilter) ([]models.Team, error) {
+	sql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).Select("id", "created_at", "updated_at", "name", "owner_id", "require_mfa", "organization_id").From("teams").Where(sq.Eq{"owner_id": filters.UserId})
+
+	orderAsc := true
+
+	if !filters.IsFirstPage {
+		if filters.PointsNext {
+			sql = sql.Where(sq.Or{
+				sq.Gt{"created_at": filters.CursorCreatedAt},
+				sq.And{
+					sq.Eq{"created_at": filters.CursorCreatedAt},
+					sq.Gt{"id": filters.CursorId},
+				},
+			})
+		} else {
+			sql = sql.Where(sq.Or{
+				sq.Lt{"created_at": filters.CursorCreatedAt},
+				sq.And{
+					sq.Eq{"created_at": filters.CursorCreatedAt},
+					sq.Lt{"id": filters.CursorId},
+				},
+			})
+			orderAsc = false
+		}
+	}
+
+	if filters.Name != "" {
+		nameLower := strings.ToLower(filters.Name)
+		sql = sql.Where(sq.Like{"LOWER(name)": fmt.Sprintf("%%%v%%", nameLower)})
+	}
+
+	if orderAsc {
+		sql = sql.OrderBy("created_at ASC, id ASC").Limit(filters.Limit + 1)
+	} else {
+		sql = sql.OrderBy("created_at DESC, id DESC").Limit(filters.Limit + 1)
+	}
+
+	queryString, args, err := sql.ToSql()
+
+	if err != nil {
+		return []models.Team{}, err
+	}
+
+	rows, err := tr.db.QueryContext(c, queryString, args...)
+
+	if err != nil {
+		return []models.Team{}, err
+	}
+
+	defer rows.Close()
+
+	teams := []models.Team{}
+
+	for rows.Next() {
+		var team models.Team
+
+		if err := rows.Scan(&team.ID, &team.CreatedAt, &team.UpdatedAt, &team.Name, &team.OwnerID, &team.RequireMfa, &team.OrganizationID); err != nil {
+			return []models.Team{}, err
+		}
+
+		teams = append(teams, team)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []models.Team{}, err
+	}
+
+	if !orderAsc {
+		for i, j := 0, len(teams)-1; i < j; i, j = i+1, j-1 {
+			teams[i], teams[j] = teams[j], teams[i]
+		}
+	}
+
+	return teams, nil
+}
+
+func (tr *TeamsRepository) UpdateTeamName(c context.Context, data interfaces.UpdateTeamNameData) (models.Team, error) {
+	team, err := tr.queries.UpdateTeamName(c, database.UpdateTeamNameParams{
+		Name:      data.Name,
+		UpdatedAt: data.UpdatedAt,
+		ID:        data.TeamID,
+	})
+
+	if err != nil {
+		return models.Team{}, err
+	}
+
+	return models.DatabaseTeamToTeam(team), nil
+}
+
+// TransferTeamOwnership makes another admin of the team its owner. The previous owner keeps its
+// membership, so it goes on working in the team as one of its admins
+func (tr *TeamsRepository) TransferTeamOwnership(c context.Context, data interfaces.TransferTeamOwnershipData) (models.Team, error) {
+	team, err := tr.queries.UpdateTeamOwner(c, database.UpdateTeamOwnerParams{
+		OwnerID:   data.OwnerID,
+		UpdatedAt: data.UpdatedAt,
+		ID:        data.TeamID,
+	})
+
+	if err != nil {
+		return models.Team{}, err
+	}
+
+	return models.DatabaseTeamToTeam(team), nil
+}
+
+func (tr *TeamsRepository) GetTeamDeletionReport(c context.Context, teamId uuid.UUID) (interfaces.TeamDeletionReport, error) {
+	report, err := tr.queries.GetTeamDeletionReport(c, teamId)
+
+	if err != nil {
+		return interfaces.TeamDeletionReport{}, err
+	}
+
+	return interfaces.TeamDeletionReport{
+		Projects:    report.Projects,
+		Tasks:       report.Tasks,
+		Users:       report.Users,
+		Invitations: report.Invitations,
+		Roles:       report.Roles,
+	}, nil
+}
+
+// DeleteTeam deletes the team, the database cascades it to its projects, tasks, invitations, roles and
+// memberships. The sessions working in it are logged out and the users it was the default team of move
+// to their next team first, users don't go away with their team
+func (tr *TeamsRepository) DeleteTeam(c context.Context, data interfaces.DeleteTeamData) error {
+	tx, err := tr.db.BeginTx(c, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qtx := tr.queries.WithTx(tx)
+
+	teamId := uuid.NullUUID{
+		UUID:  data.TeamID,
+		Valid: true,
+	}
+
+	if err := qtx.DeleteTeamSessions(c, teamId); err != nil {
+		return err
+	}
+
+	err = qtx.MoveUserDefaultTeam(c, database.MoveUserDefaultTeamParams{
+		UpdatedAt: data.UpdatedAt,
+		TeamID:    data.TeamID,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if err := qtx.DeleteTeam(c, data.TeamID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (tr *TeamsRepository) GetTeamMember(c context.Context, teamId uuid.UUID, userId uuid.UUID) (exists bool, member models.TeamMember, err error) {
+	m, err := tr.queries.GetTeamMembership(c, database.GetTeamMembershipParams{
+		TeamID: teamId,
+		UserID: userId,
+	})
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, models.TeamMember{}, nil
+	}
+
+	if err != nil {
+		return false, models.TeamMember{}, err
+	}
+
+	return true, models.DatabaseTeamMembershipToTeamMember(m), nil
+}
+
+// GetTeamMembers lists the members of the team with their username and email
+func (tr *TeamsRepository) GetTeamMembers(c context.Context, teamId uuid.UUID) ([]models.TeamMember, error) {
+	members, err := tr.queries.GetTeamMemberships(c, teamId)
+
+	if err != nil {
+		return []models.TeamMember{}, err
+	}
+
+	return models.DatabaseTeamMembershipsToTeamMembers(members), nil
+}
+
+// GetUserTeams lists the teams the user is a member of with their name
+func (tr *TeamsRepository) GetUserTeams(c context.Context, userId uuid.UUID) ([]models.TeamMember, error) {
+	teams, err := tr.queries.GetUserTeamMemberships(c, userId)
+
+	if err != nil {
+		return []models.TeamMember{}, err
+	}
+
+	return models.DatabaseUserTeamMembershipsToTeamMembers(teams), nil
+}
+
+func (tr *TeamsRepository) AddTeamMember(c context.Context, data models.TeamMember) (models.TeamMember, error) {
+	member, err := tr.queries.AddTeamMembership(c, database.AddTeamMembershipParams{
+		TeamID: data.TeamID,
+		UserID: data.UserID,
+		Role:   database.Userroles(data.Role),
+		RoleID: uuid.NullUUID{
+			UUID:  data.RoleId,
+			Valid: data.RoleId != uuid.Nil,
+		},
+		CreatedAt: data.CreatedAt,
+		UpdatedAt: data.UpdatedAt,
+	})
+
+	if err != nil {
+		return models.TeamMember{}, err
+	}
+
+	return models.DatabaseTeamMembershipToTeamMember(member), nil
+}
+
+// UpdateTeamMemberRole changes the role of the user in the team and logs them out, their tokens carry
+// the old one
+func (tr *TeamsRepository) UpdateTeamMemberRole(c context.Context, data interfaces.UpdateTeamMemberRoleData) (models.TeamMember, error) {
+	tx, err := tr.db.BeginTx(c, nil)
+	if err != nil {
+		return models.TeamMember{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := tr.queries.WithTx(tx)
+
+	member, err := qtx.UpdateTeamMembershipRole(c, database.UpdateTeamMembershipRoleParams{
+		Role: database.Userroles(data.Role),
+		RoleID: uuid.NullUUID{
+			UUID:  data.RoleId,
+			Valid: data.RoleId != uuid.Nil,
+		},
+		UpdatedAt: data.UpdatedAt,
+		TeamID:    data.TeamID,
+		UserID:    data.UserID,
+	})
+
+	if err != nil {
+		return models.TeamMember{}, err
+	}
+
+	if err := qtx.DeleteSessionsByUserId(c, data.UserID); err != nil {
+		return models.TeamMember{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.TeamMember{}, err
+	}
+
+	return models.DatabaseTeamMembershipToTeamMember(member), nil
+}
+
+// CountProjectsOnlyOwnedBy counts the projects of the team the user manages or owns that have no other owner
+func (tr *TeamsRepository) CountProjectsOnlyOwnedBy(c context.Context, teamId uuid.UUID, userId uuid.UUID) (int64, error) {
+	return tr.queries.CountProjectsOnlyOwnedBy(c, database.CountProjectsOnlyOwnedByParams{
+		TeamID: teamId,
+		UserID: userId,
+	})
+}
+
+// RemoveTeamMember takes the user out of the projects of the team, the projects they managed go to the
+// owner that has been one the longest. When it was their default team they move to their next team
+func (tr *TeamsRepository) RemoveTeamMember(c context.Context, data interfaces.RemoveTeamMemberData) error {
+	tx, err := tr.db.BeginTx(c, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qtx := tr.queries.WithTx(tx)
+
+	err = qtx.UnassignTeamMemberTasks(c, database.UnassignTeamMemberTasksParams{
+		TeamID:    data.TeamID,
+		UserID:    data.UserID,
+		UpdatedAt: data.UpdatedAt,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	err = qtx.DeleteTeamMemberProjectMemberships(c, database.DeleteTeamMemberProjectMembershipsParams{
+		TeamID: data.TeamID,
+		UserID: data.UserID,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	err = qtx.ReassignTeamMemberProjects(c, database.ReassignTeamMemberProjectsParams{
+		UpdatedAt: data.UpdatedAt,
+		TeamID:    data.TeamID,
+		ManagerID: data.UserID,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	err = qtx.DeleteTeamMemberSessions(c, database.DeleteTeamMemberSessionsParams{
+		UserID: data.UserID,
+		TeamID: uuid.NullUUID{
+			UUID:  data.TeamID,
+			Valid: true,
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	err = qtx.MoveUserDefaultTeam(c, database.MoveUserDefaultTeamParams{
+		UpdatedAt: data.UpdatedAt,
+		TeamID:    data.TeamID,
+		UserID: uuid.NullUUID{
+			UUID:  data.UserID,
+			Valid: true,
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	err = qtx.DeleteTeamMembership(c, database.DeleteTeamMembershipParams{
+		TeamID: data.TeamID,
+		UserID: data.UserID,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
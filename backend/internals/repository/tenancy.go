@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/TobiasRV/challenge-fs-senior/internals/tenant"
+)
+
+// inTeam runs fn scoped to the team of the context: in a transaction as the app_tenant role with
+// app.current_team_id set, so the row level security policies only let the rows of the team through
+// whatever the queries of fn look like, and with app.acting_admin_id set when an admin makes the
+// request. Requests that aren't scoped to a team fail with tenant.ErrUnscoped instead of reaching every
+// team, other contexts that aren't scoped (background jobs, migrations) run fn on the connection pool
+func inTeam(c context.Context, db *sql.DB, queries *database.Queries, fn func(*database.Queries, database.DBTX) error) error {
+	if tenant.UnscopedRequest(c) {
+		return tenant.ErrUnscoped
+	}
+
+	teamId, scoped := tenant.TeamID(c)
+
+	if !scoped {
+		return fn(queries, db)
+	}
+
+	tx, err := db.BeginTx(c, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qtx := queries.WithTx(tx)
+
+	if err := qtx.SetTenantRole(c); err != nil {
+		return err
+	}
+
+	if err := qtx.SetCurrentTeam(c, teamId.String()); err != nil {
+		return err
+	}
+
+	// The triggers on projects and tasks write the admin audit log when it's set
+	if adminId, acting := tenant.ActingAdminID(c); acting {
+		if err := qtx.SetActingAdmin(c, adminId.String()); err != nil {
+			return err
+		}
+	}
+
+	if err := fn(qtx, tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// inTeamTx is inTeam for fn that write more than one row, contexts that aren't scoped to a team run fn
+// in a transaction too
+func inTeamTx(c context.Context, db *sql.DB, queries *database.Queries, fn func(*database.Queries, database.DBTX) error) error {
+	if _, scoped := tenant.TeamID(c); scoped || tenant.UnscopedRequest(c) {
+		return inTeam(c, db, queries, fn)
+	}
+
+	tx, err := db.BeginTx(c, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(queries.WithTx(tx), tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
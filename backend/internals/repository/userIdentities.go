@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/google/uuid"
+)
+
+type UserIdentityRepository struct {
+	queries *database.Queries
+	db      *sql.DB
+}
+
+func NewUserIdentityRepository(queries *database.Queries, db *sql.DB) *UserIdentityRepository {
+	return &UserIdentityRepository{
+		queries: queries,
+		db:      db,
+	}
+}
+
+func (uir *UserIdentityRepository) GetUserIdentity(c context.Context, data interfaces.GetUserIdentityData) (models.UserIdentity, error) {
+	identity, err := uir.queries.GetUserIdentity(c, database.GetUserIdentityParams{
+		Issuer:  data.Issuer,
+		Subject: data.Subject,
+	})
+
+	if err != nil {
+		return models.UserIdentity{}, err
+	}
+
+	return models.DatabaseUserIdentityToUserIdentity(identity), nil
+}
+
+func (uir *UserIdentityRepository) CreateUserIdentity(c context.Context, identity models.UserIdentity) error {
+	return uir.queries.CreateUserIdentity(c, database.CreateUserIdentityParams{
+		ID:        identity.ID,
+		UserID:    identity.UserID,
+		Issuer:    identity.Issuer,
+		Subject:   identity.Subject,
+		Email:     identity.Email,
+		CreatedAt: identity.CreatedAt,
+	})
+}
+
+// ProvisionUser creates the user and links the identity in the same transaction, so a failed
+// link doesn't leave an account nobody can sign in to
+func (uir *UserIdentityRepository) ProvisionUser(c context.Context, data interfaces.ProvisionUserData) (models.User, error) {
+	tx, err := uir.db.BeginTx(c, nil)
+	if err != nil {
+		return models.User{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := uir.queries.WithTx(tx)
+
+	newUser, err := qtx.CreateUser(c, database.CreateUserParams{
+		Username:  data.User.Username,
+		Password:  data.User.Password,
+		Email:     data.User.Email,
+		Role:      database.Userroles(data.User.Role),
+		CreatedAt: data.User.CreatedAt,
+		UpdatedAt: data.User.UpdatedAt,
+		TeamID: uuid.NullUUID{
+			UUID:  data.User.TeamId,
+			Valid: data.User.TeamId != uuid.Nil,
+		},
+	})
+
+	if err != nil {
+		return models.User{}, err
+	}
+
+	err = qtx.CreateUserIdentity(c, database.CreateUserIdentityParams{
+		ID:        data.Identity.ID,
+		UserID:    newUser.ID,
+		Issuer:    data.Identity.Issuer,
+		Subject:   data.Identity.Subject,
+		Email:     data.Identity.Email,
+		CreatedAt: data.Identity.CreatedAt,
+	})
+
+	if err != nil {
+		return models.User{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.User{}, err
+	}
+
+	return models.DatabaseUserToUser(newUser), nil
+}
@@ -47,6 +47,57 @@ func (ur *UserRepository) CreateUser(c context.Context, userData models.User) (m
 	return models.DatabaseUserToUser(newUser), nil
 }
 
+func (ur *UserRepository) AdminExists(c context.Context) (bool, error) {
+	return ur.queries.AdminExists(c)
+}
+
+// CreateFirstAdmin creates the admin only when there is none yet. The check and the insert hold a lock
+// so two setup requests can't both create one
+func (ur *UserRepository) CreateFirstAdmin(c context.Context, userData models.User) (created bool, user models.User, err error) {
+	tx, err := ur.db.BeginTx(c, nil)
+	if err != nil {
+		return false, models.User{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := ur.queries.WithTx(tx)
+
+	err = qtx.LockAdminBootstrap(c)
+
+	if err != nil {
+		return false, models.User{}, err
+	}
+
+	exists, err := qtx.AdminExists(c)
+
+	if err != nil {
+		return false, models.User{}, err
+	}
+
+	if exists {
+		return false, models.User{}, nil
+	}
+
+	newUser, err := qtx.CreateUser(c, database.CreateUserParams{
+		Username:  userData.Username,
+		Password:  userData.Password,
+		Email:     userData.Email,
+		Role:      database.UserrolesAdmin,
+		CreatedAt: userData.CreatedAt,
+		UpdatedAt: userData.UpdatedAt,
+	})
+
+	if err != nil {
+		return false, models.User{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, models.User{}, err
+	}
+
+	return true, models.DatabaseUserToUser(newUser), nil
+}
+
 func (ur *UserRepository) GetUserByEmail(c context.Context, email string) (models.User, error) {
 
 	user, err := ur.queries.GetUserByEmail(c, email)
@@ -71,10 +122,17 @@ func (ur *UserRepository) GetUserById(c context.Context, id uuid.UUID) (models.U
 }
 
 func (ur *UserRepository) GetUsers(c context.Context, filters interfaces.GetUserFilters) ([]models.User, error) {
-	sql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).Select("*").From("users")
+	role, roleId := "u.role", "u.role_id"
+
+	// The users of a team are its members, with the role they have in it
+	if filters.TeamId != uuid.Nil {
+		role, roleId = "m.role", "COALESCE(m.role_id, (SELECT id FROM roles WHERE roles.base_role = m.role))"
+	}
+
+	sql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).Select("u.id", "u.created_at", "u.updated_at", "u.username", "u.password", "u.email", role, "u.team_id", roleId, "u.deactivated_at").From("users u")
 
 	if filters.TeamId != uuid.Nil {
-		sql = sql.Where(sq.Eq{"team_id": filters.TeamId})
+		sql = sql.Join("team_memberships m ON m.user_id = u.id").Where(sq.Eq{"m.team_id": filters.TeamId})
 	}
 
 	orderAsc := true
@@ -83,19 +141,19 @@ func (ur *UserRepository) GetUsers(c context.Context, filters interfaces.GetUser
 	if !filters.IsFirstPage {
 		if filters.PointsNext {
 			sql = sql.Where(sq.Or{
-				sq.Gt{"created_at": filters.CursorCreatedAt},
+				sq.Gt{"u.created_at": filters.CursorCreatedAt},
 				sq.And{
-					sq.Eq{"created_at": filters.CursorCreatedAt},
-					sq.Gt{"id": filters.CursorId},
+					sq.Eq{"u.created_at": filters.CursorCreatedAt},
+					sq.Gt{"u.id": filters.CursorId},
 				},
 			})
 			orderAsc = true
 		} else {
 			sql = sql.Where(sq.Or{
-				sq.Lt{"created_at": filters.CursorCreatedAt},
+				sq.Lt{"u.created_at": filters.CursorCreatedAt},
 				sq.And{
-					sq.Eq{"created_at": filters.CursorCreatedAt},
-					sq.Lt{"id": filters.CursorId},
+					sq.Eq{"u.created_at": filters.CursorCreatedAt},
+					sq.Lt{"u.id": filters.CursorId},
 				},
 			})
 			orderAsc = false
@@ -104,19 +162,19 @@ func (ur *UserRepository) GetUsers(c context.Context, filters interfaces.GetUser
 
 	if filters.Email != "" {
 		emailLower := strings.ToLower(filters.Email)
-		sql = sql.Where(sq.Like{"LOWER(email)": fmt.Sprintf("%%%v%%", emailLower)})
+		sql = sql.Where(sq.Like{"LOWER(u.email)": fmt.Sprintf("%%%v%%", emailLower)})
 	}
 
 	if filters.Role != "" {
 		sql = sql.Where(sq.Eq{
-			"role": filters.Role,
+			role: filters.Role,
 		})
 	}
 
 	if orderAsc {
-		sql = sql.OrderBy("created_at ASC, id ASC").Limit(filters.Limit + 1)
+		sql = sql.OrderBy("u.created_at ASC, u.id ASC").Limit(filters.Limit + 1)
 	} else {
-		sql = sql.OrderBy("created_at DESC, id DESC").Limit(filters.Limit + 1)
+		sql = sql.OrderBy("u.created_at DESC, u.id DESC").Limit(filters.Limit + 1)
 	}
 
 	queryString, arg, err := sql.ToSql()
@@ -125,27 +183,33 @@ func (ur *UserRepository) GetUsers(c context.Context, filters interfaces.GetUser
 		return []models.User{}, err
 	}
 
-	rows, err := ur.db.Query(queryString, arg...)
+	var users []models.User
 
-	if err != nil {
-		return []models.User{}, err
-	}
+	// Scoped to the team of the request, the database drops the rows of other teams even if the
+	// filters above miss them
+	err = inTeam(c, ur.db, ur.queries, func(_ *database.Queries, db database.DBTX) error {
+		rows, err := db.QueryContext(c, queryString, arg...)
 
-	defer rows.Close()
+		if err != nil {
+			return err
+		}
 
-	var users []models.User
+		defer rows.Close()
+
+		for rows.Next() {
+			var user models.User
 
-	for rows.Next() {
-		var user models.User
+			if err := rows.Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt, &user.Username, &user.Password, &user.Email, &user.Role, &user.TeamId, &user.RoleId, &user.DeactivatedAt); err != nil {
+				return err
+			}
 
-		if err := rows.Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt, &user.Username, &user.Password, &user.Email, &user.Role, &user.TeamId); err != nil {
-			return users, err
+			users = append(users, user)
 		}
 
-		users = append(users, user)
-	}
+		return rows.Err()
+	})
 
-	if err = rows.Err(); err != nil {
+	if err != nil {
 		return []models.User{}, err
 	}
 
@@ -174,6 +238,270 @@ func (ur *UserRepository) UpdateUser(c context.Context, data interfaces.UpdateUs
 	return models.DatabaseUserToUser(user), nil
 }
 
+// UpdateUserRole changes the role of the user and revokes their sessions, so tokens carrying the
+// old role stop working right away
+func (ur *UserRepository) UpdateUserRole(c context.Context, data interfaces.UpdateUserRoleData) (models.User, error) {
+	tx, err := ur.db.BeginTx(c, nil)
+	if err != nil {
+		return models.User{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := ur.queries.WithTx(tx)
+
+	user, err := qtx.UpdateUserRole(c, database.UpdateUserRoleParams{
+		Role:      database.Userroles(data.Role),
+		UpdatedAt: data.UpdatedAt,
+		ID:        data.ID,
+	})
+
+	if err != nil {
+		return models.User{}, err
+	}
+
+	err = qtx.DeleteSessionsByUserId(c, data.ID)
+
+	if err != nil {
+		return models.User{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.User{}, err
+	}
+
+	return models.DatabaseUserToUser(user), nil
+}
+
+// AssignUserRole gives the user a role and revokes their sessions, tokens carry the role they were
+// issued with
+func (ur *UserRepository) AssignUserRole(c context.Context, data interfaces.AssignUserRoleData) (models.User, error) {
+	tx, err := ur.db.BeginTx(c, nil)
+	if err != nil {
+		return models.User{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := ur.queries.WithTx(tx)
+
+	user, err := qtx.AssignUserRole(c, database.AssignUserRoleParams{
+		RoleID:    data.RoleId,
+		UpdatedAt: data.UpdatedAt,
+		ID:        data.ID,
+	})
+
+	if err != nil {
+		return models.User{}, err
+	}
+
+	err = qtx.DeleteSessionsByUserId(c, data.ID)
+
+	if err != nil {
+		return models.User{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.User{}, err
+	}
+
+	return models.DatabaseUserToUser(user), nil
+}
+
+// ChangeUserPassword sets a new password and revokes every session of the user except KeepSessionID,
+// the one the password was changed from
+func (ur *UserRepository) ChangeUserPassword(c context.Context, data interfaces.ChangeUserPasswordData) error {
+	tx, err := ur.db.BeginTx(c, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qtx := ur.queries.WithTx(tx)
+
+	err = qtx.UpdateUserPassword(c, database.UpdateUserPasswordParams{
+		Password:  data.Password,
+		UpdatedAt: data.UpdatedAt,
+		ID:        data.ID,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	err = qtx.DeleteOtherSessionsByUserId(c, database.DeleteOtherSessionsByUserIdParams{
+		UserID: data.ID,
+		ID:     data.KeepSessionID,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetUserWorkTeamIds returns every team the user owns, manages projects of or has open tasks in
+func (ur *UserRepository) GetUserWorkTeamIds(c context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	return ur.queries.GetUserWorkTeamIds(c, id)
+}
+
+// GetUserOffboarding lists what the user leaves behind in teamIds: the teams they own, the projects they
+// manage and the tasks assigned to them that aren't done
+func (ur *UserRepository) GetUserOffboarding(c context.Context, id uuid.UUID, teamIds []uuid.UUID) (interfaces.UserOffboardingReport, error) {
+	teams, err := ur.queries.GetUserOwnedTeams(c, database.GetUserOwnedTeamsParams{
+		OwnerID: id,
+		TeamIds: teamIds,
+	})
+
+	if err != nil {
+		return interfaces.UserOffboardingReport{}, err
+	}
+
+	projects, err := ur.queries.GetUserManagedProjects(c, database.GetUserManagedProjectsParams{
+		ManagerID: id,
+		TeamIds:   teamIds,
+	})
+
+	if err != nil {
+		return interfaces.UserOffboardingReport{}, err
+	}
+
+	tasks, err := ur.queries.GetUserOpenTasks(c, database.GetUserOpenTasksParams{
+		UserID:  uuid.NullUUID{UUID: id, Valid: true},
+		TeamIds: teamIds,
+	})
+
+	if err != nil {
+		return interfaces.UserOffboardingReport{}, err
+	}
+
+	report := interfaces.UserOffboardingReport{
+		Teams:    models.DatabaseTeamsToTeams(teams),
+		Projects: models.DatabaseProjectsToProjects(projects),
+		Tasks:    []interfaces.OffboardingTask{},
+	}
+
+	for _, t := range tasks {
+		report.Tasks = append(report.Tasks, interfaces.OffboardingTask{
+			Task: models.DatabaseTaskToTask(database.Task{
+				ID:          t.ID,
+				CreatedAt:   t.CreatedAt,
+				UpdatedAt:   t.UpdatedAt,
+				ProjectID:   t.ProjectID,
+				UserID:      t.UserID,
+				Status:      t.Status,
+				Title:       t.Title,
+				Description: t.Description,
+			}),
+			TeamID: t.TeamID,
+		})
+	}
+
+	return report, nil
+}
+
+// DeactivateUser hands the teams, projects and open tasks of the user in data.TeamIDs over to the users in
+// data, which become owners of the projects and contributors of the projects of the tasks, then deactivates
+// the user and revokes their sessions. Nothing is handed over for the ids left as uuid.Nil
+func (ur *UserRepository) DeactivateUser(c context.Context, data interfaces.DeactivateUserData) error {
+	tx, err := ur.db.BeginTx(c, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qtx := ur.queries.WithTx(tx)
+
+	if data.TeamOwnerID != uuid.Nil {
+		err = qtx.TransferUserTeams(c, database.TransferUserTeamsParams{
+			NewOwnerID: data.TeamOwnerID,
+			UpdatedAt:  data.DeactivatedAt,
+			OwnerID:    data.ID,
+			TeamIds:    data.TeamIDs,
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if data.ProjectManagerID != uuid.Nil {
+		err = qtx.AddUserProjectsOwner(c, database.AddUserProjectsOwnerParams{
+			NewManagerID: data.ProjectManagerID,
+			UpdatedAt:    data.DeactivatedAt,
+			ManagerID:    data.ID,
+			TeamIds:      data.TeamIDs,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		err = qtx.TransferUserProjects(c, database.TransferUserProjectsParams{
+			NewManagerID: data.ProjectManagerID,
+			UpdatedAt:    data.DeactivatedAt,
+			ManagerID:    data.ID,
+			TeamIds:      data.TeamIDs,
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if data.TaskAssigneeID != uuid.Nil {
+		err = qtx.AddUserTasksContributor(c, database.AddUserTasksContributorParams{
+			NewUserID: data.TaskAssigneeID,
+			UpdatedAt: data.DeactivatedAt,
+			UserID:    uuid.NullUUID{UUID: data.ID, Valid: true},
+			TeamIds:   data.TeamIDs,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		err = qtx.ReassignUserTasks(c, database.ReassignUserTasksParams{
+			NewUserID: uuid.NullUUID{UUID: data.TaskAssigneeID, Valid: true},
+			UpdatedAt: data.DeactivatedAt,
+			UserID:    uuid.NullUUID{UUID: data.ID, Valid: true},
+			TeamIds:   data.TeamIDs,
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	err = qtx.DeactivateUser(c, database.DeactivateUserParams{
+		DeactivatedAt: sql.NullTime{Time: data.DeactivatedAt, Valid: true},
+		UpdatedAt:     data.DeactivatedAt,
+		ID:            data.ID,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if err := qtx.DeleteSessionsByUserId(c, data.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (ur *UserRepository) ReactivateUser(c context.Context, data interfaces.ReactivateUserData) (models.User, error) {
+	user, err := ur.queries.ReactivateUser(c, database.ReactivateUserParams{
+		UpdatedAt: data.UpdatedAt,
+		ID:        data.ID,
+	})
+
+	if err != nil {
+		return models.User{}, err
+	}
+
+	return models.DatabaseUserToUser(user), nil
+}
+
 func (ur *UserRepository) DeleteUser(c context.Context, id uuid.UUID) error {
 	err := ur.queries.DeleteUser(c, id)
 
@@ -1,6 +1,8 @@
 package router
 
 import (
+	"os"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
@@ -9,10 +11,17 @@ import (
 func New() *fiber.App {
 	f := fiber.New()
 	f.Use(logger.New())
+	// Browsers only send the auth cookie to other origins when they are listed in CORS_ALLOW_ORIGINS
+	allowOrigins := os.Getenv("CORS_ALLOW_ORIGINS")
+	if allowOrigins == "" {
+		allowOrigins = "*"
+	}
+
 	f.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowHeaders: "Origin, Content-Type, Accept, Authorization",
-		AllowMethods: "GET, HEAD, PUT, PATCH, POST, DELETE",
+		AllowOrigins:     allowOrigins,
+		AllowHeaders:     "Origin, Content-Type, Accept, Authorization, X-CSRF-Token",
+		AllowMethods:     "GET, HEAD, PUT, PATCH, POST, DELETE",
+		AllowCredentials: allowOrigins != "*",
 	}))
 
 	return f
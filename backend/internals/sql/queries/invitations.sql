@@ -0,0 +1,33 @@
+-- name: CreateInvitation :one
+INSERT INTO invitations (id, team_id, email, role, token_id, invited_by, expires_at, created_at, updated_at)
+VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: GetInvitationById :one
+SELECT * FROM invitations
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetPendingInvitationByEmail :one
+SELECT * FROM invitations
+WHERE team_id = $1 AND email = $2 AND accepted_at IS NULL
+LIMIT 1;
+
+-- name: GetPendingInvitationsByTeam :many
+SELECT * FROM invitations
+WHERE team_id = $1 AND accepted_at IS NULL
+ORDER BY created_at DESC;
+
+-- name: RenewInvitation :one
+UPDATE invitations
+SET token_id = $1, expires_at = $2, updated_at = $3
+WHERE id = $4 AND accepted_at IS NULL
+RETURNING *;
+
+-- name: MarkInvitationAccepted :execrows
+UPDATE invitations
+SET accepted_at = $1, updated_at = $2
+WHERE id = $3 AND token_id = $4 AND accepted_at IS NULL AND expires_at > $2;
+
+-- name: DeleteInvitation :exec
+DELETE FROM invitations WHERE id = $1;
@@ -0,0 +1,18 @@
+-- name: GetLoginAttempts :many
+SELECT * FROM login_attempts
+WHERE (scope = 'account' AND identifier = sqlc.arg(account_key))
+   OR (scope = 'ip' AND identifier = sqlc.arg(ip_address));
+
+-- name: RecordFailedLoginAttempt :one
+INSERT INTO login_attempts (scope, identifier, failed_count, last_failed_at)
+VALUES(sqlc.arg(scope), sqlc.arg(identifier), 1, sqlc.arg(failed_at))
+ON CONFLICT (scope, identifier) DO UPDATE
+SET failed_count = CASE
+        WHEN login_attempts.last_failed_at < sqlc.arg(window_start) THEN 1
+        ELSE login_attempts.failed_count + 1
+    END,
+    last_failed_at = EXCLUDED.last_failed_at
+RETURNING *;
+
+-- name: DeleteLoginAttempt :exec
+DELETE FROM login_attempts WHERE scope = $1 AND identifier = $2;
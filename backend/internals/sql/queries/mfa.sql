@@ -0,0 +1,51 @@
+-- name: StartUserTotpEnrollment :one
+INSERT INTO user_totp (user_id, secret, created_at, updated_at)
+VALUES($1, $2, $3, $3)
+ON CONFLICT (user_id) DO UPDATE
+SET secret = EXCLUDED.secret, updated_at = EXCLUDED.updated_at
+WHERE user_totp.enabled_at IS NULL
+RETURNING *;
+
+-- name: GetUserTotp :one
+SELECT * FROM user_totp
+WHERE user_id = $1
+LIMIT 1;
+
+-- name: EnableUserTotp :execrows
+UPDATE user_totp
+SET enabled_at = $1, last_used_step = $2, updated_at = $3
+WHERE user_id = $4 AND enabled_at IS NULL;
+
+-- name: UseUserTotpStep :execrows
+UPDATE user_totp
+SET last_used_step = $1, updated_at = $2
+WHERE user_id = $3 AND enabled_at IS NOT NULL AND last_used_step < $1;
+
+-- name: DeleteUserTotp :exec
+DELETE FROM user_totp WHERE user_id = $1;
+
+-- name: CreateRecoveryCode :exec
+INSERT INTO mfa_recovery_codes (id, user_id, code_hash, created_at)
+VALUES($1, $2, $3, $4);
+
+-- name: UseRecoveryCode :execrows
+UPDATE mfa_recovery_codes
+SET used_at = $1
+WHERE user_id = $2 AND code_hash = $3 AND used_at IS NULL;
+
+-- name: DeleteRecoveryCodesByUserId :exec
+DELETE FROM mfa_recovery_codes WHERE user_id = $1;
+
+-- name: DeleteSessionsWithoutMfaByTeam :exec
+DELETE FROM sessions
+WHERE team_id = $1
+AND user_id NOT IN (SELECT user_id FROM user_totp WHERE enabled_at IS NOT NULL);
+
+-- name: CreateMfaPendingToken :exec
+INSERT INTO mfa_pending_tokens (id, user_id, expires_at, created_at)
+VALUES($1, $2, $3, $4);
+
+-- name: UseMfaPendingToken :execrows
+UPDATE mfa_pending_tokens
+SET used_at = $1
+WHERE id = $2 AND user_id = $3 AND used_at IS NULL AND expires_at > $1;
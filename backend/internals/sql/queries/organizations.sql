@@ -0,0 +1,91 @@
+-- name: CreateOrganization :one
+INSERT INTO organizations (created_at, updated_at, name, require_mfa)
+VALUES($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetOrganizationById :one
+SELECT * FROM organizations
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetUserOrganizations :many
+SELECT organizations.*
+FROM organizations
+INNER JOIN organization_admins ON organization_admins.organization_id = organizations.id
+WHERE organization_admins.user_id = $1
+ORDER BY organizations.created_at;
+
+-- name: UpdateOrganization :one
+UPDATE organizations
+SET name = $1, require_mfa = $2, updated_at = $3
+WHERE id = $4
+RETURNING *;
+
+-- name: RequireOrganizationTeamsMfa :exec
+UPDATE teams
+SET require_mfa = TRUE, updated_at = $1
+WHERE organization_id = $2;
+
+-- name: DeleteOrganizationSessionsWithoutMfa :exec
+DELETE FROM sessions
+WHERE team_id IN (SELECT id FROM teams WHERE teams.organization_id = $1)
+AND user_id NOT IN (SELECT user_id FROM user_totp WHERE enabled_at IS NOT NULL);
+
+-- name: GetOrganizationTeams :many
+SELECT * FROM teams
+WHERE organization_id = $1
+ORDER BY created_at;
+
+-- name: GetOrganizationUsers :many
+SELECT team_memberships.*, users.username, users.email, teams.name
+FROM team_memberships
+INNER JOIN users ON users.id = team_memberships.user_id
+INNER JOIN teams ON teams.id = team_memberships.team_id
+WHERE teams.organization_id = $1
+ORDER BY users.username, users.id, teams.created_at;
+
+-- name: IsOrganizationMember :one
+SELECT EXISTS (
+    SELECT 1 FROM team_memberships
+    INNER JOIN teams ON teams.id = team_memberships.team_id
+    WHERE teams.organization_id = $1 AND team_memberships.user_id = $2
+);
+
+-- name: GetOrganizationTeamReports :many
+SELECT
+    teams.id,
+    teams.name,
+    (SELECT COUNT(*) FROM team_memberships WHERE team_memberships.team_id = teams.id) AS members,
+    (SELECT COUNT(*) FROM projects WHERE projects.team_id = teams.id) AS projects,
+    (SELECT COUNT(*) FROM tasks WHERE tasks.project_id IN (SELECT id FROM projects WHERE projects.team_id = teams.id)) AS tasks,
+    (SELECT COUNT(*) FROM tasks WHERE tasks.status = 'Done' AND tasks.project_id IN (SELECT id FROM projects WHERE projects.team_id = teams.id)) AS done_tasks
+FROM teams
+WHERE teams.organization_id = $1
+ORDER BY teams.created_at;
+
+-- name: CountOrganizationUsers :one
+SELECT COUNT(DISTINCT team_memberships.user_id)
+FROM team_memberships
+INNER JOIN teams ON teams.id = team_memberships.team_id
+WHERE teams.organization_id = $1;
+
+-- name: AddOrganizationAdmin :one
+INSERT INTO organization_admins (organization_id, user_id, created_at)
+VALUES($1, $2, $3)
+RETURNING *;
+
+-- name: GetOrganizationAdmin :one
+SELECT * FROM organization_admins
+WHERE organization_id = $1 AND user_id = $2
+LIMIT 1;
+
+-- name: GetOrganizationAdmins :many
+SELECT organization_admins.*, users.username, users.email
+FROM organization_admins
+INNER JOIN users ON users.id = organization_admins.user_id
+WHERE organization_admins.organization_id = $1
+ORDER BY organization_admins.created_at;
+
+-- name: DeleteOrganizationAdmin :exec
+DELETE FROM organization_admins
+WHERE organization_id = $1 AND user_id = $2;
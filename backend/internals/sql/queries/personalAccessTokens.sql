@@ -0,0 +1,28 @@
+-- name: CreatePersonalAccessToken :one
+INSERT INTO personal_access_tokens (id, user_id, name, token_hash, token_prefix, scopes, expires_at, created_at)
+VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetPersonalAccessTokenByHash :one
+SELECT personal_access_tokens.*,
+users.role AS user_role,
+users.team_id AS user_team_id,
+users.role_id AS user_role_id
+FROM personal_access_tokens
+JOIN users ON personal_access_tokens.user_id = users.id
+WHERE token_hash = $1 AND users.deactivated_at IS NULL
+LIMIT 1;
+
+-- name: GetPersonalAccessTokensByUserId :many
+SELECT * FROM personal_access_tokens
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: UpdatePersonalAccessTokenLastUsed :exec
+UPDATE personal_access_tokens
+SET last_used_at = $1
+WHERE id = $2;
+
+-- name: DeletePersonalAccessToken :execrows
+DELETE FROM personal_access_tokens
+WHERE id = $1 AND user_id = $2;
@@ -0,0 +1,35 @@
+-- name: AddProjectMember :one
+INSERT INTO project_members (project_id, user_id, role, created_at, updated_at)
+VALUES($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetProjectMember :one
+SELECT * FROM project_members
+WHERE project_id = $1 AND user_id = $2
+LIMIT 1;
+
+-- name: GetProjectMembers :many
+SELECT project_members.*, users.username, users.email
+FROM project_members
+INNER JOIN users ON users.id = project_members.user_id
+WHERE project_members.project_id = $1
+ORDER BY project_members.created_at;
+
+-- name: DeleteProjectMember :exec
+DELETE FROM project_members
+WHERE project_id = $1 AND user_id = $2;
+
+-- name: UnassignProjectMemberTasks :exec
+UPDATE tasks
+SET user_id = NULL, updated_at = $3
+WHERE project_id = $1 AND user_id = $2;
+
+-- name: ReassignProjectManager :exec
+UPDATE projects
+SET manager_id = (
+    SELECT project_members.user_id FROM project_members
+    WHERE project_members.project_id = sqlc.arg(id) AND project_members.role = 'Owner'
+    ORDER BY project_members.created_at
+    LIMIT 1
+), updated_at = sqlc.arg(updated_at)
+WHERE projects.id = sqlc.arg(id) AND projects.manager_id = sqlc.arg(manager_id);
@@ -1,6 +1,6 @@
 -- name: CreateRefreshToken :exec
-INSERT INTO refresh_tokens (id, userId, token, expires_at, created_at, revoked)
-VALUES($1, $2, $3, $4, $5, $6);
+INSERT INTO refresh_tokens (id, userId, token, expires_at, created_at, revoked, family_id)
+VALUES($1, $2, $3, $4, $5, $6, $7);
 
 -- name: UpdaterefreshToken :exec
 UPDATE refresh_tokens
@@ -15,7 +15,8 @@ users.updated_at AS userDataUpdatedAt,
 users.username AS userDataUsername,
 users.password AS userDataPassword,
 users.email AS userDataEmail,
-users.role AS userDataRole
+users.role AS userDataRole,
+users.role_id AS userDataRoleId
 FROM refresh_tokens
 JOIN users ON refresh_tokens.userId = users.id
 WHERE token = $1
@@ -24,3 +25,13 @@ LIMIT 1;
 -- name: DeleteRefreshTokensByUserId :exec
 DELETE FROM refresh_tokens
 WHERE userId = $1;
+
+-- name: MarkRefreshTokenUsed :execrows
+UPDATE refresh_tokens
+SET used_at = $1
+WHERE id = $2 AND used_at IS NULL AND revoked = false;
+
+-- name: RevokeRefreshTokenFamily :exec
+UPDATE refresh_tokens
+SET revoked = true
+WHERE family_id = $1;
@@ -0,0 +1,6 @@
+-- name: GetRevokedSessionIds :many
+SELECT session_id FROM revoked_sessions
+WHERE revoked_at > $1;
+
+-- name: DeleteRevokedSessionsBefore :exec
+DELETE FROM revoked_sessions WHERE revoked_at <= $1;
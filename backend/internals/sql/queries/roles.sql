@@ -0,0 +1,33 @@
+-- name: CreateRole :one
+INSERT INTO roles (created_at, updated_at, team_id, name, permissions)
+VALUES($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetRoleById :one
+SELECT * FROM roles
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetRoleByName :one
+SELECT * FROM roles
+WHERE (team_id = $1 OR team_id IS NULL) AND LOWER(name) = LOWER(sqlc.arg(name))
+LIMIT 1;
+
+-- name: GetCustomRoles :many
+SELECT * FROM roles
+WHERE base_role IS NULL;
+
+-- name: GetRolesByTeam :many
+SELECT * FROM roles
+WHERE team_id = $1 OR team_id IS NULL
+ORDER BY base_role NULLS LAST, created_at;
+
+-- name: UpdateRole :one
+UPDATE roles
+SET name = $1, permissions = $2, updated_at = $3
+WHERE id = $4 AND base_role IS NULL
+RETURNING *;
+
+-- name: DeleteRole :exec
+DELETE FROM roles
+WHERE id = $1 AND base_role IS NULL;
@@ -0,0 +1,33 @@
+-- name: CreateSession :one
+INSERT INTO sessions (id, user_id, label, user_agent, ip_address, created_at, last_used_at, team_id)
+VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetSessionById :one
+SELECT * FROM sessions
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetSessionsByUserId :many
+SELECT * FROM sessions
+WHERE user_id = $1
+ORDER BY last_used_at DESC;
+
+-- name: UpdateSessionLastUsed :exec
+UPDATE sessions
+SET last_used_at = $1, ip_address = $2
+WHERE id = $3;
+
+-- name: UpdateSessionTeam :exec
+UPDATE sessions
+SET team_id = $1
+WHERE id = $2;
+
+-- name: DeleteSession :exec
+DELETE FROM sessions WHERE id = $1;
+
+-- name: DeleteSessionsByUserId :exec
+DELETE FROM sessions WHERE user_id = $1;
+
+-- name: DeleteOtherSessionsByUserId :exec
+DELETE FROM sessions WHERE user_id = $1 AND id <> $2;
@@ -0,0 +1,83 @@
+-- name: AddTeamMembership :one
+INSERT INTO team_memberships (team_id, user_id, role, role_id, created_at, updated_at)
+VALUES($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetTeamMembership :one
+SELECT * FROM team_memberships
+WHERE team_id = $1 AND user_id = $2
+LIMIT 1;
+
+-- name: GetTeamMemberships :many
+SELECT team_memberships.*, users.username, users.email
+FROM team_memberships
+INNER JOIN users ON users.id = team_memberships.user_id
+WHERE team_memberships.team_id = $1
+ORDER BY team_memberships.created_at;
+
+-- name: GetUserTeamMemberships :many
+SELECT team_memberships.*, teams.name
+FROM team_memberships
+INNER JOIN teams ON teams.id = team_memberships.team_id
+WHERE team_memberships.user_id = $1
+ORDER BY team_memberships.created_at;
+
+-- name: UpdateTeamMembershipRole :one
+UPDATE team_memberships
+SET role = $1, role_id = $2, updated_at = $3
+WHERE team_id = $4 AND user_id = $5
+RETURNING *;
+
+-- name: DeleteTeamMembership :exec
+DELETE FROM team_memberships
+WHERE team_id = $1 AND user_id = $2;
+
+-- name: CountProjectsOnlyOwnedBy :one
+SELECT COUNT(*) FROM projects
+WHERE projects.team_id = $1
+AND (projects.manager_id = $2 OR EXISTS (
+    SELECT 1 FROM project_members
+    WHERE project_members.project_id = projects.id AND project_members.user_id = $2 AND project_members.role = 'Owner'
+))
+AND NOT EXISTS (
+    SELECT 1 FROM project_members
+    WHERE project_members.project_id = projects.id AND project_members.user_id <> $2 AND project_members.role = 'Owner'
+);
+
+-- name: UnassignTeamMemberTasks :exec
+UPDATE tasks
+SET user_id = NULL, updated_at = $3
+WHERE user_id = $2 AND project_id IN (SELECT id FROM projects WHERE projects.team_id = $1);
+
+-- name: DeleteTeamMemberProjectMemberships :exec
+DELETE FROM project_members
+WHERE user_id = $2 AND project_id IN (SELECT id FROM projects WHERE projects.team_id = $1);
+
+-- name: ReassignTeamMemberProjects :exec
+UPDATE projects
+SET manager_id = (
+    SELECT project_members.user_id FROM project_members
+    WHERE project_members.project_id = projects.id AND project_members.role = 'Owner'
+    ORDER BY project_members.created_at
+    LIMIT 1
+), updated_at = sqlc.arg(updated_at)
+WHERE projects.team_id = sqlc.arg(team_id) AND projects.manager_id = sqlc.arg(manager_id);
+
+-- name: DeleteTeamMemberSessions :exec
+DELETE FROM sessions
+WHERE user_id = $1 AND team_id = $2;
+
+-- name: MoveUserDefaultTeam :exec
+UPDATE users
+SET team_id = next_team.team_id,
+    role = COALESCE(next_team.role, users.role),
+    role_id = next_team.role_id,
+    updated_at = sqlc.arg(updated_at)
+FROM users u
+LEFT JOIN LATERAL (
+    SELECT team_memberships.team_id, team_memberships.role, team_memberships.role_id FROM team_memberships
+    WHERE team_memberships.user_id = u.id AND team_memberships.team_id <> sqlc.arg(team_id)
+    ORDER BY team_memberships.created_at
+    LIMIT 1
+) next_team ON true
+WHERE users.id = u.id AND users.team_id = sqlc.arg(team_id) AND (sqlc.narg(user_id)::UUID IS NULL OR users.id = sqlc.narg(user_id));
@@ -1,9 +1,50 @@
 -- name: CreateTeam :one
-INSERT INTO teams (created_at, updated_at, name, owner_id)
-VALUES($1, $2, $3, $4)
+INSERT INTO teams (created_at, updated_at, name, owner_id, organization_id, require_mfa)
+VALUES($1, $2, $3, $4, $5, $6)
 RETURNING *;
 
 -- name: GetTeamByOwner :one
 SELECT * FROM teams
 WHERE owner_id = $1
-LIMIT 1;
\ No newline at end of file
+ORDER BY created_at
+LIMIT 1;
+
+-- name: GetTeamById :one
+SELECT * FROM teams
+WHERE id = $1
+LIMIT 1;
+
+-- name: UpdateTeamRequireMfa :one
+UPDATE teams
+SET require_mfa = $1, updated_at = $2
+WHERE id = $3
+RETURNING *;
+
+-- name: UpdateTeamName :one
+UPDATE teams
+SET name = $1, updated_at = $2
+WHERE id = $3
+RETURNING *;
+
+-- name: UpdateTeamOwner :one
+UPDATE teams
+SET owner_id = $1, updated_at = $2
+WHERE id = $3
+RETURNING *;
+
+-- name: GetTeamDeletionReport :one
+SELECT
+    (SELECT COUNT(*) FROM projects WHERE projects.team_id = $1) AS projects,
+    (SELECT COUNT(*) FROM tasks WHERE tasks.project_id IN (SELECT id FROM projects WHERE projects.team_id = $1)) AS tasks,
+    (SELECT COUNT(*) FROM team_memberships WHERE team_memberships.team_id = $1) AS users,
+    (SELECT COUNT(*) FROM invitations WHERE invitations.team_id = $1) AS invitations,
+    (SELECT COUNT(*) FROM roles WHERE roles.team_id = $1) AS roles;
+
+-- name: DeleteTeamSessions :exec
+DELETE FROM sessions
+WHERE team_id = $1
+AND user_id <> (SELECT owner_id FROM teams WHERE teams.id = $1);
+
+-- name: DeleteTeam :exec
+DELETE FROM teams
+WHERE id = $1;
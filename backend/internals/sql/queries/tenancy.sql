@@ -0,0 +1,8 @@
+-- name: SetTenantRole :exec
+SET LOCAL ROLE app_tenant;
+
+-- name: SetCurrentTeam :exec
+SELECT set_config('app.current_team_id', sqlc.arg(team_id)::text, true);
+
+-- name: SetActingAdmin :exec
+SELECT set_config('app.acting_admin_id', sqlc.arg(admin_id)::text, true);
@@ -0,0 +1,8 @@
+-- name: CreateUserIdentity :exec
+INSERT INTO user_identities (id, user_id, issuer, subject, email, created_at)
+VALUES($1, $2, $3, $4, $5, $6);
+
+-- name: GetUserIdentity :one
+SELECT * FROM user_identities
+WHERE issuer = $1 AND subject = $2
+LIMIT 1;
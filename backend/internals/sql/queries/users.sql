@@ -20,4 +20,96 @@ WHERE id = $4
 RETURNING *;
 
 -- name: DeleteUser :exec
-DELETE FROM users WHERE id = $1;
\ No newline at end of file
+DELETE FROM users WHERE id = $1;
+
+-- name: UpdateUserPassword :exec
+UPDATE users
+SET password = $1, updated_at = $2
+WHERE id = $3;
+
+-- name: UpdateUserRole :one
+UPDATE users
+SET role = $1, updated_at = $2
+WHERE id = $3
+RETURNING *;
+
+-- name: AssignUserRole :one
+UPDATE users
+SET role_id = $1, updated_at = $2
+WHERE id = $3
+RETURNING *;
+
+-- name: AdminExists :one
+SELECT EXISTS(SELECT 1 FROM users WHERE role = 'Admin');
+
+-- name: LockAdminBootstrap :exec
+SELECT pg_advisory_xact_lock(hashtext('admin_bootstrap'));
+
+-- name: GetUserWorkTeamIds :many
+SELECT id FROM teams WHERE owner_id = sqlc.arg(user_id)
+UNION
+SELECT team_id FROM projects WHERE manager_id = sqlc.arg(user_id)
+UNION
+SELECT projects.team_id FROM tasks
+INNER JOIN projects ON projects.id = tasks.project_id
+WHERE tasks.user_id = sqlc.arg(user_id) AND tasks.status <> 'Done';
+
+-- name: GetUserOwnedTeams :many
+SELECT * FROM teams
+WHERE owner_id = sqlc.arg(owner_id) AND id = ANY(sqlc.arg(team_ids)::uuid[])
+ORDER BY created_at;
+
+-- name: GetUserManagedProjects :many
+SELECT * FROM projects
+WHERE manager_id = sqlc.arg(manager_id) AND team_id = ANY(sqlc.arg(team_ids)::uuid[])
+ORDER BY created_at;
+
+-- name: GetUserOpenTasks :many
+SELECT tasks.*, projects.team_id
+FROM tasks
+INNER JOIN projects ON projects.id = tasks.project_id
+WHERE tasks.user_id = sqlc.arg(user_id) AND tasks.status <> 'Done' AND projects.team_id = ANY(sqlc.arg(team_ids)::uuid[])
+ORDER BY tasks.created_at;
+
+-- name: TransferUserTeams :exec
+UPDATE teams
+SET owner_id = sqlc.arg(new_owner_id), updated_at = sqlc.arg(updated_at)
+WHERE owner_id = sqlc.arg(owner_id) AND id = ANY(sqlc.arg(team_ids)::uuid[]);
+
+-- name: AddUserProjectsOwner :exec
+INSERT INTO project_members (project_id, user_id, role, created_at, updated_at)
+SELECT id, sqlc.arg(new_manager_id), 'Owner', sqlc.arg(updated_at), sqlc.arg(updated_at) FROM projects
+WHERE manager_id = sqlc.arg(manager_id) AND team_id = ANY(sqlc.arg(team_ids)::uuid[])
+ON CONFLICT (project_id, user_id) DO UPDATE
+SET role = 'Owner', updated_at = EXCLUDED.updated_at;
+
+-- name: TransferUserProjects :exec
+UPDATE projects
+SET manager_id = sqlc.arg(new_manager_id), updated_at = sqlc.arg(updated_at)
+WHERE manager_id = sqlc.arg(manager_id) AND team_id = ANY(sqlc.arg(team_ids)::uuid[]);
+
+-- name: AddUserTasksContributor :exec
+INSERT INTO project_members (project_id, user_id, role, created_at, updated_at)
+SELECT DISTINCT project_id, sqlc.arg(new_user_id), 'Contributor', sqlc.arg(updated_at), sqlc.arg(updated_at) FROM tasks
+WHERE user_id = sqlc.arg(user_id) AND status <> 'Done'
+    AND project_id IN (SELECT id FROM projects WHERE team_id = ANY(sqlc.arg(team_ids)::uuid[]))
+ON CONFLICT (project_id, user_id) DO UPDATE
+SET role = 'Contributor', updated_at = EXCLUDED.updated_at
+WHERE project_members.role = 'Viewer';
+
+-- name: ReassignUserTasks :exec
+UPDATE tasks
+SET user_id = sqlc.arg(new_user_id), updated_at = sqlc.arg(updated_at)
+WHERE user_id = sqlc.arg(user_id) AND status <> 'Done'
+    AND project_id IN (SELECT id FROM projects WHERE team_id = ANY(sqlc.arg(team_ids)::uuid[]));
+
+-- name: DeactivateUser :exec
+UPDATE users
+SET deactivated_at = $1, updated_at = $2
+WHERE id = $3;
+
+-- name: ReactivateUser :one
+UPDATE users
+SET deactivated_at = NULL, updated_at = $1
+WHERE id = $2
+RETURNING *;
@@ -0,0 +1,23 @@
+-- +goose Up
+
+-- Rotation keeps the used token next to its replacement, so a user can have several rows
+ALTER TABLE refresh_tokens DROP CONSTRAINT IF EXISTS refresh_tokens_userid_key;
+
+ALTER TABLE refresh_tokens
+    ADD COLUMN family_id UUID NOT NULL DEFAULT gen_random_uuid(),
+    ADD COLUMN used_at TIMESTAMP;
+
+CREATE INDEX idx_refresh_tokens_userid ON refresh_tokens(userId);
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_refresh_tokens_family_id;
+DROP INDEX IF EXISTS idx_refresh_tokens_userid;
+
+DELETE FROM refresh_tokens WHERE used_at IS NOT NULL OR revoked = true;
+
+ALTER TABLE refresh_tokens
+    DROP COLUMN used_at,
+    DROP COLUMN family_id;
+
+ALTER TABLE refresh_tokens ADD CONSTRAINT refresh_tokens_userid_key UNIQUE (userId);
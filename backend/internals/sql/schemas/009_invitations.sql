@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE invitations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    email TEXT NOT NULL,
+    role UserRoles NOT NULL,
+    token_id UUID NOT NULL,
+    invited_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    accepted_at TIMESTAMP
+);
+
+-- A team can only have one pending invitation per email, it has to be resent or revoked instead
+CREATE UNIQUE INDEX idx_invitations_pending_email ON invitations(team_id, email) WHERE accepted_at IS NULL;
+
+-- +goose Down
+DROP TABLE invitations;
@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE login_attempts (
+    scope TEXT NOT NULL CHECK (scope IN ('account', 'ip')),
+    identifier TEXT NOT NULL,
+    failed_count INTEGER NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (scope, identifier)
+);
+
+CREATE INDEX idx_login_attempts_last_failed_at ON login_attempts(last_failed_at);
+
+-- +goose Down
+DROP TABLE login_attempts;
@@ -0,0 +1,30 @@
+-- +goose Up
+CREATE TABLE revoked_sessions (
+    session_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    revoked_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_revoked_sessions_revoked_at ON revoked_sessions(revoked_at);
+
+-- Every way a session goes away (log out, revoke, password reset, deleting the user) is recorded so
+-- the access tokens it issued stop working before they expire
+-- +goose StatementBegin
+CREATE FUNCTION record_revoked_session() RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO revoked_sessions (session_id, user_id, revoked_at)
+    VALUES (OLD.id, OLD.user_id, now() AT TIME ZONE 'UTC')
+    ON CONFLICT (session_id) DO NOTHING;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_sessions_revoked
+AFTER DELETE ON sessions
+FOR EACH ROW EXECUTE FUNCTION record_revoked_session();
+
+-- +goose Down
+DROP TRIGGER trg_sessions_revoked ON sessions;
+DROP FUNCTION record_revoked_session();
+DROP TABLE revoked_sessions;
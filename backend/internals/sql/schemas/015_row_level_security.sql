@@ -0,0 +1,60 @@
+-- +goose Up
+
+-- Queries scoped to a team run as app_tenant for the transaction. The policies below apply to it,
+-- not to the owner of the tables the api connects as (nor to a superuser), so the queries that aren't
+-- scoped to a team, like logging in, keep working
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'app_tenant') THEN
+        CREATE ROLE app_tenant NOLOGIN;
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+
+GRANT app_tenant TO CURRENT_USER;
+
+GRANT SELECT ON users, teams TO app_tenant;
+GRANT SELECT, INSERT, UPDATE, DELETE ON projects, tasks TO app_tenant;
+
+-- The team of the transaction, NULL when app.current_team_id isn't set which matches no row
+-- +goose StatementBegin
+CREATE FUNCTION app_current_team_id() RETURNS UUID AS $$
+    SELECT NULLIF(current_setting('app.current_team_id', true), '')::UUID;
+$$ LANGUAGE sql STABLE;
+-- +goose StatementEnd
+
+ALTER TABLE users ENABLE ROW LEVEL SECURITY;
+ALTER TABLE projects ENABLE ROW LEVEL SECURITY;
+ALTER TABLE tasks ENABLE ROW LEVEL SECURITY;
+
+-- Admins don't belong to the team they own, they are part of it too
+CREATE POLICY users_team_isolation ON users TO app_tenant
+    USING (
+        team_id = app_current_team_id()
+        OR id IN (SELECT owner_id FROM teams WHERE id = app_current_team_id())
+    );
+
+CREATE POLICY projects_team_isolation ON projects TO app_tenant
+    USING (team_id = app_current_team_id())
+    WITH CHECK (team_id = app_current_team_id());
+
+-- Tasks belong to the team of their project
+CREATE POLICY tasks_team_isolation ON tasks TO app_tenant
+    USING (project_id IN (SELECT id FROM projects WHERE team_id = app_current_team_id()))
+    WITH CHECK (project_id IN (SELECT id FROM projects WHERE team_id = app_current_team_id()));
+
+-- +goose Down
+DROP POLICY tasks_team_isolation ON tasks;
+DROP POLICY projects_team_isolation ON projects;
+DROP POLICY users_team_isolation ON users;
+
+ALTER TABLE tasks DISABLE ROW LEVEL SECURITY;
+ALTER TABLE projects DISABLE ROW LEVEL SECURITY;
+ALTER TABLE users DISABLE ROW LEVEL SECURITY;
+
+DROP FUNCTION app_current_team_id();
+
+-- Roles are shared by the databases of the server, app_tenant is left in place
+REVOKE ALL ON users, teams, projects, tasks FROM app_tenant;
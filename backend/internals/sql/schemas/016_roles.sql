@@ -0,0 +1,56 @@
+-- +goose Up
+-- Built-in roles have a base_role and no team, custom roles belong to the team that created them
+CREATE TABLE roles (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    team_id UUID REFERENCES teams(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    permissions TEXT[] NOT NULL,
+    base_role UserRoles UNIQUE
+);
+
+CREATE UNIQUE INDEX idx_roles_team_id_name ON roles(team_id, LOWER(name));
+
+-- The same permissions as models.BuiltInRolePermissions, the ids are the ones of models.BuiltInRoleIds
+INSERT INTO roles (id, created_at, updated_at, name, permissions, base_role) VALUES
+    ('00000000-0000-0000-0000-000000000001', now() AT TIME ZONE 'UTC', now() AT TIME ZONE 'UTC', 'Admin',
+        ARRAY['users:view', 'users:manage', 'projects:view', 'tasks:view_any'], 'Admin'),
+    ('00000000-0000-0000-0000-000000000002', now() AT TIME ZONE 'UTC', now() AT TIME ZONE 'UTC', 'Manager',
+        ARRAY['users:view', 'projects:view', 'projects:create', 'projects:edit_own', 'projects:delete_own',
+              'tasks:view_any', 'tasks:create_own', 'tasks:edit_own', 'tasks:delete_own'], 'Manager'),
+    ('00000000-0000-0000-0000-000000000003', now() AT TIME ZONE 'UTC', now() AT TIME ZONE 'UTC', 'Member',
+        ARRAY['tasks:view_assigned'], 'Member');
+
+ALTER TABLE users ADD COLUMN role_id UUID REFERENCES roles(id) ON DELETE SET NULL;
+
+UPDATE users SET role_id = roles.id FROM roles WHERE roles.base_role = users.role;
+
+-- Users get the built-in role of their base role when they are created without one, when the custom
+-- role they had is deleted, and when their base role changes while they are on a built-in one
+-- +goose StatementBegin
+CREATE FUNCTION set_default_user_role() RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.role_id IS NULL
+        OR (TG_OP = 'UPDATE' AND NEW.role IS DISTINCT FROM OLD.role
+            AND NEW.role_id IN (SELECT id FROM roles WHERE base_role IS NOT NULL)) THEN
+        NEW.role_id := (SELECT id FROM roles WHERE base_role = NEW.role);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_users_default_role
+BEFORE INSERT OR UPDATE ON users
+FOR EACH ROW EXECUTE FUNCTION set_default_user_role();
+
+ALTER TABLE users ALTER COLUMN role_id SET NOT NULL;
+
+CREATE INDEX idx_users_role_id ON users(role_id);
+
+-- +goose Down
+DROP TRIGGER trg_users_default_role ON users;
+DROP FUNCTION set_default_user_role();
+ALTER TABLE users DROP COLUMN role_id;
+DROP TABLE roles;
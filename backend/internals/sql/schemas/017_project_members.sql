@@ -0,0 +1,50 @@
+-- +goose Up
+DROP TYPE IF EXISTS ProjectMemberRoles; CREATE TYPE ProjectMemberRoles AS ENUM (
+  'Owner',
+  'Contributor',
+  'Viewer'
+);
+
+CREATE TABLE project_members (
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role ProjectMemberRoles NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (project_id, user_id)
+);
+
+CREATE INDEX idx_project_members_user_id ON project_members(user_id);
+
+-- The manager of a project owns it and the users with tasks in it contribute to it
+INSERT INTO project_members (project_id, user_id, role, created_at, updated_at)
+SELECT id, manager_id, 'Owner', created_at, created_at FROM projects;
+
+INSERT INTO project_members (project_id, user_id, role, created_at, updated_at)
+SELECT DISTINCT project_id, user_id, 'Contributor'::ProjectMemberRoles, now() AT TIME ZONE 'UTC', now() AT TIME ZONE 'UTC'
+FROM tasks
+WHERE user_id IS NOT NULL
+ON CONFLICT (project_id, user_id) DO NOTHING;
+
+-- Members see the projects they are members of and their tasks, the same as models.BuiltInRolePermissions
+UPDATE roles SET permissions = permissions || ARRAY['projects:view_member', 'tasks:view_member']
+WHERE base_role = 'Member';
+
+GRANT SELECT, INSERT, UPDATE, DELETE ON project_members TO app_tenant;
+
+ALTER TABLE project_members ENABLE ROW LEVEL SECURITY;
+
+-- Members belong to the team of their project
+CREATE POLICY project_members_team_isolation ON project_members TO app_tenant
+    USING (project_id IN (SELECT id FROM projects WHERE team_id = app_current_team_id()))
+    WITH CHECK (project_id IN (SELECT id FROM projects WHERE team_id = app_current_team_id()));
+
+-- +goose Down
+DROP POLICY project_members_team_isolation ON project_members;
+
+REVOKE ALL ON project_members FROM app_tenant;
+
+UPDATE roles SET permissions = array_remove(array_remove(permissions, 'projects:view_member'), 'tasks:view_member');
+
+DROP TABLE project_members;
+DROP TYPE ProjectMemberRoles;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- The admin that last created or changed the project or task, null when it was its manager
+ALTER TABLE projects ADD COLUMN acting_admin_id UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE tasks ADD COLUMN acting_admin_id UUID REFERENCES users(id) ON DELETE SET NULL;
+
+-- Admins manage the projects and tasks of their team, the same as models.BuiltInRolePermissions
+UPDATE roles SET permissions = permissions || ARRAY['projects:create', 'projects:edit_any', 'projects:delete_any', 'tasks:create_any', 'tasks:edit_any', 'tasks:delete_any']
+WHERE base_role = 'Admin';
+
+-- +goose Down
+UPDATE roles SET permissions = ARRAY['users:view', 'users:manage', 'projects:view', 'tasks:view_any']
+WHERE base_role = 'Admin';
+
+ALTER TABLE tasks DROP COLUMN acting_admin_id;
+ALTER TABLE projects DROP COLUMN acting_admin_id;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Members change the status and add to the description of the tasks assigned to them, the same as
+-- models.BuiltInRolePermissions
+UPDATE roles SET permissions = permissions || ARRAY['tasks:edit_assigned']
+WHERE base_role = 'Member';
+
+-- +goose Down
+UPDATE roles SET permissions = array_remove(permissions, 'tasks:edit_assigned');
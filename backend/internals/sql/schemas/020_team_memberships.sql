@@ -0,0 +1,108 @@
+-- +goose Up
+-- Users can belong to several teams with a role in each one. users.team_id is the default team of the
+-- user, the one they log in to, and users.role and users.role_id are their role in it
+CREATE TABLE team_memberships (
+    team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role UserRoles NOT NULL,
+    role_id UUID REFERENCES roles(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (team_id, user_id)
+);
+
+CREATE INDEX idx_team_memberships_user_id ON team_memberships(user_id);
+
+-- Admins join the teams they own, the oldest one becomes their default team
+UPDATE users SET team_id = (
+    SELECT teams.id FROM teams WHERE teams.owner_id = users.id ORDER BY teams.created_at LIMIT 1
+)
+WHERE team_id IS NULL AND EXISTS (SELECT 1 FROM teams WHERE teams.owner_id = users.id);
+
+INSERT INTO team_memberships (team_id, user_id, role, role_id, created_at, updated_at)
+SELECT team_id, id, role, role_id, created_at, now() AT TIME ZONE 'UTC'
+FROM users
+WHERE team_id IS NOT NULL;
+
+INSERT INTO team_memberships (team_id, user_id, role, role_id, created_at, updated_at)
+SELECT teams.id, teams.owner_id, 'Admin', '00000000-0000-0000-0000-000000000001', teams.created_at, now() AT TIME ZONE 'UTC'
+FROM teams
+ON CONFLICT (team_id, user_id) DO NOTHING;
+
+-- The membership of the default team follows the role of the user, so the code that creates users or
+-- changes their role keeps them in their team
+-- +goose StatementBegin
+CREATE FUNCTION sync_default_team_membership() RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.team_id IS NOT NULL THEN
+        INSERT INTO team_memberships (team_id, user_id, role, role_id, created_at, updated_at)
+        VALUES (NEW.team_id, NEW.id, NEW.role, NEW.role_id, NEW.updated_at, NEW.updated_at)
+        ON CONFLICT (team_id, user_id) DO UPDATE
+        SET role = EXCLUDED.role, role_id = EXCLUDED.role_id, updated_at = EXCLUDED.updated_at;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_users_default_team_membership
+AFTER INSERT OR UPDATE OF team_id, role, role_id ON users
+FOR EACH ROW EXECUTE FUNCTION sync_default_team_membership();
+
+-- Owners are admins of their teams, and the team becomes the default one of owners without one
+-- +goose StatementBegin
+CREATE FUNCTION sync_team_owner_membership() RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO team_memberships (team_id, user_id, role, role_id, created_at, updated_at)
+    VALUES (NEW.id, NEW.owner_id, 'Admin', (SELECT id FROM roles WHERE base_role = 'Admin'), NEW.updated_at, NEW.updated_at)
+    ON CONFLICT (team_id, user_id) DO UPDATE
+    SET role = EXCLUDED.role, role_id = EXCLUDED.role_id, updated_at = EXCLUDED.updated_at;
+
+    UPDATE users SET team_id = NEW.id, updated_at = NEW.updated_at
+    WHERE id = NEW.owner_id AND team_id IS NULL;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_teams_owner_membership
+AFTER INSERT OR UPDATE OF owner_id ON teams
+FOR EACH ROW EXECUTE FUNCTION sync_team_owner_membership();
+
+-- The team the session works in, the access tokens of the session carry it
+ALTER TABLE sessions ADD COLUMN team_id UUID REFERENCES teams(id) ON DELETE SET NULL;
+
+UPDATE sessions SET team_id = users.team_id FROM users WHERE users.id = sessions.user_id;
+
+-- The users of a team are its members, wherever their default team is
+GRANT SELECT ON team_memberships TO app_tenant;
+
+DROP POLICY users_team_isolation ON users;
+
+CREATE POLICY users_team_isolation ON users TO app_tenant
+    USING (id IN (SELECT user_id FROM team_memberships WHERE team_id = app_current_team_id()));
+
+-- +goose Down
+DROP POLICY users_team_isolation ON users;
+
+CREATE POLICY users_team_isolation ON users TO app_tenant
+    USING (
+        team_id = app_current_team_id()
+        OR id IN (SELECT owner_id FROM teams WHERE id = app_current_team_id())
+    );
+
+REVOKE ALL ON team_memberships FROM app_tenant;
+
+ALTER TABLE sessions DROP COLUMN team_id;
+
+DROP TRIGGER trg_teams_owner_membership ON teams;
+DROP FUNCTION sync_team_owner_membership();
+DROP TRIGGER trg_users_default_team_membership ON users;
+DROP FUNCTION sync_default_team_membership();
+
+-- Admins went back to not belonging to the teams they own
+UPDATE users SET team_id = NULL
+WHERE role = 'Admin' AND team_id IN (SELECT id FROM teams WHERE teams.owner_id = users.id);
+
+DROP TABLE team_memberships;
@@ -0,0 +1,41 @@
+-- +goose Up
+-- Organizations own teams, their admins manage every team of the organization. require_mfa is required
+-- by all its teams
+CREATE TABLE organizations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    name TEXT NOT NULL,
+    require_mfa BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE organization_admins (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (organization_id, user_id)
+);
+
+CREATE INDEX idx_organization_admins_user_id ON organization_admins(user_id);
+
+ALTER TABLE teams ADD COLUMN organization_id UUID REFERENCES organizations(id);
+
+-- Every team is wrapped in an organization of its own, with the id and the name of the team, and its
+-- owner administers it
+INSERT INTO organizations (id, created_at, updated_at, name, require_mfa)
+SELECT id, created_at, updated_at, name, require_mfa FROM teams;
+
+UPDATE teams SET organization_id = id;
+
+INSERT INTO organization_admins (organization_id, user_id, created_at)
+SELECT id, owner_id, created_at FROM teams;
+
+ALTER TABLE teams ALTER COLUMN organization_id SET NOT NULL;
+
+CREATE INDEX idx_teams_organization_id ON teams(organization_id);
+
+-- +goose Down
+ALTER TABLE teams DROP COLUMN organization_id;
+
+DROP TABLE organization_admins;
+DROP TABLE organizations;
@@ -0,0 +1,21 @@
+-- +goose Up
+-- Users are deactivated instead of deleted, they can't log in or use their tokens until they are
+-- reactivated
+ALTER TABLE users ADD COLUMN deactivated_at TIMESTAMP;
+
+-- Deleting the owner of a team or the manager of a project used to delete the team or the project with
+-- them, they have to be handed over to other users first
+ALTER TABLE teams DROP CONSTRAINT teams_owner_id_fkey;
+ALTER TABLE teams ADD CONSTRAINT teams_owner_id_fkey FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE RESTRICT;
+
+ALTER TABLE projects DROP CONSTRAINT projects_manager_id_fkey;
+ALTER TABLE projects ADD CONSTRAINT projects_manager_id_fkey FOREIGN KEY (manager_id) REFERENCES users(id) ON DELETE RESTRICT;
+
+-- +goose Down
+ALTER TABLE projects DROP CONSTRAINT projects_manager_id_fkey;
+ALTER TABLE projects ADD CONSTRAINT projects_manager_id_fkey FOREIGN KEY (manager_id) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE teams DROP CONSTRAINT teams_owner_id_fkey;
+ALTER TABLE teams ADD CONSTRAINT teams_owner_id_fkey FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE users DROP COLUMN deactivated_at;
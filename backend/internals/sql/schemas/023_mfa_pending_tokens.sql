@@ -0,0 +1,14 @@
+-- +goose Up
+-- Mfa tokens issued by the login, each one can only be exchanged for a session once
+CREATE TABLE mfa_pending_tokens (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP
+);
+
+CREATE INDEX idx_mfa_pending_tokens_user_id ON mfa_pending_tokens(user_id);
+
+-- +goose Down
+DROP TABLE mfa_pending_tokens;
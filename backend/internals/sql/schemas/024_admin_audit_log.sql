@@ -0,0 +1,115 @@
+-- +goose Up
+-- Every project and task an admin creates, updates or deletes. Rows are only ever inserted, by the
+-- triggers below, so an admin can't hide a change by making another one
+CREATE TABLE admin_audit_log (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at TIMESTAMP NOT NULL,
+    team_id UUID NOT NULL,
+    actor_id UUID NOT NULL,
+    entity_type TEXT NOT NULL,
+    entity_id UUID NOT NULL,
+    action TEXT NOT NULL
+);
+
+CREATE INDEX idx_admin_audit_log_team_id_created_at ON admin_audit_log(team_id, created_at);
+CREATE INDEX idx_admin_audit_log_entity ON admin_audit_log(entity_type, entity_id);
+
+-- The admin the last change of each project and task was recorded for becomes its first row
+INSERT INTO admin_audit_log (created_at, team_id, actor_id, entity_type, entity_id, action)
+SELECT updated_at, team_id, acting_admin_id, 'project', id, 'update'
+FROM projects
+WHERE acting_admin_id IS NOT NULL;
+
+INSERT INTO admin_audit_log (created_at, team_id, actor_id, entity_type, entity_id, action)
+SELECT tasks.updated_at, projects.team_id, tasks.acting_admin_id, 'task', tasks.id, 'update'
+FROM tasks
+INNER JOIN projects ON projects.id = tasks.project_id
+WHERE tasks.acting_admin_id IS NOT NULL;
+
+ALTER TABLE tasks DROP COLUMN acting_admin_id;
+ALTER TABLE projects DROP COLUMN acting_admin_id;
+
+-- +goose StatementBegin
+CREATE FUNCTION prevent_admin_audit_log_changes() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'admin_audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_admin_audit_log_append_only
+BEFORE UPDATE OR DELETE ON admin_audit_log
+FOR EACH ROW EXECUTE FUNCTION prevent_admin_audit_log_changes();
+
+CREATE TRIGGER trg_admin_audit_log_no_truncate
+BEFORE TRUNCATE ON admin_audit_log
+FOR EACH STATEMENT EXECUTE FUNCTION prevent_admin_audit_log_changes();
+
+-- The admin making the request, the repositories set app.acting_admin_id next to app.current_team_id.
+-- NULL for every other role, whose changes aren't recorded
+-- +goose StatementBegin
+CREATE FUNCTION app_acting_admin_id() RETURNS UUID AS $$
+    SELECT NULLIF(current_setting('app.acting_admin_id', true), '')::UUID;
+$$ LANGUAGE sql STABLE;
+-- +goose StatementEnd
+
+-- Runs in the transaction of the change, the row is written with it or not at all. The tasks deleted
+-- along with a project are recorded too
+-- +goose StatementBegin
+CREATE FUNCTION record_admin_action() RETURNS TRIGGER AS $$
+DECLARE
+    changed_id UUID;
+    change TEXT;
+BEGIN
+    IF app_acting_admin_id() IS NULL THEN
+        RETURN NULL;
+    END IF;
+
+    IF TG_OP = 'DELETE' THEN
+        changed_id := OLD.id;
+        change := 'delete';
+    ELSIF TG_OP = 'INSERT' THEN
+        changed_id := NEW.id;
+        change := 'create';
+    ELSE
+        changed_id := NEW.id;
+        change := 'update';
+    END IF;
+
+    INSERT INTO admin_audit_log (created_at, team_id, actor_id, entity_type, entity_id, action)
+    VALUES (now() AT TIME ZONE 'UTC', app_current_team_id(), app_acting_admin_id(), TG_ARGV[0], changed_id, change);
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_projects_admin_audit
+AFTER INSERT OR UPDATE OR DELETE ON projects
+FOR EACH ROW EXECUTE FUNCTION record_admin_action('project');
+
+CREATE TRIGGER trg_tasks_admin_audit
+AFTER INSERT OR UPDATE OR DELETE ON tasks
+FOR EACH ROW EXECUTE FUNCTION record_admin_action('task');
+
+-- app_tenant reads and adds the rows of its team, it can't change them
+GRANT SELECT, INSERT ON admin_audit_log TO app_tenant;
+
+ALTER TABLE admin_audit_log ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY admin_audit_log_team_isolation ON admin_audit_log TO app_tenant
+    USING (team_id = app_current_team_id())
+    WITH CHECK (team_id = app_current_team_id());
+
+-- +goose Down
+DROP TRIGGER trg_tasks_admin_audit ON tasks;
+DROP TRIGGER trg_projects_admin_audit ON projects;
+DROP FUNCTION record_admin_action();
+DROP FUNCTION app_acting_admin_id();
+
+ALTER TABLE projects ADD COLUMN acting_admin_id UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE tasks ADD COLUMN acting_admin_id UUID REFERENCES users(id) ON DELETE SET NULL;
+
+REVOKE ALL ON admin_audit_log FROM app_tenant;
+DROP TABLE admin_audit_log;
+DROP FUNCTION prevent_admin_audit_log_changes();
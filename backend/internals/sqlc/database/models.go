@@ -13,6 +13,49 @@ import (
 	"github.com/google/uuid"
 )
 
+type Projectmemberroles string
+
+const (
+	ProjectmemberrolesOwner       Projectmemberroles = "Owner"
+	ProjectmemberrolesContributor Projectmemberroles = "Contributor"
+	ProjectmemberrolesViewer      Projectmemberroles = "Viewer"
+)
+
+func (e *Projectmemberroles) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = Projectmemberroles(s)
+	case string:
+		*e = Projectmemberroles(s)
+	default:
+		return fmt.Errorf("unsupported scan type for Projectmemberroles: %T", src)
+	}
+	return nil
+}
+
+type NullProjectmemberroles struct {
+	Projectmemberroles Projectmemberroles
+	Valid              bool // Valid is true if Projectmemberroles is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullProjectmemberroles) Scan(value interface{}) error {
+	if value == nil {
+		ns.Projectmemberroles, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.Projectmemberroles.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullProjectmemberroles) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.Projectmemberroles), nil
+}
+
 type Projectstatus string
 
 const (
@@ -142,6 +185,87 @@ func (ns NullUserroles) Value() (driver.Value, error) {
 	return string(ns.Userroles), nil
 }
 
+type AdminAuditLog struct {
+	ID         uuid.UUID
+	CreatedAt  time.Time
+	TeamID     uuid.UUID
+	ActorID    uuid.UUID
+	EntityType string
+	EntityID   uuid.UUID
+	Action     string
+}
+
+type Invitation struct {
+	ID         uuid.UUID
+	TeamID     uuid.UUID
+	Email      string
+	Role       Userroles
+	TokenID    uuid.UUID
+	InvitedBy  uuid.UUID
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	AcceptedAt sql.NullTime
+}
+
+type LoginAttempt struct {
+	Scope        string
+	Identifier   string
+	FailedCount  int32
+	LastFailedAt time.Time
+}
+
+type MfaPendingToken struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UsedAt    sql.NullTime
+}
+
+type MfaRecoveryCode struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	CodeHash  string
+	CreatedAt time.Time
+	UsedAt    sql.NullTime
+}
+
+type Organization struct {
+	ID         uuid.UUID
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	Name       string
+	RequireMfa bool
+}
+
+type OrganizationAdmin struct {
+	OrganizationID uuid.UUID
+	UserID         uuid.UUID
+	CreatedAt      time.Time
+}
+
+type PasswordResetToken struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	TokenHash string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UsedAt    sql.NullTime
+}
+
+type PersonalAccessToken struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Name        string
+	TokenHash   string
+	TokenPrefix string
+	Scopes      []string
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+	LastUsedAt  sql.NullTime
+}
+
 type Project struct {
 	ID        uuid.UUID
 	CreatedAt time.Time
@@ -152,6 +276,14 @@ type Project struct {
 	Status    Projectstatus
 }
 
+type ProjectMember struct {
+	ProjectID uuid.UUID
+	UserID    uuid.UUID
+	Role      Projectmemberroles
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
 type RefreshToken struct {
 	ID        uuid.UUID
 	Userid    uuid.UUID
@@ -159,6 +291,35 @@ type RefreshToken struct {
 	ExpiresAt time.Time
 	CreatedAt time.Time
 	Revoked   bool
+	FamilyID  uuid.UUID
+	UsedAt    sql.NullTime
+}
+
+type RevokedSession struct {
+	SessionID uuid.UUID
+	UserID    uuid.UUID
+	RevokedAt time.Time
+}
+
+type Role struct {
+	ID          uuid.UUID
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	TeamID      uuid.NullUUID
+	Name        string
+	Permissions []string
+	BaseRole    NullUserroles
+}
+
+type Session struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Label      string
+	UserAgent  string
+	IpAddress  string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	TeamID     uuid.NullUUID
 }
 
 type Task struct {
@@ -173,20 +334,51 @@ type Task struct {
 }
 
 type Team struct {
-	ID        uuid.UUID
+	ID             uuid.UUID
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Name           string
+	OwnerID        uuid.UUID
+	RequireMfa     bool
+	OrganizationID uuid.UUID
+}
+
+type TeamMembership struct {
+	TeamID    uuid.UUID
+	UserID    uuid.UUID
+	Role      Userroles
+	RoleID    uuid.NullUUID
 	CreatedAt time.Time
 	UpdatedAt time.Time
-	Name      string
-	OwnerID   uuid.UUID
 }
 
 type User struct {
+	ID            uuid.UUID
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	Username      string
+	Password      string
+	Email         string
+	Role          Userroles
+	TeamID        uuid.NullUUID
+	RoleID        uuid.UUID
+	DeactivatedAt sql.NullTime
+}
+
+type UserIdentity struct {
 	ID        uuid.UUID
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	Username  string
-	Password  string
+	UserID    uuid.UUID
+	Issuer    string
+	Subject   string
 	Email     string
-	Role      Userroles
-	TeamID    uuid.NullUUID
+	CreatedAt time.Time
+}
+
+type UserTotp struct {
+	UserID       uuid.UUID
+	Secret       string
+	EnabledAt    sql.NullTime
+	LastUsedStep int64
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
@@ -7,14 +7,15 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 const createRefreshToken = `-- name: CreateRefreshToken :exec
-INSERT INTO refresh_tokens (id, userId, token, expires_at, created_at, revoked)
-VALUES($1, $2, $3, $4, $5, $6)
+INSERT INTO refresh_tokens (id, userId, token, expires_at, created_at, revoked, family_id)
+VALUES($1, $2, $3, $4, $5, $6, $7)
 `
 
 type CreateRefreshTokenParams struct {
@@ -24,6 +25,7 @@ type CreateRefreshTokenParams struct {
 	ExpiresAt time.Time
 	CreatedAt time.Time
 	Revoked   bool
+	FamilyID  uuid.UUID
 }
 
 func (q *Queries) CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) error {
@@ -34,6 +36,7 @@ func (q *Queries) CreateRefreshToken(ctx context.Context, arg CreateRefreshToken
 		arg.ExpiresAt,
 		arg.CreatedAt,
 		arg.Revoked,
+		arg.FamilyID,
 	)
 	return err
 }
@@ -49,14 +52,15 @@ func (q *Queries) DeleteRefreshTokensByUserId(ctx context.Context, userid uuid.U
 }
 
 const getRefreshTokenByToken = `-- name: GetRefreshTokenByToken :one
-SELECT refresh_tokens.id, refresh_tokens.userid, refresh_tokens.token, refresh_tokens.expires_at, refresh_tokens.created_at, refresh_tokens.revoked,
+SELECT refresh_tokens.id, refresh_tokens.userid, refresh_tokens.token, refresh_tokens.expires_at, refresh_tokens.created_at, refresh_tokens.revoked, refresh_tokens.family_id, refresh_tokens.used_at,
 users.id AS userDataId,
 users.created_at AS userDataCreatedAt,
 users.updated_at AS userDataUpdatedAt,
 users.username AS userDataUsername,
 users.password AS userDataPassword,
 users.email AS userDataEmail,
-users.role AS userDataRole
+users.role AS userDataRole,
+users.role_id AS userDataRoleId
 FROM refresh_tokens
 JOIN users ON refresh_tokens.userId = users.id
 WHERE token = $1
@@ -70,6 +74,8 @@ type GetRefreshTokenByTokenRow struct {
 	ExpiresAt         time.Time
 	CreatedAt         time.Time
 	Revoked           bool
+	FamilyID          uuid.UUID
+	UsedAt            sql.NullTime
 	Userdataid        uuid.UUID
 	Userdatacreatedat time.Time
 	Userdataupdatedat time.Time
@@ -77,6 +83,7 @@ type GetRefreshTokenByTokenRow struct {
 	Userdatapassword  string
 	Userdataemail     string
 	Userdatarole      Userroles
+	Userdataroleid    uuid.UUID
 }
 
 func (q *Queries) GetRefreshTokenByToken(ctx context.Context, token string) (GetRefreshTokenByTokenRow, error) {
@@ -89,6 +96,8 @@ func (q *Queries) GetRefreshTokenByToken(ctx context.Context, token string) (Get
 		&i.ExpiresAt,
 		&i.CreatedAt,
 		&i.Revoked,
+		&i.FamilyID,
+		&i.UsedAt,
 		&i.Userdataid,
 		&i.Userdatacreatedat,
 		&i.Userdataupdatedat,
@@ -96,10 +105,41 @@ func (q *Queries) GetRefreshTokenByToken(ctx context.Context, token string) (Get
 		&i.Userdatapassword,
 		&i.Userdataemail,
 		&i.Userdatarole,
+		&i.Userdataroleid,
 	)
 	return i, err
 }
 
+const markRefreshTokenUsed = `-- name: MarkRefreshTokenUsed :execrows
+UPDATE refresh_tokens
+SET used_at = $1
+WHERE id = $2 AND used_at IS NULL AND revoked = false
+`
+
+type MarkRefreshTokenUsedParams struct {
+	UsedAt sql.NullTime
+	ID     uuid.UUID
+}
+
+func (q *Queries) MarkRefreshTokenUsed(ctx context.Context, arg MarkRefreshTokenUsedParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markRefreshTokenUsed, arg.UsedAt, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const revokeRefreshTokenFamily = `-- name: RevokeRefreshTokenFamily :exec
+UPDATE refresh_tokens
+SET revoked = true
+WHERE family_id = $1
+`
+
+func (q *Queries) RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, revokeRefreshTokenFamily, familyID)
+	return err
+}
+
 const updaterefreshToken = `-- name: UpdaterefreshToken :exec
 UPDATE refresh_tokens
 SET token = $1, expires_at = $2, created_at = $3, revoked = $4
@@ -13,16 +13,18 @@ import (
 )
 
 const createTeam = `-- name: CreateTeam :one
-INSERT INTO teams (created_at, updated_at, name, owner_id)
-VALUES($1, $2, $3, $4)
-RETURNING id, created_at, updated_at, name, owner_id
+INSERT INTO teams (created_at, updated_at, name, owner_id, organization_id, require_mfa)
+VALUES($1, $2, $3, $4, $5, $6)
+RETURNING id, created_at, updated_at, name, owner_id, require_mfa, organization_id
 `
 
 type CreateTeamParams struct {
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	Name      string
-	OwnerID   uuid.UUID
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Name           string
+	OwnerID        uuid.UUID
+	OrganizationID uuid.UUID
+	RequireMfa     bool
 }
 
 func (q *Queries) CreateTeam(ctx context.Context, arg CreateTeamParams) (Team, error) {
@@ -31,7 +33,51 @@ func (q *Queries) CreateTeam(ctx context.Context, arg CreateTeamParams) (Team, e
 		arg.UpdatedAt,
 		arg.Name,
 		arg.OwnerID,
+		arg.OrganizationID,
+		arg.RequireMfa,
+	)
+	var i Team
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.OwnerID,
+		&i.RequireMfa,
+		&i.OrganizationID,
 	)
+	return i, err
+}
+
+const deleteTeam = `-- name: DeleteTeam :exec
+DELETE FROM teams
+WHERE id = $1
+`
+
+func (q *Queries) DeleteTeam(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteTeam, id)
+	return err
+}
+
+const deleteTeamSessions = `-- name: DeleteTeamSessions :exec
+DELETE FROM sessions
+WHERE team_id = $1
+AND user_id <> (SELECT owner_id FROM teams WHERE teams.id = $1)
+`
+
+func (q *Queries) DeleteTeamSessions(ctx context.Context, teamID uuid.NullUUID) error {
+	_, err := q.db.ExecContext(ctx, deleteTeamSessions, teamID)
+	return err
+}
+
+const getTeamById = `-- name: GetTeamById :one
+SELECT id, created_at, updated_at, name, owner_id, require_mfa, organization_id FROM teams
+WHERE id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetTeamById(ctx context.Context, id uuid.UUID) (Team, error) {
+	row := q.db.QueryRowContext(ctx, getTeamById, id)
 	var i Team
 	err := row.Scan(
 		&i.ID,
@@ -39,13 +85,16 @@ func (q *Queries) CreateTeam(ctx context.Context, arg CreateTeamParams) (Team, e
 		&i.UpdatedAt,
 		&i.Name,
 		&i.OwnerID,
+		&i.RequireMfa,
+		&i.OrganizationID,
 	)
 	return i, err
 }
 
 const getTeamByOwner = `-- name: GetTeamByOwner :one
-SELECT id, created_at, updated_at, name, owner_id FROM teams
+SELECT id, created_at, updated_at, name, owner_id, require_mfa, organization_id FROM teams
 WHERE owner_id = $1
+ORDER BY created_at
 LIMIT 1
 `
 
@@ -58,6 +107,122 @@ func (q *Queries) GetTeamByOwner(ctx context.Context, ownerID uuid.UUID) (Team,
 		&i.UpdatedAt,
 		&i.Name,
 		&i.OwnerID,
+		&i.RequireMfa,
+		&i.OrganizationID,
+	)
+	return i, err
+}
+
+const getTeamDeletionReport = `-- name: GetTeamDeletionReport :one
+SELECT
+    (SELECT COUNT(*) FROM projects WHERE projects.team_id = $1) AS projects,
+    (SELECT COUNT(*) FROM tasks WHERE tasks.project_id IN (SELECT id FROM projects WHERE projects.team_id = $1)) AS tasks,
+    (SELECT COUNT(*) FROM team_memberships WHERE team_memberships.team_id = $1) AS users,
+    (SELECT COUNT(*) FROM invitations WHERE invitations.team_id = $1) AS invitations,
+    (SELECT COUNT(*) FROM roles WHERE roles.team_id = $1) AS roles
+`
+
+type GetTeamDeletionReportRow struct {
+	Projects    int64
+	Tasks       int64
+	Users       int64
+	Invitations int64
+	Roles       int64
+}
+
+func (q *Queries) GetTeamDeletionReport(ctx context.Context, teamID uuid.UUID) (GetTeamDeletionReportRow, error) {
+	row := q.db.QueryRowContext(ctx, getTeamDeletionReport, teamID)
+	var i GetTeamDeletionReportRow
+	err := row.Scan(
+		&i.Projects,
+		&i.Tasks,
+		&i.Users,
+		&i.Invitations,
+		&i.Roles,
+	)
+	return i, err
+}
+
+const updateTeamName = `-- name: UpdateTeamName :one
+UPDATE teams
+SET name = $1, updated_at = $2
+WHERE id = $3
+RETURNING id, created_at, updated_at, name, owner_id, require_mfa, organization_id
+`
+
+type UpdateTeamNameParams struct {
+	Name      string
+	UpdatedAt time.Time
+	ID        uuid.UUID
+}
+
+func (q *Queries) UpdateTeamName(ctx context.Context, arg UpdateTeamNameParams) (Team, error) {
+	row := q.db.QueryRowContext(ctx, updateTeamName, arg.Name, arg.UpdatedAt, arg.ID)
+	var i Team
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.OwnerID,
+		&i.RequireMfa,
+		&i.OrganizationID,
+	)
+	return i, err
+}
+
+const updateTeamOwner = `-- name: UpdateTeamOwner :one
+UPDATE teams
+SET owner_id = $1, updated_at = $2
+WHERE id = $3
+RETURNING id, created_at, updated_at, name, owner_id, require_mfa, organization_id
+`
+
+type UpdateTeamOwnerParams struct {
+	OwnerID   uuid.UUID
+	UpdatedAt time.Time
+	ID        uuid.UUID
+}
+
+func (q *Queries) UpdateTeamOwner(ctx context.Context, arg UpdateTeamOwnerParams) (Team, error) {
+	row := q.db.QueryRowContext(ctx, updateTeamOwner, arg.OwnerID, arg.UpdatedAt, arg.ID)
+	var i Team
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.OwnerID,
+		&i.RequireMfa,
+		&i.OrganizationID,
+	)
+	return i, err
+}
+
+const updateTeamRequireMfa = `-- name: UpdateTeamRequireMfa :one
+UPDATE teams
+SET require_mfa = $1, updated_at = $2
+WHERE id = $3
+RETURNING id, created_at, updated_at, name, owner_id, require_mfa, organization_id
+`
+
+type UpdateTeamRequireMfaParams struct {
+	RequireMfa bool
+	UpdatedAt  time.Time
+	ID         uuid.UUID
+}
+
+func (q *Queries) UpdateTeamRequireMfa(ctx context.Context, arg UpdateTeamRequireMfaParams) (Team, error) {
+	row := q.db.QueryRowContext(ctx, updateTeamRequireMfa, arg.RequireMfa, arg.UpdatedAt, arg.ID)
+	var i Team
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.OwnerID,
+		&i.RequireMfa,
+		&i.OrganizationID,
 	)
 	return i, err
 }
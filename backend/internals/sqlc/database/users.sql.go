@@ -7,15 +7,111 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+const addUserProjectsOwner = `-- name: AddUserProjectsOwner :exec
+INSERT INTO project_members (project_id, user_id, role, created_at, updated_at)
+SELECT id, $1, 'Owner', $2, $2 FROM projects
+WHERE manager_id = $3 AND team_id = ANY($4::uuid[])
+ON CONFLICT (project_id, user_id) DO UPDATE
+SET role = 'Owner', updated_at = EXCLUDED.updated_at
+`
+
+type AddUserProjectsOwnerParams struct {
+	NewManagerID uuid.UUID
+	UpdatedAt    time.Time
+	ManagerID    uuid.UUID
+	TeamIds      []uuid.UUID
+}
+
+func (q *Queries) AddUserProjectsOwner(ctx context.Context, arg AddUserProjectsOwnerParams) error {
+	_, err := q.db.ExecContext(ctx, addUserProjectsOwner,
+		arg.NewManagerID,
+		arg.UpdatedAt,
+		arg.ManagerID,
+		pq.Array(arg.TeamIds),
+	)
+	return err
+}
+
+const addUserTasksContributor = `-- name: AddUserTasksContributor :exec
+INSERT INTO project_members (project_id, user_id, role, created_at, updated_at)
+SELECT DISTINCT project_id, $1, 'Contributor', $2, $2 FROM tasks
+WHERE user_id = $3 AND status <> 'Done'
+    AND project_id IN (SELECT id FROM projects WHERE team_id = ANY($4::uuid[]))
+ON CONFLICT (project_id, user_id) DO UPDATE
+SET role = 'Contributor', updated_at = EXCLUDED.updated_at
+WHERE project_members.role = 'Viewer'
+`
+
+type AddUserTasksContributorParams struct {
+	NewUserID uuid.UUID
+	UpdatedAt time.Time
+	UserID    uuid.NullUUID
+	TeamIds   []uuid.UUID
+}
+
+func (q *Queries) AddUserTasksContributor(ctx context.Context, arg AddUserTasksContributorParams) error {
+	_, err := q.db.ExecContext(ctx, addUserTasksContributor,
+		arg.NewUserID,
+		arg.UpdatedAt,
+		arg.UserID,
+		pq.Array(arg.TeamIds),
+	)
+	return err
+}
+
+const adminExists = `-- name: AdminExists :one
+SELECT EXISTS(SELECT 1 FROM users WHERE role = 'Admin')
+`
+
+func (q *Queries) AdminExists(ctx context.Context) (bool, error) {
+	row := q.db.QueryRowContext(ctx, adminExists)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const assignUserRole = `-- name: AssignUserRole :one
+UPDATE users
+SET role_id = $1, updated_at = $2
+WHERE id = $3
+RETURNING id, created_at, updated_at, username, password, email, role, team_id, role_id, deactivated_at
+`
+
+type AssignUserRoleParams struct {
+	RoleID    uuid.UUID
+	UpdatedAt time.Time
+	ID        uuid.UUID
+}
+
+func (q *Queries) AssignUserRole(ctx context.Context, arg AssignUserRoleParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, assignUserRole, arg.RoleID, arg.UpdatedAt, arg.ID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Username,
+		&i.Password,
+		&i.Email,
+		&i.Role,
+		&i.TeamID,
+		&i.RoleID,
+		&i.DeactivatedAt,
+	)
+	return i, err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (created_at, updated_at, username, password, email, role, team_id)
 VALUES($1, $2, $3, $4, $5, $6, $7)
-RETURNING id, created_at, updated_at, username, password, email, role, team_id
+RETURNING id, created_at, updated_at, username, password, email, role, team_id, role_id, deactivated_at
 `
 
 type CreateUserParams struct {
@@ -48,10 +144,29 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Email,
 		&i.Role,
 		&i.TeamID,
+		&i.RoleID,
+		&i.DeactivatedAt,
 	)
 	return i, err
 }
 
+const deactivateUser = `-- name: DeactivateUser :exec
+UPDATE users
+SET deactivated_at = $1, updated_at = $2
+WHERE id = $3
+`
+
+type DeactivateUserParams struct {
+	DeactivatedAt sql.NullTime
+	UpdatedAt     time.Time
+	ID            uuid.UUID
+}
+
+func (q *Queries) DeactivateUser(ctx context.Context, arg DeactivateUserParams) error {
+	_, err := q.db.ExecContext(ctx, deactivateUser, arg.DeactivatedAt, arg.UpdatedAt, arg.ID)
+	return err
+}
+
 const deleteUser = `-- name: DeleteUser :exec
 DELETE FROM users WHERE id = $1
 `
@@ -62,7 +177,7 @@ func (q *Queries) DeleteUser(ctx context.Context, id uuid.UUID) error {
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, created_at, updated_at, username, password, email, role, team_id FROM users
+SELECT id, created_at, updated_at, username, password, email, role, team_id, role_id, deactivated_at FROM users
 WHERE email = $1
 LIMIT 1
 `
@@ -79,12 +194,14 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.Email,
 		&i.Role,
 		&i.TeamID,
+		&i.RoleID,
+		&i.DeactivatedAt,
 	)
 	return i, err
 }
 
 const getUserById = `-- name: GetUserById :one
-SELECT id, created_at, updated_at, username, password, email, role, team_id FROM users
+SELECT id, created_at, updated_at, username, password, email, role, team_id, role_id, deactivated_at FROM users
 WHERE id = $1
 LIMIT 1
 `
@@ -101,15 +218,301 @@ func (q *Queries) GetUserById(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.Email,
 		&i.Role,
 		&i.TeamID,
+		&i.RoleID,
+		&i.DeactivatedAt,
 	)
 	return i, err
 }
 
+const getUserManagedProjects = `-- name: GetUserManagedProjects :many
+SELECT id, created_at, updated_at, name, team_id, manager_id, status FROM projects
+WHERE manager_id = $1 AND team_id = ANY($2::uuid[])
+ORDER BY created_at
+`
+
+type GetUserManagedProjectsParams struct {
+	ManagerID uuid.UUID
+	TeamIds   []uuid.UUID
+}
+
+func (q *Queries) GetUserManagedProjects(ctx context.Context, arg GetUserManagedProjectsParams) ([]Project, error) {
+	rows, err := q.db.QueryContext(ctx, getUserManagedProjects, arg.ManagerID, pq.Array(arg.TeamIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Project
+	for rows.Next() {
+		var i Project
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.TeamID,
+			&i.ManagerID,
+			&i.Status,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserOpenTasks = `-- name: GetUserOpenTasks :many
+SELECT tasks.id, tasks.created_at, tasks.updated_at, tasks.project_id, tasks.user_id, tasks.status, tasks.title, tasks.description, projects.team_id
+FROM tasks
+INNER JOIN projects ON projects.id = tasks.project_id
+WHERE tasks.user_id = $1 AND tasks.status <> 'Done' AND projects.team_id = ANY($2::uuid[])
+ORDER BY tasks.created_at
+`
+
+type GetUserOpenTasksParams struct {
+	UserID  uuid.NullUUID
+	TeamIds []uuid.UUID
+}
+
+type GetUserOpenTasksRow struct {
+	ID          uuid.UUID
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	ProjectID   uuid.UUID
+	UserID      uuid.NullUUID
+	Status      Taskstatus
+	Title       string
+	Description sql.NullString
+	TeamID      uuid.UUID
+}
+
+func (q *Queries) GetUserOpenTasks(ctx context.Context, arg GetUserOpenTasksParams) ([]GetUserOpenTasksRow, error) {
+	rows, err := q.db.QueryContext(ctx, getUserOpenTasks, arg.UserID, pq.Array(arg.TeamIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserOpenTasksRow
+	for rows.Next() {
+		var i GetUserOpenTasksRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ProjectID,
+			&i.UserID,
+			&i.Status,
+			&i.Title,
+			&i.Description,
+			&i.TeamID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserOwnedTeams = `-- name: GetUserOwnedTeams :many
+SELECT id, created_at, updated_at, name, owner_id, require_mfa, organization_id FROM teams
+WHERE owner_id = $1 AND id = ANY($2::uuid[])
+ORDER BY created_at
+`
+
+type GetUserOwnedTeamsParams struct {
+	OwnerID uuid.UUID
+	TeamIds []uuid.UUID
+}
+
+func (q *Queries) GetUserOwnedTeams(ctx context.Context, arg GetUserOwnedTeamsParams) ([]Team, error) {
+	rows, err := q.db.QueryContext(ctx, getUserOwnedTeams, arg.OwnerID, pq.Array(arg.TeamIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Team
+	for rows.Next() {
+		var i Team
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.OwnerID,
+			&i.RequireMfa,
+			&i.OrganizationID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserWorkTeamIds = `-- name: GetUserWorkTeamIds :many
+SELECT id FROM teams WHERE owner_id = $1
+UNION
+SELECT team_id FROM projects WHERE manager_id = $1
+UNION
+SELECT projects.team_id FROM tasks
+INNER JOIN projects ON projects.id = tasks.project_id
+WHERE tasks.user_id = $1 AND tasks.status <> 'Done'
+`
+
+func (q *Queries) GetUserWorkTeamIds(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, getUserWorkTeamIds, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockAdminBootstrap = `-- name: LockAdminBootstrap :exec
+SELECT pg_advisory_xact_lock(hashtext('admin_bootstrap'))
+`
+
+func (q *Queries) LockAdminBootstrap(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, lockAdminBootstrap)
+	return err
+}
+
+const reactivateUser = `-- name: ReactivateUser :one
+UPDATE users
+SET deactivated_at = NULL, updated_at = $1
+WHERE id = $2
+RETURNING id, created_at, updated_at, username, password, email, role, team_id, role_id, deactivated_at
+`
+
+type ReactivateUserParams struct {
+	UpdatedAt time.Time
+	ID        uuid.UUID
+}
+
+func (q *Queries) ReactivateUser(ctx context.Context, arg ReactivateUserParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, reactivateUser, arg.UpdatedAt, arg.ID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Username,
+		&i.Password,
+		&i.Email,
+		&i.Role,
+		&i.TeamID,
+		&i.RoleID,
+		&i.DeactivatedAt,
+	)
+	return i, err
+}
+
+const reassignUserTasks = `-- name: ReassignUserTasks :exec
+UPDATE tasks
+SET user_id = $1, updated_at = $2
+WHERE user_id = $3 AND status <> 'Done'
+    AND project_id IN (SELECT id FROM projects WHERE team_id = ANY($4::uuid[]))
+`
+
+type ReassignUserTasksParams struct {
+	NewUserID uuid.NullUUID
+	UpdatedAt time.Time
+	UserID    uuid.NullUUID
+	TeamIds   []uuid.UUID
+}
+
+func (q *Queries) ReassignUserTasks(ctx context.Context, arg ReassignUserTasksParams) error {
+	_, err := q.db.ExecContext(ctx, reassignUserTasks,
+		arg.NewUserID,
+		arg.UpdatedAt,
+		arg.UserID,
+		pq.Array(arg.TeamIds),
+	)
+	return err
+}
+
+const transferUserProjects = `-- name: TransferUserProjects :exec
+UPDATE projects
+SET manager_id = $1, updated_at = $2
+WHERE manager_id = $3 AND team_id = ANY($4::uuid[])
+`
+
+type TransferUserProjectsParams struct {
+	NewManagerID uuid.UUID
+	UpdatedAt    time.Time
+	ManagerID    uuid.UUID
+	TeamIds      []uuid.UUID
+}
+
+func (q *Queries) TransferUserProjects(ctx context.Context, arg TransferUserProjectsParams) error {
+	_, err := q.db.ExecContext(ctx, transferUserProjects,
+		arg.NewManagerID,
+		arg.UpdatedAt,
+		arg.ManagerID,
+		pq.Array(arg.TeamIds),
+	)
+	return err
+}
+
+const transferUserTeams = `-- name: TransferUserTeams :exec
+UPDATE teams
+SET owner_id = $1, updated_at = $2
+WHERE owner_id = $3 AND id = ANY($4::uuid[])
+`
+
+type TransferUserTeamsParams struct {
+	NewOwnerID uuid.UUID
+	UpdatedAt  time.Time
+	OwnerID    uuid.UUID
+	TeamIds    []uuid.UUID
+}
+
+func (q *Queries) TransferUserTeams(ctx context.Context, arg TransferUserTeamsParams) error {
+	_, err := q.db.ExecContext(ctx, transferUserTeams,
+		arg.NewOwnerID,
+		arg.UpdatedAt,
+		arg.OwnerID,
+		pq.Array(arg.TeamIds),
+	)
+	return err
+}
+
 const updateUser = `-- name: UpdateUser :one
 UPDATE users
 SET username = $1, email = $2, updated_at = $3
 WHERE id = $4
-RETURNING id, created_at, updated_at, username, password, email, role, team_id
+RETURNING id, created_at, updated_at, username, password, email, role, team_id, role_id, deactivated_at
 `
 
 type UpdateUserParams struct {
@@ -136,6 +539,56 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.Email,
 		&i.Role,
 		&i.TeamID,
+		&i.RoleID,
+		&i.DeactivatedAt,
+	)
+	return i, err
+}
+
+const updateUserPassword = `-- name: UpdateUserPassword :exec
+UPDATE users
+SET password = $1, updated_at = $2
+WHERE id = $3
+`
+
+type UpdateUserPasswordParams struct {
+	Password  string
+	UpdatedAt time.Time
+	ID        uuid.UUID
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserPassword, arg.Password, arg.UpdatedAt, arg.ID)
+	return err
+}
+
+const updateUserRole = `-- name: UpdateUserRole :one
+UPDATE users
+SET role = $1, updated_at = $2
+WHERE id = $3
+RETURNING id, created_at, updated_at, username, password, email, role, team_id, role_id, deactivated_at
+`
+
+type UpdateUserRoleParams struct {
+	Role      Userroles
+	UpdatedAt time.Time
+	ID        uuid.UUID
+}
+
+func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserRole, arg.Role, arg.UpdatedAt, arg.ID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Username,
+		&i.Password,
+		&i.Email,
+		&i.Role,
+		&i.TeamID,
+		&i.RoleID,
+		&i.DeactivatedAt,
 	)
 	return i, err
 }
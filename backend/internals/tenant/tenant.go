@@ -0,0 +1,70 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
+)
+
+// ErrUnscoped is a request that reached the repositories without being scoped to a team
+var ErrUnscoped = errors.New("request isn't scoped to a team")
+
+// teamKey is where the team of a request is kept: a context value, or the fiber locals of the request
+// since c.Context() reads them as values
+type teamKey struct{}
+
+// WithTeam scopes the context to a team
+func WithTeam(ctx context.Context, teamId uuid.UUID) context.Context {
+	return context.WithValue(ctx, teamKey{}, teamId)
+}
+
+// SetTeam scopes the request to a team, the repositories called with c.Context() only reach its rows
+// from then on
+func SetTeam(c *fiber.Ctx, teamId uuid.UUID) {
+	c.Locals(teamKey{}, teamId)
+}
+
+// TeamID returns the team the context is scoped to, false when it isn't. uuid.Nil is a user without a
+// team, which reaches no team's rows
+func TeamID(ctx context.Context) (uuid.UUID, bool) {
+	teamId, ok := ctx.Value(teamKey{}).(uuid.UUID)
+
+	return teamId, ok
+}
+
+// UnscopedRequest reports whether ctx is the c.Context() of a request that wasn't scoped to a team. The
+// auth middleware scopes every authenticated request, so one that isn't scoped missed it
+func UnscopedRequest(ctx context.Context) bool {
+	if _, scoped := TeamID(ctx); scoped {
+		return false
+	}
+
+	_, isRequest := ctx.(*fasthttp.RequestCtx)
+
+	return isRequest
+}
+
+// actingAdminKey is where the admin making the request is kept, like teamKey
+type actingAdminKey struct{}
+
+// WithActingAdmin records the changes made with the context to projects and tasks as made by the admin
+func WithActingAdmin(ctx context.Context, adminId uuid.UUID) context.Context {
+	return context.WithValue(ctx, actingAdminKey{}, adminId)
+}
+
+// SetActingAdmin records the changes the request makes to projects and tasks in the admin audit log as
+// made by the admin. It only applies to the queries scoped to a team
+func SetActingAdmin(c *fiber.Ctx, adminId uuid.UUID) {
+	c.Locals(actingAdminKey{}, adminId)
+}
+
+// ActingAdminID returns the admin the changes made with the context are recorded for, false when they
+// aren't recorded
+func ActingAdminID(ctx context.Context) (uuid.UUID, bool) {
+	adminId, ok := ctx.Value(actingAdminKey{}).(uuid.UUID)
+
+	return adminId, ok
+}
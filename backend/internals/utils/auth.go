@@ -1,31 +1,92 @@
 package utils
 
 import (
-	"os"
+	"errors"
 	"time"
 
 	"github.com/TobiasRV/challenge-fs-senior/internals/models"
-	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
+const (
+	AccessTokenType     = "access"
+	RefreshTokenType    = "refresh"
+	InvitationTokenType = "invitation"
+	MfaPendingTokenType = "mfa_pending"
+	CSRFTokenType       = "csrf"
+)
+
+// AccessTokenTTL is how long an access token works, a revoked session has to be denied for as long
+const AccessTokenTTL = time.Minute * 15
+
+var (
+	ErrInvalidTokenType   = errors.New("invalid token type")
+	ErrInvalidTokenClaims = errors.New("invalid token claims")
+)
+
+var (
+	jwtIssuer   = "challenge-fs-senior"
+	jwtAudience = "challenge-fs-senior-api"
+)
+
+// CustomerClaims are the claims of every token issued by the api, Type tells access, refresh, invitation and mfa pending tokens apart
 type CustomerClaims struct {
-	UserId string `json:"userId"`
-	Role   string `json:"role"`
-	jwt.Claims
+	UserId    string `json:"userId,omitempty"`
+	Role      string `json:"role,omitempty"`
+	TeamId    string `json:"teamId,omitempty"`
+	RoleId    string `json:"roleId,omitempty"`
+	SessionId string `json:"sessionId,omitempty"`
+	Type      string `json:"typ"`
+	jwt.RegisteredClaims
 }
 
-func GenerateJWTToken(user models.User) (string, error) {
-	jwtSecret := []byte(os.Getenv("JWT_SECRET"))
+// ConfigureJWTClaims overrides the issuer and audience set in and required from tokens, empty values keep the defaults
+func ConfigureJWTClaims(issuer string, audience string) {
+	if issuer != "" {
+		jwtIssuer = issuer
+	}
+
+	if audience != "" {
+		jwtAudience = audience
+	}
+}
+
+func newClaims(user models.User, sessionId uuid.UUID, tokenType string, issuedAt time.Time, expiresAt time.Time) CustomerClaims {
+	return CustomerClaims{
+		UserId:    user.ID.String(),
+		SessionId: sessionId.String(),
+		Type:      tokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    jwtIssuer,
+			Subject:   user.ID.String(),
+			Audience:  jwt.ClaimStrings{jwtAudience},
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+}
+
+func GenerateJWTToken(user models.User, sessionId uuid.UUID) (string, error) {
+	keySet, err := GetJWTKeySet()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC()
+	claims := newClaims(user, sessionId, AccessTokenType, now, now.Add(AccessTokenTTL))
+	claims.Role = string(user.Role)
 
-	method := jwt.SigningMethodHS256
-	claims := jwt.MapClaims{
-		"userId":   user.ID,
-		"userRole": user.Role,
-		"exp":      time.Now().Add(time.Minute * 15).Unix(),
+	if user.TeamId != uuid.Nil {
+		claims.TeamId = user.TeamId.String()
 	}
 
-	token, err := jwt.NewWithClaims(method, claims).SignedString(jwtSecret)
+	if user.RoleId != uuid.Nil {
+		claims.RoleId = user.RoleId.String()
+	}
+
+	token, err := keySet.Sign(claims)
 	if err != nil {
 		return "", err
 	}
@@ -33,16 +94,18 @@ func GenerateJWTToken(user models.User) (string, error) {
 	return token, nil
 }
 
-func GenerateJWTRefreshToken(user models.User) (refreshToken string, expiresAt time.Time, err error) {
-	jwtSecret := []byte(os.Getenv("JWT_SECRET"))
-	method := jwt.SigningMethodHS256
-	expiresAt = time.Now().UTC().Add(time.Hour * 168) // 7 days
-	claims := jwt.MapClaims{
-		"userId": user.ID,
-		"exp":    expiresAt.Unix(),
+// familyId is the id of the session the token belongs to, a reused token revokes the whole family
+func GenerateJWTRefreshToken(user models.User, familyId uuid.UUID) (refreshToken string, expiresAt time.Time, err error) {
+	keySet, err := GetJWTKeySet()
+	if err != nil {
+		return "", time.Now(), err
 	}
 
-	token, err := jwt.NewWithClaims(method, claims).SignedString(jwtSecret)
+	now := time.Now().UTC()
+	expiresAt = now.Add(time.Hour * 168) // 7 days
+	claims := newClaims(user, familyId, RefreshTokenType, now, expiresAt)
+
+	token, err := keySet.Sign(claims)
 	if err != nil {
 		return "", time.Now(), err
 	}
@@ -50,12 +113,114 @@ func GenerateJWTRefreshToken(user models.User) (refreshToken string, expiresAt t
 	return token, expiresAt, nil
 }
 
-func GenerateCookie(c *fiber.Ctx, token string) {
-	c.Cookie(&fiber.Cookie{
-		Name:     "jwt",
-		Value:    token,
-		HTTPOnly: !c.IsFromLocal(),
-		Secure:   !c.IsFromLocal(),
-		MaxAge:   3600 * 24 * 7, // 7 days
-	})
+// GenerateMfaPendingToken is issued after the password of a user with two-factor authentication is verified,
+// it can only be exchanged for full tokens together with a valid code. The jti is the id of the stored token,
+// which is marked as used once it's exchanged
+func GenerateMfaPendingToken(pending models.MfaPendingToken) (string, error) {
+	keySet, err := GetJWTKeySet()
+	if err != nil {
+		return "", err
+	}
+
+	claims := CustomerClaims{
+		UserId: pending.UserID.String(),
+		Type:   MfaPendingTokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        pending.ID.String(),
+			Issuer:    jwtIssuer,
+			Subject:   pending.UserID.String(),
+			Audience:  jwt.ClaimStrings{jwtAudience},
+			IssuedAt:  jwt.NewNumericDate(pending.CreatedAt),
+			ExpiresAt: jwt.NewNumericDate(pending.ExpiresAt),
+		},
+	}
+
+	token, err := keySet.Sign(claims)
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// GenerateInvitationToken signs the link sent to an invited user. The subject is the invitation id and the
+// jti its token id, renewing the invitation changes the token id so older links stop working
+func GenerateInvitationToken(invitation models.Invitation) (string, error) {
+	keySet, err := GetJWTKeySet()
+	if err != nil {
+		return "", err
+	}
+
+	claims := CustomerClaims{
+		Role:   string(invitation.Role),
+		TeamId: invitation.TeamID.String(),
+		Type:   InvitationTokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        invitation.TokenID.String(),
+			Issuer:    jwtIssuer,
+			Subject:   invitation.ID.String(),
+			Audience:  jwt.ClaimStrings{jwtAudience},
+			IssuedAt:  jwt.NewNumericDate(time.Now().UTC()),
+			ExpiresAt: jwt.NewNumericDate(invitation.ExpiresAt),
+		},
+	}
+
+	token, err := keySet.Sign(claims)
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// ParseJWTToken verifies the signature, issuer, audience and expiration of the token and
+// checks it is of the expected type
+func ParseJWTToken(tokenString string, tokenType string) (*CustomerClaims, error) {
+	claims := &CustomerClaims{}
+
+	_, err := jwt.ParseWithClaims(tokenString, claims, JWTKeyFunc,
+		jwt.WithValidMethods(JWTValidMethods()),
+		jwt.WithIssuer(jwtIssuer),
+		jwt.WithAudience(jwtAudience),
+		jwt.WithIssuedAt(),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Type != tokenType {
+		return nil, ErrInvalidTokenType
+	}
+
+	if tokenType == InvitationTokenType {
+		if _, err := uuid.Parse(claims.Subject); err != nil {
+			return nil, ErrInvalidTokenClaims
+		}
+
+		if _, err := uuid.Parse(claims.ID); err != nil {
+			return nil, ErrInvalidTokenClaims
+		}
+
+		return claims, nil
+	}
+
+	if _, err := uuid.Parse(claims.UserId); err != nil {
+		return nil, ErrInvalidTokenClaims
+	}
+
+	// Mfa pending tokens are issued before the session exists
+	if tokenType == MfaPendingTokenType {
+		return claims, nil
+	}
+
+	if _, err := uuid.Parse(claims.SessionId); err != nil {
+		return nil, ErrInvalidTokenClaims
+	}
+
+	if tokenType == AccessTokenType && claims.Role == "" {
+		return nil, ErrInvalidTokenClaims
+	}
+
+	return claims, nil
 }
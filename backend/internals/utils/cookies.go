@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const (
+	AccessTokenCookie = "jwt"
+	CSRFTokenCookie   = "csrf_token"
+	CSRFTokenHeader   = "X-CSRF-Token"
+)
+
+// authCookieMaxAge keeps the cookies as long as the refresh token of the session
+const authCookieMaxAge = time.Hour * 168 // 7 days
+
+var (
+	cookieDomain   = ""
+	cookiePath     = "/"
+	cookieSameSite = fiber.CookieSameSiteLaxMode
+)
+
+// ConfigureCookies sets the domain, path and SameSite attribute of the cookies issued by the api, empty values
+// keep the defaults (current host, "/" and Lax)
+func ConfigureCookies(domain string, path string, sameSite string) error {
+	switch strings.ToLower(sameSite) {
+	case "":
+	case fiber.CookieSameSiteLaxMode, fiber.CookieSameSiteStrictMode, fiber.CookieSameSiteNoneMode:
+		cookieSameSite = strings.ToLower(sameSite)
+	default:
+		return fmt.Errorf("invalid cookie SameSite %q, it must be Lax, Strict or None", sameSite)
+	}
+
+	cookieDomain = domain
+
+	if path != "" {
+		cookiePath = path
+	}
+
+	return nil
+}
+
+func newCookie(c *fiber.Ctx, name string, value string, httpOnly bool) *fiber.Cookie {
+	return &fiber.Cookie{
+		Name:     name,
+		Value:    value,
+		Domain:   cookieDomain,
+		Path:     cookiePath,
+		HTTPOnly: httpOnly,
+		// Browsers drop SameSite=None cookies that aren't secure
+		Secure:   !c.IsFromLocal() || cookieSameSite == fiber.CookieSameSiteNoneMode,
+		SameSite: cookieSameSite,
+		MaxAge:   int(authCookieMaxAge.Seconds()),
+	}
+}
+
+// GenerateCookie sets the access token cookie and the csrf token cookie the frontend has to echo in the
+// X-CSRF-Token header of requests that change something
+func GenerateCookie(c *fiber.Ctx, token string, csrfToken string) {
+	c.Cookie(newCookie(c, AccessTokenCookie, token, !c.IsFromLocal()))
+	c.Cookie(newCookie(c, CSRFTokenCookie, csrfToken, false))
+}
+
+// ClearCookies expires the cookies set by GenerateCookie, they have to be cleared with the same domain and path
+func ClearCookies(c *fiber.Ctx) {
+	for _, name := range []string{AccessTokenCookie, CSRFTokenCookie} {
+		cookie := newCookie(c, name, "", false)
+		cookie.MaxAge = -1
+		cookie.Expires = time.Unix(0, 0)
+		c.Cookie(cookie)
+	}
+}
+
+// GenerateCSRFToken signs a token bound to the session, a request authenticated with the cookie of another
+// session (or a token planted by another site) can't use it
+func GenerateCSRFToken(user models.User, sessionId uuid.UUID) (string, error) {
+	keySet, err := GetJWTKeySet()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC()
+	claims := newClaims(user, sessionId, CSRFTokenType, now, now.Add(authCookieMaxAge))
+
+	token, err := keySet.Sign(claims)
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// VerifyCSRFToken checks the token was issued for the session of the access token
+func VerifyCSRFToken(csrfToken string, accessClaims *CustomerClaims) error {
+	claims, err := ParseJWTToken(csrfToken, CSRFTokenType)
+	if err != nil {
+		return err
+	}
+
+	if claims.SessionId != accessClaims.SessionId || claims.UserId != accessClaims.UserId {
+		return ErrInvalidTokenClaims
+	}
+
+	return nil
+}
+
+// IsSafeMethod tells the methods that don't change anything and don't need a csrf token
+func IsSafeMethod(method string) bool {
+	switch method {
+	case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,255 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrJWTKeysNotLoaded = errors.New("jwt keys not loaded")
+	ErrUnknownJWTKey    = errors.New("unknown jwt key id")
+)
+
+// JWTKey is a key identified by its kid. Keys without a private part can only verify tokens,
+// they are kept around after a rotation until the tokens they signed expire
+type JWTKey struct {
+	ID         string
+	Method     jwt.SigningMethod
+	PrivateKey crypto.Signer
+	PublicKey  crypto.PublicKey
+}
+
+type JWTKeySet struct {
+	signingKey JWTKey
+	keys       map[string]JWTKey
+}
+
+// JWK is the public part of a key as served in /.well-known/jwks.json
+type JWK struct {
+	Kty string `json:"kty" example:"OKP"`
+	Kid string `json:"kid" example:"2026-01"`
+	Use string `json:"use" example:"sig"`
+	Alg string `json:"alg" example:"EdDSA"`
+	Crv string `json:"crv,omitempty" example:"Ed25519"`
+	X   string `json:"x,omitempty" example:"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+var jwtKeys *JWTKeySet
+
+// SetJWTKeySet sets the keys used to sign and verify every token issued by the api
+func SetJWTKeySet(keySet *JWTKeySet) {
+	jwtKeys = keySet
+}
+
+func GetJWTKeySet() (*JWTKeySet, error) {
+	if jwtKeys == nil {
+		return nil, ErrJWTKeysNotLoaded
+	}
+
+	return jwtKeys, nil
+}
+
+func NewJWTKeySet(signingKeyId string, keys ...JWTKey) (*JWTKeySet, error) {
+	keySet := &JWTKeySet{
+		keys: make(map[string]JWTKey),
+	}
+
+	for _, key := range keys {
+		if _, exists := keySet.keys[key.ID]; exists {
+			return nil, fmt.Errorf("duplicated jwt key id %q", key.ID)
+		}
+		keySet.keys[key.ID] = key
+	}
+
+	signingKey, ok := keySet.keys[signingKeyId]
+	if !ok {
+		return nil, fmt.Errorf("signing key %q not found", signingKeyId)
+	}
+
+	if signingKey.PrivateKey == nil {
+		return nil, fmt.Errorf("signing key %q has no private key", signingKeyId)
+	}
+
+	keySet.signingKey = signingKey
+
+	return keySet, nil
+}
+
+// LoadJWTKeySet reads every .pem file of dir, the file name without the extension is used as the kid.
+// Private keys can sign and verify, public keys only verify
+func LoadJWTKeySet(dir string, signingKeyId string) (*JWTKeySet, error) {
+	if dir == "" {
+		return nil, errors.New("missing jwt keys directory")
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []JWTKey{}
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		kid := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+		key, err := ParseJWTKeyPEM(kid, data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	return NewJWTKeySet(signingKeyId, keys...)
+}
+
+// ParseJWTKeyPEM accepts RSA (RS256) and Ed25519 (EdDSA) keys in PKCS#8, PKCS#1 or PKIX form
+func ParseJWTKeyPEM(kid string, data []byte) (JWTKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return JWTKey{}, errors.New("no PEM data found")
+	}
+
+	var parsed any
+	var err error
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return JWTKey{}, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+
+	if err != nil {
+		return JWTKey{}, err
+	}
+
+	key := JWTKey{ID: kid}
+
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.Method = jwt.SigningMethodRS256
+		key.PrivateKey = k
+		key.PublicKey = &k.PublicKey
+	case *rsa.PublicKey:
+		key.Method = jwt.SigningMethodRS256
+		key.PublicKey = k
+	case ed25519.PrivateKey:
+		key.Method = jwt.SigningMethodEdDSA
+		key.PrivateKey = k
+		key.PublicKey = k.Public()
+	case ed25519.PublicKey:
+		key.Method = jwt.SigningMethodEdDSA
+		key.PublicKey = k
+	default:
+		return JWTKey{}, fmt.Errorf("unsupported key type %T", parsed)
+	}
+
+	return key, nil
+}
+
+// Sign signs the claims with the current signing key and sets its kid in the token header
+func (ks *JWTKeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(ks.signingKey.Method, claims)
+	token.Header["kid"] = ks.signingKey.ID
+
+	return token.SignedString(ks.signingKey.PrivateKey)
+}
+
+// KeyFunc picks the verification key from the token kid, the algorithm must match the key type
+func (ks *JWTKeySet) KeyFunc(t *jwt.Token) (any, error) {
+	kid, ok := t.Header["kid"].(string)
+	if !ok {
+		return nil, ErrUnknownJWTKey
+	}
+
+	key, ok := ks.keys[kid]
+	if !ok {
+		return nil, ErrUnknownJWTKey
+	}
+
+	if t.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+	}
+
+	return key.PublicKey, nil
+}
+
+func (ks *JWTKeySet) JWKS() JWKS {
+	ids := make([]string, 0, len(ks.keys))
+	for id := range ks.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	jwks := JWKS{Keys: []JWK{}}
+
+	for _, id := range ids {
+		key := ks.keys[id]
+		jwk := JWK{
+			Kid: key.ID,
+			Use: "sig",
+			Alg: key.Method.Alg(),
+		}
+
+		switch k := key.PublicKey.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(k.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(k)
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return jwks
+}
+
+// JWTKeyFunc verifies tokens against the loaded key set, it can be given to any jwt parser
+func JWTKeyFunc(t *jwt.Token) (any, error) {
+	keySet, err := GetJWTKeySet()
+	if err != nil {
+		return nil, err
+	}
+
+	return keySet.KeyFunc(t)
+}
+
+// JWTValidMethods are the only algorithms accepted when parsing tokens
+func JWTValidMethods() []string {
+	return []string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}
+}
@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const OIDCStateTokenType = "oidc_state"
+
+// OIDCStateClaims keep what the api needs to finish a single sign-on between the redirect to the
+// identity provider and the callback, so nothing has to be stored until the user signs in
+type OIDCStateClaims struct {
+	State        string `json:"state"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"codeVerifier"`
+	Type         string `json:"typ"`
+	jwt.RegisteredClaims
+}
+
+func GenerateOIDCStateToken(state string, nonce string, codeVerifier string) (string, error) {
+	keySet, err := GetJWTKeySet()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC()
+
+	claims := OIDCStateClaims{
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		Type:         OIDCStateTokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    jwtIssuer,
+			Audience:  jwt.ClaimStrings{jwtAudience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute * 10)),
+		},
+	}
+
+	token, err := keySet.Sign(claims)
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func ParseOIDCStateToken(tokenString string) (*OIDCStateClaims, error) {
+	claims := &OIDCStateClaims{}
+
+	_, err := jwt.ParseWithClaims(tokenString, claims, JWTKeyFunc,
+		jwt.WithValidMethods(JWTValidMethods()),
+		jwt.WithIssuer(jwtIssuer),
+		jwt.WithAudience(jwtAudience),
+		jwt.WithIssuedAt(),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Type != OIDCStateTokenType {
+		return nil, ErrInvalidTokenType
+	}
+
+	if claims.State == "" || claims.Nonce == "" || claims.CodeVerifier == "" {
+		return nil, ErrInvalidTokenClaims
+	}
+
+	return claims, nil
+}
+
+// PKCECodeChallenge is the S256 challenge of the code verifier (RFC 7636)
+func PKCECodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
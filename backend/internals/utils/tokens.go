@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// PersonalAccessTokenPrefix tells personal access tokens apart from JWTs in the Authorization header
+const PersonalAccessTokenPrefix = "pat_"
+
+// GenerateOpaqueToken returns a random url safe token to hand to the user and the hash to store in its place
+func GenerateOpaqueToken() (token string, hash string, err error) {
+	b := make([]byte, 32)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(b)
+
+	return token, HashOpaqueToken(token), nil
+}
+
+func HashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func GeneratePersonalAccessToken() (token string, hash string, err error) {
+	opaque, _, err := GenerateOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
+
+	token = PersonalAccessTokenPrefix + opaque
+
+	return token, HashOpaqueToken(token), nil
+}
+
+func IsPersonalAccessToken(token string) bool {
+	return strings.HasPrefix(token, PersonalAccessTokenPrefix)
+}
@@ -0,0 +1,345 @@
+package handlers_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
+	"github.com/TobiasRV/challenge-fs-senior/test/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_JWTSuccessHandler(t *testing.T) {
+	currentKeySet, err := utils.GetJWTKeySet()
+	assert.NoError(t, err)
+	defer utils.SetJWTKeySet(currentKeySet)
+
+	_, oldPrivateKey, _ := ed25519.GenerateKey(rand.Reader)
+	newRSAKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	oldKey := utils.JWTKey{ID: "old", Method: jwt.SigningMethodEdDSA, PrivateKey: oldPrivateKey, PublicKey: oldPrivateKey.Public()}
+	newKey := utils.JWTKey{ID: "new", Method: jwt.SigningMethodRS256, PrivateKey: newRSAKey, PublicKey: &newRSAKey.PublicKey}
+
+	// The old key signed tokens before the rotation, only its public part is kept
+	oldKeySet, err := utils.NewJWTKeySet("old", oldKey)
+	assert.NoError(t, err)
+
+	rotatedKeySet, err := utils.NewJWTKeySet("new", newKey, utils.JWTKey{ID: "old", Method: oldKey.Method, PublicKey: oldKey.PublicKey})
+	assert.NoError(t, err)
+
+	_, unknownPrivateKey, _ := ed25519.GenerateKey(rand.Reader)
+	unknownKeySet, err := utils.NewJWTKeySet("unknown", utils.JWTKey{ID: "unknown", Method: jwt.SigningMethodEdDSA, PrivateKey: unknownPrivateKey, PublicKey: unknownPrivateKey.Public()})
+	assert.NoError(t, err)
+
+	user := models.User{ID: uuid.New(), Role: models.UserrolesAdmin}
+	sessionId := uuid.New()
+
+	signWith := func(keySet *utils.JWTKeySet) string {
+		utils.SetJWTKeySet(keySet)
+		token, err := utils.GenerateJWTToken(user, sessionId)
+		assert.NoError(t, err)
+		return token
+	}
+
+	hs256Token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"userId":   user.ID,
+		"userRole": user.Role,
+		"exp":      time.Now().Add(time.Minute).Unix(),
+	}).SignedString([]byte("secret"))
+
+	refreshToken := func() string {
+		utils.SetJWTKeySet(rotatedKeySet)
+		token, _, err := utils.GenerateJWTRefreshToken(user, sessionId)
+		assert.NoError(t, err)
+		return token
+	}
+
+	signClaims := func(claims utils.CustomerClaims) string {
+		token, err := rotatedKeySet.Sign(claims)
+		assert.NoError(t, err)
+		return token
+	}
+
+	registeredClaims := func(audience string) jwt.RegisteredClaims {
+		return jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    "challenge-fs-senior",
+			Audience:  jwt.ClaimStrings{audience},
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		}
+	}
+
+	tests := []struct {
+		name           string
+		token          string
+		expectedStatus int
+	}{
+		{
+			name:           "Token signed with the current key",
+			token:          signWith(rotatedKeySet),
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "Token signed with a rotated out key",
+			token:          signWith(oldKeySet),
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "Token signed with an unknown key",
+			token:          signWith(unknownKeySet),
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name:           "HS256 token",
+			token:          hs256Token,
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name:           "Refresh token used as access token",
+			token:          refreshToken(),
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name: "Token for another audience",
+			token: signClaims(utils.CustomerClaims{
+				UserId:           user.ID.String(),
+				Role:             string(user.Role),
+				SessionId:        sessionId.String(),
+				Type:             utils.AccessTokenType,
+				RegisteredClaims: registeredClaims("another-service"),
+			}),
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name: "Access token without role",
+			token: signClaims(utils.CustomerClaims{
+				UserId:           user.ID.String(),
+				SessionId:        sessionId.String(),
+				Type:             utils.AccessTokenType,
+				RegisteredClaims: registeredClaims("challenge-fs-senior-api"),
+			}),
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name: "Access token with a malformed user id",
+			token: signClaims(utils.CustomerClaims{
+				UserId:           "not-a-uuid",
+				Role:             string(user.Role),
+				SessionId:        sessionId.String(),
+				Type:             utils.AccessTokenType,
+				RegisteredClaims: registeredClaims("challenge-fs-senior-api"),
+			}),
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name:           "Malformed token",
+			token:          "not-a-jwt",
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			utils.SetJWTKeySet(rotatedKeySet)
+
+			app := setupTestApp()
+
+			handler := newTestHandler(handlerDeps{})
+
+			app.Get("/protected", handler.JWTSuccessHandler, func(c *fiber.Ctx) error {
+				assert.Equal(t, user.ID.String(), c.Locals("userId"))
+				assert.Equal(t, sessionId.String(), c.Locals("sessionId"))
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestHandler_GetJWKS(t *testing.T) {
+	currentKeySet, err := utils.GetJWTKeySet()
+	assert.NoError(t, err)
+	defer utils.SetJWTKeySet(currentKeySet)
+
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	keySet, err := utils.NewJWTKeySet("ed",
+		utils.JWTKey{ID: "ed", Method: jwt.SigningMethodEdDSA, PrivateKey: edKey, PublicKey: edKey.Public()},
+		utils.JWTKey{ID: "rsa", Method: jwt.SigningMethodRS256, PublicKey: &rsaKey.PublicKey},
+	)
+	assert.NoError(t, err)
+	utils.SetJWTKeySet(keySet)
+
+	app := setupTestApp()
+
+	handler := newTestHandler(handlerDeps{})
+
+	app.Get("/.well-known/jwks.json", handler.GetJWKS)
+
+	req := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+
+	var jwks map[string][]map[string]string
+	assert.NoError(t, json.Unmarshal(body, &jwks))
+	assert.Len(t, jwks["keys"], 2)
+
+	for _, key := range jwks["keys"] {
+		assert.Empty(t, key["d"], "private key material must not be published")
+
+		switch key["kid"] {
+		case "ed":
+			assert.Equal(t, "OKP", key["kty"])
+			assert.Equal(t, "EdDSA", key["alg"])
+			assert.NotEmpty(t, key["x"])
+		case "rsa":
+			assert.Equal(t, "RSA", key["kty"])
+			assert.Equal(t, "RS256", key["alg"])
+			assert.Equal(t, "AQAB", key["e"])
+		default:
+			t.Errorf("unexpected kid %q", key["kid"])
+		}
+	}
+}
+
+func TestHandler_CSRFProtection(t *testing.T) {
+	user := models.User{ID: uuid.New(), Role: models.UserrolesMember}
+	sessionId := uuid.New()
+
+	accessToken, err := utils.GenerateJWTToken(user, sessionId)
+	assert.NoError(t, err)
+
+	csrfToken, err := utils.GenerateCSRFToken(user, sessionId)
+	assert.NoError(t, err)
+
+	otherSessionCsrfToken, err := utils.GenerateCSRFToken(user, uuid.New())
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		method         string
+		cookie         bool
+		bearer         bool
+		csrfToken      string
+		expectedStatus int
+	}{
+		{
+			name:           "Cookie read without csrf token",
+			method:         http.MethodGet,
+			cookie:         true,
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "Cookie write without csrf token",
+			method:         http.MethodPost,
+			cookie:         true,
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:           "Cookie write with the csrf token of the session",
+			method:         http.MethodDelete,
+			cookie:         true,
+			csrfToken:      csrfToken,
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "Cookie write with the csrf token of another session",
+			method:         http.MethodPut,
+			cookie:         true,
+			csrfToken:      otherSessionCsrfToken,
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:           "Cookie write with the access token as csrf token",
+			method:         http.MethodPatch,
+			cookie:         true,
+			csrfToken:      accessToken,
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:           "Bearer write without csrf token",
+			method:         http.MethodPost,
+			bearer:         true,
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "Bearer takes precedence over the cookie",
+			method:         http.MethodPost,
+			cookie:         true,
+			bearer:         true,
+			expectedStatus: fiber.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			handler := newTestHandler(handlerDeps{})
+
+			app.All("/protected", handler.JWTSuccessHandler, func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			req := httptest.NewRequest(tt.method, "/protected", nil)
+
+			if tt.cookie {
+				req.AddCookie(&http.Cookie{Name: utils.AccessTokenCookie, Value: accessToken})
+			}
+
+			if tt.bearer {
+				req.Header.Set("Authorization", "Bearer "+accessToken)
+			}
+
+			if tt.csrfToken != "" {
+				req.Header.Set(utils.CSRFTokenHeader, tt.csrfToken)
+			}
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+
+	t.Run("Routes accept the access token cookie", func(t *testing.T) {
+		mockSessionRepo := mocks.NewMockSessionRepository()
+		mockSessionRepo.On("GetSessionsByUserId", mock.Anything, user.ID).Return([]models.Session{}, nil)
+
+		app := setupTestApp()
+
+		handler := newTestHandler(handlerDeps{sessionRepo: mockSessionRepo})
+		handler.Register(app)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/sessions", nil)
+		req.AddCookie(&http.Cookie{Name: utils.AccessTokenCookie, Value: accessToken})
+
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		mockSessionRepo.AssertExpectations(t)
+	})
+}
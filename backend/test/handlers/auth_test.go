@@ -9,8 +9,9 @@ import (
 	"testing"
 	"time"
 
-	"github.com/TobiasRV/challenge-fs-senior/internals/handlers"
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
 	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
 	"github.com/TobiasRV/challenge-fs-senior/test/mocks"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -26,20 +27,28 @@ func setupTestApp() *fiber.App {
 
 func TestHandler_LogIn(t *testing.T) {
 	userId := uuid.New()
+	sessionId := uuid.New()
+	teamId := uuid.New()
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
 
+	noAttempts := []models.LoginAttempt{}
+	accountAttempts := interfaces.GetLoginAttemptsData{AccountKey: "test@example.com", IpAddress: "0.0.0.0"}
+	clearAccount := interfaces.LoginAttemptKey{Scope: models.LoginAttemptScopeAccount, Identifier: "test@example.com"}
+
 	tests := []struct {
 		name           string
 		requestBody    map[string]interface{}
-		setupMocks     func(*mocks.MockUserRepository, *mocks.MockRefreshTokenRepository)
+		setupMocks     func(*mocks.MockUserRepository, *mocks.MockRefreshTokenRepository, *mocks.MockSessionRepository, *mocks.MockTeamRepository, *mocks.MockMfaRepository, *mocks.MockLoginAttemptRepository)
 		expectedStatus int
+		expectedError  string
+		expectedMfa    *interfaces.MfaLoginResponse
 	}{
 		{
 			name: "Missing email field",
 			requestBody: map[string]interface{}{
 				"password": "password123",
 			},
-			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository) {
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockTeamRepo *mocks.MockTeamRepository, mockMfaRepo *mocks.MockMfaRepository, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
 			},
 			expectedStatus: fiber.StatusBadRequest,
 		},
@@ -48,7 +57,7 @@ func TestHandler_LogIn(t *testing.T) {
 			requestBody: map[string]interface{}{
 				"email": "test@example.com",
 			},
-			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository) {
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockTeamRepo *mocks.MockTeamRepository, mockMfaRepo *mocks.MockMfaRepository, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
 			},
 			expectedStatus: fiber.StatusBadRequest,
 		},
@@ -58,10 +67,15 @@ func TestHandler_LogIn(t *testing.T) {
 				"email":    "notfound@example.com",
 				"password": "password123",
 			},
-			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository) {
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockTeamRepo *mocks.MockTeamRepository, mockMfaRepo *mocks.MockMfaRepository, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+				mockLoginAttemptRepo.On("GetLoginAttempts", mock.Anything, interfaces.GetLoginAttemptsData{AccountKey: "notfound@example.com", IpAddress: "0.0.0.0"}).Return(noAttempts, nil)
 				mockUserRepo.On("GetUserByEmail", mock.Anything, "notfound@example.com").Return(models.User{}, sql.ErrNoRows)
+				mockLoginAttemptRepo.On("RecordFailedLogin", mock.Anything, mock.MatchedBy(func(data interfaces.RecordFailedLoginData) bool {
+					return data.AccountKey == "notfound@example.com" && data.IpAddress == "0.0.0.0" && data.WindowStart.Equal(data.FailedAt.Add(-15*time.Minute))
+				})).Return(noAttempts, nil)
 			},
 			expectedStatus: fiber.StatusConflict,
+			expectedError:  "Invalid email or password",
 		},
 		{
 			name: "Invalid password",
@@ -69,36 +83,210 @@ func TestHandler_LogIn(t *testing.T) {
 				"email":    "test@example.com",
 				"password": "wrongpassword",
 			},
-			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository) {
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockTeamRepo *mocks.MockTeamRepository, mockMfaRepo *mocks.MockMfaRepository, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+				mockLoginAttemptRepo.On("GetLoginAttempts", mock.Anything, accountAttempts).Return(noAttempts, nil)
 				mockUserRepo.On("GetUserByEmail", mock.Anything, "test@example.com").Return(models.User{
 					ID:       userId,
 					Email:    "test@example.com",
 					Password: string(hashedPassword),
 				}, nil)
+				mockLoginAttemptRepo.On("RecordFailedLogin", mock.Anything, mock.MatchedBy(func(data interfaces.RecordFailedLoginData) bool {
+					return data.AccountKey == "test@example.com" && data.IpAddress == "0.0.0.0"
+				})).Return(noAttempts, nil)
 			},
 			expectedStatus: fiber.StatusConflict,
+			expectedError:  "Invalid email or password",
+		},
+		{
+			name: "Deactivated user",
+			requestBody: map[string]interface{}{
+				"email":    "test@example.com",
+				"password": "password123",
+			},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockTeamRepo *mocks.MockTeamRepository, mockMfaRepo *mocks.MockMfaRepository, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+				deactivatedAt := time.Now().UTC()
+				mockLoginAttemptRepo.On("GetLoginAttempts", mock.Anything, accountAttempts).Return(noAttempts, nil)
+				mockUserRepo.On("GetUserByEmail", mock.Anything, "test@example.com").Return(models.User{
+					ID:            userId,
+					Email:         "test@example.com",
+					Password:      string(hashedPassword),
+					Role:          models.UserrolesMember,
+					TeamId:        teamId,
+					DeactivatedAt: &deactivatedAt,
+				}, nil)
+				mockLoginAttemptRepo.On("ClearLoginAttempts", mock.Anything, clearAccount).Return(nil)
+			},
+			expectedStatus: fiber.StatusForbidden,
+			expectedError:  "the account has been deactivated",
 		},
 		{
 			name: "Successful login",
 			requestBody: map[string]interface{}{
 				"email":    "test@example.com",
 				"password": "password123",
+				"label":    "Work laptop",
 			},
-			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository) {
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockTeamRepo *mocks.MockTeamRepository, mockMfaRepo *mocks.MockMfaRepository, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+				mockLoginAttemptRepo.On("GetLoginAttempts", mock.Anything, accountAttempts).Return(noAttempts, nil)
 				mockUserRepo.On("GetUserByEmail", mock.Anything, "test@example.com").Return(models.User{
 					ID:        userId,
 					Email:     "test@example.com",
 					Password:  string(hashedPassword),
 					Username:  "testuser",
 					Role:      models.UserrolesMember,
+					TeamId:    teamId,
 					CreatedAt: time.Now(),
 					UpdatedAt: time.Now(),
 				}, nil)
-				mockRefreshTokenRepo.On("DeleteRefreshTokensByUserId", mock.Anything, userId.String()).Return(nil)
-				mockRefreshTokenRepo.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("models.RefreshToken")).Return(nil)
+				mockLoginAttemptRepo.On("ClearLoginAttempts", mock.Anything, clearAccount).Return(nil)
+				mockMfaRepo.On("GetUserTotp", mock.Anything, userId).Return(models.UserTotp{}, sql.ErrNoRows)
+				mockTeamRepo.On("GetTeamById", mock.Anything, teamId).Return(models.Team{ID: teamId, RequireMfa: false}, nil)
+				mockSessionRepo.On("CreateSession", mock.Anything, mock.MatchedBy(func(session models.Session) bool {
+					return session.UserID == userId && session.Label == "Work laptop"
+				})).Return(models.Session{ID: sessionId, UserID: userId}, nil)
+				mockRefreshTokenRepo.On("CreateRefreshToken", mock.Anything, mock.MatchedBy(func(token models.RefreshToken) bool {
+					return token.FamilyID == sessionId
+				})).Return(nil)
 			},
 			expectedStatus: fiber.StatusOK,
 		},
+		{
+			name: "Two-factor enabled returns an mfa token",
+			requestBody: map[string]interface{}{
+				"email":    "test@example.com",
+				"password": "password123",
+			},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockTeamRepo *mocks.MockTeamRepository, mockMfaRepo *mocks.MockMfaRepository, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+				mockLoginAttemptRepo.On("GetLoginAttempts", mock.Anything, accountAttempts).Return(noAttempts, nil)
+				mockUserRepo.On("GetUserByEmail", mock.Anything, "test@example.com").Return(models.User{
+					ID:       userId,
+					Email:    "test@example.com",
+					Password: string(hashedPassword),
+					Role:     models.UserrolesMember,
+					TeamId:   teamId,
+				}, nil)
+				mockLoginAttemptRepo.On("ClearLoginAttempts", mock.Anything, clearAccount).Return(nil)
+				mockMfaRepo.On("GetUserTotp", mock.Anything, userId).Return(models.UserTotp{
+					UserID:    userId,
+					EnabledAt: sql.NullTime{Time: time.Now(), Valid: true},
+				}, nil)
+				mockMfaRepo.On("CreateMfaPendingToken", mock.Anything, mock.MatchedBy(func(token models.MfaPendingToken) bool {
+					return token.UserID == userId && token.ExpiresAt.After(time.Now())
+				})).Return(nil)
+			},
+			expectedStatus: fiber.StatusAccepted,
+			expectedMfa:    &interfaces.MfaLoginResponse{MfaRequired: true, MfaEnrolled: true},
+		},
+		{
+			name: "Team requiring two-factor returns an mfa token to enroll",
+			requestBody: map[string]interface{}{
+				"email":    "test@example.com",
+				"password": "password123",
+			},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockTeamRepo *mocks.MockTeamRepository, mockMfaRepo *mocks.MockMfaRepository, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+				mockLoginAttemptRepo.On("GetLoginAttempts", mock.Anything, accountAttempts).Return(noAttempts, nil)
+				mockUserRepo.On("GetUserByEmail", mock.Anything, "test@example.com").Return(models.User{
+					ID:       userId,
+					Email:    "test@example.com",
+					Password: string(hashedPassword),
+					Role:     models.UserrolesMember,
+					TeamId:   teamId,
+				}, nil)
+				mockLoginAttemptRepo.On("ClearLoginAttempts", mock.Anything, clearAccount).Return(nil)
+				mockMfaRepo.On("GetUserTotp", mock.Anything, userId).Return(models.UserTotp{}, sql.ErrNoRows)
+				mockTeamRepo.On("GetTeamById", mock.Anything, teamId).Return(models.Team{ID: teamId, RequireMfa: true}, nil)
+				mockMfaRepo.On("CreateMfaPendingToken", mock.Anything, mock.MatchedBy(func(token models.MfaPendingToken) bool {
+					return token.UserID == userId && token.ExpiresAt.After(time.Now())
+				})).Return(nil)
+			},
+			expectedStatus: fiber.StatusAccepted,
+			expectedMfa:    &interfaces.MfaLoginResponse{MfaRequired: true, MfaEnrolled: false},
+		},
+		{
+			name: "Admin of a team requiring two-factor",
+			requestBody: map[string]interface{}{
+				"email":    "test@example.com",
+				"password": "password123",
+			},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockTeamRepo *mocks.MockTeamRepository, mockMfaRepo *mocks.MockMfaRepository, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+				mockLoginAttemptRepo.On("GetLoginAttempts", mock.Anything, accountAttempts).Return(noAttempts, nil)
+				mockUserRepo.On("GetUserByEmail", mock.Anything, "test@example.com").Return(models.User{
+					ID:       userId,
+					Email:    "test@example.com",
+					Password: string(hashedPassword),
+					Role:     models.UserrolesAdmin,
+				}, nil)
+				mockLoginAttemptRepo.On("ClearLoginAttempts", mock.Anything, clearAccount).Return(nil)
+				mockMfaRepo.On("GetUserTotp", mock.Anything, userId).Return(models.UserTotp{}, sql.ErrNoRows)
+				mockTeamRepo.On("GetTeamByOwner", mock.Anything, userId).Return(true, models.Team{ID: teamId, OwnerID: userId, RequireMfa: true}, nil)
+				mockTeamRepo.On("GetTeamById", mock.Anything, teamId).Return(models.Team{ID: teamId, OwnerID: userId, RequireMfa: true}, nil)
+				mockMfaRepo.On("CreateMfaPendingToken", mock.Anything, mock.MatchedBy(func(token models.MfaPendingToken) bool {
+					return token.UserID == userId && token.ExpiresAt.After(time.Now())
+				})).Return(nil)
+			},
+			expectedStatus: fiber.StatusAccepted,
+			expectedMfa:    &interfaces.MfaLoginResponse{MfaRequired: true, MfaEnrolled: false},
+		},
+		{
+			name: "Account locked after too many failures",
+			requestBody: map[string]interface{}{
+				"email":    "test@example.com",
+				"password": "password123",
+			},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockTeamRepo *mocks.MockTeamRepository, mockMfaRepo *mocks.MockMfaRepository, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+				mockLoginAttemptRepo.On("GetLoginAttempts", mock.Anything, accountAttempts).Return([]models.LoginAttempt{
+					{Scope: models.LoginAttemptScopeAccount, Identifier: "test@example.com", FailedCount: 10, LastFailedAt: time.Now().UTC().Add(-10 * time.Minute)},
+				}, nil)
+			},
+			expectedStatus: fiber.StatusTooManyRequests,
+		},
+		{
+			name: "Account waiting after a few failures",
+			requestBody: map[string]interface{}{
+				"email":    "Test@Example.com",
+				"password": "password123",
+			},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockTeamRepo *mocks.MockTeamRepository, mockMfaRepo *mocks.MockMfaRepository, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+				mockLoginAttemptRepo.On("GetLoginAttempts", mock.Anything, accountAttempts).Return([]models.LoginAttempt{
+					{Scope: models.LoginAttemptScopeAccount, Identifier: "test@example.com", FailedCount: 6, LastFailedAt: time.Now().UTC()},
+				}, nil)
+			},
+			expectedStatus: fiber.StatusTooManyRequests,
+		},
+		{
+			name: "Ip address locked after too many failures",
+			requestBody: map[string]interface{}{
+				"email":    "test@example.com",
+				"password": "password123",
+			},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockTeamRepo *mocks.MockTeamRepository, mockMfaRepo *mocks.MockMfaRepository, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+				mockLoginAttemptRepo.On("GetLoginAttempts", mock.Anything, accountAttempts).Return([]models.LoginAttempt{
+					{Scope: models.LoginAttemptScopeIp, Identifier: "0.0.0.0", FailedCount: 50, LastFailedAt: time.Now().UTC()},
+				}, nil)
+			},
+			expectedStatus: fiber.StatusTooManyRequests,
+		},
+		{
+			name: "Delay already waited lets the attempt through",
+			requestBody: map[string]interface{}{
+				"email":    "test@example.com",
+				"password": "wrongpassword",
+			},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockTeamRepo *mocks.MockTeamRepository, mockMfaRepo *mocks.MockMfaRepository, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+				mockLoginAttemptRepo.On("GetLoginAttempts", mock.Anything, accountAttempts).Return([]models.LoginAttempt{
+					{Scope: models.LoginAttemptScopeAccount, Identifier: "test@example.com", FailedCount: 5, LastFailedAt: time.Now().UTC().Add(-5 * time.Second)},
+					{Scope: models.LoginAttemptScopeIp, Identifier: "0.0.0.0", FailedCount: 5, LastFailedAt: time.Now().UTC()},
+				}, nil)
+				mockUserRepo.On("GetUserByEmail", mock.Anything, "test@example.com").Return(models.User{
+					ID:       userId,
+					Email:    "test@example.com",
+					Password: string(hashedPassword),
+				}, nil)
+				mockLoginAttemptRepo.On("RecordFailedLogin", mock.Anything, mock.AnythingOfType("interfaces.RecordFailedLoginData")).Return(noAttempts, nil)
+			},
+			expectedStatus: fiber.StatusConflict,
+			expectedError:  "Invalid email or password",
+		},
 	}
 
 	for _, tt := range tests {
@@ -108,12 +296,13 @@ func TestHandler_LogIn(t *testing.T) {
 			mockUserRepo := mocks.NewMockUserRepository()
 			mockRefreshTokenRepo := mocks.NewMockRefreshTokenRepository()
 			mockTeamRepo := mocks.NewMockTeamRepository()
-			mockProjectRepo := mocks.NewMockProjectRepository()
-			mockTaskRepo := mocks.NewMockTaskRepository()
+			mockSessionRepo := mocks.NewMockSessionRepository()
+			mockMfaRepo := mocks.NewMockMfaRepository()
+			mockLoginAttemptRepo := mocks.NewMockLoginAttemptRepository()
 
-			tt.setupMocks(mockUserRepo, mockRefreshTokenRepo)
+			tt.setupMocks(mockUserRepo, mockRefreshTokenRepo, mockSessionRepo, mockTeamRepo, mockMfaRepo, mockLoginAttemptRepo)
 
-			handler := handlers.NewHandler(mockUserRepo, mockRefreshTokenRepo, mockTeamRepo, mockProjectRepo, mockTaskRepo)
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, refreshTokenRepo: mockRefreshTokenRepo, teamRepo: mockTeamRepo, sessionRepo: mockSessionRepo, mfaRepo: mockMfaRepo, loginAttemptRepo: mockLoginAttemptRepo})
 
 			app.Post("/auth/login", handler.LogIn)
 
@@ -125,8 +314,275 @@ func TestHandler_LogIn(t *testing.T) {
 
 			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
 
+			if tt.expectedStatus == fiber.StatusTooManyRequests {
+				assert.NotEmpty(t, resp.Header.Get(fiber.HeaderRetryAfter))
+			}
+
+			if tt.expectedError != "" {
+				var result utils.Error
+				json.NewDecoder(resp.Body).Decode(&result)
+				assert.Equal(t, tt.expectedError, result.Errors["body"])
+			}
+
+			if tt.expectedMfa != nil {
+				var result interfaces.MfaLoginResponse
+				json.NewDecoder(resp.Body).Decode(&result)
+				assert.Equal(t, tt.expectedMfa.MfaRequired, result.MfaRequired)
+				assert.Equal(t, tt.expectedMfa.MfaEnrolled, result.MfaEnrolled)
+
+				claims, err := utils.ParseJWTToken(result.MfaToken, utils.MfaPendingTokenType)
+				assert.NoError(t, err)
+				assert.Equal(t, userId.String(), claims.UserId)
+			}
+
 			mockUserRepo.AssertExpectations(t)
 			mockRefreshTokenRepo.AssertExpectations(t)
+			mockSessionRepo.AssertExpectations(t)
+			mockTeamRepo.AssertExpectations(t)
+			mockMfaRepo.AssertExpectations(t)
+			mockLoginAttemptRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_RefreshToken(t *testing.T) {
+	userId := uuid.New()
+	tokenId := uuid.New()
+	familyId := uuid.New()
+	teamId := uuid.New()
+	otherTeamId := uuid.New()
+
+	user := models.User{
+		ID:       userId,
+		Username: "testuser",
+		Email:    "test@example.com",
+		Role:     models.UserrolesMember,
+		TeamId:   teamId,
+	}
+
+	refreshJWT, _, _ := utils.GenerateJWTRefreshToken(user, familyId)
+	unknownRefreshJWT, _, _ := utils.GenerateJWTRefreshToken(user, uuid.New())
+	accessJWT, _ := utils.GenerateJWTToken(user, familyId)
+
+	validToken := models.RefreshTokenWithUser{
+		ID:        tokenId,
+		Userid:    userId,
+		Token:     refreshJWT,
+		ExpiresAt: time.Now().UTC().Add(time.Hour),
+		CreatedAt: time.Now().UTC(),
+		FamilyID:  familyId,
+		UserData:  user,
+	}
+
+	usedToken := validToken
+	usedToken.UsedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+
+	revokedToken := validToken
+	revokedToken.Revoked = true
+
+	expiredToken := validToken
+	expiredToken.ExpiresAt = time.Now().UTC().Add(-time.Hour)
+
+	tests := []struct {
+		name                string
+		requestBody         map[string]interface{}
+		setupMocks          func(*mocks.MockRefreshTokenRepository, *mocks.MockSessionRepository, *mocks.MockTeamRepository)
+		expectedStatus      int
+		expectNewRefreshJWT bool
+		expectedTeamId      string
+		expectedRole        string
+	}{
+		{
+			name:        "Missing refresh token",
+			requestBody: map[string]interface{}{},
+			setupMocks: func(mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockTeamRepo *mocks.MockTeamRepository) {
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "Malformed refresh token",
+			requestBody: map[string]interface{}{
+				"refreshToken": "not-a-jwt",
+			},
+			setupMocks: func(mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockTeamRepo *mocks.MockTeamRepository) {
+			},
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name: "Access token used as refresh token",
+			requestBody: map[string]interface{}{
+				"refreshToken": accessJWT,
+			},
+			setupMocks: func(mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockTeamRepo *mocks.MockTeamRepository) {
+			},
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name: "Refresh token not found",
+			requestBody: map[string]interface{}{
+				"refreshToken": unknownRefreshJWT,
+			},
+			setupMocks: func(mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockTeamRepo *mocks.MockTeamRepository) {
+				mockRefreshTokenRepo.On("GetRefreshTokenByToken", mock.Anything, unknownRefreshJWT).Return(models.RefreshTokenWithUser{}, sql.ErrNoRows)
+			},
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name: "Revoked refresh token",
+			requestBody: map[string]interface{}{
+				"refreshToken": refreshJWT,
+			},
+			setupMocks: func(mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockTeamRepo *mocks.MockTeamRepository) {
+				mockRefreshTokenRepo.On("GetRefreshTokenByToken", mock.Anything, refreshJWT).Return(revokedToken, nil)
+			},
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name: "Expired refresh token",
+			requestBody: map[string]interface{}{
+				"refreshToken": refreshJWT,
+			},
+			setupMocks: func(mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockTeamRepo *mocks.MockTeamRepository) {
+				mockRefreshTokenRepo.On("GetRefreshTokenByToken", mock.Anything, refreshJWT).Return(expiredToken, nil)
+			},
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name: "Reused refresh token revokes the session",
+			requestBody: map[string]interface{}{
+				"refreshToken": refreshJWT,
+			},
+			setupMocks: func(mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockTeamRepo *mocks.MockTeamRepository) {
+				mockRefreshTokenRepo.On("GetRefreshTokenByToken", mock.Anything, refreshJWT).Return(usedToken, nil)
+				mockRefreshTokenRepo.On("RevokeRefreshTokenFamily", mock.Anything, familyId).Return(nil)
+				mockSessionRepo.On("DeleteSession", mock.Anything, familyId).Return(nil)
+			},
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name: "Concurrent rotation revokes the session",
+			requestBody: map[string]interface{}{
+				"refreshToken": refreshJWT,
+			},
+			setupMocks: func(mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockTeamRepo *mocks.MockTeamRepository) {
+				mockRefreshTokenRepo.On("GetRefreshTokenByToken", mock.Anything, refreshJWT).Return(validToken, nil)
+				mockRefreshTokenRepo.On("RotateRefreshToken", mock.Anything, tokenId, mock.AnythingOfType("models.RefreshToken")).Return(false, nil)
+				mockRefreshTokenRepo.On("RevokeRefreshTokenFamily", mock.Anything, familyId).Return(nil)
+				mockSessionRepo.On("DeleteSession", mock.Anything, familyId).Return(nil)
+			},
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name: "Error deleting the session of a reused refresh token",
+			requestBody: map[string]interface{}{
+				"refreshToken": refreshJWT,
+			},
+			setupMocks: func(mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockTeamRepo *mocks.MockTeamRepository) {
+				mockRefreshTokenRepo.On("GetRefreshTokenByToken", mock.Anything, refreshJWT).Return(usedToken, nil)
+				mockRefreshTokenRepo.On("RevokeRefreshTokenFamily", mock.Anything, familyId).Return(nil)
+				mockSessionRepo.On("DeleteSession", mock.Anything, familyId).Return(sql.ErrConnDone)
+			},
+			expectedStatus: fiber.StatusInternalServerError,
+		},
+		{
+			name: "Successful rotation",
+			requestBody: map[string]interface{}{
+				"refreshToken": refreshJWT,
+			},
+			setupMocks: func(mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockTeamRepo *mocks.MockTeamRepository) {
+				mockRefreshTokenRepo.On("GetRefreshTokenByToken", mock.Anything, refreshJWT).Return(validToken, nil)
+				mockRefreshTokenRepo.On("RotateRefreshToken", mock.Anything, tokenId, mock.MatchedBy(func(token models.RefreshToken) bool {
+					return token.FamilyID == familyId && token.Userid == userId && token.Token != refreshJWT
+				})).Return(true, nil)
+				mockSessionRepo.On("UpdateSessionLastUsed", mock.Anything, mock.MatchedBy(func(data interfaces.UpdateSessionLastUsedData) bool {
+					return data.ID == familyId
+				})).Return(nil)
+				mockSessionRepo.On("GetSessionById", mock.Anything, familyId).Return(models.Session{ID: familyId, UserID: userId, TeamID: teamId}, nil)
+			},
+			expectedStatus:      fiber.StatusOK,
+			expectNewRefreshJWT: true,
+			expectedTeamId:      teamId.String(),
+			expectedRole:        string(models.UserrolesMember),
+		},
+		{
+			name: "Rotation keeps the team the session switched to",
+			requestBody: map[string]interface{}{
+				"refreshToken": refreshJWT,
+			},
+			setupMocks: func(mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockTeamRepo *mocks.MockTeamRepository) {
+				mockRefreshTokenRepo.On("GetRefreshTokenByToken", mock.Anything, refreshJWT).Return(validToken, nil)
+				mockRefreshTokenRepo.On("RotateRefreshToken", mock.Anything, tokenId, mock.AnythingOfType("models.RefreshToken")).Return(true, nil)
+				mockSessionRepo.On("UpdateSessionLastUsed", mock.Anything, mock.AnythingOfType("interfaces.UpdateSessionLastUsedData")).Return(nil)
+				mockSessionRepo.On("GetSessionById", mock.Anything, familyId).Return(models.Session{ID: familyId, UserID: userId, TeamID: otherTeamId}, nil)
+				mockTeamRepo.On("GetTeamMember", mock.Anything, otherTeamId, userId).Return(true, models.TeamMember{
+					TeamID: otherTeamId,
+					UserID: userId,
+					Role:   models.UserrolesManager,
+					RoleId: models.BuiltInRoleIds[models.UserrolesManager],
+				}, nil)
+			},
+			expectedStatus:      fiber.StatusOK,
+			expectNewRefreshJWT: true,
+			expectedTeamId:      otherTeamId.String(),
+			expectedRole:        string(models.UserrolesManager),
+		},
+		{
+			name: "Rotation falls back to the default team after leaving the team of the session",
+			requestBody: map[string]interface{}{
+				"refreshToken": refreshJWT,
+			},
+			setupMocks: func(mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockTeamRepo *mocks.MockTeamRepository) {
+				mockRefreshTokenRepo.On("GetRefreshTokenByToken", mock.Anything, refreshJWT).Return(validToken, nil)
+				mockRefreshTokenRepo.On("RotateRefreshToken", mock.Anything, tokenId, mock.AnythingOfType("models.RefreshToken")).Return(true, nil)
+				mockSessionRepo.On("UpdateSessionLastUsed", mock.Anything, mock.AnythingOfType("interfaces.UpdateSessionLastUsedData")).Return(nil)
+				mockSessionRepo.On("GetSessionById", mock.Anything, familyId).Return(models.Session{ID: familyId, UserID: userId, TeamID: otherTeamId}, nil)
+				mockTeamRepo.On("GetTeamMember", mock.Anything, otherTeamId, userId).Return(false, models.TeamMember{}, nil)
+			},
+			expectedStatus:      fiber.StatusOK,
+			expectNewRefreshJWT: true,
+			expectedTeamId:      teamId.String(),
+			expectedRole:        string(models.UserrolesMember),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockRefreshTokenRepo := mocks.NewMockRefreshTokenRepository()
+			mockTeamRepo := mocks.NewMockTeamRepository()
+			mockSessionRepo := mocks.NewMockSessionRepository()
+
+			tt.setupMocks(mockRefreshTokenRepo, mockSessionRepo, mockTeamRepo)
+
+			handler := newTestHandler(handlerDeps{refreshTokenRepo: mockRefreshTokenRepo, teamRepo: mockTeamRepo, sessionRepo: mockSessionRepo})
+
+			app.Post("/auth/refresh-token", handler.RefreshToken)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/auth/refresh-token", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.expectNewRefreshJWT {
+				var result map[string]interface{}
+				json.NewDecoder(resp.Body).Decode(&result)
+				assert.NotEmpty(t, result["accessToken"])
+				assert.NotEmpty(t, result["refreshToken"])
+				assert.NotEqual(t, refreshJWT, result["refreshToken"])
+
+				claims, err := utils.ParseJWTToken(result["accessToken"].(string), utils.AccessTokenType)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedTeamId, claims.TeamId)
+				assert.Equal(t, tt.expectedRole, claims.Role)
+			}
+
+			mockRefreshTokenRepo.AssertExpectations(t)
+			mockSessionRepo.AssertExpectations(t)
+			mockTeamRepo.AssertExpectations(t)
 		})
 	}
 }
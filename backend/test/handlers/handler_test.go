@@ -0,0 +1,116 @@
+package handlers_test
+
+import (
+	"database/sql"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/handlers"
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/test/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// handlerDeps are the dependencies of the handler under test, the ones a test doesn't care about can be left nil
+type handlerDeps struct {
+	userRepo                interfaces.IUserRepository
+	refreshTokenRepo        interfaces.IRefreshTokenRepository
+	teamRepo                interfaces.ITeamRepository
+	projectRepo             interfaces.IProjectRepository
+	taskRepo                interfaces.ITaskRepository
+	sessionRepo             interfaces.ISessionRepository
+	passwordResetTokenRepo  interfaces.IPasswordResetTokenRepository
+	invitationRepo          interfaces.IInvitationRepository
+	mfaRepo                 interfaces.IMfaRepository
+	loginAttemptRepo        interfaces.ILoginAttemptRepository
+	personalAccessTokenRepo interfaces.IPersonalAccessTokenRepository
+	userIdentityRepo        interfaces.IUserIdentityRepository
+	revokedSessionRepo      interfaces.IRevokedSessionRepository
+	roleRepo                interfaces.IRoleRepository
+	organizationRepo        interfaces.IOrganizationRepository
+	// oidcProvider stays nil when not set, that is how the handler knows SSO is not configured
+	oidcProvider interfaces.OIDCProvider
+	mailer       interfaces.Mailer
+}
+
+// newTestHandler builds a handler with the dependencies of the test and empty mocks for the rest. The mocks
+// read on every authenticated request have no users, revoked sessions, teams or organizations unless the test sets them
+func newTestHandler(deps handlerDeps) *handlers.Handler {
+	if deps.userRepo == nil {
+		mockUserRepo := mocks.NewMockUserRepository()
+		mockUserRepo.On("GetUserById", mock.Anything, mock.Anything).Return(models.User{}, sql.ErrNoRows).Maybe()
+		deps.userRepo = mockUserRepo
+	}
+	if deps.refreshTokenRepo == nil {
+		deps.refreshTokenRepo = mocks.NewMockRefreshTokenRepository()
+	}
+	if deps.teamRepo == nil {
+		mockTeamRepo := mocks.NewMockTeamRepository()
+		mockTeamRepo.On("GetTeamByOwner", mock.Anything, mock.Anything).Return(false, models.Team{}, nil).Maybe()
+		mockTeamRepo.On("GetTeamMember", mock.Anything, mock.Anything, mock.Anything).Return(false, models.TeamMember{}, nil).Maybe()
+		deps.teamRepo = mockTeamRepo
+	}
+	if deps.projectRepo == nil {
+		deps.projectRepo = mocks.NewMockProjectRepository()
+	}
+	if deps.taskRepo == nil {
+		deps.taskRepo = mocks.NewMockTaskRepository()
+	}
+	if deps.sessionRepo == nil {
+		deps.sessionRepo = mocks.NewMockSessionRepository()
+	}
+	if deps.passwordResetTokenRepo == nil {
+		deps.passwordResetTokenRepo = mocks.NewMockPasswordResetTokenRepository()
+	}
+	if deps.invitationRepo == nil {
+		deps.invitationRepo = mocks.NewMockInvitationRepository()
+	}
+	if deps.mfaRepo == nil {
+		deps.mfaRepo = mocks.NewMockMfaRepository()
+	}
+	if deps.loginAttemptRepo == nil {
+		deps.loginAttemptRepo = mocks.NewMockLoginAttemptRepository()
+	}
+	if deps.personalAccessTokenRepo == nil {
+		deps.personalAccessTokenRepo = mocks.NewMockPersonalAccessTokenRepository()
+	}
+	if deps.userIdentityRepo == nil {
+		deps.userIdentityRepo = mocks.NewMockUserIdentityRepository()
+	}
+	if deps.revokedSessionRepo == nil {
+		mockRevokedSessionRepo := mocks.NewMockRevokedSessionRepository()
+		mockRevokedSessionRepo.On("GetRevokedSessionIds", mock.Anything, mock.Anything).Return([]uuid.UUID{}, nil).Maybe()
+		deps.revokedSessionRepo = mockRevokedSessionRepo
+	}
+	if deps.roleRepo == nil {
+		deps.roleRepo = mocks.NewMockRoleRepository()
+	}
+	if deps.organizationRepo == nil {
+		mockOrganizationRepo := mocks.NewMockOrganizationRepository()
+		mockOrganizationRepo.On("GetUserOrganizations", mock.Anything, mock.Anything).Return([]models.Organization{}, nil).Maybe()
+		deps.organizationRepo = mockOrganizationRepo
+	}
+	if deps.mailer == nil {
+		deps.mailer = mocks.NewMockMailer()
+	}
+
+	return handlers.NewHandler(
+		deps.userRepo,
+		deps.refreshTokenRepo,
+		deps.teamRepo,
+		deps.projectRepo,
+		deps.taskRepo,
+		deps.sessionRepo,
+		deps.passwordResetTokenRepo,
+		deps.invitationRepo,
+		deps.mfaRepo,
+		deps.loginAttemptRepo,
+		deps.personalAccessTokenRepo,
+		deps.userIdentityRepo,
+		deps.revokedSessionRepo,
+		deps.roleRepo,
+		deps.organizationRepo,
+		deps.oidcProvider,
+		deps.mailer,
+	)
+}
@@ -0,0 +1,611 @@
+package handlers_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
+	"github.com/TobiasRV/challenge-fs-senior/test/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_CreateInvitation(t *testing.T) {
+	adminId := uuid.New()
+	teamId := uuid.New()
+	team := models.Team{ID: teamId, Name: "Test Team", OwnerID: adminId}
+
+	invitation := models.Invitation{
+		ID:        uuid.New(),
+		TeamID:    teamId,
+		Email:     "member@example.com",
+		Role:      models.UserrolesMember,
+		TokenID:   uuid.New(),
+		InvitedBy: adminId,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	tests := []struct {
+		name           string
+		userRole       string
+		requestBody    map[string]interface{}
+		setupMocks     func(*mocks.MockUserRepository, *mocks.MockTeamRepository, *mocks.MockInvitationRepository, *mocks.MockMailer)
+		expectedStatus int
+	}{
+		{
+			name:        "Successfully invite user",
+			userRole:    "Admin",
+			requestBody: map[string]interface{}{"email": "member@example.com", "role": "Member"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockInvitationRepo *mocks.MockInvitationRepository, mockMailer *mocks.MockMailer) {
+				mockTeamRepo.On("GetTeamByOwner", mock.Anything, adminId).Return(true, team, nil)
+				mockUserRepo.On("GetUserByEmail", mock.Anything, "member@example.com").Return(models.User{}, sql.ErrNoRows)
+				mockInvitationRepo.On("GetPendingInvitationByEmail", mock.Anything, teamId, "member@example.com").Return(models.Invitation{}, sql.ErrNoRows)
+				mockInvitationRepo.On("CreateInvitation", mock.Anything, mock.MatchedBy(func(i models.Invitation) bool {
+					return i.TeamID == teamId && i.InvitedBy == adminId && i.Role == models.UserrolesMember && i.ExpiresAt.After(time.Now())
+				})).Return(invitation, nil)
+				mockMailer.On("Send", mock.Anything, mock.MatchedBy(func(email interfaces.Email) bool {
+					_, token, found := strings.Cut(email.Body, "?token=")
+					if !found || email.To != "member@example.com" {
+						return false
+					}
+
+					claims, err := utils.ParseJWTToken(strings.Fields(token)[0], utils.InvitationTokenType)
+					return err == nil && claims.Subject == invitation.ID.String() && claims.ID == invitation.TokenID.String()
+				})).Return(nil)
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name:        "Mailer error keeps the invitation",
+			userRole:    "Admin",
+			requestBody: map[string]interface{}{"email": "member@example.com", "role": "Manager"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockInvitationRepo *mocks.MockInvitationRepository, mockMailer *mocks.MockMailer) {
+				mockTeamRepo.On("GetTeamByOwner", mock.Anything, adminId).Return(true, team, nil)
+				mockUserRepo.On("GetUserByEmail", mock.Anything, "member@example.com").Return(models.User{}, sql.ErrNoRows)
+				mockInvitationRepo.On("GetPendingInvitationByEmail", mock.Anything, teamId, "member@example.com").Return(models.Invitation{}, sql.ErrNoRows)
+				mockInvitationRepo.On("CreateInvitation", mock.Anything, mock.AnythingOfType("models.Invitation")).Return(invitation, nil)
+				mockMailer.On("Send", mock.Anything, mock.AnythingOfType("interfaces.Email")).Return(errors.New("smtp unavailable"))
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name:        "Admin role can't be invited",
+			userRole:    "Admin",
+			requestBody: map[string]interface{}{"email": "member@example.com", "role": "Admin"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockInvitationRepo *mocks.MockInvitationRepository, mockMailer *mocks.MockMailer) {
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "User already exists",
+			userRole:    "Admin",
+			requestBody: map[string]interface{}{"email": "member@example.com", "role": "Member"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockInvitationRepo *mocks.MockInvitationRepository, mockMailer *mocks.MockMailer) {
+				mockTeamRepo.On("GetTeamByOwner", mock.Anything, adminId).Return(true, team, nil)
+				mockUserRepo.On("GetUserByEmail", mock.Anything, "member@example.com").Return(models.User{ID: uuid.New()}, nil)
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name:        "User already invited",
+			userRole:    "Admin",
+			requestBody: map[string]interface{}{"email": "member@example.com", "role": "Member"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockInvitationRepo *mocks.MockInvitationRepository, mockMailer *mocks.MockMailer) {
+				mockTeamRepo.On("GetTeamByOwner", mock.Anything, adminId).Return(true, team, nil)
+				mockUserRepo.On("GetUserByEmail", mock.Anything, "member@example.com").Return(models.User{}, sql.ErrNoRows)
+				mockInvitationRepo.On("GetPendingInvitationByEmail", mock.Anything, teamId, "member@example.com").Return(models.Invitation{ID: uuid.New()}, nil)
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name:        "Admin without team",
+			userRole:    "Admin",
+			requestBody: map[string]interface{}{"email": "member@example.com", "role": "Member"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockInvitationRepo *mocks.MockInvitationRepository, mockMailer *mocks.MockMailer) {
+				mockTeamRepo.On("GetTeamByOwner", mock.Anything, adminId).Return(false, models.Team{}, nil)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:        "Non-admin user",
+			userRole:    "Manager",
+			requestBody: map[string]interface{}{"email": "member@example.com", "role": "Member"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockInvitationRepo *mocks.MockInvitationRepository, mockMailer *mocks.MockMailer) {
+			},
+			expectedStatus: fiber.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockUserRepo := mocks.NewMockUserRepository()
+			mockTeamRepo := mocks.NewMockTeamRepository()
+			mockInvitationRepo := mocks.NewMockInvitationRepository()
+			mockMailer := mocks.NewMockMailer()
+
+			tt.setupMocks(mockUserRepo, mockTeamRepo, mockInvitationRepo, mockMailer)
+
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, teamRepo: mockTeamRepo, invitationRepo: mockInvitationRepo, mailer: mockMailer})
+
+			app.Post("/invitations", func(c *fiber.Ctx) error {
+				c.Locals("userRole", tt.userRole)
+				c.Locals("userId", adminId.String())
+				return handler.CreateInvitation(c)
+			})
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/invitations", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockUserRepo.AssertExpectations(t)
+			mockTeamRepo.AssertExpectations(t)
+			mockInvitationRepo.AssertExpectations(t)
+			mockMailer.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_GetInvitations(t *testing.T) {
+	adminId := uuid.New()
+	teamId := uuid.New()
+
+	tests := []struct {
+		name           string
+		userRole       string
+		setupMocks     func(*mocks.MockTeamRepository, *mocks.MockInvitationRepository)
+		expectedStatus int
+		expectedCount  int
+	}{
+		{
+			name:     "Successfully list pending invitations",
+			userRole: "Admin",
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository, mockInvitationRepo *mocks.MockInvitationRepository) {
+				mockTeamRepo.On("GetTeamByOwner", mock.Anything, adminId).Return(true, models.Team{ID: teamId, OwnerID: adminId}, nil)
+				mockInvitationRepo.On("GetPendingInvitationsByTeam", mock.Anything, teamId).Return([]models.Invitation{
+					{ID: uuid.New(), TeamID: teamId, Email: "a@example.com"},
+					{ID: uuid.New(), TeamID: teamId, Email: "b@example.com"},
+				}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+			expectedCount:  2,
+		},
+		{
+			name:     "Admin without team",
+			userRole: "Admin",
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository, mockInvitationRepo *mocks.MockInvitationRepository) {
+				mockTeamRepo.On("GetTeamByOwner", mock.Anything, adminId).Return(false, models.Team{}, nil)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:     "Non-admin user",
+			userRole: "Member",
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository, mockInvitationRepo *mocks.MockInvitationRepository) {
+			},
+			expectedStatus: fiber.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockTeamRepo := mocks.NewMockTeamRepository()
+			mockInvitationRepo := mocks.NewMockInvitationRepository()
+
+			tt.setupMocks(mockTeamRepo, mockInvitationRepo)
+
+			handler := newTestHandler(handlerDeps{teamRepo: mockTeamRepo, invitationRepo: mockInvitationRepo})
+
+			app.Get("/invitations", func(c *fiber.Ctx) error {
+				c.Locals("userRole", tt.userRole)
+				c.Locals("userId", adminId.String())
+				return handler.GetInvitations(c)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/invitations", nil)
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.expectedStatus == fiber.StatusOK {
+				var result interfaces.InvitationsListResponse
+				json.NewDecoder(resp.Body).Decode(&result)
+				assert.Len(t, result.Data, tt.expectedCount)
+			}
+
+			mockTeamRepo.AssertExpectations(t)
+			mockInvitationRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_ResendInvitation(t *testing.T) {
+	adminId := uuid.New()
+	teamId := uuid.New()
+	invitationId := uuid.New()
+	team := models.Team{ID: teamId, Name: "Test Team", OwnerID: adminId}
+
+	invitation := models.Invitation{
+		ID:        invitationId,
+		TeamID:    teamId,
+		Email:     "member@example.com",
+		Role:      models.UserrolesMember,
+		TokenID:   uuid.New(),
+		ExpiresAt: time.Now().Add(-time.Hour),
+	}
+
+	renewed := invitation
+	renewed.TokenID = uuid.New()
+	renewed.ExpiresAt = time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name           string
+		userRole       string
+		invitationId   string
+		setupMocks     func(*mocks.MockTeamRepository, *mocks.MockInvitationRepository, *mocks.MockMailer)
+		expectedStatus int
+	}{
+		{
+			name:         "Successfully resend invitation",
+			userRole:     "Admin",
+			invitationId: invitationId.String(),
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository, mockInvitationRepo *mocks.MockInvitationRepository, mockMailer *mocks.MockMailer) {
+				mockTeamRepo.On("GetTeamByOwner", mock.Anything, adminId).Return(true, team, nil)
+				mockInvitationRepo.On("GetInvitationById", mock.Anything, invitationId).Return(invitation, nil)
+				mockInvitationRepo.On("RenewInvitation", mock.Anything, mock.MatchedBy(func(data interfaces.RenewInvitationData) bool {
+					return data.ID == invitationId && data.TokenID != invitation.TokenID && data.ExpiresAt.After(time.Now())
+				})).Return(renewed, nil)
+				mockMailer.On("Send", mock.Anything, mock.MatchedBy(func(email interfaces.Email) bool {
+					_, token, found := strings.Cut(email.Body, "?token=")
+					if !found {
+						return false
+					}
+
+					claims, err := utils.ParseJWTToken(strings.Fields(token)[0], utils.InvitationTokenType)
+					return err == nil && claims.ID == renewed.TokenID.String()
+				})).Return(nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:         "Invitation from another team",
+			userRole:     "Admin",
+			invitationId: invitationId.String(),
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository, mockInvitationRepo *mocks.MockInvitationRepository, mockMailer *mocks.MockMailer) {
+				mockTeamRepo.On("GetTeamByOwner", mock.Anything, adminId).Return(true, models.Team{ID: uuid.New(), OwnerID: adminId}, nil)
+				mockInvitationRepo.On("GetInvitationById", mock.Anything, invitationId).Return(invitation, nil)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:         "Invitation already accepted",
+			userRole:     "Admin",
+			invitationId: invitationId.String(),
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository, mockInvitationRepo *mocks.MockInvitationRepository, mockMailer *mocks.MockMailer) {
+				accepted := invitation
+				accepted.AcceptedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+				mockTeamRepo.On("GetTeamByOwner", mock.Anything, adminId).Return(true, team, nil)
+				mockInvitationRepo.On("GetInvitationById", mock.Anything, invitationId).Return(accepted, nil)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:         "Mailer error",
+			userRole:     "Admin",
+			invitationId: invitationId.String(),
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository, mockInvitationRepo *mocks.MockInvitationRepository, mockMailer *mocks.MockMailer) {
+				mockTeamRepo.On("GetTeamByOwner", mock.Anything, adminId).Return(true, team, nil)
+				mockInvitationRepo.On("GetInvitationById", mock.Anything, invitationId).Return(invitation, nil)
+				mockInvitationRepo.On("RenewInvitation", mock.Anything, mock.AnythingOfType("interfaces.RenewInvitationData")).Return(renewed, nil)
+				mockMailer.On("Send", mock.Anything, mock.AnythingOfType("interfaces.Email")).Return(errors.New("smtp unavailable"))
+			},
+			expectedStatus: fiber.StatusInternalServerError,
+		},
+		{
+			name:         "Non-admin user",
+			userRole:     "Manager",
+			invitationId: invitationId.String(),
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository, mockInvitationRepo *mocks.MockInvitationRepository, mockMailer *mocks.MockMailer) {
+			},
+			expectedStatus: fiber.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockTeamRepo := mocks.NewMockTeamRepository()
+			mockInvitationRepo := mocks.NewMockInvitationRepository()
+			mockMailer := mocks.NewMockMailer()
+
+			tt.setupMocks(mockTeamRepo, mockInvitationRepo, mockMailer)
+
+			handler := newTestHandler(handlerDeps{teamRepo: mockTeamRepo, invitationRepo: mockInvitationRepo, mailer: mockMailer})
+
+			app.Post("/invitations/:id/resend", func(c *fiber.Ctx) error {
+				c.Locals("userRole", tt.userRole)
+				c.Locals("userId", adminId.String())
+				return handler.ResendInvitation(c)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/invitations/"+tt.invitationId+"/resend", nil)
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockTeamRepo.AssertExpectations(t)
+			mockInvitationRepo.AssertExpectations(t)
+			mockMailer.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_RevokeInvitation(t *testing.T) {
+	adminId := uuid.New()
+	teamId := uuid.New()
+	invitationId := uuid.New()
+
+	invitation := models.Invitation{
+		ID:     invitationId,
+		TeamID: teamId,
+		Email:  "member@example.com",
+	}
+
+	tests := []struct {
+		name           string
+		userRole       string
+		invitationId   string
+		setupMocks     func(*mocks.MockTeamRepository, *mocks.MockInvitationRepository)
+		expectedStatus int
+	}{
+		{
+			name:         "Successfully revoke invitation",
+			userRole:     "Admin",
+			invitationId: invitationId.String(),
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository, mockInvitationRepo *mocks.MockInvitationRepository) {
+				mockTeamRepo.On("GetTeamByOwner", mock.Anything, adminId).Return(true, models.Team{ID: teamId, OwnerID: adminId}, nil)
+				mockInvitationRepo.On("GetInvitationById", mock.Anything, invitationId).Return(invitation, nil)
+				mockInvitationRepo.On("DeleteInvitation", mock.Anything, invitationId).Return(nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:         "Invitation not found",
+			userRole:     "Admin",
+			invitationId: invitationId.String(),
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository, mockInvitationRepo *mocks.MockInvitationRepository) {
+				mockTeamRepo.On("GetTeamByOwner", mock.Anything, adminId).Return(true, models.Team{ID: teamId, OwnerID: adminId}, nil)
+				mockInvitationRepo.On("GetInvitationById", mock.Anything, invitationId).Return(models.Invitation{}, sql.ErrNoRows)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:         "Invalid invitation ID",
+			userRole:     "Admin",
+			invitationId: "invalid-uuid",
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository, mockInvitationRepo *mocks.MockInvitationRepository) {
+			},
+			expectedStatus: fiber.StatusInternalServerError,
+		},
+		{
+			name:         "Non-admin user",
+			userRole:     "Member",
+			invitationId: invitationId.String(),
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository, mockInvitationRepo *mocks.MockInvitationRepository) {
+			},
+			expectedStatus: fiber.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockTeamRepo := mocks.NewMockTeamRepository()
+			mockInvitationRepo := mocks.NewMockInvitationRepository()
+
+			tt.setupMocks(mockTeamRepo, mockInvitationRepo)
+
+			handler := newTestHandler(handlerDeps{teamRepo: mockTeamRepo, invitationRepo: mockInvitationRepo})
+
+			app.Delete("/invitations/:id", func(c *fiber.Ctx) error {
+				c.Locals("userRole", tt.userRole)
+				c.Locals("userId", adminId.String())
+				return handler.RevokeInvitation(c)
+			})
+
+			req := httptest.NewRequest(http.MethodDelete, "/invitations/"+tt.invitationId, nil)
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockTeamRepo.AssertExpectations(t)
+			mockInvitationRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_AcceptInvitation(t *testing.T) {
+	teamId := uuid.New()
+	invitationId := uuid.New()
+	tokenId := uuid.New()
+	userId := uuid.New()
+
+	invitation := models.Invitation{
+		ID:        invitationId,
+		TeamID:    teamId,
+		Email:     "member@example.com",
+		Role:      models.UserrolesMember,
+		TokenID:   tokenId,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	token, err := utils.GenerateInvitationToken(invitation)
+	assert.NoError(t, err)
+
+	// Token of a link sent before the invitation was resent
+	staleInvitation := invitation
+	staleInvitation.TokenID = uuid.New()
+	staleToken, err := utils.GenerateInvitationToken(staleInvitation)
+	assert.NoError(t, err)
+
+	accessToken, err := utils.GenerateJWTToken(models.User{ID: userId, Role: models.UserrolesMember}, uuid.New())
+	assert.NoError(t, err)
+
+	newUser := models.User{
+		ID:       userId,
+		Username: "member",
+		Email:    "member@example.com",
+		Role:     models.UserrolesMember,
+		TeamId:   teamId,
+	}
+
+	tests := []struct {
+		name           string
+		requestBody    map[string]interface{}
+		requireMfa     bool
+		setupMocks     func(*mocks.MockUserRepository, *mocks.MockRefreshTokenRepository, *mocks.MockSessionRepository, *mocks.MockInvitationRepository)
+		expectedStatus int
+	}{
+		{
+			name:        "Successfully accept invitation",
+			requestBody: map[string]interface{}{"token": token, "username": "member", "password": "password123"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockInvitationRepo *mocks.MockInvitationRepository) {
+				mockInvitationRepo.On("GetInvitationById", mock.Anything, invitationId).Return(invitation, nil)
+				mockUserRepo.On("GetUserByEmail", mock.Anything, "member@example.com").Return(models.User{}, sql.ErrNoRows)
+				mockInvitationRepo.On("AcceptInvitation", mock.Anything, mock.MatchedBy(func(data interfaces.AcceptInvitationData) bool {
+					return data.InvitationID == invitationId && data.TokenID == tokenId && data.Username == "member" && data.Password != "password123"
+				})).Return(newUser, true, nil)
+				mockSessionRepo.On("CreateSession", mock.Anything, mock.AnythingOfType("models.Session")).Return(models.Session{}, nil)
+				mockRefreshTokenRepo.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("models.RefreshToken")).Return(nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:        "Team requires 2FA - no session until the user sets it up",
+			requestBody: map[string]interface{}{"token": token, "username": "member", "password": "password123"},
+			requireMfa:  true,
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockInvitationRepo *mocks.MockInvitationRepository) {
+				mockInvitationRepo.On("GetInvitationById", mock.Anything, invitationId).Return(invitation, nil)
+				mockUserRepo.On("GetUserByEmail", mock.Anything, "member@example.com").Return(models.User{}, sql.ErrNoRows)
+				mockInvitationRepo.On("AcceptInvitation", mock.Anything, mock.AnythingOfType("interfaces.AcceptInvitationData")).Return(newUser, true, nil)
+			},
+			expectedStatus: fiber.StatusAccepted,
+		},
+		{
+			name:        "Link replaced by a resend",
+			requestBody: map[string]interface{}{"token": staleToken, "username": "member", "password": "password123"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockInvitationRepo *mocks.MockInvitationRepository) {
+				mockInvitationRepo.On("GetInvitationById", mock.Anything, invitationId).Return(invitation, nil)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "Revoked invitation",
+			requestBody: map[string]interface{}{"token": token, "username": "member", "password": "password123"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockInvitationRepo *mocks.MockInvitationRepository) {
+				mockInvitationRepo.On("GetInvitationById", mock.Anything, invitationId).Return(models.Invitation{}, sql.ErrNoRows)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "Invitation accepted by another request",
+			requestBody: map[string]interface{}{"token": token, "username": "member", "password": "password123"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockInvitationRepo *mocks.MockInvitationRepository) {
+				mockInvitationRepo.On("GetInvitationById", mock.Anything, invitationId).Return(invitation, nil)
+				mockUserRepo.On("GetUserByEmail", mock.Anything, "member@example.com").Return(models.User{}, sql.ErrNoRows)
+				mockInvitationRepo.On("AcceptInvitation", mock.Anything, mock.AnythingOfType("interfaces.AcceptInvitationData")).Return(models.User{}, false, nil)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "User already exists",
+			requestBody: map[string]interface{}{"token": token, "username": "member", "password": "password123"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockInvitationRepo *mocks.MockInvitationRepository) {
+				mockInvitationRepo.On("GetInvitationById", mock.Anything, invitationId).Return(invitation, nil)
+				mockUserRepo.On("GetUserByEmail", mock.Anything, "member@example.com").Return(models.User{ID: uuid.New()}, nil)
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name:        "Access token instead of invitation",
+			requestBody: map[string]interface{}{"token": accessToken, "username": "member", "password": "password123"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockInvitationRepo *mocks.MockInvitationRepository) {
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "Missing password",
+			requestBody: map[string]interface{}{"token": token, "username": "member"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockInvitationRepo *mocks.MockInvitationRepository) {
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockUserRepo := mocks.NewMockUserRepository()
+			mockRefreshTokenRepo := mocks.NewMockRefreshTokenRepository()
+			mockTeamRepo := mocks.NewMockTeamRepository()
+			mockSessionRepo := mocks.NewMockSessionRepository()
+			mockInvitationRepo := mocks.NewMockInvitationRepository()
+			mockMfaRepo := mocks.NewMockMfaRepository()
+
+			tt.setupMocks(mockUserRepo, mockRefreshTokenRepo, mockSessionRepo, mockInvitationRepo)
+			mockMfaRepo.On("GetUserTotp", mock.Anything, userId).Return(models.UserTotp{}, sql.ErrNoRows).Maybe()
+			mockMfaRepo.On("CreateMfaPendingToken", mock.Anything, mock.AnythingOfType("models.MfaPendingToken")).Return(nil).Maybe()
+			mockTeamRepo.On("GetTeamById", mock.Anything, teamId).Return(models.Team{ID: teamId, RequireMfa: tt.requireMfa}, nil).Maybe()
+
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, refreshTokenRepo: mockRefreshTokenRepo, teamRepo: mockTeamRepo, sessionRepo: mockSessionRepo, invitationRepo: mockInvitationRepo, mfaRepo: mockMfaRepo})
+
+			app.Post("/invitations/accept", handler.AcceptInvitation)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/invitations/accept", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.expectedStatus == fiber.StatusAccepted {
+				var result interfaces.MfaLoginResponse
+				json.NewDecoder(resp.Body).Decode(&result)
+				assert.True(t, result.MfaRequired)
+				assert.NotEmpty(t, result.MfaToken)
+				mockSessionRepo.AssertNotCalled(t, "CreateSession", mock.Anything, mock.Anything)
+			}
+
+			mockUserRepo.AssertExpectations(t)
+			mockRefreshTokenRepo.AssertExpectations(t)
+			mockSessionRepo.AssertExpectations(t)
+			mockInvitationRepo.AssertExpectations(t)
+		})
+	}
+}
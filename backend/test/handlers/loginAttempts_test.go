@@ -0,0 +1,124 @@
+package handlers_test
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/test/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_UnlockUser(t *testing.T) {
+	adminId := uuid.New()
+	memberId := uuid.New()
+	teamId := uuid.New()
+	otherTeamId := uuid.New()
+
+	memberKey := interfaces.LoginAttemptKey{
+		Scope:      models.LoginAttemptScopeAccount,
+		Identifier: "member@example.com",
+	}
+
+	tests := []struct {
+		name           string
+		userRole       string
+		targetId       string
+		setupMocks     func(*mocks.MockUserRepository, *mocks.MockTeamRepository, *mocks.MockLoginAttemptRepository)
+		expectedStatus int
+	}{
+		{
+			name:     "Unauthorized - non-admin user",
+			userRole: "Manager",
+			targetId: memberId.String(),
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+			},
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:     "Successfully unlock a team member",
+			userRole: "Admin",
+			targetId: memberId.String(),
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, memberId).Return(models.User{
+					ID:     memberId,
+					Email:  "Member@Example.com",
+					Role:   models.UserrolesMember,
+					TeamId: teamId,
+				}, nil)
+				mockTeamRepo.On("GetTeamByOwner", mock.Anything, adminId).Return(true, models.Team{ID: teamId, OwnerID: adminId}, nil)
+				mockLoginAttemptRepo.On("ClearLoginAttempts", mock.Anything, memberKey).Return(nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:     "User from another team",
+			userRole: "Admin",
+			targetId: memberId.String(),
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, memberId).Return(models.User{
+					ID:     memberId,
+					Email:  "member@example.com",
+					Role:   models.UserrolesMember,
+					TeamId: otherTeamId,
+				}, nil)
+				mockTeamRepo.On("GetTeamByOwner", mock.Anything, adminId).Return(true, models.Team{ID: teamId, OwnerID: adminId}, nil)
+				mockTeamRepo.On("GetTeamMember", mock.Anything, teamId, memberId).Return(false, models.TeamMember{}, nil)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:     "User not found",
+			userRole: "Admin",
+			targetId: memberId.String(),
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, memberId).Return(models.User{}, sql.ErrNoRows)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:     "Invalid id",
+			userRole: "Admin",
+			targetId: "not-a-uuid",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+			},
+			expectedStatus: fiber.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockUserRepo := mocks.NewMockUserRepository()
+			mockTeamRepo := mocks.NewMockTeamRepository()
+			mockLoginAttemptRepo := mocks.NewMockLoginAttemptRepository()
+
+			tt.setupMocks(mockUserRepo, mockTeamRepo, mockLoginAttemptRepo)
+
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, teamRepo: mockTeamRepo, loginAttemptRepo: mockLoginAttemptRepo})
+
+			app.Post("/users/:id/unlock", func(c *fiber.Ctx) error {
+				c.Locals("userRole", tt.userRole)
+				c.Locals("userId", adminId.String())
+				return handler.UnlockUser(c)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/users/"+tt.targetId+"/unlock", nil)
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockUserRepo.AssertExpectations(t)
+			mockTeamRepo.AssertExpectations(t)
+			mockLoginAttemptRepo.AssertExpectations(t)
+		})
+	}
+}
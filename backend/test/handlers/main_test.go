@@ -0,0 +1,36 @@
+package handlers_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testSigningKeyId = "test-key"
+
+// TestMain loads a throwaway signing key so handlers can issue tokens
+func TestMain(m *testing.M) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	keySet, err := utils.NewJWTKeySet(testSigningKeyId, utils.JWTKey{
+		ID:         testSigningKeyId,
+		Method:     jwt.SigningMethodEdDSA,
+		PrivateKey: privateKey,
+		PublicKey:  privateKey.Public(),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	utils.SetJWTKeySet(keySet)
+
+	os.Exit(m.Run())
+}
@@ -0,0 +1,478 @@
+package handlers_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
+	"github.com/TobiasRV/challenge-fs-senior/test/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_VerifyMfa(t *testing.T) {
+	userId := uuid.New()
+	sessionId := uuid.New()
+	secret, _ := utils.GenerateTOTPSecret()
+	code, _ := utils.TOTPCode(secret, utils.TOTPStep(time.Now()))
+
+	user := models.User{
+		ID:    userId,
+		Email: "test@example.com",
+		Role:  models.UserrolesMember,
+	}
+
+	enabledTotp := models.UserTotp{
+		UserID:    userId,
+		Secret:    secret,
+		EnabledAt: sql.NullTime{Time: time.Now(), Valid: true},
+	}
+
+	tokenId := uuid.New()
+	now := time.Now().UTC()
+	mfaToken, _ := utils.GenerateMfaPendingToken(models.MfaPendingToken{ID: tokenId, UserID: userId, ExpiresAt: now.Add(5 * time.Minute), CreatedAt: now})
+	accessToken, _ := utils.GenerateJWTToken(user, sessionId)
+
+	tests := []struct {
+		name           string
+		requestBody    map[string]interface{}
+		setupMocks     func(*mocks.MockUserRepository, *mocks.MockRefreshTokenRepository, *mocks.MockSessionRepository, *mocks.MockMfaRepository, *mocks.MockLoginAttemptRepository)
+		expectedStatus int
+	}{
+		{
+			name:        "Successfully verify authenticator code",
+			requestBody: map[string]interface{}{"mfaToken": mfaToken, "code": code},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockMfaRepo *mocks.MockMfaRepository, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(user, nil)
+				mockMfaRepo.On("GetUserTotp", mock.Anything, userId).Return(enabledTotp, nil)
+				mockMfaRepo.On("UseTotpStep", mock.Anything, mock.MatchedBy(func(data interfaces.UseTotpStepData) bool {
+					return data.UserID == userId
+				})).Return(true, nil)
+				mockMfaRepo.On("UseMfaPendingToken", mock.Anything, mock.MatchedBy(func(data interfaces.UseMfaPendingTokenData) bool {
+					return data.ID == tokenId && data.UserID == userId
+				})).Return(true, nil)
+				mockLoginAttemptRepo.On("ClearLoginAttempts", mock.Anything, interfaces.LoginAttemptKey{Scope: models.LoginAttemptScopeAccount, Identifier: "mfa:" + userId.String()}).Return(nil)
+				mockSessionRepo.On("CreateSession", mock.Anything, mock.AnythingOfType("models.Session")).Return(models.Session{ID: sessionId, UserID: userId}, nil)
+				mockRefreshTokenRepo.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("models.RefreshToken")).Return(nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:        "Successfully verify recovery code",
+			requestBody: map[string]interface{}{"mfaToken": mfaToken, "code": "ABCDE-FGHJK"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockMfaRepo *mocks.MockMfaRepository, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(user, nil)
+				mockMfaRepo.On("GetUserTotp", mock.Anything, userId).Return(enabledTotp, nil)
+				mockMfaRepo.On("UseRecoveryCode", mock.Anything, mock.MatchedBy(func(data interfaces.UseRecoveryCodeData) bool {
+					return data.UserID == userId && data.CodeHash == utils.HashRecoveryCode("abcde-fghjk")
+				})).Return(true, nil)
+				mockMfaRepo.On("UseMfaPendingToken", mock.Anything, mock.MatchedBy(func(data interfaces.UseMfaPendingTokenData) bool {
+					return data.ID == tokenId && data.UserID == userId
+				})).Return(true, nil)
+				mockLoginAttemptRepo.On("ClearLoginAttempts", mock.Anything, interfaces.LoginAttemptKey{Scope: models.LoginAttemptScopeAccount, Identifier: "mfa:" + userId.String()}).Return(nil)
+				mockSessionRepo.On("CreateSession", mock.Anything, mock.AnythingOfType("models.Session")).Return(models.Session{ID: sessionId, UserID: userId}, nil)
+				mockRefreshTokenRepo.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("models.RefreshToken")).Return(nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:        "Code already used",
+			requestBody: map[string]interface{}{"mfaToken": mfaToken, "code": code},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockMfaRepo *mocks.MockMfaRepository, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(user, nil)
+				mockMfaRepo.On("GetUserTotp", mock.Anything, userId).Return(enabledTotp, nil)
+				mockMfaRepo.On("UseTotpStep", mock.Anything, mock.AnythingOfType("interfaces.UseTotpStepData")).Return(false, nil)
+				mockLoginAttemptRepo.On("RecordFailedLogin", mock.Anything, mock.MatchedBy(func(data interfaces.RecordFailedLoginData) bool {
+					return data.AccountKey == "mfa:"+userId.String() && data.IpAddress == "mfa:0.0.0.0"
+				})).Return([]models.LoginAttempt{}, nil)
+			},
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name:        "Invalid code",
+			requestBody: map[string]interface{}{"mfaToken": mfaToken, "code": "not-a-code"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockMfaRepo *mocks.MockMfaRepository, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(user, nil)
+				mockMfaRepo.On("GetUserTotp", mock.Anything, userId).Return(enabledTotp, nil)
+				mockMfaRepo.On("UseRecoveryCode", mock.Anything, mock.AnythingOfType("interfaces.UseRecoveryCodeData")).Return(false, nil)
+				mockLoginAttemptRepo.On("RecordFailedLogin", mock.Anything, mock.MatchedBy(func(data interfaces.RecordFailedLoginData) bool {
+					return data.AccountKey == "mfa:"+userId.String() && data.IpAddress == "mfa:0.0.0.0"
+				})).Return([]models.LoginAttempt{}, nil)
+			},
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name:        "Mfa token already exchanged",
+			requestBody: map[string]interface{}{"mfaToken": mfaToken, "code": code},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockMfaRepo *mocks.MockMfaRepository, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(user, nil)
+				mockMfaRepo.On("GetUserTotp", mock.Anything, userId).Return(enabledTotp, nil)
+				mockMfaRepo.On("UseTotpStep", mock.Anything, mock.AnythingOfType("interfaces.UseTotpStepData")).Return(true, nil)
+				mockLoginAttemptRepo.On("ClearLoginAttempts", mock.Anything, mock.AnythingOfType("interfaces.LoginAttemptKey")).Return(nil)
+				mockMfaRepo.On("UseMfaPendingToken", mock.Anything, mock.AnythingOfType("interfaces.UseMfaPendingTokenData")).Return(false, nil)
+			},
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name:        "Too many invalid codes",
+			requestBody: map[string]interface{}{"mfaToken": mfaToken, "code": code},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockMfaRepo *mocks.MockMfaRepository, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(user, nil)
+				mockLoginAttemptRepo.On("GetLoginAttempts", mock.Anything, interfaces.GetLoginAttemptsData{AccountKey: "mfa:" + userId.String(), IpAddress: "mfa:0.0.0.0"}).Return([]models.LoginAttempt{
+					{Scope: models.LoginAttemptScopeAccount, Identifier: "mfa:" + userId.String(), FailedCount: 10, LastFailedAt: time.Now().UTC()},
+				}, nil)
+			},
+			expectedStatus: fiber.StatusTooManyRequests,
+		},
+		{
+			name:        "Two-factor not enabled",
+			requestBody: map[string]interface{}{"mfaToken": mfaToken, "code": code},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockMfaRepo *mocks.MockMfaRepository, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(user, nil)
+				mockMfaRepo.On("GetUserTotp", mock.Anything, userId).Return(models.UserTotp{}, sql.ErrNoRows)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "Access token instead of mfa token",
+			requestBody: map[string]interface{}{"mfaToken": accessToken, "code": code},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockMfaRepo *mocks.MockMfaRepository, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+			},
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name:        "Missing code",
+			requestBody: map[string]interface{}{"mfaToken": mfaToken},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockMfaRepo *mocks.MockMfaRepository, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockUserRepo := mocks.NewMockUserRepository()
+			mockRefreshTokenRepo := mocks.NewMockRefreshTokenRepository()
+			mockSessionRepo := mocks.NewMockSessionRepository()
+			mockMfaRepo := mocks.NewMockMfaRepository()
+			mockLoginAttemptRepo := mocks.NewMockLoginAttemptRepository()
+
+			tt.setupMocks(mockUserRepo, mockRefreshTokenRepo, mockSessionRepo, mockMfaRepo, mockLoginAttemptRepo)
+			mockLoginAttemptRepo.On("GetLoginAttempts", mock.Anything, mock.Anything).Return([]models.LoginAttempt{}, nil).Maybe()
+
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, refreshTokenRepo: mockRefreshTokenRepo, sessionRepo: mockSessionRepo, mfaRepo: mockMfaRepo, loginAttemptRepo: mockLoginAttemptRepo})
+
+			app.Post("/auth/mfa/verify", handler.VerifyMfa)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/auth/mfa/verify", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.expectedStatus == fiber.StatusTooManyRequests {
+				assert.NotEmpty(t, resp.Header.Get(fiber.HeaderRetryAfter))
+				mockMfaRepo.AssertNotCalled(t, "GetUserTotp", mock.Anything, mock.Anything)
+			}
+
+			mockUserRepo.AssertExpectations(t)
+			mockRefreshTokenRepo.AssertExpectations(t)
+			mockSessionRepo.AssertExpectations(t)
+			mockMfaRepo.AssertExpectations(t)
+			mockLoginAttemptRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_ConfirmMfaEnrollment(t *testing.T) {
+	userId := uuid.New()
+	sessionId := uuid.New()
+	secret, _ := utils.GenerateTOTPSecret()
+	code, _ := utils.TOTPCode(secret, utils.TOTPStep(time.Now()))
+
+	user := models.User{
+		ID:    userId,
+		Email: "test@example.com",
+		Role:  models.UserrolesMember,
+	}
+
+	pendingTotp := models.UserTotp{
+		UserID: userId,
+		Secret: secret,
+	}
+
+	tokenId := uuid.New()
+	now := time.Now().UTC()
+	mfaToken, _ := utils.GenerateMfaPendingToken(models.MfaPendingToken{ID: tokenId, UserID: userId, ExpiresAt: now.Add(5 * time.Minute), CreatedAt: now})
+
+	tests := []struct {
+		name           string
+		requestBody    map[string]interface{}
+		setupMocks     func(*mocks.MockUserRepository, *mocks.MockRefreshTokenRepository, *mocks.MockSessionRepository, *mocks.MockMfaRepository)
+		expectedStatus int
+	}{
+		{
+			name:        "Successfully enable and log in",
+			requestBody: map[string]interface{}{"mfaToken": mfaToken, "code": code},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockMfaRepo *mocks.MockMfaRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(user, nil)
+				mockMfaRepo.On("GetUserTotp", mock.Anything, userId).Return(pendingTotp, nil)
+				mockMfaRepo.On("EnableTotp", mock.Anything, mock.MatchedBy(func(data interfaces.EnableTotpData) bool {
+					return data.UserID == userId && data.Step == utils.TOTPStep(time.Now()) && len(data.RecoveryCodes) == 10
+				})).Return(true, nil)
+				mockMfaRepo.On("UseMfaPendingToken", mock.Anything, mock.MatchedBy(func(data interfaces.UseMfaPendingTokenData) bool {
+					return data.ID == tokenId && data.UserID == userId
+				})).Return(true, nil)
+				mockSessionRepo.On("CreateSession", mock.Anything, mock.AnythingOfType("models.Session")).Return(models.Session{ID: sessionId, UserID: userId}, nil)
+				mockRefreshTokenRepo.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("models.RefreshToken")).Return(nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:        "Enrollment not started",
+			requestBody: map[string]interface{}{"mfaToken": mfaToken, "code": code},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockMfaRepo *mocks.MockMfaRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(user, nil)
+				mockMfaRepo.On("GetUserTotp", mock.Anything, userId).Return(models.UserTotp{}, sql.ErrNoRows)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "Invalid code",
+			requestBody: map[string]interface{}{"mfaToken": mfaToken, "code": "000000"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockMfaRepo *mocks.MockMfaRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(user, nil)
+				mockMfaRepo.On("GetUserTotp", mock.Anything, userId).Return(models.UserTotp{UserID: userId, Secret: "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"}, nil)
+			},
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name:        "Already enabled",
+			requestBody: map[string]interface{}{"mfaToken": mfaToken, "code": code},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository, mockMfaRepo *mocks.MockMfaRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(user, nil)
+				mockMfaRepo.On("GetUserTotp", mock.Anything, userId).Return(models.UserTotp{
+					UserID:    userId,
+					Secret:    secret,
+					EnabledAt: sql.NullTime{Time: time.Now(), Valid: true},
+				}, nil)
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockUserRepo := mocks.NewMockUserRepository()
+			mockRefreshTokenRepo := mocks.NewMockRefreshTokenRepository()
+			mockSessionRepo := mocks.NewMockSessionRepository()
+			mockMfaRepo := mocks.NewMockMfaRepository()
+
+			tt.setupMocks(mockUserRepo, mockRefreshTokenRepo, mockSessionRepo, mockMfaRepo)
+
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, refreshTokenRepo: mockRefreshTokenRepo, sessionRepo: mockSessionRepo, mfaRepo: mockMfaRepo})
+
+			app.Post("/auth/mfa/enroll/confirm", handler.ConfirmMfaEnrollment)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/auth/mfa/enroll/confirm", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.expectedStatus == fiber.StatusOK {
+				var result interfaces.ConfirmMfaEnrollmentResponse
+				json.NewDecoder(resp.Body).Decode(&result)
+				assert.Len(t, result.RecoveryCodes, 10)
+				assert.NotEmpty(t, result.AccessToken)
+			}
+
+			mockUserRepo.AssertExpectations(t)
+			mockRefreshTokenRepo.AssertExpectations(t)
+			mockSessionRepo.AssertExpectations(t)
+			mockMfaRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_StartTotp(t *testing.T) {
+	userId := uuid.New()
+
+	user := models.User{
+		ID:    userId,
+		Email: "test@example.com",
+		Role:  models.UserrolesMember,
+	}
+
+	tests := []struct {
+		name           string
+		setupMocks     func(*mocks.MockUserRepository, *mocks.MockMfaRepository)
+		expectedStatus int
+	}{
+		{
+			name: "Successfully start enrollment",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockMfaRepo *mocks.MockMfaRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(user, nil)
+				mockMfaRepo.On("StartTotpEnrollment", mock.Anything, mock.MatchedBy(func(totp models.UserTotp) bool {
+					return totp.UserID == userId && totp.Secret != ""
+				})).Return(models.UserTotp{UserID: userId}, true, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name: "Already enabled",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockMfaRepo *mocks.MockMfaRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(user, nil)
+				mockMfaRepo.On("StartTotpEnrollment", mock.Anything, mock.AnythingOfType("models.UserTotp")).Return(models.UserTotp{}, false, nil)
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockUserRepo := mocks.NewMockUserRepository()
+			mockMfaRepo := mocks.NewMockMfaRepository()
+
+			tt.setupMocks(mockUserRepo, mockMfaRepo)
+
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, mfaRepo: mockMfaRepo})
+
+			app.Post("/auth/mfa/totp", func(c *fiber.Ctx) error {
+				c.Locals("userId", userId.String())
+				return handler.StartTotp(c)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/auth/mfa/totp", nil)
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.expectedStatus == fiber.StatusOK {
+				var result interfaces.TotpEnrollmentResponse
+				json.NewDecoder(resp.Body).Decode(&result)
+				assert.NotEmpty(t, result.Secret)
+				assert.Contains(t, result.ProvisioningURI, "secret="+result.Secret)
+				assert.Equal(t, result.ProvisioningURI, result.QRPayload)
+			}
+
+			mockUserRepo.AssertExpectations(t)
+			mockMfaRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_DisableTotp(t *testing.T) {
+	userId := uuid.New()
+	teamId := uuid.New()
+	switchedTeamId := uuid.New()
+	secret, _ := utils.GenerateTOTPSecret()
+	code, _ := utils.TOTPCode(secret, utils.TOTPStep(time.Now()))
+
+	user := models.User{
+		ID:     userId,
+		Email:  "test@example.com",
+		Role:   models.UserrolesMember,
+		TeamId: teamId,
+	}
+
+	enabledTotp := models.UserTotp{
+		UserID:    userId,
+		Secret:    secret,
+		EnabledAt: sql.NullTime{Time: time.Now(), Valid: true},
+	}
+
+	tests := []struct {
+		name           string
+		sessionTeamId  uuid.UUID
+		setupMocks     func(*mocks.MockUserRepository, *mocks.MockTeamRepository, *mocks.MockMfaRepository)
+		expectedStatus int
+	}{
+		{
+			name: "Successfully disable",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockMfaRepo *mocks.MockMfaRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(user, nil)
+				mockTeamRepo.On("GetTeamById", mock.Anything, teamId).Return(models.Team{ID: teamId}, nil)
+				mockMfaRepo.On("GetUserTotp", mock.Anything, userId).Return(enabledTotp, nil)
+				mockMfaRepo.On("UseTotpStep", mock.Anything, mock.AnythingOfType("interfaces.UseTotpStepData")).Return(true, nil)
+				mockMfaRepo.On("DisableTotp", mock.Anything, userId).Return(nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name: "Required by the team",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockMfaRepo *mocks.MockMfaRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(user, nil)
+				mockTeamRepo.On("GetTeamById", mock.Anything, teamId).Return(models.Team{ID: teamId, RequireMfa: true}, nil)
+			},
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:          "Required by the team the session switched to",
+			sessionTeamId: switchedTeamId,
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockMfaRepo *mocks.MockMfaRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(user, nil)
+				mockTeamRepo.On("GetTeamMember", mock.Anything, switchedTeamId, userId).Return(true, models.TeamMember{TeamID: switchedTeamId, UserID: userId, Role: models.UserrolesMember}, nil)
+				mockTeamRepo.On("GetTeamById", mock.Anything, switchedTeamId).Return(models.Team{ID: switchedTeamId, RequireMfa: true}, nil)
+			},
+			expectedStatus: fiber.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockUserRepo := mocks.NewMockUserRepository()
+			mockTeamRepo := mocks.NewMockTeamRepository()
+			mockMfaRepo := mocks.NewMockMfaRepository()
+			mockLoginAttemptRepo := mocks.NewMockLoginAttemptRepository()
+
+			tt.setupMocks(mockUserRepo, mockTeamRepo, mockMfaRepo)
+			mockLoginAttemptRepo.On("GetLoginAttempts", mock.Anything, mock.Anything).Return([]models.LoginAttempt{}, nil).Maybe()
+			mockLoginAttemptRepo.On("ClearLoginAttempts", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, teamRepo: mockTeamRepo, mfaRepo: mockMfaRepo, loginAttemptRepo: mockLoginAttemptRepo})
+
+			app.Delete("/auth/mfa/totp", func(c *fiber.Ctx) error {
+				c.Locals("userId", userId.String())
+				if tt.sessionTeamId != uuid.Nil {
+					c.Locals("teamId", tt.sessionTeamId.String())
+				}
+				return handler.DisableTotp(c)
+			})
+
+			body, _ := json.Marshal(map[string]interface{}{"code": code})
+			req := httptest.NewRequest(http.MethodDelete, "/auth/mfa/totp", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockUserRepo.AssertExpectations(t)
+			mockTeamRepo.AssertExpectations(t)
+			mockMfaRepo.AssertExpectations(t)
+		})
+	}
+}
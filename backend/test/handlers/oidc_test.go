@@ -0,0 +1,400 @@
+package handlers_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/oidc"
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
+	"github.com/TobiasRV/challenge-fs-senior/test/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_OIDCAuthorize(t *testing.T) {
+	tests := []struct {
+		name           string
+		configured     bool
+		setupMocks     func(*mocks.MockOIDCProvider)
+		expectedStatus int
+	}{
+		{
+			name:       "Successfully start single sign-on",
+			configured: true,
+			setupMocks: func(mockOIDCProvider *mocks.MockOIDCProvider) {
+				mockOIDCProvider.On("AuthorizationURL", mock.Anything, mock.MatchedBy(func(data interfaces.OIDCAuthorizationData) bool {
+					return data.State != "" && data.Nonce != "" && data.CodeChallenge != ""
+				})).Return("https://idp.example.com/authorize?state=abc", nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:       "Identity provider unavailable",
+			configured: true,
+			setupMocks: func(mockOIDCProvider *mocks.MockOIDCProvider) {
+				mockOIDCProvider.On("AuthorizationURL", mock.Anything, mock.Anything).Return("", errors.New("connection refused"))
+			},
+			expectedStatus: fiber.StatusBadGateway,
+		},
+		{
+			name:           "Single sign-on not configured",
+			configured:     false,
+			setupMocks:     func(mockOIDCProvider *mocks.MockOIDCProvider) {},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockOIDCProvider := mocks.NewMockOIDCProvider()
+
+			tt.setupMocks(mockOIDCProvider)
+
+			var provider interfaces.OIDCProvider
+			if tt.configured {
+				provider = mockOIDCProvider
+			}
+
+			handler := newTestHandler(handlerDeps{oidcProvider: provider})
+
+			app.Get("/auth/oidc/authorize", handler.OIDCAuthorize)
+
+			req := httptest.NewRequest(http.MethodGet, "/auth/oidc/authorize", nil)
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.expectedStatus == fiber.StatusOK {
+				body, _ := io.ReadAll(resp.Body)
+
+				var response interfaces.OIDCAuthorizeResponse
+				assert.NoError(t, json.Unmarshal(body, &response))
+				assert.NotEmpty(t, response.AuthorizationURL)
+
+				claims, err := utils.ParseOIDCStateToken(response.StateToken)
+				assert.NoError(t, err)
+				assert.NotEmpty(t, claims.CodeVerifier)
+			}
+
+			mockOIDCProvider.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_OIDCCallback(t *testing.T) {
+	userId := uuid.New()
+	sessionId := uuid.New()
+	teamId := uuid.New()
+	issuer := "https://idp.example.com"
+
+	stateToken, err := utils.GenerateOIDCStateToken("state", "nonce", "verifier")
+	assert.NoError(t, err)
+
+	exchange := interfaces.OIDCExchangeData{
+		Code:         "code",
+		CodeVerifier: "verifier",
+		Nonce:        "nonce",
+	}
+
+	verifiedIdentity := interfaces.OIDCIdentity{
+		Issuer:        issuer,
+		Subject:       "idp-user-1",
+		Email:         "sso@example.com",
+		EmailVerified: true,
+		Name:          "Sso User",
+	}
+
+	identityKey := interfaces.GetUserIdentityData{
+		Issuer:  issuer,
+		Subject: "idp-user-1",
+	}
+
+	user := models.User{
+		ID:       userId,
+		Email:    "sso@example.com",
+		Username: "Sso User",
+		Role:     models.UserrolesMember,
+		TeamId:   teamId,
+	}
+
+	expectSession := func(mockSessionRepo *mocks.MockSessionRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockMfaRepo *mocks.MockMfaRepository, mockTeamRepo *mocks.MockTeamRepository) {
+		mockMfaRepo.On("GetUserTotp", mock.Anything, userId).Return(models.UserTotp{}, sql.ErrNoRows)
+		mockTeamRepo.On("GetTeamById", mock.Anything, teamId).Return(models.Team{ID: teamId}, nil)
+		mockSessionRepo.On("CreateSession", mock.Anything, mock.Anything).Return(models.Session{ID: sessionId, UserID: userId}, nil)
+		mockRefreshTokenRepo.On("CreateRefreshToken", mock.Anything, mock.Anything).Return(nil)
+	}
+
+	type repos struct {
+		user         *mocks.MockUserRepository
+		userIdentity *mocks.MockUserIdentityRepository
+		oidc         *mocks.MockOIDCProvider
+		session      *mocks.MockSessionRepository
+		refreshToken *mocks.MockRefreshTokenRepository
+		mfa          *mocks.MockMfaRepository
+		team         *mocks.MockTeamRepository
+	}
+
+	tests := []struct {
+		name           string
+		requestBody    map[string]interface{}
+		oidcTeamId     string
+		setupMocks     func(repos)
+		expectedStatus int
+	}{
+		{
+			name:        "Sign in with a linked identity",
+			requestBody: map[string]interface{}{"code": "code", "state": "state", "stateToken": stateToken},
+			setupMocks: func(r repos) {
+				r.oidc.On("Exchange", mock.Anything, exchange).Return(verifiedIdentity, nil)
+				r.userIdentity.On("GetUserIdentity", mock.Anything, identityKey).Return(models.UserIdentity{UserID: userId, Issuer: issuer, Subject: "idp-user-1"}, nil)
+				r.user.On("GetUserById", mock.Anything, userId).Return(user, nil)
+				expectSession(r.session, r.refreshToken, r.mfa, r.team)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:        "Link an existing user by verified email",
+			requestBody: map[string]interface{}{"code": "code", "state": "state", "stateToken": stateToken},
+			setupMocks: func(r repos) {
+				r.oidc.On("Exchange", mock.Anything, exchange).Return(verifiedIdentity, nil)
+				r.userIdentity.On("GetUserIdentity", mock.Anything, identityKey).Return(models.UserIdentity{}, sql.ErrNoRows)
+				r.user.On("GetUserByEmail", mock.Anything, "sso@example.com").Return(user, nil)
+				r.userIdentity.On("CreateUserIdentity", mock.Anything, mock.MatchedBy(func(identity models.UserIdentity) bool {
+					return identity.UserID == userId && identity.Issuer == issuer && identity.Subject == "idp-user-1"
+				})).Return(nil)
+				expectSession(r.session, r.refreshToken, r.mfa, r.team)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:        "Unverified email is neither linked nor provisioned",
+			requestBody: map[string]interface{}{"code": "code", "state": "state", "stateToken": stateToken},
+			oidcTeamId:  teamId.String(),
+			setupMocks: func(r repos) {
+				unverified := verifiedIdentity
+				unverified.EmailVerified = false
+				r.oidc.On("Exchange", mock.Anything, exchange).Return(unverified, nil)
+				r.userIdentity.On("GetUserIdentity", mock.Anything, identityKey).Return(models.UserIdentity{}, sql.ErrNoRows)
+			},
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:        "Provision a new user in the configured team",
+			requestBody: map[string]interface{}{"code": "code", "state": "state", "stateToken": stateToken},
+			oidcTeamId:  teamId.String(),
+			setupMocks: func(r repos) {
+				r.oidc.On("Exchange", mock.Anything, exchange).Return(verifiedIdentity, nil)
+				r.userIdentity.On("GetUserIdentity", mock.Anything, identityKey).Return(models.UserIdentity{}, sql.ErrNoRows)
+				r.user.On("GetUserByEmail", mock.Anything, "sso@example.com").Return(models.User{}, sql.ErrNoRows)
+				r.userIdentity.On("ProvisionUser", mock.Anything, mock.MatchedBy(func(data interfaces.ProvisionUserData) bool {
+					return data.User.Email == "sso@example.com" &&
+						data.User.Username == "Sso User" &&
+						data.User.Role == models.UserrolesMember &&
+						data.User.TeamId == teamId &&
+						data.User.Password != "" &&
+						data.Identity.Subject == "idp-user-1"
+				})).Return(user, nil)
+				expectSession(r.session, r.refreshToken, r.mfa, r.team)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:        "Unknown user without a provisioning team",
+			requestBody: map[string]interface{}{"code": "code", "state": "state", "stateToken": stateToken},
+			setupMocks: func(r repos) {
+				r.oidc.On("Exchange", mock.Anything, exchange).Return(verifiedIdentity, nil)
+				r.userIdentity.On("GetUserIdentity", mock.Anything, identityKey).Return(models.UserIdentity{}, sql.ErrNoRows)
+				r.user.On("GetUserByEmail", mock.Anything, "sso@example.com").Return(models.User{}, sql.ErrNoRows)
+			},
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:        "User with two-factor authentication",
+			requestBody: map[string]interface{}{"code": "code", "state": "state", "stateToken": stateToken},
+			setupMocks: func(r repos) {
+				r.oidc.On("Exchange", mock.Anything, exchange).Return(verifiedIdentity, nil)
+				r.userIdentity.On("GetUserIdentity", mock.Anything, identityKey).Return(models.UserIdentity{UserID: userId}, nil)
+				r.user.On("GetUserById", mock.Anything, userId).Return(user, nil)
+				r.mfa.On("GetUserTotp", mock.Anything, userId).Return(models.UserTotp{EnabledAt: sql.NullTime{Valid: true}}, nil)
+				r.mfa.On("CreateMfaPendingToken", mock.Anything, mock.AnythingOfType("models.MfaPendingToken")).Return(nil)
+			},
+			expectedStatus: fiber.StatusAccepted,
+		},
+		{
+			name:           "State doesn't match the state token",
+			requestBody:    map[string]interface{}{"code": "code", "state": "other-state", "stateToken": stateToken},
+			setupMocks:     func(r repos) {},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "Invalid state token",
+			requestBody:    map[string]interface{}{"code": "code", "state": "state", "stateToken": "not-a-token"},
+			setupMocks:     func(r repos) {},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "Identity provider rejects the code",
+			requestBody: map[string]interface{}{"code": "code", "state": "state", "stateToken": stateToken},
+			setupMocks: func(r repos) {
+				r.oidc.On("Exchange", mock.Anything, exchange).Return(interfaces.OIDCIdentity{}, errors.New("invalid_grant"))
+			},
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name:           "Missing code",
+			requestBody:    map[string]interface{}{"state": "state", "stateToken": stateToken},
+			setupMocks:     func(r repos) {},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OIDC_TEAM_ID", tt.oidcTeamId)
+			t.Setenv("OIDC_DEFAULT_ROLE", "")
+
+			app := setupTestApp()
+
+			mockUserRepo := mocks.NewMockUserRepository()
+			mockRefreshTokenRepo := mocks.NewMockRefreshTokenRepository()
+			mockTeamRepo := mocks.NewMockTeamRepository()
+			mockSessionRepo := mocks.NewMockSessionRepository()
+			mockMfaRepo := mocks.NewMockMfaRepository()
+			mockUserIdentityRepo := mocks.NewMockUserIdentityRepository()
+			mockOIDCProvider := mocks.NewMockOIDCProvider()
+
+			tt.setupMocks(repos{
+				user:         mockUserRepo,
+				userIdentity: mockUserIdentityRepo,
+				oidc:         mockOIDCProvider,
+				session:      mockSessionRepo,
+				refreshToken: mockRefreshTokenRepo,
+				mfa:          mockMfaRepo,
+				team:         mockTeamRepo,
+			})
+
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, refreshTokenRepo: mockRefreshTokenRepo, teamRepo: mockTeamRepo, sessionRepo: mockSessionRepo, mfaRepo: mockMfaRepo, userIdentityRepo: mockUserIdentityRepo, oidcProvider: mockOIDCProvider})
+
+			app.Post("/auth/oidc/callback", handler.OIDCCallback)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/auth/oidc/callback", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockUserRepo.AssertExpectations(t)
+			mockUserIdentityRepo.AssertExpectations(t)
+			mockOIDCProvider.AssertExpectations(t)
+			mockSessionRepo.AssertExpectations(t)
+			mockRefreshTokenRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// TestHandler_OIDCSignIn runs the whole single sign-on against the stand-in identity provider
+func TestHandler_OIDCSignIn(t *testing.T) {
+	userId := uuid.New()
+	sessionId := uuid.New()
+	teamId := uuid.New()
+
+	idp := mocks.NewMockIdentityProvider("challenge", "challenge-secret")
+	defer idp.Close()
+
+	idp.User = mocks.MockIdentityUser{
+		Subject:       "idp-user-1",
+		Email:         "sso@example.com",
+		EmailVerified: true,
+		Name:          "Sso User",
+	}
+
+	provider := oidc.NewProvider(oidc.Config{
+		IssuerURL:    idp.Issuer(),
+		ClientID:     "challenge",
+		ClientSecret: "challenge-secret",
+		RedirectURL:  "http://localhost:3000/sso/callback",
+		Scopes:       []string{"openid", "email", "profile"},
+	})
+
+	t.Setenv("OIDC_TEAM_ID", teamId.String())
+	t.Setenv("OIDC_DEFAULT_ROLE", "Manager")
+
+	app := setupTestApp()
+
+	mockUserRepo := mocks.NewMockUserRepository()
+	mockRefreshTokenRepo := mocks.NewMockRefreshTokenRepository()
+	mockTeamRepo := mocks.NewMockTeamRepository()
+	mockSessionRepo := mocks.NewMockSessionRepository()
+	mockMfaRepo := mocks.NewMockMfaRepository()
+	mockUserIdentityRepo := mocks.NewMockUserIdentityRepository()
+
+	mockUserIdentityRepo.On("GetUserIdentity", mock.Anything, interfaces.GetUserIdentityData{Issuer: idp.Issuer(), Subject: "idp-user-1"}).Return(models.UserIdentity{}, sql.ErrNoRows)
+	mockUserRepo.On("GetUserByEmail", mock.Anything, "sso@example.com").Return(models.User{}, sql.ErrNoRows)
+	mockUserIdentityRepo.On("ProvisionUser", mock.Anything, mock.MatchedBy(func(data interfaces.ProvisionUserData) bool {
+		return data.User.Role == models.UserrolesManager && data.User.TeamId == teamId && data.Identity.Issuer == idp.Issuer()
+	})).Return(models.User{ID: userId, Email: "sso@example.com", Role: models.UserrolesManager, TeamId: teamId}, nil)
+	mockMfaRepo.On("GetUserTotp", mock.Anything, userId).Return(models.UserTotp{}, sql.ErrNoRows)
+	mockTeamRepo.On("GetTeamById", mock.Anything, teamId).Return(models.Team{ID: teamId}, nil)
+	mockSessionRepo.On("CreateSession", mock.Anything, mock.Anything).Return(models.Session{ID: sessionId, UserID: userId}, nil)
+	mockRefreshTokenRepo.On("CreateRefreshToken", mock.Anything, mock.Anything).Return(nil)
+
+	handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, refreshTokenRepo: mockRefreshTokenRepo, teamRepo: mockTeamRepo, sessionRepo: mockSessionRepo, mfaRepo: mockMfaRepo, userIdentityRepo: mockUserIdentityRepo, oidcProvider: provider})
+
+	app.Get("/auth/oidc/authorize", handler.OIDCAuthorize)
+	app.Post("/auth/oidc/callback", handler.OIDCCallback)
+
+	resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/auth/oidc/authorize", nil))
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var authorize interfaces.OIDCAuthorizeResponse
+	body, _ := io.ReadAll(resp.Body)
+	assert.NoError(t, json.Unmarshal(body, &authorize))
+
+	authorizationURL, err := url.Parse(authorize.AuthorizationURL)
+	assert.NoError(t, err)
+	assert.Equal(t, "S256", authorizationURL.Query().Get("code_challenge_method"))
+
+	code, state, err := idp.SignIn(authorize.AuthorizationURL)
+	assert.NoError(t, err)
+
+	callbackBody, _ := json.Marshal(map[string]string{
+		"code":       code,
+		"state":      state,
+		"stateToken": authorize.StateToken,
+	})
+	req := httptest.NewRequest(http.MethodPost, "/auth/oidc/callback", bytes.NewReader(callbackBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ = app.Test(req)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var login interfaces.LoginResponse
+	body, _ = io.ReadAll(resp.Body)
+	assert.NoError(t, json.Unmarshal(body, &login))
+
+	claims, err := utils.ParseJWTToken(login.AccessToken, utils.AccessTokenType)
+	assert.NoError(t, err)
+	assert.Equal(t, userId.String(), claims.UserId)
+	assert.Equal(t, string(models.UserrolesManager), claims.Role)
+
+	mockUserRepo.AssertExpectations(t)
+	mockUserIdentityRepo.AssertExpectations(t)
+	mockSessionRepo.AssertExpectations(t)
+	mockRefreshTokenRepo.AssertExpectations(t)
+}
@@ -0,0 +1,523 @@
+package handlers_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/test/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_CreateOrganization(t *testing.T) {
+	adminId := uuid.New()
+	organizationId := uuid.New()
+
+	tests := []struct {
+		name           string
+		userRole       string
+		requestBody    map[string]interface{}
+		setupMocks     func(*mocks.MockOrganizationRepository)
+		expectedStatus int
+	}{
+		{
+			name:           "Managers can't create organizations",
+			userRole:       "Manager",
+			requestBody:    map[string]interface{}{"name": "Acme"},
+			setupMocks:     func(mockOrganizationRepo *mocks.MockOrganizationRepository) {},
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:           "Missing name",
+			userRole:       "Admin",
+			requestBody:    map[string]interface{}{},
+			setupMocks:     func(mockOrganizationRepo *mocks.MockOrganizationRepository) {},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "Successfully create an organization administered by the admin",
+			userRole:    "Admin",
+			requestBody: map[string]interface{}{"name": "Acme"},
+			setupMocks: func(mockOrganizationRepo *mocks.MockOrganizationRepository) {
+				mockOrganizationRepo.On("CreateOrganization", mock.Anything, mock.MatchedBy(func(data interfaces.CreateOrganizationData) bool {
+					return data.Name == "Acme" && data.AdminID == adminId
+				})).Return(models.Organization{ID: organizationId, Name: "Acme"}, nil)
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockOrganizationRepo := mocks.NewMockOrganizationRepository()
+			tt.setupMocks(mockOrganizationRepo)
+
+			handler := newTestHandler(handlerDeps{organizationRepo: mockOrganizationRepo})
+
+			app.Post("/organizations", func(c *fiber.Ctx) error {
+				c.Locals("userRole", tt.userRole)
+				c.Locals("userId", adminId.String())
+				return handler.CreateOrganization(c)
+			})
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/organizations", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.expectedStatus == fiber.StatusCreated {
+				var result interfaces.OrganizationResponse
+				json.NewDecoder(resp.Body).Decode(&result)
+				assert.Equal(t, organizationId, result.Organization.ID)
+			}
+
+			mockOrganizationRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_GetOrganization(t *testing.T) {
+	adminId := uuid.New()
+	organizationId := uuid.New()
+	otherOrganizationId := uuid.New()
+
+	organization := models.Organization{ID: organizationId, Name: "Acme"}
+
+	tests := []struct {
+		name           string
+		organizationId string
+		setupMocks     func(*mocks.MockOrganizationRepository)
+		expectedStatus int
+	}{
+		{
+			name:           "Successfully get an organization the admin administers",
+			organizationId: organizationId.String(),
+			setupMocks:     func(mockOrganizationRepo *mocks.MockOrganizationRepository) {},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "Organization the admin doesn't administer",
+			organizationId: otherOrganizationId.String(),
+			setupMocks: func(mockOrganizationRepo *mocks.MockOrganizationRepository) {
+				mockOrganizationRepo.On("GetOrganizationById", mock.Anything, otherOrganizationId).Return(models.Organization{ID: otherOrganizationId}, nil)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:           "Organization not found",
+			organizationId: otherOrganizationId.String(),
+			setupMocks: func(mockOrganizationRepo *mocks.MockOrganizationRepository) {
+				mockOrganizationRepo.On("GetOrganizationById", mock.Anything, otherOrganizationId).Return(models.Organization{}, sql.ErrNoRows)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockOrganizationRepo := mocks.NewMockOrganizationRepository()
+			tt.setupMocks(mockOrganizationRepo)
+			mockOrganizationRepo.On("GetOrganizationById", mock.Anything, organizationId).Return(organization, nil).Maybe()
+			mockOrganizationRepo.On("GetUserOrganizations", mock.Anything, adminId).Return([]models.Organization{organization}, nil).Maybe()
+
+			handler := newTestHandler(handlerDeps{organizationRepo: mockOrganizationRepo})
+
+			app.Get("/organizations/:id", func(c *fiber.Ctx) error {
+				c.Locals("userRole", "Admin")
+				c.Locals("userId", adminId.String())
+				return handler.GetOrganization(c)
+			})
+
+			resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/organizations/"+tt.organizationId, nil))
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockOrganizationRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_UpdateOrganization(t *testing.T) {
+	adminId := uuid.New()
+	organizationId := uuid.New()
+
+	organization := models.Organization{ID: organizationId, Name: "Acme"}
+
+	tests := []struct {
+		name           string
+		requestBody    map[string]interface{}
+		setupMocks     func(*mocks.MockOrganizationRepository)
+		expectedStatus int
+	}{
+		{
+			name:           "Empty name",
+			requestBody:    map[string]interface{}{"name": ""},
+			setupMocks:     func(mockOrganizationRepo *mocks.MockOrganizationRepository) {},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "Rename keeps the other settings",
+			requestBody: map[string]interface{}{"name": "Acme Inc"},
+			setupMocks: func(mockOrganizationRepo *mocks.MockOrganizationRepository) {
+				mockOrganizationRepo.On("UpdateOrganization", mock.Anything, mock.MatchedBy(func(data interfaces.UpdateOrganizationData) bool {
+					return data.ID == organizationId && data.Name == "Acme Inc" && !data.RequireMfa
+				})).Return(models.Organization{ID: organizationId, Name: "Acme Inc"}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:        "Require two-factor authentication in every team",
+			requestBody: map[string]interface{}{"requireMfa": true},
+			setupMocks: func(mockOrganizationRepo *mocks.MockOrganizationRepository) {
+				mockOrganizationRepo.On("UpdateOrganization", mock.Anything, mock.MatchedBy(func(data interfaces.UpdateOrganizationData) bool {
+					return data.ID == organizationId && data.Name == "Acme" && data.RequireMfa
+				})).Return(models.Organization{ID: organizationId, Name: "Acme", RequireMfa: true}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockOrganizationRepo := mocks.NewMockOrganizationRepository()
+			tt.setupMocks(mockOrganizationRepo)
+			mockOrganizationRepo.On("GetOrganizationById", mock.Anything, organizationId).Return(organization, nil)
+			mockOrganizationRepo.On("GetUserOrganizations", mock.Anything, adminId).Return([]models.Organization{organization}, nil)
+
+			handler := newTestHandler(handlerDeps{organizationRepo: mockOrganizationRepo})
+
+			app.Patch("/organizations/:id", func(c *fiber.Ctx) error {
+				c.Locals("userRole", "Admin")
+				c.Locals("userId", adminId.String())
+				return handler.UpdateOrganization(c)
+			})
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPatch, "/organizations/"+organizationId.String(), bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockOrganizationRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_GetOrganizationDirectoryAndReport(t *testing.T) {
+	adminId := uuid.New()
+	organizationId := uuid.New()
+	teamId := uuid.New()
+	otherTeamId := uuid.New()
+	contractorId := uuid.New()
+
+	organization := models.Organization{ID: organizationId, Name: "Acme"}
+
+	app := setupTestApp()
+
+	mockOrganizationRepo := mocks.NewMockOrganizationRepository()
+	mockOrganizationRepo.On("GetOrganizationById", mock.Anything, organizationId).Return(organization, nil)
+	mockOrganizationRepo.On("GetUserOrganizations", mock.Anything, adminId).Return([]models.Organization{organization}, nil)
+	mockOrganizationRepo.On("GetOrganizationUsers", mock.Anything, organizationId).Return([]models.OrganizationUser{
+		{ID: adminId, Username: "admin", Teams: []models.TeamMember{{TeamID: teamId, UserID: adminId, Role: models.UserrolesAdmin}}},
+		{ID: contractorId, Username: "contractor", Teams: []models.TeamMember{
+			{TeamID: teamId, UserID: contractorId, Role: models.UserrolesMember},
+			{TeamID: otherTeamId, UserID: contractorId, Role: models.UserrolesManager},
+		}},
+	}, nil)
+	mockOrganizationRepo.On("GetOrganizationReport", mock.Anything, organizationId).Return(interfaces.OrganizationReport{
+		Users:     2,
+		Projects:  3,
+		Tasks:     10,
+		DoneTasks: 4,
+		Teams: []interfaces.TeamReport{
+			{TeamID: teamId, Members: 2, Projects: 2, Tasks: 8, DoneTasks: 4},
+			{TeamID: otherTeamId, Members: 1, Projects: 1, Tasks: 2},
+		},
+	}, nil)
+
+	handler := newTestHandler(handlerDeps{organizationRepo: mockOrganizationRepo})
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("userRole", "Admin")
+		c.Locals("userId", adminId.String())
+		return c.Next()
+	})
+	app.Get("/organizations/:id/users", handler.GetOrganizationUsers)
+	app.Get("/organizations/:id/report", handler.GetOrganizationReport)
+
+	t.Run("The directory lists every user with their teams", func(t *testing.T) {
+		resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/organizations/"+organizationId.String()+"/users", nil))
+
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var result interfaces.OrganizationUsersResponse
+		json.NewDecoder(resp.Body).Decode(&result)
+		assert.Len(t, result.Data, 2)
+		assert.Len(t, result.Data[1].Teams, 2)
+	})
+
+	t.Run("The report counts every team", func(t *testing.T) {
+		resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/organizations/"+organizationId.String()+"/report", nil))
+
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var result interfaces.OrganizationReportResponse
+		json.NewDecoder(resp.Body).Decode(&result)
+		assert.Equal(t, int64(3), result.Report.Projects)
+		assert.Len(t, result.Report.Teams, 2)
+	})
+
+	mockOrganizationRepo.AssertExpectations(t)
+}
+
+func TestHandler_AddOrganizationAdmin(t *testing.T) {
+	adminId := uuid.New()
+	organizationId := uuid.New()
+	userId := uuid.New()
+
+	organization := models.Organization{ID: organizationId, Name: "Acme"}
+
+	tests := []struct {
+		name           string
+		setupMocks     func(*mocks.MockUserRepository, *mocks.MockOrganizationRepository)
+		expectedStatus int
+	}{
+		{
+			name: "Users outside the organization are not found",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockOrganizationRepo *mocks.MockOrganizationRepository) {
+				mockOrganizationRepo.On("IsOrganizationMember", mock.Anything, organizationId, userId).Return(false, nil)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name: "Only admins can administer an organization",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockOrganizationRepo *mocks.MockOrganizationRepository) {
+				mockOrganizationRepo.On("IsOrganizationMember", mock.Anything, organizationId, userId).Return(true, nil)
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(models.User{ID: userId, Role: models.UserrolesManager}, nil)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "The user already administers the organization",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockOrganizationRepo *mocks.MockOrganizationRepository) {
+				mockOrganizationRepo.On("IsOrganizationMember", mock.Anything, organizationId, userId).Return(true, nil)
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(models.User{ID: userId, Role: models.UserrolesAdmin}, nil)
+				mockOrganizationRepo.On("GetOrganizationAdmin", mock.Anything, organizationId, userId).Return(true, models.OrganizationAdmin{OrganizationID: organizationId, UserID: userId}, nil)
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name: "Successfully add an admin of one of the teams",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockOrganizationRepo *mocks.MockOrganizationRepository) {
+				mockOrganizationRepo.On("IsOrganizationMember", mock.Anything, organizationId, userId).Return(true, nil)
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(models.User{ID: userId, Role: models.UserrolesAdmin}, nil)
+				mockOrganizationRepo.On("GetOrganizationAdmin", mock.Anything, organizationId, userId).Return(false, models.OrganizationAdmin{}, nil)
+				mockOrganizationRepo.On("AddOrganizationAdmin", mock.Anything, mock.MatchedBy(func(admin models.OrganizationAdmin) bool {
+					return admin.OrganizationID == organizationId && admin.UserID == userId
+				})).Return(models.OrganizationAdmin{OrganizationID: organizationId, UserID: userId}, nil)
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockUserRepo := mocks.NewMockUserRepository()
+			mockOrganizationRepo := mocks.NewMockOrganizationRepository()
+			tt.setupMocks(mockUserRepo, mockOrganizationRepo)
+			mockOrganizationRepo.On("GetOrganizationById", mock.Anything, organizationId).Return(organization, nil)
+			mockOrganizationRepo.On("GetUserOrganizations", mock.Anything, adminId).Return([]models.Organization{organization}, nil)
+
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, organizationRepo: mockOrganizationRepo})
+
+			app.Post("/organizations/:id/admins", func(c *fiber.Ctx) error {
+				c.Locals("userRole", "Admin")
+				c.Locals("userId", adminId.String())
+				return handler.AddOrganizationAdmin(c)
+			})
+
+			body, _ := json.Marshal(map[string]interface{}{"userId": userId})
+			req := httptest.NewRequest(http.MethodPost, "/organizations/"+organizationId.String()+"/admins", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockUserRepo.AssertExpectations(t)
+			mockOrganizationRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_RemoveOrganizationAdmin(t *testing.T) {
+	adminId := uuid.New()
+	organizationId := uuid.New()
+	otherAdminId := uuid.New()
+
+	organization := models.Organization{ID: organizationId, Name: "Acme"}
+
+	tests := []struct {
+		name           string
+		userId         uuid.UUID
+		admins         []models.OrganizationAdmin
+		setupMocks     func(*mocks.MockOrganizationRepository)
+		expectedStatus int
+	}{
+		{
+			name:           "Users that aren't admins are not found",
+			userId:         uuid.New(),
+			admins:         []models.OrganizationAdmin{{UserID: adminId}, {UserID: otherAdminId}},
+			setupMocks:     func(mockOrganizationRepo *mocks.MockOrganizationRepository) {},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:           "The last admin can't be removed",
+			userId:         adminId,
+			admins:         []models.OrganizationAdmin{{UserID: adminId}},
+			setupMocks:     func(mockOrganizationRepo *mocks.MockOrganizationRepository) {},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:   "Successfully remove another admin",
+			userId: otherAdminId,
+			admins: []models.OrganizationAdmin{{UserID: adminId}, {UserID: otherAdminId}},
+			setupMocks: func(mockOrganizationRepo *mocks.MockOrganizationRepository) {
+				mockOrganizationRepo.On("RemoveOrganizationAdmin", mock.Anything, organizationId, otherAdminId).Return(nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockOrganizationRepo := mocks.NewMockOrganizationRepository()
+			tt.setupMocks(mockOrganizationRepo)
+			mockOrganizationRepo.On("GetOrganizationById", mock.Anything, organizationId).Return(organization, nil)
+			mockOrganizationRepo.On("GetUserOrganizations", mock.Anything, adminId).Return([]models.Organization{organization}, nil)
+			mockOrganizationRepo.On("GetOrganizationAdmins", mock.Anything, organizationId).Return(tt.admins, nil)
+
+			handler := newTestHandler(handlerDeps{organizationRepo: mockOrganizationRepo})
+
+			app.Delete("/organizations/:id/admins/:userId", func(c *fiber.Ctx) error {
+				c.Locals("userRole", "Admin")
+				c.Locals("userId", adminId.String())
+				return handler.RemoveOrganizationAdmin(c)
+			})
+
+			resp, _ := app.Test(httptest.NewRequest(http.MethodDelete, "/organizations/"+organizationId.String()+"/admins/"+tt.userId.String(), nil))
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockOrganizationRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_OrganizationAdminsManageTheirTeams(t *testing.T) {
+	adminId := uuid.New()
+	ownerId := uuid.New()
+	organizationId := uuid.New()
+	otherOrganizationId := uuid.New()
+
+	organization := models.Organization{ID: organizationId, Name: "Acme", RequireMfa: true}
+	ownTeam := models.Team{ID: uuid.New(), Name: "Platform", OwnerID: adminId, OrganizationID: organizationId}
+	teamOfTheOrganization := models.Team{ID: uuid.New(), Name: "Mobile", OwnerID: ownerId, OrganizationID: organizationId}
+	teamOfAnotherOrganization := models.Team{ID: uuid.New(), Name: "Sales", OwnerID: ownerId, OrganizationID: otherOrganizationId}
+
+	newApp := func() (*fiber.App, *mocks.MockUserRepository, *mocks.MockTeamRepository, *mocks.MockOrganizationRepository) {
+		app := setupTestApp()
+
+		mockUserRepo := mocks.NewMockUserRepository()
+		mockTeamRepo := mocks.NewMockTeamRepository()
+		mockTeamRepo.On("GetTeamByOwner", mock.Anything, adminId).Return(true, ownTeam, nil).Maybe()
+		mockTeamRepo.On("GetTeamById", mock.Anything, teamOfTheOrganization.ID).Return(teamOfTheOrganization, nil).Maybe()
+		mockTeamRepo.On("GetTeamById", mock.Anything, teamOfAnotherOrganization.ID).Return(teamOfAnotherOrganization, nil).Maybe()
+
+		mockOrganizationRepo := mocks.NewMockOrganizationRepository()
+		mockOrganizationRepo.On("GetUserOrganizations", mock.Anything, adminId).Return([]models.Organization{organization}, nil).Maybe()
+		mockOrganizationRepo.On("GetOrganizationById", mock.Anything, organizationId).Return(organization, nil).Maybe()
+		mockOrganizationRepo.On("GetOrganizationById", mock.Anything, otherOrganizationId).Return(models.Organization{ID: otherOrganizationId}, nil).Maybe()
+
+		handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, teamRepo: mockTeamRepo, organizationRepo: mockOrganizationRepo})
+
+		app.Use(func(c *fiber.Ctx) error {
+			c.Locals("userRole", "Admin")
+			c.Locals("userId", adminId.String())
+			return c.Next()
+		})
+		app.Get("/teams/:id", handler.GetTeam)
+		app.Post("/teams", handler.CreateTeam)
+
+		return app, mockUserRepo, mockTeamRepo, mockOrganizationRepo
+	}
+
+	t.Run("Teams of the organization owned by other admins", func(t *testing.T) {
+		app, _, _, _ := newApp()
+
+		resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/teams/"+teamOfTheOrganization.ID.String(), nil))
+
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("Teams of other organizations are not found", func(t *testing.T) {
+		app, _, _, _ := newApp()
+
+		resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/teams/"+teamOfAnotherOrganization.ID.String(), nil))
+
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("Teams created in the organization require what it requires", func(t *testing.T) {
+		app, mockUserRepo, mockTeamRepo, _ := newApp()
+		mockUserRepo.On("GetUserById", mock.Anything, adminId).Return(models.User{ID: adminId, Role: models.UserrolesAdmin}, nil)
+		mockTeamRepo.On("CreateTeam", mock.Anything, mock.MatchedBy(func(team models.Team) bool {
+			return team.OrganizationID == organizationId && team.RequireMfa && team.OwnerID == adminId
+		})).Return(models.Team{ID: uuid.New(), Name: "Web", OwnerID: adminId, OrganizationID: organizationId, RequireMfa: true}, nil)
+
+		body, _ := json.Marshal(map[string]interface{}{"name": "Web", "organizationId": organizationId})
+		req := httptest.NewRequest(http.MethodPost, "/teams", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+		mockTeamRepo.AssertExpectations(t)
+	})
+
+	t.Run("Teams can't be created in organizations the admin doesn't administer", func(t *testing.T) {
+		app, mockUserRepo, _, _ := newApp()
+		mockUserRepo.On("GetUserById", mock.Anything, adminId).Return(models.User{ID: adminId, Role: models.UserrolesAdmin}, nil)
+
+		body, _ := json.Marshal(map[string]interface{}{"name": "Web", "organizationId": otherOrganizationId})
+		req := httptest.NewRequest(http.MethodPost, "/teams", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+}
@@ -0,0 +1,252 @@
+package handlers_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
+	"github.com/TobiasRV/challenge-fs-senior/test/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestHandler_ForgotPassword(t *testing.T) {
+	userId := uuid.New()
+
+	user := models.User{
+		ID:       userId,
+		Username: "testuser",
+		Email:    "test@example.com",
+		Role:     models.UserrolesMember,
+	}
+
+	tests := []struct {
+		name           string
+		requestBody    map[string]interface{}
+		setupMocks     func(*mocks.MockUserRepository, *mocks.MockPasswordResetTokenRepository, *mocks.MockMailer, *mocks.MockLoginAttemptRepository)
+		expectedStatus int
+	}{
+		{
+			name:        "Invalid email",
+			requestBody: map[string]interface{}{"email": "not-an-email"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockPasswordResetTokenRepo *mocks.MockPasswordResetTokenRepository, mockMailer *mocks.MockMailer, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "Unknown email gets the same response",
+			requestBody: map[string]interface{}{"email": "unknown@example.com"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockPasswordResetTokenRepo *mocks.MockPasswordResetTokenRepository, mockMailer *mocks.MockMailer, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+				mockLoginAttemptRepo.On("RecordFailedLogin", mock.Anything, mock.MatchedBy(func(data interfaces.RecordFailedLoginData) bool {
+					// Counted apart from the failed logins of the email
+					return data.AccountKey == "password-reset:unknown@example.com" && data.IpAddress == "password-reset:0.0.0.0"
+				})).Return([]models.LoginAttempt{}, nil)
+				mockUserRepo.On("GetUserByEmail", mock.Anything, "unknown@example.com").Return(models.User{}, sql.ErrNoRows)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:        "Too many requests for the email",
+			requestBody: map[string]interface{}{"email": "test@example.com"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockPasswordResetTokenRepo *mocks.MockPasswordResetTokenRepository, mockMailer *mocks.MockMailer, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+				mockLoginAttemptRepo.On("GetLoginAttempts", mock.Anything, mock.Anything).Return([]models.LoginAttempt{
+					{Scope: models.LoginAttemptScopeAccount, Identifier: "password-reset:test@example.com", FailedCount: 5, LastFailedAt: time.Now().UTC()},
+				}, nil)
+			},
+			expectedStatus: fiber.StatusTooManyRequests,
+		},
+		{
+			name:        "Reset link is emailed",
+			requestBody: map[string]interface{}{"email": "test@example.com"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockPasswordResetTokenRepo *mocks.MockPasswordResetTokenRepository, mockMailer *mocks.MockMailer, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+				var tokenHash string
+
+				mockUserRepo.On("GetUserByEmail", mock.Anything, "test@example.com").Return(user, nil)
+				mockPasswordResetTokenRepo.On("CreatePasswordResetToken", mock.Anything, mock.MatchedBy(func(token models.PasswordResetToken) bool {
+					tokenHash = token.TokenHash
+					return token.UserID == userId && token.ExpiresAt.After(time.Now())
+				})).Return(nil)
+				mockMailer.On("Send", mock.Anything, mock.MatchedBy(func(email interfaces.Email) bool {
+					// Only the hash is stored, the plain token only travels in the email
+					_, token, found := strings.Cut(email.Body, "?token=")
+					token = strings.Fields(token)[0]
+					return found && email.To == "test@example.com" && utils.HashOpaqueToken(token) == tokenHash
+				})).Return(nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:        "Mailer error gets the same response",
+			requestBody: map[string]interface{}{"email": "test@example.com"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockPasswordResetTokenRepo *mocks.MockPasswordResetTokenRepository, mockMailer *mocks.MockMailer, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+				mockUserRepo.On("GetUserByEmail", mock.Anything, "test@example.com").Return(user, nil)
+				mockPasswordResetTokenRepo.On("CreatePasswordResetToken", mock.Anything, mock.AnythingOfType("models.PasswordResetToken")).Return(nil)
+				mockMailer.On("Send", mock.Anything, mock.AnythingOfType("interfaces.Email")).Return(errors.New("smtp unavailable"))
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:        "Database error",
+			requestBody: map[string]interface{}{"email": "test@example.com"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockPasswordResetTokenRepo *mocks.MockPasswordResetTokenRepository, mockMailer *mocks.MockMailer, mockLoginAttemptRepo *mocks.MockLoginAttemptRepository) {
+				mockUserRepo.On("GetUserByEmail", mock.Anything, "test@example.com").Return(user, nil)
+				mockPasswordResetTokenRepo.On("CreatePasswordResetToken", mock.Anything, mock.AnythingOfType("models.PasswordResetToken")).Return(sql.ErrConnDone)
+			},
+			expectedStatus: fiber.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockUserRepo := mocks.NewMockUserRepository()
+			mockPasswordResetTokenRepo := mocks.NewMockPasswordResetTokenRepository()
+			mockLoginAttemptRepo := mocks.NewMockLoginAttemptRepository()
+			mockMailer := mocks.NewMockMailer()
+
+			tt.setupMocks(mockUserRepo, mockPasswordResetTokenRepo, mockMailer, mockLoginAttemptRepo)
+			mockLoginAttemptRepo.On("GetLoginAttempts", mock.Anything, mock.Anything).Return([]models.LoginAttempt{}, nil).Maybe()
+			mockLoginAttemptRepo.On("RecordFailedLogin", mock.Anything, mock.Anything).Return([]models.LoginAttempt{}, nil).Maybe()
+
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, passwordResetTokenRepo: mockPasswordResetTokenRepo, loginAttemptRepo: mockLoginAttemptRepo, mailer: mockMailer})
+
+			app.Post("/auth/forgot-password", handler.ForgotPassword)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/auth/forgot-password", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.expectedStatus == fiber.StatusTooManyRequests {
+				assert.NotEmpty(t, resp.Header.Get(fiber.HeaderRetryAfter))
+				mockUserRepo.AssertNotCalled(t, "GetUserByEmail", mock.Anything, mock.Anything)
+			}
+
+			mockUserRepo.AssertExpectations(t)
+			mockPasswordResetTokenRepo.AssertExpectations(t)
+			mockMailer.AssertExpectations(t)
+			mockLoginAttemptRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_ResetPassword(t *testing.T) {
+	userId := uuid.New()
+	tokenId := uuid.New()
+	token := "reset-token"
+	tokenHash := utils.HashOpaqueToken(token)
+
+	validToken := models.PasswordResetToken{
+		ID:        tokenId,
+		UserID:    userId,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().UTC().Add(time.Hour),
+		CreatedAt: time.Now().UTC(),
+	}
+
+	usedToken := validToken
+	usedToken.UsedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+
+	expiredToken := validToken
+	expiredToken.ExpiresAt = time.Now().UTC().Add(-time.Minute)
+
+	tests := []struct {
+		name           string
+		requestBody    map[string]interface{}
+		setupMocks     func(*mocks.MockPasswordResetTokenRepository)
+		expectedStatus int
+	}{
+		{
+			name:           "Missing password",
+			requestBody:    map[string]interface{}{"token": token},
+			setupMocks:     func(mockPasswordResetTokenRepo *mocks.MockPasswordResetTokenRepository) {},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "Unknown token",
+			requestBody: map[string]interface{}{"token": "unknown", "password": "newpassword123"},
+			setupMocks: func(mockPasswordResetTokenRepo *mocks.MockPasswordResetTokenRepository) {
+				mockPasswordResetTokenRepo.On("GetPasswordResetTokenByHash", mock.Anything, utils.HashOpaqueToken("unknown")).Return(models.PasswordResetToken{}, sql.ErrNoRows)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "Used token",
+			requestBody: map[string]interface{}{"token": token, "password": "newpassword123"},
+			setupMocks: func(mockPasswordResetTokenRepo *mocks.MockPasswordResetTokenRepository) {
+				mockPasswordResetTokenRepo.On("GetPasswordResetTokenByHash", mock.Anything, tokenHash).Return(usedToken, nil)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "Expired token",
+			requestBody: map[string]interface{}{"token": token, "password": "newpassword123"},
+			setupMocks: func(mockPasswordResetTokenRepo *mocks.MockPasswordResetTokenRepository) {
+				mockPasswordResetTokenRepo.On("GetPasswordResetTokenByHash", mock.Anything, tokenHash).Return(expiredToken, nil)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "Token used by a concurrent request",
+			requestBody: map[string]interface{}{"token": token, "password": "newpassword123"},
+			setupMocks: func(mockPasswordResetTokenRepo *mocks.MockPasswordResetTokenRepository) {
+				mockPasswordResetTokenRepo.On("GetPasswordResetTokenByHash", mock.Anything, tokenHash).Return(validToken, nil)
+				mockPasswordResetTokenRepo.On("ResetPassword", mock.Anything, mock.AnythingOfType("interfaces.ResetPasswordData")).Return(false, nil)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "Successful reset",
+			requestBody: map[string]interface{}{"token": token, "password": "newpassword123"},
+			setupMocks: func(mockPasswordResetTokenRepo *mocks.MockPasswordResetTokenRepository) {
+				mockPasswordResetTokenRepo.On("GetPasswordResetTokenByHash", mock.Anything, tokenHash).Return(validToken, nil)
+				mockPasswordResetTokenRepo.On("ResetPassword", mock.Anything, mock.MatchedBy(func(data interfaces.ResetPasswordData) bool {
+					return data.TokenID == tokenId &&
+						data.UserID == userId &&
+						bcrypt.CompareHashAndPassword([]byte(data.Password), []byte("newpassword123")) == nil
+				})).Return(true, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockPasswordResetTokenRepo := mocks.NewMockPasswordResetTokenRepository()
+
+			tt.setupMocks(mockPasswordResetTokenRepo)
+
+			handler := newTestHandler(handlerDeps{passwordResetTokenRepo: mockPasswordResetTokenRepo})
+
+			app.Post("/auth/reset-password", handler.ResetPassword)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/auth/reset-password", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockPasswordResetTokenRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,310 @@
+package handlers_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/tenant"
+	"github.com/TobiasRV/challenge-fs-senior/test/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// projectMembersFixture is a project owned by a manager, with a contributor and a viewer
+type projectMembersFixture struct {
+	teamId      uuid.UUID
+	project     models.Project
+	manager     models.User
+	coOwner     models.User
+	contributor models.User
+	viewer      models.User
+	members     []models.ProjectMember
+}
+
+func newProjectMembersFixture() projectMembersFixture {
+	teamId := uuid.New()
+	manager := models.User{ID: uuid.New(), Username: "manager", Email: "manager@example.com", Role: models.UserrolesManager, TeamId: teamId}
+	contributor := models.User{ID: uuid.New(), Username: "contributor", Email: "contributor@example.com", Role: models.UserrolesMember, TeamId: teamId}
+	viewer := models.User{ID: uuid.New(), Username: "viewer", Email: "viewer@example.com", Role: models.UserrolesMember, TeamId: teamId}
+	project := models.Project{ID: uuid.New(), Name: "Project", TeamID: teamId, ManagerID: manager.ID}
+
+	return projectMembersFixture{
+		teamId:      teamId,
+		project:     project,
+		manager:     manager,
+		coOwner:     models.User{ID: uuid.New(), Username: "co-owner", Email: "co-owner@example.com", Role: models.UserrolesManager, TeamId: teamId},
+		contributor: contributor,
+		viewer:      viewer,
+		members: []models.ProjectMember{
+			{ProjectID: project.ID, UserID: manager.ID, Role: models.ProjectmemberrolesOwner, Username: manager.Username, Email: manager.Email},
+			{ProjectID: project.ID, UserID: contributor.ID, Role: models.ProjectmemberrolesContributor, Username: contributor.Username, Email: contributor.Email},
+			{ProjectID: project.ID, UserID: viewer.ID, Role: models.ProjectmemberrolesViewer, Username: viewer.Username, Email: viewer.Email},
+		},
+	}
+}
+
+func (f projectMembersFixture) mock(ur *mocks.MockUserRepository, pr *mocks.MockProjectRepository) {
+	for _, user := range []models.User{f.manager, f.coOwner, f.contributor, f.viewer} {
+		ur.On("GetUserById", mock.Anything, user.ID).Return(user, nil).Maybe()
+	}
+
+	pr.On("GetProjectById", mock.Anything, f.project.ID).Return(f.project, nil).Maybe()
+	pr.On("GetProjectMembers", mock.Anything, f.project.ID).Return(f.members, nil).Maybe()
+}
+
+func setupProjectMembersTestApp(ur *mocks.MockUserRepository, pr *mocks.MockProjectRepository, user models.User) *fiber.App {
+	handler := newTestHandler(handlerDeps{userRepo: ur, projectRepo: pr})
+
+	app := setupTestApp()
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("userId", user.ID.String())
+		c.Locals("userRole", string(user.Role))
+		tenant.SetTeam(c, user.TeamId)
+		return c.Next()
+	})
+
+	app.Get("/projects/:id/members", handler.GetProjectMembers)
+	app.Post("/projects/:id/members", handler.AddProjectMember)
+	app.Delete("/projects/:id/members/:userId", handler.RemoveProjectMember)
+
+	return app
+}
+
+func TestHandler_GetProjectMembers(t *testing.T) {
+	f := newProjectMembersFixture()
+	outsider := models.User{ID: uuid.New(), Role: models.UserrolesMember, TeamId: f.teamId}
+
+	tests := []struct {
+		name           string
+		user           models.User
+		expectedStatus int
+	}{
+		{name: "Owner lists the members", user: f.manager, expectedStatus: fiber.StatusOK},
+		{name: "Viewer lists the members", user: f.viewer, expectedStatus: fiber.StatusOK},
+		{name: "Member of the team outside of the project", user: outsider, expectedStatus: fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := mocks.NewMockUserRepository()
+			mockProjectRepo := mocks.NewMockProjectRepository()
+			f.mock(mockUserRepo, mockProjectRepo)
+
+			app := setupProjectMembersTestApp(mockUserRepo, mockProjectRepo, tt.user)
+
+			resp := rolesRequest(t, app, http.MethodGet, "/projects/"+f.project.ID.String()+"/members", nil)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.expectedStatus == fiber.StatusOK {
+				var body interfaces.ProjectMembersListResponse
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+				assert.Len(t, body.Data, 3)
+			}
+		})
+	}
+
+	t.Run("Project not found", func(t *testing.T) {
+		mockUserRepo := mocks.NewMockUserRepository()
+		mockProjectRepo := mocks.NewMockProjectRepository()
+		missing := uuid.New()
+		mockProjectRepo.On("GetProjectById", mock.Anything, missing).Return(models.Project{}, sql.ErrNoRows)
+
+		app := setupProjectMembersTestApp(mockUserRepo, mockProjectRepo, f.manager)
+
+		resp := rolesRequest(t, app, http.MethodGet, "/projects/"+missing.String()+"/members", nil)
+
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestHandler_AddProjectMember(t *testing.T) {
+	f := newProjectMembersFixture()
+	newMember := models.User{ID: uuid.New(), Username: "new", Email: "new@example.com", Role: models.UserrolesMember, TeamId: f.teamId}
+
+	tests := []struct {
+		name           string
+		user           models.User
+		body           map[string]interface{}
+		setupMocks     func(*mocks.MockUserRepository, *mocks.MockProjectRepository)
+		expectedStatus int
+	}{
+		{
+			name: "Owner adds a contributor",
+			user: f.manager,
+			body: map[string]interface{}{"userId": newMember.ID.String(), "role": "Contributor"},
+			setupMocks: func(ur *mocks.MockUserRepository, pr *mocks.MockProjectRepository) {
+				ur.On("GetUserById", mock.Anything, newMember.ID).Return(newMember, nil)
+				pr.On("GetProjectMember", mock.Anything, f.project.ID, newMember.ID).Return(false, models.ProjectMember{}, nil)
+				pr.On("AddProjectMember", mock.Anything, mock.MatchedBy(func(m models.ProjectMember) bool {
+					return m.ProjectID == f.project.ID && m.UserID == newMember.ID && m.Role == models.ProjectmemberrolesContributor
+				})).Return(models.ProjectMember{ProjectID: f.project.ID, UserID: newMember.ID, Role: models.ProjectmemberrolesContributor}, nil)
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name: "Owner adds another manager as a co-owner",
+			user: f.manager,
+			body: map[string]interface{}{"userId": f.coOwner.ID.String(), "role": "Owner"},
+			setupMocks: func(ur *mocks.MockUserRepository, pr *mocks.MockProjectRepository) {
+				pr.On("GetProjectMember", mock.Anything, f.project.ID, f.coOwner.ID).Return(false, models.ProjectMember{}, nil)
+				pr.On("AddProjectMember", mock.Anything, mock.MatchedBy(func(m models.ProjectMember) bool {
+					return m.UserID == f.coOwner.ID && m.Role == models.ProjectmemberrolesOwner
+				})).Return(models.ProjectMember{ProjectID: f.project.ID, UserID: f.coOwner.ID, Role: models.ProjectmemberrolesOwner}, nil)
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name:           "Members can't own a project",
+			user:           f.manager,
+			body:           map[string]interface{}{"userId": f.viewer.ID.String(), "role": "Owner"},
+			setupMocks:     func(ur *mocks.MockUserRepository, pr *mocks.MockProjectRepository) {},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "Invalid role",
+			user:           f.manager,
+			body:           map[string]interface{}{"userId": newMember.ID.String(), "role": "Maintainer"},
+			setupMocks:     func(ur *mocks.MockUserRepository, pr *mocks.MockProjectRepository) {},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "Already a member",
+			user: f.manager,
+			body: map[string]interface{}{"userId": f.contributor.ID.String(), "role": "Viewer"},
+			setupMocks: func(ur *mocks.MockUserRepository, pr *mocks.MockProjectRepository) {
+				pr.On("GetProjectMember", mock.Anything, f.project.ID, f.contributor.ID).Return(true, f.members[1], nil)
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name: "User not found",
+			user: f.manager,
+			body: map[string]interface{}{"userId": newMember.ID.String(), "role": "Viewer"},
+			setupMocks: func(ur *mocks.MockUserRepository, pr *mocks.MockProjectRepository) {
+				ur.On("GetUserById", mock.Anything, newMember.ID).Return(models.User{}, sql.ErrNoRows)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:           "Contributors can't add members",
+			user:           f.contributor,
+			body:           map[string]interface{}{"userId": newMember.ID.String(), "role": "Viewer"},
+			setupMocks:     func(ur *mocks.MockUserRepository, pr *mocks.MockProjectRepository) {},
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:           "Managers that don't own the project can't add members",
+			user:           f.coOwner,
+			body:           map[string]interface{}{"userId": newMember.ID.String(), "role": "Viewer"},
+			setupMocks:     func(ur *mocks.MockUserRepository, pr *mocks.MockProjectRepository) {},
+			expectedStatus: fiber.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := mocks.NewMockUserRepository()
+			mockProjectRepo := mocks.NewMockProjectRepository()
+			tt.setupMocks(mockUserRepo, mockProjectRepo)
+			f.mock(mockUserRepo, mockProjectRepo)
+
+			app := setupProjectMembersTestApp(mockUserRepo, mockProjectRepo, tt.user)
+
+			resp := rolesRequest(t, app, http.MethodPost, "/projects/"+f.project.ID.String()+"/members", tt.body)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockProjectRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_RemoveProjectMember(t *testing.T) {
+	f := newProjectMembersFixture()
+
+	coOwned := f
+	coOwned.members = append([]models.ProjectMember{{ProjectID: f.project.ID, UserID: f.coOwner.ID, Role: models.ProjectmemberrolesOwner}}, f.members...)
+
+	tests := []struct {
+		name           string
+		fixture        projectMembersFixture
+		user           models.User
+		userId         uuid.UUID
+		setupMocks     func(*mocks.MockProjectRepository)
+		expectedStatus int
+	}{
+		{
+			name:    "Owner removes a contributor",
+			fixture: f,
+			user:    f.manager,
+			userId:  f.contributor.ID,
+			setupMocks: func(pr *mocks.MockProjectRepository) {
+				pr.On("RemoveProjectMember", mock.Anything, mock.MatchedBy(func(data interfaces.RemoveProjectMemberData) bool {
+					return data.ProjectID == f.project.ID && data.UserID == f.contributor.ID
+				})).Return(nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:    "Co-owner removes the manager of the project",
+			fixture: coOwned,
+			user:    f.coOwner,
+			userId:  f.manager.ID,
+			setupMocks: func(pr *mocks.MockProjectRepository) {
+				pr.On("RemoveProjectMember", mock.Anything, mock.MatchedBy(func(data interfaces.RemoveProjectMemberData) bool {
+					return data.UserID == f.manager.ID
+				})).Return(nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "The last owner can't be removed",
+			fixture:        f,
+			user:           f.manager,
+			userId:         f.manager.ID,
+			setupMocks:     func(pr *mocks.MockProjectRepository) {},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "Not a member",
+			fixture:        f,
+			user:           f.manager,
+			userId:         uuid.New(),
+			setupMocks:     func(pr *mocks.MockProjectRepository) {},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:           "Viewers can't remove members",
+			fixture:        f,
+			user:           f.viewer,
+			userId:         f.contributor.ID,
+			setupMocks:     func(pr *mocks.MockProjectRepository) {},
+			expectedStatus: fiber.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := mocks.NewMockUserRepository()
+			mockProjectRepo := mocks.NewMockProjectRepository()
+			tt.setupMocks(mockProjectRepo)
+			tt.fixture.mock(mockUserRepo, mockProjectRepo)
+
+			app := setupProjectMembersTestApp(mockUserRepo, mockProjectRepo, tt.user)
+
+			resp := rolesRequest(t, app, http.MethodDelete, "/projects/"+f.project.ID.String()+"/members/"+tt.userId.String(), nil)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockProjectRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package handlers_test
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"encoding/json"
 	"net/http"
@@ -9,9 +10,10 @@ import (
 	"testing"
 	"time"
 
-	"github.com/TobiasRV/challenge-fs-senior/internals/handlers"
 	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
 	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/TobiasRV/challenge-fs-senior/internals/tenant"
 	"github.com/TobiasRV/challenge-fs-senior/test/mocks"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -21,6 +23,7 @@ import (
 
 func TestHandler_CreateProject(t *testing.T) {
 	userId := uuid.New()
+	managerId := uuid.New()
 	teamId := uuid.New()
 	projectId := uuid.New()
 	now := time.Now()
@@ -30,7 +33,7 @@ func TestHandler_CreateProject(t *testing.T) {
 		userRole       string
 		userId         string
 		requestBody    map[string]interface{}
-		setupMocks     func(*mocks.MockUserRepository, *mocks.MockProjectRepository)
+		setupMocks     func(*mocks.MockUserRepository, *mocks.MockTeamRepository, *mocks.MockProjectRepository)
 		expectedStatus int
 	}{
 		{
@@ -40,39 +43,93 @@ func TestHandler_CreateProject(t *testing.T) {
 			requestBody: map[string]interface{}{
 				"name": "Test Project",
 			},
-			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockProjectRepo *mocks.MockProjectRepository) {
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockProjectRepo *mocks.MockProjectRepository) {
 			},
 			expectedStatus: fiber.StatusForbidden,
 		},
 		{
-			name:     "Unauthorized - admin user",
+			name:     "Admin without managerId",
 			userRole: "Admin",
 			userId:   userId.String(),
 			requestBody: map[string]interface{}{
 				"name": "Test Project",
 			},
-			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockProjectRepo *mocks.MockProjectRepository) {
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockProjectRepo *mocks.MockProjectRepository) {
 			},
-			expectedStatus: fiber.StatusForbidden,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:     "Admin picks a user that isn't a manager",
+			userRole: "Admin",
+			userId:   userId.String(),
+			requestBody: map[string]interface{}{
+				"name":      "Test Project",
+				"managerId": managerId.String(),
+			},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockProjectRepo *mocks.MockProjectRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, managerId).Return(models.User{ID: managerId, Role: models.UserrolesMember, TeamId: teamId}, nil)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:     "Admin picks a manager of another team",
+			userRole: "Admin",
+			userId:   userId.String(),
+			requestBody: map[string]interface{}{
+				"name":      "Test Project",
+				"managerId": managerId.String(),
+			},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockProjectRepo *mocks.MockProjectRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, managerId).Return(models.User{ID: managerId, Role: models.UserrolesManager, TeamId: uuid.New()}, nil)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:     "Successfully create project as an admin",
+			userRole: "Admin",
+			userId:   userId.String(),
+			requestBody: map[string]interface{}{
+				"name":      "New Project",
+				"managerId": managerId.String(),
+			},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockProjectRepo *mocks.MockProjectRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, managerId).Return(models.User{ID: managerId, Role: models.UserrolesManager, TeamId: teamId}, nil)
+				mockTeamRepo.On("GetTeamMember", mock.Anything, teamId, managerId).Return(true, models.TeamMember{TeamID: teamId, UserID: managerId, Role: models.UserrolesManager}, nil)
+				mockProjectRepo.On("CreateProject", mock.MatchedBy(func(c context.Context) bool {
+					adminId, acting := tenant.ActingAdminID(c)
+					return acting && adminId == userId
+				}), mock.MatchedBy(func(params database.CreateProjectParams) bool {
+					return params.ManagerID == managerId && params.TeamID == teamId
+				})).Return(models.Project{
+					ID:        projectId,
+					Name:      "New Project",
+					TeamID:    teamId,
+					ManagerID: managerId,
+					Status:    models.ProjectstatusOnHold,
+					CreatedAt: now,
+					UpdatedAt: now,
+				}, nil)
+			},
+			expectedStatus: fiber.StatusCreated,
 		},
 		{
 			name:        "Missing name field",
 			userRole:    "Manager",
 			userId:      userId.String(),
 			requestBody: map[string]interface{}{},
-			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockProjectRepo *mocks.MockProjectRepository) {
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockProjectRepo *mocks.MockProjectRepository) {
 			},
 			expectedStatus: fiber.StatusBadRequest,
 		},
 		{
-			name:     "Manager not found",
+			name:     "Manager that is a member of the team",
 			userRole: "Manager",
 			userId:   userId.String(),
 			requestBody: map[string]interface{}{
 				"name": "Test Project",
 			},
-			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockProjectRepo *mocks.MockProjectRepository) {
-				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(models.User{}, sql.ErrNoRows)
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockProjectRepo *mocks.MockProjectRepository) {
+				mockTeamRepo.On("GetTeamMember", mock.Anything, teamId, userId).Return(true, models.TeamMember{TeamID: teamId, UserID: userId, Role: models.UserrolesMember}, nil)
 			},
 			expectedStatus: fiber.StatusBadRequest,
 		},
@@ -83,15 +140,14 @@ func TestHandler_CreateProject(t *testing.T) {
 			requestBody: map[string]interface{}{
 				"name": "New Project",
 			},
-			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockProjectRepo *mocks.MockProjectRepository) {
-				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(models.User{
-					ID:       userId,
-					Username: "manager",
-					Email:    "manager@example.com",
-					Role:     models.UserrolesManager,
-					TeamId:   teamId,
-				}, nil)
-				mockProjectRepo.On("CreateProject", mock.Anything, mock.AnythingOfType("database.CreateProjectParams")).Return(models.Project{
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockProjectRepo *mocks.MockProjectRepository) {
+				mockTeamRepo.On("GetTeamMember", mock.Anything, teamId, userId).Return(true, models.TeamMember{TeamID: teamId, UserID: userId, Role: models.UserrolesManager}, nil)
+				mockProjectRepo.On("CreateProject", mock.MatchedBy(func(c context.Context) bool {
+					_, acting := tenant.ActingAdminID(c)
+					return !acting
+				}), mock.MatchedBy(func(params database.CreateProjectParams) bool {
+					return params.ManagerID == userId && params.TeamID == teamId
+				})).Return(models.Project{
 					ID:        projectId,
 					Name:      "New Project",
 					TeamID:    teamId,
@@ -110,18 +166,20 @@ func TestHandler_CreateProject(t *testing.T) {
 			app := setupTestApp()
 
 			mockUserRepo := mocks.NewMockUserRepository()
-			mockRefreshTokenRepo := mocks.NewMockRefreshTokenRepository()
 			mockTeamRepo := mocks.NewMockTeamRepository()
 			mockProjectRepo := mocks.NewMockProjectRepository()
-			mockTaskRepo := mocks.NewMockTaskRepository()
 
-			tt.setupMocks(mockUserRepo, mockProjectRepo)
+			tt.setupMocks(mockUserRepo, mockTeamRepo, mockProjectRepo)
 
-			handler := handlers.NewHandler(mockUserRepo, mockRefreshTokenRepo, mockTeamRepo, mockProjectRepo, mockTaskRepo)
+			// Users of other teams aren't members of this one
+			mockTeamRepo.On("GetTeamMember", mock.Anything, teamId, mock.Anything).Return(false, models.TeamMember{}, nil).Maybe()
+
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, teamRepo: mockTeamRepo, projectRepo: mockProjectRepo})
 
 			app.Post("/projects", func(c *fiber.Ctx) error {
 				c.Locals("userRole", tt.userRole)
 				c.Locals("userId", tt.userId)
+				tenant.SetTeam(c, teamId)
 				return handler.CreateProject(c)
 			})
 
@@ -134,6 +192,66 @@ func TestHandler_CreateProject(t *testing.T) {
 			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
 
 			mockUserRepo.AssertExpectations(t)
+			mockTeamRepo.AssertExpectations(t)
+			mockProjectRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_CreateProject_SwitchedTeam(t *testing.T) {
+	userId := uuid.New()
+	switchedTeamId := uuid.New()
+
+	tests := []struct {
+		name           string
+		role           models.Userroles
+		expectedStatus int
+	}{
+		{
+			name:           "Manager of the team the session switched to",
+			role:           models.UserrolesManager,
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name:           "Member of the team the session switched to",
+			role:           models.UserrolesMember,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTeamRepo := mocks.NewMockTeamRepository()
+			mockProjectRepo := mocks.NewMockProjectRepository()
+
+			// The access token carries the team the session switched to, the user has another role in its default team
+			mockTeamRepo.On("GetTeamMember", mock.Anything, switchedTeamId, userId).Return(true, models.TeamMember{TeamID: switchedTeamId, UserID: userId, Role: tt.role}, nil)
+
+			if tt.expectedStatus == fiber.StatusCreated {
+				mockProjectRepo.On("CreateProject", mock.Anything, mock.MatchedBy(func(params database.CreateProjectParams) bool {
+					return params.ManagerID == userId && params.TeamID == switchedTeamId
+				})).Return(models.Project{ID: uuid.New(), Name: "New Project", TeamID: switchedTeamId, ManagerID: userId}, nil)
+			}
+
+			handler := newTestHandler(handlerDeps{teamRepo: mockTeamRepo, projectRepo: mockProjectRepo})
+
+			app := setupTestApp()
+			app.Post("/projects", func(c *fiber.Ctx) error {
+				c.Locals("userRole", string(models.UserrolesManager))
+				c.Locals("userId", userId.String())
+				c.Locals("teamId", switchedTeamId.String())
+				return handler.CreateProject(c)
+			})
+
+			body, _ := json.Marshal(map[string]interface{}{"name": "New Project"})
+			req := httptest.NewRequest(http.MethodPost, "/projects", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockTeamRepo.AssertExpectations(t)
 			mockProjectRepo.AssertExpectations(t)
 		})
 	}
@@ -150,23 +268,37 @@ func TestHandler_GetProjects(t *testing.T) {
 		userRole       string
 		userId         string
 		queryParams    string
-		setupMocks     func(*mocks.MockProjectRepository)
+		setupMocks     func(*mocks.MockUserRepository, *mocks.MockProjectRepository)
 		expectedStatus int
 	}{
 		{
-			name:           "Unauthorized - member user",
-			userRole:       "Member",
-			userId:         userId.String(),
-			queryParams:    "?limit=10&teamId=" + teamId.String(),
-			setupMocks:     func(mockProjectRepo *mocks.MockProjectRepository) {},
-			expectedStatus: fiber.StatusForbidden,
+			name:        "Member only lists the projects they are a member of",
+			userRole:    "Member",
+			userId:      userId.String(),
+			queryParams: "?limit=10",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockProjectRepo *mocks.MockProjectRepository) {
+				mockProjectRepo.On("GetProjects", mock.Anything, mock.MatchedBy(func(filters interfaces.GetProjectsFilters) bool {
+					return filters.MemberId == userId && filters.TeamId == teamId && filters.Limit == 10
+				})).Return([]interfaces.GetProjectsResponse{
+					{
+						ID:        projectId,
+						Name:      "Test Project",
+						TeamID:    teamId,
+						ManagerID: uuid.New(),
+						Status:    models.ProjectstatusInProgress,
+						CreatedAt: now,
+						UpdatedAt: now,
+					},
+				}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
 		},
 		{
 			name:           "Missing teamId and managerId",
 			userRole:       "Admin",
 			userId:         userId.String(),
 			queryParams:    "?limit=10",
-			setupMocks:     func(mockProjectRepo *mocks.MockProjectRepository) {},
+			setupMocks:     func(mockUserRepo *mocks.MockUserRepository, mockProjectRepo *mocks.MockProjectRepository) {},
 			expectedStatus: fiber.StatusBadRequest,
 		},
 		{
@@ -174,9 +306,9 @@ func TestHandler_GetProjects(t *testing.T) {
 			userRole:    "Admin",
 			userId:      userId.String(),
 			queryParams: "?limit=10&teamId=" + teamId.String(),
-			setupMocks: func(mockProjectRepo *mocks.MockProjectRepository) {
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockProjectRepo *mocks.MockProjectRepository) {
 				mockProjectRepo.On("GetProjects", mock.Anything, mock.MatchedBy(func(filters interfaces.GetProjectsFilters) bool {
-					return filters.TeamId == teamId && filters.Limit == 10
+					return filters.TeamId == teamId && filters.MemberId == uuid.Nil && filters.Limit == 10
 				})).Return([]interfaces.GetProjectsResponse{
 					{
 						ID:        projectId,
@@ -196,9 +328,10 @@ func TestHandler_GetProjects(t *testing.T) {
 			userRole:    "Manager",
 			userId:      userId.String(),
 			queryParams: "?limit=10&managerId=" + userId.String(),
-			setupMocks: func(mockProjectRepo *mocks.MockProjectRepository) {
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockProjectRepo *mocks.MockProjectRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(models.User{ID: userId, Role: models.UserrolesManager, TeamId: teamId}, nil)
 				mockProjectRepo.On("GetProjects", mock.Anything, mock.MatchedBy(func(filters interfaces.GetProjectsFilters) bool {
-					return filters.ManagerId == userId && filters.Limit == 10
+					return filters.ManagerId == userId && filters.TeamId == teamId && filters.Limit == 10
 				})).Return([]interfaces.GetProjectsResponse{
 					{
 						ID:        projectId,
@@ -218,7 +351,7 @@ func TestHandler_GetProjects(t *testing.T) {
 			userRole:    "Admin",
 			userId:      userId.String(),
 			queryParams: "?limit=10&teamId=" + teamId.String(),
-			setupMocks: func(mockProjectRepo *mocks.MockProjectRepository) {
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockProjectRepo *mocks.MockProjectRepository) {
 				mockProjectRepo.On("GetProjects", mock.Anything, mock.Anything).Return([]interfaces.GetProjectsResponse{}, nil)
 			},
 			expectedStatus: fiber.StatusOK,
@@ -230,18 +363,16 @@ func TestHandler_GetProjects(t *testing.T) {
 			app := setupTestApp()
 
 			mockUserRepo := mocks.NewMockUserRepository()
-			mockRefreshTokenRepo := mocks.NewMockRefreshTokenRepository()
-			mockTeamRepo := mocks.NewMockTeamRepository()
 			mockProjectRepo := mocks.NewMockProjectRepository()
-			mockTaskRepo := mocks.NewMockTaskRepository()
 
-			tt.setupMocks(mockProjectRepo)
+			tt.setupMocks(mockUserRepo, mockProjectRepo)
 
-			handler := handlers.NewHandler(mockUserRepo, mockRefreshTokenRepo, mockTeamRepo, mockProjectRepo, mockTaskRepo)
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, projectRepo: mockProjectRepo})
 
 			app.Get("/projects", func(c *fiber.Ctx) error {
 				c.Locals("userRole", tt.userRole)
 				c.Locals("userId", tt.userId)
+				tenant.SetTeam(c, teamId)
 				return handler.GetProjects(c)
 			})
 
@@ -251,6 +382,7 @@ func TestHandler_GetProjects(t *testing.T) {
 
 			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
 
+			mockUserRepo.AssertExpectations(t)
 			mockProjectRepo.AssertExpectations(t)
 		})
 	}
@@ -281,7 +413,9 @@ func TestHandler_UpdateProject(t *testing.T) {
 				"name":   "Updated Project",
 				"status": "InProgress",
 			},
-			setupMocks:     func(mockProjectRepo *mocks.MockProjectRepository) {},
+			setupMocks: func(mockProjectRepo *mocks.MockProjectRepository) {
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{ID: projectId, TeamID: teamId, ManagerID: userId}, nil)
+			},
 			expectedStatus: fiber.StatusForbidden,
 		},
 		{
@@ -392,19 +526,17 @@ func TestHandler_UpdateProject(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			app := setupTestApp()
 
-			mockUserRepo := mocks.NewMockUserRepository()
-			mockRefreshTokenRepo := mocks.NewMockRefreshTokenRepository()
-			mockTeamRepo := mocks.NewMockTeamRepository()
 			mockProjectRepo := mocks.NewMockProjectRepository()
-			mockTaskRepo := mocks.NewMockTaskRepository()
 
 			tt.setupMocks(mockProjectRepo)
+			mockProjectRepo.On("GetProjectMembers", mock.Anything, projectId).Return([]models.ProjectMember{}, nil).Maybe()
 
-			handler := handlers.NewHandler(mockUserRepo, mockRefreshTokenRepo, mockTeamRepo, mockProjectRepo, mockTaskRepo)
+			handler := newTestHandler(handlerDeps{projectRepo: mockProjectRepo})
 
 			app.Put("/projects/:id", func(c *fiber.Ctx) error {
 				c.Locals("userRole", tt.userRole)
 				c.Locals("userId", tt.userId)
+				tenant.SetTeam(c, teamId)
 				return handler.UpdateProject(c)
 			})
 
@@ -424,6 +556,8 @@ func TestHandler_UpdateProject(t *testing.T) {
 
 func TestHandler_DeleteProject(t *testing.T) {
 	userId := uuid.New()
+	otherUserId := uuid.New()
+	teamId := uuid.New()
 	projectId := uuid.New()
 
 	tests := []struct {
@@ -435,13 +569,35 @@ func TestHandler_DeleteProject(t *testing.T) {
 		expectedStatus int
 	}{
 		{
-			name:           "Unauthorized - non-manager user",
-			userRole:       "Member",
-			userId:         userId.String(),
-			projectId:      projectId.String(),
-			setupMocks:     func(mockProjectRepo *mocks.MockProjectRepository) {},
+			name:      "Unauthorized - non-manager user",
+			userRole:  "Member",
+			userId:    userId.String(),
+			projectId: projectId.String(),
+			setupMocks: func(mockProjectRepo *mocks.MockProjectRepository) {
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{ID: projectId, TeamID: teamId, ManagerID: userId}, nil)
+			},
 			expectedStatus: fiber.StatusForbidden,
 		},
+		{
+			name:      "Unauthorized - not project manager",
+			userRole:  "Manager",
+			userId:    userId.String(),
+			projectId: projectId.String(),
+			setupMocks: func(mockProjectRepo *mocks.MockProjectRepository) {
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{ID: projectId, TeamID: teamId, ManagerID: otherUserId}, nil)
+			},
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:      "Project not found",
+			userRole:  "Manager",
+			userId:    userId.String(),
+			projectId: projectId.String(),
+			setupMocks: func(mockProjectRepo *mocks.MockProjectRepository) {
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{}, sql.ErrNoRows)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
 		{
 			name:           "Invalid project ID - invalid uuid",
 			userRole:       "Manager",
@@ -456,6 +612,7 @@ func TestHandler_DeleteProject(t *testing.T) {
 			userId:    userId.String(),
 			projectId: projectId.String(),
 			setupMocks: func(mockProjectRepo *mocks.MockProjectRepository) {
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{ID: projectId, TeamID: teamId, ManagerID: userId}, nil)
 				mockProjectRepo.On("DeleteProject", mock.Anything, projectId).Return(nil)
 			},
 			expectedStatus: fiber.StatusOK,
@@ -466,6 +623,7 @@ func TestHandler_DeleteProject(t *testing.T) {
 			userId:    userId.String(),
 			projectId: projectId.String(),
 			setupMocks: func(mockProjectRepo *mocks.MockProjectRepository) {
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{ID: projectId, TeamID: teamId, ManagerID: userId}, nil)
 				mockProjectRepo.On("DeleteProject", mock.Anything, projectId).Return(sql.ErrConnDone)
 			},
 			expectedStatus: fiber.StatusInternalServerError,
@@ -476,19 +634,17 @@ func TestHandler_DeleteProject(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			app := setupTestApp()
 
-			mockUserRepo := mocks.NewMockUserRepository()
-			mockRefreshTokenRepo := mocks.NewMockRefreshTokenRepository()
-			mockTeamRepo := mocks.NewMockTeamRepository()
 			mockProjectRepo := mocks.NewMockProjectRepository()
-			mockTaskRepo := mocks.NewMockTaskRepository()
 
 			tt.setupMocks(mockProjectRepo)
+			mockProjectRepo.On("GetProjectMembers", mock.Anything, projectId).Return([]models.ProjectMember{}, nil).Maybe()
 
-			handler := handlers.NewHandler(mockUserRepo, mockRefreshTokenRepo, mockTeamRepo, mockProjectRepo, mockTaskRepo)
+			handler := newTestHandler(handlerDeps{projectRepo: mockProjectRepo})
 
 			app.Delete("/projects/:id", func(c *fiber.Ctx) error {
 				c.Locals("userRole", tt.userRole)
 				c.Locals("userId", tt.userId)
+				tenant.SetTeam(c, teamId)
 				return handler.DeleteProject(c)
 			})
 
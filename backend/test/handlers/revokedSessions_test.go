@@ -0,0 +1,278 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/tenant"
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
+	"github.com/TobiasRV/challenge-fs-senior/test/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func requestWithToken(t *testing.T, app *fiber.App, method string, path string, token string) int {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+
+	return resp.StatusCode
+}
+
+func TestHandler_RevokedSessions(t *testing.T) {
+	user := models.User{ID: uuid.New(), Role: models.UserrolesMember}
+	revokedSessionId := uuid.New()
+	activeSessionId := uuid.New()
+
+	revokedToken, err := utils.GenerateJWTToken(user, revokedSessionId)
+	assert.NoError(t, err)
+
+	activeToken, err := utils.GenerateJWTToken(user, activeSessionId)
+	assert.NoError(t, err)
+
+	t.Run("Session revoked through another instance is denied after a sync", func(t *testing.T) {
+		mockRevokedSessionRepo := mocks.NewMockRevokedSessionRepository()
+		mockRevokedSessionRepo.On("GetRevokedSessionIds", mock.Anything, mock.MatchedBy(func(since time.Time) bool {
+			return time.Since(since) >= utils.AccessTokenTTL
+		})).Return([]uuid.UUID{revokedSessionId}, nil)
+
+		handler := newTestHandler(handlerDeps{revokedSessionRepo: mockRevokedSessionRepo})
+
+		app := setupTestApp()
+		app.Get("/protected", handler.JWTSuccessHandler, func(c *fiber.Ctx) error {
+			return c.SendStatus(fiber.StatusOK)
+		})
+
+		assert.Equal(t, fiber.StatusOK, requestWithToken(t, app, http.MethodGet, "/protected", revokedToken))
+
+		assert.NoError(t, handler.SyncRevokedSessions(context.Background()))
+
+		assert.Equal(t, fiber.StatusUnauthorized, requestWithToken(t, app, http.MethodGet, "/protected", revokedToken))
+		assert.Equal(t, fiber.StatusOK, requestWithToken(t, app, http.MethodGet, "/protected", activeToken))
+
+		mockRevokedSessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Logging out denies the access token right away", func(t *testing.T) {
+		mockSessionRepo := mocks.NewMockSessionRepository()
+		mockSessionRepo.On("DeleteSession", mock.Anything, revokedSessionId).Return(nil)
+
+		// No sync is needed on the instance that revoked the session
+		mockRevokedSessionRepo := mocks.NewMockRevokedSessionRepository()
+
+		handler := newTestHandler(handlerDeps{sessionRepo: mockSessionRepo, revokedSessionRepo: mockRevokedSessionRepo})
+
+		app := setupTestApp()
+		app.Post("/logout", handler.JWTSuccessHandler, handler.LogOut)
+		app.Get("/protected", handler.JWTSuccessHandler, func(c *fiber.Ctx) error {
+			return c.SendStatus(fiber.StatusOK)
+		})
+
+		assert.Equal(t, fiber.StatusOK, requestWithToken(t, app, http.MethodPost, "/logout", revokedToken))
+		assert.Equal(t, fiber.StatusUnauthorized, requestWithToken(t, app, http.MethodGet, "/protected", revokedToken))
+		assert.Equal(t, fiber.StatusOK, requestWithToken(t, app, http.MethodGet, "/protected", activeToken))
+
+		mockSessionRepo.AssertExpectations(t)
+		mockRevokedSessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Reusing a refresh token denies the access tokens of its session right away", func(t *testing.T) {
+		refreshJWT, _, err := utils.GenerateJWTRefreshToken(user, revokedSessionId)
+		assert.NoError(t, err)
+
+		mockRefreshTokenRepo := mocks.NewMockRefreshTokenRepository()
+		mockRefreshTokenRepo.On("GetRefreshTokenByToken", mock.Anything, refreshJWT).Return(models.RefreshTokenWithUser{
+			ID:        uuid.New(),
+			Userid:    user.ID,
+			Token:     refreshJWT,
+			ExpiresAt: time.Now().UTC().Add(time.Hour),
+			FamilyID:  revokedSessionId,
+			UsedAt:    sql.NullTime{Time: time.Now().UTC(), Valid: true},
+			UserData:  user,
+		}, nil)
+		mockRefreshTokenRepo.On("RevokeRefreshTokenFamily", mock.Anything, revokedSessionId).Return(nil)
+
+		mockSessionRepo := mocks.NewMockSessionRepository()
+		mockSessionRepo.On("DeleteSession", mock.Anything, revokedSessionId).Return(nil)
+
+		handler := newTestHandler(handlerDeps{refreshTokenRepo: mockRefreshTokenRepo, sessionRepo: mockSessionRepo})
+
+		app := setupTestApp()
+		app.Post("/auth/refresh-token", handler.RefreshToken)
+		app.Get("/protected", handler.JWTSuccessHandler, func(c *fiber.Ctx) error {
+			return c.SendStatus(fiber.StatusOK)
+		})
+
+		body, _ := json.Marshal(map[string]string{"refreshToken": refreshJWT})
+		req := httptest.NewRequest(http.MethodPost, "/auth/refresh-token", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+		assert.Equal(t, fiber.StatusUnauthorized, requestWithToken(t, app, http.MethodGet, "/protected", revokedToken))
+		assert.Equal(t, fiber.StatusOK, requestWithToken(t, app, http.MethodGet, "/protected", activeToken))
+
+		mockRefreshTokenRepo.AssertExpectations(t)
+		mockSessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Watching removes expired rows and syncs", func(t *testing.T) {
+		synced := make(chan struct{}, 1)
+
+		mockRevokedSessionRepo := mocks.NewMockRevokedSessionRepository()
+		mockRevokedSessionRepo.On("DeleteRevokedSessionsBefore", mock.Anything, mock.AnythingOfType("time.Time")).Return(nil)
+		mockRevokedSessionRepo.On("GetRevokedSessionIds", mock.Anything, mock.AnythingOfType("time.Time")).Return([]uuid.UUID{revokedSessionId}, nil).
+			Run(func(args mock.Arguments) {
+				select {
+				case synced <- struct{}{}:
+				default:
+				}
+			})
+
+		handler := newTestHandler(handlerDeps{revokedSessionRepo: mockRevokedSessionRepo})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go handler.WatchRevokedSessions(ctx, 10*time.Millisecond)
+
+		select {
+		case <-synced:
+		case <-time.After(time.Second):
+			t.Fatal("revoked sessions were not synced")
+		}
+
+		app := setupTestApp()
+		app.Get("/protected", handler.JWTSuccessHandler, func(c *fiber.Ctx) error {
+			return c.SendStatus(fiber.StatusOK)
+		})
+
+		assert.Equal(t, fiber.StatusUnauthorized, requestWithToken(t, app, http.MethodGet, "/protected", revokedToken))
+		mockRevokedSessionRepo.AssertCalled(t, "DeleteRevokedSessionsBefore", mock.Anything, mock.AnythingOfType("time.Time"))
+	})
+}
+
+func TestHandler_UpdateUserRole(t *testing.T) {
+	managerId := uuid.New()
+	adminId := uuid.New()
+	teamId := uuid.New()
+	sessionId := uuid.New()
+
+	manager := models.User{ID: managerId, Username: "manager", Email: "manager@example.com", Role: models.UserrolesManager, TeamId: teamId}
+	demoted := models.User{ID: managerId, Username: "manager", Email: "manager@example.com", Role: models.UserrolesMember, TeamId: teamId}
+	admin := models.User{ID: adminId, Username: "admin", Email: "admin@example.com", Role: models.UserrolesAdmin}
+
+	managerToken, err := utils.GenerateJWTToken(manager, sessionId)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name             string
+		userId           uuid.UUID
+		body             map[string]interface{}
+		setupMocks       func(*mocks.MockUserRepository, *mocks.MockRevokedSessionRepository)
+		expectedStatus   int
+		expectTokenValid bool
+	}{
+		{
+			name:   "Demoting revokes the sessions of the user",
+			userId: managerId,
+			body:   map[string]interface{}{"username": "manager", "email": "manager@example.com", "role": "Member"},
+			setupMocks: func(ur *mocks.MockUserRepository, rsr *mocks.MockRevokedSessionRepository) {
+				ur.On("GetUserById", mock.Anything, managerId).Return(manager, nil)
+				ur.On("UpdateUser", mock.Anything, mock.AnythingOfType("interfaces.UpdateUserData")).Return(manager, nil)
+				ur.On("UpdateUserRole", mock.Anything, mock.MatchedBy(func(data interfaces.UpdateUserRoleData) bool {
+					return data.ID == managerId && data.Role == models.UserrolesMember
+				})).Return(demoted, nil)
+				rsr.On("GetRevokedSessionIds", mock.Anything, mock.AnythingOfType("time.Time")).Return([]uuid.UUID{sessionId}, nil)
+			},
+			expectedStatus:   fiber.StatusCreated,
+			expectTokenValid: false,
+		},
+		{
+			name:   "Same role keeps the sessions",
+			userId: managerId,
+			body:   map[string]interface{}{"username": "manager", "email": "manager@example.com", "role": "Manager"},
+			setupMocks: func(ur *mocks.MockUserRepository, rsr *mocks.MockRevokedSessionRepository) {
+				ur.On("GetUserById", mock.Anything, managerId).Return(manager, nil)
+				ur.On("UpdateUser", mock.Anything, mock.AnythingOfType("interfaces.UpdateUserData")).Return(manager, nil)
+			},
+			expectedStatus:   fiber.StatusCreated,
+			expectTokenValid: true,
+		},
+		{
+			name:   "Admin role can't be changed",
+			userId: adminId,
+			body:   map[string]interface{}{"username": "admin", "email": "admin@example.com", "role": "Member"},
+			setupMocks: func(ur *mocks.MockUserRepository, rsr *mocks.MockRevokedSessionRepository) {
+				ur.On("GetUserById", mock.Anything, adminId).Return(admin, nil)
+			},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectTokenValid: true,
+		},
+		{
+			name:   "Promoting to admin is not allowed",
+			userId: managerId,
+			body:   map[string]interface{}{"username": "manager", "email": "manager@example.com", "role": "Admin"},
+			setupMocks: func(ur *mocks.MockUserRepository, rsr *mocks.MockRevokedSessionRepository) {
+				ur.On("GetUserById", mock.Anything, managerId).Return(manager, nil)
+			},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectTokenValid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := mocks.NewMockUserRepository()
+			mockRevokedSessionRepo := mocks.NewMockRevokedSessionRepository()
+
+			tt.setupMocks(mockUserRepo, mockRevokedSessionRepo)
+
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, revokedSessionRepo: mockRevokedSessionRepo})
+
+			app := setupTestApp()
+			app.Put("/users/:id", func(c *fiber.Ctx) error {
+				c.Locals("userId", adminId.String())
+				c.Locals("userRole", "Admin")
+				tenant.SetTeam(c, teamId)
+				return c.Next()
+			}, handler.UpdateUser)
+			app.Get("/protected", handler.JWTSuccessHandler, func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPut, "/users/"+tt.userId.String(), bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			status := requestWithToken(t, app, http.MethodGet, "/protected", managerToken)
+
+			if tt.expectTokenValid {
+				assert.Equal(t, fiber.StatusOK, status)
+			} else {
+				assert.Equal(t, fiber.StatusUnauthorized, status)
+			}
+
+			mockUserRepo.AssertExpectations(t)
+			mockRevokedSessionRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,762 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/handlers"
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/policy"
+	"github.com/TobiasRV/challenge-fs-senior/internals/tenant"
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
+	"github.com/TobiasRV/challenge-fs-senior/test/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupRolesTestApp(handler *handlers.Handler, userId uuid.UUID, role models.Userroles, teamId uuid.UUID) *fiber.App {
+	app := setupTestApp()
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("userId", userId.String())
+		c.Locals("userRole", string(role))
+		tenant.SetTeam(c, teamId)
+		return c.Next()
+	})
+
+	app.Get("/roles", handler.GetRoles)
+	app.Post("/roles", handler.CreateRole)
+	app.Get("/roles/:id", handler.GetRole)
+	app.Put("/roles/:id", handler.UpdateRole)
+	app.Delete("/roles/:id", handler.DeleteRole)
+	app.Put("/users/:id", handler.UpdateUser)
+
+	return app
+}
+
+func rolesRequest(t *testing.T, app *fiber.App, method string, path string, body map[string]interface{}) *http.Response {
+	var reader *bytes.Reader
+
+	if body != nil {
+		payload, _ := json.Marshal(body)
+		reader = bytes.NewReader(payload)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+
+	return resp
+}
+
+func builtInRole(role models.Userroles) models.Role {
+	return models.Role{
+		ID:          models.BuiltInRoleIds[role],
+		Name:        string(role),
+		Permissions: models.BuiltInRolePermissions[role],
+		BuiltIn:     true,
+		BaseRole:    role,
+	}
+}
+
+func TestHandler_GetRoles(t *testing.T) {
+	teamId := uuid.New()
+	lead := models.Role{ID: uuid.New(), TeamID: uuid.NullUUID{UUID: teamId, Valid: true}, Name: "Lead", Permissions: []string{models.PermissionTasksEditAny}}
+
+	tests := []struct {
+		name           string
+		role           models.Userroles
+		setupMocks     func(*mocks.MockRoleRepository)
+		expectedStatus int
+		expectedCount  int
+	}{
+		{
+			name: "Admin lists the built-in roles and the ones of its team",
+			role: models.UserrolesAdmin,
+			setupMocks: func(rr *mocks.MockRoleRepository) {
+				rr.On("GetRolesByTeam", mock.Anything, teamId).Return([]models.Role{
+					builtInRole(models.UserrolesAdmin), builtInRole(models.UserrolesManager), builtInRole(models.UserrolesMember), lead,
+				}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+			expectedCount:  4,
+		},
+		{
+			name: "Members can list the roles",
+			role: models.UserrolesMember,
+			setupMocks: func(rr *mocks.MockRoleRepository) {
+				rr.On("GetRolesByTeam", mock.Anything, teamId).Return([]models.Role{lead}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+			expectedCount:  1,
+		},
+		{
+			name: "Database error",
+			role: models.UserrolesAdmin,
+			setupMocks: func(rr *mocks.MockRoleRepository) {
+				rr.On("GetRolesByTeam", mock.Anything, teamId).Return([]models.Role{}, sql.ErrConnDone)
+			},
+			expectedStatus: fiber.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRoleRepo := mocks.NewMockRoleRepository()
+			tt.setupMocks(mockRoleRepo)
+
+			handler := newTestHandler(handlerDeps{roleRepo: mockRoleRepo})
+			app := setupRolesTestApp(handler, uuid.New(), tt.role, teamId)
+
+			resp := rolesRequest(t, app, http.MethodGet, "/roles", nil)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.expectedStatus == fiber.StatusOK {
+				var body interfaces.RolesListResponse
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+				assert.Len(t, body.Data, tt.expectedCount)
+			}
+
+			mockRoleRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_GetRole(t *testing.T) {
+	teamId := uuid.New()
+	lead := models.Role{ID: uuid.New(), TeamID: uuid.NullUUID{UUID: teamId, Valid: true}, Name: "Lead", Permissions: []string{models.PermissionTasksEditAny}}
+	otherTeamRole := models.Role{ID: uuid.New(), TeamID: uuid.NullUUID{UUID: uuid.New(), Valid: true}, Name: "QA", Permissions: []string{models.PermissionTasksViewAny}}
+	manager := builtInRole(models.UserrolesManager)
+
+	tests := []struct {
+		name           string
+		roleId         uuid.UUID
+		setupMocks     func(*mocks.MockRoleRepository)
+		expectedStatus int
+	}{
+		{
+			name:   "Successfully get a custom role",
+			roleId: lead.ID,
+			setupMocks: func(rr *mocks.MockRoleRepository) {
+				rr.On("GetRoleById", mock.Anything, lead.ID).Return(lead, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:   "Successfully get a built-in role",
+			roleId: manager.ID,
+			setupMocks: func(rr *mocks.MockRoleRepository) {
+				rr.On("GetRoleById", mock.Anything, manager.ID).Return(manager, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:   "Role of another team is not found",
+			roleId: otherTeamRole.ID,
+			setupMocks: func(rr *mocks.MockRoleRepository) {
+				rr.On("GetRoleById", mock.Anything, otherTeamRole.ID).Return(otherTeamRole, nil)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:   "Role not found",
+			roleId: uuid.New(),
+			setupMocks: func(rr *mocks.MockRoleRepository) {
+				rr.On("GetRoleById", mock.Anything, mock.AnythingOfType("uuid.UUID")).Return(models.Role{}, sql.ErrNoRows)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRoleRepo := mocks.NewMockRoleRepository()
+			tt.setupMocks(mockRoleRepo)
+
+			handler := newTestHandler(handlerDeps{roleRepo: mockRoleRepo})
+			app := setupRolesTestApp(handler, uuid.New(), models.UserrolesMember, teamId)
+
+			resp := rolesRequest(t, app, http.MethodGet, "/roles/"+tt.roleId.String(), nil)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockRoleRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_CreateRole(t *testing.T) {
+	teamId := uuid.New()
+
+	tests := []struct {
+		name           string
+		role           models.Userroles
+		body           map[string]interface{}
+		setupMocks     func(*mocks.MockRoleRepository)
+		expectedStatus int
+	}{
+		{
+			name: "Successfully create a role",
+			role: models.UserrolesAdmin,
+			body: map[string]interface{}{"name": "QA", "permissions": []string{models.PermissionTasksViewAny, models.PermissionTasksEditAny}},
+			setupMocks: func(rr *mocks.MockRoleRepository) {
+				rr.On("GetRoleByName", mock.Anything, interfaces.GetRoleByNameData{TeamID: teamId, Name: "QA"}).Return(false, models.Role{}, nil)
+				rr.On("CreateRole", mock.Anything, mock.MatchedBy(func(role models.Role) bool {
+					return role.TeamID.UUID == teamId && role.Name == "QA" && len(role.Permissions) == 2
+				})).Return(models.Role{ID: uuid.New(), TeamID: uuid.NullUUID{UUID: teamId, Valid: true}, Name: "QA"}, nil)
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name: "Name of an existing role",
+			role: models.UserrolesAdmin,
+			body: map[string]interface{}{"name": "manager", "permissions": []string{models.PermissionTasksViewAny}},
+			setupMocks: func(rr *mocks.MockRoleRepository) {
+				rr.On("GetRoleByName", mock.Anything, interfaces.GetRoleByNameData{TeamID: teamId, Name: "manager"}).Return(true, builtInRole(models.UserrolesManager), nil)
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name:           "Unknown permission",
+			role:           models.UserrolesAdmin,
+			body:           map[string]interface{}{"name": "QA", "permissions": []string{"teams:delete"}},
+			setupMocks:     func(rr *mocks.MockRoleRepository) {},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "Missing permissions",
+			role:           models.UserrolesAdmin,
+			body:           map[string]interface{}{"name": "QA", "permissions": []string{}},
+			setupMocks:     func(rr *mocks.MockRoleRepository) {},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "Managers can't create roles",
+			role:           models.UserrolesManager,
+			body:           map[string]interface{}{"name": "QA", "permissions": []string{models.PermissionTasksViewAny}},
+			setupMocks:     func(rr *mocks.MockRoleRepository) {},
+			expectedStatus: fiber.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRoleRepo := mocks.NewMockRoleRepository()
+			tt.setupMocks(mockRoleRepo)
+
+			handler := newTestHandler(handlerDeps{roleRepo: mockRoleRepo})
+			app := setupRolesTestApp(handler, uuid.New(), tt.role, teamId)
+
+			resp := rolesRequest(t, app, http.MethodPost, "/roles", tt.body)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockRoleRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_UpdateRole(t *testing.T) {
+	teamId := uuid.New()
+	lead := models.Role{ID: uuid.New(), TeamID: uuid.NullUUID{UUID: teamId, Valid: true}, Name: "Lead", Permissions: []string{models.PermissionTasksEditAny}}
+	qa := models.Role{ID: uuid.New(), TeamID: uuid.NullUUID{UUID: teamId, Valid: true}, Name: "QA", Permissions: []string{models.PermissionTasksViewAny}}
+	otherTeamRole := models.Role{ID: uuid.New(), TeamID: uuid.NullUUID{UUID: uuid.New(), Valid: true}, Name: "Lead", Permissions: []string{models.PermissionTasksEditAny}}
+	member := builtInRole(models.UserrolesMember)
+
+	tests := []struct {
+		name           string
+		roleId         uuid.UUID
+		body           map[string]interface{}
+		setupMocks     func(*mocks.MockRoleRepository)
+		expectedStatus int
+	}{
+		{
+			name:   "Successfully update the permissions of a role",
+			roleId: lead.ID,
+			body:   map[string]interface{}{"name": "Lead", "permissions": []string{models.PermissionTasksEditAny, models.PermissionTasksDeleteAny}},
+			setupMocks: func(rr *mocks.MockRoleRepository) {
+				rr.On("GetRoleById", mock.Anything, lead.ID).Return(lead, nil)
+				rr.On("GetRoleByName", mock.Anything, interfaces.GetRoleByNameData{TeamID: teamId, Name: "Lead"}).Return(true, lead, nil)
+				rr.On("UpdateRole", mock.Anything, mock.MatchedBy(func(data interfaces.UpdateRoleData) bool {
+					return data.ID == lead.ID && len(data.Permissions) == 2
+				})).Return(lead, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:   "Renaming to the name of another role",
+			roleId: lead.ID,
+			body:   map[string]interface{}{"name": "qa", "permissions": []string{models.PermissionTasksEditAny}},
+			setupMocks: func(rr *mocks.MockRoleRepository) {
+				rr.On("GetRoleById", mock.Anything, lead.ID).Return(lead, nil)
+				rr.On("GetRoleByName", mock.Anything, interfaces.GetRoleByNameData{TeamID: teamId, Name: "qa"}).Return(true, qa, nil)
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name:   "Built-in roles can't be changed",
+			roleId: member.ID,
+			body:   map[string]interface{}{"name": "Member", "permissions": []string{models.PermissionTasksEditAny}},
+			setupMocks: func(rr *mocks.MockRoleRepository) {
+				rr.On("GetRoleById", mock.Anything, member.ID).Return(member, nil)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:   "Role of another team is not found",
+			roleId: otherTeamRole.ID,
+			body:   map[string]interface{}{"name": "Lead", "permissions": []string{models.PermissionTasksEditAny}},
+			setupMocks: func(rr *mocks.MockRoleRepository) {
+				rr.On("GetRoleById", mock.Anything, otherTeamRole.ID).Return(otherTeamRole, nil)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRoleRepo := mocks.NewMockRoleRepository()
+			tt.setupMocks(mockRoleRepo)
+
+			handler := newTestHandler(handlerDeps{roleRepo: mockRoleRepo})
+			app := setupRolesTestApp(handler, uuid.New(), models.UserrolesAdmin, teamId)
+
+			resp := rolesRequest(t, app, http.MethodPut, "/roles/"+tt.roleId.String(), tt.body)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockRoleRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_DeleteRole(t *testing.T) {
+	teamId := uuid.New()
+	lead := models.Role{ID: uuid.New(), TeamID: uuid.NullUUID{UUID: teamId, Valid: true}, Name: "Lead", Permissions: []string{models.PermissionTasksEditAny}}
+	otherTeamRole := models.Role{ID: uuid.New(), TeamID: uuid.NullUUID{UUID: uuid.New(), Valid: true}, Name: "Lead", Permissions: []string{models.PermissionTasksEditAny}}
+	manager := builtInRole(models.UserrolesManager)
+
+	tests := []struct {
+		name           string
+		role           models.Userroles
+		roleId         uuid.UUID
+		setupMocks     func(*mocks.MockRoleRepository)
+		expectedStatus int
+	}{
+		{
+			name:   "Successfully delete a role",
+			role:   models.UserrolesAdmin,
+			roleId: lead.ID,
+			setupMocks: func(rr *mocks.MockRoleRepository) {
+				rr.On("GetRoleById", mock.Anything, lead.ID).Return(lead, nil)
+				rr.On("DeleteRole", mock.Anything, lead.ID).Return(nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:   "Built-in roles can't be deleted",
+			role:   models.UserrolesAdmin,
+			roleId: manager.ID,
+			setupMocks: func(rr *mocks.MockRoleRepository) {
+				rr.On("GetRoleById", mock.Anything, manager.ID).Return(manager, nil)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:   "Role of another team is not found",
+			role:   models.UserrolesAdmin,
+			roleId: otherTeamRole.ID,
+			setupMocks: func(rr *mocks.MockRoleRepository) {
+				rr.On("GetRoleById", mock.Anything, otherTeamRole.ID).Return(otherTeamRole, nil)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:           "Members can't delete roles",
+			role:           models.UserrolesMember,
+			roleId:         lead.ID,
+			setupMocks:     func(rr *mocks.MockRoleRepository) {},
+			expectedStatus: fiber.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRoleRepo := mocks.NewMockRoleRepository()
+			tt.setupMocks(mockRoleRepo)
+
+			handler := newTestHandler(handlerDeps{roleRepo: mockRoleRepo})
+			app := setupRolesTestApp(handler, uuid.New(), tt.role, teamId)
+
+			resp := rolesRequest(t, app, http.MethodDelete, "/roles/"+tt.roleId.String(), nil)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockRoleRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_AssignRole(t *testing.T) {
+	adminId := uuid.New()
+	teamId := uuid.New()
+	lead := models.Role{ID: uuid.New(), TeamID: uuid.NullUUID{UUID: teamId, Valid: true}, Name: "Lead", Permissions: []string{models.PermissionTasksEditAny}}
+	otherTeamRole := models.Role{ID: uuid.New(), TeamID: uuid.NullUUID{UUID: uuid.New(), Valid: true}, Name: "Lead", Permissions: []string{models.PermissionTasksEditAny}}
+	managerRole := builtInRole(models.UserrolesManager)
+	adminRole := builtInRole(models.UserrolesAdmin)
+
+	member := models.User{ID: uuid.New(), Username: "member", Email: "member@example.com", Role: models.UserrolesMember, RoleId: models.BuiltInRoleIds[models.UserrolesMember], TeamId: teamId}
+	admin := models.User{ID: adminId, Username: "admin", Email: "admin@example.com", Role: models.UserrolesAdmin, RoleId: adminRole.ID}
+
+	tests := []struct {
+		name           string
+		userId         uuid.UUID
+		body           map[string]interface{}
+		setupMocks     func(*mocks.MockUserRepository, *mocks.MockRevokedSessionRepository, *mocks.MockRoleRepository)
+		expectedStatus int
+	}{
+		{
+			name:   "Assigning a custom role keeps the base role",
+			userId: member.ID,
+			body:   map[string]interface{}{"username": "member", "email": "member@example.com", "roleId": lead.ID},
+			setupMocks: func(ur *mocks.MockUserRepository, rsr *mocks.MockRevokedSessionRepository, rr *mocks.MockRoleRepository) {
+				ur.On("GetUserById", mock.Anything, member.ID).Return(member, nil)
+				rr.On("GetRoleById", mock.Anything, lead.ID).Return(lead, nil)
+				ur.On("UpdateUser", mock.Anything, mock.AnythingOfType("interfaces.UpdateUserData")).Return(member, nil)
+				ur.On("AssignUserRole", mock.Anything, mock.MatchedBy(func(data interfaces.AssignUserRoleData) bool {
+					return data.ID == member.ID && data.RoleId == lead.ID
+				})).Return(member, nil)
+				rsr.On("GetRevokedSessionIds", mock.Anything, mock.AnythingOfType("time.Time")).Return([]uuid.UUID{}, nil)
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name:   "Assigning a built-in role sets the base role",
+			userId: member.ID,
+			body:   map[string]interface{}{"username": "member", "email": "member@example.com", "roleId": managerRole.ID},
+			setupMocks: func(ur *mocks.MockUserRepository, rsr *mocks.MockRevokedSessionRepository, rr *mocks.MockRoleRepository) {
+				ur.On("GetUserById", mock.Anything, member.ID).Return(member, nil)
+				rr.On("GetRoleById", mock.Anything, managerRole.ID).Return(managerRole, nil)
+				ur.On("UpdateUser", mock.Anything, mock.AnythingOfType("interfaces.UpdateUserData")).Return(member, nil)
+				ur.On("UpdateUserRole", mock.Anything, mock.MatchedBy(func(data interfaces.UpdateUserRoleData) bool {
+					return data.ID == member.ID && data.Role == models.UserrolesManager
+				})).Return(member, nil)
+				ur.On("AssignUserRole", mock.Anything, mock.MatchedBy(func(data interfaces.AssignUserRoleData) bool {
+					return data.ID == member.ID && data.RoleId == managerRole.ID
+				})).Return(member, nil)
+				rsr.On("GetRevokedSessionIds", mock.Anything, mock.AnythingOfType("time.Time")).Return([]uuid.UUID{}, nil)
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name:   "Role of another team is not found",
+			userId: member.ID,
+			body:   map[string]interface{}{"username": "member", "email": "member@example.com", "roleId": otherTeamRole.ID},
+			setupMocks: func(ur *mocks.MockUserRepository, rsr *mocks.MockRevokedSessionRepository, rr *mocks.MockRoleRepository) {
+				ur.On("GetUserById", mock.Anything, member.ID).Return(member, nil)
+				rr.On("GetRoleById", mock.Anything, otherTeamRole.ID).Return(otherTeamRole, nil)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:   "Admin role can't be assigned",
+			userId: member.ID,
+			body:   map[string]interface{}{"username": "member", "email": "member@example.com", "roleId": adminRole.ID},
+			setupMocks: func(ur *mocks.MockUserRepository, rsr *mocks.MockRevokedSessionRepository, rr *mocks.MockRoleRepository) {
+				ur.On("GetUserById", mock.Anything, member.ID).Return(member, nil)
+				rr.On("GetRoleById", mock.Anything, adminRole.ID).Return(adminRole, nil)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:   "Role of an admin can't be changed",
+			userId: adminId,
+			body:   map[string]interface{}{"username": "admin", "email": "admin@example.com", "roleId": lead.ID},
+			setupMocks: func(ur *mocks.MockUserRepository, rsr *mocks.MockRevokedSessionRepository, rr *mocks.MockRoleRepository) {
+				ur.On("GetUserById", mock.Anything, adminId).Return(admin, nil)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := mocks.NewMockUserRepository()
+			mockRevokedSessionRepo := mocks.NewMockRevokedSessionRepository()
+			mockRoleRepo := mocks.NewMockRoleRepository()
+
+			tt.setupMocks(mockUserRepo, mockRevokedSessionRepo, mockRoleRepo)
+
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, revokedSessionRepo: mockRevokedSessionRepo, roleRepo: mockRoleRepo})
+			app := setupRolesTestApp(handler, adminId, models.UserrolesAdmin, teamId)
+
+			resp := rolesRequest(t, app, http.MethodPut, "/users/"+tt.userId.String(), tt.body)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockUserRepo.AssertExpectations(t)
+			mockRevokedSessionRepo.AssertExpectations(t)
+			mockRoleRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_CustomRolePermissions(t *testing.T) {
+	teamId := uuid.New()
+	lead := models.Role{ID: uuid.New(), TeamID: uuid.NullUUID{UUID: teamId, Valid: true}, Name: "Lead", Permissions: []string{models.PermissionTasksViewAny, models.PermissionProjectsCreate}}
+	user := models.User{ID: uuid.New(), Role: models.UserrolesMember, RoleId: lead.ID, TeamId: teamId}
+
+	token, err := utils.GenerateJWTToken(user, uuid.New())
+	assert.NoError(t, err)
+
+	builtInToken, err := utils.GenerateJWTToken(models.User{ID: uuid.New(), Role: models.UserrolesMember, RoleId: models.BuiltInRoleIds[models.UserrolesMember]}, uuid.New())
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		token          string
+		setupMocks     func(*mocks.MockRoleRepository)
+		expectedStatus int
+	}{
+		{
+			name:  "Permissions of the custom role are granted",
+			token: token,
+			setupMocks: func(rr *mocks.MockRoleRepository) {
+				rr.On("GetRoleById", mock.Anything, lead.ID).Return(lead, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:  "Permissions the role doesn't have are denied",
+			token: token,
+			setupMocks: func(rr *mocks.MockRoleRepository) {
+				rr.On("GetRoleById", mock.Anything, lead.ID).Return(models.Role{ID: lead.ID, TeamID: lead.TeamID, Name: "Lead", Permissions: []string{models.PermissionTasksViewAny}}, nil)
+			},
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:  "Deleted role goes by the base role",
+			token: token,
+			setupMocks: func(rr *mocks.MockRoleRepository) {
+				rr.On("GetRoleById", mock.Anything, lead.ID).Return(models.Role{}, sql.ErrNoRows)
+			},
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:           "Built-in roles don't load their permissions",
+			token:          builtInToken,
+			setupMocks:     func(rr *mocks.MockRoleRepository) {},
+			expectedStatus: fiber.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRoleRepo := mocks.NewMockRoleRepository()
+			tt.setupMocks(mockRoleRepo)
+
+			handler := newTestHandler(handlerDeps{roleRepo: mockRoleRepo})
+
+			app := setupTestApp()
+			app.Post("/projects", handler.JWTSuccessHandler, policy.Require(policy.ActionCreate, policy.ResourceProjects), func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			assert.Equal(t, tt.expectedStatus, requestWithToken(t, app, http.MethodPost, "/projects", tt.token))
+
+			mockRoleRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_CustomRolePermissionsCache(t *testing.T) {
+	teamId := uuid.New()
+	lead := models.Role{ID: uuid.New(), TeamID: uuid.NullUUID{UUID: teamId, Valid: true}, Name: "Lead", Permissions: []string{models.PermissionTasksViewAny, models.PermissionProjectsCreate}}
+	demotedLead := models.Role{ID: lead.ID, TeamID: lead.TeamID, Name: "Lead", Permissions: []string{models.PermissionTasksViewAny}}
+	user := models.User{ID: uuid.New(), Role: models.UserrolesMember, RoleId: lead.ID, TeamId: teamId}
+
+	token, err := utils.GenerateJWTToken(user, uuid.New())
+	assert.NoError(t, err)
+
+	setup := func(rr *mocks.MockRoleRepository) (*handlers.Handler, *fiber.App, *fiber.App) {
+		handler := newTestHandler(handlerDeps{roleRepo: rr})
+
+		app := setupTestApp()
+		app.Post("/projects", handler.JWTSuccessHandler, policy.Require(policy.ActionCreate, policy.ResourceProjects), func(c *fiber.Ctx) error {
+			return c.SendStatus(fiber.StatusOK)
+		})
+
+		return handler, app, setupRolesTestApp(handler, uuid.New(), models.UserrolesAdmin, teamId)
+	}
+
+	t.Run("Permissions are loaded once", func(t *testing.T) {
+		mockRoleRepo := mocks.NewMockRoleRepository()
+		mockRoleRepo.On("GetRoleById", mock.Anything, lead.ID).Return(lead, nil).Once()
+
+		_, app, _ := setup(mockRoleRepo)
+
+		assert.Equal(t, fiber.StatusOK, requestWithToken(t, app, http.MethodPost, "/projects", token))
+		assert.Equal(t, fiber.StatusOK, requestWithToken(t, app, http.MethodPost, "/projects", token))
+
+		mockRoleRepo.AssertExpectations(t)
+	})
+
+	t.Run("Updating the role applies right away", func(t *testing.T) {
+		mockRoleRepo := mocks.NewMockRoleRepository()
+		mockRoleRepo.On("GetRoleById", mock.Anything, lead.ID).Return(lead, nil).Twice()
+		mockRoleRepo.On("GetRoleByName", mock.Anything, interfaces.GetRoleByNameData{TeamID: teamId, Name: "Lead"}).Return(true, lead, nil)
+		mockRoleRepo.On("UpdateRole", mock.Anything, mock.AnythingOfType("interfaces.UpdateRoleData")).Return(demotedLead, nil)
+
+		_, app, adminApp := setup(mockRoleRepo)
+
+		assert.Equal(t, fiber.StatusOK, requestWithToken(t, app, http.MethodPost, "/projects", token))
+
+		resp := rolesRequest(t, adminApp, http.MethodPut, "/roles/"+lead.ID.String(), map[string]interface{}{"name": "Lead", "permissions": demotedLead.Permissions})
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		assert.Equal(t, fiber.StatusForbidden, requestWithToken(t, app, http.MethodPost, "/projects", token))
+
+		mockRoleRepo.AssertExpectations(t)
+	})
+
+	t.Run("Deleting the role applies right away", func(t *testing.T) {
+		mockRoleRepo := mocks.NewMockRoleRepository()
+		mockRoleRepo.On("GetRoleById", mock.Anything, lead.ID).Return(lead, nil).Twice()
+		mockRoleRepo.On("DeleteRole", mock.Anything, lead.ID).Return(nil)
+
+		_, app, adminApp := setup(mockRoleRepo)
+
+		assert.Equal(t, fiber.StatusOK, requestWithToken(t, app, http.MethodPost, "/projects", token))
+
+		resp := rolesRequest(t, adminApp, http.MethodDelete, "/roles/"+lead.ID.String(), nil)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		assert.Equal(t, fiber.StatusForbidden, requestWithToken(t, app, http.MethodPost, "/projects", token))
+
+		mockRoleRepo.AssertExpectations(t)
+	})
+
+	t.Run("Changes made through another instance apply after a sync", func(t *testing.T) {
+		mockRoleRepo := mocks.NewMockRoleRepository()
+		mockRoleRepo.On("GetRoleById", mock.Anything, lead.ID).Return(lead, nil).Once()
+		mockRoleRepo.On("GetCustomRoles", mock.Anything).Return([]models.Role{demotedLead}, nil)
+
+		handler, app, _ := setup(mockRoleRepo)
+
+		assert.Equal(t, fiber.StatusOK, requestWithToken(t, app, http.MethodPost, "/projects", token))
+
+		assert.NoError(t, handler.SyncRolePermissions(context.Background()))
+
+		assert.Equal(t, fiber.StatusForbidden, requestWithToken(t, app, http.MethodPost, "/projects", token))
+
+		mockRoleRepo.AssertExpectations(t)
+	})
+
+	t.Run("Roles deleted through another instance are dropped by the sync", func(t *testing.T) {
+		mockRoleRepo := mocks.NewMockRoleRepository()
+		mockRoleRepo.On("GetCustomRoles", mock.Anything).Return([]models.Role{lead}, nil).Once()
+		mockRoleRepo.On("GetCustomRoles", mock.Anything).Return([]models.Role{}, nil).Once()
+		mockRoleRepo.On("GetRoleById", mock.Anything, lead.ID).Return(models.Role{}, sql.ErrNoRows).Once()
+
+		handler, app, _ := setup(mockRoleRepo)
+
+		assert.NoError(t, handler.SyncRolePermissions(context.Background()))
+		assert.Equal(t, fiber.StatusOK, requestWithToken(t, app, http.MethodPost, "/projects", token))
+
+		assert.NoError(t, handler.SyncRolePermissions(context.Background()))
+		assert.Equal(t, fiber.StatusForbidden, requestWithToken(t, app, http.MethodPost, "/projects", token))
+		assert.Equal(t, fiber.StatusForbidden, requestWithToken(t, app, http.MethodPost, "/projects", token))
+
+		mockRoleRepo.AssertExpectations(t)
+	})
+}
+
+func TestHandler_CustomRoleManagingAdmins(t *testing.T) {
+	teamId := uuid.New()
+	callerId := uuid.New()
+	caller := models.User{ID: callerId, Username: "lead", Email: "lead@example.com", Role: models.UserrolesMember, TeamId: teamId}
+	admin := models.User{ID: uuid.New(), Username: "admin", Email: "admin@example.com", Role: models.UserrolesAdmin}
+	member := models.User{ID: uuid.New(), Username: "member", Email: "member@example.com", Role: models.UserrolesMember, TeamId: teamId}
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           map[string]interface{}
+		setupMocks     func(*mocks.MockUserRepository)
+		expectedStatus int
+	}{
+		{
+			name:   "Updates a member of the team",
+			method: http.MethodPut,
+			path:   "/users/" + member.ID.String(),
+			body:   map[string]interface{}{"username": "renamed", "email": "member@example.com"},
+			setupMocks: func(ur *mocks.MockUserRepository) {
+				ur.On("GetUserById", mock.Anything, member.ID).Return(member, nil)
+				ur.On("UpdateUser", mock.Anything, mock.AnythingOfType("interfaces.UpdateUserData")).Return(member, nil)
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name:   "Can't update the admin",
+			method: http.MethodPut,
+			path:   "/users/" + admin.ID.String(),
+			body:   map[string]interface{}{"username": "admin", "email": "taken-over@example.com"},
+			setupMocks: func(ur *mocks.MockUserRepository) {
+				ur.On("GetUserById", mock.Anything, admin.ID).Return(admin, nil)
+			},
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:           "Can't create an admin",
+			method:         http.MethodPost,
+			path:           "/users",
+			body:           map[string]interface{}{"username": "new", "email": "new@example.com", "password": "password", "role": "Admin"},
+			setupMocks:     func(ur *mocks.MockUserRepository) {},
+			expectedStatus: fiber.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := mocks.NewMockUserRepository()
+			mockUserRepo.On("GetUserById", mock.Anything, callerId).Return(caller, nil).Maybe()
+			tt.setupMocks(mockUserRepo)
+
+			mockTeamRepo := mocks.NewMockTeamRepository()
+			mockTeamRepo.On("GetTeamByOwner", mock.Anything, admin.ID).Return(true, models.Team{ID: teamId, OwnerID: admin.ID}, nil).Maybe()
+
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, teamRepo: mockTeamRepo})
+
+			app := setupTestApp()
+			app.Use(func(c *fiber.Ctx) error {
+				c.Locals("userId", callerId.String())
+				c.Locals("userRole", string(models.UserrolesMember))
+				c.Locals("permissions", []string{models.PermissionUsersView, models.PermissionUsersManage})
+				tenant.SetTeam(c, teamId)
+				return c.Next()
+			})
+			app.Post("/users", handler.CreateUser)
+			app.Put("/users/:id", handler.UpdateUser)
+
+			resp := rolesRequest(t, app, tt.method, tt.path, tt.body)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,422 @@
+package handlers_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
+	"github.com/TobiasRV/challenge-fs-senior/test/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_GetSessions(t *testing.T) {
+	userId := uuid.New()
+	currentSessionId := uuid.New()
+	otherSessionId := uuid.New()
+	now := time.Now()
+
+	tests := []struct {
+		name            string
+		userId          string
+		sessionId       string
+		setupMocks      func(*mocks.MockSessionRepository)
+		expectedStatus  int
+		expectedCurrent map[string]bool
+	}{
+		{
+			name:      "Successfully list sessions",
+			userId:    userId.String(),
+			sessionId: currentSessionId.String(),
+			setupMocks: func(mockSessionRepo *mocks.MockSessionRepository) {
+				mockSessionRepo.On("GetSessionsByUserId", mock.Anything, userId).Return([]models.Session{
+					{
+						ID:         currentSessionId,
+						UserID:     userId,
+						Label:      "Work laptop",
+						CreatedAt:  now,
+						LastUsedAt: now,
+					},
+					{
+						ID:         otherSessionId,
+						UserID:     userId,
+						Label:      "Phone",
+						CreatedAt:  now,
+						LastUsedAt: now,
+					},
+				}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+			expectedCurrent: map[string]bool{
+				currentSessionId.String(): true,
+				otherSessionId.String():   false,
+			},
+		},
+		{
+			name:      "Database error",
+			userId:    userId.String(),
+			sessionId: currentSessionId.String(),
+			setupMocks: func(mockSessionRepo *mocks.MockSessionRepository) {
+				mockSessionRepo.On("GetSessionsByUserId", mock.Anything, userId).Return([]models.Session{}, sql.ErrConnDone)
+			},
+			expectedStatus: fiber.StatusInternalServerError,
+		},
+		{
+			name:           "Invalid user ID",
+			userId:         "invalid-uuid",
+			sessionId:      currentSessionId.String(),
+			setupMocks:     func(mockSessionRepo *mocks.MockSessionRepository) {},
+			expectedStatus: fiber.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockSessionRepo := mocks.NewMockSessionRepository()
+
+			tt.setupMocks(mockSessionRepo)
+
+			handler := newTestHandler(handlerDeps{sessionRepo: mockSessionRepo})
+
+			app.Get("/auth/sessions", func(c *fiber.Ctx) error {
+				c.Locals("userId", tt.userId)
+				c.Locals("sessionId", tt.sessionId)
+				return handler.GetSessions(c)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/auth/sessions", nil)
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.expectedCurrent != nil {
+				body, _ := io.ReadAll(resp.Body)
+
+				var response struct {
+					Data []struct {
+						ID      string `json:"id"`
+						Current bool   `json:"current"`
+					} `json:"data"`
+				}
+				assert.NoError(t, json.Unmarshal(body, &response))
+				assert.Len(t, response.Data, len(tt.expectedCurrent))
+
+				for _, s := range response.Data {
+					assert.Equal(t, tt.expectedCurrent[s.ID], s.Current)
+				}
+			}
+
+			mockSessionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_DeleteSession(t *testing.T) {
+	userId := uuid.New()
+	otherUserId := uuid.New()
+	sessionId := uuid.New()
+
+	tests := []struct {
+		name           string
+		userId         string
+		sessionId      string
+		setupMocks     func(*mocks.MockSessionRepository)
+		expectedStatus int
+	}{
+		{
+			name:      "Successfully delete session",
+			userId:    userId.String(),
+			sessionId: sessionId.String(),
+			setupMocks: func(mockSessionRepo *mocks.MockSessionRepository) {
+				mockSessionRepo.On("GetSessionById", mock.Anything, sessionId).Return(models.Session{ID: sessionId, UserID: userId}, nil)
+				mockSessionRepo.On("DeleteSession", mock.Anything, sessionId).Return(nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:      "Session not found",
+			userId:    userId.String(),
+			sessionId: sessionId.String(),
+			setupMocks: func(mockSessionRepo *mocks.MockSessionRepository) {
+				mockSessionRepo.On("GetSessionById", mock.Anything, sessionId).Return(models.Session{}, sql.ErrNoRows)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:      "Session belongs to another user",
+			userId:    userId.String(),
+			sessionId: sessionId.String(),
+			setupMocks: func(mockSessionRepo *mocks.MockSessionRepository) {
+				mockSessionRepo.On("GetSessionById", mock.Anything, sessionId).Return(models.Session{ID: sessionId, UserID: otherUserId}, nil)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:           "Invalid session ID",
+			userId:         userId.String(),
+			sessionId:      "invalid-uuid",
+			setupMocks:     func(mockSessionRepo *mocks.MockSessionRepository) {},
+			expectedStatus: fiber.StatusInternalServerError,
+		},
+		{
+			name:      "Database error on delete",
+			userId:    userId.String(),
+			sessionId: sessionId.String(),
+			setupMocks: func(mockSessionRepo *mocks.MockSessionRepository) {
+				mockSessionRepo.On("GetSessionById", mock.Anything, sessionId).Return(models.Session{ID: sessionId, UserID: userId}, nil)
+				mockSessionRepo.On("DeleteSession", mock.Anything, sessionId).Return(sql.ErrConnDone)
+			},
+			expectedStatus: fiber.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockSessionRepo := mocks.NewMockSessionRepository()
+
+			tt.setupMocks(mockSessionRepo)
+
+			handler := newTestHandler(handlerDeps{sessionRepo: mockSessionRepo})
+
+			app.Delete("/auth/sessions/:id", func(c *fiber.Ctx) error {
+				c.Locals("userId", tt.userId)
+				c.Locals("userRole", "Member")
+				return handler.DeleteSession(c)
+			})
+
+			req := httptest.NewRequest(http.MethodDelete, "/auth/sessions/"+tt.sessionId, nil)
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockSessionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_RevokeUserSessions(t *testing.T) {
+	adminId := uuid.New()
+	memberId := uuid.New()
+	teamId := uuid.New()
+	otherTeamId := uuid.New()
+
+	tests := []struct {
+		name           string
+		userRole       string
+		targetId       string
+		setupMocks     func(*mocks.MockUserRepository, *mocks.MockTeamRepository, *mocks.MockSessionRepository)
+		expectedStatus int
+	}{
+		{
+			name:     "Unauthorized - non-admin user",
+			userRole: "Member",
+			targetId: memberId.String(),
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockSessionRepo *mocks.MockSessionRepository) {
+			},
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:     "Successfully revoke sessions of a team member",
+			userRole: "Admin",
+			targetId: memberId.String(),
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockSessionRepo *mocks.MockSessionRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, memberId).Return(models.User{
+					ID:     memberId,
+					Role:   models.UserrolesMember,
+					TeamId: teamId,
+				}, nil)
+				mockTeamRepo.On("GetTeamByOwner", mock.Anything, adminId).Return(true, models.Team{ID: teamId, OwnerID: adminId}, nil)
+				mockSessionRepo.On("DeleteSessionsByUserId", mock.Anything, memberId).Return(nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:     "Successfully revoke own sessions",
+			userRole: "Admin",
+			targetId: adminId.String(),
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockSessionRepo *mocks.MockSessionRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, adminId).Return(models.User{ID: adminId, Role: models.UserrolesAdmin}, nil)
+				mockTeamRepo.On("GetTeamByOwner", mock.Anything, adminId).Return(true, models.Team{ID: teamId, OwnerID: adminId}, nil)
+				mockSessionRepo.On("DeleteSessionsByUserId", mock.Anything, adminId).Return(nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:     "User from another team",
+			userRole: "Admin",
+			targetId: memberId.String(),
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockSessionRepo *mocks.MockSessionRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, memberId).Return(models.User{
+					ID:     memberId,
+					Role:   models.UserrolesMember,
+					TeamId: otherTeamId,
+				}, nil)
+				mockTeamRepo.On("GetTeamByOwner", mock.Anything, adminId).Return(true, models.Team{ID: teamId, OwnerID: adminId}, nil)
+				mockTeamRepo.On("GetTeamMember", mock.Anything, teamId, memberId).Return(false, models.TeamMember{}, nil)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:     "User not found",
+			userRole: "Admin",
+			targetId: memberId.String(),
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockSessionRepo *mocks.MockSessionRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, memberId).Return(models.User{}, sql.ErrNoRows)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:     "Invalid user ID",
+			userRole: "Admin",
+			targetId: "invalid-uuid",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockSessionRepo *mocks.MockSessionRepository) {
+			},
+			expectedStatus: fiber.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockUserRepo := mocks.NewMockUserRepository()
+			mockTeamRepo := mocks.NewMockTeamRepository()
+			mockSessionRepo := mocks.NewMockSessionRepository()
+
+			tt.setupMocks(mockUserRepo, mockTeamRepo, mockSessionRepo)
+
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, teamRepo: mockTeamRepo, sessionRepo: mockSessionRepo})
+
+			app.Delete("/users/:id/sessions", func(c *fiber.Ctx) error {
+				c.Locals("userRole", tt.userRole)
+				c.Locals("userId", adminId.String())
+				return handler.RevokeUserSessions(c)
+			})
+
+			req := httptest.NewRequest(http.MethodDelete, "/users/"+tt.targetId+"/sessions", nil)
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockUserRepo.AssertExpectations(t)
+			mockTeamRepo.AssertExpectations(t)
+			mockSessionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_SwitchTeam(t *testing.T) {
+	userId := uuid.New()
+	sessionId := uuid.New()
+	teamId := uuid.New()
+	otherTeamId := uuid.New()
+
+	user := models.User{ID: userId, Username: "contractor", Email: "contractor@example.com", Role: models.UserrolesMember, TeamId: teamId}
+	member := models.TeamMember{TeamID: otherTeamId, UserID: userId, Role: models.UserrolesManager, RoleId: models.BuiltInRoleIds[models.UserrolesManager]}
+
+	tests := []struct {
+		name           string
+		requestBody    map[string]interface{}
+		setupMocks     func(*mocks.MockTeamRepository, *mocks.MockSessionRepository, *mocks.MockMfaRepository)
+		expectedStatus int
+	}{
+		{
+			name:        "Missing team",
+			requestBody: map[string]interface{}{},
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository, mockSessionRepo *mocks.MockSessionRepository, mockMfaRepo *mocks.MockMfaRepository) {
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "Not a member of the team",
+			requestBody: map[string]interface{}{"teamId": otherTeamId.String()},
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository, mockSessionRepo *mocks.MockSessionRepository, mockMfaRepo *mocks.MockMfaRepository) {
+				mockTeamRepo.On("GetTeamMember", mock.Anything, otherTeamId, userId).Return(false, models.TeamMember{}, nil)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:        "The team requires two-factor authentication",
+			requestBody: map[string]interface{}{"teamId": otherTeamId.String()},
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository, mockSessionRepo *mocks.MockSessionRepository, mockMfaRepo *mocks.MockMfaRepository) {
+				mockTeamRepo.On("GetTeamMember", mock.Anything, otherTeamId, userId).Return(true, member, nil)
+				mockTeamRepo.On("GetTeamById", mock.Anything, otherTeamId).Return(models.Team{ID: otherTeamId, RequireMfa: true}, nil)
+				mockMfaRepo.On("GetUserTotp", mock.Anything, userId).Return(models.UserTotp{}, sql.ErrNoRows)
+			},
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:        "Successfully switch the team",
+			requestBody: map[string]interface{}{"teamId": otherTeamId.String()},
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository, mockSessionRepo *mocks.MockSessionRepository, mockMfaRepo *mocks.MockMfaRepository) {
+				mockTeamRepo.On("GetTeamMember", mock.Anything, otherTeamId, userId).Return(true, member, nil)
+				mockTeamRepo.On("GetTeamById", mock.Anything, otherTeamId).Return(models.Team{ID: otherTeamId}, nil)
+				mockSessionRepo.On("UpdateSessionTeam", mock.Anything, interfaces.UpdateSessionTeamData{TeamID: otherTeamId, ID: sessionId}).Return(nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockUserRepo := mocks.NewMockUserRepository()
+			mockTeamRepo := mocks.NewMockTeamRepository()
+			mockSessionRepo := mocks.NewMockSessionRepository()
+			mockMfaRepo := mocks.NewMockMfaRepository()
+			mockUserRepo.On("GetUserById", mock.Anything, userId).Return(user, nil).Maybe()
+			tt.setupMocks(mockTeamRepo, mockSessionRepo, mockMfaRepo)
+
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, teamRepo: mockTeamRepo, sessionRepo: mockSessionRepo, mfaRepo: mockMfaRepo})
+
+			app.Post("/auth/switch-team", func(c *fiber.Ctx) error {
+				c.Locals("userRole", string(user.Role))
+				c.Locals("userId", userId.String())
+				c.Locals("sessionId", sessionId.String())
+				return handler.SwitchTeam(c)
+			})
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/auth/switch-team", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			// The new access token works in the team with the role the user has in it
+			if tt.expectedStatus == fiber.StatusOK {
+				var result interfaces.SwitchTeamResponse
+				json.NewDecoder(resp.Body).Decode(&result)
+
+				claims, err := utils.ParseJWTToken(result.AccessToken, utils.AccessTokenType)
+				assert.NoError(t, err)
+				assert.Equal(t, otherTeamId.String(), claims.TeamId)
+				assert.Equal(t, string(models.UserrolesManager), claims.Role)
+				assert.Equal(t, sessionId.String(), claims.SessionId)
+			}
+
+			mockTeamRepo.AssertExpectations(t)
+			mockSessionRepo.AssertExpectations(t)
+			mockMfaRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package handlers_test
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"encoding/json"
 	"net/http"
@@ -9,9 +10,9 @@ import (
 	"testing"
 	"time"
 
-	"github.com/TobiasRV/challenge-fs-senior/internals/handlers"
 	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
 	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/tenant"
 	"github.com/TobiasRV/challenge-fs-senior/test/mocks"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -21,6 +22,7 @@ import (
 
 func TestHandler_CreateTask(t *testing.T) {
 	userId := uuid.New()
+	teamId := uuid.New()
 	projectId := uuid.New()
 	taskId := uuid.New()
 	now := time.Now()
@@ -30,7 +32,7 @@ func TestHandler_CreateTask(t *testing.T) {
 		userRole       string
 		userId         string
 		requestBody    map[string]interface{}
-		setupMocks     func(*mocks.MockTaskRepository)
+		setupMocks     func(*mocks.MockTaskRepository, *mocks.MockProjectRepository)
 		expectedStatus int
 	}{
 		{
@@ -41,20 +43,54 @@ func TestHandler_CreateTask(t *testing.T) {
 				"title":     "Test Task",
 				"projectId": projectId.String(),
 			},
-			setupMocks:     func(mockTaskRepo *mocks.MockTaskRepository) {},
+			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{ID: projectId, TeamID: teamId, ManagerID: userId}, nil)
+			},
 			expectedStatus: fiber.StatusForbidden,
 		},
 		{
-			name:     "Unauthorized - admin user",
+			name:     "Successfully create task as an admin",
 			userRole: "Admin",
 			userId:   userId.String(),
 			requestBody: map[string]interface{}{
 				"title":     "Test Task",
 				"projectId": projectId.String(),
 			},
-			setupMocks:     func(mockTaskRepo *mocks.MockTaskRepository) {},
+			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{ID: projectId, TeamID: teamId, ManagerID: uuid.New()}, nil)
+				mockTaskRepo.On("CreateTask", mock.MatchedBy(func(c context.Context) bool {
+					adminId, acting := tenant.ActingAdminID(c)
+					return acting && adminId == userId
+				}), mock.AnythingOfType("database.CreateTasksParams")).Return(models.Task{ID: uuid.New(), ProjectID: projectId, Title: "Test Task"}, nil)
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name:     "Unauthorized - not project manager",
+			userRole: "Manager",
+			userId:   userId.String(),
+			requestBody: map[string]interface{}{
+				"title":     "Test Task",
+				"projectId": projectId.String(),
+			},
+			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{ID: projectId, TeamID: teamId, ManagerID: uuid.New()}, nil)
+			},
 			expectedStatus: fiber.StatusForbidden,
 		},
+		{
+			name:     "Project not found",
+			userRole: "Manager",
+			userId:   userId.String(),
+			requestBody: map[string]interface{}{
+				"title":     "Test Task",
+				"projectId": projectId.String(),
+			},
+			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{}, sql.ErrNoRows)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
 		{
 			name:     "Missing title field",
 			userRole: "Manager",
@@ -62,7 +98,7 @@ func TestHandler_CreateTask(t *testing.T) {
 			requestBody: map[string]interface{}{
 				"projectId": projectId.String(),
 			},
-			setupMocks:     func(mockTaskRepo *mocks.MockTaskRepository) {},
+			setupMocks:     func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {},
 			expectedStatus: fiber.StatusBadRequest,
 		},
 		{
@@ -72,7 +108,7 @@ func TestHandler_CreateTask(t *testing.T) {
 			requestBody: map[string]interface{}{
 				"title": "Test Task",
 			},
-			setupMocks:     func(mockTaskRepo *mocks.MockTaskRepository) {},
+			setupMocks:     func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {},
 			expectedStatus: fiber.StatusBadRequest,
 		},
 		{
@@ -84,7 +120,8 @@ func TestHandler_CreateTask(t *testing.T) {
 				"description": "Task description",
 				"projectId":   projectId.String(),
 			},
-			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository) {
+			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{ID: projectId, TeamID: teamId, ManagerID: userId}, nil)
 				mockTaskRepo.On("CreateTask", mock.Anything, mock.AnythingOfType("database.CreateTasksParams")).Return(models.Task{
 					ID:        taskId,
 					Title:     "New Task",
@@ -110,7 +147,9 @@ func TestHandler_CreateTask(t *testing.T) {
 				"projectId":   projectId.String(),
 				"userId":      userId.String(),
 			},
-			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository) {
+			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{ID: projectId, TeamID: teamId, ManagerID: userId}, nil)
+				mockProjectRepo.On("GetProjectMember", mock.Anything, projectId, userId).Return(true, models.ProjectMember{ProjectID: projectId, UserID: userId, Role: models.ProjectmemberrolesOwner}, nil)
 				mockTaskRepo.On("CreateTask", mock.Anything, mock.AnythingOfType("database.CreateTasksParams")).Return(models.Task{
 					ID:        taskId,
 					Title:     "Assigned Task",
@@ -130,6 +169,51 @@ func TestHandler_CreateTask(t *testing.T) {
 			},
 			expectedStatus: fiber.StatusCreated,
 		},
+		{
+			name:     "Assigned to a viewer of the project",
+			userRole: "Manager",
+			userId:   userId.String(),
+			requestBody: map[string]interface{}{
+				"title":     "Assigned Task",
+				"projectId": projectId.String(),
+				"userId":    userId.String(),
+			},
+			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{ID: projectId, TeamID: teamId, ManagerID: userId}, nil)
+				mockProjectRepo.On("GetProjectMember", mock.Anything, projectId, userId).Return(true, models.ProjectMember{ProjectID: projectId, UserID: userId, Role: models.ProjectmemberrolesViewer}, nil)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:     "Assigned to a user outside of the project",
+			userRole: "Manager",
+			userId:   userId.String(),
+			requestBody: map[string]interface{}{
+				"title":     "Assigned Task",
+				"projectId": projectId.String(),
+				"userId":    userId.String(),
+			},
+			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{ID: projectId, TeamID: teamId, ManagerID: userId}, nil)
+				mockProjectRepo.On("GetProjectMember", mock.Anything, projectId, userId).Return(false, models.ProjectMember{}, nil)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:     "Co-owner creates a task",
+			userRole: "Manager",
+			userId:   userId.String(),
+			requestBody: map[string]interface{}{
+				"title":     "New Task",
+				"projectId": projectId.String(),
+			},
+			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{ID: projectId, TeamID: teamId, ManagerID: uuid.New()}, nil)
+				mockProjectRepo.On("GetProjectMembers", mock.Anything, projectId).Return([]models.ProjectMember{{ProjectID: projectId, UserID: userId, Role: models.ProjectmemberrolesOwner}}, nil)
+				mockTaskRepo.On("CreateTask", mock.Anything, mock.AnythingOfType("database.CreateTasksParams")).Return(models.Task{ID: taskId, Title: "New Task", ProjectID: projectId}, nil)
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
 	}
 
 	for _, tt := range tests {
@@ -137,18 +221,21 @@ func TestHandler_CreateTask(t *testing.T) {
 			app := setupTestApp()
 
 			mockUserRepo := mocks.NewMockUserRepository()
-			mockRefreshTokenRepo := mocks.NewMockRefreshTokenRepository()
-			mockTeamRepo := mocks.NewMockTeamRepository()
 			mockProjectRepo := mocks.NewMockProjectRepository()
 			mockTaskRepo := mocks.NewMockTaskRepository()
 
-			tt.setupMocks(mockTaskRepo)
+			// Tasks are assigned to a user of the team
+			mockUserRepo.On("GetUserById", mock.Anything, userId).Return(models.User{ID: userId, Role: models.UserrolesMember, TeamId: teamId}, nil).Maybe()
+
+			tt.setupMocks(mockTaskRepo, mockProjectRepo)
+			mockProjectRepo.On("GetProjectMembers", mock.Anything, projectId).Return([]models.ProjectMember{}, nil).Maybe()
 
-			handler := handlers.NewHandler(mockUserRepo, mockRefreshTokenRepo, mockTeamRepo, mockProjectRepo, mockTaskRepo)
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, projectRepo: mockProjectRepo, taskRepo: mockTaskRepo})
 
 			app.Post("/tasks", func(c *fiber.Ctx) error {
 				c.Locals("userRole", tt.userRole)
 				c.Locals("userId", tt.userId)
+				tenant.SetTeam(c, teamId)
 				return handler.CreateTask(c)
 			})
 
@@ -161,12 +248,14 @@ func TestHandler_CreateTask(t *testing.T) {
 			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
 
 			mockTaskRepo.AssertExpectations(t)
+			mockProjectRepo.AssertExpectations(t)
 		})
 	}
 }
 
 func TestHandler_GetTasks(t *testing.T) {
 	userId := uuid.New()
+	teamId := uuid.New()
 	projectId := uuid.New()
 	taskId := uuid.New()
 	now := time.Now()
@@ -176,6 +265,7 @@ func TestHandler_GetTasks(t *testing.T) {
 		userRole       string
 		userId         string
 		queryParams    string
+		members        []models.ProjectMember
 		setupMocks     func(*mocks.MockTaskRepository)
 		expectedStatus int
 	}{
@@ -196,13 +286,13 @@ func TestHandler_GetTasks(t *testing.T) {
 			expectedStatus: fiber.StatusBadRequest,
 		},
 		{
-			name:        "Member without projectId - returns own tasks",
+			name:        "Member without projectId - returns the tasks of their projects",
 			userRole:    "Member",
 			userId:      userId.String(),
 			queryParams: "?limit=10",
 			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository) {
 				mockTaskRepo.On("GetTasks", mock.Anything, mock.MatchedBy(func(filters interfaces.GetTasksFilters) bool {
-					return filters.UserId == userId && filters.Limit == 10
+					return filters.MemberId == userId && filters.UserId == uuid.Nil && filters.Limit == 10
 				})).Return([]interfaces.GetTasksResponse{
 					{
 						ID:        taskId,
@@ -220,6 +310,31 @@ func TestHandler_GetTasks(t *testing.T) {
 			},
 			expectedStatus: fiber.StatusOK,
 		},
+		{
+			name:        "Member of the project - returns all its tasks",
+			userRole:    "Member",
+			userId:      userId.String(),
+			queryParams: "?limit=10&projectId=" + projectId.String(),
+			members:     []models.ProjectMember{{ProjectID: projectId, UserID: userId, Role: models.ProjectmemberrolesViewer}},
+			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository) {
+				mockTaskRepo.On("GetTasks", mock.Anything, mock.MatchedBy(func(filters interfaces.GetTasksFilters) bool {
+					return filters.ProjectId == projectId && filters.UserId == uuid.Nil && filters.MemberId == uuid.Nil
+				})).Return([]interfaces.GetTasksResponse{}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:        "Not a member of the project - returns own tasks",
+			userRole:    "Member",
+			userId:      userId.String(),
+			queryParams: "?limit=10&projectId=" + projectId.String(),
+			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository) {
+				mockTaskRepo.On("GetTasks", mock.Anything, mock.MatchedBy(func(filters interfaces.GetTasksFilters) bool {
+					return filters.ProjectId == projectId && filters.UserId == userId && filters.MemberId == uuid.Nil
+				})).Return([]interfaces.GetTasksResponse{}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
 		{
 			name:        "Successfully get tasks by projectId as Admin",
 			userRole:    "Admin",
@@ -278,19 +393,21 @@ func TestHandler_GetTasks(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			app := setupTestApp()
 
-			mockUserRepo := mocks.NewMockUserRepository()
-			mockRefreshTokenRepo := mocks.NewMockRefreshTokenRepository()
-			mockTeamRepo := mocks.NewMockTeamRepository()
 			mockProjectRepo := mocks.NewMockProjectRepository()
 			mockTaskRepo := mocks.NewMockTaskRepository()
 
+			mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{ID: projectId, TeamID: teamId, ManagerID: userId}, nil).Maybe()
+
+			mockProjectRepo.On("GetProjectMembers", mock.Anything, projectId).Return(tt.members, nil).Maybe()
+
 			tt.setupMocks(mockTaskRepo)
 
-			handler := handlers.NewHandler(mockUserRepo, mockRefreshTokenRepo, mockTeamRepo, mockProjectRepo, mockTaskRepo)
+			handler := newTestHandler(handlerDeps{projectRepo: mockProjectRepo, taskRepo: mockTaskRepo})
 
 			app.Get("/tasks", func(c *fiber.Ctx) error {
 				c.Locals("userRole", tt.userRole)
 				c.Locals("userId", tt.userId)
+				tenant.SetTeam(c, teamId)
 				return handler.GetTasks(c)
 			})
 
@@ -307,6 +424,7 @@ func TestHandler_GetTasks(t *testing.T) {
 
 func TestHandler_UpdateTask(t *testing.T) {
 	userId := uuid.New()
+	teamId := uuid.New()
 	projectId := uuid.New()
 	taskId := uuid.New()
 	now := time.Now()
@@ -317,7 +435,7 @@ func TestHandler_UpdateTask(t *testing.T) {
 		userId         string
 		taskId         string
 		requestBody    map[string]interface{}
-		setupMocks     func(*mocks.MockTaskRepository)
+		setupMocks     func(*mocks.MockTaskRepository, *mocks.MockProjectRepository)
 		expectedStatus int
 	}{
 		{
@@ -329,7 +447,10 @@ func TestHandler_UpdateTask(t *testing.T) {
 				"title":  "Updated Task",
 				"status": "InProgress",
 			},
-			setupMocks:     func(mockTaskRepo *mocks.MockTaskRepository) {},
+			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {
+				mockTaskRepo.On("GetTaskById", mock.Anything, taskId).Return(models.Task{ID: taskId, ProjectID: projectId}, nil)
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{ID: projectId, TeamID: teamId, ManagerID: userId}, nil)
+			},
 			expectedStatus: fiber.StatusForbidden,
 		},
 		{
@@ -341,7 +462,7 @@ func TestHandler_UpdateTask(t *testing.T) {
 				"title":  "Updated Task",
 				"status": "InProgress",
 			},
-			setupMocks:     func(mockTaskRepo *mocks.MockTaskRepository) {},
+			setupMocks:     func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {},
 			expectedStatus: fiber.StatusNotFound,
 		},
 		{
@@ -353,18 +474,33 @@ func TestHandler_UpdateTask(t *testing.T) {
 				"title":  "Updated Task",
 				"status": "InProgress",
 			},
-			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository) {
+			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {
 				mockTaskRepo.On("GetTaskById", mock.Anything, taskId).Return(models.Task{}, sql.ErrNoRows)
 			},
 			expectedStatus: fiber.StatusNotFound,
 		},
+		{
+			name:     "Unauthorized - not project manager",
+			userRole: "Manager",
+			userId:   userId.String(),
+			taskId:   taskId.String(),
+			requestBody: map[string]interface{}{
+				"title":  "Updated Task",
+				"status": "InProgress",
+			},
+			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {
+				mockTaskRepo.On("GetTaskById", mock.Anything, taskId).Return(models.Task{ID: taskId, ProjectID: projectId}, nil)
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{ID: projectId, TeamID: teamId, ManagerID: uuid.New()}, nil)
+			},
+			expectedStatus: fiber.StatusForbidden,
+		},
 		{
 			name:        "Missing required fields",
 			userRole:    "Manager",
 			userId:      userId.String(),
 			taskId:      taskId.String(),
 			requestBody: map[string]interface{}{},
-			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository) {
+			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {
 				mockTaskRepo.On("GetTaskById", mock.Anything, taskId).Return(models.Task{
 					ID:        taskId,
 					Title:     "Test Task",
@@ -373,6 +509,7 @@ func TestHandler_UpdateTask(t *testing.T) {
 					CreatedAt: now,
 					UpdatedAt: now,
 				}, nil)
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{ID: projectId, TeamID: teamId, ManagerID: userId}, nil)
 			},
 			expectedStatus: fiber.StatusBadRequest,
 		},
@@ -386,7 +523,7 @@ func TestHandler_UpdateTask(t *testing.T) {
 				"description": "New description",
 				"status":      "InProgress",
 			},
-			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository) {
+			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {
 				mockTaskRepo.On("GetTaskById", mock.Anything, taskId).Return(models.Task{
 					ID:        taskId,
 					Title:     "Test Task",
@@ -395,6 +532,7 @@ func TestHandler_UpdateTask(t *testing.T) {
 					CreatedAt: now,
 					UpdatedAt: now,
 				}, nil)
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{ID: projectId, TeamID: teamId, ManagerID: userId}, nil)
 				mockTaskRepo.On("UpdateTask", mock.Anything, mock.MatchedBy(func(data interfaces.UpdateTaskData) bool {
 					return data.ID == taskId && data.Title == "Updated Task" && data.Status == "InProgress"
 				})).Return(models.Task{
@@ -412,6 +550,87 @@ func TestHandler_UpdateTask(t *testing.T) {
 			},
 			expectedStatus: fiber.StatusOK,
 		},
+		{
+			name:     "Assignee updates the status and adds to the description",
+			userRole: "Member",
+			userId:   userId.String(),
+			taskId:   taskId.String(),
+			requestBody: map[string]interface{}{
+				"title":       "Test Task",
+				"description": "Description\nBlocked by the api",
+				"status":      "InProgress",
+				"userId":      userId.String(),
+			},
+			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {
+				mockTaskRepo.On("GetTaskById", mock.Anything, taskId).Return(models.Task{
+					ID:          taskId,
+					Title:       "Test Task",
+					Description: sql.NullString{String: "Description", Valid: true},
+					ProjectID:   projectId,
+					UserID:      uuid.NullUUID{UUID: userId, Valid: true},
+					Status:      models.TaskstatusToDo,
+				}, nil)
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{ID: projectId, TeamID: teamId, ManagerID: uuid.New()}, nil)
+				mockTaskRepo.On("UpdateTask", mock.Anything, mock.MatchedBy(func(data interfaces.UpdateTaskData) bool {
+					return data.Status == models.TaskstatusInProgress && data.Description.String == "Description\nBlocked by the api" && data.UserId.UUID == userId
+				})).Return(models.Task{ID: taskId, Title: "Test Task", ProjectID: projectId, Status: models.TaskstatusInProgress}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:     "Assignee changes the title",
+			userRole: "Member",
+			userId:   userId.String(),
+			taskId:   taskId.String(),
+			requestBody: map[string]interface{}{
+				"title":  "Renamed Task",
+				"status": "Done",
+				"userId": userId.String(),
+			},
+			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {
+				mockTaskRepo.On("GetTaskById", mock.Anything, taskId).Return(models.Task{ID: taskId, Title: "Test Task", ProjectID: projectId, UserID: uuid.NullUUID{UUID: userId, Valid: true}}, nil)
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{ID: projectId, TeamID: teamId, ManagerID: uuid.New()}, nil)
+			},
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:     "Assignee unassigns the task",
+			userRole: "Member",
+			userId:   userId.String(),
+			taskId:   taskId.String(),
+			requestBody: map[string]interface{}{
+				"title":  "Test Task",
+				"status": "Done",
+			},
+			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {
+				mockTaskRepo.On("GetTaskById", mock.Anything, taskId).Return(models.Task{ID: taskId, Title: "Test Task", ProjectID: projectId, UserID: uuid.NullUUID{UUID: userId, Valid: true}}, nil)
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{ID: projectId, TeamID: teamId, ManagerID: uuid.New()}, nil)
+			},
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:     "Assignee replaces the description",
+			userRole: "Member",
+			userId:   userId.String(),
+			taskId:   taskId.String(),
+			requestBody: map[string]interface{}{
+				"title":       "Test Task",
+				"description": "Something else",
+				"status":      "Done",
+				"userId":      userId.String(),
+			},
+			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {
+				mockTaskRepo.On("GetTaskById", mock.Anything, taskId).Return(models.Task{
+					ID:          taskId,
+					Title:       "Test Task",
+					Description: sql.NullString{String: "Description", Valid: true},
+					ProjectID:   projectId,
+					UserID:      uuid.NullUUID{UUID: userId, Valid: true},
+				}, nil)
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{ID: projectId, TeamID: teamId, ManagerID: uuid.New()}, nil)
+			},
+			expectedStatus: fiber.StatusForbidden,
+		},
 		{
 			name:     "Successfully update task with userId",
 			userRole: "Manager",
@@ -422,7 +641,7 @@ func TestHandler_UpdateTask(t *testing.T) {
 				"status": "Done",
 				"userId": userId.String(),
 			},
-			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository) {
+			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {
 				mockTaskRepo.On("GetTaskById", mock.Anything, taskId).Return(models.Task{
 					ID:        taskId,
 					Title:     "Test Task",
@@ -431,6 +650,8 @@ func TestHandler_UpdateTask(t *testing.T) {
 					CreatedAt: now,
 					UpdatedAt: now,
 				}, nil)
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{ID: projectId, TeamID: teamId, ManagerID: userId}, nil)
+				mockProjectRepo.On("GetProjectMember", mock.Anything, projectId, userId).Return(true, models.ProjectMember{ProjectID: projectId, UserID: userId, Role: models.ProjectmemberrolesContributor}, nil)
 				mockTaskRepo.On("UpdateTask", mock.Anything, mock.AnythingOfType("interfaces.UpdateTaskData")).Return(models.Task{
 					ID:        taskId,
 					Title:     "Updated Task",
@@ -453,18 +674,21 @@ func TestHandler_UpdateTask(t *testing.T) {
 			app := setupTestApp()
 
 			mockUserRepo := mocks.NewMockUserRepository()
-			mockRefreshTokenRepo := mocks.NewMockRefreshTokenRepository()
-			mockTeamRepo := mocks.NewMockTeamRepository()
 			mockProjectRepo := mocks.NewMockProjectRepository()
 			mockTaskRepo := mocks.NewMockTaskRepository()
 
-			tt.setupMocks(mockTaskRepo)
+			// Tasks are assigned to a user of the team
+			mockUserRepo.On("GetUserById", mock.Anything, userId).Return(models.User{ID: userId, Role: models.UserrolesMember, TeamId: teamId}, nil).Maybe()
+
+			tt.setupMocks(mockTaskRepo, mockProjectRepo)
+			mockProjectRepo.On("GetProjectMembers", mock.Anything, projectId).Return([]models.ProjectMember{}, nil).Maybe()
 
-			handler := handlers.NewHandler(mockUserRepo, mockRefreshTokenRepo, mockTeamRepo, mockProjectRepo, mockTaskRepo)
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, projectRepo: mockProjectRepo, taskRepo: mockTaskRepo})
 
 			app.Put("/tasks/:id", func(c *fiber.Ctx) error {
 				c.Locals("userRole", tt.userRole)
 				c.Locals("userId", tt.userId)
+				tenant.SetTeam(c, teamId)
 				return handler.UpdateTask(c)
 			})
 
@@ -478,12 +702,15 @@ func TestHandler_UpdateTask(t *testing.T) {
 			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
 
 			mockTaskRepo.AssertExpectations(t)
+			mockProjectRepo.AssertExpectations(t)
 		})
 	}
 }
 
 func TestHandler_DeleteTask(t *testing.T) {
 	userId := uuid.New()
+	teamId := uuid.New()
+	projectId := uuid.New()
 	taskId := uuid.New()
 
 	tests := []struct {
@@ -491,31 +718,59 @@ func TestHandler_DeleteTask(t *testing.T) {
 		userRole       string
 		userId         string
 		taskId         string
-		setupMocks     func(*mocks.MockTaskRepository)
+		setupMocks     func(*mocks.MockTaskRepository, *mocks.MockProjectRepository)
 		expectedStatus int
 	}{
 		{
-			name:           "Unauthorized - non-manager user",
-			userRole:       "Member",
-			userId:         userId.String(),
-			taskId:         taskId.String(),
-			setupMocks:     func(mockTaskRepo *mocks.MockTaskRepository) {},
+			name:     "Unauthorized - non-manager user",
+			userRole: "Member",
+			userId:   userId.String(),
+			taskId:   taskId.String(),
+			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {
+				mockTaskRepo.On("GetTaskById", mock.Anything, taskId).Return(models.Task{ID: taskId, ProjectID: projectId}, nil)
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{ID: projectId, TeamID: teamId, ManagerID: userId}, nil)
+			},
 			expectedStatus: fiber.StatusForbidden,
 		},
 		{
-			name:           "Unauthorized - admin user",
-			userRole:       "Admin",
-			userId:         userId.String(),
-			taskId:         taskId.String(),
-			setupMocks:     func(mockTaskRepo *mocks.MockTaskRepository) {},
-			expectedStatus: fiber.StatusForbidden,
+			name:     "Successfully delete task as an admin",
+			userRole: "Admin",
+			userId:   userId.String(),
+			taskId:   taskId.String(),
+			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {
+				mockTaskRepo.On("GetTaskById", mock.Anything, taskId).Return(models.Task{ID: taskId, ProjectID: projectId}, nil)
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{ID: projectId, TeamID: teamId, ManagerID: uuid.New()}, nil)
+				mockTaskRepo.On("DeleteTask", mock.Anything, taskId).Return(nil)
+			},
+			expectedStatus: fiber.StatusOK,
 		},
 		{
 			name:           "Invalid task ID - empty",
 			userRole:       "Manager",
 			userId:         userId.String(),
 			taskId:         "",
-			setupMocks:     func(mockTaskRepo *mocks.MockTaskRepository) {},
+			setupMocks:     func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:     "Unauthorized - not project manager",
+			userRole: "Manager",
+			userId:   userId.String(),
+			taskId:   taskId.String(),
+			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {
+				mockTaskRepo.On("GetTaskById", mock.Anything, taskId).Return(models.Task{ID: taskId, ProjectID: projectId}, nil)
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{ID: projectId, TeamID: teamId, ManagerID: uuid.New()}, nil)
+			},
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:     "Task not found",
+			userRole: "Manager",
+			userId:   userId.String(),
+			taskId:   taskId.String(),
+			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {
+				mockTaskRepo.On("GetTaskById", mock.Anything, taskId).Return(models.Task{}, sql.ErrNoRows)
+			},
 			expectedStatus: fiber.StatusNotFound,
 		},
 		{
@@ -523,7 +778,9 @@ func TestHandler_DeleteTask(t *testing.T) {
 			userRole: "Manager",
 			userId:   userId.String(),
 			taskId:   taskId.String(),
-			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository) {
+			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {
+				mockTaskRepo.On("GetTaskById", mock.Anything, taskId).Return(models.Task{ID: taskId, ProjectID: projectId}, nil)
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{ID: projectId, TeamID: teamId, ManagerID: userId}, nil)
 				mockTaskRepo.On("DeleteTask", mock.Anything, taskId).Return(nil)
 			},
 			expectedStatus: fiber.StatusOK,
@@ -533,7 +790,9 @@ func TestHandler_DeleteTask(t *testing.T) {
 			userRole: "Manager",
 			userId:   userId.String(),
 			taskId:   taskId.String(),
-			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository) {
+			setupMocks: func(mockTaskRepo *mocks.MockTaskRepository, mockProjectRepo *mocks.MockProjectRepository) {
+				mockTaskRepo.On("GetTaskById", mock.Anything, taskId).Return(models.Task{ID: taskId, ProjectID: projectId}, nil)
+				mockProjectRepo.On("GetProjectById", mock.Anything, projectId).Return(models.Project{ID: projectId, TeamID: teamId, ManagerID: userId}, nil)
 				mockTaskRepo.On("DeleteTask", mock.Anything, taskId).Return(sql.ErrConnDone)
 			},
 			expectedStatus: fiber.StatusInternalServerError,
@@ -545,18 +804,21 @@ func TestHandler_DeleteTask(t *testing.T) {
 			app := setupTestApp()
 
 			mockUserRepo := mocks.NewMockUserRepository()
-			mockRefreshTokenRepo := mocks.NewMockRefreshTokenRepository()
-			mockTeamRepo := mocks.NewMockTeamRepository()
 			mockProjectRepo := mocks.NewMockProjectRepository()
 			mockTaskRepo := mocks.NewMockTaskRepository()
 
-			tt.setupMocks(mockTaskRepo)
+			// Tasks are assigned to a user of the team
+			mockUserRepo.On("GetUserById", mock.Anything, userId).Return(models.User{ID: userId, Role: models.UserrolesMember, TeamId: teamId}, nil).Maybe()
+
+			tt.setupMocks(mockTaskRepo, mockProjectRepo)
+			mockProjectRepo.On("GetProjectMembers", mock.Anything, projectId).Return([]models.ProjectMember{}, nil).Maybe()
 
-			handler := handlers.NewHandler(mockUserRepo, mockRefreshTokenRepo, mockTeamRepo, mockProjectRepo, mockTaskRepo)
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, projectRepo: mockProjectRepo, taskRepo: mockTaskRepo})
 
 			app.Delete("/tasks/:id", func(c *fiber.Ctx) error {
 				c.Locals("userRole", tt.userRole)
 				c.Locals("userId", tt.userId)
+				tenant.SetTeam(c, teamId)
 				return handler.DeleteTask(c)
 			})
 
@@ -568,6 +830,7 @@ func TestHandler_DeleteTask(t *testing.T) {
 			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
 
 			mockTaskRepo.AssertExpectations(t)
+			mockProjectRepo.AssertExpectations(t)
 		})
 	}
 }
@@ -0,0 +1,272 @@
+package handlers_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/test/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_GetTeamMembers(t *testing.T) {
+	ownerId := uuid.New()
+	teamId := uuid.New()
+	otherTeamId := uuid.New()
+
+	team := models.Team{ID: teamId, Name: "Test Team", OwnerID: ownerId}
+
+	tests := []struct {
+		name           string
+		teamId         string
+		setupMocks     func(*mocks.MockTeamRepository)
+		expectedStatus int
+		expectedCount  int
+	}{
+		{
+			name:   "Successfully list the members",
+			teamId: teamId.String(),
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository) {
+				mockTeamRepo.On("GetTeamMembers", mock.Anything, teamId).Return([]models.TeamMember{
+					{TeamID: teamId, UserID: ownerId, Role: models.UserrolesAdmin},
+					{TeamID: teamId, UserID: uuid.New(), Role: models.UserrolesMember},
+				}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+			expectedCount:  2,
+		},
+		{
+			name:   "Team of another admin",
+			teamId: otherTeamId.String(),
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository) {
+				mockTeamRepo.On("GetTeamById", mock.Anything, otherTeamId).Return(models.Team{ID: otherTeamId, OwnerID: uuid.New()}, nil)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockTeamRepo := mocks.NewMockTeamRepository()
+			tt.setupMocks(mockTeamRepo)
+			mockTeamRepo.On("GetTeamById", mock.Anything, teamId).Return(team, nil).Maybe()
+			mockTeamRepo.On("GetTeamByOwner", mock.Anything, ownerId).Return(true, team, nil).Maybe()
+
+			handler := newTestHandler(handlerDeps{teamRepo: mockTeamRepo})
+
+			app.Get("/teams/:id/members", func(c *fiber.Ctx) error {
+				c.Locals("userRole", "Admin")
+				c.Locals("userId", ownerId.String())
+				return handler.GetTeamMembers(c)
+			})
+
+			resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/teams/"+tt.teamId+"/members", nil))
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.expectedStatus == fiber.StatusOK {
+				var result interfaces.TeamMembersListResponse
+				json.NewDecoder(resp.Body).Decode(&result)
+				assert.Len(t, result.Data, tt.expectedCount)
+			}
+
+			mockTeamRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_AddTeamMember(t *testing.T) {
+	ownerId := uuid.New()
+	teamId := uuid.New()
+	userId := uuid.New()
+	customRoleId := uuid.New()
+
+	team := models.Team{ID: teamId, Name: "Test Team", OwnerID: ownerId}
+	contractor := models.User{ID: userId, Username: "contractor", Email: "contractor@example.com", Role: models.UserrolesManager, TeamId: uuid.New()}
+
+	tests := []struct {
+		name           string
+		requestBody    map[string]interface{}
+		setupMocks     func(*mocks.MockUserRepository, *mocks.MockTeamRepository, *mocks.MockRoleRepository)
+		expectedStatus int
+	}{
+		{
+			name:        "Admins can't be added",
+			requestBody: map[string]interface{}{"email": contractor.Email, "role": "Admin"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockRoleRepo *mocks.MockRoleRepository) {
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "User not found",
+			requestBody: map[string]interface{}{"email": contractor.Email, "role": "Member"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockRoleRepo *mocks.MockRoleRepository) {
+				mockUserRepo.On("GetUserByEmail", mock.Anything, contractor.Email).Return(models.User{}, sql.ErrNoRows)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:        "User is already a member",
+			requestBody: map[string]interface{}{"email": contractor.Email, "role": "Member"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockRoleRepo *mocks.MockRoleRepository) {
+				mockUserRepo.On("GetUserByEmail", mock.Anything, contractor.Email).Return(contractor, nil)
+				mockTeamRepo.On("GetTeamMember", mock.Anything, teamId, userId).Return(true, models.TeamMember{TeamID: teamId, UserID: userId}, nil)
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name:        "Custom role of another team",
+			requestBody: map[string]interface{}{"email": contractor.Email, "role": "Member", "roleId": customRoleId.String()},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockRoleRepo *mocks.MockRoleRepository) {
+				mockUserRepo.On("GetUserByEmail", mock.Anything, contractor.Email).Return(contractor, nil)
+				mockTeamRepo.On("GetTeamMember", mock.Anything, teamId, userId).Return(false, models.TeamMember{}, nil)
+				mockRoleRepo.On("GetRoleById", mock.Anything, customRoleId).Return(models.Role{ID: customRoleId, TeamID: uuid.NullUUID{UUID: uuid.New(), Valid: true}}, nil)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:        "Successfully add a member with a custom role",
+			requestBody: map[string]interface{}{"email": contractor.Email, "role": "Member", "roleId": customRoleId.String()},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockRoleRepo *mocks.MockRoleRepository) {
+				mockUserRepo.On("GetUserByEmail", mock.Anything, contractor.Email).Return(contractor, nil)
+				mockTeamRepo.On("GetTeamMember", mock.Anything, teamId, userId).Return(false, models.TeamMember{}, nil)
+				mockRoleRepo.On("GetRoleById", mock.Anything, customRoleId).Return(models.Role{ID: customRoleId, TeamID: uuid.NullUUID{UUID: teamId, Valid: true}}, nil)
+				mockTeamRepo.On("AddTeamMember", mock.Anything, mock.MatchedBy(func(member models.TeamMember) bool {
+					return member.TeamID == teamId && member.UserID == userId && member.Role == models.UserrolesMember && member.RoleId == customRoleId
+				})).Return(models.TeamMember{TeamID: teamId, UserID: userId, Role: models.UserrolesMember, RoleId: customRoleId}, nil)
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name:        "Successfully add a manager",
+			requestBody: map[string]interface{}{"email": contractor.Email, "role": "Manager"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository, mockRoleRepo *mocks.MockRoleRepository) {
+				mockUserRepo.On("GetUserByEmail", mock.Anything, contractor.Email).Return(contractor, nil)
+				mockTeamRepo.On("GetTeamMember", mock.Anything, teamId, userId).Return(false, models.TeamMember{}, nil)
+				mockTeamRepo.On("AddTeamMember", mock.Anything, mock.MatchedBy(func(member models.TeamMember) bool {
+					return member.Role == models.UserrolesManager && member.RoleId == models.BuiltInRoleIds[models.UserrolesManager]
+				})).Return(models.TeamMember{TeamID: teamId, UserID: userId, Role: models.UserrolesManager, RoleId: models.BuiltInRoleIds[models.UserrolesManager]}, nil)
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockUserRepo := mocks.NewMockUserRepository()
+			mockTeamRepo := mocks.NewMockTeamRepository()
+			mockRoleRepo := mocks.NewMockRoleRepository()
+			tt.setupMocks(mockUserRepo, mockTeamRepo, mockRoleRepo)
+			mockTeamRepo.On("GetTeamById", mock.Anything, teamId).Return(team, nil)
+			mockTeamRepo.On("GetTeamByOwner", mock.Anything, ownerId).Return(true, team, nil).Maybe()
+
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, teamRepo: mockTeamRepo, roleRepo: mockRoleRepo})
+
+			app.Post("/teams/:id/members", func(c *fiber.Ctx) error {
+				c.Locals("userRole", "Admin")
+				c.Locals("userId", ownerId.String())
+				return handler.AddTeamMember(c)
+			})
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/teams/"+teamId.String()+"/members", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockUserRepo.AssertExpectations(t)
+			mockTeamRepo.AssertExpectations(t)
+			mockRoleRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_RemoveTeamMember(t *testing.T) {
+	ownerId := uuid.New()
+	teamId := uuid.New()
+	userId := uuid.New()
+
+	team := models.Team{ID: teamId, Name: "Test Team", OwnerID: ownerId}
+
+	tests := []struct {
+		name           string
+		userId         string
+		setupMocks     func(*mocks.MockTeamRepository)
+		expectedStatus int
+	}{
+		{
+			name:           "The owner can't leave the team",
+			userId:         ownerId.String(),
+			setupMocks:     func(mockTeamRepo *mocks.MockTeamRepository) {},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:   "Member not found",
+			userId: userId.String(),
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository) {
+				mockTeamRepo.On("GetTeamMember", mock.Anything, teamId, userId).Return(false, models.TeamMember{}, nil)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:   "Only owner of projects of the team",
+			userId: userId.String(),
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository) {
+				mockTeamRepo.On("GetTeamMember", mock.Anything, teamId, userId).Return(true, models.TeamMember{TeamID: teamId, UserID: userId, Role: models.UserrolesManager}, nil)
+				mockTeamRepo.On("CountProjectsOnlyOwnedBy", mock.Anything, teamId, userId).Return(int64(2), nil)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:   "Successfully remove a member",
+			userId: userId.String(),
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository) {
+				mockTeamRepo.On("GetTeamMember", mock.Anything, teamId, userId).Return(true, models.TeamMember{TeamID: teamId, UserID: userId, Role: models.UserrolesMember}, nil)
+				mockTeamRepo.On("CountProjectsOnlyOwnedBy", mock.Anything, teamId, userId).Return(int64(0), nil)
+				mockTeamRepo.On("RemoveTeamMember", mock.Anything, mock.MatchedBy(func(data interfaces.RemoveTeamMemberData) bool {
+					return data.TeamID == teamId && data.UserID == userId
+				})).Return(nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockTeamRepo := mocks.NewMockTeamRepository()
+			tt.setupMocks(mockTeamRepo)
+			mockTeamRepo.On("GetTeamById", mock.Anything, teamId).Return(team, nil)
+			mockTeamRepo.On("GetTeamByOwner", mock.Anything, ownerId).Return(true, team, nil).Maybe()
+
+			handler := newTestHandler(handlerDeps{teamRepo: mockTeamRepo})
+
+			app.Delete("/teams/:id/members/:userId", func(c *fiber.Ctx) error {
+				c.Locals("userRole", "Admin")
+				c.Locals("userId", ownerId.String())
+				return handler.RemoveTeamMember(c)
+			})
+
+			resp, _ := app.Test(httptest.NewRequest(http.MethodDelete, "/teams/"+teamId.String()+"/members/"+tt.userId, nil))
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockTeamRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -9,7 +9,7 @@ import (
 	"testing"
 	"time"
 
-	"github.com/TobiasRV/challenge-fs-senior/internals/handlers"
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
 	"github.com/TobiasRV/challenge-fs-senior/internals/models"
 	"github.com/TobiasRV/challenge-fs-senior/test/mocks"
 	"github.com/gofiber/fiber/v2"
@@ -64,11 +64,11 @@ func TestHandler_CreateTeam(t *testing.T) {
 			expectedStatus: fiber.StatusNotFound,
 		},
 		{
-			name:     "User already has a team",
+			name:     "Admin that owns a team creates another one",
 			userRole: "Admin",
 			userId:   userId.String(),
 			requestBody: map[string]interface{}{
-				"name": "Test Team",
+				"name": "Second Team",
 			},
 			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository) {
 				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(models.User{
@@ -76,14 +76,19 @@ func TestHandler_CreateTeam(t *testing.T) {
 					Username: "admin",
 					Email:    "admin@example.com",
 					Role:     models.UserrolesAdmin,
+					TeamId:   uuid.New(),
 				}, nil)
-				mockTeamRepo.On("GetTeamByOwner", mock.Anything, userId).Return(true, models.Team{
-					ID:      teamId,
-					Name:    "Existing Team",
-					OwnerID: userId,
+				mockTeamRepo.On("CreateTeam", mock.Anything, mock.MatchedBy(func(team models.Team) bool {
+					return team.Name == "Second Team" && team.OwnerID == userId
+				})).Return(models.Team{
+					ID:        teamId,
+					Name:      "Second Team",
+					OwnerID:   userId,
+					CreatedAt: now,
+					UpdatedAt: now,
 				}, nil)
 			},
-			expectedStatus: fiber.StatusConflict,
+			expectedStatus: fiber.StatusCreated,
 		},
 		{
 			name:     "Successfully create team",
@@ -99,7 +104,6 @@ func TestHandler_CreateTeam(t *testing.T) {
 					Email:    "admin@example.com",
 					Role:     models.UserrolesAdmin,
 				}, nil)
-				mockTeamRepo.On("GetTeamByOwner", mock.Anything, userId).Return(false, models.Team{}, nil)
 				mockTeamRepo.On("CreateTeam", mock.Anything, mock.AnythingOfType("models.Team")).Return(models.Team{
 					ID:        teamId,
 					Name:      "New Team",
@@ -117,14 +121,11 @@ func TestHandler_CreateTeam(t *testing.T) {
 			app := setupTestApp()
 
 			mockUserRepo := mocks.NewMockUserRepository()
-			mockRefreshTokenRepo := mocks.NewMockRefreshTokenRepository()
 			mockTeamRepo := mocks.NewMockTeamRepository()
-			mockProjectRepo := mocks.NewMockProjectRepository()
-			mockTaskRepo := mocks.NewMockTaskRepository()
 
 			tt.setupMocks(mockUserRepo, mockTeamRepo)
 
-			handler := handlers.NewHandler(mockUserRepo, mockRefreshTokenRepo, mockTeamRepo, mockProjectRepo, mockTaskRepo)
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, teamRepo: mockTeamRepo})
 
 			app.Post("/teams", func(c *fiber.Ctx) error {
 				c.Locals("userRole", tt.userRole)
@@ -196,15 +197,11 @@ func TestHandler_GetTeamByOwner(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			app := setupTestApp()
 
-			mockUserRepo := mocks.NewMockUserRepository()
-			mockRefreshTokenRepo := mocks.NewMockRefreshTokenRepository()
 			mockTeamRepo := mocks.NewMockTeamRepository()
-			mockProjectRepo := mocks.NewMockProjectRepository()
-			mockTaskRepo := mocks.NewMockTaskRepository()
 
 			tt.setupMocks(mockTeamRepo)
 
-			handler := handlers.NewHandler(mockUserRepo, mockRefreshTokenRepo, mockTeamRepo, mockProjectRepo, mockTaskRepo)
+			handler := newTestHandler(handlerDeps{teamRepo: mockTeamRepo})
 
 			app.Get("/teams/by-owner", func(c *fiber.Ctx) error {
 				c.Locals("userRole", tt.userRole)
@@ -222,3 +219,522 @@ func TestHandler_GetTeamByOwner(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_UpdateTeamMfa(t *testing.T) {
+	userId := uuid.New()
+	teamId := uuid.New()
+	organizationId := uuid.New()
+	now := time.Now()
+
+	team := models.Team{
+		ID:             teamId,
+		Name:           "Test Team",
+		OwnerID:        userId,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		OrganizationID: organizationId,
+	}
+
+	tests := []struct {
+		name           string
+		userRole       string
+		requestBody    map[string]interface{}
+		setupMocks     func(*mocks.MockTeamRepository, *mocks.MockOrganizationRepository)
+		expectedStatus int
+	}{
+		{
+			name:        "Unauthorized - non-admin user",
+			userRole:    "Manager",
+			requestBody: map[string]interface{}{"requireMfa": true},
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository, mockOrganizationRepo *mocks.MockOrganizationRepository) {
+			},
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:        "Validation error - missing requireMfa",
+			userRole:    "Admin",
+			requestBody: map[string]interface{}{},
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository, mockOrganizationRepo *mocks.MockOrganizationRepository) {
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "Team not found",
+			userRole:    "Admin",
+			requestBody: map[string]interface{}{"requireMfa": true},
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository, mockOrganizationRepo *mocks.MockOrganizationRepository) {
+				mockTeamRepo.On("GetTeamByOwner", mock.Anything, userId).Return(false, models.Team{}, nil)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:        "Successfully require two-factor authentication",
+			userRole:    "Admin",
+			requestBody: map[string]interface{}{"requireMfa": true},
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository, mockOrganizationRepo *mocks.MockOrganizationRepository) {
+				mockTeamRepo.On("GetTeamByOwner", mock.Anything, userId).Return(true, team, nil)
+				mockTeamRepo.On("SetTeamRequireMfa", mock.Anything, mock.MatchedBy(func(data interfaces.SetTeamRequireMfaData) bool {
+					return data.TeamID == teamId && data.RequireMfa
+				})).Return(models.Team{ID: teamId, RequireMfa: true}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:        "Successfully stop requiring two-factor authentication",
+			userRole:    "Admin",
+			requestBody: map[string]interface{}{"requireMfa": false},
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository, mockOrganizationRepo *mocks.MockOrganizationRepository) {
+				mockTeamRepo.On("GetTeamByOwner", mock.Anything, userId).Return(true, team, nil)
+				mockOrganizationRepo.On("GetOrganizationById", mock.Anything, organizationId).Return(models.Organization{ID: organizationId}, nil)
+				mockTeamRepo.On("SetTeamRequireMfa", mock.Anything, mock.MatchedBy(func(data interfaces.SetTeamRequireMfaData) bool {
+					return data.TeamID == teamId && !data.RequireMfa
+				})).Return(team, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:        "The organization of the team requires two-factor authentication",
+			userRole:    "Admin",
+			requestBody: map[string]interface{}{"requireMfa": false},
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository, mockOrganizationRepo *mocks.MockOrganizationRepository) {
+				mockTeamRepo.On("GetTeamByOwner", mock.Anything, userId).Return(true, team, nil)
+				mockOrganizationRepo.On("GetOrganizationById", mock.Anything, organizationId).Return(models.Organization{ID: organizationId, RequireMfa: true}, nil)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockTeamRepo := mocks.NewMockTeamRepository()
+			mockOrganizationRepo := mocks.NewMockOrganizationRepository()
+
+			tt.setupMocks(mockTeamRepo, mockOrganizationRepo)
+
+			handler := newTestHandler(handlerDeps{teamRepo: mockTeamRepo, organizationRepo: mockOrganizationRepo})
+
+			app.Put("/teams/mfa", func(c *fiber.Ctx) error {
+				c.Locals("userRole", tt.userRole)
+				c.Locals("userId", userId.String())
+				return handler.UpdateTeamMfa(c)
+			})
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPut, "/teams/mfa", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockTeamRepo.AssertExpectations(t)
+			mockOrganizationRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_GetTeams(t *testing.T) {
+	userId := uuid.New()
+	now := time.Now().UTC()
+
+	teams := []models.Team{
+		{ID: uuid.New(), Name: "Team A", OwnerID: userId, CreatedAt: now, UpdatedAt: now},
+		{ID: uuid.New(), Name: "Team B", OwnerID: userId, CreatedAt: now.Add(time.Second), UpdatedAt: now},
+	}
+
+	tests := []struct {
+		name           string
+		userRole       string
+		query          string
+		setupMocks     func(*mocks.MockTeamRepository)
+		expectedStatus int
+		expectedCount  int
+		expectNext     bool
+	}{
+		{
+			name:           "Unauthorized - non-admin user",
+			userRole:       "Manager",
+			query:          "?limit=10",
+			setupMocks:     func(mockTeamRepo *mocks.MockTeamRepository) {},
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:     "Lists the teams owned by the user",
+			userRole: "Admin",
+			query:    "?limit=10&name=team",
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository) {
+				mockTeamRepo.On("GetTeams", mock.Anything, mock.MatchedBy(func(filter models.TeamFilter) bool {
+					return filter.UserId == userId && filter.Name == "team" && filter.Limit == 10 && filter.IsFirstPage
+				})).Return(teams, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+			expectedCount:  2,
+		},
+		{
+			name:     "Pages the teams",
+			userRole: "Admin",
+			query:    "?limit=1",
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository) {
+				mockTeamRepo.On("GetTeams", mock.Anything, mock.AnythingOfType("models.TeamFilter")).Return(teams, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+			expectedCount:  1,
+			expectNext:     true,
+		},
+		{
+			name:     "Database error",
+			userRole: "Admin",
+			query:    "?limit=10",
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository) {
+				mockTeamRepo.On("GetTeams", mock.Anything, mock.AnythingOfType("models.TeamFilter")).Return([]models.Team{}, sql.ErrConnDone)
+			},
+			expectedStatus: fiber.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockTeamRepo := mocks.NewMockTeamRepository()
+			tt.setupMocks(mockTeamRepo)
+
+			handler := newTestHandler(handlerDeps{teamRepo: mockTeamRepo})
+
+			app.Get("/teams", func(c *fiber.Ctx) error {
+				c.Locals("userRole", tt.userRole)
+				c.Locals("userId", userId.String())
+				return handler.GetTeams(c)
+			})
+
+			resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/teams"+tt.query, nil))
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.expectedStatus == fiber.StatusOK {
+				var body struct {
+					Data       []models.Team `json:"data"`
+					Pagination struct {
+						NextCursor string `json:"next_cursor"`
+					} `json:"pagination"`
+				}
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+				assert.Len(t, body.Data, tt.expectedCount)
+				assert.Equal(t, tt.expectNext, body.Pagination.NextCursor != "")
+			}
+
+			mockTeamRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_GetTeam(t *testing.T) {
+	userId := uuid.New()
+	teamId := uuid.New()
+	otherTeamId := uuid.New()
+
+	team := models.Team{ID: teamId, Name: "Test Team", OwnerID: userId}
+
+	tests := []struct {
+		name           string
+		userRole       string
+		teamId         string
+		setupMocks     func(*mocks.MockTeamRepository)
+		expectedStatus int
+	}{
+		{
+			name:     "Successfully get team",
+			userRole: "Admin",
+			teamId:   teamId.String(),
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository) {
+				mockTeamRepo.On("GetTeamById", mock.Anything, teamId).Return(team, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:     "Team not found",
+			userRole: "Admin",
+			teamId:   teamId.String(),
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository) {
+				mockTeamRepo.On("GetTeamById", mock.Anything, teamId).Return(models.Team{}, sql.ErrNoRows)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:     "Team of another admin",
+			userRole: "Admin",
+			teamId:   otherTeamId.String(),
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository) {
+				mockTeamRepo.On("GetTeamById", mock.Anything, otherTeamId).Return(models.Team{ID: otherTeamId, Name: "Other Team", OwnerID: uuid.New()}, nil)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockTeamRepo := mocks.NewMockTeamRepository()
+			tt.setupMocks(mockTeamRepo)
+			mockTeamRepo.On("GetTeamByOwner", mock.Anything, userId).Return(true, team, nil).Maybe()
+
+			handler := newTestHandler(handlerDeps{teamRepo: mockTeamRepo})
+
+			app.Get("/teams/:id", func(c *fiber.Ctx) error {
+				c.Locals("userRole", tt.userRole)
+				c.Locals("userId", userId.String())
+				return handler.GetTeam(c)
+			})
+
+			resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/teams/"+tt.teamId, nil))
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockTeamRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_UpdateTeam(t *testing.T) {
+	userId := uuid.New()
+	teamId := uuid.New()
+
+	team := models.Team{ID: teamId, Name: "Test Team", OwnerID: userId}
+
+	tests := []struct {
+		name           string
+		requestBody    map[string]interface{}
+		setupMocks     func(*mocks.MockTeamRepository)
+		expectedStatus int
+	}{
+		{
+			name:           "Missing name",
+			requestBody:    map[string]interface{}{},
+			setupMocks:     func(mockTeamRepo *mocks.MockTeamRepository) {},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "Successfully rename team",
+			requestBody: map[string]interface{}{"name": "Platform Team"},
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository) {
+				mockTeamRepo.On("UpdateTeamName", mock.Anything, mock.MatchedBy(func(data interfaces.UpdateTeamNameData) bool {
+					return data.TeamID == teamId && data.Name == "Platform Team"
+				})).Return(models.Team{ID: teamId, Name: "Platform Team", OwnerID: userId}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockTeamRepo := mocks.NewMockTeamRepository()
+			tt.setupMocks(mockTeamRepo)
+			mockTeamRepo.On("GetTeamById", mock.Anything, teamId).Return(team, nil)
+			mockTeamRepo.On("GetTeamByOwner", mock.Anything, userId).Return(true, team, nil).Maybe()
+
+			handler := newTestHandler(handlerDeps{teamRepo: mockTeamRepo})
+
+			app.Patch("/teams/:id", func(c *fiber.Ctx) error {
+				c.Locals("userRole", "Admin")
+				c.Locals("userId", userId.String())
+				return handler.UpdateTeam(c)
+			})
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPatch, "/teams/"+teamId.String(), bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockTeamRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_TransferTeamOwnership(t *testing.T) {
+	userId := uuid.New()
+	adminId := uuid.New()
+	teamId := uuid.New()
+
+	team := models.Team{ID: teamId, Name: "Test Team", OwnerID: userId}
+
+	tests := []struct {
+		name           string
+		requestBody    map[string]interface{}
+		setupMocks     func(*mocks.MockUserRepository, *mocks.MockTeamRepository)
+		expectedStatus int
+	}{
+		{
+			name:           "Missing userId",
+			requestBody:    map[string]interface{}{},
+			setupMocks:     func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository) {},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "User already owns the team",
+			requestBody:    map[string]interface{}{"userId": userId.String()},
+			setupMocks:     func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository) {},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "User isn't a member of the team",
+			requestBody: map[string]interface{}{"userId": adminId.String()},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository) {
+				mockTeamRepo.On("GetTeamMember", mock.Anything, teamId, adminId).Return(false, models.TeamMember{}, nil)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:        "User isn't an admin of the team",
+			requestBody: map[string]interface{}{"userId": adminId.String()},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository) {
+				mockTeamRepo.On("GetTeamMember", mock.Anything, teamId, adminId).Return(true, models.TeamMember{TeamID: teamId, UserID: adminId, Role: models.UserrolesManager}, nil)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "Successfully transfer the ownership to an admin that owns another team",
+			requestBody: map[string]interface{}{"userId": adminId.String()},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository) {
+				mockTeamRepo.On("GetTeamMember", mock.Anything, teamId, adminId).Return(true, models.TeamMember{TeamID: teamId, UserID: adminId, Role: models.UserrolesAdmin}, nil)
+				mockTeamRepo.On("TransferTeamOwnership", mock.Anything, mock.MatchedBy(func(data interfaces.TransferTeamOwnershipData) bool {
+					return data.TeamID == teamId && data.OwnerID == adminId
+				})).Return(models.Team{ID: teamId, Name: "Test Team", OwnerID: adminId}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockUserRepo := mocks.NewMockUserRepository()
+			mockTeamRepo := mocks.NewMockTeamRepository()
+			tt.setupMocks(mockUserRepo, mockTeamRepo)
+			mockTeamRepo.On("GetTeamById", mock.Anything, teamId).Return(team, nil)
+			mockTeamRepo.On("GetTeamByOwner", mock.Anything, userId).Return(true, team, nil).Maybe()
+
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, teamRepo: mockTeamRepo})
+
+			app.Post("/teams/:id/transfer-ownership", func(c *fiber.Ctx) error {
+				c.Locals("userRole", "Admin")
+				c.Locals("userId", userId.String())
+				return handler.TransferTeamOwnership(c)
+			})
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/teams/"+teamId.String()+"/transfer-ownership", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockUserRepo.AssertExpectations(t)
+			mockTeamRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_DeleteTeam(t *testing.T) {
+	userId := uuid.New()
+	teamId := uuid.New()
+
+	team := models.Team{ID: teamId, Name: "Test Team", OwnerID: userId}
+	report := interfaces.TeamDeletionReport{Projects: 2, Tasks: 7, Users: 3, Invitations: 1}
+
+	tests := []struct {
+		name            string
+		query           string
+		setupMocks      func(*mocks.MockTeamRepository)
+		expectedStatus  int
+		expectedDeleted bool
+	}{
+		{
+			name:  "Dry run reports what would be removed",
+			query: "?dryRun=true",
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository) {
+				mockTeamRepo.On("GetTeamDeletionReport", mock.Anything, teamId).Return(report, nil)
+			},
+			expectedStatus:  fiber.StatusOK,
+			expectedDeleted: false,
+		},
+		{
+			name:  "Missing confirmation",
+			query: "",
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository) {
+				mockTeamRepo.On("GetTeamDeletionReport", mock.Anything, teamId).Return(report, nil)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:  "Confirmation with another name",
+			query: "?confirm=Other%20Team",
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository) {
+				mockTeamRepo.On("GetTeamDeletionReport", mock.Anything, teamId).Return(report, nil)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:  "Successfully delete team",
+			query: "?confirm=Test%20Team",
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository) {
+				mockTeamRepo.On("GetTeamDeletionReport", mock.Anything, teamId).Return(report, nil)
+				mockTeamRepo.On("DeleteTeam", mock.Anything, mock.MatchedBy(func(data interfaces.DeleteTeamData) bool {
+					return data.TeamID == teamId
+				})).Return(nil)
+			},
+			expectedStatus:  fiber.StatusOK,
+			expectedDeleted: true,
+		},
+		{
+			name:  "Database error on delete",
+			query: "?confirm=Test%20Team",
+			setupMocks: func(mockTeamRepo *mocks.MockTeamRepository) {
+				mockTeamRepo.On("GetTeamDeletionReport", mock.Anything, teamId).Return(report, nil)
+				mockTeamRepo.On("DeleteTeam", mock.Anything, mock.AnythingOfType("interfaces.DeleteTeamData")).Return(sql.ErrConnDone)
+			},
+			expectedStatus: fiber.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockTeamRepo := mocks.NewMockTeamRepository()
+			tt.setupMocks(mockTeamRepo)
+			mockTeamRepo.On("GetTeamById", mock.Anything, teamId).Return(team, nil)
+			mockTeamRepo.On("GetTeamByOwner", mock.Anything, userId).Return(true, team, nil).Maybe()
+
+			handler := newTestHandler(handlerDeps{teamRepo: mockTeamRepo})
+
+			app.Delete("/teams/:id", func(c *fiber.Ctx) error {
+				c.Locals("userRole", "Admin")
+				c.Locals("userId", userId.String())
+				return handler.DeleteTeam(c)
+			})
+
+			resp, _ := app.Test(httptest.NewRequest(http.MethodDelete, "/teams/"+teamId.String()+tt.query, nil))
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.expectedStatus == fiber.StatusOK {
+				var body interfaces.TeamDeletionResponse
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+				assert.Equal(t, tt.expectedDeleted, body.Deleted)
+				assert.Equal(t, report, body.Report)
+			}
+
+			mockTeamRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,418 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/handlers"
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/tenant"
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
+	"github.com/TobiasRV/challenge-fs-senior/test/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// teamFixture is a team with a user of every role, a project and a task
+type teamFixture struct {
+	team    models.Team
+	admin   models.User
+	manager models.User
+	member  models.User
+	project models.Project
+	task    models.Task
+}
+
+func newTeamFixture(name string) teamFixture {
+	teamId := uuid.New()
+	adminId := uuid.New()
+	managerId := uuid.New()
+	memberId := uuid.New()
+	projectId := uuid.New()
+
+	return teamFixture{
+		team:    models.Team{ID: teamId, Name: name, OwnerID: adminId},
+		admin:   models.User{ID: adminId, Username: name + "-admin", Email: name + "-admin@example.com", Role: models.UserrolesAdmin},
+		manager: models.User{ID: managerId, Username: name + "-manager", Email: name + "-manager@example.com", Role: models.UserrolesManager, TeamId: teamId},
+		member:  models.User{ID: memberId, Username: name + "-member", Email: name + "-member@example.com", Role: models.UserrolesMember, TeamId: teamId},
+		project: models.Project{ID: projectId, Name: name + "-project", TeamID: teamId, ManagerID: managerId},
+		task:    models.Task{ID: uuid.New(), Title: name + "-task", ProjectID: projectId, UserID: uuid.NullUUID{UUID: memberId, Valid: true}},
+	}
+}
+
+// mockTeamFixture lets the handlers look up the fixtures of the team, anything that lists or changes data
+// isn't mocked so reaching it fails the request
+func mockTeamFixture(tn teamFixture, ur *mocks.MockUserRepository, tr *mocks.MockTeamRepository, pr *mocks.MockProjectRepository, tsr *mocks.MockTaskRepository) {
+	tr.On("GetTeamByOwner", mock.Anything, tn.admin.ID).Return(true, tn.team, nil).Maybe()
+
+	for _, user := range []models.User{tn.admin, tn.manager, tn.member} {
+		ur.On("GetUserById", mock.Anything, user.ID).Return(user, nil).Maybe()
+		tr.On("GetTeamMember", mock.Anything, tn.team.ID, user.ID).Return(true, models.TeamMember{
+			TeamID: tn.team.ID,
+			UserID: user.ID,
+			Role:   user.Role,
+		}, nil).Maybe()
+	}
+
+	// Users of other teams aren't members of this one
+	tr.On("GetTeamMember", mock.Anything, tn.team.ID, mock.Anything).Return(false, models.TeamMember{}, nil).Maybe()
+
+	pr.On("GetProjectById", mock.Anything, tn.project.ID).Return(tn.project, nil).Maybe()
+
+	// The manager owns the project and the member contributes to it
+	members := []models.ProjectMember{
+		{ProjectID: tn.project.ID, UserID: tn.manager.ID, Role: models.ProjectmemberrolesOwner},
+		{ProjectID: tn.project.ID, UserID: tn.member.ID, Role: models.ProjectmemberrolesContributor},
+	}
+	pr.On("GetProjectMembers", mock.Anything, tn.project.ID).Return(members, nil).Maybe()
+
+	for _, member := range members {
+		pr.On("GetProjectMember", mock.Anything, tn.project.ID, member.UserID).Return(true, member, nil).Maybe()
+	}
+
+	tsr.On("GetTaskById", mock.Anything, tn.task.ID).Return(tn.task, nil).Maybe()
+}
+
+func setupTenancyTestApp(handler *handlers.Handler, user models.User) *fiber.App {
+	app := setupTestApp()
+	app.Use(recover.New())
+
+	// Only what the auth middleware stores, the team is looked up by the handlers
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("userId", user.ID.String())
+		c.Locals("userRole", string(user.Role))
+		return c.Next()
+	})
+
+	app.Get("/users", handler.GetUsers)
+	app.Post("/users", handler.CreateUser)
+	app.Put("/users/:id", handler.UpdateUser)
+	app.Delete("/users/:id", handler.DeleteUser)
+	app.Delete("/users/:id/sessions", handler.RevokeUserSessions)
+	app.Post("/users/:id/unlock", handler.UnlockUser)
+	app.Get("/projects", handler.GetProjects)
+	app.Put("/projects/:id", handler.UpdateProject)
+	app.Delete("/projects/:id", handler.DeleteProject)
+	app.Get("/projects/:id/members", handler.GetProjectMembers)
+	app.Post("/projects/:id/members", handler.AddProjectMember)
+	app.Delete("/projects/:id/members/:userId", handler.RemoveProjectMember)
+	app.Get("/tasks", handler.GetTasks)
+	app.Post("/tasks", handler.CreateTask)
+	app.Put("/tasks/:id", handler.UpdateTask)
+	app.Delete("/tasks/:id", handler.DeleteTask)
+
+	return app
+}
+
+func TestHandler_Tenancy(t *testing.T) {
+	own := newTeamFixture("own")
+	other := newTeamFixture("other")
+
+	t.Run("Resources of another team are not found", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			user   models.User
+			method string
+			path   string
+			body   map[string]interface{}
+		}{
+			{name: "Admin lists the users of another team", user: own.admin, method: http.MethodGet, path: "/users?limit=10&teamId=" + other.team.ID.String()},
+			{name: "Admin creates a user in another team", user: own.admin, method: http.MethodPost, path: "/users", body: map[string]interface{}{"username": "new", "email": "new@example.com", "password": "password", "role": "Member", "teamId": other.team.ID.String()}},
+			{name: "Admin updates a user of another team", user: own.admin, method: http.MethodPut, path: "/users/" + other.member.ID.String(), body: map[string]interface{}{"username": "renamed", "email": "renamed@example.com"}},
+			{name: "Admin updates the admin of another team", user: own.admin, method: http.MethodPut, path: "/users/" + other.admin.ID.String(), body: map[string]interface{}{"username": "renamed", "email": "renamed@example.com"}},
+			{name: "Admin deletes a user of another team", user: own.admin, method: http.MethodDelete, path: "/users/" + other.manager.ID.String()},
+			{name: "Admin revokes the sessions of a user of another team", user: own.admin, method: http.MethodDelete, path: "/users/" + other.member.ID.String() + "/sessions"},
+			{name: "Admin unlocks a user of another team", user: own.admin, method: http.MethodPost, path: "/users/" + other.member.ID.String() + "/unlock"},
+			{name: "Admin lists the projects of another team", user: own.admin, method: http.MethodGet, path: "/projects?limit=10&teamId=" + other.team.ID.String()},
+			{name: "Admin lists the projects of a manager of another team", user: own.admin, method: http.MethodGet, path: "/projects?limit=10&managerId=" + other.manager.ID.String()},
+			{name: "Admin lists the tasks of a project of another team", user: own.admin, method: http.MethodGet, path: "/tasks?limit=10&projectId=" + other.project.ID.String()},
+			{name: "Manager lists the users of another team", user: own.manager, method: http.MethodGet, path: "/users?limit=10&teamId=" + other.team.ID.String()},
+			{name: "Manager lists the projects of another team", user: own.manager, method: http.MethodGet, path: "/projects?limit=10&teamId=" + other.team.ID.String()},
+			{name: "Manager lists the projects of a manager of another team", user: own.manager, method: http.MethodGet, path: "/projects?limit=10&managerId=" + other.manager.ID.String()},
+			{name: "Manager lists the tasks of a project of another team", user: own.manager, method: http.MethodGet, path: "/tasks?limit=10&projectId=" + other.project.ID.String()},
+			{name: "Manager updates a project of another team", user: own.manager, method: http.MethodPut, path: "/projects/" + other.project.ID.String(), body: map[string]interface{}{"name": "renamed", "status": "InProgress"}},
+			{name: "Manager deletes a project of another team", user: own.manager, method: http.MethodDelete, path: "/projects/" + other.project.ID.String()},
+			{name: "Manager lists the members of a project of another team", user: own.manager, method: http.MethodGet, path: "/projects/" + other.project.ID.String() + "/members"},
+			{name: "Manager adds a member to a project of another team", user: own.manager, method: http.MethodPost, path: "/projects/" + other.project.ID.String() + "/members", body: map[string]interface{}{"userId": own.member.ID.String(), "role": "Contributor"}},
+			{name: "Manager adds a user of another team to a project", user: own.manager, method: http.MethodPost, path: "/projects/" + own.project.ID.String() + "/members", body: map[string]interface{}{"userId": other.member.ID.String(), "role": "Contributor"}},
+			{name: "Manager removes a member of a project of another team", user: own.manager, method: http.MethodDelete, path: "/projects/" + other.project.ID.String() + "/members/" + other.member.ID.String()},
+			{name: "Manager creates a task in a project of another team", user: own.manager, method: http.MethodPost, path: "/tasks", body: map[string]interface{}{"title": "task", "projectId": other.project.ID.String()}},
+			{name: "Manager assigns a new task to a user of another team", user: own.manager, method: http.MethodPost, path: "/tasks", body: map[string]interface{}{"title": "task", "projectId": own.project.ID.String(), "userId": other.member.ID.String()}},
+			{name: "Manager updates a task of another team", user: own.manager, method: http.MethodPut, path: "/tasks/" + other.task.ID.String(), body: map[string]interface{}{"title": "task", "status": "Done"}},
+			{name: "Manager assigns a task to a user of another team", user: own.manager, method: http.MethodPut, path: "/tasks/" + own.task.ID.String(), body: map[string]interface{}{"title": "task", "status": "Done", "userId": other.member.ID.String()}},
+			{name: "Manager deletes a task of another team", user: own.manager, method: http.MethodDelete, path: "/tasks/" + other.task.ID.String()},
+			{name: "Member lists the tasks of a project of another team", user: own.member, method: http.MethodGet, path: "/tasks?limit=10&projectId=" + other.project.ID.String()},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockUserRepo := mocks.NewMockUserRepository()
+				mockTeamRepo := mocks.NewMockTeamRepository()
+				mockProjectRepo := mocks.NewMockProjectRepository()
+				mockTaskRepo := mocks.NewMockTaskRepository()
+
+				mockTeamFixture(own, mockUserRepo, mockTeamRepo, mockProjectRepo, mockTaskRepo)
+				mockTeamFixture(other, mockUserRepo, mockTeamRepo, mockProjectRepo, mockTaskRepo)
+
+				handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, teamRepo: mockTeamRepo, projectRepo: mockProjectRepo, taskRepo: mockTaskRepo})
+
+				app := setupTenancyTestApp(handler, tt.user)
+
+				var body io.Reader
+				if tt.body != nil {
+					payload, _ := json.Marshal(tt.body)
+					body = bytes.NewReader(payload)
+				}
+
+				req := httptest.NewRequest(tt.method, tt.path, body)
+				req.Header.Set("Content-Type", "application/json")
+
+				resp, err := app.Test(req)
+				assert.NoError(t, err)
+
+				assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+
+				// The response doesn't tell the resource exists elsewhere
+				responseBody, _ := io.ReadAll(resp.Body)
+				assert.NotContains(t, string(responseBody), other.team.ID.String())
+				assert.NotContains(t, string(responseBody), "unauthorized")
+			})
+		}
+	})
+
+	t.Run("Lists are scoped to the team of the user", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			user       models.User
+			path       string
+			setupMocks func(*mocks.MockUserRepository, *mocks.MockProjectRepository, *mocks.MockTaskRepository)
+		}{
+			{
+				name: "Users default to the team of the admin",
+				user: own.admin,
+				path: "/users?limit=10",
+				setupMocks: func(ur *mocks.MockUserRepository, pr *mocks.MockProjectRepository, tsr *mocks.MockTaskRepository) {
+					ur.On("GetUsers", mock.Anything, mock.MatchedBy(func(filters interfaces.GetUserFilters) bool {
+						return filters.TeamId == own.team.ID
+					})).Return([]models.User{own.manager, own.member}, nil)
+				},
+			},
+			{
+				name: "Users of the team of the manager",
+				user: own.manager,
+				path: "/users?limit=10&teamId=" + own.team.ID.String(),
+				setupMocks: func(ur *mocks.MockUserRepository, pr *mocks.MockProjectRepository, tsr *mocks.MockTaskRepository) {
+					ur.On("GetUsers", mock.Anything, mock.MatchedBy(func(filters interfaces.GetUserFilters) bool {
+						return filters.TeamId == own.team.ID
+					})).Return([]models.User{own.manager, own.member}, nil)
+				},
+			},
+			{
+				name: "Projects of a manager are listed within the team",
+				user: own.admin,
+				path: "/projects?limit=10&managerId=" + own.manager.ID.String(),
+				setupMocks: func(ur *mocks.MockUserRepository, pr *mocks.MockProjectRepository, tsr *mocks.MockTaskRepository) {
+					pr.On("GetProjects", mock.Anything, mock.MatchedBy(func(filters interfaces.GetProjectsFilters) bool {
+						return filters.TeamId == own.team.ID && filters.ManagerId == own.manager.ID
+					})).Return([]interfaces.GetProjectsResponse{}, nil)
+				},
+			},
+			{
+				name: "Tasks of a project of the team",
+				user: own.manager,
+				path: "/tasks?limit=10&projectId=" + own.project.ID.String(),
+				setupMocks: func(ur *mocks.MockUserRepository, pr *mocks.MockProjectRepository, tsr *mocks.MockTaskRepository) {
+					tsr.On("GetTasks", mock.Anything, mock.MatchedBy(func(filters interfaces.GetTasksFilters) bool {
+						return filters.ProjectId == own.project.ID
+					})).Return([]interfaces.GetTasksResponse{}, nil)
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockUserRepo := mocks.NewMockUserRepository()
+				mockTeamRepo := mocks.NewMockTeamRepository()
+				mockProjectRepo := mocks.NewMockProjectRepository()
+				mockTaskRepo := mocks.NewMockTaskRepository()
+
+				mockTeamFixture(own, mockUserRepo, mockTeamRepo, mockProjectRepo, mockTaskRepo)
+				mockTeamFixture(other, mockUserRepo, mockTeamRepo, mockProjectRepo, mockTaskRepo)
+
+				tt.setupMocks(mockUserRepo, mockProjectRepo, mockTaskRepo)
+
+				handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, teamRepo: mockTeamRepo, projectRepo: mockProjectRepo, taskRepo: mockTaskRepo})
+
+				app := setupTenancyTestApp(handler, tt.user)
+
+				resp, err := app.Test(httptest.NewRequest(http.MethodGet, tt.path, nil))
+				assert.NoError(t, err)
+
+				assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+				mockUserRepo.AssertExpectations(t)
+				mockProjectRepo.AssertExpectations(t)
+				mockTaskRepo.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("The auth middleware scopes every request to its team", func(t *testing.T) {
+		// The database only lets the rows of the team the request is scoped to through, like the row level
+		// security policies do. Requests that aren't scoped match no mock and fail
+		scopedTo := func(teamId uuid.UUID) interface{} {
+			return mock.MatchedBy(func(c context.Context) bool {
+				scopedTeamId, scoped := tenant.TeamID(c)
+				return scoped && scopedTeamId == teamId
+			})
+		}
+
+		accessToken, err := utils.GenerateJWTToken(own.manager, uuid.New())
+		assert.NoError(t, err)
+
+		personalAccessToken, hash, err := utils.GeneratePersonalAccessToken()
+		assert.NoError(t, err)
+
+		recentlyUsed := time.Now().UTC()
+
+		tests := []struct {
+			name       string
+			middleware func(*handlers.Handler) fiber.Handler
+			token      string
+		}{
+			{
+				name:       "Access token",
+				middleware: func(h *handlers.Handler) fiber.Handler { return h.JWTSuccessHandler },
+				token:      accessToken,
+			},
+			{
+				name: "Personal access token",
+				middleware: func(h *handlers.Handler) fiber.Handler {
+					return h.AuthMiddleware(func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusUnauthorized) }, models.ScopeProjectsRead)
+				},
+				token: personalAccessToken,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockUserRepo := mocks.NewMockUserRepository()
+				mockTeamRepo := mocks.NewMockTeamRepository()
+				mockProjectRepo := mocks.NewMockProjectRepository()
+				mockTaskRepo := mocks.NewMockTaskRepository()
+				mockPersonalAccessTokenRepo := mocks.NewMockPersonalAccessTokenRepository()
+
+				mockTeamFixture(own, mockUserRepo, mockTeamRepo, mocks.NewMockProjectRepository(), mockTaskRepo)
+				mockTeamFixture(other, mockUserRepo, mockTeamRepo, mocks.NewMockProjectRepository(), mockTaskRepo)
+
+				mockProjectRepo.On("GetProjectById", scopedTo(own.team.ID), own.project.ID).Return(own.project, nil)
+				mockProjectRepo.On("GetProjectById", scopedTo(own.team.ID), other.project.ID).Return(models.Project{}, sql.ErrNoRows)
+				mockProjectRepo.On("GetProjectMembers", scopedTo(own.team.ID), own.project.ID).Return([]models.ProjectMember{
+					{ProjectID: own.project.ID, UserID: own.manager.ID, Role: models.ProjectmemberrolesOwner},
+				}, nil)
+
+				mockPersonalAccessTokenRepo.On("GetPersonalAccessTokenByHash", mock.Anything, hash).Return(models.PersonalAccessTokenWithUser{
+					PersonalAccessToken: models.PersonalAccessToken{
+						ID:         uuid.New(),
+						UserID:     own.manager.ID,
+						Scopes:     []string{models.ScopeProjectsRead},
+						ExpiresAt:  time.Now().UTC().Add(time.Hour),
+						LastUsedAt: &recentlyUsed,
+					},
+					UserRole: models.UserrolesManager,
+				}, nil).Maybe()
+
+				handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, teamRepo: mockTeamRepo, projectRepo: mockProjectRepo, taskRepo: mockTaskRepo, personalAccessTokenRepo: mockPersonalAccessTokenRepo})
+
+				app := setupTestApp()
+				app.Use(recover.New())
+				app.Get("/projects/:id/members", tt.middleware(handler), handler.GetProjectMembers)
+
+				assert.Equal(t, fiber.StatusOK, requestWithToken(t, app, http.MethodGet, "/projects/"+own.project.ID.String()+"/members", tt.token))
+				assert.Equal(t, fiber.StatusNotFound, requestWithToken(t, app, http.MethodGet, "/projects/"+other.project.ID.String()+"/members", tt.token))
+
+				mockProjectRepo.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("Members from other teams work in the team of their session", func(t *testing.T) {
+		mockUserRepo := mocks.NewMockUserRepository()
+		mockTeamRepo := mocks.NewMockTeamRepository()
+		mockProjectRepo := mocks.NewMockProjectRepository()
+		mockTaskRepo := mocks.NewMockTaskRepository()
+		mockSessionRepo := mocks.NewMockSessionRepository()
+
+		// The manager of the other team is a contractor of this one
+		contractor := other.manager
+		mockTeamRepo.On("GetTeamMember", mock.Anything, own.team.ID, contractor.ID).Return(true, models.TeamMember{
+			TeamID: own.team.ID,
+			UserID: contractor.ID,
+			Role:   models.UserrolesManager,
+		}, nil).Maybe()
+
+		mockTeamFixture(own, mockUserRepo, mockTeamRepo, mockProjectRepo, mockTaskRepo)
+		mockTeamFixture(other, mockUserRepo, mockTeamRepo, mockProjectRepo, mockTaskRepo)
+
+		mockProjectRepo.On("GetProjects", mock.Anything, mock.MatchedBy(func(filters interfaces.GetProjectsFilters) bool {
+			return filters.TeamId == own.team.ID
+		})).Return([]interfaces.GetProjectsResponse{}, nil)
+		mockSessionRepo.On("DeleteSessionsByUserId", mock.Anything, contractor.ID).Return(nil)
+		mockUserRepo.On("UpdateUser", mock.Anything, mock.AnythingOfType("interfaces.UpdateUserData")).Return(contractor, nil)
+		mockTeamRepo.On("UpdateTeamMemberRole", mock.Anything, mock.MatchedBy(func(data interfaces.UpdateTeamMemberRoleData) bool {
+			return data.TeamID == own.team.ID && data.UserID == contractor.ID && data.Role == models.UserrolesMember
+		})).Return(models.TeamMember{TeamID: own.team.ID, UserID: contractor.ID, Role: models.UserrolesMember}, nil)
+
+		handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, teamRepo: mockTeamRepo, projectRepo: mockProjectRepo, taskRepo: mockTaskRepo, sessionRepo: mockSessionRepo})
+
+		// The access token of the contractor carries the team the session switched to
+		contractorApp := setupTestApp()
+		contractorApp.Use(func(c *fiber.Ctx) error {
+			c.Locals("userId", contractor.ID.String())
+			c.Locals("userRole", string(models.UserrolesManager))
+			c.Locals("teamId", own.team.ID.String())
+			return c.Next()
+		})
+		contractorApp.Get("/projects", handler.GetProjects)
+
+		resp, err := contractorApp.Test(httptest.NewRequest(http.MethodGet, "/projects?limit=10&teamId="+own.team.ID.String(), nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		// The admin of the team manages the contractor, but only removing them from the team takes them out of it
+		app := setupTenancyTestApp(handler, own.admin)
+
+		resp, err = app.Test(httptest.NewRequest(http.MethodDelete, "/users/"+contractor.ID.String()+"/sessions", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		resp, err = app.Test(httptest.NewRequest(http.MethodDelete, "/users/"+contractor.ID.String(), nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+		// Their role in the team changes, the one in their own team doesn't
+		payload, _ := json.Marshal(map[string]interface{}{"username": contractor.Username, "email": contractor.Email, "role": "Member"})
+		req := httptest.NewRequest(http.MethodPut, "/users/"+contractor.ID.String(), bytes.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err = app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+		mockProjectRepo.AssertExpectations(t)
+		mockSessionRepo.AssertExpectations(t)
+		mockUserRepo.AssertNotCalled(t, "UpdateUserRole", mock.Anything, mock.Anything)
+		mockTeamRepo.AssertCalled(t, "UpdateTeamMemberRole", mock.Anything, mock.Anything)
+	})
+}
@@ -9,13 +9,14 @@ import (
 	"testing"
 	"time"
 
-	"github.com/TobiasRV/challenge-fs-senior/internals/handlers"
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
 	"github.com/TobiasRV/challenge-fs-senior/internals/models"
 	"github.com/TobiasRV/challenge-fs-senior/test/mocks"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestHandler_CreateUserAdmin(t *testing.T) {
@@ -25,58 +26,100 @@ func TestHandler_CreateUserAdmin(t *testing.T) {
 	tests := []struct {
 		name           string
 		requestBody    map[string]interface{}
-		setupMocks     func(*mocks.MockUserRepository, *mocks.MockRefreshTokenRepository)
+		setupMocks     func(*mocks.MockUserRepository, *mocks.MockRefreshTokenRepository, *mocks.MockSessionRepository)
+		closed         bool
 		expectedStatus int
 	}{
 		{
-			name: "Missing username field",
+			name: "Registration is closed once an admin exists",
+			requestBody: map[string]interface{}{
+				"username":   "newadmin",
+				"password":   "password123",
+				"email":      "newadmin@example.com",
+				"setupToken": "setup-token",
+			},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository) {
+			},
+			closed:         true,
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name: "Missing setup token",
 			requestBody: map[string]interface{}{
+				"username": "newadmin",
 				"password": "password123",
-				"email":    "test@example.com",
+				"email":    "newadmin@example.com",
 			},
-			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository) {
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository) {
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "Invalid setup token",
+			requestBody: map[string]interface{}{
+				"username":   "newadmin",
+				"password":   "password123",
+				"email":      "newadmin@example.com",
+				"setupToken": "wrong-token",
+			},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository) {
+			},
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name: "Missing username field",
+			requestBody: map[string]interface{}{
+				"password":   "password123",
+				"email":      "test@example.com",
+				"setupToken": "setup-token",
+			},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository) {
 			},
 			expectedStatus: fiber.StatusBadRequest,
 		},
 		{
 			name: "Missing password field",
 			requestBody: map[string]interface{}{
-				"username": "testuser",
-				"email":    "test@example.com",
+				"username":   "testuser",
+				"email":      "test@example.com",
+				"setupToken": "setup-token",
 			},
-			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository) {
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository) {
 			},
 			expectedStatus: fiber.StatusBadRequest,
 		},
 		{
 			name: "Missing email field",
 			requestBody: map[string]interface{}{
-				"username": "testuser",
-				"password": "password123",
+				"username":   "testuser",
+				"password":   "password123",
+				"setupToken": "setup-token",
 			},
-			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository) {
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository) {
 			},
 			expectedStatus: fiber.StatusBadRequest,
 		},
 		{
 			name: "Invalid email format",
 			requestBody: map[string]interface{}{
-				"username": "testuser",
-				"password": "password123",
-				"email":    "invalid-email",
+				"username":   "testuser",
+				"password":   "password123",
+				"email":      "invalid-email",
+				"setupToken": "setup-token",
 			},
-			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository) {
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository) {
 			},
 			expectedStatus: fiber.StatusBadRequest,
 		},
 		{
 			name: "User already exists",
 			requestBody: map[string]interface{}{
-				"username": "existinguser",
-				"password": "password123",
-				"email":    "existing@example.com",
+				"username":   "existinguser",
+				"password":   "password123",
+				"email":      "existing@example.com",
+				"setupToken": "setup-token",
 			},
-			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository) {
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository) {
 				mockUserRepo.On("GetUserByEmail", mock.Anything, "existing@example.com").Return(models.User{
 					ID:       userId,
 					Email:    "existing@example.com",
@@ -88,13 +131,14 @@ func TestHandler_CreateUserAdmin(t *testing.T) {
 		{
 			name: "Successfully create admin user",
 			requestBody: map[string]interface{}{
-				"username": "newadmin",
-				"password": "password123",
-				"email":    "newadmin@example.com",
+				"username":   "newadmin",
+				"password":   "password123",
+				"email":      "newadmin@example.com",
+				"setupToken": "setup-token",
 			},
-			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository) {
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository) {
 				mockUserRepo.On("GetUserByEmail", mock.Anything, "newadmin@example.com").Return(models.User{}, sql.ErrNoRows)
-				mockUserRepo.On("CreateUser", mock.Anything, mock.AnythingOfType("models.User")).Return(models.User{
+				mockUserRepo.On("CreateFirstAdmin", mock.Anything, mock.AnythingOfType("models.User")).Return(true, models.User{
 					ID:        userId,
 					Username:  "newadmin",
 					Email:     "newadmin@example.com",
@@ -102,10 +146,25 @@ func TestHandler_CreateUserAdmin(t *testing.T) {
 					CreatedAt: now,
 					UpdatedAt: now,
 				}, nil)
+				mockSessionRepo.On("CreateSession", mock.Anything, mock.AnythingOfType("models.Session")).Return(models.Session{ID: uuid.New()}, nil)
 				mockRefreshTokenRepo.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("models.RefreshToken")).Return(nil)
 			},
 			expectedStatus: fiber.StatusCreated,
 		},
+		{
+			name: "Admin created by another instance first",
+			requestBody: map[string]interface{}{
+				"username":   "newadmin",
+				"password":   "password123",
+				"email":      "newadmin@example.com",
+				"setupToken": "setup-token",
+			},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRefreshTokenRepo *mocks.MockRefreshTokenRepository, mockSessionRepo *mocks.MockSessionRepository) {
+				mockUserRepo.On("GetUserByEmail", mock.Anything, "newadmin@example.com").Return(models.User{}, sql.ErrNoRows)
+				mockUserRepo.On("CreateFirstAdmin", mock.Anything, mock.AnythingOfType("models.User")).Return(false, models.User{}, nil)
+			},
+			expectedStatus: fiber.StatusForbidden,
+		},
 	}
 
 	for _, tt := range tests {
@@ -114,13 +173,15 @@ func TestHandler_CreateUserAdmin(t *testing.T) {
 
 			mockUserRepo := mocks.NewMockUserRepository()
 			mockRefreshTokenRepo := mocks.NewMockRefreshTokenRepository()
-			mockTeamRepo := mocks.NewMockTeamRepository()
-			mockProjectRepo := mocks.NewMockProjectRepository()
-			mockTaskRepo := mocks.NewMockTaskRepository()
+			mockSessionRepo := mocks.NewMockSessionRepository()
+
+			tt.setupMocks(mockUserRepo, mockRefreshTokenRepo, mockSessionRepo)
 
-			tt.setupMocks(mockUserRepo, mockRefreshTokenRepo)
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, refreshTokenRepo: mockRefreshTokenRepo, sessionRepo: mockSessionRepo})
 
-			handler := handlers.NewHandler(mockUserRepo, mockRefreshTokenRepo, mockTeamRepo, mockProjectRepo, mockTaskRepo)
+			if !tt.closed {
+				handler.EnableAdminBootstrap("setup-token")
+			}
 
 			app.Post("/auth/register-admin", handler.CreateUserAdmin)
 
@@ -136,6 +197,42 @@ func TestHandler_CreateUserAdmin(t *testing.T) {
 			mockRefreshTokenRepo.AssertExpectations(t)
 		})
 	}
+
+	t.Run("Setup token can only be used once", func(t *testing.T) {
+		mockUserRepo := mocks.NewMockUserRepository()
+		mockRefreshTokenRepo := mocks.NewMockRefreshTokenRepository()
+		mockSessionRepo := mocks.NewMockSessionRepository()
+
+		mockUserRepo.On("GetUserByEmail", mock.Anything, "newadmin@example.com").Return(models.User{}, sql.ErrNoRows).Once()
+		mockUserRepo.On("CreateFirstAdmin", mock.Anything, mock.AnythingOfType("models.User")).Return(true, models.User{ID: userId, Email: "newadmin@example.com", Role: models.UserrolesAdmin}, nil).Once()
+		mockSessionRepo.On("CreateSession", mock.Anything, mock.AnythingOfType("models.Session")).Return(models.Session{ID: uuid.New()}, nil)
+		mockRefreshTokenRepo.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("models.RefreshToken")).Return(nil)
+
+		handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, refreshTokenRepo: mockRefreshTokenRepo, sessionRepo: mockSessionRepo})
+		handler.EnableAdminBootstrap("setup-token")
+
+		app := setupTestApp()
+		app.Post("/auth/register-admin", handler.CreateUserAdmin)
+
+		body, _ := json.Marshal(map[string]interface{}{
+			"username":   "newadmin",
+			"password":   "password123",
+			"email":      "newadmin@example.com",
+			"setupToken": "setup-token",
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/auth/register-admin", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+		req = httptest.NewRequest(http.MethodPost, "/auth/register-admin", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ = app.Test(req)
+		assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+
+		mockUserRepo.AssertExpectations(t)
+	})
 }
 
 func TestHandler_UserExistsByEmail(t *testing.T) {
@@ -143,20 +240,31 @@ func TestHandler_UserExistsByEmail(t *testing.T) {
 
 	tests := []struct {
 		name           string
+		userRole       string
 		email          string
 		setupMocks     func(*mocks.MockUserRepository)
 		expectedStatus int
 	}{
 		{
-			name:  "Missing email parameter",
-			email: "",
+			name:     "Unauthorized - non-admin user",
+			userRole: "Member",
+			email:    "existing@example.com",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository) {
+			},
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:     "Missing email parameter",
+			userRole: "Admin",
+			email:    "",
 			setupMocks: func(mockUserRepo *mocks.MockUserRepository) {
 			},
 			expectedStatus: fiber.StatusBadRequest,
 		},
 		{
-			name:  "User exists",
-			email: "existing@example.com",
+			name:     "User exists",
+			userRole: "Admin",
+			email:    "existing@example.com",
 			setupMocks: func(mockUserRepo *mocks.MockUserRepository) {
 				mockUserRepo.On("GetUserByEmail", mock.Anything, "existing@example.com").Return(models.User{
 					ID:    userId,
@@ -166,8 +274,9 @@ func TestHandler_UserExistsByEmail(t *testing.T) {
 			expectedStatus: fiber.StatusOK,
 		},
 		{
-			name:  "User does not exist",
-			email: "notfound@example.com",
+			name:     "User does not exist",
+			userRole: "Admin",
+			email:    "notfound@example.com",
 			setupMocks: func(mockUserRepo *mocks.MockUserRepository) {
 				mockUserRepo.On("GetUserByEmail", mock.Anything, "notfound@example.com").Return(models.User{}, sql.ErrNoRows)
 			},
@@ -180,16 +289,15 @@ func TestHandler_UserExistsByEmail(t *testing.T) {
 			app := setupTestApp()
 
 			mockUserRepo := mocks.NewMockUserRepository()
-			mockRefreshTokenRepo := mocks.NewMockRefreshTokenRepository()
-			mockTeamRepo := mocks.NewMockTeamRepository()
-			mockProjectRepo := mocks.NewMockProjectRepository()
-			mockTaskRepo := mocks.NewMockTaskRepository()
 
 			tt.setupMocks(mockUserRepo)
 
-			handler := handlers.NewHandler(mockUserRepo, mockRefreshTokenRepo, mockTeamRepo, mockProjectRepo, mockTaskRepo)
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo})
 
-			app.Get("/users/exists-by-email", handler.UserExistsByEmail)
+			app.Get("/users/exists-by-email", func(c *fiber.Ctx) error {
+				c.Locals("userRole", tt.userRole)
+				return handler.UserExistsByEmail(c)
+			})
 
 			url := "/users/exists-by-email"
 			if tt.email != "" {
@@ -205,3 +313,614 @@ func TestHandler_UserExistsByEmail(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_GetCurrentUser(t *testing.T) {
+	userId := uuid.New()
+
+	tests := []struct {
+		name           string
+		setupMocks     func(*mocks.MockUserRepository)
+		expectedStatus int
+	}{
+		{
+			name: "Successfully get profile",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(models.User{
+					ID:       userId,
+					Username: "member",
+					Email:    "member@example.com",
+					Password: "hashed",
+					Role:     models.UserrolesMember,
+				}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name: "User deleted after the token was issued",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(models.User{}, sql.ErrNoRows)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockUserRepo := mocks.NewMockUserRepository()
+			tt.setupMocks(mockUserRepo)
+
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo})
+
+			app.Get("/users/me", func(c *fiber.Ctx) error {
+				c.Locals("userId", userId.String())
+				c.Locals("userRole", "Member")
+				return handler.GetCurrentUser(c)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/users/me", nil)
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.expectedStatus == fiber.StatusOK {
+				var body map[string]interface{}
+				json.NewDecoder(resp.Body).Decode(&body)
+				assert.Equal(t, "member@example.com", body["email"])
+				assert.NotContains(t, body, "password")
+			}
+
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_UpdateCurrentUser(t *testing.T) {
+	userId := uuid.New()
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	currentUser := models.User{ID: userId, Username: "member", Email: "member@example.com", Password: string(hashedPassword), Role: models.UserrolesMember}
+
+	tests := []struct {
+		name           string
+		requestBody    map[string]interface{}
+		setupMocks     func(*mocks.MockUserRepository)
+		expectedStatus int
+	}{
+		{
+			name:        "Change username only",
+			requestBody: map[string]interface{}{"username": "new-name"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(currentUser, nil)
+				mockUserRepo.On("UpdateUser", mock.Anything, mock.MatchedBy(func(data interfaces.UpdateUserData) bool {
+					return data.ID == userId && data.Username == "new-name" && data.Email == "member@example.com"
+				})).Return(models.User{ID: userId, Username: "new-name", Email: "member@example.com"}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:        "Change email",
+			requestBody: map[string]interface{}{"email": "new@example.com", "currentPassword": "password123"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(currentUser, nil)
+				mockUserRepo.On("GetUserByEmail", mock.Anything, "new@example.com").Return(models.User{}, sql.ErrNoRows)
+				mockUserRepo.On("UpdateUser", mock.Anything, mock.MatchedBy(func(data interfaces.UpdateUserData) bool {
+					return data.Username == "member" && data.Email == "new@example.com"
+				})).Return(models.User{ID: userId, Username: "member", Email: "new@example.com"}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:        "Email used by another user",
+			requestBody: map[string]interface{}{"email": "taken@example.com", "currentPassword": "password123"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(currentUser, nil)
+				mockUserRepo.On("GetUserByEmail", mock.Anything, "taken@example.com").Return(models.User{ID: uuid.New()}, nil)
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name:        "Change email without the current password",
+			requestBody: map[string]interface{}{"email": "new@example.com"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(currentUser, nil)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "Change email with a wrong current password",
+			requestBody: map[string]interface{}{"email": "new@example.com", "currentPassword": "wrong-password"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(currentUser, nil)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "Same email doesn't need the current password",
+			requestBody: map[string]interface{}{"username": "new-name", "email": "member@example.com"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(currentUser, nil)
+				mockUserRepo.On("UpdateUser", mock.Anything, mock.MatchedBy(func(data interfaces.UpdateUserData) bool {
+					return data.Username == "new-name" && data.Email == "member@example.com"
+				})).Return(models.User{ID: userId, Username: "new-name", Email: "member@example.com"}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "Invalid email",
+			requestBody:    map[string]interface{}{"email": "not-an-email"},
+			setupMocks:     func(mockUserRepo *mocks.MockUserRepository) {},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockUserRepo := mocks.NewMockUserRepository()
+			tt.setupMocks(mockUserRepo)
+
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo})
+
+			app.Patch("/users/me", func(c *fiber.Ctx) error {
+				c.Locals("userId", userId.String())
+				c.Locals("userRole", "Member")
+				return handler.UpdateCurrentUser(c)
+			})
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPatch, "/users/me", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_ChangePassword(t *testing.T) {
+	userId := uuid.New()
+	sessionId := uuid.New()
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	currentUser := models.User{ID: userId, Username: "member", Email: "member@example.com", Password: string(hashedPassword), Role: models.UserrolesMember}
+
+	tests := []struct {
+		name           string
+		requestBody    map[string]interface{}
+		setupMocks     func(*mocks.MockUserRepository, *mocks.MockRevokedSessionRepository)
+		expectedStatus int
+	}{
+		{
+			name:        "Successfully change password - revokes the other sessions",
+			requestBody: map[string]interface{}{"currentPassword": "password123", "newPassword": "newpassword123"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRevokedSessionRepo *mocks.MockRevokedSessionRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(currentUser, nil)
+				mockUserRepo.On("ChangeUserPassword", mock.Anything, mock.MatchedBy(func(data interfaces.ChangeUserPasswordData) bool {
+					return data.ID == userId &&
+						data.KeepSessionID == sessionId &&
+						bcrypt.CompareHashAndPassword([]byte(data.Password), []byte("newpassword123")) == nil
+				})).Return(nil)
+				mockRevokedSessionRepo.On("GetRevokedSessionIds", mock.Anything, mock.AnythingOfType("time.Time")).Return([]uuid.UUID{}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:        "Wrong current password",
+			requestBody: map[string]interface{}{"currentPassword": "wrong-password", "newPassword": "newpassword123"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRevokedSessionRepo *mocks.MockRevokedSessionRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(currentUser, nil)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "Missing current password",
+			requestBody: map[string]interface{}{"newPassword": "newpassword123"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRevokedSessionRepo *mocks.MockRevokedSessionRepository) {
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "Same password",
+			requestBody: map[string]interface{}{"currentPassword": "password123", "newPassword": "password123"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRevokedSessionRepo *mocks.MockRevokedSessionRepository) {
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "Error changing password",
+			requestBody: map[string]interface{}{"currentPassword": "password123", "newPassword": "newpassword123"},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockRevokedSessionRepo *mocks.MockRevokedSessionRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, userId).Return(currentUser, nil)
+				mockUserRepo.On("ChangeUserPassword", mock.Anything, mock.AnythingOfType("interfaces.ChangeUserPasswordData")).Return(sql.ErrConnDone)
+			},
+			expectedStatus: fiber.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockUserRepo := mocks.NewMockUserRepository()
+			mockRevokedSessionRepo := mocks.NewMockRevokedSessionRepository()
+			tt.setupMocks(mockUserRepo, mockRevokedSessionRepo)
+
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, revokedSessionRepo: mockRevokedSessionRepo})
+
+			app.Post("/users/me/password", func(c *fiber.Ctx) error {
+				c.Locals("userId", userId.String())
+				c.Locals("userRole", "Member")
+				c.Locals("sessionId", sessionId.String())
+				return handler.ChangePassword(c)
+			})
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/users/me/password", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			mockUserRepo.AssertExpectations(t)
+			mockRevokedSessionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_DeleteUser(t *testing.T) {
+	adminId := uuid.New()
+	teamId := uuid.New()
+	userId := uuid.New()
+	managerId := uuid.New()
+	memberId := uuid.New()
+	deactivatedId := uuid.New()
+	otherAdminId := uuid.New()
+	ownedTeamId := uuid.New()
+	otherTeamId := uuid.New()
+	organizationId := uuid.New()
+	now := time.Now().UTC()
+
+	manager := models.User{ID: userId, Role: models.UserrolesManager, TeamId: teamId}
+	admin := models.User{ID: userId, Role: models.UserrolesAdmin, TeamId: teamId}
+	deactivated := models.User{ID: userId, Role: models.UserrolesManager, TeamId: teamId, DeactivatedAt: &now}
+
+	emptyReport := interfaces.UserOffboardingReport{Teams: []models.Team{}, Projects: []models.Project{}, Tasks: []interfaces.OffboardingTask{}}
+	managerReport := interfaces.UserOffboardingReport{
+		Teams:    []models.Team{},
+		Projects: []models.Project{{ID: uuid.New(), TeamID: teamId, ManagerID: userId}},
+		Tasks:    []interfaces.OffboardingTask{{Task: models.Task{ID: uuid.New(), Status: models.TaskstatusInProgress}, TeamID: teamId}},
+	}
+	adminReport := interfaces.UserOffboardingReport{
+		Teams:    []models.Team{{ID: ownedTeamId, OwnerID: userId, OrganizationID: organizationId}},
+		Projects: []models.Project{},
+		Tasks:    []interfaces.OffboardingTask{},
+	}
+
+	tests := []struct {
+		name                string
+		user                models.User
+		workTeamIds         []uuid.UUID
+		report              interfaces.UserOffboardingReport
+		query               string
+		requestBody         map[string]interface{}
+		setupMocks          func(*mocks.MockUserRepository, *mocks.MockTeamRepository)
+		expectedStatus      int
+		expectedDeactivated bool
+	}{
+		{
+			name:           "Dry run reports what would be handed over",
+			user:           manager,
+			workTeamIds:    []uuid.UUID{teamId},
+			report:         managerReport,
+			query:          "?dryRun=true",
+			setupMocks:     func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository) {},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "The projects of the user need a new manager",
+			user:           manager,
+			workTeamIds:    []uuid.UUID{teamId},
+			report:         managerReport,
+			requestBody:    map[string]interface{}{"taskAssigneeId": memberId},
+			setupMocks:     func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository) {},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "The new manager has to be a manager of the team",
+			user:        manager,
+			workTeamIds: []uuid.UUID{teamId},
+			report:      managerReport,
+			requestBody: map[string]interface{}{"projectManagerId": memberId, "taskAssigneeId": memberId},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, memberId).Return(models.User{ID: memberId, Role: models.UserrolesMember, TeamId: teamId}, nil)
+				mockTeamRepo.On("GetTeamMember", mock.Anything, teamId, memberId).Return(true, models.TeamMember{TeamID: teamId, UserID: memberId, Role: models.UserrolesMember}, nil)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "Deactivated users can't take over tasks",
+			user:        manager,
+			workTeamIds: []uuid.UUID{teamId},
+			report:      managerReport,
+			requestBody: map[string]interface{}{"projectManagerId": managerId, "taskAssigneeId": deactivatedId},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, managerId).Return(models.User{ID: managerId, Role: models.UserrolesManager, TeamId: teamId}, nil)
+				mockTeamRepo.On("GetTeamMember", mock.Anything, teamId, managerId).Return(true, models.TeamMember{TeamID: teamId, UserID: managerId, Role: models.UserrolesManager}, nil)
+				mockUserRepo.On("GetUserById", mock.Anything, deactivatedId).Return(models.User{ID: deactivatedId, Role: models.UserrolesMember, TeamId: teamId, DeactivatedAt: &now}, nil)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "The user can't take over their own work",
+			user:        manager,
+			workTeamIds: []uuid.UUID{teamId},
+			report:      managerReport,
+			requestBody: map[string]interface{}{"projectManagerId": userId, "taskAssigneeId": memberId},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository) {
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:        "Successfully hand over the projects and tasks and deactivate the user",
+			user:        manager,
+			workTeamIds: []uuid.UUID{teamId},
+			report:      managerReport,
+			requestBody: map[string]interface{}{"projectManagerId": managerId, "taskAssigneeId": memberId},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository) {
+				mockUserRepo.On("GetUserById", mock.Anything, managerId).Return(models.User{ID: managerId, Role: models.UserrolesManager, TeamId: teamId}, nil)
+				mockTeamRepo.On("GetTeamMember", mock.Anything, teamId, managerId).Return(true, models.TeamMember{TeamID: teamId, UserID: managerId, Role: models.UserrolesManager}, nil)
+				mockUserRepo.On("GetUserById", mock.Anything, memberId).Return(models.User{ID: memberId, Role: models.UserrolesMember, TeamId: teamId}, nil)
+				mockTeamRepo.On("GetTeamMember", mock.Anything, teamId, memberId).Return(true, models.TeamMember{TeamID: teamId, UserID: memberId, Role: models.UserrolesMember}, nil)
+				mockUserRepo.On("DeactivateUser", mock.Anything, mock.MatchedBy(func(data interfaces.DeactivateUserData) bool {
+					return data.ID == userId && len(data.TeamIDs) == 1 && data.TeamIDs[0] == teamId && data.TeamOwnerID == uuid.Nil && data.ProjectManagerID == managerId && data.TaskAssigneeID == memberId
+				})).Return(nil)
+			},
+			expectedStatus:      fiber.StatusOK,
+			expectedDeactivated: true,
+		},
+		{
+			name:        "Successfully hand over the teams owned by an admin",
+			user:        admin,
+			workTeamIds: []uuid.UUID{ownedTeamId},
+			report:      adminReport,
+			requestBody: map[string]interface{}{"teamOwnerId": otherAdminId, "taskAssigneeId": memberId},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository) {
+				mockTeamRepo.On("GetTeamByOwner", mock.Anything, userId).Return(true, models.Team{ID: ownedTeamId, OwnerID: userId}, nil)
+				// The caller administers the organization of the team
+				mockTeamRepo.On("GetTeamById", mock.Anything, ownedTeamId).Return(models.Team{ID: ownedTeamId, OwnerID: userId, OrganizationID: organizationId}, nil)
+				mockTeamRepo.On("GetTeamMember", mock.Anything, teamId, userId).Return(true, models.TeamMember{TeamID: teamId, UserID: userId, Role: models.UserrolesAdmin}, nil)
+				mockUserRepo.On("GetUserById", mock.Anything, otherAdminId).Return(models.User{ID: otherAdminId, Role: models.UserrolesAdmin, TeamId: teamId}, nil)
+				mockTeamRepo.On("GetTeamMember", mock.Anything, ownedTeamId, otherAdminId).Return(true, models.TeamMember{TeamID: ownedTeamId, UserID: otherAdminId, Role: models.UserrolesAdmin}, nil)
+				mockUserRepo.On("DeactivateUser", mock.Anything, mock.MatchedBy(func(data interfaces.DeactivateUserData) bool {
+					// Nothing to hand over to taskAssigneeId
+					return data.ID == userId && data.TeamOwnerID == otherAdminId && data.TaskAssigneeID == uuid.Nil
+				})).Return(nil)
+			},
+			expectedStatus:      fiber.StatusOK,
+			expectedDeactivated: true,
+		},
+		{
+			name:        "Users with work in a team the caller doesn't manage can't be deactivated",
+			user:        manager,
+			workTeamIds: []uuid.UUID{teamId, otherTeamId},
+			requestBody: map[string]interface{}{"projectManagerId": managerId, "taskAssigneeId": memberId},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository) {
+				mockTeamRepo.On("GetTeamById", mock.Anything, otherTeamId).Return(models.Team{ID: otherTeamId, OwnerID: otherAdminId, OrganizationID: uuid.New()}, nil)
+			},
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:        "Users with work in a team the caller doesn't manage aren't reported",
+			user:        manager,
+			workTeamIds: []uuid.UUID{otherTeamId},
+			query:       "?dryRun=true",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository) {
+				mockTeamRepo.On("GetTeamById", mock.Anything, otherTeamId).Return(models.Team{ID: otherTeamId, OwnerID: otherAdminId}, nil)
+			},
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:   "Users without work to hand over are deactivated right away",
+			user:   manager,
+			report: emptyReport,
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository) {
+				mockUserRepo.On("DeactivateUser", mock.Anything, mock.MatchedBy(func(data interfaces.DeactivateUserData) bool {
+					return data.ID == userId && data.ProjectManagerID == uuid.Nil && data.TaskAssigneeID == uuid.Nil
+				})).Return(nil)
+			},
+			expectedStatus:      fiber.StatusOK,
+			expectedDeactivated: true,
+		},
+		{
+			name:           "Already deactivated",
+			user:           deactivated,
+			report:         emptyReport,
+			setupMocks:     func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository) {},
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name:           "Active users can't be deleted for good",
+			user:           manager,
+			report:         emptyReport,
+			query:          "?permanent=true",
+			setupMocks:     func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository) {},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "Deactivated users that still manage projects can't be deleted for good",
+			user:           deactivated,
+			workTeamIds:    []uuid.UUID{teamId},
+			report:         managerReport,
+			query:          "?permanent=true",
+			setupMocks:     func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository) {},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:   "Successfully delete a deactivated user for good",
+			user:   deactivated,
+			report: emptyReport,
+			query:  "?permanent=true",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository) {
+				mockUserRepo.On("DeleteUser", mock.Anything, userId).Return(nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockUserRepo := mocks.NewMockUserRepository()
+			mockTeamRepo := mocks.NewMockTeamRepository()
+			mockOrganizationRepo := mocks.NewMockOrganizationRepository()
+			tt.setupMocks(mockUserRepo, mockTeamRepo)
+			mockTeamRepo.On("GetTeamByOwner", mock.Anything, adminId).Return(true, models.Team{ID: teamId, OwnerID: adminId}, nil)
+			mockOrganizationRepo.On("GetUserOrganizations", mock.Anything, adminId).Return([]models.Organization{{ID: organizationId}}, nil).Maybe()
+			mockUserRepo.On("GetUserById", mock.Anything, userId).Return(tt.user, nil)
+
+			workTeamIds := tt.workTeamIds
+			if workTeamIds == nil {
+				workTeamIds = []uuid.UUID{}
+			}
+
+			mockUserRepo.On("GetUserWorkTeamIds", mock.Anything, userId).Return(workTeamIds, nil)
+
+			if tt.expectedStatus != fiber.StatusForbidden {
+				mockUserRepo.On("GetUserOffboarding", mock.Anything, userId, workTeamIds).Return(tt.report, nil)
+			}
+
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, teamRepo: mockTeamRepo, organizationRepo: mockOrganizationRepo})
+
+			app.Delete("/users/:id", func(c *fiber.Ctx) error {
+				c.Locals("userRole", "Admin")
+				c.Locals("userId", adminId.String())
+				return handler.DeleteUser(c)
+			})
+
+			var req *http.Request
+
+			if tt.requestBody != nil {
+				body, _ := json.Marshal(tt.requestBody)
+				req = httptest.NewRequest(http.MethodDelete, "/users/"+userId.String()+tt.query, bytes.NewReader(body))
+				req.Header.Set("Content-Type", "application/json")
+			} else {
+				req = httptest.NewRequest(http.MethodDelete, "/users/"+userId.String()+tt.query, nil)
+			}
+
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.expectedStatus == fiber.StatusOK && tt.query != "?permanent=true" {
+				var result interfaces.UserOffboardingResponse
+				json.NewDecoder(resp.Body).Decode(&result)
+				assert.Equal(t, tt.expectedDeactivated, result.Deactivated)
+				assert.Len(t, result.Report.Projects, len(tt.report.Projects))
+			}
+
+			if !tt.expectedDeactivated {
+				mockUserRepo.AssertNotCalled(t, "DeactivateUser", mock.Anything, mock.Anything)
+			}
+
+			mockUserRepo.AssertExpectations(t)
+			mockTeamRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_DeleteUser_Self(t *testing.T) {
+	adminId := uuid.New()
+	teamId := uuid.New()
+
+	app := setupTestApp()
+
+	mockUserRepo := mocks.NewMockUserRepository()
+	mockTeamRepo := mocks.NewMockTeamRepository()
+	mockTeamRepo.On("GetTeamByOwner", mock.Anything, adminId).Return(true, models.Team{ID: teamId, OwnerID: adminId}, nil)
+	mockUserRepo.On("GetUserById", mock.Anything, adminId).Return(models.User{ID: adminId, Role: models.UserrolesAdmin, TeamId: teamId}, nil)
+	mockUserRepo.On("GetUserWorkTeamIds", mock.Anything, adminId).Return([]uuid.UUID{}, nil)
+	mockUserRepo.On("GetUserOffboarding", mock.Anything, adminId, []uuid.UUID{}).Return(interfaces.UserOffboardingReport{}, nil)
+
+	handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, teamRepo: mockTeamRepo})
+
+	app.Delete("/users/:id", func(c *fiber.Ctx) error {
+		c.Locals("userRole", "Admin")
+		c.Locals("userId", adminId.String())
+		return handler.DeleteUser(c)
+	})
+
+	resp, _ := app.Test(httptest.NewRequest(http.MethodDelete, "/users/"+adminId.String(), nil))
+
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUserRepo.AssertNotCalled(t, "DeactivateUser", mock.Anything, mock.Anything)
+}
+
+func TestHandler_ReactivateUser(t *testing.T) {
+	adminId := uuid.New()
+	teamId := uuid.New()
+	userId := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name           string
+		user           models.User
+		setupMocks     func(*mocks.MockUserRepository)
+		expectedStatus int
+	}{
+		{
+			name: "Successfully reactivate a deactivated user",
+			user: models.User{ID: userId, Role: models.UserrolesMember, TeamId: teamId, DeactivatedAt: &now},
+			setupMocks: func(mockUserRepo *mocks.MockUserRepository) {
+				mockUserRepo.On("ReactivateUser", mock.Anything, mock.MatchedBy(func(data interfaces.ReactivateUserData) bool {
+					return data.ID == userId
+				})).Return(models.User{ID: userId, Role: models.UserrolesMember, TeamId: teamId}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "The user isn't deactivated",
+			user:           models.User{ID: userId, Role: models.UserrolesMember, TeamId: teamId},
+			setupMocks:     func(mockUserRepo *mocks.MockUserRepository) {},
+			expectedStatus: fiber.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			mockUserRepo := mocks.NewMockUserRepository()
+			mockTeamRepo := mocks.NewMockTeamRepository()
+			tt.setupMocks(mockUserRepo)
+			mockTeamRepo.On("GetTeamByOwner", mock.Anything, adminId).Return(true, models.Team{ID: teamId, OwnerID: adminId}, nil)
+			mockUserRepo.On("GetUserById", mock.Anything, userId).Return(tt.user, nil)
+
+			handler := newTestHandler(handlerDeps{userRepo: mockUserRepo, teamRepo: mockTeamRepo})
+
+			app.Post("/users/:id/reactivate", func(c *fiber.Ctx) error {
+				c.Locals("userRole", "Admin")
+				c.Locals("userId", adminId.String())
+				return handler.ReactivateUser(c)
+			})
+
+			resp, _ := app.Test(httptest.NewRequest(http.MethodPost, "/users/"+userId.String()+"/reactivate", nil))
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.expectedStatus == fiber.StatusOK {
+				var result models.User
+				json.NewDecoder(resp.Body).Decode(&result)
+				assert.Nil(t, result.DeactivatedAt)
+			}
+
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package mailer_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/mailer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutboxMailer_Send(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "outbox")
+
+	m := mailer.NewOutboxMailer(dir, "no-reply@example.com")
+
+	err := m.Send(context.Background(), interfaces.Email{
+		To:      "user@example.com",
+		Subject: "Reset your password",
+		Body:    "Open the link below",
+	})
+	assert.NoError(t, err)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	data, err := os.ReadFile(files[0])
+	assert.NoError(t, err)
+
+	headers, body, found := strings.Cut(string(data), "\r\n\r\n")
+	assert.True(t, found)
+	assert.Contains(t, headers, "From: no-reply@example.com\r\n")
+	assert.Contains(t, headers, "To: user@example.com\r\n")
+	assert.Contains(t, headers, "Subject: Reset your password\r\n")
+	assert.Equal(t, "Open the link below", body)
+}
@@ -0,0 +1,47 @@
+package mailer_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/mailer"
+	"github.com/TobiasRV/challenge-fs-senior/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestQueueMailer_Send(t *testing.T) {
+	email := interfaces.Email{
+		To:      "user@example.com",
+		Subject: "Reset your password",
+		Body:    "Open the link below",
+	}
+
+	sent := make(chan interfaces.Email, 1)
+
+	next := mocks.NewMockMailer()
+	next.On("Send", mock.Anything, email).Run(func(args mock.Arguments) {
+		sent <- args.Get(1).(interfaces.Email)
+	}).Return(errors.New("smtp unavailable"))
+
+	m := mailer.NewQueueMailer(next, 1)
+
+	// Nothing sends until the worker runs, the email waits in the queue
+	assert.NoError(t, m.Send(context.Background(), email))
+	assert.ErrorIs(t, m.Send(context.Background(), email), mailer.ErrQueueFull)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go m.Run(ctx)
+
+	select {
+	case got := <-sent:
+		assert.Equal(t, email, got)
+	case <-time.After(time.Second):
+		t.Fatal("the queued email wasn't sent")
+	}
+}
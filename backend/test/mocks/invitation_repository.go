@@ -0,0 +1,53 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockInvitationRepository struct {
+	mock.Mock
+}
+
+func NewMockInvitationRepository() *MockInvitationRepository {
+	return &MockInvitationRepository{}
+}
+
+func (m *MockInvitationRepository) CreateInvitation(ctx context.Context, invitation models.Invitation) (models.Invitation, error) {
+	args := m.Called(ctx, invitation)
+	return args.Get(0).(models.Invitation), args.Error(1)
+}
+
+func (m *MockInvitationRepository) GetInvitationById(ctx context.Context, id uuid.UUID) (models.Invitation, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.Invitation), args.Error(1)
+}
+
+func (m *MockInvitationRepository) GetPendingInvitationByEmail(ctx context.Context, teamId uuid.UUID, email string) (models.Invitation, error) {
+	args := m.Called(ctx, teamId, email)
+	return args.Get(0).(models.Invitation), args.Error(1)
+}
+
+func (m *MockInvitationRepository) GetPendingInvitationsByTeam(ctx context.Context, teamId uuid.UUID) ([]models.Invitation, error) {
+	args := m.Called(ctx, teamId)
+	return args.Get(0).([]models.Invitation), args.Error(1)
+}
+
+func (m *MockInvitationRepository) RenewInvitation(ctx context.Context, data interfaces.RenewInvitationData) (models.Invitation, error) {
+	args := m.Called(ctx, data)
+	return args.Get(0).(models.Invitation), args.Error(1)
+}
+
+func (m *MockInvitationRepository) DeleteInvitation(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockInvitationRepository) AcceptInvitation(ctx context.Context, data interfaces.AcceptInvitationData) (models.User, bool, error) {
+	args := m.Called(ctx, data)
+	return args.Get(0).(models.User), args.Bool(1), args.Error(2)
+}
@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLoginAttemptRepository struct {
+	mock.Mock
+}
+
+func NewMockLoginAttemptRepository() *MockLoginAttemptRepository {
+	return &MockLoginAttemptRepository{}
+}
+
+func (m *MockLoginAttemptRepository) GetLoginAttempts(ctx context.Context, data interfaces.GetLoginAttemptsData) ([]models.LoginAttempt, error) {
+	args := m.Called(ctx, data)
+	return args.Get(0).([]models.LoginAttempt), args.Error(1)
+}
+
+func (m *MockLoginAttemptRepository) RecordFailedLogin(ctx context.Context, data interfaces.RecordFailedLoginData) ([]models.LoginAttempt, error) {
+	args := m.Called(ctx, data)
+	return args.Get(0).([]models.LoginAttempt), args.Error(1)
+}
+
+func (m *MockLoginAttemptRepository) ClearLoginAttempts(ctx context.Context, key interfaces.LoginAttemptKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
@@ -0,0 +1,21 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMailer struct {
+	mock.Mock
+}
+
+func NewMockMailer() *MockMailer {
+	return &MockMailer{}
+}
+
+func (m *MockMailer) Send(ctx context.Context, email interfaces.Email) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
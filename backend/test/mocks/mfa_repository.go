@@ -0,0 +1,63 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMfaRepository struct {
+	mock.Mock
+}
+
+func NewMockMfaRepository() *MockMfaRepository {
+	return &MockMfaRepository{}
+}
+
+func (m *MockMfaRepository) StartTotpEnrollment(ctx context.Context, totp models.UserTotp) (models.UserTotp, bool, error) {
+	args := m.Called(ctx, totp)
+	return args.Get(0).(models.UserTotp), args.Bool(1), args.Error(2)
+}
+
+func (m *MockMfaRepository) GetUserTotp(ctx context.Context, userId uuid.UUID) (models.UserTotp, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).(models.UserTotp), args.Error(1)
+}
+
+func (m *MockMfaRepository) EnableTotp(ctx context.Context, data interfaces.EnableTotpData) (bool, error) {
+	args := m.Called(ctx, data)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMfaRepository) UseTotpStep(ctx context.Context, data interfaces.UseTotpStepData) (bool, error) {
+	args := m.Called(ctx, data)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMfaRepository) UseRecoveryCode(ctx context.Context, data interfaces.UseRecoveryCodeData) (bool, error) {
+	args := m.Called(ctx, data)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMfaRepository) ReplaceRecoveryCodes(ctx context.Context, userId uuid.UUID, codes []models.MfaRecoveryCode) error {
+	args := m.Called(ctx, userId, codes)
+	return args.Error(0)
+}
+
+func (m *MockMfaRepository) DisableTotp(ctx context.Context, userId uuid.UUID) error {
+	args := m.Called(ctx, userId)
+	return args.Error(0)
+}
+
+func (m *MockMfaRepository) CreateMfaPendingToken(ctx context.Context, token models.MfaPendingToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockMfaRepository) UseMfaPendingToken(ctx context.Context, data interfaces.UseMfaPendingTokenData) (bool, error) {
+	args := m.Called(ctx, data)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOIDCProvider struct {
+	mock.Mock
+}
+
+func NewMockOIDCProvider() *MockOIDCProvider {
+	return &MockOIDCProvider{}
+}
+
+func (m *MockOIDCProvider) AuthorizationURL(ctx context.Context, data interfaces.OIDCAuthorizationData) (string, error) {
+	args := m.Called(ctx, data)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockOIDCProvider) Exchange(ctx context.Context, data interfaces.OIDCExchangeData) (interfaces.OIDCIdentity, error) {
+	args := m.Called(ctx, data)
+	return args.Get(0).(interfaces.OIDCIdentity), args.Error(1)
+}
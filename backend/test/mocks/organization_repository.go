@@ -0,0 +1,78 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOrganizationRepository struct {
+	mock.Mock
+}
+
+func NewMockOrganizationRepository() *MockOrganizationRepository {
+	return &MockOrganizationRepository{}
+}
+
+func (m *MockOrganizationRepository) CreateOrganization(ctx context.Context, data interfaces.CreateOrganizationData) (models.Organization, error) {
+	args := m.Called(ctx, data)
+	return args.Get(0).(models.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) GetOrganizationById(ctx context.Context, id uuid.UUID) (models.Organization, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) GetUserOrganizations(ctx context.Context, userId uuid.UUID) ([]models.Organization, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]models.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) UpdateOrganization(ctx context.Context, data interfaces.UpdateOrganizationData) (models.Organization, error) {
+	args := m.Called(ctx, data)
+	return args.Get(0).(models.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) GetOrganizationTeams(ctx context.Context, organizationId uuid.UUID) ([]models.Team, error) {
+	args := m.Called(ctx, organizationId)
+	return args.Get(0).([]models.Team), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) GetOrganizationUsers(ctx context.Context, organizationId uuid.UUID) ([]models.OrganizationUser, error) {
+	args := m.Called(ctx, organizationId)
+	return args.Get(0).([]models.OrganizationUser), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) IsOrganizationMember(ctx context.Context, organizationId uuid.UUID, userId uuid.UUID) (bool, error) {
+	args := m.Called(ctx, organizationId, userId)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) GetOrganizationReport(ctx context.Context, organizationId uuid.UUID) (interfaces.OrganizationReport, error) {
+	args := m.Called(ctx, organizationId)
+	return args.Get(0).(interfaces.OrganizationReport), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) GetOrganizationAdmin(ctx context.Context, organizationId uuid.UUID, userId uuid.UUID) (bool, models.OrganizationAdmin, error) {
+	args := m.Called(ctx, organizationId, userId)
+	return args.Bool(0), args.Get(1).(models.OrganizationAdmin), args.Error(2)
+}
+
+func (m *MockOrganizationRepository) GetOrganizationAdmins(ctx context.Context, organizationId uuid.UUID) ([]models.OrganizationAdmin, error) {
+	args := m.Called(ctx, organizationId)
+	return args.Get(0).([]models.OrganizationAdmin), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) AddOrganizationAdmin(ctx context.Context, admin models.OrganizationAdmin) (models.OrganizationAdmin, error) {
+	args := m.Called(ctx, admin)
+	return args.Get(0).(models.OrganizationAdmin), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) RemoveOrganizationAdmin(ctx context.Context, organizationId uuid.UUID, userId uuid.UUID) error {
+	args := m.Called(ctx, organizationId, userId)
+	return args.Error(0)
+}
@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPasswordResetTokenRepository struct {
+	mock.Mock
+}
+
+func NewMockPasswordResetTokenRepository() *MockPasswordResetTokenRepository {
+	return &MockPasswordResetTokenRepository{}
+}
+
+func (m *MockPasswordResetTokenRepository) CreatePasswordResetToken(ctx context.Context, token models.PasswordResetToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockPasswordResetTokenRepository) GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (models.PasswordResetToken, error) {
+	args := m.Called(ctx, tokenHash)
+	return args.Get(0).(models.PasswordResetToken), args.Error(1)
+}
+
+func (m *MockPasswordResetTokenRepository) ResetPassword(ctx context.Context, data interfaces.ResetPasswordData) (bool, error) {
+	args := m.Called(ctx, data)
+	return args.Bool(0), args.Error(1)
+}
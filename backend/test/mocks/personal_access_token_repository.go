@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPersonalAccessTokenRepository struct {
+	mock.Mock
+}
+
+func NewMockPersonalAccessTokenRepository() *MockPersonalAccessTokenRepository {
+	return &MockPersonalAccessTokenRepository{}
+}
+
+func (m *MockPersonalAccessTokenRepository) CreatePersonalAccessToken(ctx context.Context, token models.PersonalAccessToken) (models.PersonalAccessToken, error) {
+	args := m.Called(ctx, token)
+	return args.Get(0).(models.PersonalAccessToken), args.Error(1)
+}
+
+func (m *MockPersonalAccessTokenRepository) GetPersonalAccessTokenByHash(ctx context.Context, tokenHash string) (models.PersonalAccessTokenWithUser, error) {
+	args := m.Called(ctx, tokenHash)
+	return args.Get(0).(models.PersonalAccessTokenWithUser), args.Error(1)
+}
+
+func (m *MockPersonalAccessTokenRepository) GetPersonalAccessTokensByUserId(ctx context.Context, userId uuid.UUID) ([]models.PersonalAccessToken, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]models.PersonalAccessToken), args.Error(1)
+}
+
+func (m *MockPersonalAccessTokenRepository) UpdatePersonalAccessTokenLastUsed(ctx context.Context, data interfaces.UpdatePersonalAccessTokenLastUsedData) error {
+	args := m.Called(ctx, data)
+	return args.Error(0)
+}
+
+func (m *MockPersonalAccessTokenRepository) DeletePersonalAccessToken(ctx context.Context, data interfaces.DeletePersonalAccessTokenData) (bool, error) {
+	args := m.Called(ctx, data)
+	return args.Bool(0), args.Error(1)
+}
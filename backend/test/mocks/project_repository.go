@@ -47,3 +47,23 @@ func (m *MockProjectRepository) DeleteProject(ctx context.Context, id uuid.UUID)
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (m *MockProjectRepository) GetProjectMembers(ctx context.Context, projectId uuid.UUID) ([]models.ProjectMember, error) {
+	args := m.Called(ctx, projectId)
+	return args.Get(0).([]models.ProjectMember), args.Error(1)
+}
+
+func (m *MockProjectRepository) GetProjectMember(ctx context.Context, projectId uuid.UUID, userId uuid.UUID) (bool, models.ProjectMember, error) {
+	args := m.Called(ctx, projectId, userId)
+	return args.Bool(0), args.Get(1).(models.ProjectMember), args.Error(2)
+}
+
+func (m *MockProjectRepository) AddProjectMember(ctx context.Context, data models.ProjectMember) (models.ProjectMember, error) {
+	args := m.Called(ctx, data)
+	return args.Get(0).(models.ProjectMember), args.Error(1)
+}
+
+func (m *MockProjectRepository) RemoveProjectMember(ctx context.Context, data interfaces.RemoveProjectMemberData) error {
+	args := m.Called(ctx, data)
+	return args.Error(0)
+}
@@ -3,6 +3,7 @@ package mocks
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
 	"github.com/google/uuid"
@@ -15,9 +16,18 @@ type IQueries interface {
 	GetUserById(ctx context.Context, id uuid.UUID) (database.User, error)
 	UpdateUser(ctx context.Context, arg database.UpdateUserParams) (database.User, error)
 	DeleteUser(ctx context.Context, id uuid.UUID) error
+	UpdateUserPassword(ctx context.Context, arg database.UpdateUserPasswordParams) error
+	UpdateUserRole(ctx context.Context, arg database.UpdateUserRoleParams) (database.User, error)
+	AssignUserRole(ctx context.Context, arg database.AssignUserRoleParams) (database.User, error)
+	AdminExists(ctx context.Context) (bool, error)
+	LockAdminBootstrap(ctx context.Context) error
+	DeactivateUser(ctx context.Context, arg database.DeactivateUserParams) error
+	ReactivateUser(ctx context.Context, arg database.ReactivateUserParams) (database.User, error)
 
 	CreateTeam(ctx context.Context, arg database.CreateTeamParams) (database.Team, error)
 	GetTeamByOwner(ctx context.Context, ownerID uuid.UUID) (database.Team, error)
+	GetTeamById(ctx context.Context, id uuid.UUID) (database.Team, error)
+	UpdateTeamRequireMfa(ctx context.Context, arg database.UpdateTeamRequireMfaParams) (database.Team, error)
 
 	CreateProject(ctx context.Context, arg database.CreateProjectParams) (database.Project, error)
 	GetProjectById(ctx context.Context, id uuid.UUID) (database.Project, error)
@@ -34,6 +44,64 @@ type IQueries interface {
 	GetRefreshTokenByToken(ctx context.Context, token string) (database.GetRefreshTokenByTokenRow, error)
 	DeleteRefreshTokensByUserId(ctx context.Context, userid uuid.UUID) error
 	UpdaterefreshToken(ctx context.Context, arg database.UpdaterefreshTokenParams) error
+	MarkRefreshTokenUsed(ctx context.Context, arg database.MarkRefreshTokenUsedParams) (int64, error)
+	RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) error
+
+	CreateSession(ctx context.Context, arg database.CreateSessionParams) (database.Session, error)
+	GetSessionById(ctx context.Context, id uuid.UUID) (database.Session, error)
+	GetSessionsByUserId(ctx context.Context, userID uuid.UUID) ([]database.Session, error)
+	UpdateSessionLastUsed(ctx context.Context, arg database.UpdateSessionLastUsedParams) error
+	DeleteSession(ctx context.Context, id uuid.UUID) error
+	DeleteSessionsByUserId(ctx context.Context, userID uuid.UUID) error
+	DeleteOtherSessionsByUserId(ctx context.Context, arg database.DeleteOtherSessionsByUserIdParams) error
+
+	CreatePasswordResetToken(ctx context.Context, arg database.CreatePasswordResetTokenParams) error
+	GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (database.PasswordResetToken, error)
+	MarkPasswordResetTokenUsed(ctx context.Context, arg database.MarkPasswordResetTokenUsedParams) (int64, error)
+	DeletePasswordResetTokensByUserId(ctx context.Context, userID uuid.UUID) error
+
+	CreateInvitation(ctx context.Context, arg database.CreateInvitationParams) (database.Invitation, error)
+	GetInvitationById(ctx context.Context, id uuid.UUID) (database.Invitation, error)
+	GetPendingInvitationByEmail(ctx context.Context, arg database.GetPendingInvitationByEmailParams) (database.Invitation, error)
+	GetPendingInvitationsByTeam(ctx context.Context, teamID uuid.UUID) ([]database.Invitation, error)
+	RenewInvitation(ctx context.Context, arg database.RenewInvitationParams) (database.Invitation, error)
+	MarkInvitationAccepted(ctx context.Context, arg database.MarkInvitationAcceptedParams) (int64, error)
+	DeleteInvitation(ctx context.Context, id uuid.UUID) error
+
+	StartUserTotpEnrollment(ctx context.Context, arg database.StartUserTotpEnrollmentParams) (database.UserTotp, error)
+	GetUserTotp(ctx context.Context, userID uuid.UUID) (database.UserTotp, error)
+	EnableUserTotp(ctx context.Context, arg database.EnableUserTotpParams) (int64, error)
+	UseUserTotpStep(ctx context.Context, arg database.UseUserTotpStepParams) (int64, error)
+	DeleteUserTotp(ctx context.Context, userID uuid.UUID) error
+	CreateRecoveryCode(ctx context.Context, arg database.CreateRecoveryCodeParams) error
+	UseRecoveryCode(ctx context.Context, arg database.UseRecoveryCodeParams) (int64, error)
+	DeleteRecoveryCodesByUserId(ctx context.Context, userID uuid.UUID) error
+	DeleteSessionsWithoutMfaByTeam(ctx context.Context, teamID uuid.NullUUID) error
+
+	GetLoginAttempts(ctx context.Context, arg database.GetLoginAttemptsParams) ([]database.LoginAttempt, error)
+	RecordFailedLoginAttempt(ctx context.Context, arg database.RecordFailedLoginAttemptParams) (database.LoginAttempt, error)
+	DeleteLoginAttempt(ctx context.Context, arg database.DeleteLoginAttemptParams) error
+	CreatePersonalAccessToken(ctx context.Context, arg database.CreatePersonalAccessTokenParams) (database.PersonalAccessToken, error)
+	GetPersonalAccessTokenByHash(ctx context.Context, tokenHash string) (database.GetPersonalAccessTokenByHashRow, error)
+	GetPersonalAccessTokensByUserId(ctx context.Context, userID uuid.UUID) ([]database.PersonalAccessToken, error)
+	UpdatePersonalAccessTokenLastUsed(ctx context.Context, arg database.UpdatePersonalAccessTokenLastUsedParams) error
+	DeletePersonalAccessToken(ctx context.Context, arg database.DeletePersonalAccessTokenParams) (int64, error)
+	CreateUserIdentity(ctx context.Context, arg database.CreateUserIdentityParams) error
+	GetUserIdentity(ctx context.Context, arg database.GetUserIdentityParams) (database.UserIdentity, error)
+	GetRevokedSessionIds(ctx context.Context, revokedAt time.Time) ([]uuid.UUID, error)
+	DeleteRevokedSessionsBefore(ctx context.Context, revokedAt time.Time) error
+	CreateRole(ctx context.Context, arg database.CreateRoleParams) (database.Role, error)
+	GetRoleById(ctx context.Context, id uuid.UUID) (database.Role, error)
+	GetRoleByName(ctx context.Context, arg database.GetRoleByNameParams) (database.Role, error)
+	GetRolesByTeam(ctx context.Context, teamID uuid.NullUUID) ([]database.Role, error)
+	UpdateRole(ctx context.Context, arg database.UpdateRoleParams) (database.Role, error)
+	DeleteRole(ctx context.Context, id uuid.UUID) error
+	AddProjectMember(ctx context.Context, arg database.AddProjectMemberParams) (database.ProjectMember, error)
+	GetProjectMember(ctx context.Context, arg database.GetProjectMemberParams) (database.ProjectMember, error)
+	GetProjectMembers(ctx context.Context, projectID uuid.UUID) ([]database.GetProjectMembersRow, error)
+	DeleteProjectMember(ctx context.Context, arg database.DeleteProjectMemberParams) error
+	UnassignProjectMemberTasks(ctx context.Context, arg database.UnassignProjectMemberTasksParams) error
+	ReassignProjectManager(ctx context.Context, arg database.ReassignProjectManagerParams) error
 }
 
 type MockQueries struct {
@@ -71,6 +139,41 @@ func (m *MockQueries) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	return args.Error(0)
 }
 
+func (m *MockQueries) DeactivateUser(ctx context.Context, arg database.DeactivateUserParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockQueries) ReactivateUser(ctx context.Context, arg database.ReactivateUserParams) (database.User, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(database.User), args.Error(1)
+}
+
+func (m *MockQueries) UpdateUserPassword(ctx context.Context, arg database.UpdateUserPasswordParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockQueries) UpdateUserRole(ctx context.Context, arg database.UpdateUserRoleParams) (database.User, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(database.User), args.Error(1)
+}
+
+func (m *MockQueries) AssignUserRole(ctx context.Context, arg database.AssignUserRoleParams) (database.User, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(database.User), args.Error(1)
+}
+
+func (m *MockQueries) AdminExists(ctx context.Context) (bool, error) {
+	args := m.Called(ctx)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockQueries) LockAdminBootstrap(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
 func (m *MockQueries) CreateTeam(ctx context.Context, arg database.CreateTeamParams) (database.Team, error) {
 	args := m.Called(ctx, arg)
 	return args.Get(0).(database.Team), args.Error(1)
@@ -81,6 +184,16 @@ func (m *MockQueries) GetTeamByOwner(ctx context.Context, ownerID uuid.UUID) (da
 	return args.Get(0).(database.Team), args.Error(1)
 }
 
+func (m *MockQueries) GetTeamById(ctx context.Context, id uuid.UUID) (database.Team, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(database.Team), args.Error(1)
+}
+
+func (m *MockQueries) UpdateTeamRequireMfa(ctx context.Context, arg database.UpdateTeamRequireMfaParams) (database.Team, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(database.Team), args.Error(1)
+}
+
 func (m *MockQueries) CreateProject(ctx context.Context, arg database.CreateProjectParams) (database.Project, error) {
 	args := m.Called(ctx, arg)
 	return args.Get(0).(database.Project), args.Error(1)
@@ -146,6 +259,271 @@ func (m *MockQueries) UpdaterefreshToken(ctx context.Context, arg database.Updat
 	return args.Error(0)
 }
 
+func (m *MockQueries) MarkRefreshTokenUsed(ctx context.Context, arg database.MarkRefreshTokenUsedParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockQueries) RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) error {
+	args := m.Called(ctx, familyID)
+	return args.Error(0)
+}
+
+func (m *MockQueries) CreateSession(ctx context.Context, arg database.CreateSessionParams) (database.Session, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(database.Session), args.Error(1)
+}
+
+func (m *MockQueries) GetSessionById(ctx context.Context, id uuid.UUID) (database.Session, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(database.Session), args.Error(1)
+}
+
+func (m *MockQueries) GetSessionsByUserId(ctx context.Context, userID uuid.UUID) ([]database.Session, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]database.Session), args.Error(1)
+}
+
+func (m *MockQueries) UpdateSessionLastUsed(ctx context.Context, arg database.UpdateSessionLastUsedParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockQueries) DeleteSession(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockQueries) DeleteSessionsByUserId(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockQueries) DeleteOtherSessionsByUserId(ctx context.Context, arg database.DeleteOtherSessionsByUserIdParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockQueries) CreatePasswordResetToken(ctx context.Context, arg database.CreatePasswordResetTokenParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockQueries) GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (database.PasswordResetToken, error) {
+	args := m.Called(ctx, tokenHash)
+	return args.Get(0).(database.PasswordResetToken), args.Error(1)
+}
+
+func (m *MockQueries) MarkPasswordResetTokenUsed(ctx context.Context, arg database.MarkPasswordResetTokenUsedParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockQueries) DeletePasswordResetTokensByUserId(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockQueries) CreateInvitation(ctx context.Context, arg database.CreateInvitationParams) (database.Invitation, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(database.Invitation), args.Error(1)
+}
+
+func (m *MockQueries) GetInvitationById(ctx context.Context, id uuid.UUID) (database.Invitation, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(database.Invitation), args.Error(1)
+}
+
+func (m *MockQueries) GetPendingInvitationByEmail(ctx context.Context, arg database.GetPendingInvitationByEmailParams) (database.Invitation, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(database.Invitation), args.Error(1)
+}
+
+func (m *MockQueries) GetPendingInvitationsByTeam(ctx context.Context, teamID uuid.UUID) ([]database.Invitation, error) {
+	args := m.Called(ctx, teamID)
+	return args.Get(0).([]database.Invitation), args.Error(1)
+}
+
+func (m *MockQueries) RenewInvitation(ctx context.Context, arg database.RenewInvitationParams) (database.Invitation, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(database.Invitation), args.Error(1)
+}
+
+func (m *MockQueries) MarkInvitationAccepted(ctx context.Context, arg database.MarkInvitationAcceptedParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockQueries) DeleteInvitation(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockQueries) StartUserTotpEnrollment(ctx context.Context, arg database.StartUserTotpEnrollmentParams) (database.UserTotp, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(database.UserTotp), args.Error(1)
+}
+
+func (m *MockQueries) GetUserTotp(ctx context.Context, userID uuid.UUID) (database.UserTotp, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(database.UserTotp), args.Error(1)
+}
+
+func (m *MockQueries) EnableUserTotp(ctx context.Context, arg database.EnableUserTotpParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockQueries) UseUserTotpStep(ctx context.Context, arg database.UseUserTotpStepParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockQueries) DeleteUserTotp(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockQueries) CreateRecoveryCode(ctx context.Context, arg database.CreateRecoveryCodeParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockQueries) UseRecoveryCode(ctx context.Context, arg database.UseRecoveryCodeParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockQueries) DeleteRecoveryCodesByUserId(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockQueries) DeleteSessionsWithoutMfaByTeam(ctx context.Context, teamID uuid.NullUUID) error {
+	args := m.Called(ctx, teamID)
+	return args.Error(0)
+}
+
+func (m *MockQueries) GetLoginAttempts(ctx context.Context, arg database.GetLoginAttemptsParams) ([]database.LoginAttempt, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]database.LoginAttempt), args.Error(1)
+}
+
+func (m *MockQueries) RecordFailedLoginAttempt(ctx context.Context, arg database.RecordFailedLoginAttemptParams) (database.LoginAttempt, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(database.LoginAttempt), args.Error(1)
+}
+
+func (m *MockQueries) DeleteLoginAttempt(ctx context.Context, arg database.DeleteLoginAttemptParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockQueries) CreatePersonalAccessToken(ctx context.Context, arg database.CreatePersonalAccessTokenParams) (database.PersonalAccessToken, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(database.PersonalAccessToken), args.Error(1)
+}
+
+func (m *MockQueries) GetPersonalAccessTokenByHash(ctx context.Context, tokenHash string) (database.GetPersonalAccessTokenByHashRow, error) {
+	args := m.Called(ctx, tokenHash)
+	return args.Get(0).(database.GetPersonalAccessTokenByHashRow), args.Error(1)
+}
+
+func (m *MockQueries) GetPersonalAccessTokensByUserId(ctx context.Context, userID uuid.UUID) ([]database.PersonalAccessToken, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]database.PersonalAccessToken), args.Error(1)
+}
+
+func (m *MockQueries) UpdatePersonalAccessTokenLastUsed(ctx context.Context, arg database.UpdatePersonalAccessTokenLastUsedParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockQueries) DeletePersonalAccessToken(ctx context.Context, arg database.DeletePersonalAccessTokenParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockQueries) CreateUserIdentity(ctx context.Context, arg database.CreateUserIdentityParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockQueries) GetUserIdentity(ctx context.Context, arg database.GetUserIdentityParams) (database.UserIdentity, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(database.UserIdentity), args.Error(1)
+}
+
+func (m *MockQueries) GetRevokedSessionIds(ctx context.Context, revokedAt time.Time) ([]uuid.UUID, error) {
+	args := m.Called(ctx, revokedAt)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockQueries) DeleteRevokedSessionsBefore(ctx context.Context, revokedAt time.Time) error {
+	args := m.Called(ctx, revokedAt)
+	return args.Error(0)
+}
+
+func (m *MockQueries) CreateRole(ctx context.Context, arg database.CreateRoleParams) (database.Role, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(database.Role), args.Error(1)
+}
+
+func (m *MockQueries) GetRoleById(ctx context.Context, id uuid.UUID) (database.Role, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(database.Role), args.Error(1)
+}
+
+func (m *MockQueries) GetRoleByName(ctx context.Context, arg database.GetRoleByNameParams) (database.Role, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(database.Role), args.Error(1)
+}
+
+func (m *MockQueries) GetRolesByTeam(ctx context.Context, teamID uuid.NullUUID) ([]database.Role, error) {
+	args := m.Called(ctx, teamID)
+	return args.Get(0).([]database.Role), args.Error(1)
+}
+
+func (m *MockQueries) UpdateRole(ctx context.Context, arg database.UpdateRoleParams) (database.Role, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(database.Role), args.Error(1)
+}
+
+func (m *MockQueries) DeleteRole(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockQueries) AddProjectMember(ctx context.Context, arg database.AddProjectMemberParams) (database.ProjectMember, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(database.ProjectMember), args.Error(1)
+}
+
+func (m *MockQueries) GetProjectMember(ctx context.Context, arg database.GetProjectMemberParams) (database.ProjectMember, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(database.ProjectMember), args.Error(1)
+}
+
+func (m *MockQueries) GetProjectMembers(ctx context.Context, projectID uuid.UUID) ([]database.GetProjectMembersRow, error) {
+	args := m.Called(ctx, projectID)
+	return args.Get(0).([]database.GetProjectMembersRow), args.Error(1)
+}
+
+func (m *MockQueries) DeleteProjectMember(ctx context.Context, arg database.DeleteProjectMemberParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockQueries) UnassignProjectMemberTasks(ctx context.Context, arg database.UnassignProjectMemberTasksParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockQueries) ReassignProjectManager(ctx context.Context, arg database.ReassignProjectManagerParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
 type MockDB struct {
 	mock.Mock
 }
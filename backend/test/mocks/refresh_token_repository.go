@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -29,3 +30,13 @@ func (m *MockRefreshTokenRepository) DeleteRefreshTokensByUserId(ctx context.Con
 	args := m.Called(ctx, userId)
 	return args.Error(0)
 }
+
+func (m *MockRefreshTokenRepository) RotateRefreshToken(ctx context.Context, usedTokenId uuid.UUID, newToken models.RefreshToken) (bool, error) {
+	args := m.Called(ctx, usedTokenId, newToken)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) RevokeRefreshTokenFamily(ctx context.Context, familyId uuid.UUID) error {
+	args := m.Called(ctx, familyId)
+	return args.Error(0)
+}
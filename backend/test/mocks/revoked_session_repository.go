@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRevokedSessionRepository struct {
+	mock.Mock
+}
+
+func NewMockRevokedSessionRepository() *MockRevokedSessionRepository {
+	return &MockRevokedSessionRepository{}
+}
+
+func (m *MockRevokedSessionRepository) GetRevokedSessionIds(ctx context.Context, since time.Time) ([]uuid.UUID, error) {
+	args := m.Called(ctx, since)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockRevokedSessionRepository) DeleteRevokedSessionsBefore(ctx context.Context, before time.Time) error {
+	args := m.Called(ctx, before)
+	return args.Error(0)
+}
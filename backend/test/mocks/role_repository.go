@@ -0,0 +1,53 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRoleRepository struct {
+	mock.Mock
+}
+
+func NewMockRoleRepository() *MockRoleRepository {
+	return &MockRoleRepository{}
+}
+
+func (m *MockRoleRepository) CreateRole(ctx context.Context, role models.Role) (models.Role, error) {
+	args := m.Called(ctx, role)
+	return args.Get(0).(models.Role), args.Error(1)
+}
+
+func (m *MockRoleRepository) GetRoleById(ctx context.Context, id uuid.UUID) (models.Role, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.Role), args.Error(1)
+}
+
+func (m *MockRoleRepository) GetCustomRoles(ctx context.Context) ([]models.Role, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]models.Role), args.Error(1)
+}
+
+func (m *MockRoleRepository) GetRoleByName(ctx context.Context, data interfaces.GetRoleByNameData) (bool, models.Role, error) {
+	args := m.Called(ctx, data)
+	return args.Bool(0), args.Get(1).(models.Role), args.Error(2)
+}
+
+func (m *MockRoleRepository) GetRolesByTeam(ctx context.Context, teamId uuid.UUID) ([]models.Role, error) {
+	args := m.Called(ctx, teamId)
+	return args.Get(0).([]models.Role), args.Error(1)
+}
+
+func (m *MockRoleRepository) UpdateRole(ctx context.Context, data interfaces.UpdateRoleData) (models.Role, error) {
+	args := m.Called(ctx, data)
+	return args.Get(0).(models.Role), args.Error(1)
+}
+
+func (m *MockRoleRepository) DeleteRole(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
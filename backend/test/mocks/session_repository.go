@@ -0,0 +1,53 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSessionRepository struct {
+	mock.Mock
+}
+
+func NewMockSessionRepository() *MockSessionRepository {
+	return &MockSessionRepository{}
+}
+
+func (m *MockSessionRepository) CreateSession(ctx context.Context, session models.Session) (models.Session, error) {
+	args := m.Called(ctx, session)
+	return args.Get(0).(models.Session), args.Error(1)
+}
+
+func (m *MockSessionRepository) GetSessionById(ctx context.Context, id uuid.UUID) (models.Session, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.Session), args.Error(1)
+}
+
+func (m *MockSessionRepository) GetSessionsByUserId(ctx context.Context, userId uuid.UUID) ([]models.Session, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]models.Session), args.Error(1)
+}
+
+func (m *MockSessionRepository) UpdateSessionLastUsed(ctx context.Context, data interfaces.UpdateSessionLastUsedData) error {
+	args := m.Called(ctx, data)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepository) UpdateSessionTeam(ctx context.Context, data interfaces.UpdateSessionTeamData) error {
+	args := m.Called(ctx, data)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepository) DeleteSession(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepository) DeleteSessionsByUserId(ctx context.Context, userId uuid.UUID) error {
+	args := m.Called(ctx, userId)
+	return args.Error(0)
+}
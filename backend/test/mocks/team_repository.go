@@ -3,6 +3,7 @@ package mocks
 import (
 	"context"
 
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
 	"github.com/TobiasRV/challenge-fs-senior/internals/models"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -25,3 +26,73 @@ func (m *MockTeamRepository) GetTeamByOwner(ctx context.Context, ownerId uuid.UU
 	args := m.Called(ctx, ownerId)
 	return args.Bool(0), args.Get(1).(models.Team), args.Error(2)
 }
+
+func (m *MockTeamRepository) GetTeamById(ctx context.Context, id uuid.UUID) (models.Team, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.Team), args.Error(1)
+}
+
+func (m *MockTeamRepository) SetTeamRequireMfa(ctx context.Context, data interfaces.SetTeamRequireMfaData) (models.Team, error) {
+	args := m.Called(ctx, data)
+	return args.Get(0).(models.Team), args.Error(1)
+}
+
+func (m *MockTeamRepository) GetTeams(ctx context.Context, filters models.TeamFilter) ([]models.Team, error) {
+	args := m.Called(ctx, filters)
+	return args.Get(0).([]models.Team), args.Error(1)
+}
+
+func (m *MockTeamRepository) UpdateTeamName(ctx context.Context, data interfaces.UpdateTeamNameData) (models.Team, error) {
+	args := m.Called(ctx, data)
+	return args.Get(0).(models.Team), args.Error(1)
+}
+
+func (m *MockTeamRepository) TransferTeamOwnership(ctx context.Context, data interfaces.TransferTeamOwnershipData) (models.Team, error) {
+	args := m.Called(ctx, data)
+	return args.Get(0).(models.Team), args.Error(1)
+}
+
+func (m *MockTeamRepository) GetTeamDeletionReport(ctx context.Context, teamId uuid.UUID) (interfaces.TeamDeletionReport, error) {
+	args := m.Called(ctx, teamId)
+	return args.Get(0).(interfaces.TeamDeletionReport), args.Error(1)
+}
+
+func (m *MockTeamRepository) DeleteTeam(ctx context.Context, data interfaces.DeleteTeamData) error {
+	args := m.Called(ctx, data)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) GetTeamMember(ctx context.Context, teamId uuid.UUID, userId uuid.UUID) (bool, models.TeamMember, error) {
+	args := m.Called(ctx, teamId, userId)
+	return args.Bool(0), args.Get(1).(models.TeamMember), args.Error(2)
+}
+
+func (m *MockTeamRepository) GetTeamMembers(ctx context.Context, teamId uuid.UUID) ([]models.TeamMember, error) {
+	args := m.Called(ctx, teamId)
+	return args.Get(0).([]models.TeamMember), args.Error(1)
+}
+
+func (m *MockTeamRepository) GetUserTeams(ctx context.Context, userId uuid.UUID) ([]models.TeamMember, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]models.TeamMember), args.Error(1)
+}
+
+func (m *MockTeamRepository) AddTeamMember(ctx context.Context, member models.TeamMember) (models.TeamMember, error) {
+	args := m.Called(ctx, member)
+	return args.Get(0).(models.TeamMember), args.Error(1)
+}
+
+func (m *MockTeamRepository) UpdateTeamMemberRole(ctx context.Context, data interfaces.UpdateTeamMemberRoleData) (models.TeamMember, error) {
+	args := m.Called(ctx, data)
+	return args.Get(0).(models.TeamMember), args.Error(1)
+}
+
+func (m *MockTeamRepository) CountProjectsOnlyOwnedBy(ctx context.Context, teamId uuid.UUID, userId uuid.UUID) (int64, error) {
+	args := m.Called(ctx, teamId, userId)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTeamRepository) RemoveTeamMember(ctx context.Context, data interfaces.RemoveTeamMemberData) error {
+	args := m.Called(ctx, data)
+	return args.Error(0)
+}
@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserIdentityRepository struct {
+	mock.Mock
+}
+
+func NewMockUserIdentityRepository() *MockUserIdentityRepository {
+	return &MockUserIdentityRepository{}
+}
+
+func (m *MockUserIdentityRepository) GetUserIdentity(ctx context.Context, data interfaces.GetUserIdentityData) (models.UserIdentity, error) {
+	args := m.Called(ctx, data)
+	return args.Get(0).(models.UserIdentity), args.Error(1)
+}
+
+func (m *MockUserIdentityRepository) CreateUserIdentity(ctx context.Context, identity models.UserIdentity) error {
+	args := m.Called(ctx, identity)
+	return args.Error(0)
+}
+
+func (m *MockUserIdentityRepository) ProvisionUser(ctx context.Context, data interfaces.ProvisionUserData) (models.User, error) {
+	args := m.Called(ctx, data)
+	return args.Get(0).(models.User), args.Error(1)
+}
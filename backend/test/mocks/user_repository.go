@@ -22,6 +22,16 @@ func (m *MockUserRepository) CreateUser(ctx context.Context, user models.User) (
 	return args.Get(0).(models.User), args.Error(1)
 }
 
+func (m *MockUserRepository) AdminExists(ctx context.Context) (bool, error) {
+	args := m.Called(ctx)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserRepository) CreateFirstAdmin(ctx context.Context, user models.User) (bool, models.User, error) {
+	args := m.Called(ctx, user)
+	return args.Bool(0), args.Get(1).(models.User), args.Error(2)
+}
+
 func (m *MockUserRepository) GetUserByEmail(ctx context.Context, email string) (models.User, error) {
 	args := m.Called(ctx, email)
 	return args.Get(0).(models.User), args.Error(1)
@@ -42,6 +52,41 @@ func (m *MockUserRepository) UpdateUser(ctx context.Context, data interfaces.Upd
 	return args.Get(0).(models.User), args.Error(1)
 }
 
+func (m *MockUserRepository) UpdateUserRole(ctx context.Context, data interfaces.UpdateUserRoleData) (models.User, error) {
+	args := m.Called(ctx, data)
+	return args.Get(0).(models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) AssignUserRole(ctx context.Context, data interfaces.AssignUserRoleData) (models.User, error) {
+	args := m.Called(ctx, data)
+	return args.Get(0).(models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) ChangeUserPassword(ctx context.Context, data interfaces.ChangeUserPasswordData) error {
+	args := m.Called(ctx, data)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetUserWorkTeamIds(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUserOffboarding(ctx context.Context, id uuid.UUID, teamIds []uuid.UUID) (interfaces.UserOffboardingReport, error) {
+	args := m.Called(ctx, id, teamIds)
+	return args.Get(0).(interfaces.UserOffboardingReport), args.Error(1)
+}
+
+func (m *MockUserRepository) DeactivateUser(ctx context.Context, data interfaces.DeactivateUserData) error {
+	args := m.Called(ctx, data)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ReactivateUser(ctx context.Context, data interfaces.ReactivateUserData) (models.User, error) {
+	args := m.Called(ctx, data)
+	return args.Get(0).(models.User), args.Error(1)
+}
+
 func (m *MockUserRepository) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
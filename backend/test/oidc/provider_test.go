@@ -0,0 +1,172 @@
+package oidc_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/oidc"
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
+	"github.com/TobiasRV/challenge-fs-senior/test/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testClientId     = "challenge"
+	testClientSecret = "challenge-secret"
+	testRedirectURL  = "http://localhost:3000/sso/callback"
+)
+
+func TestProvider_AuthorizationURL(t *testing.T) {
+	idp := mocks.NewMockIdentityProvider(testClientId, testClientSecret)
+	defer idp.Close()
+
+	provider := oidc.NewProvider(oidc.Config{
+		IssuerURL:    idp.Issuer(),
+		ClientID:     testClientId,
+		ClientSecret: testClientSecret,
+		RedirectURL:  testRedirectURL,
+		Scopes:       []string{"openid", "email"},
+	})
+
+	authorizationURL, err := provider.AuthorizationURL(context.Background(), interfaces.OIDCAuthorizationData{
+		State:         "state",
+		Nonce:         "nonce",
+		CodeChallenge: "challenge",
+	})
+	assert.NoError(t, err)
+
+	parsed, err := url.Parse(authorizationURL)
+	assert.NoError(t, err)
+	assert.Equal(t, idp.Issuer()+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+
+	query := parsed.Query()
+	assert.Equal(t, "code", query.Get("response_type"))
+	assert.Equal(t, testClientId, query.Get("client_id"))
+	assert.Equal(t, testRedirectURL, query.Get("redirect_uri"))
+	assert.Equal(t, "openid email", query.Get("scope"))
+	assert.Equal(t, "state", query.Get("state"))
+	assert.Equal(t, "nonce", query.Get("nonce"))
+	assert.Equal(t, "challenge", query.Get("code_challenge"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	user := mocks.MockIdentityUser{
+		Subject:       "idp-user-1",
+		Email:         "sso@example.com",
+		EmailVerified: true,
+		Name:          "Sso User",
+	}
+
+	tests := []struct {
+		name          string
+		issuerURL     func(idp *mocks.MockIdentityProvider) string
+		clientSecret  string
+		codeVerifier  func(codeVerifier string) string
+		nonceOverride string
+		expectError   bool
+	}{
+		{
+			name:         "Successfully exchange the code",
+			clientSecret: testClientSecret,
+			expectError:  false,
+		},
+		{
+			name:         "Wrong code verifier",
+			clientSecret: testClientSecret,
+			codeVerifier: func(codeVerifier string) string { return codeVerifier + "-tampered" },
+			expectError:  true,
+		},
+		{
+			name:         "Wrong client secret",
+			clientSecret: "wrong-secret",
+			expectError:  true,
+		},
+		{
+			name:          "Id token with another nonce",
+			clientSecret:  testClientSecret,
+			nonceOverride: "replayed-nonce",
+			expectError:   true,
+		},
+		{
+			name:         "Discovery document from another issuer",
+			issuerURL:    func(idp *mocks.MockIdentityProvider) string { return idp.Issuer() + "/" },
+			clientSecret: testClientSecret,
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idp := mocks.NewMockIdentityProvider(testClientId, testClientSecret)
+			defer idp.Close()
+
+			idp.User = user
+			idp.NonceOverride = tt.nonceOverride
+
+			issuerURL := idp.Issuer()
+			if tt.issuerURL != nil {
+				issuerURL = tt.issuerURL(idp)
+			}
+
+			provider := oidc.NewProvider(oidc.Config{
+				IssuerURL:    issuerURL,
+				ClientID:     testClientId,
+				ClientSecret: tt.clientSecret,
+				RedirectURL:  testRedirectURL,
+				Scopes:       []string{"openid", "email", "profile"},
+			})
+
+			codeVerifier, _, _ := utils.GenerateOpaqueToken()
+
+			authorizationURL, err := provider.AuthorizationURL(context.Background(), interfaces.OIDCAuthorizationData{
+				State:         "state",
+				Nonce:         "nonce",
+				CodeChallenge: utils.PKCECodeChallenge(codeVerifier),
+			})
+
+			if err != nil {
+				assert.True(t, tt.expectError, "unexpected error: %v", err)
+				return
+			}
+
+			code, state, err := idp.SignIn(authorizationURL)
+			assert.NoError(t, err)
+			assert.Equal(t, "state", state)
+
+			if tt.codeVerifier != nil {
+				codeVerifier = tt.codeVerifier(codeVerifier)
+			}
+
+			identity, err := provider.Exchange(context.Background(), interfaces.OIDCExchangeData{
+				Code:         code,
+				CodeVerifier: codeVerifier,
+				Nonce:        "nonce",
+			})
+
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, interfaces.OIDCIdentity{
+				Issuer:        idp.Issuer(),
+				Subject:       user.Subject,
+				Email:         user.Email,
+				EmailVerified: true,
+				Name:          user.Name,
+			}, identity)
+
+			// Codes can't be redeemed twice
+			_, err = provider.Exchange(context.Background(), interfaces.OIDCExchangeData{
+				Code:         code,
+				CodeVerifier: codeVerifier,
+				Nonce:        "nonce",
+			})
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,519 @@
+package policy_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/policy"
+	"github.com/TobiasRV/challenge-fs-senior/internals/tenant"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	roles = []models.Userroles{models.UserrolesAdmin, models.UserrolesManager, models.UserrolesMember}
+
+	actions = []policy.Action{
+		policy.ActionCreate,
+		policy.ActionRead,
+		policy.ActionUpdate,
+		policy.ActionDelete,
+		policy.ActionRevokeSessions,
+		policy.ActionUnlock,
+	}
+
+	resourceTypes = []policy.ResourceType{
+		policy.ResourceUsers,
+		policy.ResourceProfile,
+		policy.ResourceSessions,
+		policy.ResourcePersonalAccessTokens,
+		policy.ResourceTeams,
+		policy.ResourceInvitations,
+		policy.ResourceProjects,
+		policy.ResourceTasks,
+		policy.ResourceRoles,
+		policy.ResourceOrganizations,
+	}
+)
+
+// The grants expected for every resource and action, by role: "any", "owner", "assignee", "member",
+// "assignee or member", "organization admin", "owner or organization admin" or missing when the role
+// can't perform the action
+var expectedMatrix = map[policy.ResourceType]map[policy.Action]map[models.Userroles]string{
+	policy.ResourceUsers: {
+		policy.ActionCreate:         {models.UserrolesAdmin: "any"},
+		policy.ActionRead:           {models.UserrolesAdmin: "any", models.UserrolesManager: "any"},
+		policy.ActionUpdate:         {models.UserrolesAdmin: "any"},
+		policy.ActionDelete:         {models.UserrolesAdmin: "any"},
+		policy.ActionRevokeSessions: {models.UserrolesAdmin: "any"},
+		policy.ActionUnlock:         {models.UserrolesAdmin: "any"},
+	},
+	policy.ResourceProfile: {
+		policy.ActionRead:   {models.UserrolesAdmin: "owner", models.UserrolesManager: "owner", models.UserrolesMember: "owner"},
+		policy.ActionUpdate: {models.UserrolesAdmin: "owner", models.UserrolesManager: "owner", models.UserrolesMember: "owner"},
+	},
+	policy.ResourceSessions: {
+		policy.ActionRead:   {models.UserrolesAdmin: "owner", models.UserrolesManager: "owner", models.UserrolesMember: "owner"},
+		policy.ActionDelete: {models.UserrolesAdmin: "owner", models.UserrolesManager: "owner", models.UserrolesMember: "owner"},
+	},
+	policy.ResourcePersonalAccessTokens: {
+		policy.ActionCreate: {models.UserrolesAdmin: "owner", models.UserrolesManager: "owner", models.UserrolesMember: "owner"},
+		policy.ActionRead:   {models.UserrolesAdmin: "owner", models.UserrolesManager: "owner", models.UserrolesMember: "owner"},
+		policy.ActionDelete: {models.UserrolesAdmin: "owner", models.UserrolesManager: "owner", models.UserrolesMember: "owner"},
+	},
+	policy.ResourceTeams: {
+		policy.ActionCreate: {models.UserrolesAdmin: "any"},
+		policy.ActionRead:   {models.UserrolesAdmin: "owner or organization admin"},
+		policy.ActionUpdate: {models.UserrolesAdmin: "owner or organization admin"},
+		policy.ActionDelete: {models.UserrolesAdmin: "owner or organization admin"},
+	},
+	policy.ResourceInvitations: {
+		policy.ActionCreate: {models.UserrolesAdmin: "any"},
+		policy.ActionRead:   {models.UserrolesAdmin: "any"},
+		policy.ActionUpdate: {models.UserrolesAdmin: "any"},
+		policy.ActionDelete: {models.UserrolesAdmin: "any"},
+	},
+	policy.ResourceProjects: {
+		policy.ActionCreate: {models.UserrolesAdmin: "any", models.UserrolesManager: "any"},
+		policy.ActionRead:   {models.UserrolesAdmin: "any", models.UserrolesManager: "any", models.UserrolesMember: "member"},
+		policy.ActionUpdate: {models.UserrolesAdmin: "any", models.UserrolesManager: "owner"},
+		policy.ActionDelete: {models.UserrolesAdmin: "any", models.UserrolesManager: "owner"},
+	},
+	policy.ResourceTasks: {
+		policy.ActionCreate: {models.UserrolesAdmin: "any", models.UserrolesManager: "owner"},
+		policy.ActionRead:   {models.UserrolesAdmin: "any", models.UserrolesManager: "any", models.UserrolesMember: "assignee or member"},
+		policy.ActionUpdate: {models.UserrolesAdmin: "any", models.UserrolesManager: "owner", models.UserrolesMember: "assignee"},
+		policy.ActionDelete: {models.UserrolesAdmin: "any", models.UserrolesManager: "owner"},
+	},
+	policy.ResourceRoles: {
+		policy.ActionCreate: {models.UserrolesAdmin: "any"},
+		policy.ActionRead:   {models.UserrolesAdmin: "any", models.UserrolesManager: "any", models.UserrolesMember: "any"},
+		policy.ActionUpdate: {models.UserrolesAdmin: "any"},
+		policy.ActionDelete: {models.UserrolesAdmin: "any"},
+	},
+	policy.ResourceOrganizations: {
+		policy.ActionCreate: {models.UserrolesAdmin: "any"},
+		policy.ActionRead:   {models.UserrolesAdmin: "organization admin"},
+		policy.ActionUpdate: {models.UserrolesAdmin: "organization admin"},
+	},
+}
+
+func TestCan(t *testing.T) {
+	userId := uuid.New()
+	otherUserId := uuid.New()
+	organizationId := uuid.New()
+
+	relations := []struct {
+		name     string
+		resource func(policy.ResourceType) policy.Resource
+		allowed  map[string]bool
+	}{
+		{
+			name: "whole collection",
+			resource: func(resourceType policy.ResourceType) policy.Resource {
+				return policy.Collection(resourceType)
+			},
+			allowed: map[string]bool{"any": true},
+		},
+		{
+			name: "owned by the user",
+			resource: func(resourceType policy.ResourceType) policy.Resource {
+				return policy.Resource{Type: resourceType, OwnerID: userId}
+			},
+			allowed: map[string]bool{"any": true, "owner": true, "owner or organization admin": true},
+		},
+		{
+			name: "assigned to the user",
+			resource: func(resourceType policy.ResourceType) policy.Resource {
+				return policy.Resource{Type: resourceType, OwnerID: otherUserId, AssigneeID: userId}
+			},
+			allowed: map[string]bool{"any": true, "assignee": true, "assignee or member": true},
+		},
+		{
+			name: "of a project the user is a member of",
+			resource: func(resourceType policy.ResourceType) policy.Resource {
+				return policy.Resource{Type: resourceType, OwnerID: otherUserId, Members: map[uuid.UUID]models.Projectmemberroles{userId: models.ProjectmemberrolesViewer}}
+			},
+			allowed: map[string]bool{"any": true, "member": true, "assignee or member": true},
+		},
+		{
+			name: "of a project the user owns with another user",
+			resource: func(resourceType policy.ResourceType) policy.Resource {
+				return policy.Resource{Type: resourceType, OwnerID: otherUserId, Members: map[uuid.UUID]models.Projectmemberroles{userId: models.ProjectmemberrolesOwner}}
+			},
+			allowed: map[string]bool{"any": true, "owner": true, "member": true, "assignee or member": true, "owner or organization admin": true},
+		},
+		{
+			name: "of an organization the user administers",
+			resource: func(resourceType policy.ResourceType) policy.Resource {
+				return policy.Resource{Type: resourceType, OwnerID: otherUserId, OrganizationID: uuid.NullUUID{UUID: organizationId, Valid: true}}
+			},
+			allowed: map[string]bool{"any": true, "organization admin": true, "owner or organization admin": true},
+		},
+
+		{
+			name: "owned by and assigned to another user",
+			resource: func(resourceType policy.ResourceType) policy.Resource {
+				return policy.Resource{Type: resourceType, OwnerID: otherUserId, AssigneeID: otherUserId}
+			},
+			allowed: map[string]bool{"any": true},
+		},
+	}
+
+	for _, resourceType := range resourceTypes {
+		for _, action := range actions {
+			for _, role := range roles {
+				grant := expectedMatrix[resourceType][action][role]
+				subject := policy.Subject{ID: userId, Role: role, Organizations: []uuid.UUID{organizationId}}
+
+				assert.Equal(t, grant != "", policy.Allows(subject, action, resourceType), "%s %s %s", role, action, resourceType)
+
+				for _, relation := range relations {
+					assert.Equal(t, relation.allowed[grant], policy.Can(subject, action, relation.resource(resourceType)),
+						"%s %s %s %s", role, action, resourceType, relation.name)
+				}
+			}
+		}
+	}
+}
+
+func TestCan_UnknownRole(t *testing.T) {
+	userId := uuid.New()
+
+	for _, resourceType := range resourceTypes {
+		for _, action := range actions {
+			assert.False(t, policy.Allows(policy.Subject{}, action, resourceType))
+			assert.False(t, policy.Can(policy.Subject{ID: userId, Role: "Guest"}, action, policy.Resource{Type: resourceType, OwnerID: userId, AssigneeID: userId}))
+		}
+	}
+}
+
+func TestCan_CustomRole(t *testing.T) {
+	userId := uuid.New()
+	otherUserId := uuid.New()
+	ownProject := policy.Resource{Type: policy.ResourceProjects, OwnerID: userId}
+	otherProject := policy.Resource{Type: policy.ResourceProjects, OwnerID: otherUserId}
+	otherTask := policy.Resource{Type: policy.ResourceTasks, OwnerID: otherUserId}
+
+	qa := policy.Subject{ID: userId, Role: models.UserrolesMember, Permissions: []string{models.PermissionTasksViewAny, models.PermissionTasksEditAny}}
+
+	assert.True(t, policy.Can(qa, policy.ActionRead, otherTask))
+	assert.True(t, policy.Can(qa, policy.ActionUpdate, otherTask))
+	assert.False(t, policy.Can(qa, policy.ActionDelete, otherTask))
+	assert.False(t, policy.Allows(qa, policy.ActionRead, policy.ResourceProjects))
+
+	lead := policy.Subject{ID: userId, Role: models.UserrolesManager, Permissions: []string{models.PermissionProjectsEditOwn, models.PermissionProjectsDeleteAny}}
+
+	assert.True(t, policy.Can(lead, policy.ActionUpdate, ownProject))
+	assert.False(t, policy.Can(lead, policy.ActionUpdate, otherProject))
+	assert.True(t, policy.Can(lead, policy.ActionDelete, otherProject))
+	assert.False(t, policy.Allows(lead, policy.ActionCreate, policy.ResourceProjects))
+
+	// A custom role without permissions replaces the ones of the base role, the resources that don't
+	// go by permissions keep the grants of the base role
+	viewer := policy.Subject{ID: userId, Role: models.UserrolesManager, Permissions: []string{}}
+
+	assert.False(t, policy.Allows(viewer, policy.ActionRead, policy.ResourceUsers))
+	assert.False(t, policy.Allows(viewer, policy.ActionRead, policy.ResourceTasks))
+	assert.True(t, policy.Can(viewer, policy.ActionRead, policy.Profile(userId)))
+	assert.True(t, policy.Allows(viewer, policy.ActionRead, policy.ResourceRoles))
+}
+
+func TestBuiltInRolePermissions(t *testing.T) {
+	for _, role := range roles {
+		builtIn := policy.Subject{Role: role, Permissions: models.BuiltInRolePermissions[role]}
+
+		for _, resourceType := range resourceTypes {
+			for _, action := range actions {
+				assert.Equal(t, policy.Allows(policy.Subject{Role: role}, action, resourceType), policy.Allows(builtIn, action, resourceType), "%s %s %s", role, action, resourceType)
+			}
+		}
+	}
+}
+
+func TestResources(t *testing.T) {
+	managerId := uuid.New()
+	memberId := uuid.New()
+	teamId := uuid.New()
+
+	project := models.Project{ID: uuid.New(), ManagerID: managerId, TeamID: teamId}
+	task := models.Task{ID: uuid.New(), ProjectID: project.ID, UserID: uuid.NullUUID{UUID: memberId, Valid: true}}
+
+	manager := policy.Subject{ID: managerId, Role: models.UserrolesManager, TeamID: teamId}
+	member := policy.Subject{ID: memberId, Role: models.UserrolesMember, TeamID: teamId}
+
+	assert.True(t, policy.Can(manager, policy.ActionDelete, policy.Project(project, nil)))
+	assert.False(t, policy.Can(policy.Subject{ID: uuid.New(), Role: models.UserrolesManager, TeamID: teamId}, policy.ActionDelete, policy.Project(project, nil)))
+
+	assert.True(t, policy.Can(manager, policy.ActionUpdate, policy.Task(task, project, nil)))
+	assert.True(t, policy.Can(member, policy.ActionRead, policy.Task(task, project, nil)))
+	assert.True(t, policy.Can(member, policy.ActionUpdate, policy.Task(task, project, nil)))
+	assert.False(t, policy.Can(member, policy.ActionDelete, policy.Task(task, project, nil)))
+	assert.True(t, policy.Can(manager, policy.ActionCreate, policy.ProjectTasks(project, nil)))
+
+	// Members only update the tasks assigned to them, with the fields the handlers let assignees change
+	assert.True(t, policy.OnlyAsAssignee(member, policy.ActionUpdate, policy.Task(task, project, nil)))
+	assert.False(t, policy.OnlyAsAssignee(manager, policy.ActionUpdate, policy.Task(task, project, nil)))
+	assignedManager := models.Task{ID: uuid.New(), ProjectID: project.ID, UserID: uuid.NullUUID{UUID: managerId, Valid: true}}
+	assert.False(t, policy.OnlyAsAssignee(manager, policy.ActionUpdate, policy.Task(assignedManager, project, nil)))
+
+	unassigned := models.Task{ID: uuid.New(), ProjectID: project.ID}
+	assert.False(t, policy.Can(member, policy.ActionRead, policy.Task(unassigned, project, nil)))
+
+	team := models.Team{ID: uuid.New(), OwnerID: uuid.New()}
+	assert.True(t, policy.Can(policy.Subject{ID: team.OwnerID, Role: models.UserrolesAdmin, TeamID: team.ID}, policy.ActionUpdate, policy.Team(team)))
+	assert.False(t, policy.Can(policy.Subject{ID: uuid.New(), Role: models.UserrolesAdmin, TeamID: team.ID}, policy.ActionUpdate, policy.Team(team)))
+	assert.False(t, policy.InTeam(policy.Subject{ID: team.OwnerID, Role: models.UserrolesAdmin, TeamID: uuid.New()}, policy.Team(team)))
+
+	session := models.Session{ID: uuid.New(), UserID: memberId}
+	assert.True(t, policy.Can(member, policy.ActionDelete, policy.Session(session)))
+	assert.False(t, policy.Can(manager, policy.ActionDelete, policy.Session(session)))
+}
+
+func TestProjectMembers(t *testing.T) {
+	teamId := uuid.New()
+	managerId := uuid.New()
+	coOwnerId := uuid.New()
+	contributorId := uuid.New()
+	viewerId := uuid.New()
+
+	project := models.Project{ID: uuid.New(), ManagerID: managerId, TeamID: teamId}
+	members := []models.ProjectMember{
+		{ProjectID: project.ID, UserID: managerId, Role: models.ProjectmemberrolesOwner},
+		{ProjectID: project.ID, UserID: coOwnerId, Role: models.ProjectmemberrolesOwner},
+		{ProjectID: project.ID, UserID: contributorId, Role: models.ProjectmemberrolesContributor},
+		{ProjectID: project.ID, UserID: viewerId, Role: models.ProjectmemberrolesViewer},
+	}
+	task := models.Task{ID: uuid.New(), ProjectID: project.ID}
+
+	coOwner := policy.Subject{ID: coOwnerId, Role: models.UserrolesManager, TeamID: teamId}
+	contributor := policy.Subject{ID: contributorId, Role: models.UserrolesMember, TeamID: teamId}
+	viewer := policy.Subject{ID: viewerId, Role: models.UserrolesMember, TeamID: teamId}
+	outsider := policy.Subject{ID: uuid.New(), Role: models.UserrolesMember, TeamID: teamId}
+
+	// Every owner manages the project, not only its manager
+	assert.True(t, policy.Can(coOwner, policy.ActionUpdate, policy.Project(project, members)))
+	assert.True(t, policy.Can(coOwner, policy.ActionDelete, policy.Project(project, members)))
+	assert.True(t, policy.Can(coOwner, policy.ActionCreate, policy.ProjectTasks(project, members)))
+	assert.True(t, policy.Can(coOwner, policy.ActionUpdate, policy.Task(task, project, members)))
+
+	// Members see the project and its tasks whatever their role
+	for _, subject := range []policy.Subject{contributor, viewer} {
+		assert.True(t, policy.Can(subject, policy.ActionRead, policy.Project(project, members)))
+		assert.True(t, policy.Can(subject, policy.ActionRead, policy.Task(task, project, members)))
+		assert.False(t, policy.Can(subject, policy.ActionUpdate, policy.Project(project, members)))
+		assert.False(t, policy.Can(subject, policy.ActionUpdate, policy.Task(task, project, members)))
+	}
+
+	assert.False(t, policy.Can(outsider, policy.ActionRead, policy.Project(project, members)))
+	assert.False(t, policy.Can(outsider, policy.ActionRead, policy.Task(task, project, members)))
+
+	assert.True(t, policy.Can(viewer, policy.ActionRead, policy.MemberResources(policy.ResourceProjects, viewerId)))
+	assert.False(t, policy.Can(viewer, policy.ActionRead, policy.Collection(policy.ResourceProjects)))
+}
+
+func TestInTeam(t *testing.T) {
+	teamId := uuid.New()
+	otherTeamId := uuid.New()
+
+	organizationId := uuid.New()
+	otherOrganizationId := uuid.New()
+
+	admin := policy.Subject{ID: uuid.New(), Role: models.UserrolesAdmin, TeamID: teamId}
+	organizationAdmin := policy.Subject{ID: uuid.New(), Role: models.UserrolesAdmin, TeamID: teamId, Organizations: []uuid.UUID{organizationId}}
+	teamless := policy.Subject{ID: uuid.New(), Role: models.UserrolesAdmin}
+
+	project := models.Project{ID: uuid.New(), ManagerID: uuid.New(), TeamID: teamId}
+	otherProject := models.Project{ID: uuid.New(), ManagerID: uuid.New(), TeamID: otherTeamId}
+	task := models.Task{ID: uuid.New(), ProjectID: otherProject.ID}
+
+	team := models.Team{ID: otherTeamId, OwnerID: uuid.New(), OrganizationID: organizationId}
+	teamOfAnotherOrganization := models.Team{ID: uuid.New(), OwnerID: uuid.New(), OrganizationID: otherOrganizationId}
+
+	user := models.User{ID: uuid.New(), Role: models.UserrolesMember, TeamId: teamId}
+	otherUser := models.User{ID: uuid.New(), Role: models.UserrolesMember, TeamId: otherTeamId}
+
+	tests := []struct {
+		name     string
+		subject  policy.Subject
+		resource policy.Resource
+		expected bool
+	}{
+		{name: "Resource of no team", subject: admin, resource: policy.Collection(policy.ResourceUsers), expected: true},
+		{name: "Collection of the team", subject: admin, resource: policy.TeamResources(policy.ResourceUsers, teamId), expected: true},
+		{name: "Collection of another team", subject: admin, resource: policy.TeamResources(policy.ResourceUsers, otherTeamId), expected: false},
+		{name: "Project of the team", subject: admin, resource: policy.Project(project, nil), expected: true},
+		{name: "Project of another team", subject: admin, resource: policy.Project(otherProject, nil), expected: false},
+		{name: "Task of another team", subject: admin, resource: policy.Task(task, otherProject, nil), expected: false},
+		{name: "User of the team", subject: admin, resource: policy.User(user, user.TeamId), expected: true},
+		{name: "User of another team", subject: admin, resource: policy.User(otherUser, otherUser.TeamId), expected: false},
+		{name: "User without a team", subject: admin, resource: policy.User(models.User{ID: uuid.New()}, uuid.Nil), expected: false},
+		{name: "Subject without a team", subject: teamless, resource: policy.TeamResources(policy.ResourceProjects, uuid.Nil), expected: false},
+		{name: "Subject without a team itself", subject: teamless, resource: policy.User(models.User{ID: teamless.ID}, uuid.Nil), expected: true},
+		{name: "Team of an organization the subject administers", subject: organizationAdmin, resource: policy.Team(team), expected: true},
+		{name: "Team of another organization", subject: organizationAdmin, resource: policy.Team(teamOfAnotherOrganization), expected: false},
+		{name: "Organization the subject administers", subject: organizationAdmin, resource: policy.Organization(models.Organization{ID: organizationId}), expected: true},
+		{name: "Organization the subject doesn't administer", subject: admin, resource: policy.Organization(models.Organization{ID: organizationId}), expected: false},
+		{name: "Every organization", subject: admin, resource: policy.Collection(policy.ResourceOrganizations), expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, policy.InTeam(tt.subject, tt.resource))
+
+			if !tt.expected {
+				// Not even the roles granted every resource of the type can reach it
+				assert.False(t, policy.Can(tt.subject, policy.ActionRead, tt.resource))
+			}
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	managerId := uuid.New()
+	teamId := uuid.New()
+	project := models.Project{ID: uuid.New(), ManagerID: managerId, TeamID: teamId}
+
+	tests := []struct {
+		name           string
+		userId         string
+		userRole       string
+		teamId         uuid.UUID
+		expectedStatus int
+	}{
+		{
+			name:           "Manager of the project",
+			userId:         managerId.String(),
+			userRole:       "Manager",
+			teamId:         teamId,
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "Another manager",
+			userId:         uuid.New().String(),
+			userRole:       "Manager",
+			teamId:         teamId,
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:           "Member",
+			userId:         managerId.String(),
+			userRole:       "Member",
+			teamId:         teamId,
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:           "Manager of another team",
+			userId:         uuid.New().String(),
+			userRole:       "Manager",
+			teamId:         uuid.New(),
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:           "No user",
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Delete("/projects/:id", func(c *fiber.Ctx) error {
+				if tt.userId != "" {
+					c.Locals("userId", tt.userId)
+					c.Locals("userRole", tt.userRole)
+					tenant.SetTeam(c, tt.teamId)
+				}
+
+				if err := policy.Authorize(c.Context(), policy.ActionDelete, policy.Project(project, nil)); err != nil {
+					return policy.Deny(c, err, "project not found")
+				}
+
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/projects/"+project.ID.String(), nil))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestRequire(t *testing.T) {
+	tests := []struct {
+		name           string
+		userRole       string
+		permissions    []string
+		action         policy.Action
+		resourceType   policy.ResourceType
+		expectedStatus int
+	}{
+		{
+			name:           "Role granted the action",
+			userRole:       "Manager",
+			action:         policy.ActionDelete,
+			resourceType:   policy.ResourceProjects,
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "Role granted the action on its own resources",
+			userRole:       "Member",
+			action:         policy.ActionRead,
+			resourceType:   policy.ResourceTasks,
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "Role not granted the action",
+			userRole:       "Member",
+			action:         policy.ActionDelete,
+			resourceType:   policy.ResourceProjects,
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:           "No role",
+			action:         policy.ActionRead,
+			resourceType:   policy.ResourceProfile,
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:           "Custom role granted the action",
+			userRole:       "Member",
+			permissions:    []string{models.PermissionProjectsDeleteAny},
+			action:         policy.ActionDelete,
+			resourceType:   policy.ResourceProjects,
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "Custom role not granted an action of its base role",
+			userRole:       "Manager",
+			permissions:    []string{models.PermissionTasksViewAny},
+			action:         policy.ActionDelete,
+			resourceType:   policy.ResourceProjects,
+			expectedStatus: fiber.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/", func(c *fiber.Ctx) error {
+				if tt.userRole != "" {
+					c.Locals("userRole", tt.userRole)
+				}
+				if tt.permissions != nil {
+					c.Locals("permissions", tt.permissions)
+				}
+				return c.Next()
+			}, policy.Require(tt.action, tt.resourceType), func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
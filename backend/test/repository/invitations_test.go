@@ -0,0 +1,264 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/repository"
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var invitationColumns = []string{"id", "team_id", "email", "role", "token_id", "invited_by", "expires_at", "created_at", "updated_at", "accepted_at"}
+
+func TestInvitationRepository_CreateInvitation(t *testing.T) {
+	invitationId := uuid.New()
+	teamId := uuid.New()
+	tokenId := uuid.New()
+	adminId := uuid.New()
+	now := time.Now().UTC()
+	expiresAt := now.Add(time.Hour * 168)
+
+	invitation := models.Invitation{
+		ID:        invitationId,
+		TeamID:    teamId,
+		Email:     "member@example.com",
+		Role:      models.UserrolesMember,
+		TokenID:   tokenId,
+		InvitedBy: adminId,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	tests := []struct {
+		name        string
+		mockSetup   func(sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "Successfully create invitation",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(invitationColumns).
+					AddRow(invitationId, teamId, "member@example.com", "Member", tokenId, adminId, expiresAt, now, now, nil)
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO invitations")).
+					WithArgs(invitationId, teamId, "member@example.com", database.UserrolesMember, tokenId, adminId, expiresAt, now, now).
+					WillReturnRows(rows)
+			},
+			expectError: false,
+		},
+		{
+			name: "Database error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO invitations")).
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewInvitationRepository(queries, db)
+
+			result, err := repo.CreateInvitation(context.Background(), invitation)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, invitationId, result.ID)
+				assert.Equal(t, tokenId, result.TokenID)
+				assert.False(t, result.AcceptedAt.Valid)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestInvitationRepository_GetPendingInvitationsByTeam(t *testing.T) {
+	teamId := uuid.New()
+	adminId := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name          string
+		mockSetup     func(sqlmock.Sqlmock)
+		expectedCount int
+		expectError   bool
+	}{
+		{
+			name: "Successfully list pending invitations",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(invitationColumns).
+					AddRow(uuid.New(), teamId, "a@example.com", "Member", uuid.New(), adminId, now, now, now, nil).
+					AddRow(uuid.New(), teamId, "b@example.com", "Manager", uuid.New(), adminId, now, now, now, nil)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, team_id, email, role, token_id, invited_by, expires_at, created_at, updated_at, accepted_at FROM invitations")).
+					WithArgs(teamId).
+					WillReturnRows(rows)
+			},
+			expectedCount: 2,
+			expectError:   false,
+		},
+		{
+			name: "Database error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, team_id, email, role, token_id, invited_by, expires_at, created_at, updated_at, accepted_at FROM invitations")).
+					WithArgs(teamId).
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectedCount: 0,
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewInvitationRepository(queries, db)
+
+			result, err := repo.GetPendingInvitationsByTeam(context.Background(), teamId)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Len(t, result, tt.expectedCount)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestInvitationRepository_AcceptInvitation(t *testing.T) {
+	invitationId := uuid.New()
+	teamId := uuid.New()
+	tokenId := uuid.New()
+	adminId := uuid.New()
+	userId := uuid.New()
+	now := time.Now().UTC()
+
+	data := interfaces.AcceptInvitationData{
+		InvitationID: invitationId,
+		TokenID:      tokenId,
+		Username:     "member",
+		Password:     "hashed-password",
+		AcceptedAt:   now,
+	}
+
+	invitationRow := func() *sqlmock.Rows {
+		return sqlmock.NewRows(invitationColumns).
+			AddRow(invitationId, teamId, "member@example.com", "Member", tokenId, adminId, now.Add(time.Hour), now, now, nil)
+	}
+
+	tests := []struct {
+		name             string
+		mockSetup        func(sqlmock.Sqlmock)
+		expectedAccepted bool
+		expectError      bool
+	}{
+		{
+			name: "Creates the user in the invitation team",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta("FROM invitations")).
+					WithArgs(invitationId).
+					WillReturnRows(invitationRow())
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE invitations")).
+					WithArgs(sql.NullTime{Time: now, Valid: true}, now, invitationId, tokenId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO users")).
+					WithArgs(now, now, "member", "hashed-password", "member@example.com", database.UserrolesMember, uuid.NullUUID{UUID: teamId, Valid: true}).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id", "role_id", "deactivated_at"}).
+						AddRow(userId, now, now, "member", "hashed-password", "member@example.com", "Member", teamId, models.BuiltInRoleIds[models.UserrolesMember], nil))
+				mock.ExpectCommit()
+			},
+			expectedAccepted: true,
+			expectError:      false,
+		},
+		{
+			name: "Invitation accepted, renewed or expired meanwhile",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta("FROM invitations")).
+					WithArgs(invitationId).
+					WillReturnRows(invitationRow())
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE invitations")).
+					WithArgs(sql.NullTime{Time: now, Valid: true}, now, invitationId, tokenId).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			expectedAccepted: false,
+			expectError:      false,
+		},
+		{
+			name: "Create user error rolls back",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta("FROM invitations")).
+					WithArgs(invitationId).
+					WillReturnRows(invitationRow())
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE invitations")).
+					WithArgs(sql.NullTime{Time: now, Valid: true}, now, invitationId, tokenId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO users")).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			expectedAccepted: false,
+			expectError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewInvitationRepository(queries, db)
+
+			user, accepted, err := repo.AcceptInvitation(context.Background(), data)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedAccepted, accepted)
+
+			if tt.expectedAccepted {
+				assert.Equal(t, userId, user.ID)
+				assert.Equal(t, teamId, user.TeamId)
+				assert.Equal(t, models.UserrolesMember, user.Role)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/repository"
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/stretchr/testify/assert"
+)
+
+var loginAttemptColumns = []string{"scope", "identifier", "failed_count", "last_failed_at"}
+
+func TestLoginAttemptRepository_GetLoginAttempts(t *testing.T) {
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name          string
+		mockSetup     func(sqlmock.Sqlmock)
+		expectedCount int
+		expectError   bool
+	}{
+		{
+			name: "Successfully get account and ip attempts",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(loginAttemptColumns).
+					AddRow("account", "test@example.com", 2, now).
+					AddRow("ip", "127.0.0.1", 5, now)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT scope, identifier, failed_count, last_failed_at FROM login_attempts")).
+					WithArgs("test@example.com", "127.0.0.1").
+					WillReturnRows(rows)
+			},
+			expectedCount: 2,
+			expectError:   false,
+		},
+		{
+			name: "Database error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT scope, identifier, failed_count, last_failed_at FROM login_attempts")).
+					WithArgs("test@example.com", "127.0.0.1").
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectedCount: 0,
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewLoginAttemptRepository(queries, db)
+
+			result, err := repo.GetLoginAttempts(context.Background(), interfaces.GetLoginAttemptsData{
+				AccountKey: "test@example.com",
+				IpAddress:  "127.0.0.1",
+			})
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Len(t, result, tt.expectedCount)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestLoginAttemptRepository_RecordFailedLogin(t *testing.T) {
+	now := time.Now().UTC()
+	windowStart := now.Add(-15 * time.Minute)
+
+	tests := []struct {
+		name        string
+		mockSetup   func(sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "Successfully record account and ip failures",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO login_attempts")).
+					WithArgs("account", "test@example.com", now, windowStart).
+					WillReturnRows(sqlmock.NewRows(loginAttemptColumns).AddRow("account", "test@example.com", 3, now))
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO login_attempts")).
+					WithArgs("ip", "127.0.0.1", now, windowStart).
+					WillReturnRows(sqlmock.NewRows(loginAttemptColumns).AddRow("ip", "127.0.0.1", 7, now))
+				mock.ExpectCommit()
+			},
+			expectError: false,
+		},
+		{
+			name: "Error recording ip failure - rolls back",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO login_attempts")).
+					WithArgs("account", "test@example.com", now, windowStart).
+					WillReturnRows(sqlmock.NewRows(loginAttemptColumns).AddRow("account", "test@example.com", 3, now))
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO login_attempts")).
+					WithArgs("ip", "127.0.0.1", now, windowStart).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewLoginAttemptRepository(queries, db)
+
+			result, err := repo.RecordFailedLogin(context.Background(), interfaces.RecordFailedLoginData{
+				AccountKey:  "test@example.com",
+				IpAddress:   "127.0.0.1",
+				FailedAt:    now,
+				WindowStart: windowStart,
+			})
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result, 2)
+				assert.Equal(t, models.LoginAttemptScopeAccount, result[0].Scope)
+				assert.Equal(t, int32(3), result[0].FailedCount)
+				assert.Equal(t, models.LoginAttemptScopeIp, result[1].Scope)
+				assert.Equal(t, int32(7), result[1].FailedCount)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,286 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/repository"
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var userTotpColumns = []string{"user_id", "secret", "enabled_at", "last_used_step", "created_at", "updated_at"}
+
+func TestMfaRepository_StartTotpEnrollment(t *testing.T) {
+	userId := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name            string
+		mockSetup       func(sqlmock.Sqlmock)
+		expectError     bool
+		expectedStarted bool
+	}{
+		{
+			name: "Successfully start enrollment",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(userTotpColumns).
+					AddRow(userId, "SECRET", nil, 0, now, now)
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO user_totp")).
+					WithArgs(userId, "SECRET", now).
+					WillReturnRows(rows)
+			},
+			expectError:     false,
+			expectedStarted: true,
+		},
+		{
+			name: "Already enabled - not started",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO user_totp")).
+					WithArgs(userId, "SECRET", now).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectError:     false,
+			expectedStarted: false,
+		},
+		{
+			name: "Database error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO user_totp")).
+					WithArgs(userId, "SECRET", now).
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewMfaRepository(queries, db)
+
+			result, started, err := repo.StartTotpEnrollment(context.Background(), models.UserTotp{
+				UserID:    userId,
+				Secret:    "SECRET",
+				CreatedAt: now,
+			})
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStarted, started)
+				if tt.expectedStarted {
+					assert.Equal(t, userId, result.UserID)
+					assert.False(t, result.EnabledAt.Valid)
+				}
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMfaRepository_EnableTotp(t *testing.T) {
+	userId := uuid.New()
+	now := time.Now().UTC()
+
+	codes := []models.MfaRecoveryCode{
+		{ID: uuid.New(), UserID: userId, CodeHash: "hash-1", CreatedAt: now},
+		{ID: uuid.New(), UserID: userId, CodeHash: "hash-2", CreatedAt: now},
+	}
+
+	tests := []struct {
+		name            string
+		mockSetup       func(sqlmock.Sqlmock)
+		expectError     bool
+		expectedEnabled bool
+	}{
+		{
+			name: "Successfully enable and store recovery codes",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE user_totp")).
+					WithArgs(sql.NullTime{Time: now, Valid: true}, int64(100), now, userId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM mfa_recovery_codes")).
+					WithArgs(userId).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO mfa_recovery_codes")).
+					WithArgs(codes[0].ID, userId, "hash-1", now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO mfa_recovery_codes")).
+					WithArgs(codes[1].ID, userId, "hash-2", now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectError:     false,
+			expectedEnabled: true,
+		},
+		{
+			name: "Enrollment already confirmed - rolls back",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE user_totp")).
+					WithArgs(sql.NullTime{Time: now, Valid: true}, int64(100), now, userId).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			expectError:     false,
+			expectedEnabled: false,
+		},
+		{
+			name: "Error storing recovery codes - rolls back",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE user_totp")).
+					WithArgs(sql.NullTime{Time: now, Valid: true}, int64(100), now, userId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM mfa_recovery_codes")).
+					WithArgs(userId).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewMfaRepository(queries, db)
+
+			enabled, err := repo.EnableTotp(context.Background(), interfaces.EnableTotpData{
+				UserID:        userId,
+				Step:          100,
+				EnabledAt:     now,
+				RecoveryCodes: codes,
+			})
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedEnabled, enabled)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMfaRepository_UseTotpStep(t *testing.T) {
+	userId := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name         string
+		affectedRows int64
+		expectedUsed bool
+	}{
+		{
+			name:         "Successfully use step",
+			affectedRows: 1,
+			expectedUsed: true,
+		},
+		{
+			name:         "Step already used",
+			affectedRows: 0,
+			expectedUsed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectExec(regexp.QuoteMeta("UPDATE user_totp")).
+				WithArgs(int64(100), now, userId).
+				WillReturnResult(sqlmock.NewResult(0, tt.affectedRows))
+
+			queries := database.New(db)
+			repo := repository.NewMfaRepository(queries, db)
+
+			used, err := repo.UseTotpStep(context.Background(), interfaces.UseTotpStepData{
+				UserID: userId,
+				Step:   100,
+				UsedAt: now,
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedUsed, used)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMfaRepository_UseMfaPendingToken(t *testing.T) {
+	tokenId := uuid.New()
+	userId := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name         string
+		affectedRows int64
+		expectedUsed bool
+	}{
+		{
+			name:         "Successfully use token",
+			affectedRows: 1,
+			expectedUsed: true,
+		},
+		{
+			name:         "Token already used or expired",
+			affectedRows: 0,
+			expectedUsed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectExec(regexp.QuoteMeta("UPDATE mfa_pending_tokens")).
+				WithArgs(sql.NullTime{Time: now, Valid: true}, tokenId, userId).
+				WillReturnResult(sqlmock.NewResult(0, tt.affectedRows))
+
+			queries := database.New(db)
+			repo := repository.NewMfaRepository(queries, db)
+
+			used, err := repo.UseMfaPendingToken(context.Background(), interfaces.UseMfaPendingTokenData{
+				ID:     tokenId,
+				UserID: userId,
+				UsedAt: now,
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedUsed, used)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
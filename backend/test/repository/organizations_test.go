@@ -0,0 +1,279 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/repository"
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var organizationColumns = []string{"id", "created_at", "updated_at", "name", "require_mfa"}
+
+func TestOrganizationRepository_CreateOrganization(t *testing.T) {
+	organizationId := uuid.New()
+	adminId := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name        string
+		mockSetup   func(sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "Successfully create the organization with its admin",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO organizations")).
+					WithArgs(now, now, "Acme", false).
+					WillReturnRows(sqlmock.NewRows(organizationColumns).
+						AddRow(organizationId, now, now, "Acme", false))
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO organization_admins")).
+					WithArgs(organizationId, adminId, now).
+					WillReturnRows(sqlmock.NewRows([]string{"organization_id", "user_id", "created_at"}).
+						AddRow(organizationId, adminId, now))
+				mock.ExpectCommit()
+			},
+			expectError: false,
+		},
+		{
+			name: "Error adding the admin - rolls back",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO organizations")).
+					WithArgs(now, now, "Acme", false).
+					WillReturnRows(sqlmock.NewRows(organizationColumns).
+						AddRow(organizationId, now, now, "Acme", false))
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO organization_admins")).
+					WithArgs(organizationId, adminId, now).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewOrganizationRepository(queries, db)
+
+			result, err := repo.CreateOrganization(context.Background(), interfaces.CreateOrganizationData{
+				Name:      "Acme",
+				AdminID:   adminId,
+				CreatedAt: now,
+			})
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, organizationId, result.ID)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestOrganizationRepository_UpdateOrganization(t *testing.T) {
+	organizationId := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name        string
+		requireMfa  bool
+		mockSetup   func(sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name:       "Require two-factor authentication - requires it in every team and revokes sessions without it",
+			requireMfa: true,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta("UPDATE organizations")).
+					WithArgs("Acme", true, now, organizationId).
+					WillReturnRows(sqlmock.NewRows(organizationColumns).
+						AddRow(organizationId, now, now, "Acme", true))
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE teams")).
+					WithArgs(now, organizationId).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM sessions")).
+					WithArgs(organizationId).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectCommit()
+			},
+			expectError: false,
+		},
+		{
+			name:       "Stop requiring two-factor authentication leaves the teams alone",
+			requireMfa: false,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta("UPDATE organizations")).
+					WithArgs("Acme", false, now, organizationId).
+					WillReturnRows(sqlmock.NewRows(organizationColumns).
+						AddRow(organizationId, now, now, "Acme", false))
+				mock.ExpectCommit()
+			},
+			expectError: false,
+		},
+		{
+			name:       "Error revoking sessions - rolls back",
+			requireMfa: true,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta("UPDATE organizations")).
+					WithArgs("Acme", true, now, organizationId).
+					WillReturnRows(sqlmock.NewRows(organizationColumns).
+						AddRow(organizationId, now, now, "Acme", true))
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE teams")).
+					WithArgs(now, organizationId).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM sessions")).
+					WithArgs(organizationId).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewOrganizationRepository(queries, db)
+
+			result, err := repo.UpdateOrganization(context.Background(), interfaces.UpdateOrganizationData{
+				ID:         organizationId,
+				Name:       "Acme",
+				RequireMfa: tt.requireMfa,
+				UpdatedAt:  now,
+			})
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.requireMfa, result.RequireMfa)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestOrganizationRepository_GetOrganizationUsers(t *testing.T) {
+	organizationId := uuid.New()
+	teamId := uuid.New()
+	otherTeamId := uuid.New()
+	userId := uuid.New()
+	otherUserId := uuid.New()
+	now := time.Now().UTC()
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM team_memberships")).
+		WithArgs(organizationId).
+		WillReturnRows(sqlmock.NewRows([]string{"team_id", "user_id", "role", "role_id", "created_at", "updated_at", "username", "email", "name"}).
+			AddRow(teamId, userId, "Manager", nil, now, now, "jane", "jane@test.com", "Platform").
+			AddRow(otherTeamId, userId, "Member", nil, now, now, "jane", "jane@test.com", "Mobile").
+			AddRow(teamId, otherUserId, "Member", nil, now, now, "john", "john@test.com", "Platform"))
+
+	repo := repository.NewOrganizationRepository(database.New(db), db)
+
+	users, err := repo.GetOrganizationUsers(context.Background(), organizationId)
+
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.Equal(t, userId, users[0].ID)
+	assert.Len(t, users[0].Teams, 2)
+	assert.Equal(t, "Mobile", users[0].Teams[1].TeamName)
+	assert.Equal(t, otherUserId, users[1].ID)
+	assert.Len(t, users[1].Teams, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOrganizationRepository_GetOrganizationReport(t *testing.T) {
+	organizationId := uuid.New()
+	teamId := uuid.New()
+	otherTeamId := uuid.New()
+
+	tests := []struct {
+		name        string
+		mockSetup   func(sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "Adds up the counts of every team",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("AS done_tasks")).
+					WithArgs(organizationId).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "members", "projects", "tasks", "done_tasks"}).
+						AddRow(teamId, "Platform", 3, 2, 8, 5).
+						AddRow(otherTeamId, "Mobile", 2, 1, 4, 1))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(DISTINCT team_memberships.user_id)")).
+					WithArgs(organizationId).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+			},
+			expectError: false,
+		},
+		{
+			name: "Database error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("AS done_tasks")).
+					WithArgs(organizationId).
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			repo := repository.NewOrganizationRepository(database.New(db), db)
+
+			report, err := repo.GetOrganizationReport(context.Background(), organizationId)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(4), report.Users)
+				assert.Equal(t, int64(3), report.Projects)
+				assert.Equal(t, int64(12), report.Tasks)
+				assert.Equal(t, int64(6), report.DoneTasks)
+				assert.Len(t, report.Teams, 2)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/repository"
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordResetTokenRepository_CreatePasswordResetToken(t *testing.T) {
+	tokenId := uuid.New()
+	userId := uuid.New()
+	now := time.Now().UTC()
+	expiresAt := now.Add(time.Hour)
+
+	token := models.PasswordResetToken{
+		ID:        tokenId,
+		UserID:    userId,
+		TokenHash: "token-hash",
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}
+
+	tests := []struct {
+		name        string
+		mockSetup   func(sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "Replaces pending tokens of the user",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM password_reset_tokens")).
+					WithArgs(userId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO password_reset_tokens")).
+					WithArgs(tokenId, userId, "token-hash", expiresAt, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectError: false,
+		},
+		{
+			name: "Insert error rolls back",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM password_reset_tokens")).
+					WithArgs(userId).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO password_reset_tokens")).
+					WithArgs(tokenId, userId, "token-hash", expiresAt, now).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewPasswordResetTokenRepository(queries, db)
+
+			err = repo.CreatePasswordResetToken(context.Background(), token)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPasswordResetTokenRepository_ResetPassword(t *testing.T) {
+	tokenId := uuid.New()
+	userId := uuid.New()
+	now := time.Now().UTC()
+
+	data := interfaces.ResetPasswordData{
+		TokenID:  tokenId,
+		UserID:   userId,
+		Password: "hashed-password",
+		UsedAt:   now,
+	}
+
+	tests := []struct {
+		name          string
+		mockSetup     func(sqlmock.Sqlmock)
+		expectedReset bool
+		expectError   bool
+	}{
+		{
+			name: "Successfully reset password and revoke sessions",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE password_reset_tokens")).
+					WithArgs(sql.NullTime{Time: now, Valid: true}, tokenId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE users")).
+					WithArgs("hashed-password", now, userId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM sessions WHERE user_id")).
+					WithArgs(userId).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			expectedReset: true,
+			expectError:   false,
+		},
+		{
+			name: "Token already used",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE password_reset_tokens")).
+					WithArgs(sql.NullTime{Time: now, Valid: true}, tokenId).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			expectedReset: false,
+			expectError:   false,
+		},
+		{
+			name: "Update password error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE password_reset_tokens")).
+					WithArgs(sql.NullTime{Time: now, Valid: true}, tokenId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE users")).
+					WithArgs("hashed-password", now, userId).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			expectedReset: false,
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewPasswordResetTokenRepository(queries, db)
+
+			reset, err := repo.ResetPassword(context.Background(), data)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedReset, reset)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
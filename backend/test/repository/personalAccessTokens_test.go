@@ -0,0 +1,211 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/repository"
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var personalAccessTokenColumns = []string{"id", "user_id", "name", "token_hash", "token_prefix", "scopes", "expires_at", "created_at", "last_used_at"}
+
+func TestPersonalAccessTokenRepository_GetPersonalAccessTokenByHash(t *testing.T) {
+	tokenId := uuid.New()
+	userId := uuid.New()
+	teamId := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name        string
+		mockSetup   func(sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "Successfully get token with its owner",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(append(personalAccessTokenColumns, "user_role", "user_team_id", "user_role_id")).
+					AddRow(tokenId, userId, "CI pipeline", "hash", "pat_abcdef", "{tasks:read,tasks:write}", now.Add(time.Hour), now, nil, "Member", teamId, models.BuiltInRoleIds[models.UserrolesMember])
+				mock.ExpectQuery(regexp.QuoteMeta("FROM personal_access_tokens")).
+					WithArgs("hash").
+					WillReturnRows(rows)
+			},
+			expectError: false,
+		},
+		{
+			name: "Token not found",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("FROM personal_access_tokens")).
+					WithArgs("hash").
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewPersonalAccessTokenRepository(queries, db)
+
+			result, err := repo.GetPersonalAccessTokenByHash(context.Background(), "hash")
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tokenId, result.ID)
+				assert.Equal(t, []string{models.ScopeTasksRead, models.ScopeTasksWrite}, result.Scopes)
+				assert.Equal(t, models.UserrolesMember, result.UserRole)
+				assert.Equal(t, teamId, result.UserTeamId)
+				assert.Nil(t, result.LastUsedAt)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPersonalAccessTokenRepository_GetPersonalAccessTokensByUserId(t *testing.T) {
+	userId := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name          string
+		mockSetup     func(sqlmock.Sqlmock)
+		expectedCount int
+		expectError   bool
+	}{
+		{
+			name: "Successfully list tokens",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(personalAccessTokenColumns).
+					AddRow(uuid.New(), userId, "CI pipeline", "hash-1", "pat_abcdef", "{tasks:read}", now.Add(time.Hour), now, now).
+					AddRow(uuid.New(), userId, "Reports", "hash-2", "pat_ghijkl", "{projects:read}", now.Add(time.Hour), now, nil)
+				mock.ExpectQuery(regexp.QuoteMeta("FROM personal_access_tokens")).
+					WithArgs(userId).
+					WillReturnRows(rows)
+			},
+			expectedCount: 2,
+			expectError:   false,
+		},
+		{
+			name: "Database error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("FROM personal_access_tokens")).
+					WithArgs(userId).
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectedCount: 0,
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewPersonalAccessTokenRepository(queries, db)
+
+			result, err := repo.GetPersonalAccessTokensByUserId(context.Background(), userId)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Len(t, result, tt.expectedCount)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPersonalAccessTokenRepository_DeletePersonalAccessToken(t *testing.T) {
+	tokenId := uuid.New()
+	userId := uuid.New()
+
+	tests := []struct {
+		name            string
+		mockSetup       func(sqlmock.Sqlmock)
+		expectedDeleted bool
+		expectError     bool
+	}{
+		{
+			name: "Successfully delete own token",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM personal_access_tokens")).
+					WithArgs(tokenId, userId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedDeleted: true,
+			expectError:     false,
+		},
+		{
+			name: "Token of another user",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM personal_access_tokens")).
+					WithArgs(tokenId, userId).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedDeleted: false,
+			expectError:     false,
+		},
+		{
+			name: "Database error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM personal_access_tokens")).
+					WithArgs(tokenId, userId).
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectedDeleted: false,
+			expectError:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewPersonalAccessTokenRepository(queries, db)
+
+			deleted, err := repo.DeletePersonalAccessToken(context.Background(), interfaces.DeletePersonalAccessTokenData{
+				ID:     tokenId,
+				UserID: userId,
+			})
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedDeleted, deleted)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
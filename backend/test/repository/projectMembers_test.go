@@ -0,0 +1,217 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/repository"
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var projectMemberColumns = []string{"project_id", "user_id", "role", "created_at", "updated_at"}
+
+func TestProjectRepository_GetProjectMembers(t *testing.T) {
+	projectId := uuid.New()
+	ownerId := uuid.New()
+	viewerId := uuid.New()
+	now := time.Now().UTC()
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	rows := sqlmock.NewRows(append(projectMemberColumns, "username", "email")).
+		AddRow(projectId, ownerId, "Owner", now, now, "owner", "owner@example.com").
+		AddRow(projectId, viewerId, "Viewer", now, now, "viewer", "viewer@example.com")
+	mock.ExpectQuery(regexp.QuoteMeta("FROM project_members")).
+		WithArgs(projectId).
+		WillReturnRows(rows)
+
+	repo := repository.NewProjectRepository(database.New(db), db)
+
+	members, err := repo.GetProjectMembers(context.Background(), projectId)
+
+	assert.NoError(t, err)
+	assert.Len(t, members, 2)
+	assert.Equal(t, models.ProjectmemberrolesOwner, members[0].Role)
+	assert.Equal(t, "viewer@example.com", members[1].Email)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProjectRepository_GetProjectMember(t *testing.T) {
+	projectId := uuid.New()
+	userId := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name         string
+		mockSetup    func(sqlmock.Sqlmock)
+		expectError  bool
+		expectExists bool
+	}{
+		{
+			name: "Member of the project",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(projectMemberColumns).
+					AddRow(projectId, userId, "Contributor", now, now)
+				mock.ExpectQuery(regexp.QuoteMeta("FROM project_members")).
+					WithArgs(projectId, userId).
+					WillReturnRows(rows)
+			},
+			expectExists: true,
+		},
+		{
+			name: "Not a member",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("FROM project_members")).
+					WithArgs(projectId, userId).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectExists: false,
+		},
+		{
+			name: "Database error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("FROM project_members")).
+					WithArgs(projectId, userId).
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			repo := repository.NewProjectRepository(database.New(db), db)
+
+			exists, member, err := repo.GetProjectMember(context.Background(), projectId, userId)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectExists, exists)
+
+				if tt.expectExists {
+					assert.True(t, member.CanBeAssigned())
+				}
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestProjectRepository_AddProjectMember(t *testing.T) {
+	projectId := uuid.New()
+	userId := uuid.New()
+	now := time.Now().UTC()
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	rows := sqlmock.NewRows(projectMemberColumns).
+		AddRow(projectId, userId, "Viewer", now, now)
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO project_members")).
+		WithArgs(projectId, userId, "Viewer", now, now).
+		WillReturnRows(rows)
+
+	repo := repository.NewProjectRepository(database.New(db), db)
+
+	member, err := repo.AddProjectMember(context.Background(), models.ProjectMember{
+		ProjectID: projectId,
+		UserID:    userId,
+		Role:      models.ProjectmemberrolesViewer,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.ProjectmemberrolesViewer, member.Role)
+	assert.False(t, member.CanBeAssigned())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProjectRepository_RemoveProjectMember(t *testing.T) {
+	projectId := uuid.New()
+	userId := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name        string
+		mockSetup   func(sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "Tasks are unassigned and the manager handed over in one transaction",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE tasks SET user_id = NULL")).
+					WithArgs(projectId, userId, now).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM project_members")).
+					WithArgs(projectId, userId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE projects SET manager_id")).
+					WithArgs(projectId, now, userId).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Nothing is removed when a step fails",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE tasks SET user_id = NULL")).
+					WithArgs(projectId, userId, now).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM project_members")).
+					WithArgs(projectId, userId).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			repo := repository.NewProjectRepository(database.New(db), db)
+
+			err = repo.RemoveProjectMember(context.Background(), interfaces.RemoveProjectMemberData{
+				ProjectID: projectId,
+				UserID:    userId,
+				UpdatedAt: now,
+			})
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
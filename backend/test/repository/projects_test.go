@@ -42,9 +42,16 @@ func TestProjectRepository_CreateProject(t *testing.T) {
 			mockSetup: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name", "team_id", "manager_id", "status"}).
 					AddRow(projectId, now, now, "Test Project", teamId, managerId, "OnHold")
+				mock.ExpectBegin()
 				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO projects")).
 					WithArgs(now, now, "Test Project", teamId, managerId).
 					WillReturnRows(rows)
+				memberRows := sqlmock.NewRows(projectMemberColumns).
+					AddRow(projectId, managerId, "Owner", now, now)
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO project_members")).
+					WithArgs(projectId, managerId, "Owner", now, now).
+					WillReturnRows(memberRows)
+				mock.ExpectCommit()
 			},
 			expectError:  false,
 			expectedId:   projectId,
@@ -60,9 +67,11 @@ func TestProjectRepository_CreateProject(t *testing.T) {
 				UpdatedAt: now,
 			},
 			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
 				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO projects")).
 					WithArgs(now, now, "Test Project", teamId, managerId).
 					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
 			},
 			expectError: true,
 		},
@@ -243,7 +252,7 @@ func TestProjectRepository_GetProjects(t *testing.T) {
 			mockSetup: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name", "team_id", "manager_id", "status"}).
 					AddRow(projectId, now, now, "Test Project", teamId, managerId, "OnHold")
-				mock.ExpectQuery("SELECT").WillReturnRows(rows)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT p.id, p.created_at, p.updated_at, p.name, p.team_id, p.manager_id, p.status FROM projects p")).WillReturnRows(rows)
 			},
 			expectError:   false,
 			expectedCount: 1,
@@ -263,6 +272,24 @@ func TestProjectRepository_GetProjects(t *testing.T) {
 			expectError:   false,
 			expectedCount: 1,
 		},
+		{
+			name: "Get projects with member filter",
+			filters: interfaces.GetProjectsFilters{
+				TeamId:      teamId,
+				MemberId:    managerId,
+				Limit:       10,
+				IsFirstPage: true,
+			},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name", "team_id", "manager_id", "status"}).
+					AddRow(projectId, now, now, "Test Project", teamId, managerId, "InProgress")
+				mock.ExpectQuery(regexp.QuoteMeta("p.id IN (SELECT project_id FROM project_members WHERE user_id = $2)")).
+					WithArgs(teamId, managerId).
+					WillReturnRows(rows)
+			},
+			expectError:   false,
+			expectedCount: 1,
+		},
 		{
 			name: "Get projects with stats",
 			filters: interfaces.GetProjectsFilters{
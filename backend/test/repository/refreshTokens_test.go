@@ -18,6 +18,7 @@ import (
 func TestRefreshTokenRepository_CreateRefreshToken(t *testing.T) {
 	tokenId := uuid.New()
 	userId := uuid.New()
+	familyId := uuid.New()
 	now := time.Now().UTC()
 	expiresAt := now.Add(7 * 24 * time.Hour)
 
@@ -36,10 +37,11 @@ func TestRefreshTokenRepository_CreateRefreshToken(t *testing.T) {
 				ExpiresAt: expiresAt,
 				CreatedAt: now,
 				Revoked:   false,
+				FamilyID:  familyId,
 			},
 			mockSetup: func(mock sqlmock.Sqlmock) {
 				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO refresh_tokens")).
-					WithArgs(tokenId, userId, "test-refresh-token-abc123", expiresAt, now, false).
+					WithArgs(tokenId, userId, "test-refresh-token-abc123", expiresAt, now, false, familyId).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
 			expectError: false,
@@ -53,10 +55,11 @@ func TestRefreshTokenRepository_CreateRefreshToken(t *testing.T) {
 				ExpiresAt: expiresAt,
 				CreatedAt: now,
 				Revoked:   false,
+				FamilyID:  familyId,
 			},
 			mockSetup: func(mock sqlmock.Sqlmock) {
 				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO refresh_tokens")).
-					WithArgs(tokenId, userId, "test-refresh-token-def456", expiresAt, now, false).
+					WithArgs(tokenId, userId, "test-refresh-token-def456", expiresAt, now, false, familyId).
 					WillReturnError(sql.ErrConnDone)
 			},
 			expectError: true,
@@ -70,10 +73,11 @@ func TestRefreshTokenRepository_CreateRefreshToken(t *testing.T) {
 				ExpiresAt: expiresAt,
 				CreatedAt: now,
 				Revoked:   false,
+				FamilyID:  familyId,
 			},
 			mockSetup: func(mock sqlmock.Sqlmock) {
 				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO refresh_tokens")).
-					WithArgs(tokenId, userId, "duplicate-token", expiresAt, now, false).
+					WithArgs(tokenId, userId, "duplicate-token", expiresAt, now, false, familyId).
 					WillReturnError(sql.ErrNoRows)
 			},
 			expectError: true,
@@ -107,6 +111,7 @@ func TestRefreshTokenRepository_CreateRefreshToken(t *testing.T) {
 func TestRefreshTokenRepository_GetRefreshTokenByToken(t *testing.T) {
 	tokenId := uuid.New()
 	userId := uuid.New()
+	familyId := uuid.New()
 	now := time.Now().UTC()
 	expiresAt := now.Add(7 * 24 * time.Hour)
 
@@ -123,13 +128,13 @@ func TestRefreshTokenRepository_GetRefreshTokenByToken(t *testing.T) {
 			token: "valid-refresh-token",
 			mockSetup: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{
-					"id", "userid", "token", "expires_at", "created_at", "revoked",
+					"id", "userid", "token", "expires_at", "created_at", "revoked", "family_id", "used_at",
 					"userdataid", "userdatacreatedat", "userdataupdatedat",
-					"userdatausername", "userdatapassword", "userdataemail", "userdatarole",
+					"userdatausername", "userdatapassword", "userdataemail", "userdatarole", "userdataroleid",
 				}).
 					AddRow(
-						tokenId, userId, "valid-refresh-token", expiresAt, now, false,
-						userId, now, now, "testuser", "hashedpassword", "test@example.com", "user",
+						tokenId, userId, "valid-refresh-token", expiresAt, now, false, familyId, nil,
+						userId, now, now, "testuser", "hashedpassword", "test@example.com", "user", models.BuiltInRoleIds[models.UserrolesMember],
 					)
 				mock.ExpectQuery(regexp.QuoteMeta("SELECT refresh_tokens.id")).
 					WithArgs("valid-refresh-token").
@@ -164,13 +169,13 @@ func TestRefreshTokenRepository_GetRefreshTokenByToken(t *testing.T) {
 			token: "revoked-refresh-token",
 			mockSetup: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{
-					"id", "userid", "token", "expires_at", "created_at", "revoked",
+					"id", "userid", "token", "expires_at", "created_at", "revoked", "family_id", "used_at",
 					"userdataid", "userdatacreatedat", "userdataupdatedat",
-					"userdatausername", "userdatapassword", "userdataemail", "userdatarole",
+					"userdatausername", "userdatapassword", "userdataemail", "userdatarole", "userdataroleid",
 				}).
 					AddRow(
-						tokenId, userId, "revoked-refresh-token", expiresAt, now, true,
-						userId, now, now, "testuser", "hashedpassword", "test@example.com", "user",
+						tokenId, userId, "revoked-refresh-token", expiresAt, now, true, familyId, nil,
+						userId, now, now, "testuser", "hashedpassword", "test@example.com", "user", models.BuiltInRoleIds[models.UserrolesMember],
 					)
 				mock.ExpectQuery(regexp.QuoteMeta("SELECT refresh_tokens.id")).
 					WithArgs("revoked-refresh-token").
@@ -279,3 +284,156 @@ func TestRefreshTokenRepository_DeleteRefreshTokensByUserId(t *testing.T) {
 		})
 	}
 }
+
+func TestRefreshTokenRepository_RotateRefreshToken(t *testing.T) {
+	usedTokenId := uuid.New()
+	newTokenId := uuid.New()
+	userId := uuid.New()
+	familyId := uuid.New()
+	now := time.Now().UTC()
+	expiresAt := now.Add(7 * 24 * time.Hour)
+
+	newToken := models.RefreshToken{
+		ID:        newTokenId,
+		Userid:    userId,
+		Token:     "rotated-refresh-token",
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+		Revoked:   false,
+		FamilyID:  familyId,
+	}
+
+	tests := []struct {
+		name            string
+		mockSetup       func(sqlmock.Sqlmock)
+		expectError     bool
+		expectedRotated bool
+	}{
+		{
+			name: "Successfully rotate refresh token",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE refresh_tokens")).
+					WithArgs(sql.NullTime{Time: now, Valid: true}, usedTokenId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO refresh_tokens")).
+					WithArgs(newTokenId, userId, "rotated-refresh-token", expiresAt, now, false, familyId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectError:     false,
+			expectedRotated: true,
+		},
+		{
+			name: "Rotate refresh token - already used",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE refresh_tokens")).
+					WithArgs(sql.NullTime{Time: now, Valid: true}, usedTokenId).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			expectError:     false,
+			expectedRotated: false,
+		},
+		{
+			name: "Rotate refresh token - insert error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE refresh_tokens")).
+					WithArgs(sql.NullTime{Time: now, Valid: true}, usedTokenId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO refresh_tokens")).
+					WithArgs(newTokenId, userId, "rotated-refresh-token", expiresAt, now, false, familyId).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			expectError:     true,
+			expectedRotated: false,
+		},
+		{
+			name: "Rotate refresh token - begin error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin().WillReturnError(sql.ErrConnDone)
+			},
+			expectError:     true,
+			expectedRotated: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewRefreshTokenRepository(queries, db)
+
+			rotated, err := repo.RotateRefreshToken(context.Background(), usedTokenId, newToken)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedRotated, rotated)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRefreshTokenRepository_RevokeRefreshTokenFamily(t *testing.T) {
+	familyId := uuid.New()
+
+	tests := []struct {
+		name        string
+		mockSetup   func(sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "Successfully revoke refresh token family",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE refresh_tokens")).
+					WithArgs(familyId).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			expectError: false,
+		},
+		{
+			name: "Revoke refresh token family - database error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE refresh_tokens")).
+					WithArgs(familyId).
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewRefreshTokenRepository(queries, db)
+
+			err = repo.RevokeRefreshTokenFamily(context.Background(), familyId)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/TobiasRV/challenge-fs-senior/internals/repository"
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevokedSessionRepository_GetRevokedSessionIds(t *testing.T) {
+	since := time.Now().UTC().Add(-15 * time.Minute)
+	sessionIds := []uuid.UUID{uuid.New(), uuid.New()}
+
+	tests := []struct {
+		name          string
+		mockSetup     func(sqlmock.Sqlmock)
+		expectedCount int
+		expectError   bool
+	}{
+		{
+			name: "Successfully get revoked sessions",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"session_id"}).
+					AddRow(sessionIds[0]).
+					AddRow(sessionIds[1])
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT session_id FROM revoked_sessions")).
+					WithArgs(since).
+					WillReturnRows(rows)
+			},
+			expectedCount: 2,
+			expectError:   false,
+		},
+		{
+			name: "Get revoked sessions - database error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT session_id FROM revoked_sessions")).
+					WithArgs(since).
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectedCount: 0,
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewRevokedSessionRepository(queries, db)
+
+			ids, err := repo.GetRevokedSessionIds(context.Background(), since)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, sessionIds, ids)
+			}
+
+			assert.Len(t, ids, tt.expectedCount)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRevokedSessionRepository_DeleteRevokedSessionsBefore(t *testing.T) {
+	before := time.Now().UTC().Add(-15 * time.Minute)
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM revoked_sessions WHERE revoked_at <=")).
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	queries := database.New(db)
+	repo := repository.NewRevokedSessionRepository(queries, db)
+
+	assert.NoError(t, repo.DeleteRevokedSessionsBefore(context.Background(), before))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,286 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/repository"
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var roleColumns = []string{"id", "created_at", "updated_at", "team_id", "name", "permissions", "base_role"}
+
+func TestRoleRepository_CreateRole(t *testing.T) {
+	roleId := uuid.New()
+	teamId := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name        string
+		mockSetup   func(sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "Successfully create role",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(roleColumns).
+					AddRow(roleId, now, now, teamId, "QA", "{tasks:view_any,tasks:edit_any}", nil)
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO roles")).
+					WithArgs(now, now, teamId, "QA", "{\"tasks:view_any\",\"tasks:edit_any\"}").
+					WillReturnRows(rows)
+			},
+			expectError: false,
+		},
+		{
+			name: "Create role - database error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO roles")).
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewRoleRepository(queries, db)
+
+			result, err := repo.CreateRole(context.Background(), models.Role{
+				CreatedAt:   now,
+				UpdatedAt:   now,
+				TeamID:      uuid.NullUUID{UUID: teamId, Valid: true},
+				Name:        "QA",
+				Permissions: []string{models.PermissionTasksViewAny, models.PermissionTasksEditAny},
+			})
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, roleId, result.ID)
+				assert.Equal(t, []string{models.PermissionTasksViewAny, models.PermissionTasksEditAny}, result.Permissions)
+				assert.False(t, result.BuiltIn)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRoleRepository_GetRoleByName(t *testing.T) {
+	teamId := uuid.New()
+	now := time.Now().UTC()
+	managerId := models.BuiltInRoleIds[models.UserrolesManager]
+
+	tests := []struct {
+		name           string
+		mockSetup      func(sqlmock.Sqlmock)
+		expectError    bool
+		expectExists   bool
+		expectBuiltIn  bool
+		expectBaseRole models.Userroles
+	}{
+		{
+			name: "Name of a built-in role",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(roleColumns).
+					AddRow(managerId, now, now, nil, "Manager", "{users:view}", "Manager")
+				mock.ExpectQuery(regexp.QuoteMeta("FROM roles")).
+					WithArgs(teamId, "manager").
+					WillReturnRows(rows)
+			},
+			expectExists:   true,
+			expectBuiltIn:  true,
+			expectBaseRole: models.UserrolesManager,
+		},
+		{
+			name: "Unused name",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("FROM roles")).
+					WithArgs(teamId, "manager").
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectExists: false,
+		},
+		{
+			name: "Database error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("FROM roles")).
+					WithArgs(teamId, "manager").
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewRoleRepository(queries, db)
+
+			exists, role, err := repo.GetRoleByName(context.Background(), interfaces.GetRoleByNameData{TeamID: teamId, Name: "manager"})
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectExists, exists)
+				assert.Equal(t, tt.expectBuiltIn, role.BuiltIn)
+				assert.Equal(t, tt.expectBaseRole, role.BaseRole)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRoleRepository_GetRolesByTeam(t *testing.T) {
+	teamId := uuid.New()
+	now := time.Now().UTC()
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	rows := sqlmock.NewRows(roleColumns).
+		AddRow(models.BuiltInRoleIds[models.UserrolesMember], now, now, nil, "Member", "{tasks:view_assigned}", "Member").
+		AddRow(uuid.New(), now, now, teamId, "Lead", "{tasks:edit_any}", nil)
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE team_id = $1 OR team_id IS NULL")).
+		WithArgs(teamId).
+		WillReturnRows(rows)
+
+	repo := repository.NewRoleRepository(database.New(db), db)
+
+	roles, err := repo.GetRolesByTeam(context.Background(), teamId)
+
+	assert.NoError(t, err)
+	assert.Len(t, roles, 2)
+	assert.True(t, roles[0].BuiltIn)
+	assert.Equal(t, teamId, roles[1].TeamID.UUID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRoleRepository_GetCustomRoles(t *testing.T) {
+	now := time.Now().UTC()
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	rows := sqlmock.NewRows(roleColumns).
+		AddRow(uuid.New(), now, now, uuid.New(), "Lead", "{tasks:edit_any,tasks:delete_any}", nil).
+		AddRow(uuid.New(), now, now, uuid.New(), "Viewer", "{}", nil)
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE base_role IS NULL")).
+		WillReturnRows(rows)
+
+	repo := repository.NewRoleRepository(database.New(db), db)
+
+	roles, err := repo.GetCustomRoles(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, roles, 2)
+	assert.Equal(t, []string{models.PermissionTasksEditAny, models.PermissionTasksDeleteAny}, roles[0].Permissions)
+	assert.False(t, roles[1].BuiltIn)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRoleRepository_UpdateRole(t *testing.T) {
+	roleId := uuid.New()
+	teamId := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name        string
+		mockSetup   func(sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "Successfully update role",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(roleColumns).
+					AddRow(roleId, now, now, teamId, "Lead", "{tasks:edit_any}", nil)
+				mock.ExpectQuery(regexp.QuoteMeta("WHERE id = $4 AND base_role IS NULL")).
+					WithArgs("Lead", "{\"tasks:edit_any\"}", now, roleId).
+					WillReturnRows(rows)
+			},
+			expectError: false,
+		},
+		{
+			name: "Built-in role is not updated",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("WHERE id = $4 AND base_role IS NULL")).
+					WithArgs("Lead", "{\"tasks:edit_any\"}", now, roleId).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewRoleRepository(queries, db)
+
+			result, err := repo.UpdateRole(context.Background(), interfaces.UpdateRoleData{
+				Name:        "Lead",
+				Permissions: []string{models.PermissionTasksEditAny},
+				UpdatedAt:   now,
+				ID:          roleId,
+			})
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, roleId, result.ID)
+				assert.Equal(t, "Lead", result.Name)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRoleRepository_DeleteRole(t *testing.T) {
+	roleId := uuid.New()
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM roles")).
+		WithArgs(roleId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := repository.NewRoleRepository(database.New(db), db)
+
+	assert.NoError(t, repo.DeleteRole(context.Background(), roleId))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,322 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/repository"
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var sessionColumns = []string{"id", "user_id", "label", "user_agent", "ip_address", "created_at", "last_used_at", "team_id"}
+
+func TestSessionRepository_CreateSession(t *testing.T) {
+	sessionId := uuid.New()
+	userId := uuid.New()
+	teamId := uuid.New()
+	now := time.Now().UTC()
+
+	session := models.Session{
+		ID:         sessionId,
+		UserID:     userId,
+		Label:      "Work laptop",
+		UserAgent:  "Mozilla/5.0",
+		IpAddress:  "127.0.0.1",
+		TeamID:     teamId,
+		CreatedAt:  now,
+		LastUsedAt: now,
+	}
+
+	tests := []struct {
+		name        string
+		mockSetup   func(sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "Successfully create session",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(sessionColumns).
+					AddRow(sessionId, userId, "Work laptop", "Mozilla/5.0", "127.0.0.1", now, now, teamId)
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO sessions")).
+					WithArgs(sessionId, userId, "Work laptop", "Mozilla/5.0", "127.0.0.1", now, now, uuid.NullUUID{UUID: teamId, Valid: true}).
+					WillReturnRows(rows)
+			},
+			expectError: false,
+		},
+		{
+			name: "Create session - database error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO sessions")).
+					WithArgs(sessionId, userId, "Work laptop", "Mozilla/5.0", "127.0.0.1", now, now, uuid.NullUUID{UUID: teamId, Valid: true}).
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewSessionRepository(queries, db)
+
+			result, err := repo.CreateSession(context.Background(), session)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, session, result)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSessionRepository_GetSessionsByUserId(t *testing.T) {
+	userId := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name          string
+		mockSetup     func(sqlmock.Sqlmock)
+		expectedCount int
+		expectError   bool
+	}{
+		{
+			name: "Successfully get sessions",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(sessionColumns).
+					AddRow(uuid.New(), userId, "Work laptop", "Mozilla/5.0", "127.0.0.1", now, now, nil).
+					AddRow(uuid.New(), userId, "Phone", "Mobile Safari", "10.0.0.2", now, now, nil)
+				mock.ExpectQuery(regexp.QuoteMeta("FROM sessions")).
+					WithArgs(userId).
+					WillReturnRows(rows)
+			},
+			expectedCount: 2,
+			expectError:   false,
+		},
+		{
+			name: "Get sessions - no sessions",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("FROM sessions")).
+					WithArgs(userId).
+					WillReturnRows(sqlmock.NewRows(sessionColumns))
+			},
+			expectedCount: 0,
+			expectError:   false,
+		},
+		{
+			name: "Get sessions - database error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("FROM sessions")).
+					WithArgs(userId).
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewSessionRepository(queries, db)
+
+			result, err := repo.GetSessionsByUserId(context.Background(), userId)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result, tt.expectedCount)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSessionRepository_UpdateSessionLastUsed(t *testing.T) {
+	sessionId := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name        string
+		mockSetup   func(sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "Successfully update session",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE sessions")).
+					WithArgs(now, "127.0.0.1", sessionId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectError: false,
+		},
+		{
+			name: "Update session - database error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE sessions")).
+					WithArgs(now, "127.0.0.1", sessionId).
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewSessionRepository(queries, db)
+
+			err = repo.UpdateSessionLastUsed(context.Background(), interfaces.UpdateSessionLastUsedData{
+				LastUsedAt: now,
+				IpAddress:  "127.0.0.1",
+				ID:         sessionId,
+			})
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSessionRepository_UpdateSessionTeam(t *testing.T) {
+	sessionId := uuid.New()
+	teamId := uuid.New()
+
+	tests := []struct {
+		name        string
+		mockSetup   func(sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "Successfully switch the team of the session",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE sessions")).
+					WithArgs(uuid.NullUUID{UUID: teamId, Valid: true}, sessionId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectError: false,
+		},
+		{
+			name: "Switch the team of the session - database error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE sessions")).
+					WithArgs(uuid.NullUUID{UUID: teamId, Valid: true}, sessionId).
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewSessionRepository(queries, db)
+
+			err = repo.UpdateSessionTeam(context.Background(), interfaces.UpdateSessionTeamData{
+				TeamID: teamId,
+				ID:     sessionId,
+			})
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSessionRepository_DeleteSessionsByUserId(t *testing.T) {
+	userId := uuid.New()
+
+	tests := []struct {
+		name        string
+		mockSetup   func(sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "Successfully delete sessions",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM sessions WHERE user_id")).
+					WithArgs(userId).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			expectError: false,
+		},
+		{
+			name: "Delete sessions - database error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM sessions WHERE user_id")).
+					WithArgs(userId).
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewSessionRepository(queries, db)
+
+			err = repo.DeleteSessionsByUserId(context.Background(), userId)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -217,6 +217,23 @@ func TestTaskRepository_GetTasks(t *testing.T) {
 			expectError:   false,
 			expectedCount: 1,
 		},
+		{
+			name: "Get tasks with member filter",
+			filters: interfaces.GetTasksFilters{
+				MemberId:    userId,
+				Limit:       10,
+				IsFirstPage: true,
+			},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "project_id", "user_id", "status", "title", "description", "name", "username"}).
+					AddRow(taskId, now, now, projectId, nil, "ToDo", "Project Task", "Description", "Project Name", nil)
+				mock.ExpectQuery(regexp.QuoteMeta("t.project_id IN (SELECT project_id FROM project_members WHERE user_id = $1)")).
+					WithArgs(userId).
+					WillReturnRows(rows)
+			},
+			expectError:   false,
+			expectedCount: 1,
+		},
 		{
 			name: "Get tasks with user filter",
 			filters: interfaces.GetTasksFilters{
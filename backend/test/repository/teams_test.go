@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
 	"github.com/TobiasRV/challenge-fs-senior/internals/models"
 	"github.com/TobiasRV/challenge-fs-senior/internals/repository"
 	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
@@ -18,8 +19,11 @@ import (
 func TestTeamsRepository_CreateTeam(t *testing.T) {
 	teamId := uuid.New()
 	ownerId := uuid.New()
+	organizationId := uuid.New()
 	now := time.Now().UTC()
 
+	teamColumns := []string{"id", "created_at", "updated_at", "name", "owner_id", "require_mfa", "organization_id"}
+
 	tests := []struct {
 		name         string
 		teamData     models.Team
@@ -29,7 +33,7 @@ func TestTeamsRepository_CreateTeam(t *testing.T) {
 		expectedName string
 	}{
 		{
-			name: "Successfully create team",
+			name: "Successfully create team - wraps it in an organization of its own",
 			teamData: models.Team{
 				Name:      "Test Team",
 				OwnerID:   ownerId,
@@ -37,11 +41,42 @@ func TestTeamsRepository_CreateTeam(t *testing.T) {
 				UpdatedAt: now,
 			},
 			mockSetup: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name", "owner_id"}).
-					AddRow(teamId, now, now, "Test Team", ownerId)
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO organizations")).
+					WithArgs(now, now, "Test Team", false).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name", "require_mfa"}).
+						AddRow(organizationId, now, now, "Test Team", false))
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO organization_admins")).
+					WithArgs(organizationId, ownerId, now).
+					WillReturnRows(sqlmock.NewRows([]string{"organization_id", "user_id", "created_at"}).
+						AddRow(organizationId, ownerId, now))
 				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO teams")).
-					WithArgs(now, now, "Test Team", ownerId).
-					WillReturnRows(rows)
+					WithArgs(now, now, "Test Team", ownerId, organizationId, false).
+					WillReturnRows(sqlmock.NewRows(teamColumns).
+						AddRow(teamId, now, now, "Test Team", ownerId, false, organizationId))
+				mock.ExpectCommit()
+			},
+			expectError:  false,
+			expectedId:   teamId,
+			expectedName: "Test Team",
+		},
+		{
+			name: "Successfully create team in an existing organization",
+			teamData: models.Team{
+				Name:           "Test Team",
+				OwnerID:        ownerId,
+				OrganizationID: organizationId,
+				RequireMfa:     true,
+				CreatedAt:      now,
+				UpdatedAt:      now,
+			},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO teams")).
+					WithArgs(now, now, "Test Team", ownerId, organizationId, true).
+					WillReturnRows(sqlmock.NewRows(teamColumns).
+						AddRow(teamId, now, now, "Test Team", ownerId, true, organizationId))
+				mock.ExpectCommit()
 			},
 			expectError:  false,
 			expectedId:   teamId,
@@ -50,20 +85,23 @@ func TestTeamsRepository_CreateTeam(t *testing.T) {
 		{
 			name: "Database error on create",
 			teamData: models.Team{
-				Name:      "Test Team",
-				OwnerID:   ownerId,
-				CreatedAt: now,
-				UpdatedAt: now,
+				Name:           "Test Team",
+				OwnerID:        ownerId,
+				OrganizationID: organizationId,
+				CreatedAt:      now,
+				UpdatedAt:      now,
 			},
 			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
 				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO teams")).
-					WithArgs(now, now, "Test Team", ownerId).
+					WithArgs(now, now, "Test Team", ownerId, organizationId, false).
 					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
 			},
 			expectError: true,
 		},
 		{
-			name: "Duplicate owner error",
+			name: "Error creating the organization - rolls back",
 			teamData: models.Team{
 				Name:      "Another Team",
 				OwnerID:   ownerId,
@@ -71,9 +109,11 @@ func TestTeamsRepository_CreateTeam(t *testing.T) {
 				UpdatedAt: now,
 			},
 			mockSetup: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO teams")).
-					WithArgs(now, now, "Another Team", ownerId).
-					WillReturnError(sql.ErrNoRows)
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO organizations")).
+					WithArgs(now, now, "Another Team", false).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
 			},
 			expectError: true,
 		},
@@ -109,6 +149,7 @@ func TestTeamsRepository_CreateTeam(t *testing.T) {
 func TestTeamsRepository_GetTeamByOwner(t *testing.T) {
 	teamId := uuid.New()
 	ownerId := uuid.New()
+	organizationId := uuid.New()
 	now := time.Now().UTC()
 
 	tests := []struct {
@@ -123,9 +164,9 @@ func TestTeamsRepository_GetTeamByOwner(t *testing.T) {
 			name:    "Successfully get team by owner - team exists",
 			ownerId: ownerId,
 			mockSetup: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name", "owner_id"}).
-					AddRow(teamId, now, now, "Test Team", ownerId)
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, created_at, updated_at, name, owner_id FROM teams")).
+				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name", "owner_id", "require_mfa", "organization_id"}).
+					AddRow(teamId, now, now, "Test Team", ownerId, false, organizationId)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, created_at, updated_at, name, owner_id, require_mfa, organization_id FROM teams")).
 					WithArgs(ownerId).
 					WillReturnRows(rows)
 			},
@@ -137,7 +178,7 @@ func TestTeamsRepository_GetTeamByOwner(t *testing.T) {
 			name:    "Team not found - returns exists: false",
 			ownerId: ownerId,
 			mockSetup: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, created_at, updated_at, name, owner_id FROM teams")).
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, created_at, updated_at, name, owner_id, require_mfa, organization_id FROM teams")).
 					WithArgs(ownerId).
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -148,7 +189,7 @@ func TestTeamsRepository_GetTeamByOwner(t *testing.T) {
 			name:    "Database connection error",
 			ownerId: ownerId,
 			mockSetup: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, created_at, updated_at, name, owner_id FROM teams")).
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, created_at, updated_at, name, owner_id, require_mfa, organization_id FROM teams")).
 					WithArgs(ownerId).
 					WillReturnError(sql.ErrConnDone)
 			},
@@ -183,3 +224,540 @@ func TestTeamsRepository_GetTeamByOwner(t *testing.T) {
 		})
 	}
 }
+
+func TestTeamsRepository_SetTeamRequireMfa(t *testing.T) {
+	teamId := uuid.New()
+	ownerId := uuid.New()
+	organizationId := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name        string
+		requireMfa  bool
+		mockSetup   func(sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name:       "Require two-factor authentication - revokes sessions without it",
+			requireMfa: true,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name", "owner_id", "require_mfa", "organization_id"}).
+					AddRow(teamId, now, now, "Test Team", ownerId, true, organizationId)
+				mock.ExpectQuery(regexp.QuoteMeta("UPDATE teams")).
+					WithArgs(true, now, teamId).
+					WillReturnRows(rows)
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM sessions")).
+					WithArgs(uuid.NullUUID{UUID: teamId, Valid: true}).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			expectError: false,
+		},
+		{
+			name:       "Stop requiring two-factor authentication",
+			requireMfa: false,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name", "owner_id", "require_mfa", "organization_id"}).
+					AddRow(teamId, now, now, "Test Team", ownerId, false, organizationId)
+				mock.ExpectQuery(regexp.QuoteMeta("UPDATE teams")).
+					WithArgs(false, now, teamId).
+					WillReturnRows(rows)
+				mock.ExpectCommit()
+			},
+			expectError: false,
+		},
+		{
+			name:       "Error revoking sessions - rolls back",
+			requireMfa: true,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name", "owner_id", "require_mfa", "organization_id"}).
+					AddRow(teamId, now, now, "Test Team", ownerId, true, organizationId)
+				mock.ExpectQuery(regexp.QuoteMeta("UPDATE teams")).
+					WithArgs(true, now, teamId).
+					WillReturnRows(rows)
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM sessions")).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewTeamsRepository(queries, db)
+
+			team, err := repo.SetTeamRequireMfa(context.Background(), interfaces.SetTeamRequireMfaData{
+				TeamID:     teamId,
+				RequireMfa: tt.requireMfa,
+				UpdatedAt:  now,
+			})
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.requireMfa, team.RequireMfa)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTeamsRepository_GetTeams(t *testing.T) {
+	ownerId := uuid.New()
+	organizationId := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name          string
+		filter        models.TeamFilter
+		mockSetup     func(sqlmock.Sqlmock)
+		expectError   bool
+		expectedNames []string
+	}{
+		{
+			name: "First page of the teams of the owner",
+			filter: models.TeamFilter{
+				Limit:       10,
+				IsFirstPage: true,
+				UserId:      ownerId,
+			},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name", "owner_id", "require_mfa", "organization_id"}).
+					AddRow(uuid.New(), now, now, "Team A", ownerId, false, organizationId).
+					AddRow(uuid.New(), now, now, "Team B", ownerId, false, organizationId)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, created_at, updated_at, name, owner_id, require_mfa, organization_id FROM teams WHERE owner_id = $1 ORDER BY created_at ASC, id ASC LIMIT 11")).
+					WithArgs(ownerId).
+					WillReturnRows(rows)
+			},
+			expectedNames: []string{"Team A", "Team B"},
+		},
+		{
+			name: "Previous page filtered by name comes back in order",
+			filter: models.TeamFilter{
+				Limit:           10,
+				CursorCreatedAt: now,
+				CursorId:        uuid.Nil,
+				Name:            "Team",
+				UserId:          ownerId,
+			},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name", "owner_id", "require_mfa", "organization_id"}).
+					AddRow(uuid.New(), now, now, "Team B", ownerId, false, organizationId).
+					AddRow(uuid.New(), now, now, "Team A", ownerId, false, organizationId)
+				mock.ExpectQuery(regexp.QuoteMeta("LOWER(name) LIKE $5 ORDER BY created_at DESC, id DESC LIMIT 11")).
+					WithArgs(ownerId, now, now, uuid.Nil, "%team%").
+					WillReturnRows(rows)
+			},
+			expectedNames: []string{"Team A", "Team B"},
+		},
+		{
+			name: "Database error",
+			filter: models.TeamFilter{
+				Limit:       10,
+				IsFirstPage: true,
+				UserId:      ownerId,
+			},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("FROM teams")).
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewTeamsRepository(queries, db)
+
+			teams, err := repo.GetTeams(context.Background(), tt.filter)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				names := []string{}
+				for _, team := range teams {
+					names = append(names, team.Name)
+				}
+				assert.Equal(t, tt.expectedNames, names)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTeamsRepository_TransferTeamOwnership(t *testing.T) {
+	teamId := uuid.New()
+	newOwnerId := uuid.New()
+	organizationId := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name        string
+		mockSetup   func(sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "Successfully transfer the ownership",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("UPDATE teams")).
+					WithArgs(newOwnerId, now, teamId).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name", "owner_id", "require_mfa", "organization_id"}).
+						AddRow(teamId, now, now, "Test Team", newOwnerId, false, organizationId))
+			},
+		},
+		{
+			name: "Transfer the ownership - database error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("UPDATE teams")).
+					WithArgs(newOwnerId, now, teamId).
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewTeamsRepository(queries, db)
+
+			team, err := repo.TransferTeamOwnership(context.Background(), interfaces.TransferTeamOwnershipData{
+				TeamID:    teamId,
+				OwnerID:   newOwnerId,
+				UpdatedAt: now,
+			})
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, newOwnerId, team.OwnerID)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTeamsRepository_DeleteTeam(t *testing.T) {
+	teamId := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name        string
+		mockSetup   func(sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "Logs out the sessions in the team and moves its users before deleting it",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM sessions")).
+					WithArgs(uuid.NullUUID{UUID: teamId, Valid: true}).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE users")).
+					WithArgs(now, teamId, uuid.NullUUID{}).
+					WillReturnResult(sqlmock.NewResult(0, 4))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM teams")).
+					WithArgs(teamId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Error deleting the team - rolls back",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM sessions")).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE users")).
+					WillReturnResult(sqlmock.NewResult(0, 4))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM teams")).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewTeamsRepository(queries, db)
+
+			err = repo.DeleteTeam(context.Background(), interfaces.DeleteTeamData{
+				TeamID:    teamId,
+				UpdatedAt: now,
+			})
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+var teamMembershipColumns = []string{"team_id", "user_id", "role", "role_id", "created_at", "updated_at"}
+
+func TestTeamsRepository_GetTeamMember(t *testing.T) {
+	teamId := uuid.New()
+	userId := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name           string
+		mockSetup      func(sqlmock.Sqlmock)
+		expectedExists bool
+		expectedRoleId uuid.UUID
+		expectError    bool
+	}{
+		{
+			name: "Member with a built-in role",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("FROM team_memberships")).
+					WithArgs(teamId, userId).
+					WillReturnRows(sqlmock.NewRows(teamMembershipColumns).
+						AddRow(teamId, userId, "Manager", nil, now, now))
+			},
+			expectedExists: true,
+			expectedRoleId: models.BuiltInRoleIds[models.UserrolesManager],
+		},
+		{
+			name: "Not a member",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("FROM team_memberships")).
+					WithArgs(teamId, userId).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedExists: false,
+		},
+		{
+			name: "Get member - database error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("FROM team_memberships")).
+					WithArgs(teamId, userId).
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewTeamsRepository(queries, db)
+
+			exists, member, err := repo.GetTeamMember(context.Background(), teamId, userId)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedExists, exists)
+				assert.Equal(t, tt.expectedRoleId, member.RoleId)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTeamsRepository_UpdateTeamMemberRole(t *testing.T) {
+	teamId := uuid.New()
+	userId := uuid.New()
+	roleId := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name        string
+		mockSetup   func(sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "Changes the role in the team and logs the user out",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta("UPDATE team_memberships")).
+					WithArgs(database.UserrolesMember, uuid.NullUUID{UUID: roleId, Valid: true}, now, teamId, userId).
+					WillReturnRows(sqlmock.NewRows(teamMembershipColumns).
+						AddRow(teamId, userId, "Member", roleId, now, now))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM sessions WHERE user_id")).
+					WithArgs(userId).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Error logging the user out - rolls back",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta("UPDATE team_memberships")).
+					WillReturnRows(sqlmock.NewRows(teamMembershipColumns).
+						AddRow(teamId, userId, "Member", roleId, now, now))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM sessions WHERE user_id")).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewTeamsRepository(queries, db)
+
+			member, err := repo.UpdateTeamMemberRole(context.Background(), interfaces.UpdateTeamMemberRoleData{
+				TeamID:    teamId,
+				UserID:    userId,
+				Role:      models.UserrolesMember,
+				RoleId:    roleId,
+				UpdatedAt: now,
+			})
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, roleId, member.RoleId)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTeamsRepository_RemoveTeamMember(t *testing.T) {
+	teamId := uuid.New()
+	userId := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name        string
+		mockSetup   func(sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "Takes the user out of the work of the team before removing the membership",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE tasks")).
+					WithArgs(teamId, userId, now).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM project_members")).
+					WithArgs(teamId, userId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE projects")).
+					WithArgs(now, teamId, userId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM sessions")).
+					WithArgs(userId, uuid.NullUUID{UUID: teamId, Valid: true}).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE users")).
+					WithArgs(now, teamId, uuid.NullUUID{UUID: userId, Valid: true}).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM team_memberships")).
+					WithArgs(teamId, userId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Error removing the membership - rolls back",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE tasks")).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM project_members")).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE projects")).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM sessions")).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE users")).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM team_memberships")).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewTeamsRepository(queries, db)
+
+			err = repo.RemoveTeamMember(context.Background(), interfaces.RemoveTeamMemberData{
+				TeamID:    teamId,
+				UserID:    userId,
+				UpdatedAt: now,
+			})
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,349 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/TobiasRV/challenge-fs-senior/internals/db"
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/repository"
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/TobiasRV/challenge-fs-senior/internals/tenant"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func expectTenantScope(mock sqlmock.Sqlmock, teamId uuid.UUID) {
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SET LOCAL ROLE app_tenant")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("set_config('app.current_team_id'")).
+		WithArgs(teamId.String()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func TestTenancy_ScopedQueries(t *testing.T) {
+	projectId := uuid.New()
+	taskId := uuid.New()
+	teamId := uuid.New()
+	managerId := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		mockSetup   func(sqlmock.Sqlmock)
+		run         func(*database.Queries, *sql.DB, context.Context) error
+		expectError bool
+	}{
+		{
+			name: "Projects are listed in a transaction scoped to the team",
+			ctx:  tenant.WithTeam(context.Background(), teamId),
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectTenantScope(mock, teamId)
+				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name", "team_id", "manager_id", "status"}).
+					AddRow(projectId, now, now, "Test Project", teamId, managerId, "OnHold")
+				mock.ExpectQuery("SELECT").WillReturnRows(rows)
+				mock.ExpectCommit()
+			},
+			run: func(queries *database.Queries, conn *sql.DB, c context.Context) error {
+				_, err := repository.NewProjectRepository(queries, conn).GetProjects(c, interfaces.GetProjectsFilters{Limit: 10, IsFirstPage: true})
+				return err
+			},
+		},
+		{
+			name: "Projects are listed on the pool without a team",
+			ctx:  context.Background(),
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name", "team_id", "manager_id", "status"}).
+					AddRow(projectId, now, now, "Test Project", teamId, managerId, "OnHold")
+				mock.ExpectQuery("SELECT").WillReturnRows(rows)
+			},
+			run: func(queries *database.Queries, conn *sql.DB, c context.Context) error {
+				_, err := repository.NewProjectRepository(queries, conn).GetProjects(c, interfaces.GetProjectsFilters{Limit: 10, IsFirstPage: true})
+				return err
+			},
+		},
+		{
+			name: "Project is looked up in a transaction scoped to the team",
+			ctx:  tenant.WithTeam(context.Background(), teamId),
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectTenantScope(mock, teamId)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, created_at, updated_at, name, team_id, manager_id, status FROM projects")).
+					WithArgs(projectId).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			run: func(queries *database.Queries, conn *sql.DB, c context.Context) error {
+				_, err := repository.NewProjectRepository(queries, conn).GetProjectById(c, projectId)
+				return err
+			},
+			expectError: true,
+		},
+		{
+			name: "Task is deleted in a transaction scoped to the team",
+			ctx:  tenant.WithTeam(context.Background(), teamId),
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectTenantScope(mock, teamId)
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM tasks")).
+					WithArgs(taskId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			run: func(queries *database.Queries, conn *sql.DB, c context.Context) error {
+				return repository.NewTaskRepository(queries, conn).DeleteTask(c, taskId)
+			},
+		},
+		{
+			name: "The admin deleting a task is set on the transaction for the audit log",
+			ctx:  tenant.WithActingAdmin(tenant.WithTeam(context.Background(), teamId), managerId),
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectTenantScope(mock, teamId)
+				mock.ExpectExec(regexp.QuoteMeta("set_config('app.acting_admin_id'")).
+					WithArgs(managerId.String()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM tasks")).
+					WithArgs(taskId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			run: func(queries *database.Queries, conn *sql.DB, c context.Context) error {
+				return repository.NewTaskRepository(queries, conn).DeleteTask(c, taskId)
+			},
+		},
+		{
+			name: "Tasks are listed in a transaction scoped to the team",
+			ctx:  tenant.WithTeam(context.Background(), teamId),
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectTenantScope(mock, teamId)
+				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "project_id", "user_id", "status", "title", "description", "name", "username"}).
+					AddRow(taskId, now, now, projectId, managerId, "ToDo", "Test Task", "Description", "Test Project", "manager")
+				mock.ExpectQuery("SELECT").WillReturnRows(rows)
+				mock.ExpectCommit()
+			},
+			run: func(queries *database.Queries, conn *sql.DB, c context.Context) error {
+				_, err := repository.NewTaskRepository(queries, conn).GetTasks(c, interfaces.GetTasksFilters{Limit: 10, IsFirstPage: true})
+				return err
+			},
+		},
+		{
+			name: "Users are listed in a transaction scoped to the team",
+			ctx:  tenant.WithTeam(context.Background(), teamId),
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectTenantScope(mock, teamId)
+				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id", "role_id", "deactivated_at"}).
+					AddRow(managerId, now, now, "manager", "hashed", "manager@test.com", "Manager", teamId, models.BuiltInRoleIds[models.UserrolesManager], nil)
+				mock.ExpectQuery("SELECT").WillReturnRows(rows)
+				mock.ExpectCommit()
+			},
+			run: func(queries *database.Queries, conn *sql.DB, c context.Context) error {
+				_, err := repository.NewUserRepository(queries, conn).GetUsers(c, interfaces.GetUserFilters{Limit: 10, IsFirstPage: true})
+				return err
+			},
+		},
+		{
+			// The auth middleware scopes every request, a request that reaches the database without a team would see all of them
+			name:      "Project isn't looked up for a request that isn't scoped",
+			ctx:       &fasthttp.RequestCtx{},
+			mockSetup: func(mock sqlmock.Sqlmock) {},
+			run: func(queries *database.Queries, conn *sql.DB, c context.Context) error {
+				_, err := repository.NewProjectRepository(queries, conn).GetProjectById(c, projectId)
+				return err
+			},
+			expectError: true,
+		},
+		{
+			name:      "Project isn't created for a request that isn't scoped",
+			ctx:       &fasthttp.RequestCtx{},
+			mockSetup: func(mock sqlmock.Sqlmock) {},
+			run: func(queries *database.Queries, conn *sql.DB, c context.Context) error {
+				_, err := repository.NewProjectRepository(queries, conn).CreateProject(c, database.CreateProjectParams{Name: "Test Project", TeamID: teamId, ManagerID: managerId, CreatedAt: now, UpdatedAt: now})
+				return err
+			},
+			expectError: true,
+		},
+		{
+			name: "Scoping the transaction fails",
+			ctx:  tenant.WithTeam(context.Background(), teamId),
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("SET LOCAL ROLE app_tenant")).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			run: func(queries *database.Queries, conn *sql.DB, c context.Context) error {
+				return repository.NewTaskRepository(queries, conn).DeleteTask(c, taskId)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer conn.Close()
+
+			tt.mockSetup(mock)
+
+			err = tt.run(database.New(conn), conn, tt.ctx)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// rlsTeam is a team seeded in the test database with an admin, a manager, a project and a task
+type rlsTeam struct {
+	teamId    uuid.UUID
+	adminId   uuid.UUID
+	managerId uuid.UUID
+	projectId uuid.UUID
+	taskId    uuid.UUID
+}
+
+func seedRlsTeam(t *testing.T, conn *sql.DB) rlsTeam {
+	team := rlsTeam{
+		teamId:    uuid.New(),
+		adminId:   uuid.New(),
+		managerId: uuid.New(),
+		projectId: uuid.New(),
+		taskId:    uuid.New(),
+	}
+	now := time.Now().UTC()
+
+	statements := []struct {
+		query string
+		args  []any
+	}{
+		{
+			"INSERT INTO users (id, created_at, updated_at, username, password, email, role) VALUES ($1, $2, $2, $3, 'hashed', $4, 'Admin')",
+			[]any{team.adminId, now, "admin-" + team.adminId.String(), team.adminId.String() + "@rls.test"},
+		},
+		{
+			"INSERT INTO teams (id, created_at, updated_at, name, owner_id) VALUES ($1, $2, $2, $3, $4)",
+			[]any{team.teamId, now, "team-" + team.teamId.String(), team.adminId},
+		},
+		{
+			"INSERT INTO users (id, created_at, updated_at, username, password, email, role, team_id) VALUES ($1, $2, $2, $3, 'hashed', $4, 'Manager', $5)",
+			[]any{team.managerId, now, "manager-" + team.managerId.String(), team.managerId.String() + "@rls.test", team.teamId},
+		},
+		{
+			"INSERT INTO projects (id, created_at, updated_at, name, team_id, manager_id) VALUES ($1, $2, $2, 'Project', $3, $4)",
+			[]any{team.projectId, now, team.teamId, team.managerId},
+		},
+		{
+			"INSERT INTO tasks (id, created_at, updated_at, project_id, user_id, title) VALUES ($1, $2, $2, $3, $4, 'Task')",
+			[]any{team.taskId, now, team.projectId, team.managerId},
+		},
+	}
+
+	for _, statement := range statements {
+		_, err := conn.Exec(statement.query, statement.args...)
+		require.NoError(t, err)
+	}
+
+	t.Cleanup(func() {
+		// Teams, projects and tasks go with the users that own them
+		conn.Exec("DELETE FROM users WHERE id = ANY($1::uuid[])", "{"+team.adminId.String()+","+team.managerId.String()+"}")
+	})
+
+	return team
+}
+
+// TestTenancy_RowLevelSecurity runs against a real database, set TEST_DB_URL to a postgres the tests
+// may write to. The queries aren't filtered by team, the policies are the only thing isolating them
+func TestTenancy_RowLevelSecurity(t *testing.T) {
+	dbUrl := os.Getenv("TEST_DB_URL")
+	if dbUrl == "" {
+		t.Skip("TEST_DB_URL is not set")
+	}
+
+	conn, err := sql.Open("postgres", dbUrl)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, db.Migrate(conn))
+
+	queries := database.New(conn)
+	projectRepository := repository.NewProjectRepository(queries, conn)
+	taskRepository := repository.NewTaskRepository(queries, conn)
+	userRepository := repository.NewUserRepository(queries, conn)
+
+	teamA := seedRlsTeam(t, conn)
+	teamB := seedRlsTeam(t, conn)
+
+	ctxA := tenant.WithTeam(context.Background(), teamA.teamId)
+
+	t.Run("Lists only have the rows of the team", func(t *testing.T) {
+		projects, err := projectRepository.GetProjects(ctxA, interfaces.GetProjectsFilters{Limit: 1000, IsFirstPage: true})
+		require.NoError(t, err)
+		require.Len(t, projects, 1)
+		assert.Equal(t, teamA.projectId, projects[0].ID)
+
+		tasks, err := taskRepository.GetTasks(ctxA, interfaces.GetTasksFilters{Limit: 1000, IsFirstPage: true})
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		assert.Equal(t, teamA.taskId, tasks[0].ID)
+
+		users, err := userRepository.GetUsers(ctxA, interfaces.GetUserFilters{Limit: 1000, IsFirstPage: true})
+		require.NoError(t, err)
+		ids := []uuid.UUID{}
+		for _, user := range users {
+			ids = append(ids, user.ID)
+		}
+		assert.ElementsMatch(t, []uuid.UUID{teamA.adminId, teamA.managerId}, ids)
+	})
+
+	t.Run("Rows of another team are not found", func(t *testing.T) {
+		_, err := projectRepository.GetProjectById(ctxA, teamB.projectId)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+
+		_, err = taskRepository.GetTaskById(ctxA, teamB.taskId)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+
+		_, err = projectRepository.UpdateProject(ctxA, interfaces.UpdateProjectData{ID: teamB.projectId, Name: "Hijacked", Status: models.ProjectstatusOnHold, UpdatedAt: time.Now().UTC()})
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("Rows of another team can't be deleted", func(t *testing.T) {
+		assert.NoError(t, taskRepository.DeleteTask(ctxA, teamB.taskId))
+		assert.NoError(t, projectRepository.DeleteProject(ctxA, teamB.projectId))
+
+		_, err := taskRepository.GetTaskById(context.Background(), teamB.taskId)
+		assert.NoError(t, err)
+
+		_, err = projectRepository.GetProjectById(context.Background(), teamB.projectId)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Rows can't be written into another team", func(t *testing.T) {
+		now := time.Now().UTC()
+
+		_, err := projectRepository.CreateProject(ctxA, database.CreateProjectParams{Name: "Planted", TeamID: teamB.teamId, ManagerID: teamA.managerId, CreatedAt: now, UpdatedAt: now})
+		assert.Error(t, err)
+
+		_, err = taskRepository.CreateTask(ctxA, database.CreateTasksParams{ProjectID: teamB.projectId, UserID: uuid.NullUUID{UUID: teamA.managerId, Valid: true}, Title: "Planted", CreatedAt: now, UpdatedAt: now})
+		assert.Error(t, err)
+	})
+
+	t.Run("Requests without a team see nothing", func(t *testing.T) {
+		projects, err := projectRepository.GetProjects(tenant.WithTeam(context.Background(), uuid.Nil), interfaces.GetProjectsFilters{Limit: 1000, IsFirstPage: true})
+		require.NoError(t, err)
+		assert.Empty(t, projects)
+	})
+}
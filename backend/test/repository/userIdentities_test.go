@@ -0,0 +1,165 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/TobiasRV/challenge-fs-senior/internals/interfaces"
+	"github.com/TobiasRV/challenge-fs-senior/internals/models"
+	"github.com/TobiasRV/challenge-fs-senior/internals/repository"
+	"github.com/TobiasRV/challenge-fs-senior/internals/sqlc/database"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserIdentityRepository_GetUserIdentity(t *testing.T) {
+	identityId := uuid.New()
+	userId := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name        string
+		mockSetup   func(sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "Successfully get identity",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "issuer", "subject", "email", "created_at"}).
+					AddRow(identityId, userId, "https://idp.example.com", "idp-user-1", "sso@example.com", now)
+				mock.ExpectQuery(regexp.QuoteMeta("FROM user_identities")).
+					WithArgs("https://idp.example.com", "idp-user-1").
+					WillReturnRows(rows)
+			},
+			expectError: false,
+		},
+		{
+			name: "Identity not linked",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("FROM user_identities")).
+					WithArgs("https://idp.example.com", "idp-user-1").
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewUserIdentityRepository(queries, db)
+
+			result, err := repo.GetUserIdentity(context.Background(), interfaces.GetUserIdentityData{
+				Issuer:  "https://idp.example.com",
+				Subject: "idp-user-1",
+			})
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, userId, result.UserID)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserIdentityRepository_ProvisionUser(t *testing.T) {
+	identityId := uuid.New()
+	userId := uuid.New()
+	teamId := uuid.New()
+	now := time.Now().UTC()
+
+	data := interfaces.ProvisionUserData{
+		User: models.User{
+			CreatedAt: now,
+			UpdatedAt: now,
+			Username:  "Sso User",
+			Password:  "hashed-password",
+			Email:     "sso@example.com",
+			Role:      models.UserrolesMember,
+			TeamId:    teamId,
+		},
+		Identity: models.UserIdentity{
+			ID:        identityId,
+			Issuer:    "https://idp.example.com",
+			Subject:   "idp-user-1",
+			Email:     "sso@example.com",
+			CreatedAt: now,
+		},
+	}
+
+	expectCreateUser := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO users")).
+			WithArgs(now, now, "Sso User", "hashed-password", "sso@example.com", database.UserrolesMember, uuid.NullUUID{UUID: teamId, Valid: true}).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id", "role_id", "deactivated_at"}).
+				AddRow(userId, now, now, "Sso User", "hashed-password", "sso@example.com", "Member", teamId, models.BuiltInRoleIds[models.UserrolesMember], nil))
+	}
+
+	tests := []struct {
+		name        string
+		mockSetup   func(sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "Creates the user and links the identity",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectCreateUser(mock)
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO user_identities")).
+					WithArgs(identityId, userId, "https://idp.example.com", "idp-user-1", "sso@example.com", now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectError: false,
+		},
+		{
+			name: "Rolls back the user when the link fails",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectCreateUser(mock)
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO user_identities")).
+					WithArgs(identityId, userId, "https://idp.example.com", "idp-user-1", "sso@example.com", now).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewUserIdentityRepository(queries, db)
+
+			user, err := repo.ProvisionUser(context.Background(), data)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, userId, user.ID)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -40,8 +40,8 @@ func TestUserRepository_CreateUser(t *testing.T) {
 				UpdatedAt: now,
 			},
 			mockSetup: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id"}).
-					AddRow(userId, now, now, "testuser", "hashedpassword", "test@example.com", "Member", teamId)
+				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id", "role_id", "deactivated_at"}).
+					AddRow(userId, now, now, "testuser", "hashedpassword", "test@example.com", "Member", teamId, models.BuiltInRoleIds[models.UserrolesMember], nil)
 				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO users")).
 					WithArgs(now, now, "testuser", "hashedpassword", "test@example.com", database.UserrolesMember, sqlmock.AnyArg()).
 					WillReturnRows(rows)
@@ -60,8 +60,8 @@ func TestUserRepository_CreateUser(t *testing.T) {
 				UpdatedAt: now,
 			},
 			mockSetup: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id"}).
-					AddRow(userId, now, now, "admin", "hashedpassword", "admin@example.com", "Admin", nil)
+				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id", "role_id", "deactivated_at"}).
+					AddRow(userId, now, now, "admin", "hashedpassword", "admin@example.com", "Admin", nil, models.BuiltInRoleIds[models.UserrolesAdmin], nil)
 				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO users")).
 					WithArgs(now, now, "admin", "hashedpassword", "admin@example.com", database.UserrolesAdmin, sqlmock.AnyArg()).
 					WillReturnRows(rows)
@@ -148,9 +148,9 @@ func TestUserRepository_GetUserByEmail(t *testing.T) {
 			name:  "Successfully get user by email",
 			email: "test@example.com",
 			mockSetup: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id"}).
-					AddRow(userId, now, now, "testuser", "hashedpassword", "test@example.com", "Member", teamId)
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, created_at, updated_at, username, password, email, role, team_id FROM users")).
+				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id", "role_id", "deactivated_at"}).
+					AddRow(userId, now, now, "testuser", "hashedpassword", "test@example.com", "Member", teamId, models.BuiltInRoleIds[models.UserrolesMember], nil)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, created_at, updated_at, username, password, email, role, team_id, role_id, deactivated_at FROM users")).
 					WithArgs("test@example.com").
 					WillReturnRows(rows)
 			},
@@ -161,7 +161,7 @@ func TestUserRepository_GetUserByEmail(t *testing.T) {
 			name:  "User not found",
 			email: "notfound@example.com",
 			mockSetup: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, created_at, updated_at, username, password, email, role, team_id FROM users")).
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, created_at, updated_at, username, password, email, role, team_id, role_id, deactivated_at FROM users")).
 					WithArgs("notfound@example.com").
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -171,7 +171,7 @@ func TestUserRepository_GetUserByEmail(t *testing.T) {
 			name:  "Database connection error",
 			email: "test@example.com",
 			mockSetup: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, created_at, updated_at, username, password, email, role, team_id FROM users")).
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, created_at, updated_at, username, password, email, role, team_id, role_id, deactivated_at FROM users")).
 					WithArgs("test@example.com").
 					WillReturnError(sql.ErrConnDone)
 			},
@@ -220,9 +220,9 @@ func TestUserRepository_GetUserById(t *testing.T) {
 			name:   "Successfully get user by ID",
 			userId: userId,
 			mockSetup: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id"}).
-					AddRow(userId, now, now, "testuser", "hashedpassword", "test@example.com", "Member", teamId)
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, created_at, updated_at, username, password, email, role, team_id FROM users")).
+				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id", "role_id", "deactivated_at"}).
+					AddRow(userId, now, now, "testuser", "hashedpassword", "test@example.com", "Member", teamId, models.BuiltInRoleIds[models.UserrolesMember], nil)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, created_at, updated_at, username, password, email, role, team_id, role_id, deactivated_at FROM users")).
 					WithArgs(userId).
 					WillReturnRows(rows)
 			},
@@ -233,7 +233,7 @@ func TestUserRepository_GetUserById(t *testing.T) {
 			name:   "User not found by ID",
 			userId: userId,
 			mockSetup: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, created_at, updated_at, username, password, email, role, team_id FROM users")).
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, created_at, updated_at, username, password, email, role, team_id, role_id, deactivated_at FROM users")).
 					WithArgs(userId).
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -286,9 +286,11 @@ func TestUserRepository_GetUsers(t *testing.T) {
 				IsFirstPage: true,
 			},
 			mockSetup: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id"}).
-					AddRow(userId, now, now, "testuser", "hashedpassword", "test@example.com", "Member", teamId)
-				mock.ExpectQuery("SELECT").WillReturnRows(rows)
+				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id", "role_id", "deactivated_at"}).
+					AddRow(userId, now, now, "testuser", "hashedpassword", "test@example.com", "Member", teamId, models.BuiltInRoleIds[models.UserrolesMember], nil)
+				mock.ExpectQuery(regexp.QuoteMeta("JOIN team_memberships m ON m.user_id = u.id WHERE m.team_id = $1")).
+					WithArgs(teamId).
+					WillReturnRows(rows)
 			},
 			expectError:   false,
 			expectedCount: 1,
@@ -301,8 +303,8 @@ func TestUserRepository_GetUsers(t *testing.T) {
 				IsFirstPage: true,
 			},
 			mockSetup: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id"}).
-					AddRow(userId, now, now, "testuser", "hashedpassword", "test@example.com", "Member", teamId)
+				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id", "role_id", "deactivated_at"}).
+					AddRow(userId, now, now, "testuser", "hashedpassword", "test@example.com", "Member", teamId, models.BuiltInRoleIds[models.UserrolesMember], nil)
 				mock.ExpectQuery("SELECT").WillReturnRows(rows)
 			},
 			expectError:   false,
@@ -316,8 +318,8 @@ func TestUserRepository_GetUsers(t *testing.T) {
 				IsFirstPage: true,
 			},
 			mockSetup: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id"}).
-					AddRow(userId, now, now, "admin", "hashedpassword", "admin@example.com", "Admin", nil)
+				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id", "role_id", "deactivated_at"}).
+					AddRow(userId, now, now, "admin", "hashedpassword", "admin@example.com", "Admin", nil, models.BuiltInRoleIds[models.UserrolesAdmin], nil)
 				mock.ExpectQuery("SELECT").WillReturnRows(rows)
 			},
 			expectError:   false,
@@ -331,7 +333,7 @@ func TestUserRepository_GetUsers(t *testing.T) {
 				IsFirstPage: true,
 			},
 			mockSetup: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id"})
+				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id", "role_id", "deactivated_at"})
 				mock.ExpectQuery("SELECT").WillReturnRows(rows)
 			},
 			expectError:   false,
@@ -348,8 +350,8 @@ func TestUserRepository_GetUsers(t *testing.T) {
 				CursorId:        uuid.New(),
 			},
 			mockSetup: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id"}).
-					AddRow(userId, now, now, "testuser", "hashedpassword", "test@example.com", "Member", teamId)
+				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id", "role_id", "deactivated_at"}).
+					AddRow(userId, now, now, "testuser", "hashedpassword", "test@example.com", "Member", teamId, models.BuiltInRoleIds[models.UserrolesMember], nil)
 				mock.ExpectQuery("SELECT").WillReturnRows(rows)
 			},
 			expectError:   false,
@@ -366,8 +368,8 @@ func TestUserRepository_GetUsers(t *testing.T) {
 				CursorId:        uuid.New(),
 			},
 			mockSetup: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id"}).
-					AddRow(userId, now, now, "testuser", "hashedpassword", "test@example.com", "Member", teamId)
+				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id", "role_id", "deactivated_at"}).
+					AddRow(userId, now, now, "testuser", "hashedpassword", "test@example.com", "Member", teamId, models.BuiltInRoleIds[models.UserrolesMember], nil)
 				mock.ExpectQuery("SELECT").WillReturnRows(rows)
 			},
 			expectError:   false,
@@ -432,8 +434,8 @@ func TestUserRepository_UpdateUser(t *testing.T) {
 				UpdatedAt: now,
 			},
 			mockSetup: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id"}).
-					AddRow(userId, now, now, "updateduser", "hashedpassword", "updated@example.com", "Member", teamId)
+				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id", "role_id", "deactivated_at"}).
+					AddRow(userId, now, now, "updateduser", "hashedpassword", "updated@example.com", "Member", teamId, models.BuiltInRoleIds[models.UserrolesMember], nil)
 				mock.ExpectQuery(regexp.QuoteMeta("UPDATE users")).
 					WithArgs("updateduser", "updated@example.com", now, userId).
 					WillReturnRows(rows)
@@ -552,3 +554,604 @@ func TestUserRepository_DeleteUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUserRepository_UpdateUserRole(t *testing.T) {
+	userId := uuid.New()
+	teamId := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name        string
+		mockSetup   func(sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "Update role - revokes the sessions of the user",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id", "role_id", "deactivated_at"}).
+					AddRow(userId, now, now, "testuser", "hashed", "test@example.com", "Member", teamId, models.BuiltInRoleIds[models.UserrolesMember], nil)
+				mock.ExpectQuery(regexp.QuoteMeta("UPDATE users")).
+					WithArgs(database.UserrolesMember, now, userId).
+					WillReturnRows(rows)
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM sessions WHERE user_id")).
+					WithArgs(userId).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			expectError: false,
+		},
+		{
+			name: "Error revoking sessions - rolls back",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id", "role_id", "deactivated_at"}).
+					AddRow(userId, now, now, "testuser", "hashed", "test@example.com", "Member", teamId, models.BuiltInRoleIds[models.UserrolesMember], nil)
+				mock.ExpectQuery(regexp.QuoteMeta("UPDATE users")).
+					WithArgs(database.UserrolesMember, now, userId).
+					WillReturnRows(rows)
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM sessions WHERE user_id")).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewUserRepository(queries, db)
+
+			user, err := repo.UpdateUserRole(context.Background(), interfaces.UpdateUserRoleData{
+				Role:      models.UserrolesMember,
+				UpdatedAt: now,
+				ID:        userId,
+			})
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, models.UserrolesMember, user.Role)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserRepository_AssignUserRole(t *testing.T) {
+	userId := uuid.New()
+	teamId := uuid.New()
+	roleId := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name        string
+		mockSetup   func(sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "Assign role - revokes the sessions of the user",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id", "role_id", "deactivated_at"}).
+					AddRow(userId, now, now, "testuser", "hashed", "test@example.com", "Member", teamId, roleId, nil)
+				mock.ExpectQuery(regexp.QuoteMeta("UPDATE users SET role_id")).
+					WithArgs(roleId, now, userId).
+					WillReturnRows(rows)
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM sessions WHERE user_id")).
+					WithArgs(userId).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			expectError: false,
+		},
+		{
+			name: "Error assigning role - rolls back",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta("UPDATE users SET role_id")).
+					WithArgs(roleId, now, userId).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewUserRepository(queries, db)
+
+			user, err := repo.AssignUserRole(context.Background(), interfaces.AssignUserRoleData{
+				RoleId:    roleId,
+				UpdatedAt: now,
+				ID:        userId,
+			})
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, roleId, user.RoleId)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserRepository_ChangeUserPassword(t *testing.T) {
+	userId := uuid.New()
+	sessionId := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name        string
+		mockSetup   func(sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "Change password - revokes the other sessions",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE users")).
+					WithArgs("new-hash", now, userId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM sessions WHERE user_id = $1 AND id <> $2")).
+					WithArgs(userId, sessionId).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			expectError: false,
+		},
+		{
+			name: "Error updating password - rolls back",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE users")).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewUserRepository(queries, db)
+
+			err = repo.ChangeUserPassword(context.Background(), interfaces.ChangeUserPasswordData{
+				Password:      "new-hash",
+				UpdatedAt:     now,
+				ID:            userId,
+				KeepSessionID: sessionId,
+			})
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserRepository_CreateFirstAdmin(t *testing.T) {
+	userId := uuid.New()
+	now := time.Now().UTC()
+
+	admin := models.User{
+		Username:  "admin",
+		Password:  "hashedpassword",
+		Email:     "admin@example.com",
+		Role:      models.UserrolesAdmin,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	tests := []struct {
+		name          string
+		mockSetup     func(sqlmock.Sqlmock)
+		expectError   bool
+		expectCreated bool
+	}{
+		{
+			name: "Creates the admin when there is none",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock")).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS(SELECT 1 FROM users WHERE role = 'Admin')")).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id", "role_id", "deactivated_at"}).
+					AddRow(userId, now, now, "admin", "hashedpassword", "admin@example.com", "Admin", nil, models.BuiltInRoleIds[models.UserrolesAdmin], nil)
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO users")).
+					WithArgs(now, now, "admin", "hashedpassword", "admin@example.com", database.UserrolesAdmin, sqlmock.AnyArg()).
+					WillReturnRows(rows)
+				mock.ExpectCommit()
+			},
+			expectError:   false,
+			expectCreated: true,
+		},
+		{
+			name: "Admin already exists - nothing is created",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock")).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS(SELECT 1 FROM users WHERE role = 'Admin')")).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectRollback()
+			},
+			expectError:   false,
+			expectCreated: false,
+		},
+		{
+			name: "Error taking the lock - rolls back",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock")).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			expectError:   true,
+			expectCreated: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewUserRepository(queries, db)
+
+			created, user, err := repo.CreateFirstAdmin(context.Background(), admin)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.expectCreated, created)
+
+			if tt.expectCreated {
+				assert.Equal(t, userId, user.ID)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserRepository_GetUserWorkTeamIds(t *testing.T) {
+	userId := uuid.New()
+	teamId := uuid.New()
+	otherTeamId := uuid.New()
+
+	tests := []struct {
+		name          string
+		mockSetup     func(sqlmock.Sqlmock)
+		expectError   bool
+		expectedTeams []uuid.UUID
+	}{
+		{
+			name: "Lists every team the user has work in",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM teams WHERE owner_id = $1")).
+					WithArgs(userId).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(teamId).AddRow(otherTeamId))
+			},
+			expectError:   false,
+			expectedTeams: []uuid.UUID{teamId, otherTeamId},
+		},
+		{
+			name: "Database error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM teams WHERE owner_id = $1")).
+					WithArgs(userId).
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewUserRepository(queries, db)
+
+			teamIds, err := repo.GetUserWorkTeamIds(context.Background(), userId)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedTeams, teamIds)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserRepository_GetUserOffboarding(t *testing.T) {
+	userId := uuid.New()
+	teamId := uuid.New()
+	projectId := uuid.New()
+	taskId := uuid.New()
+	teamIdsArg := "{\"" + teamId.String() + "\"}"
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name          string
+		mockSetup     func(sqlmock.Sqlmock)
+		expectError   bool
+		expectedTeams int
+		expectedTasks int
+	}{
+		{
+			name: "Lists the teams, projects and open tasks of the user",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, created_at, updated_at, name, owner_id, require_mfa, organization_id FROM teams")).
+					WithArgs(userId, teamIdsArg).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name", "owner_id", "require_mfa", "organization_id"}).
+						AddRow(teamId, now, now, "Team", userId, false, uuid.New()))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, created_at, updated_at, name, team_id, manager_id, status FROM projects")).
+					WithArgs(userId, teamIdsArg).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name", "team_id", "manager_id", "status"}).
+						AddRow(projectId, now, now, "Project", teamId, userId, "InProgress"))
+				mock.ExpectQuery(regexp.QuoteMeta("FROM tasks")).
+					WithArgs(uuid.NullUUID{UUID: userId, Valid: true}, teamIdsArg).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "project_id", "user_id", "status", "title", "description", "team_id"}).
+						AddRow(taskId, now, now, projectId, userId, "ToDo", "Task", nil, teamId))
+			},
+			expectError:   false,
+			expectedTeams: 1,
+			expectedTasks: 1,
+		},
+		{
+			name: "Database error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("FROM teams")).
+					WithArgs(userId, teamIdsArg).
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewUserRepository(queries, db)
+
+			report, err := repo.GetUserOffboarding(context.Background(), userId, []uuid.UUID{teamId})
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, report.Teams, tt.expectedTeams)
+				assert.Len(t, report.Tasks, tt.expectedTasks)
+				assert.Equal(t, teamId, report.Tasks[0].TeamID)
+				assert.Equal(t, taskId, report.Tasks[0].ID)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserRepository_DeactivateUser(t *testing.T) {
+	userId := uuid.New()
+	adminId := uuid.New()
+	managerId := uuid.New()
+	memberId := uuid.New()
+	teamId := uuid.New()
+	teamIdsArg := "{\"" + teamId.String() + "\"}"
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name        string
+		data        interfaces.DeactivateUserData
+		mockSetup   func(sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "Hands everything over and deactivates the user",
+			data: interfaces.DeactivateUserData{
+				ID:               userId,
+				TeamIDs:          []uuid.UUID{teamId},
+				TeamOwnerID:      adminId,
+				ProjectManagerID: managerId,
+				TaskAssigneeID:   memberId,
+				DeactivatedAt:    now,
+			},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE teams")).
+					WithArgs(adminId, now, userId, teamIdsArg).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO project_members")).
+					WithArgs(managerId, now, userId, teamIdsArg).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE projects")).
+					WithArgs(managerId, now, userId, teamIdsArg).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO project_members")).
+					WithArgs(memberId, now, uuid.NullUUID{UUID: userId, Valid: true}, teamIdsArg).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE tasks")).
+					WithArgs(uuid.NullUUID{UUID: memberId, Valid: true}, now, uuid.NullUUID{UUID: userId, Valid: true}, teamIdsArg).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE users")).
+					WithArgs(sql.NullTime{Time: now, Valid: true}, now, userId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM sessions")).
+					WithArgs(userId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectError: false,
+		},
+		{
+			name: "Nothing to hand over",
+			data: interfaces.DeactivateUserData{
+				ID:            userId,
+				DeactivatedAt: now,
+			},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE users")).
+					WithArgs(sql.NullTime{Time: now, Valid: true}, now, userId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM sessions")).
+					WithArgs(userId).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			expectError: false,
+		},
+		{
+			name: "Error handing the projects over - rolls back",
+			data: interfaces.DeactivateUserData{
+				ID:               userId,
+				TeamIDs:          []uuid.UUID{teamId},
+				ProjectManagerID: managerId,
+				DeactivatedAt:    now,
+			},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO project_members")).
+					WithArgs(managerId, now, userId, teamIdsArg).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewUserRepository(queries, db)
+
+			err = repo.DeactivateUser(context.Background(), tt.data)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserRepository_ReactivateUser(t *testing.T) {
+	userId := uuid.New()
+	teamId := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name        string
+		mockSetup   func(sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "Successfully reactivate user",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "username", "password", "email", "role", "team_id", "role_id", "deactivated_at"}).
+					AddRow(userId, now, now, "member", "hashedpassword", "member@example.com", "Member", teamId, models.BuiltInRoleIds[models.UserrolesMember], nil)
+				mock.ExpectQuery(regexp.QuoteMeta("UPDATE users")).
+					WithArgs(now, userId).
+					WillReturnRows(rows)
+			},
+			expectError: false,
+		},
+		{
+			name: "User not found",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("UPDATE users")).
+					WithArgs(now, userId).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			queries := database.New(db)
+			repo := repository.NewUserRepository(queries, db)
+
+			user, err := repo.ReactivateUser(context.Background(), interfaces.ReactivateUserData{ID: userId, UpdatedAt: now})
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, userId, user.ID)
+				assert.Nil(t, user.DeactivatedAt)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
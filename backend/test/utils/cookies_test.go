@@ -0,0 +1,62 @@
+package utils_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigureCookies(t *testing.T) {
+	defer utils.ConfigureCookies("", "/", "Lax")
+
+	assert.Error(t, utils.ConfigureCookies("", "", "Sometimes"))
+	assert.NoError(t, utils.ConfigureCookies("example.com", "/api", "None"))
+
+	app := fiber.New()
+	app.Get("/login", func(c *fiber.Ctx) error {
+		utils.GenerateCookie(c, "access-token", "csrf-token")
+		return c.SendStatus(fiber.StatusOK)
+	})
+	app.Get("/logout", func(c *fiber.Ctx) error {
+		utils.ClearCookies(c)
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/login", nil))
+	assert.NoError(t, err)
+
+	cookies := map[string]*http.Cookie{}
+	for _, cookie := range resp.Cookies() {
+		cookies[cookie.Name] = cookie
+	}
+
+	accessCookie := cookies[utils.AccessTokenCookie]
+	csrfCookie := cookies[utils.CSRFTokenCookie]
+
+	if assert.NotNil(t, accessCookie) && assert.NotNil(t, csrfCookie) {
+		assert.Equal(t, "access-token", accessCookie.Value)
+		assert.Equal(t, "example.com", accessCookie.Domain)
+		assert.Equal(t, "/api", accessCookie.Path)
+		assert.Equal(t, http.SameSiteNoneMode, accessCookie.SameSite)
+		assert.True(t, accessCookie.Secure, "SameSite=None cookies must be secure")
+		assert.True(t, accessCookie.HttpOnly)
+
+		assert.Equal(t, "csrf-token", csrfCookie.Value)
+		assert.False(t, csrfCookie.HttpOnly, "the frontend has to read the csrf token")
+	}
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/logout", nil))
+	assert.NoError(t, err)
+
+	for _, cookie := range resp.Cookies() {
+		assert.Empty(t, cookie.Value)
+		assert.Equal(t, "example.com", cookie.Domain)
+		assert.Equal(t, "/api", cookie.Path)
+		assert.True(t, cookie.MaxAge < 0)
+	}
+	assert.Len(t, resp.Cookies(), 2)
+}
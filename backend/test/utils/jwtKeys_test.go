@@ -0,0 +1,139 @@
+package utils_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func writePEM(t *testing.T, dir string, name string, blockType string, der []byte) {
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, name), data, 0600))
+}
+
+func TestLoadJWTKeySet(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	edPublicKey, edPrivateKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	rsaPrivateDER := x509.MarshalPKCS1PrivateKey(rsaKey)
+	edPrivateDER, _ := x509.MarshalPKCS8PrivateKey(edPrivateKey)
+	edPublicDER, _ := x509.MarshalPKIXPublicKey(edPublicKey)
+
+	tests := []struct {
+		name         string
+		files        map[string][2]any
+		signingKeyId string
+		expectError  bool
+		expectedKids []string
+	}{
+		{
+			name: "RSA signing key with a retired Ed25519 public key",
+			files: map[string][2]any{
+				"2026-02.pem": {"RSA PRIVATE KEY", rsaPrivateDER},
+				"2026-01.pem": {"PUBLIC KEY", edPublicDER},
+			},
+			signingKeyId: "2026-02",
+			expectedKids: []string{"2026-01", "2026-02"},
+		},
+		{
+			name: "Ed25519 PKCS#8 signing key",
+			files: map[string][2]any{
+				"ed.pem": {"PRIVATE KEY", edPrivateDER},
+			},
+			signingKeyId: "ed",
+			expectedKids: []string{"ed"},
+		},
+		{
+			name: "Signing key is missing",
+			files: map[string][2]any{
+				"ed.pem": {"PRIVATE KEY", edPrivateDER},
+			},
+			signingKeyId: "other",
+			expectError:  true,
+		},
+		{
+			name: "Signing key has no private part",
+			files: map[string][2]any{
+				"ed.pem": {"PUBLIC KEY", edPublicDER},
+			},
+			signingKeyId: "ed",
+			expectError:  true,
+		},
+		{
+			name: "Unsupported PEM block",
+			files: map[string][2]any{
+				"ed.pem": {"CERTIFICATE", edPublicDER},
+			},
+			signingKeyId: "ed",
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, file := range tt.files {
+				writePEM(t, dir, name, file[0].(string), file[1].([]byte))
+			}
+
+			keySet, err := utils.LoadJWTKeySet(dir, tt.signingKeyId)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			kids := []string{}
+			for _, key := range keySet.JWKS().Keys {
+				kids = append(kids, key.Kid)
+			}
+			assert.Equal(t, tt.expectedKids, kids)
+		})
+	}
+}
+
+func TestJWTKeySet_SignAndVerify(t *testing.T) {
+	_, edPrivateKey, _ := ed25519.GenerateKey(rand.Reader)
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	keySet, err := utils.NewJWTKeySet("rsa",
+		utils.JWTKey{ID: "rsa", Method: jwt.SigningMethodRS256, PrivateKey: rsaKey, PublicKey: &rsaKey.PublicKey},
+		utils.JWTKey{ID: "ed", Method: jwt.SigningMethodEdDSA, PublicKey: edPrivateKey.Public()},
+	)
+	assert.NoError(t, err)
+
+	signed, err := keySet.Sign(jwt.MapClaims{"userId": "123"})
+	assert.NoError(t, err)
+
+	token, err := jwt.Parse(signed, keySet.KeyFunc, jwt.WithValidMethods(utils.JWTValidMethods()))
+	assert.NoError(t, err)
+	assert.Equal(t, "rsa", token.Header["kid"])
+	assert.Equal(t, "RS256", token.Header["alg"])
+
+	// A token claiming the kid of the RSA key but signed with another algorithm is rejected
+	forged := jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.MapClaims{"userId": "123"})
+	forged.Header["kid"] = "rsa"
+	forgedString, err := forged.SignedString(edPrivateKey)
+	assert.NoError(t, err)
+
+	_, err = jwt.Parse(forgedString, keySet.KeyFunc, jwt.WithValidMethods(utils.JWTValidMethods()))
+	assert.Error(t, err)
+
+	// Tokens without kid are rejected
+	noKid, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{"userId": "123"}).SignedString(rsaKey)
+	assert.NoError(t, err)
+
+	_, err = jwt.Parse(noKid, keySet.KeyFunc, jwt.WithValidMethods(utils.JWTValidMethods()))
+	assert.ErrorIs(t, err, utils.ErrUnknownJWTKey)
+}
@@ -0,0 +1,104 @@
+package utils_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TobiasRV/challenge-fs-senior/internals/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// Base32 of the RFC 6238 SHA1 test seed "12345678901234567890"
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode(t *testing.T) {
+	// RFC 6238 appendix B vectors truncated to 6 digits
+	tests := []struct {
+		name     string
+		unix     int64
+		expected string
+	}{
+		{name: "T=59", unix: 59, expected: "287082"},
+		{name: "T=1111111109", unix: 1111111109, expected: "081804"},
+		{name: "T=1234567890", unix: 1234567890, expected: "005924"},
+		{name: "T=2000000000", unix: 2000000000, expected: "279037"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := utils.TOTPCode(rfcSecret, utils.TOTPStep(time.Unix(tt.unix, 0)))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, code)
+		})
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	currentStep := utils.TOTPStep(now)
+
+	previous, _ := utils.TOTPCode(rfcSecret, currentStep-1)
+	tooOld, _ := utils.TOTPCode(rfcSecret, currentStep-2)
+
+	tests := []struct {
+		name         string
+		code         string
+		expectedOk   bool
+		expectedStep int64
+	}{
+		{name: "Current code", code: "005924", expectedOk: true, expectedStep: currentStep},
+		{name: "Previous step is accepted for clock drift", code: previous, expectedOk: true, expectedStep: currentStep - 1},
+		{name: "Older step is rejected", code: tooOld, expectedOk: false},
+		{name: "Wrong length", code: "5924", expectedOk: false},
+		{name: "Wrong code", code: "000000", expectedOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := utils.ValidateTOTP(rfcSecret, tt.code, now)
+
+			assert.Equal(t, tt.expectedOk, ok)
+			if tt.expectedOk {
+				assert.Equal(t, tt.expectedStep, step)
+			}
+		})
+	}
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	secret, err := utils.GenerateTOTPSecret()
+	assert.NoError(t, err)
+
+	uri, err := url.Parse(utils.TOTPProvisioningURI("challenge-fs-senior", "user@example.com", secret))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/challenge-fs-senior:user@example.com", uri.Path)
+	assert.Equal(t, secret, uri.Query().Get("secret"))
+	assert.Equal(t, "challenge-fs-senior", uri.Query().Get("issuer"))
+	assert.Equal(t, "6", uri.Query().Get("digits"))
+	assert.Equal(t, "30", uri.Query().Get("period"))
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, hashes, err := utils.GenerateRecoveryCodes(10)
+
+	assert.NoError(t, err)
+	assert.Len(t, codes, 10)
+	assert.Len(t, hashes, 10)
+
+	seen := map[string]bool{}
+	for i, code := range codes {
+		assert.Len(t, code, 11)
+		assert.False(t, seen[code])
+		seen[code] = true
+
+		assert.Equal(t, hashes[i], utils.HashRecoveryCode(code))
+		// Users may type them without the dash or in upper case
+		assert.Equal(t, hashes[i], utils.HashRecoveryCode(strings.ToUpper(strings.ReplaceAll(code, "-", ""))))
+	}
+}
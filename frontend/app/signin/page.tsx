@@ -18,6 +18,7 @@ type Inputs = {
   username: string;
   password: string;
   email: string;
+  setupToken: string;
 };
 
 type MapErrorMessage = {
@@ -65,6 +66,8 @@ export default function SignInPage() {
     [HttpStatusCode.InternalServerError]:
       "Error al registrar usuario. Por favor intente nuevamente.",
     [HttpStatusCode.BadRequest]: "Datos incorrectos.",
+    [HttpStatusCode.Unauthorized]: "Token de instalación incorrecto.",
+    [HttpStatusCode.Forbidden]: "Ya existe un administrador registrado.",
   };
 
   useEffect(() => {
@@ -164,6 +167,24 @@ export default function SignInPage() {
                 </p>
               ) : null}
             </div>
+            <div>
+              <Label className="text-sm sm:text-base font-medium">Token de instalación</Label>
+              <Input
+                type="password"
+                className="mt-1.5 sm:mt-2 w-full"
+                {...register("setupToken", {
+                  required: {
+                    value: true,
+                    message: "El token de instalación es requerido",
+                  },
+                })}
+              />
+              {errors.setupToken ? (
+                <p className="text-red-500 text-xs sm:text-sm mt-1.5">
+                  {errors.setupToken.message}
+                </p>
+              ) : null}
+            </div>
 
             {error && authStatusCode && (
               <Alert className="w-full" variant="error">
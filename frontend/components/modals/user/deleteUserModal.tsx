@@ -1,14 +1,19 @@
 "use client";
 
 import * as Dialog from "@radix-ui/react-dialog";
+import { Label } from "@radix-ui/react-label";
 import { HttpStatusCode } from "axios";
 import { ReactNode, useState, useEffect } from "react";
+import { Controller, useForm } from "react-hook-form";
 import { useShallow } from "zustand/shallow";
 import { Alert, AlertTitle } from "../../ui/alert";
 import { Button } from "../../ui/button";
 import { UserRolesEnum } from "@/src/utils/enums";
 import { useUserStore } from "@/src/stores/users/users";
-import { IUser } from "@/src/stores/users/users.interface";
+import { IUser, IUserHandover, IUserOffboardingReport } from "@/src/stores/users/users.interface";
+import MemberSelector from "@/components/selectors/memberSelectors";
+import { getLsItem } from "@/src/utils/localStorage";
+import { localStorageKeys } from "@/src/utils/consts";
 
 type DeleteUserModalProps = {
   isOpen: boolean;
@@ -16,8 +21,6 @@ type DeleteUserModalProps = {
   handleClose: (success: boolean) => void;
 };
 
-type MapRoleMessage = Partial<Record<UserRolesEnum, string>>;
-
 type MapErrorMessage = {
   [key: number]: string;
 };
@@ -27,15 +30,23 @@ export default function DeleteUserModal({
   handleClose,
   user,
 }: DeleteUserModalProps): ReactNode {
-
+  const {
+    handleSubmit,
+    formState: { errors },
+    control,
+  } = useForm<IUserHandover>();
 
   const [alertTimeout, setAlertTimeout] = useState<NodeJS.Timeout>();
+  const [report, setReport] = useState<IUserOffboardingReport | null>(null);
+
+  const teamId = getLsItem(localStorageKeys.TEAM_ID);
 
   const {
     userLoading,
     error,
     statusCode,
     clearRequestState,
+    getUserOffboarding,
     deleteUser,
   } = useUserStore(
     useShallow((state) => ({
@@ -43,6 +54,7 @@ export default function DeleteUserModal({
       error: state.error,
       statusCode: state.statusCode,
       clearRequestState: state.clearRequestState,
+      getUserOffboarding: state.getUserOffboarding,
       deleteUser: state.deleteUser,
     }))
   );
@@ -55,8 +67,13 @@ export default function DeleteUserModal({
     }
   }, [error, statusCode]);
 
-  const onSubmit = async () => {
-    const response = await deleteUser(user.id)
+  // The work of the user has to be handed over before deleting them, the dry run tells what there is
+  useEffect(() => {
+    getUserOffboarding(user.id).then(setReport);
+  }, [user.id]);
+
+  const onSubmit = async (data: IUserHandover) => {
+    const response = await deleteUser(user.id, data)
 
     if (response === HttpStatusCode.Ok) {
       close(true);
@@ -67,23 +84,37 @@ export default function DeleteUserModal({
     handleClose(success);
   };
 
-  const mapRoleWarning: MapRoleMessage = {
-    [UserRolesEnum.MANAGER]:
-      "Al eliminar un manager todos los proyectos asociados a este seran eliminados.",
-    [UserRolesEnum.MEMBER]: "Al eliminar un miembro todas sus tareas quedaran sin asignar",
+  const mapErrorMessage: MapErrorMessage = {
+    [HttpStatusCode.InternalServerError]:
+      "Error al eliminar el usuario. Por favor intente nuevamente.",
+    [HttpStatusCode.BadRequest]: "Los usuarios elegidos no pueden recibir el trabajo del usuario.",
+    [HttpStatusCode.Forbidden]: "El usuario tiene trabajo en equipos que no administras.",
+    [HttpStatusCode.NotFound]: "El usuario no existe o ya esta eliminado",
+    [HttpStatusCode.Conflict]: "El usuario no existe o ya esta eliminado",
+    // Generic responses for edge case errors that should not happend but could happend
+    [HttpStatusCode.Unauthorized]: "Error al eliminar el usuario.",
   };
 
-   const mapErrorMessage: MapErrorMessage = {
-      [HttpStatusCode.InternalServerError]:
-        "Error al eliminar el usuario. Por favor intente nuevamente.",
-      [HttpStatusCode.BadRequest]: "El usuario no existe o ya esta eliminado",
-      [HttpStatusCode.NotFound]: "El usuario no existe o ya esta eliminado",
-      // Generic responses for edge case errors that should not happend but could happend
-      [HttpStatusCode.Unauthorized]: "Error al eliminar el usuario.",
-    };
-  
-
-  const roleMessage = mapRoleWarning[user.role];
+  const handovers = [
+    {
+      name: "teamOwnerId" as const,
+      count: report?.teams.length ?? 0,
+      label: "Nuevo dueño de sus equipos",
+      role: UserRolesEnum.ADMIN,
+    },
+    {
+      name: "projectManagerId" as const,
+      count: report?.projects.length ?? 0,
+      label: "Nuevo manager de sus proyectos",
+      role: UserRolesEnum.MANAGER,
+    },
+    {
+      name: "taskAssigneeId" as const,
+      count: report?.tasks.length ?? 0,
+      label: "Nuevo responsable de sus tareas sin terminar",
+      role: UserRolesEnum.MEMBER,
+    },
+  ].filter((handover) => handover.count > 0);
 
   return (
     <div>
@@ -94,13 +125,45 @@ export default function DeleteUserModal({
           <div className="w-full max-w-md rounded-md bg-white p-6 sm:p-8 text-gray-900 shadow max-h-[calc(100vh-16px)] sm:max-h-[calc(100vh-32px)] overflow-auto">
             <h2 className="text-xl">Eliminar usuario</h2>
 
-            {roleMessage && (
+            {report && handovers.length > 0 && (
               <Alert className="mt-5 w-full" variant="warning">
-                <AlertTitle className="font-normal">{roleMessage}</AlertTitle>
+                <AlertTitle className="font-normal">
+                  El usuario sera desactivado y su trabajo pasara a los usuarios que elijas.
+                </AlertTitle>
               </Alert>
             )}
 
-            {error && statusCode && (
+            <form onSubmit={handleSubmit(onSubmit)}>
+              {handovers.map((handover) => (
+                <div className="mt-5" key={handover.name}>
+                  <Label>
+                    {handover.label} ({handover.count})
+                  </Label>
+                  <div className="mt-2">
+                    <Controller
+                      name={handover.name}
+                      control={control}
+                      rules={{ required: { value: true, message: "El usuario es requerido" } }}
+                      render={({ field }) => (
+                        <MemberSelector
+                          teamId={teamId}
+                          role={handover.role}
+                          excludeId={user.id}
+                          value={field.value}
+                          onChange={field.onChange}
+                        />
+                      )}
+                    />
+                  </div>
+                  {errors[handover.name] ? (
+                    <p className="text-red-500 text-xs mt-2">
+                      {errors[handover.name]?.message}
+                    </p>
+                  ) : null}
+                </div>
+              ))}
+
+              {error && statusCode && (
                 <Alert className="mt-5 w-full" variant="error">
                   <AlertTitle className="font-normal">
                     {mapErrorMessage[statusCode]}
@@ -108,17 +171,15 @@ export default function DeleteUserModal({
                 </Alert>
               )}
 
-            <div className="flex end justify-end gap-3 mt-10">
+              <div className="flex end justify-end gap-3 mt-10">
                 <Button type="button" variant="ghost" onClick={() => close(false)}>
                   Cancelar
                 </Button>
-                <Button
-                  disabled={userLoading}
-                  onClick={() => onSubmit()}
-                >
+                <Button type="submit" disabled={userLoading || !report}>
                   Eliminar Usuario
                 </Button>
               </div>
+            </form>
           </div>
         </Dialog.Content>
       </Dialog.Root>
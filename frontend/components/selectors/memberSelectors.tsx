@@ -20,6 +20,8 @@ interface MemberSelectorProps {
   placeholder?: string;
   teamId?: string;
   limit?: number;
+  role?: UserRolesEnum;
+  excludeId?: string;
 }
 
 export default function MemberSelector({
@@ -28,6 +30,8 @@ export default function MemberSelector({
   placeholder = "Seleccionar usuario",
   teamId = "",
   limit = 10,
+  role = UserRolesEnum.MEMBER,
+  excludeId,
 }: MemberSelectorProps) {
   const [localUsers, setLocalUsers] = React.useState<IUser[]>([]);
   const [localNext, setLocalNext] = React.useState<string>("");
@@ -37,14 +41,14 @@ export default function MemberSelector({
     async (cursor = "") => {
       try {
         setLocalLoading(true);
-        const resp = await getUsersService({ teamId, limit, cursor, role: UserRolesEnum.MEMBER });
+        const resp = await getUsersService({ teamId, limit, cursor, role });
         if (resp.error) {
           // keep existing users on error
           setLocalLoading(false);
           return;
         }
 
-        const incoming: IUser[] = resp.data || [];
+        const incoming: IUser[] = (resp.data || []).filter((u: IUser) => u.id !== excludeId);
         setLocalUsers((prev) => (cursor ? [...prev, ...incoming] : incoming));
         setLocalNext(resp.pagination?.next_cursor || "");
         setLocalLoading(false);
@@ -52,7 +56,7 @@ export default function MemberSelector({
         setLocalLoading(false);
       }
     },
-    [teamId, limit]
+    [teamId, limit, role, excludeId]
   );
 
   useEffect(() => {
@@ -26,7 +26,7 @@ export const refreshTokenService = async (payload: { refreshToken: string })  =>
             refreshToken
         });
 
-        return { accessToken: response.data, statusCode: response.status };
+        return { ...response.data, statusCode: response.status };
 
     } catch (error) {
         return handleAxiosErrors(error);
@@ -34,12 +34,13 @@ export const refreshTokenService = async (payload: { refreshToken: string })  =>
 }
 
 export const signInService = async (payload: ISignInForm) => {
-    const { username, password, email} = payload;
+    const { username, password, email, setupToken } = payload;
     try {
         const response = await server.post(registerAdminRoute(), {
             username,
             password,
-            email
+            email,
+            setupToken
         });
         return {...response.data, statusCode: response.status}
     } catch (error) {
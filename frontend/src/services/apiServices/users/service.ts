@@ -1,4 +1,4 @@
-import { IGetUsersParams, IUpdateUsersParams, IUser } from "@/src/stores/users/users.interface";
+import { IGetUsersParams, IUpdateUsersParams, IUser, IUserHandover } from "@/src/stores/users/users.interface";
 import server from "../..";
 import handleAxiosErrors from "../../axios.helper";
 import { createUsersRoute, deleteUsersRoute, getEmailExistsRoute, getUsersRoute, updateUsersRoute } from "./routes";
@@ -48,9 +48,24 @@ export const updateUsersService = async (body: IUpdateUsersParams) => {
     }
 }
 
-export const deleteUserService = async (id: string) => {
+export const getUserOffboardingService = async (id: string) => {
     try {
-        const response = await server.delete(deleteUsersRoute(id));
+        const response = await server.delete(deleteUsersRoute(id), {
+            params: {
+                dryRun: true
+            }
+        });
+        return {...response.data, statusCode: response.status}
+    } catch (error) {
+        return handleAxiosErrors(error);
+    }
+}
+
+export const deleteUserService = async (id: string, handover: IUserHandover) => {
+    try {
+        const response = await server.delete(deleteUsersRoute(id), {
+            data: handover
+        });
         return {...response.data, statusCode: response.status}
     } catch (error) {
         return handleAxiosErrors(error);
@@ -35,6 +35,9 @@ const refreshToken = async () => {
       redirectToLogin();
       return;
     }
+    // The refresh token is rotated on every use, the old one is no longer valid
+    setLsItem(localStorageKeys.TOKEN, response.accessToken);
+    setLsItem(localStorageKeys.REFRESH_TOKEN, response.refreshToken);
     return response.accessToken;
   } else {
     redirectToLogin();
@@ -7,4 +7,5 @@ export interface ISignInForm {
     username: string;
     password: string;
     email: string;
+    setupToken: string;
 }
\ No newline at end of file
@@ -1,4 +1,7 @@
 import { UserRolesEnum } from "@/src/utils/enums";
+import { IProject } from "../projects/projects.interface";
+import { ITeam } from "../teams/teams.interfaces";
+import { ITask } from "../tasks/tasks.interface";
 
 export interface IUser {
     id: string;
@@ -22,4 +25,17 @@ export interface IUpdateUsersParams {
     username: string;
     email: string;
     id: string
+}
+
+export interface IUserOffboardingReport {
+    teams: Array<ITeam>;
+    projects: Array<IProject>;
+    tasks: Array<ITask & { teamId: string }>;
+}
+
+// Users that take over the work of the user being deleted, each one is only needed when there is work of that kind
+export interface IUserHandover {
+    teamOwnerId?: string;
+    projectManagerId?: string;
+    taskAssigneeId?: string;
 }
\ No newline at end of file
@@ -2,12 +2,13 @@ import {
   createUsersService,
   deleteUserService,
   emailExistsService,
+  getUserOffboardingService,
   getUsersService,
   updateUsersService,
 } from "@/src/services/apiServices/users/service";
 import { HttpStatusCode } from "axios";
 import { create } from "zustand";
-import { IGetUsersParams, IUpdateUsersParams, IUser } from "./users.interface";
+import { IGetUsersParams, IUpdateUsersParams, IUser, IUserHandover, IUserOffboardingReport } from "./users.interface";
 
 interface UserStore {
   loading: boolean;
@@ -22,7 +23,8 @@ interface UserStore {
   getUsers: (filters: IGetUsersParams) => Promise<void>;
   createUser: (userData: Partial<IUser>) => Promise<number>;
   updateUser: (userData: IUpdateUsersParams) => Promise<number>;
-  deleteUser: (id: string) => Promise<number>;
+  getUserOffboarding: (id: string) => Promise<IUserOffboardingReport | null>;
+  deleteUser: (id: string, handover: IUserHandover) => Promise<number>;
   clearRequestState: () => void;
   clearState: () => void;
 }
@@ -176,10 +178,39 @@ export const useUserStore = create<UserStore>((set, get) => ({
       return HttpStatusCode.InternalServerError;
     }
   },
-  deleteUser: async (id: string): Promise<number> => {
+  getUserOffboarding: async (id: string): Promise<IUserOffboardingReport | null> => {
     try {
       set({ loading: true });
-      const response = await deleteUserService(id);
+      const response = await getUserOffboardingService(id);
+
+      if (response.error) {
+        set({
+          error: true,
+          loading: false,
+          statusCode: response.statusCode,
+        });
+        return null;
+      }
+
+      set({
+        error: false,
+        loading: false,
+      });
+
+      return response.report;
+    } catch (error) {
+      set({
+        error: false,
+        loading: false,
+        statusCode: HttpStatusCode.InternalServerError,
+      });
+      return null;
+    }
+  },
+  deleteUser: async (id: string, handover: IUserHandover): Promise<number> => {
+    try {
+      set({ loading: true });
+      const response = await deleteUserService(id, handover);
 
       if (response.error) {
         set({